@@ -0,0 +1,383 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/auth"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// minKeepAliveInterval keeps 'auth keepalive' from hammering the session endpoint
+const minKeepAliveInterval = time.Minute
+
+// tokenRenewMargin is how long a token printed by 'auth token --print' stays valid
+// at least; sessions expiring sooner are renewed first
+const tokenRenewMargin = 5 * time.Minute
+
+var AuthCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Log in and manage the login session",
+	Long: `Log in and out and manage the login session.
+
+The top-level 'agbcloud login' and 'agbcloud logout' are shortcuts for
+'agbcloud auth login' and 'agbcloud auth logout'.`,
+	GroupID: "core",
+}
+
+var authRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Renew the login session now",
+	Long: `Renew the login session right away, extending its expiry. Commands renew a session
+that is about to expire on their own; use 'agbcloud auth keepalive' to renew it
+during long pipelines.`,
+	Args: cobra.NoArgs,
+	RunE: runAuthRefresh,
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:     "status",
+	Aliases: []string{"whoami"},
+	Short:   "Show whether you are logged in",
+	Long: `Show the endpoint, profile and expiry of the login session. The command fails when
+you are not logged in or the session has expired, so that scripts can check it.`,
+	Example: `  agbcloud auth status
+  agbcloud auth status -o json`,
+	Args: cobra.NoArgs,
+	RunE: runAuthStatus,
+}
+
+var authTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Show the login token, or print it for scripts",
+	Long: `Show the login token of the session masked, with its expiry.
+
+With --print the raw token is printed to stdout, and nothing else, for scripts that
+call the API directly. Anyone holding the token can act as you until the session
+ends, so --print asks for confirmation first; pass --yes to skip the question in
+scripts. A session that expires within 5 minutes is renewed before the token is
+printed, so that the token stays usable for a while. The token is never written to
+the logs.`,
+	Example: `  agbcloud auth token
+  curl -H "Authorization: Bearer $(agbcloud auth token --print --yes)" ...`,
+	Args: cobra.NoArgs,
+	RunE: runAuthToken,
+}
+
+var authKeepAliveCmd = &cobra.Command{
+	Use:   "keepalive",
+	Short: "Keep the login session from expiring",
+	Long: `Renew the login session right away and then at a fixed interval, so that long
+automated pipelines never run into an expired session. Run it in the background
+for the duration of the pipeline; it stops on Ctrl+C or SIGTERM.
+
+Only one keep-alive runs per configuration directory: a lockfile next to the
+configuration makes a second one exit. Network errors are retried at the next
+interval; if the server rejects the renewal, the command exits with an error and
+you need to log in again.`,
+	Example: `  agbcloud auth keepalive --interval 10m &
+  KEEPALIVE_PID=$!
+  agbcloud image create ...
+  kill $KEEPALIVE_PID`,
+	Args: cobra.NoArgs,
+	RunE: runAuthKeepAlive,
+}
+
+func init() {
+	authKeepAliveCmd.Flags().Duration("interval", 10*time.Minute, "Time between session renewals")
+	authTokenCmd.Flags().Bool("print", false, "Print the raw login token to stdout")
+	authTokenCmd.Flags().BoolP("yes", "y", false, "Print the token without asking for confirmation")
+	AuthCmd.AddCommand(newLoginCmd())
+	AuthCmd.AddCommand(newLogoutCmd())
+	AuthCmd.AddCommand(authRefreshCmd)
+	AuthCmd.AddCommand(authStatusCmd)
+	AuthCmd.AddCommand(authTokenCmd)
+	AuthCmd.AddCommand(authKeepAliveCmd)
+
+	registerOutputSchema(authStatusCmd, outputSchema{
+		Command:     "auth status",
+		Version:     1,
+		Description: "The login session of the active profile. sessionId is masked; source is login or credential-provider.",
+		Result:      AuthStatus{},
+	})
+}
+
+// AuthStatus is the login session as shown by 'auth status'
+type AuthStatus struct {
+	LoggedIn bool   `json:"loggedIn"`
+	Endpoint string `json:"endpoint"`
+	Profile  string `json:"profile,omitempty"`
+	// Source tells where the tokens come from: login or credential-provider
+	Source    string `json:"source,omitempty"`
+	SessionID string `json:"sessionId,omitempty"`
+	ExpiresAt string `json:"expiresAt,omitempty"`
+	Expired   bool   `json:"expired"`
+	// Renewable is set when the session can be renewed with 'auth refresh'
+	Renewable bool `json:"renewable"`
+}
+
+// NewAuthStatus describes the session of cfg at now. The session ID is masked.
+func NewAuthStatus(cfg *config.Config, now time.Time) AuthStatus {
+	status := AuthStatus{Endpoint: cfg.EffectiveEndpoint()}
+	if status.Endpoint == "" {
+		status.Endpoint = config.GetEndpoint()
+	}
+	status.Profile, _ = cfg.CurrentProfile()
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return status
+	}
+
+	status.LoggedIn = true
+	status.Source = "login"
+	if cfg.TokenFromProvider() {
+		status.Source = "credential-provider"
+	}
+	status.SessionID = MaskSecret(cfg.Token.SessionId)
+	if !cfg.Token.ExpiresAt.IsZero() {
+		status.ExpiresAt = cfg.Token.ExpiresAt.UTC().Format(time.RFC3339)
+		status.Expired = now.After(cfg.Token.ExpiresAt)
+	}
+	status.Renewable = cfg.Token.KeepAliveToken != "" && !cfg.TokenFromProvider()
+	return status
+}
+
+func runAuthStatus(cmd *cobra.Command, args []string) error {
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if err := applyTimeFormat(cmd); err != nil {
+		return err
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	status := NewAuthStatus(cfg, time.Now())
+
+	if outputFormat.IsStructured() {
+		if err := writeResult(outputFormat, status); err != nil {
+			return err
+		}
+	} else {
+		printAuthStatus(status)
+	}
+	switch {
+	case !status.LoggedIn:
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	case status.Expired:
+		return fmt.Errorf("the session has expired. Please run 'agbcloud login' again")
+	}
+	return nil
+}
+
+// printAuthStatus prints the session as a list of fields
+func printAuthStatus(status AuthStatus) {
+	if !status.LoggedIn {
+		fmt.Println("[INFO]  Not logged in")
+		fmt.Printf("  Endpoint:    %s\n", status.Endpoint)
+		fmt.Println("[TIP] Run 'agbcloud login' to authenticate")
+		return
+	}
+
+	if status.Expired {
+		fmt.Println("[WARN]  The session has expired")
+	} else {
+		fmt.Println("[OK] Logged in")
+	}
+	fmt.Printf("  Endpoint:    %s\n", status.Endpoint)
+	fmt.Printf("  Profile:     %s\n", valueOrDash(status.Profile))
+	fmt.Printf("  Source:      %s\n", status.Source)
+	fmt.Printf("  Session ID:  %s\n", status.SessionID)
+	fmt.Printf("  Expires:     %s\n", valueOrDash(formatTimestamp(status.ExpiresAt)))
+	if status.Expired && status.Renewable {
+		fmt.Println("[TIP] Run 'agbcloud auth refresh' to renew the session, or 'agbcloud login' to log in again")
+	} else if status.Expired {
+		fmt.Println("[TIP] Run 'agbcloud login' to log in again")
+	}
+}
+
+func runAuthRefresh(cmd *cobra.Command, args []string) error {
+	if err := applyTimeFormat(cmd); err != nil {
+		return err
+	}
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.TokenFromProvider() {
+		fmt.Printf("[INFO]  The tokens are supplied by the credential provider '%s', which renews them itself\n", cfg.CredentialProvider)
+		return nil
+	}
+	if cfg.Token == nil || cfg.Token.KeepAliveToken == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+	defer cancel()
+
+	fmt.Println("[REFRESH] Renewing the login session...")
+	token, err := auth.RenewSession(ctx)
+	if errors.Is(err, auth.ErrSessionRejected) {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] The session can no longer be renewed: %v", err),
+			"",
+			"[TIP] Run 'agbcloud login' to authenticate again",
+		)
+	}
+	if err != nil {
+		return networkError(err)
+	}
+	fmt.Printf("[OK] Session renewed, expires %s\n", formatTime(token.ExpiresAt))
+	return nil
+}
+
+func runAuthToken(cmd *cobra.Command, args []string) error {
+	printToken, _ := cmd.Flags().GetBool("print")
+	yes, _ := cmd.Flags().GetBool("yes")
+	if err := applyTimeFormat(cmd); err != nil {
+		return err
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	if !printToken {
+		fmt.Printf("[INFO]  Login token: %s\n", MaskSecret(cfg.Token.LoginToken))
+		if !cfg.Token.ExpiresAt.IsZero() {
+			fmt.Printf("  Expires:     %s\n", formatTime(cfg.Token.ExpiresAt))
+		}
+		fmt.Println("[TIP] Run 'agbcloud auth token --print' to print the token itself for a script")
+		return nil
+	}
+
+	// Only the token goes to stdout; the question and progress go to stderr
+	if !yes {
+		if !isInteractiveInput() {
+			return printErrorMessage(
+				"[ERROR] Printing the login token needs confirmation",
+				"",
+				"[TIP] Pass --yes to print it from a script: agbcloud auth token --print --yes",
+			)
+		}
+		if !Confirm(promptInput, os.Stderr, "Print the login token? Anyone who sees it can act as you until the session ends", false) {
+			fmt.Fprintln(os.Stderr, "[STOP] Token not printed")
+			return nil
+		}
+	}
+
+	token, err := renewTokenIfExpiring(commandContext(cmd), cfg, time.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(resultOutput(), token.LoginToken)
+	return nil
+}
+
+// renewTokenIfExpiring returns the session's tokens, renewing the session first when
+// it expires within tokenRenewMargin. Tokens of a credential provider are returned
+// as they are; the provider renews them itself.
+func renewTokenIfExpiring(ctx context.Context, cfg *config.Config, now time.Time) (*config.Token, error) {
+	token := cfg.Token
+	if cfg.TokenFromProvider() || token.ExpiresAt.IsZero() || token.ExpiresAt.Sub(now) > tokenRenewMargin {
+		return token, nil
+	}
+	if token.KeepAliveToken == "" {
+		if now.After(token.ExpiresAt) {
+			return nil, fmt.Errorf("the session has expired. Please run 'agbcloud login' again")
+		}
+		return token, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	fmt.Fprintln(os.Stderr, "[REFRESH] The session is about to expire, renewing it first...")
+	renewed, err := auth.RenewSession(ctx)
+	if errors.Is(err, auth.ErrSessionRejected) {
+		return nil, printErrorMessage(
+			fmt.Sprintf("[ERROR] The session can no longer be renewed: %v", err),
+			"",
+			"[TIP] Run 'agbcloud login' to authenticate again",
+		)
+	}
+	if err != nil {
+		return nil, networkError(err)
+	}
+	fmt.Fprintf(os.Stderr, "[OK] Session renewed, expires %s\n", formatTime(renewed.ExpiresAt))
+	return renewed, nil
+}
+
+func runAuthKeepAlive(cmd *cobra.Command, args []string) error {
+	interval, _ := cmd.Flags().GetDuration("interval")
+	if interval < minKeepAliveInterval {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid --interval value: %s", interval),
+			"",
+			fmt.Sprintf("[TIP] Use an interval of at least %s, e.g. --interval 10m", minKeepAliveInterval),
+		)
+	}
+
+	if err := applyTimeFormat(cmd); err != nil {
+		return err
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.KeepAliveToken == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	release, err := auth.AcquireKeepAliveLock(interval)
+	var lockedErr *auth.KeepAliveLockedError
+	if errors.As(err, &lockedErr) {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] A session keep-alive is already running (PID %d, started %s)", lockedErr.Record.PID, formatTime(lockedErr.Record.StartedAt)),
+			"",
+			"[TIP] The running keep-alive renews the session for every command using this configuration",
+		)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create keep-alive lock: %w", err)
+	}
+	defer release()
+
+	// Ctrl+C and SIGTERM cancel the command context and stop renewing
+	ctx := commandContext(cmd)
+
+	fmt.Printf("[REFRESH] Keeping the session alive, renewing every %s (PID %d)\n", interval, os.Getpid())
+	keepAlive := auth.KeepAlive{
+		Interval: interval,
+		OnRenew: func(event auth.KeepAliveEvent) {
+			if event.Err != nil {
+				fmt.Printf("[WARN] Session renewal failed, retrying in %s: %v\n", interval, event.Err)
+				return
+			}
+			fmt.Printf("[OK] Session renewed, expires %s\n", formatTime(event.ExpiresAt))
+		},
+	}
+	if err := keepAlive.Run(ctx); err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Session can no longer be renewed: %v", err),
+			"",
+			"[TIP] Run 'agbcloud login' to authenticate again",
+		)
+	}
+	fmt.Println("[STOP] Session keep-alive stopped")
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var authSessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "List and revoke login sessions",
+	Long: `List the login sessions of your account on all machines, and revoke the ones
+you no longer use or that may be compromised. Use 'agbcloud logout' to end the
+session of this machine.`,
+}
+
+var authSessionsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List active login sessions",
+	Args:    cobra.NoArgs,
+	RunE:    runAuthSessionsList,
+}
+
+var authSessionsRevokeCmd = &cobra.Command{
+	Use:   "revoke <session-id>",
+	Short: "Revoke a login session on another machine",
+	Long: `Revoke a login session, given by the session ID shown by 'agbcloud auth sessions list'.
+The machine holding the session has to log in again; its saved tokens are no longer
+accepted.`,
+	Example: `  agbcloud auth sessions list
+  agbcloud auth sessions revoke sess-3f9a21c7`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return printErrorMessage(
+				"[ERROR] Expected exactly one session ID",
+				"",
+				"[TIP] Usage: agbcloud auth sessions revoke <session-id>",
+				"[TIP] Run 'agbcloud auth sessions list' to see your sessions",
+			)
+		}
+		return nil
+	},
+	RunE: runAuthSessionsRevoke,
+}
+
+// sessionsTimeout bounds the requests of the auth sessions commands
+const sessionsTimeout = 30 * time.Second
+
+func init() {
+	authSessionsCmd.AddCommand(authSessionsListCmd)
+	authSessionsCmd.AddCommand(authSessionsRevokeCmd)
+	AuthCmd.AddCommand(authSessionsCmd)
+
+	registerOutputSchema(authSessionsListCmd, outputSchema{
+		Command:     "auth sessions list",
+		Version:     1,
+		Description: "The active login sessions of the account. current marks the session of this machine.",
+		Result:      []SessionListItem{},
+	})
+}
+
+// SessionListItem is the structured (json/csv/pson) representation of a session in `auth sessions list`
+type SessionListItem struct {
+	SessionID    string `json:"sessionId"`
+	Device       string `json:"device"`
+	CreateTime   string `json:"createTime"`
+	LastUsedTime string `json:"lastUsedTime"`
+	Current      bool   `json:"current"`
+}
+
+// NewSessionListItems converts API session information into structured list output
+func NewSessionListItems(sessions []client.SessionInfo) []SessionListItem {
+	items := make([]SessionListItem, 0, len(sessions))
+	for _, session := range sessions {
+		items = append(items, SessionListItem{
+			SessionID:    session.SessionID,
+			Device:       session.Device,
+			CreateTime:   session.CreateTime,
+			LastUsedTime: session.LastUsedTime,
+			Current:      session.Current,
+		})
+	}
+	return items
+}
+
+// sessionsUnsupported reports whether a failed request means that the server has no
+// session endpoints
+func sessionsUnsupported(httpResp *http.Response, err error) bool {
+	var apiErr *client.GenericOpenAPIError
+	return errors.As(err, &apiErr) && httpResp != nil &&
+		(httpResp.StatusCode == http.StatusNotFound || httpResp.StatusCode == http.StatusNotImplemented)
+}
+
+// sessionsUnsupportedError explains that sessions can only be ended locally
+func sessionsUnsupportedError() error {
+	return printErrorMessage(
+		"[ERROR] This server does not support listing or revoking login sessions",
+		"",
+		"[TIP] Run 'agbcloud logout' on the machine to end its session",
+	)
+}
+
+// listSessions fetches the active sessions, reporting failures like the other commands
+func listSessions(ctx context.Context, apiClient *client.APIClient, cfg *config.Config) ([]client.SessionInfo, error) {
+	listResp, httpResp, err := apiClient.SessionAPI.ListSessions(ctx, cfg.Token.LoginToken, cfg.Token.SessionId)
+	if err != nil {
+		if sessionsUnsupported(httpResp, err) {
+			return nil, sessionsUnsupportedError()
+		}
+		return nil, requestError(os.Stderr, "failed to list sessions", httpResp, err)
+	}
+	return listResp.Data.Sessions, nil
+}
+
+func runAuthSessionsList(cmd *cobra.Command, args []string) error {
+	defer startPager()()
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if err := applyTimeFormat(cmd); err != nil {
+		return err
+	}
+	progress := progressWriter(outputFormat)
+
+	apiClient, cfg, err := loggedInClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(commandContext(cmd), sessionsTimeout)
+	defer cancel()
+
+	fmt.Fprintln(progress, "[SEARCH] Fetching login sessions...")
+	sessions, err := listSessions(ctx, apiClient, cfg)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat.IsStructured() {
+		return writeResult(outputFormat, NewSessionListItems(sessions))
+	}
+	printSessions(os.Stdout, sessions)
+	return nil
+}
+
+// printSessions prints the active sessions as a table, marking the session of this machine
+func printSessions(w io.Writer, sessions []client.SessionInfo) {
+	if len(sessions) == 0 {
+		fmt.Fprintln(w, "[EMPTY] No active login sessions.")
+		return
+	}
+
+	fmt.Fprintf(w, "[OK] Found %d active session(s)\n\n", len(sessions))
+	rows := make([][]string, 0, len(sessions))
+	for _, session := range sessions {
+		marker := ""
+		if session.Current {
+			marker = "*"
+		}
+		rows = append(rows, []string{
+			marker,
+			session.SessionID,
+			valueOrDash(session.Device),
+			formatTimestamp(session.CreateTime),
+			formatTimestamp(session.LastUsedTime),
+		})
+	}
+	printTable(w, []TableColumn{
+		{Width: 1},
+		{Header: "SESSION ID", Width: 36, Truncate: true},
+		{Header: "DEVICE", Width: 40, Truncate: true},
+		{Header: "CREATED", Width: 20},
+		{Header: "LAST USED"},
+	}, rows)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "[NOTE] * marks the session of this machine")
+}
+
+func runAuthSessionsRevoke(cmd *cobra.Command, args []string) error {
+	target := strings.TrimSpace(args[0])
+
+	apiClient, cfg, err := loggedInClient()
+	if err != nil {
+		return err
+	}
+	if target == cfg.Token.SessionId {
+		return printErrorMessage(
+			"[ERROR] This is the session of this machine",
+			"",
+			"[TIP] Run 'agbcloud logout' to end it and remove the saved tokens",
+		)
+	}
+	ctx, cancel := context.WithTimeout(commandContext(cmd), sessionsTimeout)
+	defer cancel()
+
+	fmt.Printf("[DELETE] Revoking session %s...\n", target)
+	_, httpResp, err := apiClient.SessionAPI.RevokeSession(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, target)
+	if err != nil {
+		if sessionsUnsupported(httpResp, err) {
+			return sessionsUnsupportedError()
+		}
+		return requestError(os.Stdout, "failed to revoke session", httpResp, err)
+	}
+
+	fmt.Printf("[OK] Session %s revoked\n", target)
+	fmt.Println("[NOTE] The machine holding the session has to run 'agbcloud login' again")
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/poll"
+)
+
+// ExitCodePartialFailure is the exit code of a batch command that failed for some of
+// its images but not for all of them. Other failures exit with 1.
+const ExitCodePartialFailure = 2
+
+// Codes of batch failures that carry no error code from the server
+const (
+	batchCodeNetwork     = "NetworkError"
+	batchCodeNotFound    = "ImageNotFound"
+	batchCodeExists      = "ImageAlreadyExists"
+	batchCodePolicy      = "PolicyViolation"
+	batchCodeFailed      = "OperationFailed"
+	batchCodeTimeout     = "Timeout"
+	batchCodeInterrupted = "Interrupted"
+	batchCodeUnknown     = "Failed"
+)
+
+// BatchItemError describes why one image of a batch command failed
+type BatchItemError struct {
+	Item      string `json:"item"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// BatchError is returned by a batch command when some of its images failed. It tells
+// a partial failure from a batch in which every image failed, see ExitCode.
+type BatchError struct {
+	Operation string // What was done to the images, e.g. create or activate
+	Total     int    // Number of images in the batch
+	Errors    []BatchItemError
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("failed to %s %d of %d image(s)", e.Operation, len(e.Errors), e.Total)
+}
+
+// AllFailed reports whether no image of the batch succeeded
+func (e *BatchError) AllFailed() bool {
+	return len(e.Errors) >= e.Total
+}
+
+// newBatchError returns the error of a batch for its failed images, or nil when none
+// failed
+func newBatchError(operation string, total int, failures []BatchItemError) *BatchError {
+	if len(failures) == 0 {
+		return nil
+	}
+	return &BatchError{Operation: operation, Total: total, Errors: failures}
+}
+
+// ExitCode returns the exit code of a command that failed with err:
+// ExitCodePartialFailure when only some images of a batch failed, 1 otherwise
+func ExitCode(err error) int {
+	var batchErr *BatchError
+	if errors.As(err, &batchErr) && !batchErr.AllFailed() {
+		return ExitCodePartialFailure
+	}
+	return 1
+}
+
+// batchStepError is a failed step of one image of a batch, described in one line.
+// The error it wraps keeps the error code and request ID of the server response;
+// code classifies failures the server gave no code for.
+type batchStepError struct {
+	message string
+	code    string
+	err     error
+}
+
+func (e *batchStepError) Error() string { return e.message }
+func (e *batchStepError) Unwrap() error { return e.err }
+
+// withBatchCode classifies err with code unless the server gave a code for it
+func withBatchCode(code string, err error) error {
+	return &batchStepError{message: err.Error(), code: code, err: err}
+}
+
+// batchFailureCode returns the error code and request ID of a failed image of a batch:
+// those of the server response when there is one, otherwise a code describing the
+// failure
+func batchFailureCode(err error) (code, requestID string) {
+	// A timeout wraps the last failed status check, which is not why the image failed
+	if errors.Is(err, poll.ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return batchCodeTimeout, ""
+	}
+	if code, requestID = client.ErrorCode(err); code != "" {
+		return code, requestID
+	}
+	var stepErr *batchStepError
+	switch {
+	case errors.As(err, &stepErr) && stepErr.code != "":
+		return stepErr.code, requestID
+	case errors.Is(err, errImageTaskFailed):
+		return batchCodeFailed, requestID
+	case errors.Is(err, context.Canceled):
+		return batchCodeInterrupted, requestID
+	}
+	return batchCodeUnknown, requestID
+}
+
+// printBatchErrors prints the failed images of a batch as a table
+func printBatchErrors(w io.Writer, batchErr *BatchError) {
+	fmt.Fprintf(w, "[ERROR] Failed to %s %d of %d image(s):\n\n", batchErr.Operation, len(batchErr.Errors), batchErr.Total)
+	rows := make([][]string, 0, len(batchErr.Errors))
+	for _, failure := range batchErr.Errors {
+		rows = append(rows, []string{failure.Item, failure.Code, valueOrDash(failure.RequestID), failure.Message})
+	}
+	printTable(w, []TableColumn{
+		{Header: "IMAGE", Width: 25, Truncate: true},
+		{Header: "CODE", Width: 24, Truncate: true},
+		{Header: "REQUEST ID", Width: 24, Truncate: true},
+		{Header: "MESSAGE"},
+	}, rows)
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/clipboard"
+)
+
+// addCopyFlag adds --copy to a command that outputs one important value, described by what
+func addCopyFlag(c *cobra.Command, what string) {
+	c.Flags().Bool("copy", false, fmt.Sprintf("Copy the %s to the clipboard", what))
+}
+
+// copyToClipboard places value on the clipboard when --copy is given. Without a
+// clipboard, e.g. on a headless system, a note is printed and the command goes on;
+// a failing clipboard never fails the command.
+func copyToClipboard(cmd *cobra.Command, w io.Writer, what, value string) {
+	if copyValue, _ := cmd.Flags().GetBool("copy"); !copyValue || value == "" {
+		return
+	}
+	err := clipboard.Copy(value)
+	switch {
+	case err == nil:
+		fmt.Fprintf(w, "[COPY] Copied the %s to the clipboard\n", what)
+	case errors.Is(err, clipboard.ErrUnavailable):
+		fmt.Fprintf(w, "[NOTE] No clipboard is available, so the %s was not copied\n", what)
+	default:
+		fmt.Fprintf(w, "[WARN]  Could not copy the %s to the clipboard: %v\n", what, err)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// warnClockSkew records a warning when the local clock differs from the server clock
+// by more than presigned upload URLs tolerate
+func warnClockSkew(apiClient *client.APIClient, warnings *WarningRecorder) {
+	skew, ok := apiClient.ClockSkew()
+	if !ok || !client.ClockSkewExceeds(skew, client.DefaultClockSkewThreshold) {
+		return
+	}
+	warnings.Warn("Local clock is %s the server; the upload may fail with SignatureDoesNotMatch", client.DescribeClockSkew(skew))
+}
+
+// printClockSkewTip explains a rejected upload signature, pointing at the local clock
+// when it is known to be off
+func printClockSkewTip(apiClient *client.APIClient) {
+	if skew, ok := apiClient.ClockSkew(); ok && client.ClockSkewExceeds(skew, client.DefaultClockSkewThreshold) {
+		fmt.Printf("[TIP] Your clock is %s the server. Synchronize the system clock (e.g. enable NTP) and try again\n", client.DescribeClockSkew(skew))
+		return
+	}
+	fmt.Println("[TIP] A rejected upload signature is often caused by an incorrect system clock; run 'agbcloud doctor' to check it")
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+const (
+	// defaultBatchConcurrency is the number of images a batch command works on at the same time
+	defaultBatchConcurrency = 3
+	// maxBatchConcurrency bounds --concurrency to keep the load on the backend reasonable
+	maxBatchConcurrency = 10
+)
+
+// addConcurrencyFlag adds the --concurrency flag of a batch command; what says what
+// the number counts, e.g. "Images built at the same time"
+func addConcurrencyFlag(cmd *cobra.Command, what string) {
+	cmd.Flags().Int("concurrency", defaultBatchConcurrency, fmt.Sprintf("%s (1-%d, default from config)", what, maxBatchConcurrency))
+}
+
+// ValidateConcurrency checks a --concurrency value or the concurrency setting
+func ValidateConcurrency(n int) error {
+	if n < 1 || n > maxBatchConcurrency {
+		return fmt.Errorf("concurrency must be from 1 to %d, got %d", maxBatchConcurrency, n)
+	}
+	return nil
+}
+
+// batchConcurrency returns the number of images a batch command works on at the same
+// time: --concurrency, or the deprecated --parallel of 'image create-batch', falling
+// back to the concurrency setting of the configuration. Accounts with low rate limits
+// lower it to avoid being throttled.
+func batchConcurrency(cmd *cobra.Command) (int, error) {
+	flag := "concurrency"
+	if !cmd.Flags().Changed(flag) && cmd.Flags().Lookup("parallel") != nil && cmd.Flags().Changed("parallel") {
+		flag = "parallel"
+	}
+	n, err := cmd.Flags().GetInt(flag)
+	if err != nil {
+		n = defaultBatchConcurrency
+	}
+	if !cmd.Flags().Changed(flag) {
+		if cfg, err := config.GetConfig(); err == nil && cfg.Concurrency != 0 {
+			n = cfg.Concurrency
+		}
+	}
+
+	if err := ValidateConcurrency(n); err != nil {
+		source := fmt.Sprintf("--%s", flag)
+		if !cmd.Flags().Changed(flag) {
+			source = "concurrency setting"
+		}
+		return 0, printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid %s value: %d", source, n),
+			"",
+			fmt.Sprintf("[TIP] Work on between 1 and %d images at the same time", maxBatchConcurrency),
+		)
+	}
+	return n, nil
+}
@@ -0,0 +1,356 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+	"github.com/agbcloud/agbcloud-cli/internal/tracing"
+)
+
+var ConfigCmd = &cobra.Command{
+	Use:     "config",
+	Short:   "Manage CLI configuration",
+	Long:    "Export, import and inspect CLI configuration such as endpoint, profiles and output preferences",
+	GroupID: "management",
+}
+
+var configExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export shareable configuration (without tokens)",
+	Long: `Write the shareable part of the configuration (endpoint, output preferences,
+profiles and saved policies) to stdout or a file. Authentication tokens are never exported.`,
+	Example: `  agbcloud config export > team-config.yaml
+  agbcloud config export --format json --file team-config.json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigExport,
+}
+
+var configImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import shareable configuration",
+	Long: `Apply settings from a file created with 'agbcloud config export'. Use '-' to read stdin.
+
+By default settings are merged: only the values present in the file are changed.
+With --overwrite all shareable settings are replaced by the file's contents.
+Authentication tokens are never imported and are always preserved.`,
+	Example: `  agbcloud config import team-config.yaml
+  agbcloud config import team-config.yaml --overwrite`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return printErrorMessage(
+				"[ERROR] Missing required argument: <file>",
+				"",
+				"[TIP] Usage: agbcloud config import <file> [--overwrite]",
+				"[NOTE] Example: agbcloud config import team-config.yaml",
+			)
+		}
+		return nil
+	},
+	RunE: runConfigImport,
+}
+
+func init() {
+	configExportCmd.Flags().String("format", "yaml", "Export format: yaml or json")
+	configExportCmd.Flags().String("file", "", "Write to this file instead of stdout")
+
+	configImportCmd.Flags().Bool("overwrite", false, "Replace all shareable settings instead of merging")
+
+	ConfigCmd.AddCommand(configExportCmd)
+	ConfigCmd.AddCommand(configImportCmd)
+
+	registerOutputSchema(configExportCmd, outputSchema{
+		Command:     "config export",
+		Version:     1,
+		Description: "Shareable configuration written with --format json; the YAML format has the same structure. Never contains credentials.",
+		Result:      config.SharedConfig{},
+	})
+}
+
+func runConfigExport(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	file, _ := cmd.Flags().GetString("file")
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	data, err := config.MarshalShared(cfg.Export(), format)
+	if err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] %v", err),
+			"",
+			"[TIP] Usage: agbcloud config export [--format yaml|json] [--file <path>]",
+		)
+	}
+
+	if file == "" {
+		_, err = resultOutput().Write(data)
+		return err
+	}
+
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	fmt.Fprintf(os.Stderr, "[OK] Configuration exported to %s (tokens excluded)\n", file)
+	return nil
+}
+
+func runConfigImport(cmd *cobra.Command, args []string) error {
+	source := args[0]
+	overwrite, _ := cmd.Flags().GetBool("overwrite")
+
+	var data []byte
+	var err error
+	if source == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(source)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", source, err)
+	}
+
+	shared, err := config.ParseShared(data)
+	if errors.Is(err, config.ErrSecretsInSharedConfig) {
+		return printErrorMessage(
+			"[ERROR] The file contains authentication tokens and was not imported",
+			"",
+			"[TIP] Tokens are personal; remove the token section and share the file again",
+			"[NOTE] Files created with 'agbcloud config export' never contain tokens",
+		)
+	}
+	if err != nil {
+		return printErrorMessage(fmt.Sprintf("[ERROR] %v", err))
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	mode := config.ImportMerge
+	if overwrite {
+		mode = config.ImportOverwrite
+	}
+
+	// Validate the result before saving so a bad file never leaves a broken configuration
+	merged := *cfg
+	merged.Profiles = cfg.Export().Profiles
+	merged.TLSPins = cfg.Export().TLSPins
+	merged.Import(shared, mode)
+	if err := ValidateSharedConfig(merged.Export()); err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid configuration: %v", err),
+			"",
+			"[TIP] Fix the file and run the import again; nothing was changed",
+		)
+	}
+
+	if err := merged.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	fmt.Printf("[OK] Configuration imported from %s (%s)\n", source, mode)
+	printSharedSummary(os.Stdout, merged.Export())
+	return nil
+}
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateSharedConfig checks shareable settings for values the CLI cannot use
+func ValidateSharedConfig(shared config.SharedConfig) error {
+	problems := sharedConfigProblems(shared)
+	if len(problems) == 0 {
+		return nil
+	}
+	messages := make([]string, len(problems))
+	for i, problem := range problems {
+		messages[i] = problem.String()
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
+
+// sharedConfigProblems returns the values in shared settings the CLI cannot use,
+// keyed by the path of the offending setting
+func sharedConfigProblems(shared config.SharedConfig) []config.Issue {
+	var problems []config.Issue
+	add := func(path, message string) {
+		problems = append(problems, config.Issue{Severity: config.SeverityError, Path: path, Message: message})
+	}
+
+	checkEndpoint := func(field, endpoint string) {
+		if endpoint == "" {
+			return
+		}
+		if err := config.ValidateEndpoint(endpoint); err != nil {
+			add(field, err.Error())
+		}
+	}
+	checkOutput := func(field, format string) {
+		if format == "" {
+			return
+		}
+		if _, err := output.ParseFormat(format); err != nil {
+			add(field, err.Error())
+		}
+	}
+
+	checkFallbacks := func(field string, endpoints []string) {
+		for i, endpoint := range endpoints {
+			if strings.TrimSpace(endpoint) == "" {
+				add(fmt.Sprintf("%s[%d]", field, i), "endpoint must not be empty")
+				continue
+			}
+			checkEndpoint(fmt.Sprintf("%s[%d]", field, i), endpoint)
+		}
+	}
+	checkDefaults := func(field string, defaults *config.ListDefaults) {
+		if defaults == nil {
+			return
+		}
+		if defaults.ImageType != "" {
+			if _, err := ParseListImageType(defaults.ImageType); err != nil {
+				add(field+".imageType", err.Error())
+			}
+		}
+		if defaults.PageSize < 0 || defaults.PageSize > maxListPageSize {
+			add(field+".pageSize", fmt.Sprintf("must be from 1 to %d", maxListPageSize))
+		}
+	}
+	checkStrategy := func(field, strategy string) {
+		if _, err := client.ParseServerStrategy(strategy); err != nil {
+			add(field, err.Error())
+		}
+	}
+
+	checkEndpoint("endpoint", shared.Endpoint)
+	checkFallbacks("fallbackEndpoints", shared.FallbackEndpoints)
+	checkStrategy("endpointStrategy", shared.EndpointStrategy)
+	checkOutput("output", shared.Output)
+	checkDefaults("defaults", shared.Defaults)
+	if _, err := ParseTimeFormat(shared.TimeFormat); err != nil {
+		add("timeFormat", err.Error())
+	}
+	if _, err := ParseTimeZone(shared.TimeZone); err != nil {
+		add("timeZone", err.Error())
+	}
+	if shared.Concurrency != 0 {
+		if err := ValidateConcurrency(shared.Concurrency); err != nil {
+			add("concurrency", err.Error())
+		}
+	}
+	if _, err := ParseSystemImageTTL(shared.SystemImageTTL); err != nil {
+		add("systemImageTTL", err.Error())
+	}
+	if shared.OTLPEndpoint != "" {
+		if _, err := tracing.TracesURL(shared.OTLPEndpoint); err != nil {
+			add("otlpEndpoint", err.Error())
+		}
+	}
+	if shared.UploadStorage != nil {
+		if _, err := ParseStorageBackend(shared.UploadStorage.Backend); err != nil {
+			add("uploadStorage.backend", err.Error())
+		}
+		checkEndpoint("uploadStorage.endpoint", shared.UploadStorage.Endpoint)
+	}
+
+	names := make([]string, 0, len(shared.Profiles))
+	for name := range shared.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !profileNamePattern.MatchString(name) {
+			add("profiles."+name, fmt.Sprintf("invalid profile name '%s'", name))
+		}
+		checkEndpoint("profiles."+name+".endpoint", shared.Profiles[name].Endpoint)
+		checkFallbacks("profiles."+name+".fallbackEndpoints", shared.Profiles[name].FallbackEndpoints)
+		checkStrategy("profiles."+name+".endpointStrategy", shared.Profiles[name].EndpointStrategy)
+		checkOutput("profiles."+name+".output", shared.Profiles[name].Output)
+		checkDefaults("profiles."+name+".defaults", shared.Profiles[name].Defaults)
+	}
+
+	if shared.ActiveProfile != "" {
+		if _, ok := shared.Profiles[shared.ActiveProfile]; !ok {
+			add("activeProfile", fmt.Sprintf("profile '%s' is not defined", shared.ActiveProfile))
+		}
+	}
+
+	hosts := make([]string, 0, len(shared.TLSPins))
+	for host := range shared.TLSPins {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		if config.TLSPinHost(host) == "" {
+			add("tlsPins."+host, fmt.Sprintf("invalid host '%s'", host))
+		}
+		if len(shared.TLSPins[host]) == 0 {
+			add("tlsPins."+host, "no pins given; remove the host to stop pinning it")
+		}
+		for i, pin := range shared.TLSPins[host] {
+			if err := config.ValidateTLSPin(pin); err != nil {
+				add(fmt.Sprintf("tlsPins.%s[%d]", host, i), err.Error())
+			}
+		}
+	}
+
+	if shared.ImageGC != nil {
+		if shared.ImageGC.KeepLast < 0 {
+			add("imageGC.keepLast", "must not be negative")
+		}
+		if _, err := ParseAge(shared.ImageGC.OlderThan); err != nil {
+			add("imageGC.olderThan", err.Error())
+		}
+	}
+
+	return problems
+}
+
+// printSharedSummary prints the effective shareable settings after an import
+func printSharedSummary(w io.Writer, shared config.SharedConfig) {
+	value := func(s string) string {
+		if s == "" {
+			return "(default)"
+		}
+		return s
+	}
+	fmt.Fprintf(w, "[INFO]  Endpoint: %s\n", value(shared.Endpoint))
+	if len(shared.FallbackEndpoints) > 0 {
+		fmt.Fprintf(w, "[INFO]  Fallback endpoints: %s (strategy: %s)\n", strings.Join(shared.FallbackEndpoints, ", "), value(shared.EndpointStrategy))
+	}
+	fmt.Fprintf(w, "[INFO]  Output: %s\n", value(shared.Output))
+	if shared.CleanupOnFailure != nil {
+		fmt.Fprintf(w, "[INFO]  Cleanup on failure: %v\n", *shared.CleanupOnFailure)
+	}
+	if len(shared.Profiles) > 0 {
+		names := make([]string, 0, len(shared.Profiles))
+		for name := range shared.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(w, "[INFO]  Profiles: %s (active: %s)\n", strings.Join(names, ", "), value(shared.ActiveProfile))
+	}
+	if len(shared.TLSPins) > 0 {
+		hosts := make([]string, 0, len(shared.TLSPins))
+		for host := range shared.TLSPins {
+			hosts = append(hosts, host)
+		}
+		sort.Strings(hosts)
+		fmt.Fprintf(w, "[INFO]  TLS pinned hosts: %s\n", strings.Join(hosts, ", "))
+	}
+}
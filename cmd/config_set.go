@@ -0,0 +1,323 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// maxListPageSize is the largest page size accepted as a default for 'image list'
+const maxListPageSize = 100
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value> | --json <patch>",
+	Short: "Change a configuration setting",
+	Long: `Change a setting in the configuration file. An empty value removes the setting.
+
+The setting is stored in the active profile (AGB_CLI_PROFILE or activeProfile), or in
+the profile given with --profile. Without a profile it applies to all profiles that
+do not set it themselves.
+
+Keys:
+  defaults.imageType   Image type listed when 'image list' has no --type: User or System
+  defaults.pageSize    Page size of 'image list' when --size is not given: 1-100
+
+With --json, several settings are changed at once with a JSON merge patch (RFC 7386):
+objects are merged into the file, null removes a setting and other values replace it.
+The patch applies to the whole file, or to the profile given with --profile, and may
+change any key of config.json except the tokens. It is read from stdin when given as
+'-'. The file is only written if the patched configuration is valid; unknown keys are
+rejected as well.`,
+	Example: `  agbcloud config set defaults.imageType System
+  agbcloud config set defaults.pageSize 50 --profile sre
+  agbcloud config set defaults.pageSize ""
+  agbcloud config set --json '{"output": "json", "defaults": {"pageSize": 50}}'
+  agbcloud config set --json '{"endpoint": null}' --profile sre`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if patch, _ := cmd.Flags().GetString("json"); patch != "" {
+			if len(args) > 0 {
+				return printErrorMessage(
+					"[ERROR] Give either <key> <value> or --json, not both",
+					"",
+					"[TIP] Usage: agbcloud config set --json '{\"defaults\": {\"pageSize\": 50}}'",
+				)
+			}
+			return nil
+		}
+		if len(args) != 2 {
+			return printErrorMessage(
+				"[ERROR] Expected a key and a value",
+				"",
+				"[TIP] Usage: agbcloud config set <key> <value>",
+				fmt.Sprintf("[NOTE] Keys: %s", strings.Join(ConfigSettingKeys(), ", ")),
+			)
+		}
+		return nil
+	},
+	RunE: runConfigSet,
+}
+
+// listDefaultSettings are the keys 'config set' can change, with the function that
+// stores a value; an empty value removes the setting
+var listDefaultSettings = map[string]func(defaults *config.ListDefaults, value string) error{
+	"defaults.imageType": func(defaults *config.ListDefaults, value string) error {
+		if value == "" {
+			defaults.ImageType = ""
+			return nil
+		}
+		imageType, err := ParseListImageType(value)
+		if err != nil {
+			return err
+		}
+		defaults.ImageType = imageType
+		return nil
+	},
+	"defaults.pageSize": func(defaults *config.ListDefaults, value string) error {
+		if value == "" {
+			defaults.PageSize = 0
+			return nil
+		}
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 1 || size > maxListPageSize {
+			return fmt.Errorf("invalid page size '%s', expected a number from 1 to %d", value, maxListPageSize)
+		}
+		defaults.PageSize = size
+		return nil
+	},
+}
+
+func init() {
+	configSetCmd.Flags().String("profile", "", "Store the setting in this profile instead of the active one")
+	configSetCmd.Flags().String("json", "", "Apply a JSON merge patch to the configuration file ('-' reads it from stdin)")
+	ConfigCmd.AddCommand(configSetCmd)
+}
+
+// ConfigSettingKeys returns the keys 'config set' accepts, sorted
+func ConfigSettingKeys() []string {
+	keys := make([]string, 0, len(listDefaultSettings))
+	for key := range listDefaultSettings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ParseListImageType normalizes an image type for 'image list', ignoring case
+func ParseListImageType(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "user":
+		return "User", nil
+	case "system":
+		return "System", nil
+	}
+	return "", fmt.Errorf("invalid image type '%s', expected User or System", value)
+}
+
+// SetConfigValue changes the setting key of profile, or the top-level setting when
+// profile is empty. Profiles that do not exist yet are created.
+func SetConfigValue(cfg *config.Config, profile, key, value string) error {
+	set, ok := listDefaultSettings[key]
+	if !ok {
+		return fmt.Errorf("unknown key '%s', expected one of: %s", key, strings.Join(ConfigSettingKeys(), ", "))
+	}
+
+	target := cfg.Defaults
+	if profile != "" {
+		target = cfg.Profiles[profile].Defaults
+	}
+	defaults := config.ListDefaults{}
+	if target != nil {
+		defaults = *target
+	}
+	if err := set(&defaults, strings.TrimSpace(value)); err != nil {
+		return err
+	}
+
+	// Empty defaults are removed so the file does not collect empty objects
+	var updated *config.ListDefaults
+	if defaults != (config.ListDefaults{}) {
+		updated = &defaults
+	}
+	if profile == "" {
+		cfg.Defaults = updated
+		return nil
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]config.Profile)
+	}
+	p := cfg.Profiles[profile]
+	p.Defaults = updated
+	cfg.Profiles[profile] = p
+	return nil
+}
+
+// PatchConfigDocument applies a JSON merge patch to the config.json document data, or
+// to profiles.<profile> of it when profile is given. It returns the patched document,
+// or the problems the patch would add to the file. Tokens cannot be patched.
+func PatchConfigDocument(data, patch []byte, profile string) ([]byte, []config.Issue, error) {
+	var changes map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, nil, fmt.Errorf("the patch is not a JSON object: %w", err)
+	}
+	if profile != "" {
+		patch, _ = json.Marshal(map[string]map[string]map[string]json.RawMessage{"profiles": {profile: changes}})
+	} else {
+		for key := range changes {
+			if strings.EqualFold(key, "token") {
+				return nil, nil, fmt.Errorf("tokens are managed by 'agbcloud login' and cannot be patched")
+			}
+		}
+	}
+
+	patched, err := config.MergePatch(data, patch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Only reject problems the patch adds, so that a patch can fix a broken setting
+	existing := make(map[string]bool)
+	for _, issue := range ValidateConfigFile(data) {
+		existing[issue.Path+issue.Message] = true
+	}
+	var added []config.Issue
+	for _, issue := range ValidateConfigFile(patched) {
+		if !existing[issue.Path+issue.Message] {
+			// Positions refer to the patched document, which was not written
+			issue.Position = config.Position{}
+			added = append(added, issue)
+		}
+	}
+	if len(added) > 0 {
+		return nil, added, nil
+	}
+	return patched, nil, nil
+}
+
+// runConfigSetJSON applies the merge patch given with --json to the configuration file
+func runConfigSetJSON(cmd *cobra.Command, patchValue, profile string) error {
+	patch := []byte(patchValue)
+	if patchValue == "-" {
+		var err error
+		if patch, err = io.ReadAll(cmd.InOrStdin()); err != nil {
+			return fmt.Errorf("failed to read the patch from stdin: %w", err)
+		}
+	}
+	if profile != "" && !profileNamePattern.MatchString(profile) {
+		return printErrorMessage(fmt.Sprintf("[ERROR] Invalid profile name '%s'", profile))
+	}
+
+	file, err := config.ConfigFile()
+	if err != nil {
+		return fmt.Errorf("failed to resolve configuration file: %w", err)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	patched, issues, err := PatchConfigDocument(data, patch, profile)
+	if err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid --json patch: %v", err),
+			"",
+			"[TIP] Example: agbcloud config set --json '{\"defaults\": {\"pageSize\": 50}}'",
+		)
+	}
+	if len(issues) > 0 {
+		printConfigIssues(os.Stdout, file, issues)
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] The patch was not applied: it would add %d problem(s) to %s", len(issues), file),
+			"",
+			"[TIP] Run 'agbcloud config set --help' for the format of the patch",
+		)
+	}
+
+	// Saving through Config writes the file atomically, in the usual key order
+	var cfg config.Config
+	if err := json.Unmarshal(patched, &cfg); err != nil {
+		return fmt.Errorf("failed to apply the patch: %w", err)
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	set, removed, _ := config.PatchPaths(patch)
+	for _, path := range set {
+		fmt.Printf("[OK] Set %s\n", path)
+	}
+	for _, path := range removed {
+		fmt.Printf("[OK] Removed %s\n", path)
+	}
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	profile, _ := cmd.Flags().GetString("profile")
+	if patch, _ := cmd.Flags().GetString("json"); patch != "" {
+		return runConfigSetJSON(cmd, patch, profile)
+	}
+	key, value := args[0], args[1]
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if profile == "" {
+		profile, _ = cfg.CurrentProfile()
+	} else if !profileNamePattern.MatchString(profile) {
+		return printErrorMessage(fmt.Sprintf("[ERROR] Invalid profile name '%s'", profile))
+	}
+
+	if err := SetConfigValue(cfg, profile, key, value); err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] %v", err),
+			"",
+			"[TIP] Run 'agbcloud config set --help' to see the keys and their values",
+		)
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	scope := "all profiles"
+	if profile != "" {
+		scope = fmt.Sprintf("profile '%s'", profile)
+	}
+	if strings.TrimSpace(value) == "" {
+		fmt.Printf("[OK] Removed %s for %s\n", key, scope)
+	} else {
+		fmt.Printf("[OK] Set %s to %s for %s\n", key, strings.TrimSpace(value), scope)
+	}
+	return nil
+}
+
+// applyListDefaults returns the image type and page size for 'image list', replacing
+// the values of flags that were not given with the configured defaults
+func applyListDefaults(cmd *cobra.Command, imageType string, pageSize int) (string, int) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return imageType, pageSize // A broken configuration is reported by the command itself
+	}
+	defaults := cfg.EffectiveListDefaults()
+	if defaults.ImageType != "" && !cmd.Flags().Changed("type") {
+		log.Debugf("[DEBUG] Using configured default image type %s (defaults.imageType)", defaults.ImageType)
+		imageType = defaults.ImageType
+	}
+	if defaults.PageSize > 0 && !cmd.Flags().Changed("size") {
+		log.Debugf("[DEBUG] Using configured default page size %d (defaults.pageSize)", defaults.PageSize)
+		pageSize = defaults.PageSize
+	}
+	return imageType, pageSize
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var configTLSPinCmd = &cobra.Command{
+	Use:   "tls-pin [endpoint]",
+	Short: "Show or save the TLS public key pins of an endpoint",
+	Long: `Connect to the endpoint (the configured one by default) and print the public key
+pin of every certificate it presents, the server's own first. Pins have the form
+sha256/<base64>, the same as curl's --pinnedpubkey.
+
+Hosts listed under tlsPins in the configuration must present one of their pins, or
+every request fails. With AGB_CLI_SKIP_SSL_VERIFY=true only the server's own key is
+accepted, which allows pinning a self-signed certificate instead of skipping checks.
+
+--save pins the keys that are presented right now. Compare them with pins obtained
+from your administrator first: saving trusts whoever answers the connection.`,
+	Example: `  agbcloud config tls-pin
+  agbcloud config tls-pin api.example.com:8443
+  agbcloud config tls-pin --save
+  agbcloud config set --json '{"tlsPins": {"agb.cloud": ["sha256/..."]}}'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigTLSPin,
+}
+
+func init() {
+	configTLSPinCmd.Flags().Bool("save", false, "Pin the presented keys in the configuration")
+	ConfigCmd.AddCommand(configTLSPinCmd)
+}
+
+func runConfigTLSPin(cmd *cobra.Command, args []string) error {
+	save, _ := cmd.Flags().GetBool("save")
+
+	endpoint := config.GetEndpoint()
+	if len(args) == 1 {
+		endpoint = args[0]
+		if err := config.ValidateEndpoint(endpoint); err != nil {
+			return printErrorMessage(fmt.Sprintf("[ERROR] %v", err))
+		}
+	}
+	host := config.TLSPinHost(endpoint)
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+	defer cancel()
+	pins, err := client.FetchCertificatePins(ctx, endpoint)
+	if err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Could not read the certificates of %s: %v", endpoint, err),
+			"",
+			"[TIP] Set AGB_CLI_SKIP_SSL_VERIFY=true to read the pins of a self-signed certificate",
+		)
+	}
+
+	pinned := cfg.PinnedKeys(host)
+	fmt.Printf("[DATA] Public key pins presented by %s\n", endpoint)
+	for i, pin := range pins {
+		marker := ""
+		if slices.Contains(pinned, pin.Pin) {
+			marker = " (pinned)"
+		}
+		role := "issuer"
+		if i == 0 {
+			role = "server"
+		}
+		fmt.Printf("  %s  %s: %s%s\n", pin.Pin, role, pin.Subject, marker)
+	}
+
+	if !save {
+		if len(pinned) == 0 {
+			fmt.Println("[NOTE] This host is not pinned")
+			fmt.Printf("[TIP] After checking the pins with your administrator, pin them with: agbcloud config tls-pin %s --save\n", endpoint)
+		}
+		return nil
+	}
+
+	values := make([]string, 0, len(pins))
+	for _, pin := range pins {
+		values = append(values, pin.Pin)
+	}
+	if cfg.TLSPins == nil {
+		cfg.TLSPins = make(map[string][]string)
+	}
+	for key := range cfg.TLSPins {
+		if config.TLSPinHost(key) == host {
+			delete(cfg.TLSPins, key)
+		}
+	}
+	cfg.TLSPins[host] = values
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	fmt.Printf("[OK] Pinned %d public key(s) for %s\n", len(values), host)
+	fmt.Println("[NOTE] Remove the issuer pins from tlsPins to accept only the server's own key")
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/auth"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "Check the configuration file for problems",
+	Long: `Check config.json (or the given file) for malformed JSON, values of the wrong type,
+unknown keys and settings the CLI cannot use, such as invalid endpoints. Every
+problem is reported with its line and column.
+
+The command exits with an error if any problem would prevent the configuration
+from working; unknown keys are only reported as warnings.`,
+	Example: `  agbcloud config validate
+  agbcloud config validate ./config.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigValidate,
+}
+
+func init() {
+	ConfigCmd.AddCommand(configValidateCmd)
+}
+
+// ValidateConfigFile returns every problem in a config.json document: structural
+// issues from the config package followed by settings the CLI cannot use
+func ValidateConfigFile(data []byte) []config.Issue {
+	issues := config.ValidateConfigData(data)
+
+	// Settings can only be checked in a structurally valid file
+	var cfg config.Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return issues
+	}
+
+	problems := sharedConfigProblems(cfg.Export())
+	if _, err := auth.ParsePortRanges(cfg.LoginPortRange); err != nil {
+		problems = append(problems, config.Issue{Severity: config.SeverityError, Path: "loginPortRange", Message: err.Error()})
+	}
+
+	// Endpoints are checked by both passes; report each problem once
+	seen := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		seen[issue.Path+issue.Message] = true
+	}
+	positions := config.LocateKeys(data)
+	for _, problem := range problems {
+		if seen[problem.Path+problem.Message] {
+			continue
+		}
+		problem.Position = positions[problem.Path]
+		issues = append(issues, problem)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i].Position, issues[j].Position
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return issues
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	file := ""
+	if len(args) == 1 {
+		file = args[0]
+	} else {
+		var err error
+		if file, err = config.ConfigFile(); err != nil {
+			return fmt.Errorf("failed to resolve configuration file: %w", err)
+		}
+	}
+
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) && len(args) == 0 {
+		fmt.Printf("[OK] No configuration file at %s; defaults are used\n", file)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	issues := ValidateConfigFile(data)
+	errorCount := printConfigIssues(os.Stdout, file, issues)
+
+	switch {
+	case errorCount > 0:
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] %s has %d error(s)", file, errorCount),
+			"",
+			"[TIP] Fix the reported lines, or export the working settings with 'agbcloud config export' and import them into a fresh file",
+		)
+	case len(issues) > 0:
+		fmt.Printf("[OK] %s is valid, with %d warning(s)\n", file, len(issues))
+	default:
+		fmt.Printf("[OK] %s is valid\n", file)
+	}
+	return nil
+}
+
+// printConfigIssues prints one line per issue and returns the number of errors
+func printConfigIssues(w io.Writer, file string, issues []config.Issue) int {
+	errorCount := 0
+	for _, issue := range issues {
+		tag := "[WARN]"
+		if issue.Severity == config.SeverityError {
+			tag = "[ERROR]"
+			errorCount++
+		}
+		fmt.Fprintf(w, "%s %s:%s\n", tag, file, issue)
+	}
+	return errorCount
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// ExitCodeInterrupted is the exit code of a command stopped with Ctrl+C, as with shells
+const ExitCodeInterrupted = 130
+
+// InterruptContext returns a context that is cancelled on the first Ctrl+C or SIGTERM.
+// The signal handler is removed once it fires, so a second Ctrl+C terminates the
+// process even if a command does not react to the cancellation.
+func InterruptContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+// Interrupted reports whether a command failed with err because ctx was cancelled
+func Interrupted(ctx context.Context, err error) bool {
+	return err != nil && errors.Is(ctx.Err(), context.Canceled)
+}
+
+// commandContext returns the context of the running command, which the root command
+// cancels on Ctrl+C. Commands run directly, e.g. by the init wizard or tests, may have
+// none.
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/mockserver"
+)
+
+// defaultMockServerAddr is where 'dev serve' listens by default
+const defaultMockServerAddr = "127.0.0.1:8089"
+
+var DevCmd = &cobra.Command{
+	Use:    "dev",
+	Short:  "Development and QA helpers",
+	Long:   "Run the bundled mock backend and fill it with test data, to exercise the CLI without an account",
+	Hidden: true,
+}
+
+var devServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the bundled mock backend",
+	Long: `Run an in-memory mock of the image API. Point the CLI at it with AGB_CLI_ENDPOINT
+and fill it with images using 'agbcloud dev seed'. The state is lost when the
+server stops.`,
+	Example: `  agbcloud dev serve
+  agbcloud dev serve --addr 127.0.0.1:9000 --images 200`,
+	Args: cobra.NoArgs,
+	RunE: runDevServe,
+}
+
+var devSeedCmd = &cobra.Command{
+	Use:   "seed",
+	Short: "Fill the mock backend with generated images",
+	Long: `Replace the user images of the mock backend at the configured endpoint with
+generated ones, to exercise pagination, filtering and batch operations with
+realistic volumes. Images get the given statuses round-robin; "mixed" uses
+every status.
+
+If the CLI is not logged in, demo credentials accepted by the mock backend are
+saved, so image commands work right away. Only local endpoints are seeded.`,
+	Example: `  AGB_CLI_ENDPOINT=http://127.0.0.1:8089 agbcloud dev seed --images 50 --statuses mixed
+  agbcloud dev seed --images 20 --statuses RESOURCE_PUBLISHED,IMAGE_AVAILABLE`,
+	Args: cobra.NoArgs,
+	RunE: runDevSeed,
+}
+
+func init() {
+	devServeCmd.Flags().String("addr", defaultMockServerAddr, "Address to listen on")
+	devServeCmd.Flags().Int("images", 0, "Number of user images to generate at startup")
+	devServeCmd.Flags().String("statuses", "mixed", "Statuses of the generated images: mixed or a comma-separated list")
+
+	devSeedCmd.Flags().Int("images", 50, "Number of user images to generate")
+	devSeedCmd.Flags().String("statuses", "mixed", "Statuses of the generated images: mixed or a comma-separated list")
+	devSeedCmd.Flags().Int64("seed", 1, "Random seed; the same seed generates the same images")
+
+	DevCmd.AddCommand(devServeCmd)
+	DevCmd.AddCommand(devSeedCmd)
+}
+
+// seedRequestFromFlags builds a seed request from the --images, --statuses and --seed flags
+func seedRequestFromFlags(cmd *cobra.Command) (mockserver.SeedRequest, error) {
+	images, _ := cmd.Flags().GetInt("images")
+	statusesValue, _ := cmd.Flags().GetString("statuses")
+	seed, _ := cmd.Flags().GetInt64("seed")
+
+	if images < 0 {
+		return mockserver.SeedRequest{}, printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid --images value: %d", images),
+			"",
+			"[TIP] Use a number of images of 0 or more, e.g. --images 50",
+		)
+	}
+	statuses, err := mockserver.ParseStatuses(statusesValue)
+	if err != nil {
+		return mockserver.SeedRequest{}, printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid --statuses value: %v", err),
+			"",
+			"[TIP] Usage: --statuses mixed or --statuses IMAGE_AVAILABLE,RESOURCE_PUBLISHED",
+		)
+	}
+	return mockserver.SeedRequest{Images: images, Statuses: statuses, Seed: seed}, nil
+}
+
+// IsLocalEndpoint reports whether endpoint points at this machine
+func IsLocalEndpoint(endpoint string) bool {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+	host := u.Hostname()
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func runDevServe(cmd *cobra.Command, args []string) error {
+	addr, _ := cmd.Flags().GetString("addr")
+	seedRequest, err := seedRequestFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	server := mockserver.New()
+	if seedRequest.Images > 0 {
+		if _, err := server.Seed(seedRequest); err != nil {
+			return fmt.Errorf("failed to seed mock backend: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	endpoint := "http://" + listener.Addr().String()
+
+	fmt.Printf("[OK] Mock backend listening on %s\n", endpoint)
+	if seedRequest.Images > 0 {
+		fmt.Printf("[DATA] Seeded %d user images\n", seedRequest.Images)
+	}
+	fmt.Println("[TIP] In another terminal, use it with an isolated configuration:")
+	fmt.Printf("  export AGB_CLI_ENDPOINT=%s\n", endpoint)
+	fmt.Println("  export AGB_CLI_CONFIG_DIR=$(mktemp -d)")
+	fmt.Println("  agbcloud dev seed --images 50 --statuses mixed")
+	fmt.Println("[NOTE] Press Ctrl+C to stop")
+
+	ctx := commandContext(cmd)
+
+	httpServer := &http.Server{Handler: server, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		// Shutdown starts after Ctrl+C, so it cannot use the cancelled context
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx) // Open connections are dropped on exit anyway
+	}()
+
+	if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("mock backend failed: %w", err)
+	}
+	fmt.Println("[STOP] Mock backend stopped")
+	return nil
+}
+
+func runDevSeed(cmd *cobra.Command, args []string) error {
+	seedRequest, err := seedRequestFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	endpoint := config.GetEndpoint()
+	if !IsLocalEndpoint(endpoint) {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Refusing to seed %s: only a local mock backend can be seeded", endpoint),
+			"",
+			"[TIP] Start the mock backend with 'agbcloud dev serve' and point the CLI at it:",
+			fmt.Sprintf("  AGB_CLI_ENDPOINT=http://%s agbcloud dev seed", defaultMockServerAddr),
+		)
+	}
+
+	fmt.Printf("[DATA] Seeding %s with %d images...\n", endpoint, seedRequest.Images)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+	defer cancel()
+	resp, err := mockserver.RequestSeed(ctx, endpoint, seedRequest)
+	if errors.Is(err, mockserver.ErrNotMockServer) {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] %s is not the bundled mock backend", endpoint),
+			"",
+			"[TIP] Start it with 'agbcloud dev serve'",
+		)
+	}
+	if err != nil {
+		return networkError(err)
+	}
+
+	fmt.Printf("[OK] Seeded %d user images\n", resp.Images)
+	statuses := make([]string, 0, len(resp.ByStatus))
+	for status := range resp.ByStatus {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		fmt.Printf("  %-15s %d\n", FormatImageStatus(status), resp.ByStatus[status])
+	}
+
+	if !cfg.IsAuthenticated() {
+		if err := cfg.SaveTokens(resp.LoginToken, resp.SessionID, "", ""); err != nil {
+			return fmt.Errorf("failed to save demo credentials: %w", err)
+		}
+		fmt.Println("[OK] Saved demo credentials for the mock backend")
+	}
+	fmt.Println("[TIP] Try: agbcloud image list --all, agbcloud image list --search web")
+	return nil
+}
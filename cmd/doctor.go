@@ -0,0 +1,329 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/auth"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var DoctorCmd = &cobra.Command{
+	Use:     "doctor",
+	Short:   "Diagnose common problems",
+	Long:    "Check the local CLI environment (configuration, authentication, API endpoints, clock, OAuth callback ports) and report problems with suggested fixes",
+	Args:    cobra.NoArgs,
+	GroupID: "core",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDoctor(cmd)
+	},
+}
+
+// Doctor finding severities
+const (
+	doctorOK    = "OK"
+	doctorWarn  = "WARN"
+	doctorError = "ERROR"
+)
+
+// doctorFinding is a single result reported by a doctor check
+type doctorFinding struct {
+	Level   string
+	Message string
+	Tips    []string
+}
+
+// doctorCheck is a named diagnostic run by `agbcloud doctor`
+type doctorCheck struct {
+	Name string
+	Run  func(ctx context.Context, fix bool) []doctorFinding
+}
+
+// doctorChecks lists the diagnostics in the order they are run
+var doctorChecks = []doctorCheck{
+	{Name: "Configuration", Run: checkDoctorConfiguration},
+	{Name: "File permissions", Run: checkDoctorPermissions},
+	{Name: "API endpoints", Run: checkDoctorEndpoints},
+	{Name: "Clock synchronization", Run: checkDoctorClock},
+	{Name: "OAuth callback listeners", Run: checkDoctorCallbackListeners},
+}
+
+func init() {
+	DoctorCmd.Flags().Bool("fix", false, "Automatically fix problems that are safe to fix (e.g. remove stale listener records, restrict config file permissions)")
+}
+
+func runDoctor(cmd *cobra.Command) error {
+	fix, _ := cmd.Flags().GetBool("fix")
+	if err := applyTimeFormat(cmd); err != nil {
+		return err
+	}
+
+	fmt.Println("[SEARCH] Running AgbCloud CLI diagnostics...")
+	fmt.Printf("[INFO]  Platform: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+	defer cancel()
+
+	warnings, errors := 0, 0
+	for _, check := range doctorChecks {
+		fmt.Printf("\n[DOC] %s\n", check.Name)
+		for _, finding := range check.Run(ctx, fix) {
+			switch finding.Level {
+			case doctorWarn:
+				warnings++
+				fmt.Printf("  [WARN]  %s\n", finding.Message)
+			case doctorError:
+				errors++
+				fmt.Printf("  [ERROR] %s\n", finding.Message)
+			default:
+				fmt.Printf("  [OK] %s\n", finding.Message)
+			}
+			for _, tip := range finding.Tips {
+				fmt.Printf("    [TIP] %s\n", tip)
+			}
+		}
+	}
+
+	fmt.Println()
+	switch {
+	case errors > 0:
+		fmt.Printf("[ERROR] Found %d error(s) and %d warning(s)\n", errors, warnings)
+		return fmt.Errorf("doctor found %d error(s)", errors)
+	case warnings > 0:
+		fmt.Printf("[WARN]  Found %d warning(s)\n", warnings)
+	default:
+		fmt.Println("[SUCCESS] No problems found!")
+	}
+	return nil
+}
+
+// checkDoctorConfiguration verifies the config file can be loaded and reports authentication state
+func checkDoctorConfiguration(ctx context.Context, fix bool) []doctorFinding {
+	configFile, err := config.ConfigFile()
+	if err != nil {
+		return []doctorFinding{{Level: doctorError, Message: fmt.Sprintf("Could not resolve config directory: %v", err)}}
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return []doctorFinding{{
+			Level:   doctorError,
+			Message: fmt.Sprintf("Failed to load %s: %v", configFile, err),
+			Tips:    []string{"Run 'agbcloud config validate' to see every problem with its line number", "Fix or remove the config file, then run 'agbcloud login' again"},
+		}}
+	}
+
+	findings := []doctorFinding{{Level: doctorOK, Message: fmt.Sprintf("Config file: %s", configFile)}}
+
+	switch {
+	case cfg.CredentialProvider != nil && !cfg.TokenFromProvider():
+		findings = append(findings, doctorFinding{
+			Level:   doctorError,
+			Message: fmt.Sprintf("Credential provider '%s' did not supply tokens", cfg.CredentialProvider),
+			Tips:    []string{"Run the command yourself; it must print {\"loginToken\": ..., \"sessionId\": ...}", "Remove credentialProvider from the config file to use 'agbcloud login' instead"},
+		})
+	case cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "":
+		findings = append(findings, doctorFinding{
+			Level:   doctorWarn,
+			Message: "Not authenticated",
+			Tips:    []string{"Run 'agbcloud login' to authenticate"},
+		})
+	case !cfg.Token.ExpiresAt.IsZero() && time.Now().After(cfg.Token.ExpiresAt):
+		findings = append(findings, doctorFinding{
+			Level:   doctorWarn,
+			Message: fmt.Sprintf("Session expired at %s", formatTime(cfg.Token.ExpiresAt)),
+			Tips:    []string{"Run 'agbcloud login' to start a new session"},
+		})
+	case cfg.TokenFromProvider():
+		findings = append(findings, doctorFinding{Level: doctorOK, Message: fmt.Sprintf("Authenticated by credential provider '%s'", cfg.CredentialProvider)})
+	default:
+		findings = append(findings, doctorFinding{Level: doctorOK, Message: "Authenticated"})
+	}
+
+	return findings
+}
+
+// checkDoctorPermissions looks for files in the config directory, which hold tokens,
+// that other users on this machine can access
+func checkDoctorPermissions(ctx context.Context, fix bool) []doctorFinding {
+	issues, err := config.CheckPermissions()
+	if err != nil {
+		return []doctorFinding{{Level: doctorWarn, Message: fmt.Sprintf("Could not check permissions: %v", err)}}
+	}
+	if len(issues) == 0 {
+		return []doctorFinding{{Level: doctorOK, Message: "Config files are only accessible by you"}}
+	}
+
+	var findings []doctorFinding
+	for _, issue := range issues {
+		if fix {
+			if err := config.FixPermissions([]config.PermissionIssue{issue}); err != nil {
+				findings = append(findings, doctorFinding{Level: doctorWarn, Message: fmt.Sprintf("%s; failed to fix: %v", issue, err)})
+			} else {
+				findings = append(findings, doctorFinding{Level: doctorOK, Message: fmt.Sprintf("%s; restricted to your user", issue)})
+			}
+			continue
+		}
+		findings = append(findings, doctorFinding{Level: doctorWarn, Message: issue.String()})
+	}
+	if !fix {
+		findings[len(findings)-1].Tips = []string{"Run 'agbcloud doctor --fix' to restrict them to your user"}
+	}
+	return findings
+}
+
+// checkDoctorEndpoints probes the primary and fallback API endpoints
+func checkDoctorEndpoints(ctx context.Context, fix bool) []doctorFinding {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+
+	statuses := client.NewFromConfig(cfg).ProbeServers(ctx, 10*time.Second)
+	var findings []doctorFinding
+	reachable := 0
+	for i, status := range statuses {
+		role := "Primary"
+		if i > 0 {
+			role = "Fallback"
+		}
+		if status.Reachable {
+			reachable++
+			findings = append(findings, doctorFinding{
+				Level:   doctorOK,
+				Message: fmt.Sprintf("%s endpoint %s is reachable (%v)", role, status.URL, status.Latency.Round(time.Millisecond)),
+			})
+			continue
+		}
+		findings = append(findings, doctorFinding{
+			Level:   doctorWarn,
+			Message: fmt.Sprintf("%s endpoint %s is unreachable: %v", role, status.URL, status.Err),
+		})
+	}
+
+	switch {
+	case reachable == 0:
+		tips := []string{
+			"Check your network connection and proxy settings",
+			"Configure a backup endpoint with 'fallbackEndpoints' in the config file",
+		}
+		if len(statuses) > 0 {
+			if name, proxy := ProxyForEndpoint(statuses[0].URL); name != "" {
+				tips = append(tips, fmt.Sprintf("Requests go through the proxy in %s (%s); check it, or add the API host to NO_PROXY", name, redactProxy(proxy)))
+			}
+		}
+		findings = append(findings, doctorFinding{
+			Level:   doctorError,
+			Message: "No API endpoint is reachable",
+			Tips:    tips,
+		})
+	case reachable < len(statuses):
+		findings = append(findings, doctorFinding{
+			Level:   doctorOK,
+			Message: "Requests fail over to the reachable endpoints automatically",
+		})
+	}
+	return findings
+}
+
+// checkDoctorClock compares the local clock with the Date header of the API server
+func checkDoctorClock(ctx context.Context, fix bool) []doctorFinding {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		cfg = &config.Config{}
+	}
+
+	for _, status := range client.NewFromConfig(cfg).ProbeServers(ctx, 10*time.Second) {
+		if !status.ClockSkewKnown {
+			continue
+		}
+		if client.ClockSkewExceeds(status.ClockSkew, client.DefaultClockSkewThreshold) {
+			return []doctorFinding{{
+				Level:   doctorWarn,
+				Message: fmt.Sprintf("Local clock is %s the server (%s)", client.DescribeClockSkew(status.ClockSkew), status.URL),
+				Tips: []string{
+					"Presigned Dockerfile uploads may fail with SignatureDoesNotMatch",
+					"Synchronize the system clock (e.g. enable NTP) and try again",
+				},
+			}}
+		}
+		return []doctorFinding{{
+			Level:   doctorOK,
+			Message: fmt.Sprintf("Local clock is in sync with the server (offset %v)", status.ClockSkew),
+		}}
+	}
+
+	return []doctorFinding{{Level: doctorWarn, Message: "Could not determine the server time; no endpoint returned a Date header"}}
+}
+
+// checkDoctorCallbackListeners looks for OAuth callback servers left behind by crashed or stuck logins
+func checkDoctorCallbackListeners(ctx context.Context, fix bool) []doctorFinding {
+	statuses, err := auth.CheckCallbackListeners()
+	if err != nil {
+		return []doctorFinding{{Level: doctorWarn, Message: fmt.Sprintf("Could not read listener records: %v", err)}}
+	}
+
+	var findings []doctorFinding
+	for _, status := range statuses {
+		switch {
+		case status.Orphaned():
+			findings = append(findings, doctorFinding{
+				Level: doctorWarn,
+				Message: fmt.Sprintf("Port %s is held by another agbcloud login (PID %d, started %s)",
+					status.Port, status.Record.PID, formatTime(status.Record.StartedAt)),
+				Tips: []string{
+					"Finish or cancel the other login, or stop the process:",
+					killCommandHint(status.Record.PID),
+				},
+			})
+		case status.Stale():
+			message := fmt.Sprintf("Stale listener record for port %s (PID %d is no longer running)", status.Port, status.Record.PID)
+			if fix {
+				if err := auth.RemoveListenerRecord(status.Port); err != nil {
+					findings = append(findings, doctorFinding{Level: doctorWarn, Message: fmt.Sprintf("%s; failed to remove: %v", message, err)})
+				} else {
+					findings = append(findings, doctorFinding{Level: doctorOK, Message: message + "; removed"})
+				}
+				continue
+			}
+			findings = append(findings, doctorFinding{
+				Level:   doctorWarn,
+				Message: message,
+				Tips:    []string{"Run 'agbcloud doctor --fix' to remove it"},
+			})
+			if status.Occupied {
+				findings = append(findings, doctorFinding{
+					Level:   doctorWarn,
+					Message: fmt.Sprintf("Port %s is in use by another application", status.Port),
+					Tips:    []string{"Login will fall back to an alternative port provided by the server"},
+				})
+			}
+		case status.Occupied && status.Record == nil:
+			findings = append(findings, doctorFinding{
+				Level:   doctorWarn,
+				Message: fmt.Sprintf("Port %s is in use by another application", status.Port),
+				Tips:    []string{"Login will fall back to an alternative port provided by the server"},
+			})
+		default:
+			findings = append(findings, doctorFinding{Level: doctorOK, Message: fmt.Sprintf("Port %s is available", status.Port)})
+		}
+	}
+
+	return findings
+}
+
+// killCommandHint returns the platform-specific command to stop a process
+func killCommandHint(pid int) string {
+	if runtime.GOOS == "windows" {
+		return fmt.Sprintf("taskkill /PID %d /F", pid)
+	}
+	return fmt.Sprintf("kill %d", pid)
+}
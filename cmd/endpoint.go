@@ -0,0 +1,45 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// ApplyEndpointFlag makes the endpoints of the global --endpoint flag, if given,
+// override AGB_CLI_ENDPOINT and the configuration for this invocation
+func ApplyEndpointFlag(cmd *cobra.Command) error {
+	if !cmd.Flags().Changed("endpoint") {
+		config.SetEndpointOverride("")
+		return nil
+	}
+
+	value, _ := cmd.Flags().GetString("endpoint")
+
+	for _, endpoint := range strings.Split(value, ",") {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Invalid --endpoint value: '%s'", value),
+				"",
+				"[TIP] Usage: --endpoint https://staging.agb.cloud",
+			)
+		}
+		if err := config.ValidateEndpoint(endpoint); err != nil {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Invalid --endpoint value: %v", err),
+				"",
+				"[TIP] Usage: --endpoint https://staging.agb.cloud",
+				"[NOTE] Several endpoints are separated by commas; the first one is tried first",
+			)
+		}
+	}
+	config.SetEndpointOverride(value)
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+)
+
+// lineEndings is the state of the translation of piped stdout: stdout is the
+// standard output of the process, and done is closed once everything written to
+// the pipe reached it
+var lineEndings struct {
+	stdout *os.File
+	pipe   *os.File
+	done   chan struct{}
+}
+
+// ApplyEOLFlag selects the line endings of the output from the global --eol flag or
+// AGB_CLI_EOL: auto (the platform convention), lf or crlf. Structured output and
+// error messages always use them; tables and progress lines written to stdout use
+// them as well when stdout is piped or redirected, so that a file written from cmd,
+// PowerShell or Git Bash has one kind of line ending throughout.
+func ApplyEOLFlag(cmd *cobra.Command) error {
+	value, _ := cmd.Flags().GetString("eol")
+	if !cmd.Flags().Changed("eol") {
+		if env := os.Getenv("AGB_CLI_EOL"); env != "" {
+			value = env
+		}
+	}
+	nl, err := output.ParseEOL(value)
+	if err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] %v", err),
+			"",
+			"[TIP] Usage: --eol <auto|lf|crlf>",
+			"[NOTE] Example: agbcloud image list -q --eol lf > ids.txt",
+		)
+	}
+	output.SetNewline(nl)
+	translatePipedStdout()
+	return nil
+}
+
+// translatePipedStdout sends what is printed to stdout through a pipe that rewrites
+// "\n" to the selected line ending. Terminals handle either ending, so nothing is
+// translated when stdout is one, or when lines end with "\n" anyway.
+func translatePipedStdout() {
+	if lineEndings.stdout != nil || output.Newline() == "\n" {
+		return
+	}
+	if info, err := os.Stdout.Stat(); err != nil || info.Mode()&os.ModeCharDevice != 0 {
+		return
+	}
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return
+	}
+	lineEndings.stdout, lineEndings.pipe, lineEndings.done = os.Stdout, writer, make(chan struct{})
+	go func(stdout io.Writer, done chan struct{}) {
+		defer close(done)
+		_, _ = io.Copy(output.NewlineWriter(stdout), reader)
+		reader.Close()
+	}(os.Stdout, lineEndings.done)
+	os.Stdout = writer
+}
+
+// FlushOutput writes what is left of translated stdout and restores it. It must be
+// called before the process exits, or the end of the output may be lost.
+func FlushOutput() {
+	if lineEndings.stdout == nil {
+		return
+	}
+	if os.Stdout == lineEndings.pipe {
+		os.Stdout = lineEndings.stdout
+	}
+	lineEndings.pipe.Close()
+	<-lineEndings.done
+	lineEndings.stdout, lineEndings.pipe, lineEndings.done = nil, nil, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// errorHints maps backend error codes to the remediation printed below the error.
+// Keys are normalized with normalizeErrorCode, so IMAGE_NOT_FOUND and ImageNotFound
+// share an entry.
+var errorHints = map[string][]string{
+	"IMAGENOTAVAILABLE": {
+		"[TIP] The image is not ready yet. Check its status with 'agbcloud image list'",
+		"[NOTE] Only images with status Available or Activate Failed can be activated",
+	},
+	"IMAGENOTACTIVATED": {
+		"[TIP] Only an activated image can be restarted. Activate it with 'agbcloud image activate <image-id>'",
+	},
+	"IMAGENOTFOUND": {
+		"[TIP] Run 'agbcloud image list' to see the IDs of your images",
+	},
+	"INVALIDIMAGEID": {
+		"[TIP] Run 'agbcloud image list' to see the IDs of your images",
+	},
+	"INVALIDSOURCEIMAGE": {
+		"[TIP] Run 'agbcloud image list -t System' to see valid source image IDs",
+	},
+	"RESOURCEQUOTAEXCEEDED": {
+		"[TIP] Deactivate images you no longer use with 'agbcloud image deactivate <image-id>'",
+		"[TIP] Run 'agbcloud image list' to see which images are activated, or 'agbcloud image gc' to delete old ones",
+	},
+	"IMAGEQUOTAEXCEEDED": {
+		"[TIP] Delete images you no longer need with 'agbcloud image gc' before creating new ones",
+	},
+	"INVALIDTOKEN": {
+		"[TIP] Your session is no longer valid. Run 'agbcloud login' to log in again",
+	},
+	"USERLOGINEXPIRED": {
+		"[TIP] Your session has expired. Run 'agbcloud login' to log in again",
+		"[NOTE] 'agbcloud auth keepalive' keeps the session alive during long pipelines",
+	},
+	"SSHKEYEXISTS": {
+		"[TIP] Run 'agbcloud ssh-key list' to see the name the key is registered under",
+	},
+	"SSHKEYNAMEEXISTS": {
+		"[TIP] Choose another name with --name, or remove the existing key with 'agbcloud ssh-key remove <name>'",
+	},
+	"SSHKEYNOTFOUND": {
+		"[TIP] Run 'agbcloud ssh-key list' to see your keys",
+	},
+	"SESSIONNOTFOUND": {
+		"[TIP] The session has already ended. Run 'agbcloud auth sessions list' to see your active sessions",
+	},
+	"SERVICEACCOUNTNAMEEXISTS": {
+		"[TIP] Choose another name, or run 'agbcloud service-account list' to see the existing accounts",
+	},
+	"SERVICEACCOUNTNOTFOUND": {
+		"[TIP] Run 'agbcloud service-account list' to see your service accounts",
+	},
+	"INVALIDSERVICEACCOUNTTOKEN": {
+		"[TIP] The token is unknown, expired or revoked. Issue a new one with 'agbcloud service-account token <name>'",
+	},
+	"WARMCAPACITYUNAVAILABLE": {
+		"[TIP] No warm instance is ready for this image. Activate it without --fast-start, or try again later",
+		"[NOTE] The WARM column of 'agbcloud image list' shows the warm instances that are ready",
+	},
+	"INSUFFICIENTCAPACITY": {
+		"[TIP] No capacity of this size can be reserved right now. Try a smaller --reserve-spec, or create the image without one",
+	},
+	"THROTTLING": {
+		"[TIP] Too many requests were sent. Wait a moment and try again",
+	},
+}
+
+// normalizeErrorCode reduces an error code to upper-case letters and digits
+func normalizeErrorCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, code)
+}
+
+// ErrorHints returns the remediation for a backend error code, or nil if there is none
+func ErrorHints(code string) []string {
+	return errorHints[normalizeErrorCode(code)]
+}
+
+// APIErrorCode returns the error code in the body of an HTTP error response, if any
+func APIErrorCode(apiErr *client.GenericOpenAPIError) string {
+	code, _ := client.ErrorCode(apiErr)
+	return code
+}
+
+// apiCodeError reports an API response that failed with an error code, followed by
+// the remediation for that code. action describes what failed, e.g. "failed to start image".
+func apiCodeError(action, code string) error {
+	lines := []string{fmt.Sprintf("[ERROR] %s%s: %s", strings.ToUpper(action[:1]), action[1:], code)}
+	if hints := ErrorHints(code); len(hints) > 0 {
+		lines = append(append(lines, ""), hints...)
+	}
+	return printErrorMessage(lines...)
+}
+
+// apiResponseError reports an HTTP error response. When its body carries an error
+// code, the code and its remediation are printed as with apiCodeError.
+func apiResponseError(action string, apiErr *client.GenericOpenAPIError) error {
+	if code := APIErrorCode(apiErr); code != "" {
+		return apiCodeError(action, code)
+	}
+	return fmt.Errorf("%s: %s", action, apiErr.Error())
+}
+
+// requestError reports a failed API request on w. A response with an error code is
+// reported with the remediation for that code, other HTTP error responses with their
+// status, and anything else as a network problem. action describes what failed, e.g.
+// "failed to start image".
+func requestError(w io.Writer, action string, httpResp *http.Response, err error) error {
+	var failure *client.APIError
+	if errors.As(err, &failure) {
+		if failure.RequestID != "" {
+			fmt.Fprintf(w, "[SEARCH] Request ID: %s\n", failure.RequestID)
+		}
+		return apiCodeError(action, failure.Code)
+	}
+	var apiErr *client.GenericOpenAPIError
+	if errors.As(err, &apiErr) {
+		fmt.Fprintf(w, "[ERROR] API Error: %s\n", apiErr.Error())
+		if httpResp != nil {
+			fmt.Fprintf(w, "[DATA] Status Code: %d\n", httpResp.StatusCode)
+		}
+		return apiResponseError(action, apiErr)
+	}
+	return networkError(err)
+}
+
+// serverAnswered reports whether a request failed with an answer from the server,
+// rather than before reaching it
+func serverAnswered(err error) bool {
+	var failure *client.APIError
+	var apiErr *client.GenericOpenAPIError
+	return errors.As(err, &failure) || errors.As(err, &apiErr)
+}
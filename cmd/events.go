@@ -0,0 +1,62 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/agbcloud/agbcloud-cli/pkg/events"
+)
+
+var eventLogOnce sync.Once
+
+// EnableEventLog prints the client events of this command in the verbose log, as
+// lines such as "[EVENT] request.finished GET /api/image/list status=200 duration=120ms"
+func EnableEventLog() {
+	eventLogOnce.Do(func() {
+		events.Register(events.SinkFunc(func(_ context.Context, e events.Event) {
+			log.Debug(FormatEvent(e))
+		}))
+	})
+}
+
+// FormatEvent renders an event as a single [EVENT] line
+func FormatEvent(e events.Event) string {
+	parts := []string{"[EVENT]", string(e.Kind)}
+	if e.Method != "" {
+		parts = append(parts, e.Method, e.Path)
+	}
+	if e.Subject != "" {
+		parts = append(parts, e.Subject)
+	}
+	if e.To != "" {
+		from := e.From
+		if from == "" {
+			from = "(start)"
+		}
+		parts = append(parts, from, "->", e.To)
+	}
+	if e.Attempt != 0 {
+		parts = append(parts, fmt.Sprintf("attempt=%d", e.Attempt))
+	}
+	if e.StatusCode != 0 {
+		parts = append(parts, fmt.Sprintf("status=%d", e.StatusCode))
+	}
+	if e.Delay != 0 {
+		parts = append(parts, "delay="+e.Delay.Round(time.Millisecond).String())
+	}
+	if e.Duration != 0 {
+		parts = append(parts, "duration="+e.Duration.Round(time.Millisecond).String())
+	}
+	if e.Err != nil {
+		parts = append(parts, fmt.Sprintf("error=%q", e.Err.Error()))
+	}
+	return strings.Join(parts, " ")
+}
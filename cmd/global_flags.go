@@ -0,0 +1,93 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// AddGlobalFlags defines the persistent flags that every command of root accepts,
+// such as --output and --verbose. The Apply*Flag functions read them.
+func AddGlobalFlags(root *cobra.Command) {
+	flags := root.PersistentFlags()
+	flags.CountP("verbose", "v", "Verbose output: -v request summaries and step details, -vv headers, -vvv bodies (redacted)")
+	flags.StringP("output", "o", "table", "Output format: table, wide, json, csv or pson")
+	flags.String("csv-delimiter", ",", "Field delimiter for CSV output: a character, or comma, semicolon, tab or pipe")
+	flags.Bool("no-header", false, "Omit the header row of CSV output")
+	flags.String("eol", "auto", "Line endings of structured and piped output: auto, lf or crlf (auto: crlf on Windows except in Git Bash)")
+	flags.String("time-format", "", "Timestamp display: local, utc, relative or raw (default local)")
+	flags.String("tz", "", "Time zone of displayed timestamps: local, utc or an IANA name such as Asia/Shanghai (default local)")
+	flags.Bool("timestamps", false, "Prefix progress and status lines with the time they were printed")
+	flags.Bool("timing", false, "Report DNS, connect, TLS, time-to-first-byte and total time for each API call")
+	flags.String("endpoint", "", "API endpoint for this command, overriding AGB_CLI_ENDPOINT and the configuration")
+	flags.Bool("no-sticky", false, "Neither reuse nor remember flags for this command (see 'agb config sticky')")
+	flags.Bool("no-pager", false, "Do not page long output (see AGB_PAGER and the pager setting)")
+	flags.Bool("no-trunc", false, "Show IDs and names in tables in full instead of cutting them to fit the terminal")
+}
+
+// ApplyGlobalFlags applies the global flags to command before it runs: it sets up
+// logging, output formatting and the endpoint, prepares the first run, starts tracing
+// and fills in sticky flags.
+func ApplyGlobalFlags(command *cobra.Command) error {
+	// Set up logging based on the verbosity tier (-v, -vv or -vvv)
+	ApplyVerboseFlag(command)
+
+	// Record API call timings if requested
+	if timing, _ := command.Flags().GetBool("timing"); timing {
+		EnableTiming()
+	}
+
+	// Set log format to be more CLI-friendly
+	log.SetFormatter(&log.TextFormatter{
+		DisableTimestamp: true,
+		DisableColors:    false,
+	})
+
+	// Show timestamps in the team's time zone if one is selected
+	if err := ApplyTimeZoneFlag(command); err != nil {
+		return err
+	}
+
+	// Show when each step of a long operation happened
+	ApplyTimestampsFlag(command)
+
+	// Page long tables unless --no-pager is given
+	ApplyPagerFlag(command)
+
+	// Show table values in full if requested; piped tables are never truncated
+	ApplyTruncationFlag(command)
+
+	// Shape CSV output for spreadsheets
+	if err := ApplyCSVFlags(command); err != nil {
+		return err
+	}
+
+	// End lines the way the shell that reads piped output expects
+	if err := ApplyEOLFlag(command); err != nil {
+		return err
+	}
+
+	// Point this invocation at another backend if requested
+	if err := ApplyEndpointFlag(command); err != nil {
+		return err
+	}
+
+	// Create the configuration and show how to get started on first run
+	PrepareFirstRun(command)
+
+	// Export a trace of this command if an OTLP endpoint is configured
+	if err := StartTracing(command); err != nil {
+		return err
+	}
+
+	// Reuse and remember last-used flags if sticky flags are enabled
+	if err := ApplyStickyFlags(command); err != nil {
+		return err
+	}
+
+	// Keep stdout to the JSON document when JSON output is selected
+	ApplyOutputFlag(command)
+	return nil
+}
@@ -5,39 +5,87 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
-	"runtime"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/agbcloud/agbcloud-cli/internal/client"
 	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/imagestatus"
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+	"github.com/agbcloud/agbcloud-cli/internal/verbosity"
 )
 
-// printErrorMessage prints multi-line error messages by printing each line separately
-// This avoids Windows line ending issues
+// printErrorMessage prints multi-line error messages by printing each line separately,
+// ending each with the line ending selected with --eol
 func printErrorMessage(lines ...string) error {
+	nl := output.Newline()
 	// Print each line to stderr for immediate display
 	for _, line := range lines {
-		fmt.Fprintln(os.Stderr, line)
+		fmt.Fprint(os.Stderr, line+nl)
 	}
 	// Return an error containing the full message for testing purposes
-	fullMessage := strings.Join(lines, getNewline())
+	fullMessage := strings.Join(lines, nl)
 	return fmt.Errorf("%s", fullMessage)
 }
 
-// getNewline returns the appropriate newline character(s) for the current platform
-func getNewline() string {
-	if runtime.GOOS == "windows" {
-		return "\r\n"
+// networkError reports a request that failed without an API error response.
+// Oversized responses, TLS pin mismatches and a tripped circuit breaker are explained
+// with guidance instead of being reported as network failures.
+func networkError(err error) error {
+	var circuitOpen *client.CircuitOpenError
+	if errors.As(err, &circuitOpen) {
+		lines := []string{
+			fmt.Sprintf("[ERROR] The AgbCloud API at %s is failing repeatedly (%d server errors in a row)", circuitOpen.Host, circuitOpen.Failures),
+			"[NOTE] Further requests are paused to protect the service instead of retrying",
+			"",
+		}
+		if wait := circuitOpen.RetryAfter.Round(time.Second); wait > 0 {
+			lines = append(lines, fmt.Sprintf("[TIP] Try again in %s, or check the service status", wait))
+		} else {
+			lines = append(lines, "[TIP] Try again shortly, or check the service status")
+		}
+		lines = append(lines, "[TIP] Set AGB_CLI_CIRCUIT_BREAKER=off to keep retrying regardless")
+		return printErrorMessage(lines...)
+	}
+	var pinMismatch *client.PinMismatchError
+	if errors.As(err, &pinMismatch) {
+		if pinMismatch.PlainHTTP {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] TLS pins are configured for %s, but the endpoint uses plain HTTP", pinMismatch.Host),
+				"",
+				"[TIP] Use an https:// endpoint, or remove the host from tlsPins in the configuration",
+			)
+		}
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] TLS pin mismatch: %s presented a public key that is not pinned", pinMismatch.Host),
+			fmt.Sprintf("[NOTE] Presented: %s", strings.Join(pinMismatch.Presented, ", ")),
+			"[NOTE] The connection was refused; a proxy or an attacker may be intercepting it",
+			"",
+			"[TIP] If the server's certificate was replaced on purpose, verify its new pin with your administrator",
+			fmt.Sprintf("[TIP] Show the current pins with: agbcloud config tls-pin %s", pinMismatch.Host),
+		)
 	}
-	return "\n"
+	var tooLarge *client.ResponseTooLargeError
+	if errors.As(err, &tooLarge) {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] The response from %s exceeded the maximum size of %s", tooLarge.Path, client.FormatByteSize(tooLarge.Limit)),
+			"",
+			"[TIP] If such a large response is expected, raise the limit, e.g. AGB_CLI_MAX_RESPONSE_SIZE=64MB",
+			"[NOTE] An unexpectedly large response can mean that a proxy or the wrong endpoint is answering",
+		)
+	}
+	return transientError(err, fmt.Errorf("network error: %v", err))
 }
 
 var ImageCmd = &cobra.Command{
@@ -50,7 +98,16 @@ var ImageCmd = &cobra.Command{
 var imageCreateCmd = &cobra.Command{
 	Use:   "create <image-name>",
 	Short: "Create a custom image",
-	Long:  "Create a custom image using a Dockerfile",
+	Long: `Create a custom image using a Dockerfile.
+
+With --reserve-spec, capacity of the given size (2c4g, 4c8g or 8c16g) is reserved
+before the build starts, so that the first activation of the image does not wait
+for capacity. The reservation is released if the build fails; 'agbcloud image
+status' shows whether it is still held.
+
+With --no-poll, the command returns as soon as the server accepted the build, without
+polling it or recording a job. Together with -o json it prints the task handle for
+orchestration systems, which follow the build with 'agbcloud image task'.`,
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
 			return printErrorMessage(
@@ -78,7 +135,7 @@ var imageCreateCmd = &cobra.Command{
 }
 
 var imageActivateCmd = &cobra.Command{
-	Use:   "activate <image-id>",
+	Use:   "activate <image-id>...",
 	Short: "Activate an image",
 	Long: `Activate an image with specified resources.
 
@@ -87,54 +144,46 @@ Supported CPU and Memory combinations:
   4c8g  - 4 CPU cores with 8 GB memory  
   8c16g - 8 CPU cores with 16 GB memory
 
-If no CPU/memory is specified, default resources will be used.`,
-	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) == 0 {
-			return printErrorMessage(
-				"[ERROR] Missing required argument: <image-id>",
-				"",
-				"[TIP] Usage: agbcloud image activate <image-id> [--cpu <cores> --memory <gb>]",
-				"[NOTE] Example: agbcloud image activate img-7a8b9c1d0e --cpu 2 --memory 4",
-			)
-		}
-		if len(args) > 1 {
-			return printErrorMessage(
-				fmt.Sprintf("[ERROR] Too many arguments provided. Expected 1 argument (image ID), got %d", len(args)),
-				"",
-				"[TIP] Usage: agbcloud image activate <image-id> [--cpu <cores> --memory <gb>]",
-				"[NOTE] Example: agbcloud image activate img-7a8b9c1d0e --cpu 2 --memory 4",
-			)
-		}
-		return nil
-	},
+If no CPU/memory is specified, default resources will be used.
+
+With --fast-start a warm instance is requested. It starts in seconds instead of
+minutes, but is billed at a higher rate. The WARM column of 'agbcloud image list'
+shows how many warm instances are ready.
+
+With --lease the image is deactivated when the lease ends. While the command is
+attached, 10 minutes before the end the lease is extended by its duration if
+--auto-renew allows it, or you are asked whether to extend it. The lease is enforced
+by the CLI, so it cannot be combined with --detach. After Ctrl+C the lease is a job:
+'agbcloud jobs attach' watches it again, and 'agbcloud jobs enforce' deactivates
+images whose lease has ended. Activating an activated image with --lease starts a
+new lease.
+
+The image can also be given by a unique prefix of its ID, by its content digest
+(sha256:<hex>, at least 12 digits) as shown in the DIGEST column of
+'agbcloud image list', or by name with --name.
+
+Several images can be activated at once; up to --concurrency of them are activated
+at the same time (3 unless the concurrency setting says otherwise), and a summary is
+printed at the end. Lower it if your account is rate limited. --lease, --detach and
+--dry-run work with a single image.`,
+	Args: imageReferencesArgs("agbcloud image activate <image-id>... [--cpu <cores> --memory <gb>]", "agbcloud image activate img-7a8b9c1d0e --cpu 2 --memory 4"),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runImageActivate(cmd, args)
 	},
 }
 
 var imageDeactivateCmd = &cobra.Command{
-	Use:   "deactivate <image-id>",
+	Use:   "deactivate <image-id>...",
 	Short: "Deactivate an image",
-	Long:  "Deactivate a running image instance",
-	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) == 0 {
-			return printErrorMessage(
-				"[ERROR] Missing required argument: <image-id>",
-				"",
-				"[TIP] Usage: agbcloud image deactivate <image-id>",
-				"[NOTE] Example: agbcloud image deactivate img-7a8b9c1d0e",
-			)
-		}
-		if len(args) > 1 {
-			return printErrorMessage(
-				fmt.Sprintf("[ERROR] Too many arguments provided. Expected 1 argument (image ID), got %d", len(args)),
-				"",
-				"[TIP] Usage: agbcloud image deactivate <image-id>",
-				"[NOTE] Example: agbcloud image deactivate img-7a8b9c1d0e",
-			)
-		}
-		return nil
-	},
+	Long: `Deactivate a running image instance.
+
+The image can also be given by a unique prefix of its ID, by its content digest
+(sha256:<hex>, at least 12 digits) as shown in the DIGEST column of
+'agbcloud image list', or by name with --name.
+
+Several images can be deactivated at once, up to --concurrency of them at the same
+time; a summary is printed at the end.`,
+	Args: imageReferencesArgs("agbcloud image deactivate <image-id>...", "agbcloud image deactivate img-7a8b9c1d0e"),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runImageDeactivate(cmd, args)
 	},
@@ -148,6 +197,12 @@ var imageListCmd = &cobra.Command{
 Image types:
   User   - Custom images created by users
   System - System-provided base images`,
+	Example: `  agbcloud image list --search web
+  agbcloud image list --name-contains web --size 50
+  agbcloud image list --all -q | xargs -n1 agbcloud image deactivate
+  agbcloud image list --cached
+  agbcloud image list --all -o wide
+  agbcloud image list --watch --interval 10s`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runImageList(cmd, args)
@@ -158,22 +213,86 @@ func init() {
 	// Add flags for create command
 	imageCreateCmd.Flags().StringP("dockerfile", "f", "", "Path to Dockerfile (required)")
 	imageCreateCmd.Flags().StringP("imageId", "i", "", "Source image ID (required)")
+	imageCreateCmd.Flags().Bool("force", false, "Skip the check for an existing image with the same name, and upload a file that does not look like a Dockerfile")
+	imageCreateCmd.Flags().Bool("force-new", false, "Start a new build even if a build of the same image is in progress")
+	imageCreateCmd.Flags().Bool("fail-on-warnings", false, "Exit with an error if any warning occurred, even when the image was created")
+	imageCreateCmd.Flags().String("platform", "", "Comma-separated platforms to build for, e.g. linux/amd64,linux/arm64 (default: the server's default platform)")
+	imageCreateCmd.Flags().Bool("cleanup-on-failure", false, "Delete the server-side task and its artifacts if the build fails (default from config)")
+	imageCreateCmd.Flags().String("reserve-spec", "", "Reserve capacity for the first activation, e.g. 4c8g (released if the build fails)")
+	imageCreateCmd.Flags().Bool("verbose-poll", false, "Print every status check instead of only status changes")
+	imageCreateCmd.Flags().Bool("detach", false, "Return once the build has started and follow it later with 'agbcloud jobs attach'")
+	imageCreateCmd.Flags().Bool("no-resume", false, "Start a new Dockerfile upload instead of resuming an interrupted one")
+	imageCreateCmd.Flags().Bool("no-poll", false, "Return the task ID as soon as the build is accepted, without polling it or recording a job")
+	imageCreateCmd.Flags().String("callback-url", "", "https URL the server calls when the build finishes or fails, e.g. for CI; pair with --no-poll")
+	imageCreateCmd.Flags().Bool("refresh", false, "Fetch the System image list again instead of using the cached one")
+	addCopyFlag(imageCreateCmd, "new image ID, or the task ID with --detach or --no-poll,")
+	addPolicyFileFlag(imageCreateCmd)
 	// Note: We handle required flag validation manually for better error messages
 
 	// Add flags for activate command
 	imageActivateCmd.Flags().IntP("cpu", "c", 0, "CPU cores")
 	imageActivateCmd.Flags().IntP("memory", "m", 0, "Memory in GB")
+	imageActivateCmd.Flags().Bool("fast-start", false, "Request a warm instance that starts faster, billed at a higher rate")
+	imageActivateCmd.Flags().Bool("verbose-poll", false, "Print every status check instead of only status changes")
+	imageActivateCmd.Flags().String("name", "", "Select the image by name instead of ID")
+	imageActivateCmd.Flags().Bool("detach", false, "Return once the activation has started and follow it later with 'agbcloud jobs attach'")
+	imageActivateCmd.Flags().String("lease", "", "Deactivate the image after this long, e.g. 2h; you are asked to extend it 10 minutes before")
+	imageActivateCmd.Flags().Int("auto-renew", 0, "Extend the lease automatically up to this many times instead of asking")
+	imageActivateCmd.Flags().Bool("dry-run", false, "Check the image and show the activation request without sending it")
+	addConcurrencyFlag(imageActivateCmd, "Images activated at the same time when several are given")
+	addPolicyFileFlag(imageActivateCmd)
+
+	// Add flags for deactivate command
+	imageDeactivateCmd.Flags().Bool("verbose-poll", false, "Print every status check instead of only status changes")
+	imageDeactivateCmd.Flags().String("name", "", "Select the image by name instead of ID")
+	imageDeactivateCmd.Flags().Duration("wait-grace", 0, "Give the workload this long to flush its state before the instance is stopped, e.g. 30s (default: the server's)")
+	imageDeactivateCmd.Flags().Bool("force", false, "Stop the instance at once, without a grace period")
+	imageDeactivateCmd.Flags().Bool("dry-run", false, "Check the image and show the deactivation request without sending it")
+	addConcurrencyFlag(imageDeactivateCmd, "Images deactivated at the same time when several are given")
 
 	// Add flags for list command
 	imageListCmd.Flags().StringP("type", "t", "User", "Image type: User (custom images) or System (base images)")
 	imageListCmd.Flags().IntP("page", "p", 1, "Page number (default: 1)")
 	imageListCmd.Flags().IntP("size", "s", 10, "Page size (default: 10)")
+	imageListCmd.Flags().String("search", "", "Only list images whose name contains this text (alias: --name-contains)")
+	imageListCmd.Flags().Bool("all", false, "List the images on every page instead of a single page")
+	imageListCmd.Flags().BoolP("quiet", "q", false, "Only print image IDs, one per line (takes precedence over --output)")
+	imageListCmd.Flags().Bool("cached", false, "Show the last successfully fetched list without contacting the server")
+	imageListCmd.Flags().Bool("watch", false, "Keep checking the page and print it again whenever it changes")
+	imageListCmd.Flags().Duration("interval", imagePollInterval, "Time between two checks with --watch")
+	imageListCmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "name-contains" {
+			name = "search"
+		}
+		return pflag.NormalizedName(name)
+	})
 
 	// Add subcommands to image command
 	ImageCmd.AddCommand(imageCreateCmd)
 	ImageCmd.AddCommand(imageActivateCmd)
 	ImageCmd.AddCommand(imageDeactivateCmd)
 	ImageCmd.AddCommand(imageListCmd)
+
+	registerOutputSchema(imageCreateCmd, outputSchema{
+		Command:     "image create",
+		Version:     1,
+		Description: "Written with --no-poll once the server accepted the build. uploadDurationMs is the time the Dockerfile upload took, retries included.",
+		Result:      ImageCreateHandle{},
+	})
+	registerOutputSchema(imageListCmd, outputSchema{
+		Command:     "image list",
+		Version:     1,
+		Description: "Images on the requested page. cpu and memory are null when the backend does not report them.",
+		Result:      []ImageListItem{},
+	})
+	for _, command := range []*cobra.Command{imageActivateCmd, imageDeactivateCmd} {
+		registerOutputSchema(command, outputSchema{
+			Command:     "image " + command.Name(),
+			Version:     1,
+			Description: "Written when several images are given, one result per image in argument order. result is activated, deactivated, unchanged or failed. Failed images have the error code of the server, or a code such as ImageNotFound, and the request ID when the server sent one.",
+			Result:      []ImageOperationResult{},
+		})
+	}
 }
 
 // ValidateCPUMemoryCombo validates that CPU and memory combination is supported
@@ -217,10 +336,111 @@ func ValidateCPUMemoryCombo(cpu, memory int) error {
 	return nil
 }
 
+// Image naming rules enforced by the backend
+const (
+	imageNameMinLength = 2
+	imageNameMaxLength = 64
+)
+
+var imageNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9._-]*$`)
+
+// maxDeactivateGrace is the longest grace period of 'image deactivate --wait-grace'
+const maxDeactivateGrace = time.Hour
+
+// ValidateDeactivateGrace checks the --wait-grace and --force flags of 'image deactivate'
+func ValidateDeactivateGrace(grace time.Duration, force bool) error {
+	switch {
+	case force && grace != 0:
+		return printErrorMessage(
+			"[ERROR] --wait-grace cannot be combined with --force",
+			"",
+			"[TIP] --force skips the grace period; use one or the other",
+		)
+	case grace < 0, grace > maxDeactivateGrace, grace%time.Second != 0:
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid --wait-grace value: %s", grace),
+			"",
+			fmt.Sprintf("[TIP] Give whole seconds between 1s and %s", maxDeactivateGrace),
+			"[NOTE] Example: agbcloud image deactivate img-7a8b9c1d0e --wait-grace 30s",
+		)
+	}
+	return nil
+}
+
+// ValidateImageName validates that an image name satisfies the backend naming rules
+func ValidateImageName(name string) error {
+	if len(name) < imageNameMinLength || len(name) > imageNameMaxLength {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid image name length: '%s' has %d characters", name, len(name)),
+			"",
+			fmt.Sprintf("[TIP] Image names must be between %d and %d characters long", imageNameMinLength, imageNameMaxLength),
+		)
+	}
+
+	if !imageNamePattern.MatchString(name) {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid image name: '%s'", name),
+			"",
+			"[TIP] Image names must start with a letter and contain only letters, digits, '.', '_' or '-'",
+			"[NOTE] Example: agbcloud image create my-image_v1.0 --dockerfile ./Dockerfile --imageId agb-code-space-1",
+		)
+	}
+
+	return nil
+}
+
+// FindUserImageByName pages through the user's images and returns the one with the given name, or nil if none exists
+func FindUserImageByName(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, imageName string) (*client.ImageInfo, error) {
+	const pageSize = 50
+
+	for page := 1; ; page++ {
+		// The server narrows the results by name; the exact match is still checked below
+		listResp, _, err := apiClient.ImageAPI.ListImages(ctx, loginToken, sessionId, client.ImageListOptions{
+			ImageType:    "User",
+			Page:         page,
+			PageSize:     pageSize,
+			NameContains: imageName,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range listResp.Data.Images {
+			if listResp.Data.Images[i].ImageName == imageName {
+				image := listResp.Data.Images[i]
+				return &image, nil
+			}
+		}
+
+		// Stop once the last page has been fetched
+		if len(listResp.Data.Images) < pageSize || page*pageSize >= listResp.Data.Total {
+			return nil, nil
+		}
+	}
+}
+
+// ImageCreateHandle identifies a build started by 'image create --no-poll'
+type ImageCreateHandle struct {
+	TaskID           string `json:"taskId"`
+	ImageName        string `json:"imageName"`
+	UploadDurationMs int64  `json:"uploadDurationMs"`
+}
+
 func runImageCreate(cmd *cobra.Command, args []string) error {
 	imageName := args[0]
 	dockerfilePath, _ := cmd.Flags().GetString("dockerfile")
 	sourceImageId, _ := cmd.Flags().GetString("imageId")
+	force, _ := cmd.Flags().GetBool("force")
+	forceNew, _ := cmd.Flags().GetBool("force-new")
+	failOnWarnings, _ := cmd.Flags().GetBool("fail-on-warnings")
+	platformValue, _ := cmd.Flags().GetString("platform")
+	reserveSpec, _ := cmd.Flags().GetString("reserve-spec")
+	verbosePoll, _ := cmd.Flags().GetBool("verbose-poll")
+	detach, _ := cmd.Flags().GetBool("detach")
+	noResume, _ := cmd.Flags().GetBool("no-resume")
+	noPoll, _ := cmd.Flags().GetBool("no-poll")
+	callbackURL, _ := cmd.Flags().GetString("callback-url")
+	refresh, _ := cmd.Flags().GetBool("refresh")
 
 	// Validate required flags with friendly messages
 	if dockerfilePath == "" {
@@ -242,6 +462,55 @@ func runImageCreate(cmd *cobra.Command, args []string) error {
 		)
 	}
 
+	// Validate image name before talking to the server
+	if err := ValidateImageName(imageName); err != nil {
+		return err
+	}
+	platforms, err := ParsePlatforms(platformValue)
+	if err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid --platform value: %v", err),
+			"",
+			"[NOTE] Example: agbcloud image create myImage -f ./Dockerfile -i agb-code-space-1 --platform linux/amd64,linux/arm64",
+		)
+	}
+	if noPoll && detach {
+		return printErrorMessage(
+			"[ERROR] --no-poll cannot be combined with --detach",
+			"",
+			"[TIP] Use --detach to follow the build later with 'agbcloud jobs attach', or --no-poll to follow it yourself with 'agbcloud image task'",
+		)
+	}
+	if callbackURL != "" {
+		if err := ValidateCallbackURL(callbackURL); err != nil {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Invalid --callback-url value: %v", err),
+				"",
+				"[NOTE] Example: agbcloud image create myImage -f ./Dockerfile -i agb-code-space-1 --callback-url https://ci.example.com/hook --no-poll",
+			)
+		}
+	}
+	// Only the task handle of --no-poll has a structured form
+	outputFormat := output.FormatTable
+	if noPoll {
+		if outputFormat, err = getOutputFormat(cmd); err != nil {
+			return err
+		}
+	}
+	var reserveCPU, reserveMemory int
+	if reserveSpec != "" {
+		if reserveCPU, reserveMemory, err = ParseResourceSpec(reserveSpec); err != nil {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Invalid --reserve-spec value: %v", err),
+				"",
+				"[TIP] Supported specs: 2c4g, 4c8g, 8c16g",
+			)
+		}
+		if err := ValidateCPUMemoryCombo(reserveCPU, reserveMemory); err != nil {
+			return err
+		}
+	}
+
 	fmt.Printf("[BUILD]  Creating image '%s'...\n", imageName)
 
 	// Load configuration and check authentication
@@ -255,6 +524,7 @@ func runImageCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Validate dockerfile path
+	dockerfileArg := dockerfilePath
 	if !filepath.IsAbs(dockerfilePath) {
 		dockerfilePath, err = filepath.Abs(dockerfilePath)
 		if err != nil {
@@ -266,85 +536,272 @@ func runImageCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("dockerfile not found: %s", dockerfilePath)
 	}
 
+	// Warnings are collected across the whole pipeline for --fail-on-warnings
+	warnings := &WarningRecorder{}
+
+	// Catch a directory, binary or unrelated file given as Dockerfile before uploading it
+	var problem *DockerfileProblem
+	if err := CheckDockerfile(dockerfilePath); errors.As(err, &problem) {
+		problem.Path = dockerfileArg
+		if err := dockerfileProblemError(problem, force, warnings); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return fmt.Errorf("failed to read dockerfile: %w", err)
+	}
+
+	// Past builds from the same source image tell how long to expect
+	printBuildEstimate(sourceImageId)
+
+	cleanupOnFailure := ResolveCleanupOnFailure(cmd, cfg)
+
+	// A file forced through the check above would only produce meaningless lint warnings
+	if content, err := os.ReadFile(dockerfilePath); err == nil && problem == nil {
+		for _, warning := range LintDockerfile(string(content)) {
+			warnings.Warn("%s", warning)
+		}
+	}
+
+	// The organization policy is checked before anything is uploaded
+	if err := checkCreatePolicy(cmd, cfg, imageName, sourceImageId, dockerfilePath); err != nil {
+		return err
+	}
+
 	// Create API client
 	apiClient := client.NewFromConfig(cfg)
-	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Minute)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 45*time.Minute)
 	defer cancel()
 
-	// Step 1: Get upload credential
-	fmt.Println("[SIGNAL] Getting upload credentials...")
-	uploadResp, httpResp, err := apiClient.ImageAPI.GetUploadCredential(ctx, cfg.Token.LoginToken, cfg.Token.SessionId)
-	if err != nil {
-		if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
-			fmt.Printf("[ERROR] API Error: %s\n", apiErr.Error())
-			if httpResp != nil {
-				fmt.Printf("[DATA] Status Code: %d\n", httpResp.StatusCode)
+	// Follow a build of the same image that is still running instead of starting a duplicate
+	if !forceNew {
+		fmt.Println("[SEARCH] Checking for builds of the same image in progress...")
+		task, err := FindInProgressImageTask(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageName)
+		if err != nil {
+			warnings.Warn("Could not check for builds in progress: %v", err)
+		} else if task != nil && shouldAttachToImageTask(task) {
+			if detach {
+				fmt.Printf("[REFRESH] A build of '%s' is already in progress (task %s)\n", imageName, task.TaskID)
+				return detachJob(Job{Type: JobCreate, ImageName: imageName, TaskID: task.TaskID, CleanupOnFailure: cleanupOnFailure})
+			}
+			fmt.Printf("[REFRESH] Attaching to task %s...\n", task.TaskID)
+			createdId, err := monitorImageTask(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, task.TaskID, warnings, cleanupOnFailure, verbosePoll)
+			if err != nil {
+				return err
 			}
-			return fmt.Errorf("failed to get upload credentials: %s", apiErr.Error())
+			copyToClipboard(cmd, os.Stdout, "image ID", createdId)
+			return warnings.Check(failOnWarnings)
+		}
+	}
+
+	// Pre-flight check: fail early if an image with the same name already exists
+	if !force {
+		fmt.Println("[SEARCH] Checking for existing images with the same name...")
+		existing, err := FindUserImageByName(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageName)
+		if err != nil {
+			// The server still enforces uniqueness, so don't block creation on a failed check
+			warnings.Warn("Could not check for existing images: %v", err)
+		} else if existing != nil {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] An image named '%s' already exists (Image ID: %s, Status: %s)", imageName, existing.ImageID, FormatImageStatus(existing.Status)),
+				"",
+				"[TIP] Choose a different image name, or use --force to skip this check",
+			)
 		}
-		return fmt.Errorf("network error: %v", err)
 	}
 
-	if !uploadResp.Success {
-		fmt.Printf("[SEARCH] Request ID: %s\n", uploadResp.RequestID)
-		return fmt.Errorf("failed to get upload credentials: %s", uploadResp.Code)
+	// Multi-platform builds need a server that supports every requested platform
+	if len(platforms) > 0 {
+		if err := checkPlatforms(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, platforms); err != nil {
+			return err
+		}
 	}
 
-	fmt.Printf("[OK] Upload credentials obtained (Task ID: %s)\n", uploadResp.Data.TaskID)
+	// The source has to be a System image; the list is cached between commands
+	fmt.Println("[SEARCH] Checking the source image...")
+	systemImages := SystemImageList{APIClient: apiClient, LoginToken: cfg.Token.LoginToken, SessionID: cfg.Token.SessionId, Refresh: refresh}
+	if err := checkSourceImages(ctx, systemImages, []string{sourceImageId}, warnings.Warn); err != nil {
+		return err
+	}
+
+	// Step 1: Get upload credential, unless an upload interrupted by an earlier run can be resumed
+	var journal *UploadJournal
+	if content, err := os.ReadFile(dockerfilePath); err == nil && !noResume {
+		journal = resumableUpload(content)
+	}
+	var uploadData client.ImageUploadCredentialData
+	if journal != nil {
+		uploadData = journal.Credential
+	} else {
+		uploadData, err = requestUploadCredential(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, warnings)
+		if err != nil {
+			return err
+		}
+		warnClockSkew(apiClient, warnings)
+	}
 
 	// Step 2: Upload dockerfile
 	fmt.Println("[UPLOAD] Uploading Dockerfile...")
-	err = uploadDockerfile(dockerfilePath, uploadResp.Data.OssURL)
+	uploadStarted := time.Now()
+	if expiresAt, ok := uploadData.ExpiresAt(); ok && time.Until(expiresAt) < uploadCredentialMinValidity {
+		warnings.Warn("Upload credentials are about to expire, requesting new credentials...")
+		uploadData, err = requestUploadCredential(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, warnings)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = uploadDockerfile(ctx, dockerfilePath, uploadData, cfg.UploadStorage, journal, warnings)
+	if err != nil && IsUploadCredentialExpiredError(err) {
+		// Presigned URLs expire; request fresh credentials and retry exactly once
+		warnings.Warn("Upload credentials were rejected as expired")
+		fmt.Println("[REFRESH] Requesting new credentials and retrying the upload once...")
+		uploadData, err = requestUploadCredential(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, warnings)
+		if err != nil {
+			return err
+		}
+		err = uploadDockerfile(ctx, dockerfilePath, uploadData, cfg.UploadStorage, nil, warnings)
+	}
 	if err != nil {
-		fmt.Printf("[DOC] Task ID: %s\n", uploadResp.Data.TaskID)
+		fmt.Printf("[DOC] Task ID: %s\n", uploadData.TaskID)
+		if IsUploadCredentialExpiredError(err) {
+			printClockSkewTip(apiClient)
+		}
+		// A deleted task leaves nothing to resume
+		if cleanupOnFailure {
+			if content, readErr := os.ReadFile(dockerfilePath); readErr == nil {
+				if journal, _ := LoadUploadJournal(content); journal != nil {
+					journal.Remove()
+				}
+			}
+		}
+		cleanupFailedImageTask(apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, uploadData.TaskID, cleanupOnFailure)
 		return fmt.Errorf("failed to upload dockerfile: %w", err)
 	}
 
 	fmt.Println("[OK] Dockerfile uploaded successfully")
+	uploadDuration := time.Since(uploadStarted)
+
+	// Capacity is reserved before the build starts, so that a build is not started without it
+	var reservation *client.ImageReservationData
+	if reserveSpec != "" {
+		reservation, err = reserveImageCapacity(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, uploadData.TaskID, reserveCPU, reserveMemory)
+		if err != nil {
+			fmt.Printf("[DOC] Task ID: %s\n", uploadData.TaskID)
+			cleanupFailedImageTask(apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, uploadData.TaskID, cleanupOnFailure)
+			return err
+		}
+	}
 
 	// Step 3: Create image
 	fmt.Println("[WORK] Creating image...")
-	createResp, httpResp, err := apiClient.ImageAPI.CreateImage(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, imageName, uploadResp.Data.TaskID, sourceImageId)
+	_, httpResp, err := apiClient.ImageAPI.CreateImage(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, imageName, uploadData.TaskID, sourceImageId,
+		client.ImageCreateOptions{Platforms: platforms, CallbackURL: callbackURL})
 	if err != nil {
-		if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
-			fmt.Printf("[ERROR] API Error: %s\n", apiErr.Error())
-			if httpResp != nil {
-				fmt.Printf("[DATA] Status Code: %d\n", httpResp.StatusCode)
-			}
-			fmt.Printf("[DOC] Task ID: %s\n", uploadResp.Data.TaskID)
-			return fmt.Errorf("failed to create image: %s", apiErr.Error())
+		fmt.Printf("[DOC] Task ID: %s\n", uploadData.TaskID)
+		// The task is only known to have failed when the server answered
+		if serverAnswered(err) {
+			releaseImageReservation(apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, reservation)
+			cleanupFailedImageTask(apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, uploadData.TaskID, cleanupOnFailure)
 		}
-		fmt.Printf("[DOC] Task ID: %s\n", uploadResp.Data.TaskID)
-		return fmt.Errorf("network error: %v", err)
-	}
-
-	if !createResp.Success {
-		fmt.Printf("[DOC] Task ID: %s\n", uploadResp.Data.TaskID)
-		fmt.Printf("[SEARCH] Request ID: %s\n", createResp.RequestID)
-		return fmt.Errorf("failed to create image: %s", createResp.Code)
+		return requestError(os.Stdout, "failed to create image", httpResp, err)
 	}
 
 	fmt.Println("[OK] Image creation initiated")
+	if callbackURL != "" {
+		verifyImageWebhook(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, uploadData.TaskID, callbackURL, warnings)
+	}
+	if noPoll {
+		handle := ImageCreateHandle{TaskID: uploadData.TaskID, ImageName: imageName, UploadDurationMs: uploadDuration.Milliseconds()}
+		if outputFormat.IsStructured() {
+			if err := writeResult(outputFormat, handle); err != nil {
+				return err
+			}
+		} else {
+			fmt.Printf("[DOC] Task ID: %s\n", handle.TaskID)
+			fmt.Printf("[TIP] Follow the build with: agbcloud image task %s --watch\n", handle.TaskID)
+		}
+		copyToClipboard(cmd, os.Stdout, "task ID", handle.TaskID)
+		return warnings.Check(failOnWarnings)
+	}
+	if detach {
+		fmt.Printf("[DOC] Task ID: %s\n", uploadData.TaskID)
+		copyToClipboard(cmd, os.Stdout, "task ID", uploadData.TaskID)
+		if err := detachJob(Job{Type: JobCreate, ImageName: imageName, TaskID: uploadData.TaskID, CleanupOnFailure: cleanupOnFailure}); err != nil {
+			return err
+		}
+		return warnings.Check(failOnWarnings)
+	}
+	buildStarted := time.Now()
 
 	// Step 4: Poll for task status
-	fmt.Println("[MONITOR] Monitoring image creation progress...")
-	return pollImageTask(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, uploadResp.Data.TaskID)
+	createdId, err := monitorImageTask(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, uploadData.TaskID, warnings, cleanupOnFailure, verbosePoll)
+	if err != nil {
+		// After a timeout the build may still succeed and use the reservation
+		if errors.Is(err, errImageTaskFailed) {
+			releaseImageReservation(apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, reservation)
+		}
+		return err
+	}
+	if reservation != nil {
+		fmt.Printf("[RESERVE] %s is reserved for the first activation of the image\n", FormatResourceSpec(reservation.CPU, reservation.Memory))
+	}
+	recordBuildDuration(sourceImageId, time.Since(buildStarted))
+	copyToClipboard(cmd, os.Stdout, "image ID", createdId)
+	return warnings.Check(failOnWarnings)
 }
 
 func runImageActivate(cmd *cobra.Command, args []string) error {
-	imageId := args[0]
+	imageId, byName := imageReferenceArg(cmd, args)
 	cpu, _ := cmd.Flags().GetInt("cpu")
 	memory, _ := cmd.Flags().GetInt("memory")
+	fastStart, _ := cmd.Flags().GetBool("fast-start")
+	verbosePoll, _ := cmd.Flags().GetBool("verbose-poll")
+	detach, _ := cmd.Flags().GetBool("detach")
+	leaseValue, _ := cmd.Flags().GetString("lease")
+	autoRenewals, _ := cmd.Flags().GetInt("auto-renew")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 	// Validate CPU and memory combination
 	if err := ValidateCPUMemoryCombo(cpu, memory); err != nil {
 		return err
 	}
+	if len(args) > 1 {
+		return runImageBatchCommand(cmd, args, ImageOperationOptions{Operation: ImageOperationActivate, CPU: cpu, Memory: memory, FastStart: fastStart})
+	}
+	var lease time.Duration
+	if leaseValue != "" {
+		var err error
+		if lease, err = ParseLease(leaseValue); err != nil {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] %v", err),
+				"",
+				"[NOTE] Example: agbcloud image activate img-7a8b9c1d0e --lease 2h",
+			)
+		}
+	}
+	if autoRenewals < 0 || (autoRenewals > 0 && lease == 0) {
+		return printErrorMessage(
+			"[ERROR] --auto-renew needs a lease and a positive number of renewals",
+			"",
+			"[NOTE] Example: agbcloud image activate img-7a8b9c1d0e --lease 2h --auto-renew 1",
+		)
+	}
+	if lease > 0 && detach {
+		return printErrorMessage(
+			"[ERROR] --lease cannot be used with --detach",
+			"",
+			"[NOTE] A lease is enforced by the CLI while a command watches it; nothing would end a detached lease on time",
+			"[TIP] Keep the command attached, or run 'agbcloud jobs enforce' on a schedule to end leases that nobody watches",
+		)
+	}
 
 	fmt.Printf("[>>] Activating image '%s'...\n", imageId)
 	if cpu > 0 || memory > 0 {
 		fmt.Printf("[SAVE] CPU: %d cores, Memory: %d GB\n", cpu, memory)
 	}
+	if fastStart {
+		fmt.Println("[NOTE] Fast start: a warm instance is requested, which is billed at a higher rate")
+	}
 
 	// Load configuration and check authentication
 	cfg, err := config.GetConfig()
@@ -358,90 +815,201 @@ func runImageActivate(cmd *cobra.Command, args []string) error {
 
 	// Create API client
 	apiClient := client.NewFromConfig(cfg)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Requests are bounded on their own; monitoring has its own timeout
+	monitorCtx := commandContext(cmd)
+	ctx, cancel := context.WithTimeout(monitorCtx, 30*time.Second)
 	defer cancel()
 
-	// Check current image status first
-	fmt.Println("[SEARCH] Checking current image status...")
-	listResp, httpResp, err := apiClient.ImageAPI.ListImages(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, "User", 1, 1, []string{imageId})
+	// The image may also be given by ID prefix, content digest or name
+	imageId, err = resolveImageArgument(ctx, os.Stdout, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, byName)
 	if err != nil {
-		if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
-			fmt.Printf("[ERROR] API Error: %s\n", apiErr.Error())
-			if httpResp != nil {
-				fmt.Printf("[DATA] Status Code: %d\n", httpResp.StatusCode)
-			}
-			return fmt.Errorf("failed to check image status: %s", apiErr.Error())
-		}
-		return fmt.Errorf("network error: %v", err)
+		return err
 	}
 
-	if !listResp.Success {
-		fmt.Printf("[SEARCH] Request ID: %s\n", listResp.RequestID)
-		return fmt.Errorf("failed to check image status: %s", listResp.Code)
+	// Check current image status first
+	fmt.Println("[SEARCH] Checking current image status...")
+	listResp, httpResp, err := apiClient.ImageAPI.ListImages(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{imageId}})
+	if err != nil {
+		return requestError(os.Stdout, "failed to check image status", httpResp, err)
 	}
 
-	// Check if image exists
+	// Check if image exists; System images are not listed among User images
 	if len(listResp.Data.Images) == 0 {
-		return fmt.Errorf("image not found: %s", imageId)
+		return imageNotFoundError(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId)
 	}
 
 	image := listResp.Data.Images[0]
+	if !dryRun {
+		recordRecentImage(imageId, image.ImageName, "activate")
+	}
 	currentStatus := image.Status
 	formattedStatus := FormatImageStatus(currentStatus)
 
 	fmt.Printf("[DATA] Current Status: %s\n", formattedStatus)
+	if image.SourceImageID != "" {
+		fmt.Printf("[DATA] Base Image: %s\n", FormatSourceImage(image))
+	}
+	if image.Digest != "" {
+		fmt.Printf("[DATA] Digest: %s\n", image.Digest)
+	}
+	if image.WarmInstances != nil {
+		fmt.Printf("[DATA] Warm Capacity: %s\n", FormatWarmCapacity(image.WarmInstances))
+		if fastStart && *image.WarmInstances == 0 {
+			fmt.Println("[WARN]  No warm instance is ready for this image; the fast start may be rejected")
+		}
+	}
+
+	// Without --cpu and --memory the defaults stored with 'image set-defaults' apply
+	if cpu == 0 && memory == 0 {
+		if defaultCPU, defaultMemory, ok := activationDefaultSpec(image); ok {
+			cpu, memory = defaultCPU, defaultMemory
+			fmt.Printf("[SAVE] CPU: %d cores, Memory: %d GB (default of the image)\n", cpu, memory)
+		}
+	}
+	if defaults := image.ActivationDefaults; defaults != nil && len(defaults.Env) > 0 {
+		fmt.Printf("[NOTE] The server sets %d default environment variable(s) of the image\n", len(defaults.Env))
+	}
+	// Checked after the defaults of the image apply, which the policy covers as well
+	if err := checkActivatePolicy(cmd, cfg, imageId, cpu, memory); err != nil {
+		return err
+	}
 
 	// Handle different current statuses
-	switch currentStatus {
-	case "RESOURCE_PUBLISHED":
+	switch imagestatus.Status(currentStatus) {
+	case imagestatus.Published:
 		fmt.Printf("[OK] Image is already activated! Image ID: %s\n", imageId)
 		fmt.Printf("[DATA] Status: %s\n", formattedStatus)
-		return nil
-	case "RESOURCE_DEPLOYING":
+		if dryRun {
+			if lease > 0 {
+				fmt.Printf("[DRY-RUN] A lease of %s would start; no request would be sent\n", lease)
+			} else {
+				fmt.Println("[DRY-RUN] No request would be sent")
+			}
+			return nil
+		}
+		if lease == 0 {
+			return nil
+		}
+		leaseJob, err := startLease(imageId, image.ImageName, lease, autoRenewals)
+		if err != nil {
+			return err
+		}
+		return watchAttachedLease(monitorCtx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, leaseJob)
+	case imagestatus.Deploying:
+		if dryRun {
+			fmt.Println("[REFRESH] Image is already activating")
+			fmt.Println("[DRY-RUN] The activation in progress would be monitored; no request would be sent")
+			return nil
+		}
+		if detach {
+			fmt.Println("[REFRESH] Image is already activating")
+			return detachJob(Job{Type: JobActivate, ImageID: imageId, ImageName: image.ImageName})
+		}
 		fmt.Printf("[REFRESH] Image is already activating, joining the activation process...\n")
-		fmt.Println("[MONITOR] Monitoring image activation status...")
-		return pollImageActivationStatus(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId)
-	case "RESOURCE_FAILED", "RESOURCE_CEASED":
+		return monitorActivation(monitorCtx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, image.ImageName, verbosePoll, lease, autoRenewals)
+	case imagestatus.Failed, imagestatus.Ceased:
 		fmt.Printf("[WARN]  Image is in failed state (%s), attempting to restart activation...\n", formattedStatus)
-	case "IMAGE_AVAILABLE":
+	case imagestatus.Available:
 		fmt.Printf("[OK] Image is available, proceeding with activation...\n")
 	default:
 		fmt.Printf("[DATA] Image status: %s, proceeding with activation...\n", formattedStatus)
 	}
 
-	// Call StartImage API
-	fmt.Println("[REFRESH] Starting image activation...")
-	startResp, httpResp, err := apiClient.ImageAPI.StartImage(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, cpu, memory)
-	if err != nil {
-		if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
-			fmt.Printf("[ERROR] API Error: %s\n", apiErr.Error())
-			if httpResp != nil {
-				fmt.Printf("[DATA] Status Code: %d\n", httpResp.StatusCode)
-			}
-			return fmt.Errorf("failed to start image: %s", apiErr.Error())
+	if dryRun {
+		if !imagestatus.Status(currentStatus).CanActivate() {
+			fmt.Printf("[WARN]  An image in status %s cannot be activated yet; the server may reject the request\n", formattedStatus)
 		}
-		return fmt.Errorf("network error: %v", err)
+		if cpu == 0 && memory == 0 {
+			fmt.Println("[NOTE] No CPU and memory are requested; the server's default resources would be used")
+		}
+		request := client.NewImageStartRequest(cfg.Token.LoginToken, cfg.Token.SessionId, imageId, cpu, memory, fastStart)
+		if err := printDryRunRequest(ctx, os.Stdout, apiClient, "StartImage", "/api/image/start", request); err != nil {
+			return err
+		}
+		if lease > 0 {
+			fmt.Printf("[DRY-RUN] The image would be deactivated after a lease of %s\n", lease)
+		}
+		fmt.Println("[DRY-RUN] The image was not activated; run the command without --dry-run to activate it")
+		return nil
 	}
 
-	if !startResp.Success {
-		fmt.Printf("[SEARCH] Request ID: %s\n", startResp.RequestID)
-		return fmt.Errorf("failed to start image: %s", startResp.Code)
+	// Call StartImage API
+	fmt.Println("[REFRESH] Starting image activation...")
+	startResp, httpResp, err := apiClient.ImageAPI.StartImage(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, cpu, memory, fastStart)
+	if err != nil {
+		return requestError(os.Stdout, "failed to start image", httpResp, err)
 	}
 
 	// Display success information
 	fmt.Printf("[OK] Image activation initiated successfully!\n")
-	fmt.Printf("[DATA] Operation Status: %v\n", startResp.Data)
-	fmt.Printf("[SEARCH] Request ID: %s\n", startResp.RequestID)
+	printDetail(verbosity.Requests, "[DATA] Operation Status: %v\n", startResp.Data)
+	printDetail(verbosity.Requests, "[SEARCH] Request ID: %s\n", startResp.RequestID)
+	if detach {
+		return detachJob(Job{Type: JobActivate, ImageID: imageId, ImageName: image.ImageName})
+	}
 
 	// Start status polling
+	return monitorActivation(monitorCtx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, image.ImageName, verbosePoll, lease, autoRenewals)
+}
+
+// monitorActivation polls the activation of an image until it ends. With a lease the
+// lease starts when the activation does, and is watched once the image is activated.
+func monitorActivation(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, imageId, imageName string, verbosePoll bool, lease time.Duration, autoRenewals int) error {
+	var leaseJob Job
+	if lease > 0 {
+		var err error
+		if leaseJob, err = startLease(imageId, imageName, lease, autoRenewals); err != nil {
+			return err
+		}
+	}
 	fmt.Println("[MONITOR] Monitoring image activation status...")
-	return pollImageActivationStatus(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId)
+	if err := pollImageActivationStatus(ctx, apiClient, loginToken, sessionId, imageId, verbosePoll); err != nil || lease == 0 {
+		return err
+	}
+	return watchAttachedLease(ctx, apiClient, loginToken, sessionId, leaseJob)
+}
+
+// imageNotFoundError explains why imageId cannot be activated. System images cannot be
+// activated directly, only custom images built from them, so a System image ID gets a
+// hint on creating one instead of a plain "image not found". The System image list is
+// taken from the cache when it is recent enough.
+func imageNotFoundError(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, imageId string) error {
+	images, _, err := SystemImageList{APIClient: apiClient, LoginToken: loginToken, SessionID: sessionId}.Images(ctx)
+	// Anything but a confirmed System image keeps the plain error
+	if err != nil || findImageByID(images, imageId) == nil {
+		return fmt.Errorf("image not found: %s", imageId)
+	}
+
+	return printErrorMessage(
+		fmt.Sprintf("[ERROR] '%s' is a System image, which cannot be activated directly", imageId),
+		"",
+		"[TIP] Create a custom image from it and activate that instead:",
+		fmt.Sprintf("  agbcloud image create <image-name> --dockerfile ./Dockerfile --imageId %s", imageId),
+		"[TIP] Run 'agbcloud image list' to see your custom images",
+	)
 }
 
 func runImageDeactivate(cmd *cobra.Command, args []string) error {
-	imageId := args[0]
+	imageId, byName := imageReferenceArg(cmd, args)
+	verbosePoll, _ := cmd.Flags().GetBool("verbose-poll")
+	grace, _ := cmd.Flags().GetDuration("wait-grace")
+	force, _ := cmd.Flags().GetBool("force")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	if err := ValidateDeactivateGrace(grace, force); err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		return runImageBatchCommand(cmd, args, ImageOperationOptions{Operation: ImageOperationDeactivate, Stop: client.ImageStopOptions{GracePeriod: grace, Force: force}})
+	}
 
 	fmt.Printf("[STOP] Deactivating image '%s'...\n", imageId)
+	switch {
+	case force:
+		fmt.Println("[WARN]  --force: the instance is stopped at once, without a grace period")
+	case grace > 0:
+		fmt.Printf("[WAIT] The workload has %s to shut down\n", grace)
+	}
 
 	// Load configuration and check authentication
 	cfg, err := config.GetConfig()
@@ -455,44 +1023,133 @@ func runImageDeactivate(cmd *cobra.Command, args []string) error {
 
 	// Create API client
 	apiClient := client.NewFromConfig(cfg)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Requests are bounded on their own; monitoring has its own timeout
+	monitorCtx := commandContext(cmd)
+	ctx, cancel := context.WithTimeout(monitorCtx, 30*time.Second)
 	defer cancel()
 
-	// Call StopImage API
-	fmt.Println("[REFRESH] Deactivating image instance...")
-	stopResp, httpResp, err := apiClient.ImageAPI.StopImage(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, imageId)
+	// The image may also be given by ID prefix, content digest or name
+	imageId, err = resolveImageArgument(ctx, os.Stdout, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, byName)
 	if err != nil {
-		if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
-			fmt.Printf("[ERROR] API Error: %s\n", apiErr.Error())
-			if httpResp != nil {
-				fmt.Printf("[DATA] Status Code: %d\n", httpResp.StatusCode)
-			}
-			return fmt.Errorf("failed to deactivate image: %s", apiErr.Error())
-		}
-		return fmt.Errorf("network error: %v", err)
+		return err
+	}
+
+	if dryRun {
+		return dryRunImageDeactivate(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, client.ImageStopOptions{GracePeriod: grace, Force: force})
 	}
 
-	if !stopResp.Success {
-		fmt.Printf("[SEARCH] Request ID: %s\n", stopResp.RequestID)
-		return fmt.Errorf("failed to deactivate image: %s", stopResp.Code)
+	// Call StopImage API
+	fmt.Println("[REFRESH] Deactivating image instance...")
+	stopResp, httpResp, err := apiClient.ImageAPI.StopImage(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, client.ImageStopOptions{GracePeriod: grace, Force: force})
+	if err != nil {
+		return requestError(os.Stdout, "failed to deactivate image", httpResp, err)
 	}
+	countdown := newGraceCountdown(grace, time.Now())
+	recordRecentImage(imageId, "", "deactivate")
+	endLeases(imageId)
 
 	// Display success information
 	fmt.Printf("[OK] Image deactivation initiated successfully!\n")
-	fmt.Printf("[DATA] Operation Status: %v\n", stopResp.Data)
-	fmt.Printf("[SEARCH] Request ID: %s\n", stopResp.RequestID)
+	printDetail(verbosity.Requests, "[DATA] Operation Status: %v\n", stopResp.Data)
+	printDetail(verbosity.Requests, "[SEARCH] Request ID: %s\n", stopResp.RequestID)
 
 	// Start status polling
 	fmt.Println("[MONITOR] Monitoring image deactivation status...")
-	return pollImageDeactivationStatus(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId)
+	return pollImageDeactivationStatus(monitorCtx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, verbosePoll, countdown)
+}
+
+// ImageListItem is the structured (json/csv/pson) representation of an image in `image list`
+type ImageListItem struct {
+	ImageID    string `json:"imageId"`
+	ImageName  string `json:"imageName"`
+	Status     string `json:"status"`
+	Type       string `json:"type"`
+	CPU        *int   `json:"cpu"`
+	Memory     *int   `json:"memory"`
+	UpdateTime string `json:"updateTime"`
+	// SourceImageID is the System image a User image was built from
+	SourceImageID string `json:"sourceImageId"`
+	// Digest is the content digest of the image, empty if the backend did not report one
+	Digest string `json:"digest"`
+	// WarmInstances is the number of warm instances ready for a fast start, null if unknown
+	WarmInstances *int `json:"warmInstances"`
+	// Pinned is set for images pinned with 'image pin', which are listed first
+	Pinned bool `json:"pinned"`
+}
+
+// NewImageListItems converts API image information into structured list output
+func NewImageListItems(images []client.ImageInfo) []ImageListItem {
+	items := make([]ImageListItem, 0, len(images))
+	for _, image := range images {
+		items = append(items, ImageListItem{
+			ImageID:       image.ImageID,
+			ImageName:     image.ImageName,
+			Status:        image.Status,
+			Type:          image.Type,
+			CPU:           image.CPU,
+			Memory:        image.Memory,
+			SourceImageID: image.SourceImageID,
+			UpdateTime:    image.UpdateTime,
+			Digest:        image.Digest,
+			WarmInstances: image.WarmInstances,
+		})
+	}
+	return items
 }
 
 func runImageList(cmd *cobra.Command, args []string) error {
 	imageType, _ := cmd.Flags().GetString("type")
 	page, _ := cmd.Flags().GetInt("page")
 	pageSize, _ := cmd.Flags().GetInt("size")
+	search, _ := cmd.Flags().GetString("search")
+	search = strings.TrimSpace(search)
+	all, _ := cmd.Flags().GetBool("all")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	cached, _ := cmd.Flags().GetBool("cached")
+	watch, _ := cmd.Flags().GetBool("watch")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	imageType, pageSize = applyListDefaults(cmd, imageType, pageSize)
+	// Long lists are paged on a terminal; progress goes through the pager as well
+	defer startPager()()
+
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if err := applyTimeFormat(cmd); err != nil {
+		return err
+	}
+	progress := progressWriter(outputFormat)
+	if quiet {
+		// Keep stdout to the IDs so it can be piped into other commands
+		progress = os.Stderr
+	}
 
-	fmt.Printf("[DOC] Listing %s images (Page %d, Size %d)...\n", imageType, page, pageSize)
+	if watch {
+		if err := validateListWatch(outputFormat, all, quiet, cached); err != nil {
+			return err
+		}
+		if interval < imageListWatchMinInterval {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Invalid --interval value: %s", interval),
+				"",
+				fmt.Sprintf("[TIP] The interval must be at least %s", imageListWatchMinInterval),
+			)
+		}
+	}
+	if cached {
+		return runCachedImageList(progress, outputFormat, imageType, search, quiet)
+	}
+
+	scope := fmt.Sprintf("(Page %d, Size %d)", page, pageSize)
+	if all {
+		scope = "(all pages)"
+	}
+	if search != "" {
+		fmt.Fprintf(progress, "[DOC] Listing %s images matching '%s' %s...\n", imageType, search, scope)
+	} else {
+		fmt.Fprintf(progress, "[DOC] Listing %s images %s...\n", imageType, scope)
+	}
 
 	// Load configuration and check authentication
 	cfg, err := config.GetConfig()
@@ -506,286 +1163,409 @@ func runImageList(cmd *cobra.Command, args []string) error {
 
 	// Create API client
 	apiClient := client.NewFromConfig(cfg)
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
 	defer cancel()
 
-	// Call ListImages API
-	fmt.Println("[SEARCH] Fetching image list...")
-	listResp, httpResp, err := apiClient.ImageAPI.ListImages(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, imageType, page, pageSize, nil)
-	if err != nil {
-		if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
-			fmt.Printf("[ERROR] API Error: %s\n", apiErr.Error())
-			if httpResp != nil {
-				fmt.Printf("[DATA] Status Code: %d\n", httpResp.StatusCode)
-			}
-			return fmt.Errorf("failed to list images: %s", apiErr.Error())
-		}
-		return fmt.Errorf("network error: %v", err)
+	options := client.ImageListOptions{
+		ImageType:    imageType,
+		Page:         page,
+		PageSize:     pageSize,
+		NameContains: search,
 	}
 
-	if !listResp.Success {
-		fmt.Printf("[SEARCH] Request ID: %s\n", listResp.RequestID)
-		return fmt.Errorf("failed to list images: %s", listResp.Code)
+	if watch {
+		// Ctrl-C stops watching
+		lister := newConditionalImageList(apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, options)
+		return watchImageList(commandContext(cmd), progress, outputFormat, lister, search, interval)
 	}
 
-	// Display results
-	fmt.Printf("[OK] Found %d images (Total: %d)\n", len(listResp.Data.Images), listResp.Data.Total)
-	fmt.Printf("[PAGE] Page %d of %d (Page Size: %d)\n\n", listResp.Data.Page, (listResp.Data.Total+listResp.Data.PageSize-1)/listResp.Data.PageSize, listResp.Data.PageSize)
-
-	if len(listResp.Data.Images) == 0 {
-		fmt.Println("[EMPTY] No images found.")
-		return nil
+	fmt.Fprintln(progress, "[SEARCH] Fetching image list...")
+	var images []client.ImageInfo
+	var listData client.ImageListData
+	if all {
+		images, err = listAllImages(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, options)
+		if err != nil {
+			if !serverAnswered(err) {
+				printCachedListTip(imageType)
+			}
+			return fmt.Errorf("failed to list images: %w", err)
+		}
+	} else {
+		listResp, httpResp, err := apiClient.ImageAPI.ListImages(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, options)
+		if err != nil {
+			listErr := requestError(progress, "failed to list images", httpResp, err)
+			if !serverAnswered(err) {
+				printCachedListTip(imageType)
+			}
+			return listErr
+		}
+		listData = listResp.Data
+		images = listData.Images
 	}
-
-	// Display image table with CPU/Memory information
-	fmt.Printf("%-25s %-25s %-20s %-15s %-12s %-20s\n", "IMAGE ID", "IMAGE NAME", "STATUS", "TYPE", "CPU/MEMORY", "UPDATED AT")
-	fmt.Printf("%-25s %-25s %-20s %-15s %-12s %-20s\n", "--------", "----------", "------", "----", "----------", "----------")
-
-	for _, image := range listResp.Data.Images {
-		fmt.Printf("%-25s %-25s %-20s %-15s %-12s %-20s\n",
-			truncateString(image.ImageID, 25),
-			truncateString(image.ImageName, 25),
-			FormatImageStatus(image.Status),
-			truncateString(image.Type, 15),
-			FormatResources(image.CPU, image.Memory),
-			formatTimestamp(image.UpdateTime))
+	if outputFormat == output.FormatWide && imageType == "User" {
+		addImageUsage(ctx, progress, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, images)
 	}
 
-	return nil
+	total := listData.Total
+	if all {
+		total = len(images)
+	}
+	cacheImageList(CachedImageList{
+		ImageType: imageType,
+		Search:    search,
+		All:       all,
+		Page:      listData.Page,
+		PageSize:  listData.PageSize,
+		Total:     total,
+		Images:    images,
+	})
+
+	return printImageList(outputFormat, images, listData, all, search, quiet)
 }
 
-// uploadDockerfile uploads the dockerfile content to the provided OSS URL with retry mechanism
-func uploadDockerfile(dockerfilePath, ossURL string) error {
-	// Read dockerfile content
-	content, err := os.ReadFile(dockerfilePath)
+// runCachedImageList shows the last list result fetched for the image type without
+// contacting the server. A search is applied to the cached images locally.
+func runCachedImageList(progress io.Writer, outputFormat output.Format, imageType, search string, quiet bool) error {
+	profile, endpoint := imageListCacheScope()
+	entry, err := LoadCachedImageList(profile, endpoint, imageType)
 	if err != nil {
-		return fmt.Errorf("failed to read dockerfile: %w", err)
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Could not read the cached image list: %v", err),
+			"",
+			"[TIP] Run 'agbcloud image list' while online to cache the list again",
+		)
 	}
-
-	// Create retry configuration for upload
-	retryConfig := &client.RetryConfig{
-		MaxRetries:    3,
-		InitialDelay:  1 * time.Second,
-		MaxDelay:      10 * time.Second,
-		BackoffFactor: 2.0,
+	if entry == nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] No cached list of %s images for %s", imageType, endpoint),
+			"",
+			fmt.Sprintf("[TIP] Run 'agbcloud image list --type %s' while online; every successful list is cached", imageType),
+		)
 	}
 
-	var lastErr error
-	delay := retryConfig.InitialDelay
+	fmt.Fprintf(progress, "[CACHED] Showing %s images fetched %s (%s, %s)\n",
+		entry.ImageType, formatRelativeTime(time.Since(entry.FetchedAt)), inDisplayZone(entry.FetchedAt).Format("2006-01-02 15:04"), describeCachedQuery(entry))
+	fmt.Fprintln(progress, "[NOTE] The server was not contacted; statuses may have changed since. Run without --cached to refresh")
 
-	for attempt := 0; attempt <= retryConfig.MaxRetries; attempt++ {
-		fmt.Printf("[UPLOAD] Dockerfile upload attempt %d/%d...\n", attempt+1, retryConfig.MaxRetries+1)
+	images := entry.Images
+	listData := client.ImageListData{Images: images, Total: entry.Total, Page: entry.Page, PageSize: entry.PageSize}
+	if search != "" {
+		images = filterImagesByName(images, search)
+		fmt.Fprintf(progress, "[SEARCH] %d of %d cached images match '%s'\n", len(images), len(entry.Images), search)
+	}
+	// Without page information the result is shown like an --all listing
+	all := entry.All || search != "" || entry.PageSize <= 0
+	return printImageList(outputFormat, images, listData, all, search, quiet)
+}
 
-		// Create HTTP PUT request for each attempt
-		req, err := http.NewRequest(http.MethodPut, ossURL, strings.NewReader(string(content)))
-		if err != nil {
-			return fmt.Errorf("failed to create upload request: %w", err)
+// printImageList renders a list result as IDs, a structured document or a table
+func printImageList(outputFormat output.Format, images []client.ImageInfo, listData client.ImageListData, all bool, search string, quiet bool) error {
+	pinned := pinnedImageIDs()
+	images = SortPinnedFirst(images, pinned)
+	if quiet {
+		for _, image := range images {
+			fmt.Fprintln(resultOutput(), image.ImageID)
 		}
+		return nil
+	}
 
-		// Set appropriate headers
-		req.Header.Set("Content-Type", "application/octet-stream")
-		req.ContentLength = int64(len(content))
-
-		// Execute the upload
-		httpClient := &http.Client{Timeout: 60 * time.Second}
-		resp, err := httpClient.Do(req)
-
-		// Success case
-		if err == nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			resp.Body.Close()
-			if attempt > 0 {
-				fmt.Printf("[OK] Dockerfile upload succeeded on attempt %d\n", attempt+1)
-			}
-			return nil
+	// Structured output carries only the result document on stdout
+	if outputFormat.IsStructured() {
+		items := NewImageListItems(images)
+		for i := range items {
+			items[i].Pinned = slices.Contains(pinned, items[i].ImageID)
 		}
+		return writeResult(outputFormat, items)
+	}
 
-		// Handle error cases
-		if err != nil {
-			lastErr = fmt.Errorf("failed to upload dockerfile: %w", err)
+	// Display results
+	if all {
+		fmt.Printf("[OK] Found %d images\n\n", len(images))
+	} else {
+		fmt.Printf("[OK] Found %d images (Total: %d)\n", len(images), listData.Total)
+		fmt.Printf("[PAGE] Page %d of %d (Page Size: %d)\n\n", listData.Page, (listData.Total+listData.PageSize-1)/listData.PageSize, listData.PageSize)
+	}
+
+	if len(images) == 0 {
+		if search != "" {
+			fmt.Printf("[EMPTY] No images found matching '%s'.\n", search)
 		} else {
-			// Read response body for error details
-			body, _ := io.ReadAll(resp.Body)
-			resp.Body.Close()
-			lastErr = fmt.Errorf("upload failed with status %d: %s", resp.StatusCode, string(body))
+			fmt.Println("[EMPTY] No images found.")
 		}
+		return nil
+	}
 
-		// Don't retry if this is the last attempt
-		if attempt == retryConfig.MaxRetries {
-			break
+	// Display image table with CPU/Memory and base image information
+	wide := outputFormat == output.FormatWide
+	pinnedShown := false
+	rows := make([][]string, 0, len(images))
+	for _, image := range images {
+		name := image.ImageName
+		if slices.Contains(pinned, image.ImageID) {
+			name = "* " + name
+			pinnedShown = true
 		}
-
-		// Check if the error is retryable
-		shouldRetry := false
-		if err != nil {
-			// Use the same retry logic as the API client
-			shouldRetry = client.IsRetryableError(err)
-		} else if resp != nil {
-			// Check if HTTP status is retryable
-			shouldRetry = client.IsRetryableHTTPStatus(resp.StatusCode)
+		row := []string{
+			image.ImageID,
+			name,
+			FormatImageStatus(image.Status),
+			image.Type,
+			FormatSourceImage(image),
+			FormatResources(image.CPU, image.Memory),
+			FormatWarmCapacity(image.WarmInstances),
+			ShortDigest(image.Digest),
+			formatTimestamp(image.UpdateTime),
 		}
-
-		if !shouldRetry {
-			fmt.Printf("[WARN]  Upload error is not retryable, stopping attempts\n")
-			break
+		if wide {
+			lastUsed, activeHours, activations := FormatImageUsage(image)
+			row = append(row, lastUsed, activeHours, activations)
 		}
+		rows = append(rows, row)
+	}
+	columns := imageListColumns
+	if wide {
+		columns = append(slices.Clip(columns), imageUsageColumns...)
+	}
+	printTable(os.Stdout, columns, rows)
+	if pinnedShown {
+		fmt.Println("\n[PIN] * Pinned images are listed first; unpin with 'agbcloud image unpin <image-id>'")
+	}
 
-		// Wait before retrying
-		fmt.Printf("[RETRY] Upload failed (attempt %d/%d), retrying in %v...\n",
-			attempt+1, retryConfig.MaxRetries+1, delay)
+	return nil
+}
 
-		time.Sleep(delay)
+// imageListColumns are the columns of the image list table
+var imageListColumns = []TableColumn{
+	{Header: "IMAGE ID", Width: 25, Truncate: true},
+	{Header: "IMAGE NAME", Width: 25, Truncate: true},
+	{Header: "STATUS", Width: 20},
+	{Header: "TYPE", Width: 15, Truncate: true},
+	{Header: "BASE IMAGE", Width: 25, Truncate: true},
+	{Header: "CPU/MEMORY", Width: 12},
+	{Header: "WARM", Width: 8},
+	{Header: "DIGEST", Width: 12},
+	{Header: "UPDATED AT", Width: 20},
+}
 
-		// Calculate next delay with exponential backoff
-		delay = time.Duration(float64(delay) * retryConfig.BackoffFactor)
-		if delay > retryConfig.MaxDelay {
-			delay = retryConfig.MaxDelay
+// FormatWarmCapacity renders the warm instances ready for a fast start: "-" when the
+// backend does not report them, "none" or e.g. "2 ready"
+func FormatWarmCapacity(warm *int) string {
+	switch {
+	case warm == nil:
+		return "-"
+	case *warm <= 0:
+		return "none"
+	default:
+		return fmt.Sprintf("%d ready", *warm)
+	}
+}
+
+// FormatSourceImage renders the System image a User image was built from, with the
+// base version when known, or "-" for images without a source image
+func FormatSourceImage(image client.ImageInfo) string {
+	switch {
+	case image.SourceImageID == "":
+		return "-"
+	case image.SourceImageVersion != "":
+		return fmt.Sprintf("%s@%s", image.SourceImageID, image.SourceImageVersion)
+	default:
+		return image.SourceImageID
+	}
+}
+
+// Upload credential validity thresholds
+const (
+	// uploadCredentialMinValidity is the minimum remaining validity required to start an upload
+	uploadCredentialMinValidity = 30 * time.Second
+	// uploadCredentialWarnThreshold is the remaining validity below which a warning is printed
+	uploadCredentialWarnThreshold = 2 * time.Minute
+)
+
+// requestUploadCredential obtains a presigned Dockerfile upload URL and a task ID
+func requestUploadCredential(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string, warnings *WarningRecorder) (client.ImageUploadCredentialData, error) {
+	fmt.Println("[SIGNAL] Getting upload credentials...")
+	uploadResp, httpResp, err := apiClient.ImageAPI.GetUploadCredential(ctx, loginToken, sessionId)
+	if err != nil {
+		return uploadResp.Data, requestError(os.Stdout, "failed to get upload credentials", httpResp, err)
+	}
+
+	fmt.Printf("[OK] Upload credentials obtained (Task ID: %s)\n", uploadResp.Data.TaskID)
+
+	if expiresAt, ok := uploadResp.Data.ExpiresAt(); ok {
+		remaining := time.Until(expiresAt).Round(time.Second)
+		printDetail(verbosity.Requests, "[TIME] Upload URL valid until %s (%v remaining)\n", inDisplayZone(expiresAt).Format("15:04:05"), remaining)
+		if remaining < uploadCredentialWarnThreshold {
+			warnings.Warn("Upload credentials expire in %v", remaining)
 		}
 	}
 
-	fmt.Printf("[ERROR] All %d upload attempts failed\n", retryConfig.MaxRetries+1)
-	return fmt.Errorf("dockerfile upload failed after %d attempts, last error: %w",
-		retryConfig.MaxRetries+1, lastErr)
+	return uploadResp.Data, nil
 }
 
-// pollImageTask polls the image task status until completion or failure
-func pollImageTask(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, taskId string) error {
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
+// UploadError is returned when the storage service rejects a Dockerfile upload
+type UploadError struct {
+	StatusCode int
+	Body       string
+	// ClockSkew is how far the local clock was ahead of the storage service, when ClockSkewKnown
+	ClockSkew      time.Duration
+	ClockSkewKnown bool
+	// RetryAfter is the wait the storage service asked for before the next attempt, if any
+	RetryAfter time.Duration
+}
 
-	for {
-		select {
-		case <-ctx.Done():
-			fmt.Printf("[DOC] Task ID: %s\n", taskId)
-			return fmt.Errorf("timeout waiting for image creation to complete")
-		case <-ticker.C:
-			taskResp, httpResp, err := apiClient.ImageAPI.GetImageTask(ctx, loginToken, sessionId, taskId)
-			if err != nil {
-				if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
-					fmt.Printf("[WARN]  Warning: Failed to check task status: %s\n", apiErr.Error())
-					if httpResp != nil {
-						fmt.Printf("[DATA] Status Code: %d\n", httpResp.StatusCode)
-					}
-					fmt.Printf("[DOC] Task ID: %s\n", taskId)
-					continue // Continue polling on API errors
-				}
-				fmt.Printf("[DOC] Task ID: %s\n", taskId)
-				return fmt.Errorf("network error checking task status: %v", err)
-			}
+// Error implements the error interface
+func (e *UploadError) Error() string {
+	message := fmt.Sprintf("upload failed with status %d: %s", e.StatusCode, e.Body)
+	if e.ClockSkewKnown && client.ClockSkewExceeds(e.ClockSkew, client.DefaultClockSkewThreshold) {
+		message += fmt.Sprintf(" (local clock is %s the storage service; clock skew can cause SignatureDoesNotMatch)",
+			client.DescribeClockSkew(e.ClockSkew))
+	}
+	return message
+}
 
-			if !taskResp.Success {
-				fmt.Printf("[WARN]  Warning: Task status check failed: %s\n", taskResp.Code)
-				fmt.Printf("[DOC] Task ID: %s\n", taskId)
-				fmt.Printf("[SEARCH] Request ID: %s\n", taskResp.RequestID)
-				continue // Continue polling on API errors
-			}
+// RetryAfterHint implements client.RetryAfterHint, so that retries wait as long as the
+// storage service asked
+func (e *UploadError) RetryAfterHint() time.Duration {
+	return e.RetryAfter
+}
 
-			status := taskResp.Data.Status
-			message := taskResp.Data.TaskMsg
+// IsUploadCredentialExpiredError reports whether an upload failed because the presigned URL
+// expired or its signature was no longer accepted
+func IsUploadCredentialExpiredError(err error) bool {
+	var uploadErr *UploadError
+	if !errors.As(err, &uploadErr) {
+		return false
+	}
+	if uploadErr.StatusCode != http.StatusForbidden && uploadErr.StatusCode != http.StatusBadRequest {
+		return false
+	}
 
-			fmt.Printf("[DATA] Status: %s", status)
-			if message != "" {
-				fmt.Printf(" - %s", message)
-			}
-			fmt.Println()
-
-			switch status {
-			case "Finished":
-				if taskResp.Data.ImageID != nil {
-					fmt.Printf("[SUCCESS] Image created successfully! Image ID: %s\n", *taskResp.Data.ImageID)
-				} else {
-					fmt.Println("[SUCCESS] Image created successfully!")
-				}
-				return nil
-			case "Failed":
-				fmt.Printf("[DOC] Task ID: %s\n", taskId)
-				fmt.Printf("[SEARCH] Request ID: %s\n", taskResp.RequestID)
-				return fmt.Errorf("image creation failed: %s", message)
-			case "Inline":
-				// Continue polling - waiting for processing
-				continue
-			case "Preparing":
-				// Continue polling - processing in progress
-				continue
-			default:
-				fmt.Printf("[REFRESH] Unknown status '%s', continuing to monitor...\n", status)
-				continue
-			}
+	body := strings.ToLower(uploadErr.Body)
+	for _, marker := range []string{"signaturedoesnotmatch", "request has expired", "expired"} {
+		if strings.Contains(body, marker) {
+			return true
 		}
 	}
+	return false
+}
+
+// uploadRetryConfig is how failed Dockerfile uploads are retried. A Retry-After wait
+// asked for by the storage service replaces the backoff, up to MaxRetryAfter.
+var uploadRetryConfig = client.RetryConfig{
+	MaxRetries:    3,
+	InitialDelay:  1 * time.Second,
+	MaxDelay:      10 * time.Second,
+	BackoffFactor: 2.0,
+	MaxRetryAfter: 30 * time.Second,
 }
 
-// pollImageDeactivationStatus polls the image deactivation status until completion or failure
-func pollImageDeactivationStatus(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, imageId string) error {
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
+// uploadDockerfile uploads the dockerfile content to the storage service described by the
+// upload credentials, with retry mechanism. Failed attempts that are retried are recorded
+// as warnings. Multipart uploads continue from journal when it belongs to the same
+// credentials, and are journaled so that an interrupted upload can be resumed.
+func uploadDockerfile(ctx context.Context, dockerfilePath string, credential client.ImageUploadCredentialData, storage *config.UploadStorage, journal *UploadJournal, warnings *WarningRecorder) error {
+	// Read dockerfile content
+	content, err := os.ReadFile(dockerfilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read dockerfile: %w", err)
+	}
+
+	uploader, err := NewDockerfileUploader(credential, storage)
+	if err != nil {
+		return err
+	}
+	if journal == nil || journal.Credential.TaskID != credential.TaskID || journal.ContentDigest != contentDigest(content) {
+		journal = NewUploadJournal(content, credential)
+	}
+	uploader = WithUploadJournal(uploader, journal)
 
-	// Create a new context with longer timeout for polling
-	pollCtx, pollCancel := context.WithTimeout(context.Background(), 45*time.Minute)
-	defer pollCancel()
+	err = UploadWithRetry(ctx, uploader, content, &uploadRetryConfig, warnings)
+	if err == nil {
+		journal.Remove()
+		return nil
+	}
 
-	for {
-		select {
-		case <-pollCtx.Done():
-			fmt.Printf("[DOC] Image ID: %s\n", imageId)
-			return fmt.Errorf("timeout waiting for image deactivation to complete")
-		case <-ticker.C:
-			// Query specific image status using ListImages with imageIds filter
-			listResp, httpResp, err := apiClient.ImageAPI.ListImages(pollCtx, loginToken, sessionId, "User", 1, 1, []string{imageId})
-			if err != nil {
-				if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
-					fmt.Printf("[WARN]  Warning: Failed to check image status: %s\n", apiErr.Error())
-					if httpResp != nil {
-						fmt.Printf("[DATA] Status Code: %d\n", httpResp.StatusCode)
-					}
-					fmt.Printf("[DOC] Image ID: %s\n", imageId)
-					continue // Continue polling on API errors
-				}
-				fmt.Printf("[DOC] Image ID: %s\n", imageId)
-				return fmt.Errorf("network error checking image status: %v", err)
-			}
+	// Presigned URLs the storage service rejected cannot be resumed with
+	var uploadErr *UploadError
+	if errors.As(err, &uploadErr) && !client.IsRetryableHTTPStatus(uploadErr.StatusCode) {
+		journal.Remove()
+	} else if len(journal.Parts) > 0 {
+		fmt.Println("[TIP] Run the same command again to resume the upload from the parts already stored")
+	}
+	return err
+}
 
-			if !listResp.Success {
-				fmt.Printf("[WARN]  Warning: Image status check failed: %s\n", listResp.Code)
-				fmt.Printf("[DOC] Image ID: %s\n", imageId)
-				fmt.Printf("[SEARCH] Request ID: %s\n", listResp.RequestID)
-				continue // Continue polling on API errors
+// UploadWithRetry uploads content with uploader, retrying failed attempts with the
+// client retry engine. Each retried failure is recorded as a warning with the time the
+// attempt took; retries wait as long as the storage service asks with Retry-After.
+func UploadWithRetry(ctx context.Context, uploader DockerfileUploader, content []byte, retryConfig *client.RetryConfig, warnings *WarningRecorder) error {
+	maxAttempts := retryConfig.MaxRetries + 1
+	attempts, err := client.Retry(ctx, retryConfig, client.RetryOptions{
+		Retryable: func(err error) bool {
+			if !isRetryableUploadError(err) {
+				fmt.Printf("[WARN]  Upload error is not retryable, stopping attempts\n")
+				return false
 			}
-
-			// Check if we found the image
-			if len(listResp.Data.Images) == 0 {
-				fmt.Printf("[WARN]  Warning: Image not found: %s\n", imageId)
-				continue // Continue polling
+			return true
+		},
+		OnRetry: func(attempt client.RetryAttempt) {
+			warnings.Warn("Upload attempt %d/%d failed after %v: %v", attempt.Attempt, attempt.MaxAttempts, attempt.Latency.Round(time.Millisecond), describeUploadError(attempt.Err))
+			if attempt.RetryAfter {
+				fmt.Printf("[RETRY] Upload failed (attempt %d/%d), retrying in %v as requested by the storage service...\n",
+					attempt.Attempt, attempt.MaxAttempts, attempt.Delay)
+			} else {
+				fmt.Printf("[RETRY] Upload failed (attempt %d/%d), retrying in %v...\n",
+					attempt.Attempt, attempt.MaxAttempts, attempt.Delay)
 			}
+		},
+	}, func(attempt int) error {
+		// The first attempt is only worth mentioning with -v
+		if attempt > 1 {
+			fmt.Printf("[UPLOAD] Dockerfile upload attempt %d/%d...\n", attempt, maxAttempts)
+		} else {
+			printDetail(verbosity.Requests, "[UPLOAD] Dockerfile upload attempt %d/%d...\n", attempt, maxAttempts)
+		}
+		started := time.Now()
+		err := uploader.Upload(ctx, content)
+		printDetail(verbosity.Requests, "[TIME] Upload attempt %d took %v\n", attempt, time.Since(started).Round(time.Millisecond))
+		return err
+	})
 
-			image := listResp.Data.Images[0]
-			status := image.Status
-			formattedStatus := FormatImageStatus(status)
-
-			fmt.Printf("[DATA] Status: %s", formattedStatus)
-			fmt.Println()
-
-			switch status {
-			case "IMAGE_AVAILABLE":
-				fmt.Printf("[SUCCESS] Image deactivated successfully! Image ID: %s\n", imageId)
-				fmt.Printf("[DATA] Final Status: %s\n", formattedStatus)
-				return nil
-			case "RESOURCE_FAILED":
-				fmt.Printf("[DOC] Image ID: %s\n", imageId)
-				fmt.Printf("[SEARCH] Request ID: %s\n", listResp.RequestID)
-				return fmt.Errorf("image deactivation failed with status: %s", formattedStatus)
-			case "RESOURCE_DELETING":
-				// Continue polling - deactivation in progress
-				continue
-			case "RESOURCE_PUBLISHED":
-				// Image is still activated, continue polling in case deactivation is delayed
-				fmt.Printf("[REFRESH] Image still activated, continuing to monitor deactivation...\n")
-				continue
-			default:
-				fmt.Printf("[REFRESH] Unknown status '%s', continuing to monitor...\n", formattedStatus)
-				continue
-			}
+	if err == nil {
+		if attempts > 1 {
+			fmt.Printf("[OK] Dockerfile upload succeeded on attempt %d\n", attempts)
 		}
+		return nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil && err == ctxErr {
+		return fmt.Errorf("dockerfile upload interrupted: %w", err)
+	}
+
+	if attempts == maxAttempts {
+		fmt.Printf("[ERROR] All %d upload attempts failed\n", attempts)
+	}
+	return fmt.Errorf("dockerfile upload failed after %d attempt(s), last error: %w",
+		attempts, describeUploadError(err))
+}
+
+// describeUploadError adds context to upload failures that are not rejections by the
+// storage service
+func describeUploadError(err error) error {
+	var uploadErr *UploadError
+	if errors.As(err, &uploadErr) {
+		return uploadErr
 	}
+	return fmt.Errorf("failed to upload dockerfile: %w", err)
+}
+
+// isRetryableUploadError reports whether a failed upload is worth another attempt,
+// using the same rules as the API client
+func isRetryableUploadError(err error) bool {
+	var uploadErr *UploadError
+	if errors.As(err, &uploadErr) {
+		return client.IsRetryableHTTPStatus(uploadErr.StatusCode)
+	}
+	return client.IsRetryableError(err)
 }
 
 // truncateString truncates a string to the specified length with ellipsis
@@ -799,47 +1579,9 @@ func truncateString(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
-// formatTimestamp formats a timestamp string for display in local timezone
-func formatTimestamp(timestamp string) string {
-	if timestamp == "" {
-		return "-"
-	}
-	// Try to parse the UTC timestamp and convert to local timezone
-	if t, err := time.Parse(time.RFC3339, timestamp); err == nil {
-		// Convert UTC time to local timezone
-		localTime := t.Local()
-		return localTime.Format("2006-01-02 15:04")
-	}
-	// If parsing fails, return the original string truncated
-	return truncateString(timestamp, 20)
-}
-
 // FormatImageStatus formats image status for better readability
 func FormatImageStatus(status string) string {
-	switch status {
-	// Image creation related statuses
-	case "IMAGE_CREATING":
-		return "Creating"
-	case "IMAGE_CREATE_FAILED":
-		return "Create Failed"
-	case "IMAGE_AVAILABLE":
-		return "Available"
-
-	// Resource activation related statuses
-	case "RESOURCE_DEPLOYING":
-		return "Activating"
-	case "RESOURCE_PUBLISHED":
-		return "Activated"
-	case "RESOURCE_DELETING":
-		return "Deactivating"
-	case "RESOURCE_FAILED":
-		return "Activate Failed"
-	case "RESOURCE_CEASED":
-		return "Ceased Billing"
-
-	default:
-		return status
-	}
+	return imagestatus.Status(status).Label()
 }
 
 // FormatCPU formats CPU value for display, handling null values gracefully
@@ -871,73 +1613,3 @@ func FormatResources(cpu *int, memory *int) string {
 	}
 	return fmt.Sprintf("%d/%dG", *cpu, *memory)
 }
-
-// pollImageActivationStatus polls the image activation status until completion or failure
-func pollImageActivationStatus(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, imageId string) error {
-	ticker := time.NewTicker(5 * time.Second)
-	defer ticker.Stop()
-
-	// Create a new context with longer timeout for polling
-	pollCtx, pollCancel := context.WithTimeout(context.Background(), 45*time.Minute)
-	defer pollCancel()
-
-	for {
-		select {
-		case <-pollCtx.Done():
-			fmt.Printf("[DOC] Image ID: %s\n", imageId)
-			return fmt.Errorf("timeout waiting for image activation to complete")
-		case <-ticker.C:
-			// Query specific image status using ListImages with imageIds filter
-			listResp, httpResp, err := apiClient.ImageAPI.ListImages(pollCtx, loginToken, sessionId, "User", 1, 1, []string{imageId})
-			if err != nil {
-				if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
-					fmt.Printf("[WARN]  Warning: Failed to check image status: %s\n", apiErr.Error())
-					if httpResp != nil {
-						fmt.Printf("[DATA] Status Code: %d\n", httpResp.StatusCode)
-					}
-					fmt.Printf("[DOC] Image ID: %s\n", imageId)
-					continue // Continue polling on API errors
-				}
-				fmt.Printf("[DOC] Image ID: %s\n", imageId)
-				return fmt.Errorf("network error checking image status: %v", err)
-			}
-
-			if !listResp.Success {
-				fmt.Printf("[WARN]  Warning: Image status check failed: %s\n", listResp.Code)
-				fmt.Printf("[DOC] Image ID: %s\n", imageId)
-				fmt.Printf("[SEARCH] Request ID: %s\n", listResp.RequestID)
-				continue // Continue polling on API errors
-			}
-
-			// Check if we found the image
-			if len(listResp.Data.Images) == 0 {
-				fmt.Printf("[WARN]  Warning: Image not found: %s\n", imageId)
-				continue // Continue polling
-			}
-
-			image := listResp.Data.Images[0]
-			status := image.Status
-			formattedStatus := FormatImageStatus(status)
-
-			fmt.Printf("[DATA] Status: %s", formattedStatus)
-			fmt.Println()
-
-			switch status {
-			case "RESOURCE_PUBLISHED":
-				fmt.Printf("[SUCCESS] Image activated successfully! Image ID: %s\n", imageId)
-				fmt.Printf("[DATA] Final Status: %s\n", formattedStatus)
-				return nil
-			case "RESOURCE_FAILED", "RESOURCE_CEASED":
-				fmt.Printf("[DOC] Image ID: %s\n", imageId)
-				fmt.Printf("[SEARCH] Request ID: %s\n", listResp.RequestID)
-				return fmt.Errorf("image activation failed with status: %s", formattedStatus)
-			case "RESOURCE_DEPLOYING":
-				// Continue polling
-				continue
-			default:
-				fmt.Printf("[REFRESH] Unknown status '%s', continuing to monitor...\n", formattedStatus)
-				continue
-			}
-		}
-	}
-}
@@ -0,0 +1,537 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/poll"
+	"github.com/agbcloud/agbcloud-cli/internal/progress"
+	"github.com/agbcloud/agbcloud-cli/internal/workpool"
+)
+
+var imageCreateBatchCmd = &cobra.Command{
+	Use:   "create-batch",
+	Short: "Create several images concurrently from a batch file",
+	Long: `Create several images at once. The batch file lists the images to build:
+
+  images:
+    - name: web-server
+      dockerfile: ./web/Dockerfile
+      source: agb-code-space-1
+    - name: browser-tools
+      dockerfile: ./browser/Dockerfile
+      source: agb-browser-use-1
+
+Dockerfile paths are relative to the batch file. Up to --concurrency images are
+built at the same time (3 unless the concurrency setting says otherwise); the others
+wait for a free slot. Lower it if your account is rate limited. A summary with the task ID of
+every build is printed at the end, and the command fails if any build failed.`,
+	Example: `  agbcloud image create-batch -f batch.yaml
+  agbcloud image create-batch -f batch.yaml --concurrency 5 --cleanup-on-failure
+  agbcloud image create-batch -f batch.yaml -o json`,
+	Args: cobra.NoArgs,
+	RunE: runImageCreateBatch,
+}
+
+const (
+	// imageBatchUploadAttempts is the number of times a Dockerfile upload is tried
+	imageBatchUploadAttempts = 3
+)
+
+// Image batch results
+const (
+	imageBatchCreated = "created"
+	imageBatchFailed  = "failed"
+)
+
+func init() {
+	imageCreateBatchCmd.Flags().StringP("file", "f", "", "Path to the batch file (required)")
+	addConcurrencyFlag(imageCreateBatchCmd, "Number of images built at the same time")
+	imageCreateBatchCmd.Flags().Int("parallel", defaultBatchConcurrency, "Number of images built at the same time")
+	_ = imageCreateBatchCmd.Flags().MarkDeprecated("parallel", "use --concurrency instead")
+	imageCreateBatchCmd.Flags().Bool("force", false, "Skip the check for existing images with the same names, and upload files that do not look like Dockerfiles")
+	imageCreateBatchCmd.Flags().Bool("cleanup-on-failure", false, "Delete the server-side task and its artifacts of failed builds (default from config)")
+	imageCreateBatchCmd.Flags().Bool("refresh", false, "Fetch the System image list again instead of using the cached one")
+	addPolicyFileFlag(imageCreateBatchCmd)
+
+	ImageCmd.AddCommand(imageCreateBatchCmd)
+
+	registerOutputSchema(imageCreateBatchCmd, outputSchema{
+		Command:     "image create-batch",
+		Version:     1,
+		Description: "One result per image of the batch file, in file order. result is created or failed; taskId is empty when the build never started. Failed builds have the error code of the server, or a code such as NetworkError, and the request ID when the server sent one.",
+		Result:      []ImageBatchResult{},
+	})
+}
+
+// ImageBatchEntry is one image of a batch file
+type ImageBatchEntry struct {
+	Name       string `yaml:"name"`
+	Dockerfile string `yaml:"dockerfile"`
+	Source     string `yaml:"source"`
+}
+
+// imageBatchFile is the layout of a batch file
+type imageBatchFile struct {
+	Images []ImageBatchEntry `yaml:"images"`
+}
+
+// ImageBatchResult records the outcome of building one image of a batch
+type ImageBatchResult struct {
+	Name    string `json:"name"`
+	Result  string `json:"result"`
+	TaskID  string `json:"taskId"`
+	ImageID string `json:"imageId,omitempty"`
+	Digest  string `json:"digest,omitempty"`
+	Error   string `json:"error,omitempty"`
+	// Code and RequestID tell why a build failed: the error code and request ID of the
+	// server, or a code such as NetworkError when the server gave none
+	Code      string `json:"code,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// ImageBatchOptions configures BuildImageBatch
+type ImageBatchOptions struct {
+	Parallel         int  // Images built at the same time (default 1)
+	Force            bool // Skip the check for existing images with the same name
+	CleanupOnFailure bool // Delete the task of a failed build
+	Clock            poll.Clock
+	// UploadStorage overrides how Dockerfiles are uploaded; nil follows the upload credentials
+	UploadStorage *config.UploadStorage
+	// Progress shows the state of every image; nil shows nothing
+	Progress *progress.Multiplexer
+}
+
+// LoadImageBatch reads a batch file and checks every entry. Relative Dockerfile
+// paths are resolved against the directory of the batch file. All problems found
+// are returned together, one per line.
+func LoadImageBatch(path string) ([]ImageBatchEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch file: %w", err)
+	}
+
+	var file imageBatchFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid batch file: %w", err)
+	}
+	if len(file.Images) == 0 {
+		return nil, fmt.Errorf("the batch file lists no images")
+	}
+
+	dir := filepath.Dir(path)
+	seen := make(map[string]int)
+	var problems []error
+	for i := range file.Images {
+		entry := &file.Images[i]
+		label := fmt.Sprintf("images[%d]", i)
+		entry.Name = strings.TrimSpace(entry.Name)
+		if entry.Name != "" {
+			label = fmt.Sprintf("images[%d] (%s)", i, entry.Name)
+		}
+
+		switch {
+		case entry.Name == "":
+			problems = append(problems, fmt.Errorf("%s: name is required", label))
+		case len(entry.Name) < imageNameMinLength || len(entry.Name) > imageNameMaxLength || !imageNamePattern.MatchString(entry.Name):
+			problems = append(problems, fmt.Errorf("%s: invalid image name; names have %d-%d characters, start with a letter and contain only letters, digits, '.', '_' or '-'",
+				label, imageNameMinLength, imageNameMaxLength))
+		default:
+			if first, ok := seen[entry.Name]; ok {
+				problems = append(problems, fmt.Errorf("%s: duplicate image name, already used by images[%d]", label, first))
+			} else {
+				seen[entry.Name] = i
+			}
+		}
+
+		if entry.Source == "" {
+			problems = append(problems, fmt.Errorf("%s: source is required", label))
+		}
+
+		if entry.Dockerfile == "" {
+			problems = append(problems, fmt.Errorf("%s: dockerfile is required", label))
+			continue
+		}
+		if !filepath.IsAbs(entry.Dockerfile) {
+			entry.Dockerfile = filepath.Join(dir, entry.Dockerfile)
+		}
+		if absPath, err := filepath.Abs(entry.Dockerfile); err == nil {
+			entry.Dockerfile = absPath
+		}
+		if _, err := os.Stat(entry.Dockerfile); err != nil {
+			problems = append(problems, fmt.Errorf("%s: dockerfile not found: %s", label, entry.Dockerfile))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, errors.Join(problems...)
+	}
+	return file.Images, nil
+}
+
+// BuildImageBatch builds the images of a batch with at most opts.Parallel builds
+// running at the same time. It returns one result per entry, in the same order.
+// Images that have not started when ctx is cancelled are reported as failed.
+func BuildImageBatch(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string, entries []ImageBatchEntry, opts ImageBatchOptions) []ImageBatchResult {
+	tracker := opts.Progress
+	if tracker == nil {
+		tracker = progress.New(io.Discard, progress.Options{Mode: progress.ModeSequential})
+	}
+	items := make([]*progress.Item, len(entries))
+	for i, entry := range entries {
+		items[i] = tracker.Add(entry.Name)
+	}
+
+	results := make([]ImageBatchResult, len(entries))
+	workpool.Run(opts.Parallel, len(entries), func(i int) {
+		results[i] = buildBatchImage(ctx, apiClient, loginToken, sessionId, entries[i], items[i], opts)
+	})
+	return results
+}
+
+// buildBatchImage runs the create pipeline for one image, reporting each step on
+// its progress item instead of printing it
+func buildBatchImage(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string, entry ImageBatchEntry, item *progress.Item, opts ImageBatchOptions) ImageBatchResult {
+	result := ImageBatchResult{Name: entry.Name}
+	fail := func(err error) ImageBatchResult {
+		result.Result = imageBatchFailed
+		result.Error = err.Error()
+		result.Code, result.RequestID = batchFailureCode(err)
+		item.Fail(err)
+		return result
+	}
+	if ctx.Err() != nil {
+		return fail(withBatchCode(batchCodeInterrupted, errors.New("interrupted before the build started")))
+	}
+
+	content, err := os.ReadFile(entry.Dockerfile)
+	if err != nil {
+		return fail(fmt.Errorf("failed to read dockerfile: %w", err))
+	}
+
+	if !opts.Force {
+		item.Start("checking for an existing image")
+		existing, err := FindUserImageByName(ctx, apiClient, loginToken, sessionId, entry.Name)
+		if err == nil && existing != nil {
+			return fail(withBatchCode(batchCodeExists, fmt.Errorf("an image with this name already exists (Image ID: %s)", existing.ImageID)))
+		}
+		// A failed check does not block the build; the server still enforces uniqueness
+	}
+
+	item.Start("requesting upload credentials")
+	uploadData, err := requestBatchUploadCredential(ctx, apiClient, loginToken, sessionId)
+	if err != nil {
+		return fail(err)
+	}
+	result.TaskID = uploadData.TaskID
+
+	// The task exists from here on; failed builds leave it behind unless cleaned up
+	failTask := func(err error) ImageBatchResult {
+		if opts.CleanupOnFailure {
+			cleanupCtx, cancel := context.WithTimeout(context.Background(), imageTaskCleanupTimeout)
+			defer cancel()
+			if cleanupErr := deleteImageTask(cleanupCtx, apiClient, loginToken, sessionId, result.TaskID); cleanupErr != nil {
+				err = fmt.Errorf("%w (cleanup of task failed: %v)", err, cleanupErr)
+			}
+		}
+		return fail(err)
+	}
+
+	item.Update("uploading Dockerfile")
+	err = uploadBatchDockerfile(ctx, content, uploadData, opts.UploadStorage, opts.Clock)
+	if err != nil && IsUploadCredentialExpiredError(err) {
+		// Presigned URLs expire; request fresh credentials and retry exactly once
+		item.Update("upload credentials expired, retrying")
+		uploadData, err = requestBatchUploadCredential(ctx, apiClient, loginToken, sessionId)
+		if err != nil {
+			return failTask(err)
+		}
+		result.TaskID = uploadData.TaskID
+		err = uploadBatchDockerfile(ctx, content, uploadData, opts.UploadStorage, opts.Clock)
+	}
+	if err != nil {
+		return failTask(fmt.Errorf("failed to upload dockerfile: %w", err))
+	}
+
+	item.Update("starting build")
+	_, _, err = apiClient.ImageAPI.CreateImage(ctx, loginToken, sessionId, entry.Name, uploadData.TaskID, entry.Source, client.ImageCreateOptions{})
+	if err != nil {
+		return failTask(imageBatchAPIError("failed to create image", err))
+	}
+
+	item.Update("building")
+	buildStarted := time.Now()
+	poller := newImagePoller("poll image task", nil)
+	poller.Clock = opts.Clock
+	poller.Progress = func(p poll.Progress) {
+		if p.Err != nil {
+			item.Update(fmt.Sprintf("building (status check failed, retrying: %v)", p.Err))
+		}
+	}
+	err = poller.Until(ctx, func(ctx context.Context) (bool, error) {
+		taskResp, httpResp, err := apiClient.ImageAPI.GetImageTask(ctx, loginToken, sessionId, uploadData.TaskID)
+		if err != nil {
+			return false, statusCheckError(httpResp, err)
+		}
+
+		status := taskResp.Data.Status
+		switch status {
+		case "Finished":
+			if taskResp.Data.ImageID != nil {
+				result.ImageID = *taskResp.Data.ImageID
+			}
+			result.Digest = taskResp.Data.Digest
+			return true, nil
+		case "Failed":
+			return false, poll.Stop(fmt.Errorf("%w: %s", errImageTaskFailed, taskResp.Data.TaskMsg))
+		}
+		message := "building (" + status + ")"
+		if taskResp.Data.TaskMsg != "" {
+			message += " - " + taskResp.Data.TaskMsg
+		}
+		item.Update(message)
+		return false, nil
+	})
+	if err != nil {
+		err = pollError("image creation", err)
+		// Only failed builds are cleaned up; after a timeout the build may still be running
+		if errors.Is(err, errImageTaskFailed) {
+			return failTask(err)
+		}
+		return fail(err)
+	}
+
+	recordBuildDuration(entry.Source, time.Since(buildStarted))
+	result.Result = imageBatchCreated
+	item.Succeed("created " + result.ImageID)
+	return result
+}
+
+// requestBatchUploadCredential obtains a presigned Dockerfile upload URL and a task ID
+// without printing, for builds that report through a progress item
+func requestBatchUploadCredential(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string) (client.ImageUploadCredentialData, error) {
+	uploadResp, _, err := apiClient.ImageAPI.GetUploadCredential(ctx, loginToken, sessionId)
+	if err != nil {
+		return uploadResp.Data, imageBatchAPIError("failed to get upload credentials", err)
+	}
+	return uploadResp.Data, nil
+}
+
+// uploadBatchDockerfile uploads content, retrying transient failures with a growing delay
+func uploadBatchDockerfile(ctx context.Context, content []byte, credential client.ImageUploadCredentialData, storage *config.UploadStorage, clock poll.Clock) error {
+	if clock == nil {
+		clock = poll.RealClock
+	}
+	uploader, err := NewDockerfileUploader(credential, storage)
+	if err != nil {
+		return err
+	}
+	for attempt := 1; attempt <= imageBatchUploadAttempts; attempt++ {
+		err = uploader.Upload(ctx, content)
+		if err == nil || !isRetryableUploadError(err) || attempt == imageBatchUploadAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(time.Duration(attempt) * time.Second):
+		}
+	}
+	return err
+}
+
+// imageBatchAPIError describes a failed API call in one line, preferring the
+// error code of the response body. The error code and request ID stay available
+// to batchFailureCode.
+func imageBatchAPIError(action string, err error) error {
+	if code, _ := client.ErrorCode(err); code != "" {
+		return &batchStepError{message: fmt.Sprintf("%s: %s", action, code), err: err}
+	}
+	if serverAnswered(err) {
+		return &batchStepError{message: fmt.Sprintf("%s: %v", action, err), err: err}
+	}
+	return &batchStepError{message: fmt.Sprintf("%s: network error: %v", action, err), code: batchCodeNetwork, err: err}
+}
+
+func runImageCreateBatch(cmd *cobra.Command, args []string) error {
+	batchPath, _ := cmd.Flags().GetString("file")
+	force, _ := cmd.Flags().GetBool("force")
+	refresh, _ := cmd.Flags().GetBool("refresh")
+
+	if batchPath == "" {
+		return printErrorMessage(
+			"[ERROR] Missing required flag: --file",
+			"",
+			"[TIP] Usage: agbcloud image create-batch --file <batch.yaml>",
+			"[NOTE] Run 'agbcloud image create-batch --help' for the batch file format",
+		)
+	}
+	parallel, err := batchConcurrency(cmd)
+	if err != nil {
+		return err
+	}
+
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	out := progressWriter(outputFormat)
+
+	entries, err := LoadImageBatch(batchPath)
+	if err != nil {
+		lines := []string{fmt.Sprintf("[ERROR] Invalid batch file %s:", batchPath)}
+		for _, line := range strings.Split(err.Error(), "\n") {
+			lines = append(lines, "• "+line)
+		}
+		lines = append(lines, "", "[TIP] Run 'agbcloud image create-batch --help' for the batch file format")
+		return printErrorMessage(lines...)
+	}
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+	cleanupOnFailure := ResolveCleanupOnFailure(cmd, cfg)
+
+	// Files that are not Dockerfiles fail the whole batch before anything is uploaded
+	var problems []string
+	forced := make(map[string]bool)
+	for _, entry := range entries {
+		var problem *DockerfileProblem
+		err := CheckDockerfile(entry.Dockerfile)
+		switch {
+		case errors.As(err, &problem) && problem.Forcible && force:
+			forced[entry.Name] = true
+			fmt.Fprintf(out, "[WARN]  %s: %s; uploading it anyway because of --force\n", entry.Name, problem.Error())
+		case errors.As(err, &problem):
+			problems = append(problems, fmt.Sprintf("• %s: %s", entry.Name, problem.Error()))
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("• %s: failed to read dockerfile: %v", entry.Name, err))
+		}
+	}
+	if len(problems) > 0 {
+		lines := append([]string{"[ERROR] Some Dockerfiles of the batch cannot be uploaded:"}, problems...)
+		lines = append(lines, "", "[TIP] Check the dockerfile paths in the batch file", "[NOTE] Use --force to upload binary, very large or instruction-less files anyway; directories are never uploaded")
+		return printErrorMessage(lines...)
+	}
+
+	// Lint problems are shown up front; the progress view has no room for them
+	for _, entry := range entries {
+		if content, err := os.ReadFile(entry.Dockerfile); err == nil && !forced[entry.Name] {
+			for _, warning := range LintDockerfile(string(content)) {
+				fmt.Fprintf(out, "[WARN]  %s: %s\n", entry.Name, warning)
+			}
+		}
+	}
+
+	// The organization policy is checked before anything is uploaded
+	if err := checkBatchPolicy(cmd, cfg, entries); err != nil {
+		return err
+	}
+
+	// Ctrl-C skips the builds that have not started and stops monitoring the running ones
+	ctx := commandContext(cmd)
+
+	apiClient := client.NewFromConfig(cfg)
+
+	// Every source has to be a System image; one list, usually cached, covers the batch
+	sources := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		sources = append(sources, entry.Source)
+	}
+	systemImages := SystemImageList{APIClient: apiClient, LoginToken: cfg.Token.LoginToken, SessionID: cfg.Token.SessionId, Refresh: refresh}
+	warn := func(format string, args ...interface{}) { fmt.Fprintf(out, "[WARN]  "+format+"\n", args...) }
+	if err := checkSourceImages(ctx, systemImages, sources, warn); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "[BUILD]  Creating %d image(s), %d at a time...\n", len(entries), min(parallel, len(entries)))
+	tracker := progress.New(out, progress.Options{})
+	results := BuildImageBatch(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, entries, ImageBatchOptions{
+		Parallel:         parallel,
+		Force:            force,
+		CleanupOnFailure: cleanupOnFailure,
+		UploadStorage:    cfg.UploadStorage,
+		Progress:         tracker,
+	})
+	tracker.Stop()
+
+	created, failed := tracker.Counts()
+	var failures []BatchItemError
+	for _, result := range results {
+		if result.Result == imageBatchFailed {
+			failures = append(failures, BatchItemError{Item: result.Name, Code: result.Code, Message: result.Error, RequestID: result.RequestID})
+		}
+	}
+	batchErr := newBatchError("create", len(results), failures)
+
+	if outputFormat.IsStructured() {
+		if err := writeResult(outputFormat, results); err != nil {
+			return err
+		}
+	} else {
+		printImageBatchSummary(out, results)
+		if batchErr != nil {
+			printBatchErrors(out, batchErr)
+		}
+	}
+	fmt.Fprintf(out, "[DATA] Summary: %d created, %d failed\n", created, failed)
+
+	if batchErr == nil {
+		return nil
+	}
+	if !cleanupOnFailure {
+		for _, result := range results {
+			if result.Result == imageBatchFailed && result.TaskID != "" {
+				fmt.Fprintln(out, "[TIP] Run 'agbcloud image task delete <task-id>' to remove the artifacts of failed builds, or use --cleanup-on-failure")
+				break
+			}
+		}
+	}
+	return batchErr
+}
+
+// printImageBatchSummary prints one line per image of the batch with its task ID
+func printImageBatchSummary(w io.Writer, results []ImageBatchResult) {
+	fmt.Fprintln(w)
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		detail := result.ImageID
+		if result.Result == imageBatchFailed {
+			// The message is shown in the table of failures
+			detail = result.Code
+		}
+		taskID := result.TaskID
+		if taskID == "" {
+			taskID = "-"
+		}
+		rows = append(rows, []string{result.Name, strings.ToUpper(result.Result), taskID, detail})
+	}
+	printTable(w, []TableColumn{
+		{Header: "IMAGE NAME", Width: 25, Truncate: true},
+		{Header: "RESULT", Width: 8},
+		{Header: "TASK ID", Width: 20},
+		{Header: "IMAGE ID / ERROR"},
+	}, rows)
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,351 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/imagestatus"
+	"github.com/agbcloud/agbcloud-cli/internal/policy"
+	"github.com/agbcloud/agbcloud-cli/internal/poll"
+	"github.com/agbcloud/agbcloud-cli/internal/progress"
+	"github.com/agbcloud/agbcloud-cli/internal/workpool"
+)
+
+// ImageOperation is what a batch activate or deactivate does to every image
+type ImageOperation string
+
+const (
+	// ImageOperationActivate activates the images
+	ImageOperationActivate ImageOperation = "activate"
+	// ImageOperationDeactivate deactivates the images
+	ImageOperationDeactivate ImageOperation = "deactivate"
+)
+
+// Image operation results
+const (
+	imageOperationActivated   = "activated"
+	imageOperationDeactivated = "deactivated"
+	imageOperationUnchanged   = "unchanged"
+	imageOperationFailed      = "failed"
+)
+
+// singleImageFlags are the flags of activate and deactivate that only work with one image
+var singleImageFlags = []string{"name", "lease", "auto-renew", "detach", "dry-run", "verbose-poll"}
+
+// ImageOperationOptions configures RunImageBatchOperation
+type ImageOperationOptions struct {
+	Operation   ImageOperation
+	Concurrency int // Images worked on at the same time (default 1)
+	// CPU, Memory and FastStart are the resources requested by activations; without CPU
+	// and memory the defaults of each image apply
+	CPU       int
+	Memory    int
+	FastStart bool
+	// Stop configures deactivations
+	Stop client.ImageStopOptions
+	// Policy is checked before each activation; nil checks nothing
+	Policy *policy.Policy
+	Clock  poll.Clock
+	// Progress shows the state of every image; nil shows nothing
+	Progress *progress.Multiplexer
+}
+
+// ImageOperationResult records the outcome of activating or deactivating one image of a batch
+type ImageOperationResult struct {
+	Image   string `json:"image"`             // Reference given on the command line
+	ImageID string `json:"imageId,omitempty"` // Resolved image ID, empty if the image was not found
+	Result  string `json:"result"`            // activated, deactivated, unchanged or failed
+	Status  string `json:"status,omitempty"`  // Last status seen
+	Error   string `json:"error,omitempty"`
+	// Code and RequestID tell why the image failed: the error code and request ID of
+	// the server, or a code such as ImageNotFound when the server gave none
+	Code      string `json:"code,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// RunImageBatchOperation activates or deactivates the images given by refs with at most
+// opts.Concurrency of them in progress at the same time, and waits for every image to
+// settle. It returns one result per reference, in the same order. Images that have
+// not been started when ctx is cancelled are reported as failed.
+func RunImageBatchOperation(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string, refs []string, opts ImageOperationOptions) []ImageOperationResult {
+	tracker := opts.Progress
+	if tracker == nil {
+		tracker = progress.New(io.Discard, progress.Options{Mode: progress.ModeSequential})
+	}
+	items := make([]*progress.Item, len(refs))
+	for i, ref := range refs {
+		items[i] = tracker.Add(ref)
+	}
+
+	results := make([]ImageOperationResult, len(refs))
+	workpool.Run(opts.Concurrency, len(refs), func(i int) {
+		results[i] = runImageOperation(ctx, apiClient, loginToken, sessionId, refs[i], items[i], opts)
+	})
+	return results
+}
+
+// runImageOperation activates or deactivates one image of a batch, reporting each
+// step on its progress item instead of printing it
+func runImageOperation(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, ref string, item *progress.Item, opts ImageOperationOptions) ImageOperationResult {
+	result := ImageOperationResult{Image: ref}
+	fail := func(err error) ImageOperationResult {
+		result.Result = imageOperationFailed
+		result.Error = err.Error()
+		result.Code, result.RequestID = batchFailureCode(err)
+		item.Fail(err)
+		return result
+	}
+	if ctx.Err() != nil {
+		return fail(withBatchCode(batchCodeInterrupted, errors.New("interrupted before the request was sent")))
+	}
+
+	item.Start("checking status")
+	imageId, err := resolveImageReference(ctx, io.Discard, apiClient, loginToken, sessionId, ref)
+	if err != nil {
+		return fail(err)
+	}
+	listResp, _, err := apiClient.ImageAPI.ListImages(ctx, loginToken, sessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{imageId}})
+	if err != nil {
+		return fail(imageBatchAPIError("failed to check image status", err))
+	}
+	if len(listResp.Data.Images) == 0 {
+		return fail(withBatchCode(batchCodeNotFound, fmt.Errorf("image not found: %s", imageId)))
+	}
+	image := listResp.Data.Images[0]
+	result.ImageID = imageId
+	result.Status = image.Status
+	status := imagestatus.Status(image.Status)
+
+	switch {
+	case status == imageOperationDone[opts.Operation]:
+		result.Result = imageOperationUnchanged
+		item.Succeed("already " + imageOperationResults[opts.Operation])
+		return result
+	case status == imageOperationInProgress[opts.Operation]:
+		item.Update("already " + imageOperationVerbs[opts.Operation] + ", waiting")
+	case opts.Operation == ImageOperationDeactivate && !status.HasInstance():
+		result.Result = imageOperationUnchanged
+		item.Succeed("not activated (" + status.Label() + ")")
+		return result
+	default:
+		if err := sendImageOperation(ctx, apiClient, loginToken, sessionId, image, opts); err != nil {
+			return fail(err)
+		}
+		item.Update(imageOperationVerbs[opts.Operation])
+	}
+
+	poller := newImagePoller("poll image "+string(opts.Operation), nil)
+	poller.Clock = opts.Clock
+	poller.Progress = func(p poll.Progress) {
+		if p.Err != nil {
+			item.Update(fmt.Sprintf("%s (status check failed, retrying: %v)", imageOperationVerbs[opts.Operation], p.Err))
+		}
+	}
+	err = poller.Until(ctx, func(ctx context.Context) (bool, error) {
+		listResp, httpResp, err := apiClient.ImageAPI.ListImages(ctx, loginToken, sessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{imageId}})
+		if err != nil {
+			return false, statusCheckError(httpResp, err)
+		}
+		if len(listResp.Data.Images) == 0 {
+			return false, withBatchCode(batchCodeNotFound, fmt.Errorf("image not found: %s", imageId))
+		}
+		result.Status = listResp.Data.Images[0].Status
+		status := imagestatus.Status(result.Status)
+		switch {
+		case status == imageOperationDone[opts.Operation]:
+			return true, nil
+		case status.IsFailed() || (opts.Operation == ImageOperationActivate && status == imagestatus.Ceased):
+			return false, poll.Stop(withBatchCode(batchCodeFailed, fmt.Errorf("image %s failed with status: %s", opts.Operation, status.Label())))
+		}
+		item.Update(fmt.Sprintf("%s (%s)", imageOperationVerbs[opts.Operation], status.Label()))
+		return false, nil
+	})
+	if err != nil {
+		return fail(pollError("image "+string(opts.Operation), err))
+	}
+
+	result.Result = imageOperationResults[opts.Operation]
+	item.Succeed(result.Result)
+	return result
+}
+
+var (
+	// imageOperationDone is the status images reach when an operation succeeds
+	imageOperationDone = map[ImageOperation]imagestatus.Status{
+		ImageOperationActivate:   imagestatus.Published,
+		ImageOperationDeactivate: imagestatus.Available,
+	}
+	// imageOperationInProgress is the status of images the operation is running for
+	imageOperationInProgress = map[ImageOperation]imagestatus.Status{
+		ImageOperationActivate:   imagestatus.Deploying,
+		ImageOperationDeactivate: imagestatus.Deleting,
+	}
+	imageOperationVerbs = map[ImageOperation]string{
+		ImageOperationActivate:   "activating",
+		ImageOperationDeactivate: "deactivating",
+	}
+	imageOperationResults = map[ImageOperation]string{
+		ImageOperationActivate:   imageOperationActivated,
+		ImageOperationDeactivate: imageOperationDeactivated,
+	}
+)
+
+// sendImageOperation sends the activation or deactivation request for one image
+func sendImageOperation(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string, image client.ImageInfo, opts ImageOperationOptions) error {
+	if opts.Operation == ImageOperationDeactivate {
+		if _, _, err := apiClient.ImageAPI.StopImage(ctx, loginToken, sessionId, image.ImageID, opts.Stop); err != nil {
+			return imageBatchAPIError("failed to deactivate image", err)
+		}
+		recordRecentImage(image.ImageID, image.ImageName, "deactivate")
+		endLeases(image.ImageID)
+		return nil
+	}
+
+	// Without --cpu and --memory the defaults stored with 'image set-defaults' apply
+	cpu, memory := opts.CPU, opts.Memory
+	if cpu == 0 && memory == 0 {
+		if defaultCPU, defaultMemory, ok := activationDefaultSpec(image); ok {
+			cpu, memory = defaultCPU, defaultMemory
+		}
+	}
+	if opts.Policy != nil {
+		if violations := opts.Policy.CheckActivate(policy.ActivateRequest{CPU: cpu, Memory: memory}); len(violations) > 0 {
+			rules := make([]string, 0, len(violations))
+			for _, violation := range violations {
+				rules = append(rules, violation.String())
+			}
+			return withBatchCode(batchCodePolicy, fmt.Errorf("not allowed by the organization policy: %s", strings.Join(rules, "; ")))
+		}
+	}
+	if _, _, err := apiClient.ImageAPI.StartImage(ctx, loginToken, sessionId, image.ImageID, cpu, memory, opts.FastStart); err != nil {
+		return imageBatchAPIError("failed to start image", err)
+	}
+	recordRecentImage(image.ImageID, image.ImageName, "activate")
+	return nil
+}
+
+// imageReferencesArgs accepts one image reference or --name like imageReferenceArgs,
+// or several image references for a batch operation
+func imageReferencesArgs(usage, example string) cobra.PositionalArgs {
+	single := imageReferenceArgs(usage, example)
+	return func(cmd *cobra.Command, args []string) error {
+		if name, _ := cmd.Flags().GetString("name"); name == "" && len(args) > 1 {
+			return nil
+		}
+		return single(cmd, args)
+	}
+}
+
+// runImageBatchCommand runs 'image activate' or 'image deactivate' for several images
+func runImageBatchCommand(cmd *cobra.Command, refs []string, opts ImageOperationOptions) error {
+	for _, name := range singleImageFlags {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] --%s works with a single image, got %d", name, len(refs)),
+				"",
+				fmt.Sprintf("[TIP] Run 'agbcloud image %s' once per image to use --%s", opts.Operation, name),
+			)
+		}
+	}
+	concurrency, err := batchConcurrency(cmd)
+	if err != nil {
+		return err
+	}
+	opts.Concurrency = concurrency
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	out := progressWriter(outputFormat)
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+	if opts.Operation == ImageOperationActivate {
+		if opts.Policy, _, err = loadPolicy(cmd, cfg); err != nil {
+			return err
+		}
+	}
+
+	// Ctrl-C skips the images that have not started and stops monitoring the others
+	ctx := commandContext(cmd)
+	apiClient := client.NewFromConfig(cfg)
+	if opts.Operation == ImageOperationActivate {
+		fmt.Fprintf(out, "[>>] Activating %d image(s), %d at a time...\n", len(refs), min(concurrency, len(refs)))
+	} else {
+		fmt.Fprintf(out, "[STOP] Deactivating %d image(s), %d at a time...\n", len(refs), min(concurrency, len(refs)))
+	}
+	tracker := progress.New(out, progress.Options{})
+	opts.Progress = tracker
+	results := RunImageBatchOperation(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, refs, opts)
+	tracker.Stop()
+
+	var changed, unchanged int
+	var failures []BatchItemError
+	for _, result := range results {
+		switch result.Result {
+		case imageOperationFailed:
+			failures = append(failures, BatchItemError{Item: result.Image, Code: result.Code, Message: result.Error, RequestID: result.RequestID})
+		case imageOperationUnchanged:
+			unchanged++
+		default:
+			changed++
+		}
+	}
+	batchErr := newBatchError(string(opts.Operation), len(results), failures)
+
+	if outputFormat.IsStructured() {
+		if err := writeResult(outputFormat, results); err != nil {
+			return err
+		}
+	} else {
+		printImageOperationSummary(out, results)
+		if batchErr != nil {
+			printBatchErrors(out, batchErr)
+		}
+	}
+	fmt.Fprintf(out, "[DATA] Summary: %d %s, %d unchanged, %d failed\n", changed, imageOperationResults[opts.Operation], unchanged, len(failures))
+	if batchErr == nil {
+		return nil
+	}
+	return batchErr
+}
+
+// printImageOperationSummary prints one line per image of a batch activate or deactivate
+func printImageOperationSummary(w io.Writer, results []ImageOperationResult) {
+	fmt.Fprintln(w)
+	rows := make([][]string, 0, len(results))
+	for _, result := range results {
+		detail := FormatImageStatus(result.Status)
+		if result.Result == imageOperationFailed {
+			// The message is shown in the table of failures
+			detail = result.Code
+		}
+		image := result.ImageID
+		if image == "" {
+			image = result.Image
+		}
+		rows = append(rows, []string{image, strings.ToUpper(result.Result), valueOrDash(detail)})
+	}
+	printTable(w, []TableColumn{
+		{Header: "IMAGE", Width: 20, Truncate: true},
+		{Header: "RESULT", Width: 12},
+		{Header: "STATUS / ERROR"},
+	}, rows)
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+const (
+	// buildHistoryLimit is the number of builds remembered per source image
+	buildHistoryLimit = 20
+	// buildEstimateMinSamples is the number of builds needed before an estimate is shown
+	buildEstimateMinSamples = 2
+)
+
+// buildHistoryMu serializes updates of the build duration file by parallel builds
+var buildHistoryMu sync.Mutex
+
+// buildHistory is the content of the build duration file: the durations of the most
+// recent successful builds, oldest first, by endpoint and source image
+type buildHistory struct {
+	Builds map[string][]time.Duration `json:"builds"`
+}
+
+// BuildEstimate is the typical duration of builds from one source image
+type BuildEstimate struct {
+	SourceImageID string
+	Typical       time.Duration // Median of the remembered builds
+	Samples       int
+}
+
+// buildHistoryPath returns the path of the build duration file
+func buildHistoryPath() (string, error) {
+	configDir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "cache", "build-durations.json"), nil
+}
+
+// buildHistoryKey identifies the builds from a source image on an endpoint; build times
+// differ between deployments
+func buildHistoryKey(endpoint, sourceImageId string) string {
+	return endpoint + "|" + sourceImageId
+}
+
+func readBuildHistory(path string) (buildHistory, error) {
+	history := buildHistory{Builds: map[string][]time.Duration{}}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return history, err
+	}
+	if err := json.Unmarshal(content, &history); err != nil {
+		return buildHistory{Builds: map[string][]time.Duration{}}, fmt.Errorf("invalid build history %s: %w", path, err)
+	}
+	if history.Builds == nil {
+		history.Builds = map[string][]time.Duration{}
+	}
+	return history, nil
+}
+
+// RecordBuildDuration remembers how long a successful build from sourceImageId took
+func RecordBuildDuration(endpoint, sourceImageId string, duration time.Duration) error {
+	path, err := buildHistoryPath()
+	if err != nil {
+		return err
+	}
+	buildHistoryMu.Lock()
+	defer buildHistoryMu.Unlock()
+	history, err := readBuildHistory(path)
+	if err != nil && !os.IsNotExist(err) {
+		log.Debugf("Replacing unreadable build history: %v", err)
+	}
+	key := buildHistoryKey(endpoint, sourceImageId)
+	durations := append(history.Builds[key], duration.Round(time.Second))
+	if len(durations) > buildHistoryLimit {
+		durations = durations[len(durations)-buildHistoryLimit:]
+	}
+	history.Builds[key] = durations
+
+	content, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	// Write to a temporary file first so that a concurrent reader never sees half a file
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// EstimateBuildDuration returns the typical duration of builds from sourceImageId, or
+// nil until enough builds have been recorded
+func EstimateBuildDuration(endpoint, sourceImageId string) (*BuildEstimate, error) {
+	path, err := buildHistoryPath()
+	if err != nil {
+		return nil, err
+	}
+	history, err := readBuildHistory(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	durations := history.Builds[buildHistoryKey(endpoint, sourceImageId)]
+	if len(durations) < buildEstimateMinSamples {
+		return nil, nil
+	}
+
+	// The median is not thrown off by a single build that waited in a queue
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + median) / 2
+	}
+	return &BuildEstimate{SourceImageID: sourceImageId, Typical: median, Samples: len(durations)}, nil
+}
+
+// FormatBuildEstimate renders an estimate, e.g.
+// "Builds from agb-code-space-2 typically take ~12m (based on 5 builds)"
+func FormatBuildEstimate(estimate BuildEstimate) string {
+	return fmt.Sprintf("Builds from %s typically take ~%s (based on %d builds)",
+		estimate.SourceImageID, formatApproxDuration(estimate.Typical), estimate.Samples)
+}
+
+// formatApproxDuration rounds a duration for display: seconds below a minute, whole
+// minutes below an hour, and hours and minutes above
+func formatApproxDuration(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(time.Second).String()
+	}
+	d = d.Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	if minutes := int(d.Minutes()) % 60; minutes != 0 {
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), minutes)
+	}
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
+
+// printBuildEstimate shows how long builds from sourceImageId usually take, if known.
+// Estimates are best-effort and never fail the command.
+func printBuildEstimate(sourceImageId string) {
+	estimate, err := EstimateBuildDuration(config.GetEndpoints()[0], sourceImageId)
+	if err != nil {
+		log.Debugf("Could not estimate the build duration: %v", err)
+		return
+	}
+	if estimate != nil {
+		fmt.Printf("[TIME] %s\n", FormatBuildEstimate(*estimate))
+	}
+}
+
+// recordBuildDuration remembers a successful build for future estimates
+func recordBuildDuration(sourceImageId string, duration time.Duration) {
+	if err := RecordBuildDuration(config.GetEndpoints()[0], sourceImageId, duration); err != nil {
+		log.Debugf("Could not record the build duration: %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// CachedImageList is the last successful `image list` result for one profile, endpoint
+// and image type, kept so that it can be viewed with --cached while offline
+type CachedImageList struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Profile   string    `json:"profile,omitempty"`
+	Endpoint  string    `json:"endpoint"`
+	ImageType string    `json:"imageType"`
+	// Search, All, Page and PageSize describe the query the result was fetched with
+	Search   string             `json:"search,omitempty"`
+	All      bool               `json:"all,omitempty"`
+	Page     int                `json:"page,omitempty"`
+	PageSize int                `json:"pageSize,omitempty"`
+	Total    int                `json:"total"`
+	Images   []client.ImageInfo `json:"images"`
+}
+
+// imageListCache is the content of the cache file. Only the latest result per key is
+// kept, so the file stays small.
+type imageListCache struct {
+	Entries map[string]CachedImageList `json:"entries"`
+}
+
+// imageListCachePath returns the path of the image list cache file
+func imageListCachePath() (string, error) {
+	configDir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "cache", "image-list.json"), nil
+}
+
+// imageListCacheKey identifies the cached result of a profile, endpoint and image type
+func imageListCacheKey(profile, endpoint, imageType string) string {
+	return strings.Join([]string{profile, endpoint, strings.ToLower(imageType)}, "|")
+}
+
+func readImageListCache(path string) (imageListCache, error) {
+	cache := imageListCache{Entries: map[string]CachedImageList{}}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return cache, err
+	}
+	if err := json.Unmarshal(content, &cache); err != nil {
+		return imageListCache{Entries: map[string]CachedImageList{}}, fmt.Errorf("invalid image list cache %s: %w", path, err)
+	}
+	if cache.Entries == nil {
+		cache.Entries = map[string]CachedImageList{}
+	}
+	return cache, nil
+}
+
+// SaveCachedImageList stores a list result, replacing the previous one for the same
+// profile, endpoint and image type
+func SaveCachedImageList(entry CachedImageList) error {
+	path, err := imageListCachePath()
+	if err != nil {
+		return err
+	}
+	return saveImageListCacheEntry(path, entry)
+}
+
+// saveImageListCacheEntry stores entry in the cache file at path, replacing the
+// previous entry for the same profile, endpoint and image type
+func saveImageListCacheEntry(path string, entry CachedImageList) error {
+	cache, err := readImageListCache(path)
+	if err != nil && !os.IsNotExist(err) {
+		log.Debugf("Replacing unreadable image list cache: %v", err)
+	}
+	cache.Entries[imageListCacheKey(entry.Profile, entry.Endpoint, entry.ImageType)] = entry
+
+	content, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	// Write to a temporary file first so that a concurrent reader never sees half a cache
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadCachedImageList returns the cached list result for a profile, endpoint and image
+// type, or nil if there is none
+func LoadCachedImageList(profile, endpoint, imageType string) (*CachedImageList, error) {
+	path, err := imageListCachePath()
+	if err != nil {
+		return nil, err
+	}
+	return loadImageListCacheEntry(path, profile, endpoint, imageType)
+}
+
+// loadImageListCacheEntry returns the entry of a profile, endpoint and image type in
+// the cache file at path, or nil if there is none
+func loadImageListCacheEntry(path, profile, endpoint, imageType string) (*CachedImageList, error) {
+	cache, err := readImageListCache(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	entry, ok := cache.Entries[imageListCacheKey(profile, endpoint, imageType)]
+	if !ok {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+// imageListCacheScope returns the profile and primary endpoint that list results are cached for
+func imageListCacheScope() (string, string) {
+	profile := ""
+	if cfg, err := config.GetConfig(); err == nil {
+		profile, _ = cfg.CurrentProfile()
+	}
+	return profile, config.GetEndpoints()[0]
+}
+
+// cacheImageList remembers a successful list result. Caching is best-effort and never
+// fails the command.
+func cacheImageList(entry CachedImageList) {
+	entry.Profile, entry.Endpoint = imageListCacheScope()
+	entry.FetchedAt = time.Now().UTC()
+	if err := SaveCachedImageList(entry); err != nil {
+		log.Debugf("Could not cache the image list: %v", err)
+	}
+}
+
+// printCachedListTip points to --cached after a network failure when a cached list exists
+func printCachedListTip(imageType string) {
+	profile, endpoint := imageListCacheScope()
+	if entry, err := LoadCachedImageList(profile, endpoint, imageType); err == nil && entry != nil {
+		fmt.Fprintf(os.Stderr, "[TIP] Run 'agbcloud image list --type %s --cached' to view the list fetched %s\n",
+			entry.ImageType, formatRelativeTime(time.Since(entry.FetchedAt)))
+	}
+}
+
+// filterImagesByName keeps the images whose name contains search, ignoring case
+func filterImagesByName(images []client.ImageInfo, search string) []client.ImageInfo {
+	search = strings.ToLower(search)
+	filtered := make([]client.ImageInfo, 0, len(images))
+	for _, image := range images {
+		if strings.Contains(strings.ToLower(image.ImageName), search) {
+			filtered = append(filtered, image)
+		}
+	}
+	return filtered
+}
+
+// describeCachedQuery renders the query a cached result was fetched with, e.g. "page 1, size 10"
+func describeCachedQuery(entry *CachedImageList) string {
+	scope := fmt.Sprintf("page %d, size %d", entry.Page, entry.PageSize)
+	if entry.All {
+		scope = "all pages"
+	}
+	if entry.Search != "" {
+		scope += fmt.Sprintf(", matching '%s'", entry.Search)
+	}
+	return scope
+}
@@ -0,0 +1,235 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var imageSetDefaultsCmd = &cobra.Command{
+	Use:   "set-defaults <image-id>",
+	Short: "Set the default activation settings of an image",
+	Long: `Store default activation settings with an image on the server.
+
+'agbcloud image activate' uses the default spec when neither --cpu nor --memory is
+given, and the server sets the default environment variables in every instance it
+starts, whoever activates the image. 'agbcloud image status' shows the defaults.
+
+--spec replaces the default spec, --env adds or changes a variable and --unset-env
+removes one; the other defaults are kept. --clear removes all defaults.
+
+The image can also be given by a unique prefix of its ID, by its content digest
+(sha256:<hex>, at least 12 digits) as shown in the DIGEST column of
+'agbcloud image list', or by name with --name.`,
+	Example: `  agbcloud image set-defaults img-7a8b9c1d0e --spec 4c8g
+  agbcloud image set-defaults img-7a8b9c1d0e --env LOG_LEVEL=debug --env REGION=eu
+  agbcloud image set-defaults --name myImage --unset-env LOG_LEVEL
+  agbcloud image set-defaults img-7a8b9c1d0e --clear`,
+	Args: imageReferenceArgs("agbcloud image set-defaults <image-id> [--spec <spec>] [--env KEY=VALUE]...", "agbcloud image set-defaults img-7a8b9c1d0e --spec 4c8g"),
+	RunE: runImageSetDefaults,
+}
+
+func init() {
+	imageSetDefaultsCmd.Flags().String("spec", "", "Default CPU and memory, e.g. 4c8g")
+	imageSetDefaultsCmd.Flags().StringArray("env", nil, "Set a default environment variable, KEY=VALUE (repeatable)")
+	imageSetDefaultsCmd.Flags().StringArray("unset-env", nil, "Remove a default environment variable (repeatable)")
+	imageSetDefaultsCmd.Flags().Bool("clear", false, "Remove all default activation settings")
+	imageSetDefaultsCmd.Flags().String("name", "", "Select the image by name instead of ID")
+	ImageCmd.AddCommand(imageSetDefaultsCmd)
+}
+
+// envNamePattern matches the names of environment variables that can be set in an instance
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseEnvAssignment splits a KEY=VALUE assignment; the value may be empty
+func ParseEnvAssignment(assignment string) (string, string, error) {
+	name, value, ok := strings.Cut(assignment, "=")
+	if !ok {
+		return "", "", fmt.Errorf("'%s' is not a KEY=VALUE assignment", assignment)
+	}
+	if !envNamePattern.MatchString(name) {
+		return "", "", fmt.Errorf("invalid variable name '%s': use letters, digits and '_', not starting with a digit", name)
+	}
+	return name, value, nil
+}
+
+// FormatActivationDefaults describes default activation settings, e.g.
+// "4c8g, env LOG_LEVEL=debug REGION=eu"; variables are sorted by name
+func FormatActivationDefaults(defaults *client.ImageActivationDefaults) string {
+	if defaults == nil || defaults.IsZero() {
+		return "none"
+	}
+	var parts []string
+	if defaults.CPU > 0 && defaults.Memory > 0 {
+		parts = append(parts, FormatResourceSpec(defaults.CPU, defaults.Memory))
+	}
+	if len(defaults.Env) > 0 {
+		names := make([]string, 0, len(defaults.Env))
+		for name := range defaults.Env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for i, name := range names {
+			names[i] = name + "=" + defaults.Env[name]
+		}
+		parts = append(parts, "env "+strings.Join(names, " "))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printActivationDefaults prints the default activation settings of an image
+func printActivationDefaults(w io.Writer, defaults *client.ImageActivationDefaults) {
+	fmt.Fprintf(w, "[DATA] Activation Defaults: %s\n", FormatActivationDefaults(defaults))
+}
+
+func runImageSetDefaults(cmd *cobra.Command, args []string) error {
+	imageId, byName := imageReferenceArg(cmd, args)
+	spec, _ := cmd.Flags().GetString("spec")
+	setEnv, _ := cmd.Flags().GetStringArray("env")
+	unsetEnv, _ := cmd.Flags().GetStringArray("unset-env")
+	clearAll, _ := cmd.Flags().GetBool("clear")
+
+	usageTip := "[TIP] Usage: agbcloud image set-defaults <image-id> [--spec <spec>] [--env KEY=VALUE] [--unset-env KEY] | --clear"
+	if !clearAll && spec == "" && len(setEnv) == 0 && len(unsetEnv) == 0 {
+		return printErrorMessage(
+			"[ERROR] Nothing to change: give --spec, --env, --unset-env or --clear",
+			"",
+			usageTip,
+		)
+	}
+	if clearAll && (spec != "" || len(setEnv) > 0 || len(unsetEnv) > 0) {
+		return printErrorMessage(
+			"[ERROR] --clear cannot be combined with other settings",
+			"",
+			usageTip,
+		)
+	}
+
+	var cpu, memory int
+	if spec != "" {
+		var err error
+		if cpu, memory, err = ParseResourceSpec(spec); err != nil {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Invalid --spec value: %v", err),
+				"",
+				"[TIP] Supported specs: 2c4g, 4c8g, 8c16g",
+			)
+		}
+		if err := ValidateCPUMemoryCombo(cpu, memory); err != nil {
+			return err
+		}
+	}
+	env := make(map[string]string, len(setEnv))
+	for _, assignment := range setEnv {
+		name, value, err := ParseEnvAssignment(assignment)
+		if err != nil {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Invalid --env value: %v", err),
+				"",
+				"[NOTE] Example: --env LOG_LEVEL=debug",
+			)
+		}
+		env[name] = value
+	}
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	apiClient := client.NewFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+	defer cancel()
+
+	// The image may also be given by ID prefix, content digest or name
+	imageId, err = resolveImageArgument(ctx, os.Stdout, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, byName)
+	if err != nil {
+		return err
+	}
+
+	// Changes apply on top of the stored defaults
+	listResp, httpResp, err := apiClient.ImageAPI.ListImages(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{imageId}})
+	if err != nil {
+		return requestError(os.Stdout, "failed to get image", httpResp, err)
+	}
+	if len(listResp.Data.Images) == 0 {
+		return imageNotFoundError(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId)
+	}
+	image := listResp.Data.Images[0]
+	recordRecentImage(imageId, image.ImageName, "set-defaults")
+
+	var defaults client.ImageActivationDefaults
+	if image.ActivationDefaults != nil && !clearAll {
+		defaults = *image.ActivationDefaults
+	}
+	if cpu > 0 {
+		defaults.CPU, defaults.Memory = cpu, memory
+	}
+	merged := make(map[string]string, len(defaults.Env)+len(env))
+	for name, value := range defaults.Env {
+		merged[name] = value
+	}
+	for name, value := range env {
+		merged[name] = value
+	}
+	for _, name := range unsetEnv {
+		if _, ok := merged[name]; !ok {
+			fmt.Printf("[NOTE] %s is not a default variable of the image\n", name)
+		}
+		delete(merged, name)
+	}
+	defaults.Env = nil
+	if len(merged) > 0 {
+		defaults.Env = merged
+	}
+
+	_, httpResp, err = apiClient.ImageAPI.UpdateImage(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, defaults)
+	if err != nil {
+		var apiErr *client.GenericOpenAPIError
+		if errors.As(err, &apiErr) && httpResp != nil && (httpResp.StatusCode == http.StatusNotFound || httpResp.StatusCode == http.StatusNotImplemented) {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] The server at %s does not support default activation settings", config.GetEndpoint()),
+				"",
+				"[TIP] Give the resources on every activation instead: agbcloud image activate <image-id> --cpu 4 --memory 8",
+			)
+		}
+		return requestError(os.Stdout, "failed to update image", httpResp, err)
+	}
+
+	if defaults.IsZero() {
+		fmt.Printf("[OK] Default activation settings of '%s' removed\n", image.ImageName)
+		return nil
+	}
+	fmt.Printf("[OK] Default activation settings of '%s' updated\n", image.ImageName)
+	printActivationDefaults(os.Stdout, &defaults)
+	return nil
+}
+
+// activationDefaultSpec returns the default spec stored with an image, for an
+// activation that gives neither --cpu nor --memory
+func activationDefaultSpec(image client.ImageInfo) (cpu, memory int, ok bool) {
+	defaults := image.ActivationDefaults
+	if defaults == nil || defaults.CPU == 0 || defaults.Memory == 0 {
+		return 0, 0, false
+	}
+	return defaults.CPU, defaults.Memory, true
+}
@@ -0,0 +1,410 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// dockerfileDiffContext is the number of unchanged lines shown around Dockerfile changes
+const dockerfileDiffContext = 3
+
+var imageDiffCmd = &cobra.Command{
+	Use:   "diff <image-id> <image-id>",
+	Short: "Show the differences between two images",
+	Long: `Compare two images: their base image, digest, resources, platforms, labels and
+the Dockerfile they were built from. Only the fields that differ are listed;
+use --all to list every field.
+
+The Dockerfiles are compared line by line when the server lets you read both of
+them, otherwise only their digests are compared.
+
+The images can also be given by a unique prefix of their ID or by their content
+digest (sha256:<hex>).`,
+	Example: `  agbcloud image diff img-7a8b9c1d0e img-1f2e3d4c5b
+  agbcloud image diff img-7a8b img-1f2e --all
+  agbcloud image diff img-7a8b9c1d0e img-1f2e3d4c5b -o json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Expected 2 arguments (two image IDs), got %d", len(args)),
+				"",
+				"[TIP] Usage: agbcloud image diff <image-id> <image-id>",
+				"[NOTE] Example: agbcloud image diff img-7a8b9c1d0e img-1f2e3d4c5b",
+			)
+		}
+		return nil
+	},
+	RunE: runImageDiff,
+}
+
+func init() {
+	imageDiffCmd.Flags().Bool("all", false, "List every compared field, not only the ones that differ")
+
+	ImageCmd.AddCommand(imageDiffCmd)
+
+	registerOutputSchema(imageDiffCmd, outputSchema{
+		Command:     "image diff",
+		Version:     1,
+		Description: "The manifests of both images, every compared field and a unified diff of the Dockerfiles when both could be read.",
+		Result:      ImageDiff{},
+	})
+}
+
+// ImageDiff is the result of comparing two images
+type ImageDiff struct {
+	Left   client.ImageManifestData `json:"left"`
+	Right  client.ImageManifestData `json:"right"`
+	Fields []ImageFieldDiff         `json:"fields"`
+	// Differences is the number of fields that differ
+	Differences int `json:"differences"`
+	// DockerfileDiff is a unified diff of the Dockerfiles; empty when they are equal or
+	// one of them could not be read
+	DockerfileDiff string `json:"dockerfileDiff,omitempty"`
+}
+
+// ImageFieldDiff compares one field of two images
+type ImageFieldDiff struct {
+	Field   string `json:"field"`
+	Left    string `json:"left"`
+	Right   string `json:"right"`
+	Differs bool   `json:"differs"`
+}
+
+// CompareImageManifests compares the fields and Dockerfiles of two images. Labels are
+// compared one by one, in key order.
+func CompareImageManifests(left, right client.ImageManifestData) ImageDiff {
+	diff := ImageDiff{Left: left, Right: right, Fields: []ImageFieldDiff{}}
+	add := func(field, leftValue, rightValue string) {
+		entry := ImageFieldDiff{Field: field, Left: leftValue, Right: rightValue, Differs: leftValue != rightValue}
+		if entry.Differs {
+			diff.Differences++
+		}
+		diff.Fields = append(diff.Fields, entry)
+	}
+
+	add("Name", left.ImageName, right.ImageName)
+	add("Base Image", manifestSourceImage(left), manifestSourceImage(right))
+	add("Digest", valueOrDash(left.Digest), valueOrDash(right.Digest))
+	add("Resources", manifestResources(left), manifestResources(right))
+	add("Platforms", manifestPlatforms(left), manifestPlatforms(right))
+	for _, key := range labelKeys(left.Labels, right.Labels) {
+		add("Label "+key, labelValue(left.Labels, key), labelValue(right.Labels, key))
+	}
+	add("Dockerfile", dockerfileDigest(left), dockerfileDigest(right))
+
+	if left.Dockerfile != "" && right.Dockerfile != "" {
+		diff.DockerfileDiff = UnifiedDiff(left.Dockerfile, right.Dockerfile, left.ImageID+"/Dockerfile", right.ImageID+"/Dockerfile")
+	}
+	return diff
+}
+
+// manifestSourceImage describes the base image, e.g. agb-code-space-1@1.2.0
+func manifestSourceImage(manifest client.ImageManifestData) string {
+	return FormatSourceImage(client.ImageInfo{SourceImageID: manifest.SourceImageID, SourceImageVersion: manifest.SourceImageVersion})
+}
+
+// manifestResources describes the resources of an activated image, e.g. 2c4g
+func manifestResources(manifest client.ImageManifestData) string {
+	if manifest.CPU == nil || manifest.Memory == nil {
+		return "-"
+	}
+	return FormatResourceSpec(*manifest.CPU, *manifest.Memory)
+}
+
+// manifestPlatforms lists the platforms an image was built for
+func manifestPlatforms(manifest client.ImageManifestData) string {
+	if len(manifest.Platforms) == 0 {
+		return "default"
+	}
+	platforms := append([]string(nil), manifest.Platforms...)
+	sort.Strings(platforms)
+	return strings.Join(platforms, ",")
+}
+
+// labelKeys returns the label keys of both images, sorted
+func labelKeys(left, right map[string]string) []string {
+	var keys []string
+	for key := range left {
+		keys = append(keys, key)
+	}
+	for key := range right {
+		if _, ok := left[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// labelValue returns the value of a label, or "-" when it is not set
+func labelValue(labels map[string]string, key string) string {
+	value, ok := labels[key]
+	if !ok {
+		return "-"
+	}
+	return value
+}
+
+// dockerfileDigest returns the digest of the Dockerfile of an image, computed from its
+// content when the server does not report it
+func dockerfileDigest(manifest client.ImageManifestData) string {
+	switch {
+	case manifest.DockerfileDigest != "":
+		return manifest.DockerfileDigest
+	case manifest.Dockerfile != "":
+		sum := sha256.Sum256([]byte(manifest.Dockerfile))
+		return imageDigestPrefix + hex.EncodeToString(sum[:])
+	default:
+		return "-"
+	}
+}
+
+// diffLine is a line of a line-by-line diff: ' ' for unchanged, '-' for removed and
+// '+' for added lines
+type diffLine struct {
+	op   byte
+	text string
+}
+
+// diffLines computes the shortest edit turning a into b from their longest common
+// subsequence
+func diffLines(a, b []string) []diffLine {
+	// common[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case common[i+1][j] >= common[i][j+1]:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{'+', b[j]})
+	}
+	return lines
+}
+
+// splitLines splits text into lines, ignoring a final newline and carriage returns
+func splitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// UnifiedDiff returns a unified diff turning left into right, with three lines of
+// context around each change. It is empty when the texts have the same lines.
+func UnifiedDiff(left, right, leftName, rightName string) string {
+	lines := diffLines(splitLines(left), splitLines(right))
+	var changes []int
+	for i, line := range lines {
+		if line.op != ' ' {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", leftName, rightName)
+	for first := 0; first < len(changes); {
+		// Changes closer than twice the context share a hunk
+		last := first
+		for last+1 < len(changes) && changes[last+1]-changes[last] <= 2*dockerfileDiffContext {
+			last++
+		}
+		start := max(changes[first]-dockerfileDiffContext, 0)
+		end := min(changes[last]+dockerfileDiffContext+1, len(lines))
+
+		// Line numbers of the hunk start in both texts
+		leftLine, rightLine := 1, 1
+		for _, line := range lines[:start] {
+			if line.op != '+' {
+				leftLine++
+			}
+			if line.op != '-' {
+				rightLine++
+			}
+		}
+		leftCount, rightCount := 0, 0
+		for _, line := range lines[start:end] {
+			if line.op != '+' {
+				leftCount++
+			}
+			if line.op != '-' {
+				rightCount++
+			}
+		}
+		// An empty range is numbered after the line it follows
+		if leftCount == 0 {
+			leftLine--
+		}
+		if rightCount == 0 {
+			rightLine--
+		}
+
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", leftLine, leftCount, rightLine, rightCount)
+		for _, line := range lines[start:end] {
+			fmt.Fprintf(&b, "%c%s\n", line.op, line.text)
+		}
+		first = last + 1
+	}
+	return b.String()
+}
+
+// imageManifest returns the manifest of an image. Servers without the manifest
+// endpoint are asked for the image details instead; the second result is false then.
+func imageManifest(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, imageId string) (client.ImageManifestData, bool, error) {
+	resp, httpResp, err := apiClient.ImageAPI.GetImageManifest(ctx, loginToken, sessionId, imageId)
+	var apiErr *client.GenericOpenAPIError
+	switch {
+	case err == nil:
+		return resp.Data, true, nil
+	case !errors.As(err, &apiErr) || httpResp == nil ||
+		(httpResp.StatusCode != http.StatusNotFound && httpResp.StatusCode != http.StatusNotImplemented):
+		return client.ImageManifestData{}, false, err
+	}
+
+	listResp, _, err := apiClient.ImageAPI.ListImages(ctx, loginToken, sessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{imageId}})
+	if err != nil {
+		return client.ImageManifestData{}, false, err
+	}
+	if len(listResp.Data.Images) == 0 {
+		return client.ImageManifestData{}, false, fmt.Errorf("image not found: %s", imageId)
+	}
+	image := listResp.Data.Images[0]
+	return client.ImageManifestData{
+		ImageID:            image.ImageID,
+		ImageName:          image.ImageName,
+		SourceImageID:      image.SourceImageID,
+		SourceImageVersion: image.SourceImageVersion,
+		Digest:             image.Digest,
+		CPU:                image.CPU,
+		Memory:             image.Memory,
+	}, false, nil
+}
+
+func runImageDiff(cmd *cobra.Command, args []string) error {
+	showAll, _ := cmd.Flags().GetBool("all")
+
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	out := progressWriter(outputFormat)
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	apiClient := client.NewFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+	defer cancel()
+
+	var manifests [2]client.ImageManifestData
+	complete := true
+	for i, ref := range args {
+		imageId, err := resolveImageReference(ctx, out, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, ref)
+		if err != nil {
+			return err
+		}
+		manifest, full, err := imageManifest(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId)
+		if err != nil {
+			return requestError(out, "failed to get image "+imageId, nil, err)
+		}
+		manifests[i] = manifest
+		complete = complete && full
+	}
+
+	diff := CompareImageManifests(manifests[0], manifests[1])
+	if outputFormat.IsStructured() {
+		return writeResult(outputFormat, diff)
+	}
+
+	fmt.Fprintf(out, "[DIFF] Comparing %s (%s) with %s (%s)\n", diff.Left.ImageID, diff.Left.ImageName, diff.Right.ImageID, diff.Right.ImageName)
+	if !complete {
+		fmt.Fprintln(out, "[NOTE] This AgbCloud endpoint does not provide image manifests; platforms, labels and Dockerfiles are not compared")
+	}
+	printImageDiff(out, diff, showAll)
+	if diff.Differences == 0 {
+		fmt.Fprintln(out, "[OK] The images do not differ")
+		return nil
+	}
+	if diff.DockerfileDiff == "" && dockerfileDigest(diff.Left) != dockerfileDigest(diff.Right) && complete {
+		fmt.Fprintln(out, "[NOTE] The Dockerfiles differ, but their content cannot be read for a line-by-line comparison")
+	}
+	fmt.Fprintf(out, "[DATA] %d field(s) differ\n", diff.Differences)
+	return nil
+}
+
+// printImageDiff prints the compared fields, all or only those that differ, followed by
+// the Dockerfile diff
+func printImageDiff(w io.Writer, diff ImageDiff, showAll bool) {
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "FIELD\t%s\t%s\n", diff.Left.ImageID, diff.Right.ImageID)
+	fmt.Fprintf(tw, "-----\t%s\t%s\n", strings.Repeat("-", len(diff.Left.ImageID)), strings.Repeat("-", len(diff.Right.ImageID)))
+	for _, field := range diff.Fields {
+		if showAll || field.Differs {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", field.Field, field.Left, field.Right)
+		}
+	}
+	tw.Flush()
+	fmt.Fprintln(w)
+
+	if diff.DockerfileDiff != "" {
+		fmt.Fprintln(w, "[DIFF] Dockerfile:")
+		fmt.Fprint(w, diff.DockerfileDiff)
+		fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+const (
+	// imageDigestPrefix starts every image content digest
+	imageDigestPrefix = "sha256:"
+	// shortDigestLength is the number of hex digits shown in tables, and the
+	// shortest digest prefix accepted as an image reference
+	shortDigestLength = 12
+)
+
+// IsImageDigest reports whether ref refers to an image by content digest rather
+// than by image ID. Digests may be abbreviated, e.g. sha256:3f2a9c1b7e4d.
+func IsImageDigest(ref string) bool {
+	return strings.HasPrefix(strings.ToLower(ref), imageDigestPrefix)
+}
+
+// ShortDigest returns the first hex digits of a digest for tables, or "-" if the
+// backend did not report one
+func ShortDigest(digest string) string {
+	if digest == "" {
+		return "-"
+	}
+	hex := strings.TrimPrefix(digest, imageDigestPrefix)
+	if len(hex) > shortDigestLength {
+		hex = hex[:shortDigestLength]
+	}
+	return hex
+}
+
+// validateDigestReference checks that a digest reference is sha256: followed by
+// at least shortDigestLength hex digits
+func validateDigestReference(ref string) error {
+	hex := ref[len(imageDigestPrefix):]
+	if len(hex) < shortDigestLength || len(hex) > 64 {
+		return fmt.Errorf("a digest reference needs %d to 64 hex digits after '%s'", shortDigestLength, imageDigestPrefix)
+	}
+	for _, r := range hex {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return fmt.Errorf("'%s' is not a hex digest", hex)
+		}
+	}
+	return nil
+}
+
+// MatchImageDigest returns the images whose digest equals ref or starts with it.
+// Digests are compared case-insensitively.
+func MatchImageDigest(images []client.ImageInfo, ref string) []client.ImageInfo {
+	ref = strings.ToLower(ref)
+	var matches []client.ImageInfo
+	for _, image := range images {
+		if image.Digest != "" && strings.HasPrefix(strings.ToLower(image.Digest), ref) {
+			matches = append(matches, image)
+		}
+	}
+	return matches
+}
+
+// resolveImageDigest looks up the image with content digest ref among the user's
+// images, so that a renamed image can still be addressed by its content
+func resolveImageDigest(ctx context.Context, out io.Writer, apiClient *client.APIClient, loginToken, sessionId, ref string) (string, error) {
+	ref = strings.ToLower(ref)
+	if err := validateDigestReference(ref); err != nil {
+		return "", printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid image digest '%s': %v", ref, err),
+			"",
+			"[TIP] Run 'agbcloud image list' to see the digests of your images",
+		)
+	}
+
+	fmt.Fprintf(out, "[SEARCH] Looking up image with digest %s...\n", ref)
+	images, err := listAllUserImages(ctx, apiClient, loginToken, sessionId)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up image digest: %w", err)
+	}
+
+	matches := MatchImageDigest(images, ref)
+	switch len(matches) {
+	case 0:
+		return "", printErrorMessage(
+			fmt.Sprintf("[ERROR] No image with digest %s", ref),
+			"",
+			"[TIP] Run 'agbcloud image list' to see the digests of your images",
+		)
+	case 1:
+		fmt.Fprintf(out, "[OK] Digest %s is image %s (%s)\n", ref, matches[0].ImageID, matches[0].ImageName)
+		return matches[0].ImageID, nil
+	}
+
+	lines := []string{fmt.Sprintf("[ERROR] Digest %s matches %d images:", ref, len(matches))}
+	for _, image := range matches {
+		lines = append(lines, fmt.Sprintf("• %s (%s) %s", image.ImageID, image.ImageName, image.Digest))
+	}
+	lines = append(lines, "", "[TIP] Use more digits of the digest, or the image ID")
+	return "", printErrorMessage(lines...)
+}
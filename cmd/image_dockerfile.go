@@ -0,0 +1,148 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+const (
+	// maxDockerfileSize is the largest file uploaded as a Dockerfile without --force
+	maxDockerfileSize = 1 << 20
+	// binarySniffSize is how much of a file is inspected to tell text from binary data
+	binarySniffSize = 8 << 10
+)
+
+// DockerfileProblem explains why a file given as Dockerfile is almost certainly
+// something else. Forcible problems may be overridden with --force.
+type DockerfileProblem struct {
+	Path     string
+	Reason   string
+	Tip      string
+	Forcible bool
+}
+
+// Error implements the error interface
+func (p *DockerfileProblem) Error() string {
+	return fmt.Sprintf("%s %s", p.Path, p.Reason)
+}
+
+// CheckDockerfile makes sure that path is a file that can be a Dockerfile: not a
+// directory, not binary, not unusually large, and with at least one Dockerfile
+// instruction. It returns a *DockerfileProblem for such files and any error reading it.
+func CheckDockerfile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		tip := fmt.Sprintf("Pass the path of the Dockerfile itself, e.g. -f %s", filepath.Join(path, "Dockerfile"))
+		if found, err := os.Stat(filepath.Join(path, "Dockerfile")); err == nil && !found.IsDir() {
+			tip = fmt.Sprintf("The directory contains a Dockerfile; pass it with -f %s", filepath.Join(path, "Dockerfile"))
+		}
+		return &DockerfileProblem{Path: path, Reason: "is a directory, not a Dockerfile", Tip: tip}
+	}
+	if info.Size() > maxDockerfileSize {
+		return &DockerfileProblem{
+			Path:     path,
+			Reason:   fmt.Sprintf("is %s, which is too large for a Dockerfile (limit %s)", client.FormatByteSize(info.Size()), client.FormatByteSize(maxDockerfileSize)),
+			Tip:      "Check that -f points to the Dockerfile and not to an archive or a build output",
+			Forcible: true,
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	if looksBinary(content) {
+		return &DockerfileProblem{
+			Path:     path,
+			Reason:   "looks like a binary file, not a Dockerfile",
+			Tip:      "Check that -f points to the Dockerfile and not to an executable, image or archive",
+			Forcible: true,
+		}
+	}
+	if !hasDockerfileInstruction(string(content)) {
+		return &DockerfileProblem{
+			Path:     path,
+			Reason:   "contains no Dockerfile instructions such as FROM or RUN",
+			Tip:      "Check that -f points to the Dockerfile; an empty or unrelated file cannot be built",
+			Forcible: true,
+		}
+	}
+	return nil
+}
+
+// looksBinary reports whether the start of content has NUL bytes or is not UTF-8,
+// which never happens in a Dockerfile
+func looksBinary(content []byte) bool {
+	sample := content
+	if len(sample) > binarySniffSize {
+		sample = sample[:binarySniffSize]
+		// Do not mistake a multi-byte character cut at the end of the sample for invalid UTF-8
+		for i := 0; i < utf8.UTFMax-1 && len(sample) > 0 && !utf8.Valid(sample); i++ {
+			sample = sample[:len(sample)-1]
+		}
+	}
+	return bytes.IndexByte(sample, 0) >= 0 || !utf8.Valid(sample)
+}
+
+// hasDockerfileInstruction reports whether any line of content starts with a
+// Dockerfile instruction; comments and continuation lines are skipped
+func hasDockerfileInstruction(content string) bool {
+	continued := false
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxDockerfileSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		wasContinued := continued
+		continued = strings.HasSuffix(line, "\\")
+		if wasContinued {
+			continue
+		}
+		if dockerfileInstructions[strings.ToUpper(strings.Fields(line)[0])] {
+			return true
+		}
+	}
+	return false
+}
+
+// dockerfileProblemError reports a Dockerfile problem found before uploading. A
+// forcible problem only prints a warning when force is set, and nil is returned.
+func dockerfileProblemError(problem *DockerfileProblem, force bool, warnings *WarningRecorder) error {
+	if problem.Forcible && force {
+		warnings.Warn("%s; uploading it anyway because of --force", problem.Error())
+		return nil
+	}
+	lines := []string{
+		fmt.Sprintf("[ERROR] %s", problem.Error()),
+		"",
+		"[TIP] " + problem.Tip,
+	}
+	if problem.Forcible {
+		lines = append(lines, "[NOTE] Use --force to upload the file anyway")
+	} else {
+		lines = append(lines, "[NOTE] Only the Dockerfile itself is uploaded, not the directory around it")
+	}
+	return printErrorMessage(lines...)
+}
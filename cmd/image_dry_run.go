@@ -0,0 +1,90 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/imagestatus"
+)
+
+// dryRunSecretFields are the request fields masked when a request is shown
+var dryRunSecretFields = []string{"loginToken", "sessionId"}
+
+// FormatDryRunRequest renders the body of a request that --dry-run shows instead of
+// sending, with the tokens masked
+func FormatDryRunRequest(body any) (string, error) {
+	content, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	fields := map[string]any{}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return "", err
+	}
+	for _, name := range dryRunSecretFields {
+		if value, ok := fields[name].(string); ok {
+			fields[name] = MaskSecret(value)
+		}
+	}
+	// Map keys are sorted, which keeps the output stable
+	content, err = json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// printDryRunRequest prints the request a command would send to the API operation
+// at path, instead of sending it
+func printDryRunRequest(ctx context.Context, w io.Writer, apiClient *client.APIClient, operation, path string, body any) error {
+	serverURL, err := apiClient.GetConfig().ServerURLWithContext(ctx, operation)
+	if err != nil {
+		return err
+	}
+	rendered, err := FormatDryRunRequest(body)
+	if err != nil {
+		return fmt.Errorf("failed to render the request: %w", err)
+	}
+	fmt.Fprintf(w, "[DRY-RUN] Would send %s %s%s with:\n", http.MethodPost, serverURL, path)
+	for _, line := range strings.Split(rendered, "\n") {
+		fmt.Fprintf(w, "  %s\n", line)
+	}
+	return nil
+}
+
+// dryRunImageDeactivate checks the status of an image and shows the request that
+// 'image deactivate' would send, without stopping the instance
+func dryRunImageDeactivate(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, imageId string, opts client.ImageStopOptions) error {
+	fmt.Println("[SEARCH] Checking current image status...")
+	listResp, httpResp, err := apiClient.ImageAPI.ListImages(ctx, loginToken, sessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{imageId}})
+	if err != nil {
+		return requestError(os.Stdout, "failed to check image status", httpResp, err)
+	}
+	if len(listResp.Data.Images) == 0 {
+		return fmt.Errorf("image not found: %s", imageId)
+	}
+
+	image := listResp.Data.Images[0]
+	fmt.Printf("[DATA] Current Status: %s\n", FormatImageStatus(image.Status))
+	if imagestatus.Status(image.Status).HasInstance() {
+		fmt.Println("[OK] The image has an instance to deactivate")
+	} else {
+		fmt.Println("[WARN]  The image is not activated; the server would have no instance to stop")
+	}
+
+	request := client.NewImageStopRequest(loginToken, sessionId, imageId, opts)
+	if err := printDryRunRequest(ctx, os.Stdout, apiClient, "StopImage", "/api/image/stop", request); err != nil {
+		return err
+	}
+	fmt.Println("[DRY-RUN] The image was not deactivated; run the command without --dry-run to deactivate it")
+	return nil
+}
@@ -0,0 +1,385 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/imagestatus"
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+	"github.com/agbcloud/agbcloud-cli/internal/progress"
+)
+
+var imageGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Delete old failed or unused images",
+	Long: `Delete failed and unused user images according to a retention policy.
+
+The most recently updated images are always kept, as are images with an operation
+in progress. Activated images are kept unless --keep-activated=false is given.
+Use --dry-run to preview the result and --save-policy to store the policy flags
+in the configuration so later runs can simply use 'agbcloud image gc'.`,
+	Example: `  # Preview what would be deleted
+  agbcloud image gc --keep-last 5 --keep-activated --dry-run
+
+  # Delete failed/unused images not updated for 30 days and remember the policy
+  agbcloud image gc --keep-last 3 --older-than 30d --save-policy`,
+	Args: cobra.NoArgs,
+	RunE: runImageGC,
+}
+
+// defaultGCKeepLast is the number of recent images kept when no policy is configured
+const defaultGCKeepLast = 5
+
+// Image GC actions
+const (
+	imageGCKeep   = "keep"
+	imageGCDelete = "delete"
+)
+
+func init() {
+	imageGCCmd.Flags().Int("keep-last", defaultGCKeepLast, "Always keep the N most recently updated images")
+	imageGCCmd.Flags().Bool("keep-activated", true, "Never delete activated images")
+	imageGCCmd.Flags().String("older-than", "", "Only delete images not updated within this duration (e.g. 30d, 72h)")
+	imageGCCmd.Flags().Bool("dry-run", false, "Show what would be deleted without deleting anything")
+	imageGCCmd.Flags().Bool("save-policy", false, "Save the policy flags to the configuration for future runs")
+
+	ImageCmd.AddCommand(imageGCCmd)
+
+	registerOutputSchema(imageGCCmd, outputSchema{
+		Command:     "image gc",
+		Version:     1,
+		Description: "One keep/delete decision per user image. action is keep or delete; result is deleted, failed or dry-run for deleted images.",
+		Result:      []ImageGCDecision{},
+	})
+}
+
+// ImageGCDecision records what 'image gc' does with one image and why
+type ImageGCDecision struct {
+	ImageID    string `json:"imageId"`
+	ImageName  string `json:"imageName"`
+	Status     string `json:"status"`
+	UpdateTime string `json:"updateTime"`
+	Action     string `json:"action"`
+	Reason     string `json:"reason"`
+	// Result is filled in after execution: deleted, failed or dry-run
+	Result string `json:"result,omitempty"`
+}
+
+// ParseAge parses a retention age such as "30d", "12h" or "90m". An empty string means no limit.
+func ParseAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age '%s'", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age '%s'", value)
+	}
+	return d, nil
+}
+
+// ResolveImageGCPolicy combines the saved policy with explicitly set flags.
+// Flags take precedence over the saved policy, which takes precedence over defaults.
+func ResolveImageGCPolicy(cmd *cobra.Command, saved *config.ImageGCPolicy) (config.ImageGCPolicy, error) {
+	policy := config.ImageGCPolicy{KeepLast: defaultGCKeepLast, KeepActivated: true}
+	if saved != nil {
+		policy = *saved
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("keep-last") {
+		policy.KeepLast, _ = flags.GetInt("keep-last")
+	}
+	if flags.Changed("keep-activated") {
+		policy.KeepActivated, _ = flags.GetBool("keep-activated")
+	}
+	if flags.Changed("older-than") {
+		policy.OlderThan, _ = flags.GetString("older-than")
+	}
+
+	if policy.KeepLast < 0 {
+		return policy, printErrorMessage(
+			fmt.Sprintf("[ERROR] --keep-last must not be negative (got %d)", policy.KeepLast),
+		)
+	}
+	if _, err := ParseAge(policy.OlderThan); err != nil {
+		return policy, printErrorMessage(
+			fmt.Sprintf("[ERROR] %v", err),
+			"",
+			"[TIP] Use days (30d) or a Go duration (72h, 90m)",
+		)
+	}
+	return policy, nil
+}
+
+// imageTime returns the most relevant timestamp of an image for retention decisions
+func imageTime(image client.ImageInfo) (time.Time, bool) {
+	candidates := []string{image.UpdateTime}
+	if image.GmtUpdate != nil {
+		candidates = append(candidates, *image.GmtUpdate)
+	}
+	if image.GmtCreate != nil {
+		candidates = append(candidates, *image.GmtCreate)
+	}
+	for _, value := range candidates {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// PlanImageGC decides which images to delete under the given policy.
+// Decisions are returned newest first.
+func PlanImageGC(images []client.ImageInfo, policy config.ImageGCPolicy, now time.Time) ([]ImageGCDecision, error) {
+	olderThan, err := ParseAge(policy.OlderThan)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := append([]client.ImageInfo(nil), images...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ti, okI := imageTime(sorted[i])
+		tj, okJ := imageTime(sorted[j])
+		if okI != okJ {
+			return okI // images with unknown times sort last
+		}
+		return ti.After(tj)
+	})
+
+	decisions := make([]ImageGCDecision, 0, len(sorted))
+	for i, image := range sorted {
+		decision := ImageGCDecision{
+			ImageID:    image.ImageID,
+			ImageName:  image.ImageName,
+			Status:     image.Status,
+			UpdateTime: image.UpdateTime,
+			Action:     imageGCKeep,
+		}
+		updated, known := imageTime(image)
+		status := imagestatus.Status(image.Status)
+
+		switch {
+		case status.IsTransitional():
+			decision.Reason = "operation in progress"
+		case i < policy.KeepLast:
+			decision.Reason = fmt.Sprintf("among the %d most recent", policy.KeepLast)
+		case status == imagestatus.Published && policy.KeepActivated:
+			decision.Reason = "activated"
+		case olderThan > 0 && !known:
+			decision.Reason = "update time unknown"
+		case olderThan > 0 && now.Sub(updated) < olderThan:
+			decision.Reason = fmt.Sprintf("updated within %s", policy.OlderThan)
+		case status.IsFailed():
+			decision.Action = imageGCDelete
+			decision.Reason = "failed"
+		case status == imagestatus.Available || status == imagestatus.Ceased:
+			decision.Action = imageGCDelete
+			decision.Reason = "unused"
+		case status == imagestatus.Published:
+			decision.Action = imageGCDelete
+			decision.Reason = "activated (--keep-activated=false)"
+		default:
+			decision.Reason = "unknown status"
+		}
+
+		decisions = append(decisions, decision)
+	}
+	return decisions, nil
+}
+
+// listAllUserImages fetches every page of the user's images
+func listAllUserImages(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string) ([]client.ImageInfo, error) {
+	return listAllImages(ctx, apiClient, loginToken, sessionId, client.ImageListOptions{ImageType: "User"})
+}
+
+// listAllImages fetches every page of the images matching options; the page
+// and page size of options are ignored
+func listAllImages(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string, options client.ImageListOptions) ([]client.ImageInfo, error) {
+	const pageSize = 50
+	options.PageSize = pageSize
+
+	var images []client.ImageInfo
+	for page := 1; ; page++ {
+		options.Page = page
+		listResp, _, err := apiClient.ImageAPI.ListImages(ctx, loginToken, sessionId, options)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, listResp.Data.Images...)
+
+		// Stop once the last page has been fetched
+		if len(listResp.Data.Images) < pageSize || page*pageSize >= listResp.Data.Total {
+			return images, nil
+		}
+	}
+}
+
+func runImageGC(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	savePolicy, _ := cmd.Flags().GetBool("save-policy")
+
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if err := applyTimeFormat(cmd); err != nil {
+		return err
+	}
+	out := progressWriter(outputFormat)
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	policy, err := ResolveImageGCPolicy(cmd, cfg.ImageGC)
+	if err != nil {
+		return err
+	}
+
+	if savePolicy {
+		cfg.ImageGC = &policy
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save image gc policy: %w", err)
+		}
+		fmt.Fprintln(out, "[SAVE] Image GC policy saved to configuration")
+	}
+
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	fmt.Fprintf(out, "[CLEAN] Image GC policy: keep last %d, keep activated: %v", policy.KeepLast, policy.KeepActivated)
+	if policy.OlderThan != "" {
+		fmt.Fprintf(out, ", older than: %s", policy.OlderThan)
+	}
+	fmt.Fprintln(out)
+
+	apiClient := client.NewFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 5*time.Minute)
+	defer cancel()
+
+	fmt.Fprintln(out, "[SEARCH] Fetching user images...")
+	images, err := listAllUserImages(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId)
+	if err != nil {
+		return fmt.Errorf("failed to list images: %w", err)
+	}
+
+	decisions, err := PlanImageGC(images, policy, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var toDelete []*ImageGCDecision
+	for i := range decisions {
+		if decisions[i].Action == imageGCDelete {
+			toDelete = append(toDelete, &decisions[i])
+		}
+	}
+
+	if !outputFormat.IsStructured() {
+		printImageGCPlan(out, decisions)
+	}
+
+	if len(toDelete) == 0 {
+		fmt.Fprintln(out, "[OK] Nothing to delete")
+		return writeImageGCResult(outputFormat, decisions)
+	}
+
+	if dryRun {
+		for _, decision := range toDelete {
+			decision.Result = "dry-run"
+		}
+		fmt.Fprintf(out, "[NOTE] Dry run: %d image(s) would be deleted, %d kept\n", len(toDelete), len(decisions)-len(toDelete))
+		return writeImageGCResult(outputFormat, decisions)
+	}
+
+	fmt.Fprintf(out, "[DELETE] Deleting %d image(s)...\n", len(toDelete))
+	tracker := progress.New(out, progress.Options{})
+	items := make([]*progress.Item, len(toDelete))
+	for i, decision := range toDelete {
+		items[i] = tracker.Add(decision.ImageID)
+	}
+	for i, decision := range toDelete {
+		items[i].Start("deleting")
+		_, _, err := apiClient.ImageAPI.DeleteImage(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, decision.ImageID)
+		if err != nil {
+			decision.Result = "failed"
+			items[i].Fail(err)
+			continue
+		}
+		decision.Result = "deleted"
+		items[i].Succeed("deleted")
+	}
+	tracker.Stop()
+
+	deleted, failed := tracker.Counts()
+	fmt.Fprintf(out, "[DATA] Summary: %d deleted, %d failed, %d kept\n", deleted, failed, len(decisions)-len(toDelete))
+
+	if err := writeImageGCResult(outputFormat, decisions); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d image(s)", failed)
+	}
+	return nil
+}
+
+// writeImageGCResult writes the decisions for structured output formats
+func writeImageGCResult(format output.Format, decisions []ImageGCDecision) error {
+	if !format.IsStructured() {
+		return nil
+	}
+	return writeResult(format, decisions)
+}
+
+// printImageGCPlan prints the keep/delete decision for every image
+func printImageGCPlan(w io.Writer, decisions []ImageGCDecision) {
+	if len(decisions) == 0 {
+		fmt.Fprintln(w, "[EMPTY] No images found.")
+		return
+	}
+
+	fmt.Fprintln(w)
+	rows := make([][]string, 0, len(decisions))
+	for _, d := range decisions {
+		rows = append(rows, []string{
+			strings.ToUpper(d.Action),
+			d.ImageID,
+			d.ImageName,
+			FormatImageStatus(d.Status),
+			formatTimestamp(d.UpdateTime),
+			d.Reason,
+		})
+	}
+	printTable(w, []TableColumn{
+		{Header: "ACTION", Width: 8},
+		{Header: "IMAGE ID", Width: 25, Truncate: true},
+		{Header: "IMAGE NAME", Width: 25, Truncate: true},
+		{Header: "STATUS", Width: 15},
+		{Header: "UPDATED AT", Width: 18},
+		{Header: "REASON"},
+	}, rows)
+	fmt.Fprintln(w)
+}
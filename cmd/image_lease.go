@@ -0,0 +1,271 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+const (
+	// leaseRenewalNotice is how long before its end a lease is renewed or the user is asked
+	leaseRenewalNotice = 10 * time.Minute
+	// minLease is the shortest lease; it must leave time for the renewal notice
+	minLease = 15 * time.Minute
+	// maxLease is the longest lease, so that a forgotten instance is stopped within a week
+	maxLease = 7 * 24 * time.Hour
+)
+
+// ParseLease parses the duration of --lease, e.g. 2h or 90m
+func ParseLease(value string) (time.Duration, error) {
+	lease, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid lease '%s': use a duration such as 90m or 2h", value)
+	}
+	if lease < minLease || lease > maxLease {
+		return 0, fmt.Errorf("invalid lease '%s': a lease lasts between %s and %s", value, formatLeaseDuration(minLease), formatLeaseDuration(maxLease))
+	}
+	return lease, nil
+}
+
+// formatLeaseDuration shows a lease duration without zero units, e.g. 2h or 1h30m
+func formatLeaseDuration(d time.Duration) string {
+	text := d.Round(time.Minute).String()
+	text = strings.TrimSuffix(text, "0s")
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
+
+// formatLeaseEnd shows when a lease ends: the time of day, with the date unless it is today
+func formatLeaseEnd(end time.Time) string {
+	end = inDisplayZone(end)
+	if now := inDisplayZone(time.Now()); end.YearDay() == now.YearDay() && end.Year() == now.Year() {
+		return end.Format("15:04")
+	}
+	return end.Format("2006-01-02 15:04")
+}
+
+// leaseDuration returns how long the lease of a job lasts and how much a renewal adds
+func (j Job) leaseDuration() time.Duration {
+	d, err := time.ParseDuration(j.LeaseDuration)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// leaseExpired reports whether the lease of a job has ended at now
+func (j Job) leaseExpired(now time.Time) bool {
+	return j.Type == JobLease && j.LeaseExpiresAt != nil && !now.Before(*j.LeaseExpiresAt)
+}
+
+// startLease records the lease of an image as a job, replacing an earlier lease of
+// the same image, and tells the user how it ends
+func startLease(imageId, imageName string, lease time.Duration, autoRenewals int) (Job, error) {
+	if err := updateJobs(func(jobs *jobsFile) {
+		jobs.Jobs = slices.DeleteFunc(jobs.Jobs, func(job Job) bool {
+			return job.Type == JobLease && job.ImageID == imageId
+		})
+	}); err != nil {
+		return Job{}, fmt.Errorf("failed to record the lease: %w", err)
+	}
+	expires := time.Now().Add(lease).UTC()
+	job, err := AddJob(Job{
+		Type:           JobLease,
+		ImageID:        imageId,
+		ImageName:      imageName,
+		LeaseExpiresAt: &expires,
+		LeaseDuration:  lease.String(),
+		AutoRenewals:   autoRenewals,
+	})
+	if err != nil {
+		return Job{}, fmt.Errorf("failed to record the lease: %w", err)
+	}
+	fmt.Printf("[LEASE] Leased for %s, until %s; the image is deactivated when the lease ends (job %d)\n", formatLeaseDuration(lease), formatLeaseEnd(expires), job.ID)
+	if autoRenewals > 0 {
+		fmt.Printf("[LEASE] The lease is renewed automatically up to %d time(s), %s before it ends\n", autoRenewals, formatLeaseDuration(leaseRenewalNotice))
+	}
+	return job, nil
+}
+
+// endLeases forgets the leases of an image that was deactivated
+func endLeases(imageId string) {
+	if err := updateJobs(func(jobs *jobsFile) {
+		jobs.Jobs = slices.DeleteFunc(jobs.Jobs, func(job Job) bool {
+			return job.Type == JobLease && job.ImageID == imageId
+		})
+	}); err != nil {
+		log.Debugf("Could not remove the lease of image %s: %v", imageId, err)
+	}
+}
+
+// reloadJob reads the current state of a job, which another command may have renewed
+// or removed
+func reloadJob(id int) (Job, bool) {
+	jobs, err := LoadJobs()
+	if err != nil {
+		log.Debugf("Could not read the jobs: %v", err)
+		return Job{}, false
+	}
+	index := slices.IndexFunc(jobs, func(job Job) bool { return job.ID == id })
+	if index < 0 {
+		return Job{}, false
+	}
+	return jobs[index], true
+}
+
+// renewLease extends the lease of a job by its duration, counted from the current end
+func renewLease(job Job, automatic bool) (Job, error) {
+	expires := job.LeaseExpiresAt.Add(job.leaseDuration()).UTC()
+	err := updateJobs(func(jobs *jobsFile) {
+		for i := range jobs.Jobs {
+			if jobs.Jobs[i].ID == job.ID {
+				jobs.Jobs[i].LeaseExpiresAt = &expires
+				if automatic {
+					jobs.Jobs[i].AutoRenewals--
+				}
+				job = jobs.Jobs[i]
+			}
+		}
+	})
+	return job, err
+}
+
+// watchLease keeps an eye on the lease of a job until it ends: shortly before the end
+// the lease is renewed automatically or the user is asked, and when it ends the image
+// is deactivated. It returns early when the lease is removed, e.g. because the image
+// was deactivated, or when ctx is cancelled.
+func watchLease(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string, job Job) error {
+	fmt.Printf("[LEASE] Watching the lease of '%s' until %s; press Ctrl+C to detach\n", job.Target(), formatLeaseEnd(*job.LeaseExpiresAt))
+	for {
+		current, ok := reloadJob(job.ID)
+		if !ok || current.LeaseExpiresAt == nil {
+			fmt.Printf("[NOTE] The lease of '%s' was removed and is no longer watched\n", job.Target())
+			return nil
+		}
+		job = current
+		expires := *job.LeaseExpiresAt
+
+		if err := sleepUntil(ctx, expires.Add(-leaseRenewalNotice)); err != nil {
+			return err
+		}
+		// Another command may have renewed the lease in the meantime
+		if current, ok := reloadJob(job.ID); !ok || !current.LeaseExpiresAt.Equal(expires) {
+			continue
+		}
+
+		if job.AutoRenewals > 0 {
+			renewed, err := renewLease(job, true)
+			if err != nil {
+				return fmt.Errorf("failed to renew the lease: %w", err)
+			}
+			fmt.Printf("[LEASE] Lease of '%s' renewed automatically until %s (%d automatic renewal(s) left)\n", job.Target(), formatLeaseEnd(*renewed.LeaseExpiresAt), renewed.AutoRenewals)
+			continue
+		}
+		if askToRenewLease(ctx, job) {
+			renewed, err := renewLease(job, false)
+			if err != nil {
+				return fmt.Errorf("failed to renew the lease: %w", err)
+			}
+			fmt.Printf("[LEASE] Lease of '%s' renewed until %s\n", job.Target(), formatLeaseEnd(*renewed.LeaseExpiresAt))
+			continue
+		}
+
+		if err := sleepUntil(ctx, expires); err != nil {
+			return err
+		}
+		if current, ok := reloadJob(job.ID); !ok || !current.LeaseExpiresAt.Equal(expires) {
+			continue
+		}
+		return expireLease(ctx, os.Stdout, apiClient, loginToken, sessionId, job)
+	}
+}
+
+// askToRenewLease tells the user that a lease is about to end and, on a terminal, asks
+// whether to renew it. Without an answer before the lease ends, it is not renewed.
+func askToRenewLease(ctx context.Context, job Job) bool {
+	expires := *job.LeaseExpiresAt
+	fmt.Printf("[LEASE] The lease of '%s' ends at %s; the image is deactivated then\n", job.Target(), formatLeaseEnd(expires))
+	if !isInteractiveInput() {
+		fmt.Printf("[TIP] Extend it with 'agbcloud image activate %s --lease %s'\n", job.ImageID, formatLeaseDuration(job.leaseDuration()))
+		return false
+	}
+	deadline, cancel := context.WithDeadline(ctx, expires)
+	defer cancel()
+	return confirmBefore(deadline, promptInput, os.Stdout, fmt.Sprintf("Extend the lease by %s?", formatLeaseDuration(job.leaseDuration())))
+}
+
+// confirmBefore asks a yes/no question that is answered with no when ctx ends first
+func confirmBefore(ctx context.Context, r io.Reader, w io.Writer, question string) bool {
+	fmt.Fprintf(w, "[?] %s [y/N] ", question)
+	answers := make(chan string, 1)
+	go func() {
+		// The read is abandoned when ctx ends; the process exits soon after
+		answer, _ := bufio.NewReader(r).ReadString('\n')
+		answers <- answer
+	}()
+	select {
+	case answer := <-answers:
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			return true
+		}
+		return false
+	case <-ctx.Done():
+		fmt.Fprintln(w)
+		return false
+	}
+}
+
+// sleepUntil waits until t or until ctx is cancelled
+func sleepUntil(ctx context.Context, t time.Time) error {
+	wait := time.Until(t)
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// expireLease deactivates the image of a lease that has ended and forgets the lease
+func expireLease(ctx context.Context, w io.Writer, apiClient *client.APIClient, loginToken, sessionId string, job Job) error {
+	fmt.Fprintf(w, "[LEASE] The lease of '%s' ended at %s; deactivating the image...\n", job.Target(), formatLeaseEnd(*job.LeaseExpiresAt))
+	requestCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	if _, httpResp, err := apiClient.ImageAPI.StopImage(requestCtx, loginToken, sessionId, job.ImageID, client.ImageStopOptions{}); err != nil {
+		return requestError(w, "failed to deactivate image", httpResp, err)
+	}
+	removeJob(job.ID)
+	fmt.Fprintf(w, "[OK] Deactivation of '%s' initiated\n", job.Target())
+	return nil
+}
+
+// watchAttachedLease watches a lease from the command that started it. Ctrl+C leaves
+// the lease to its job, which nothing watches until it is attached or enforced.
+func watchAttachedLease(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string, job Job) error {
+	err := watchLease(ctx, apiClient, loginToken, sessionId, job)
+	if Interrupted(ctx, err) {
+		fmt.Printf("[NOTE] Detached from the lease of job %d; the image keeps running after the lease ends\n", job.ID)
+		fmt.Printf("[TIP] Run 'agbcloud jobs attach %d' to watch it again, or 'agbcloud jobs enforce' after it ends\n", job.ID)
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+	"github.com/agbcloud/agbcloud-cli/internal/poll"
+)
+
+// imageListWatchMinInterval is the shortest refresh interval of 'image list --watch'
+const imageListWatchMinInterval = time.Second
+
+// conditionalImageList repeats one ListImages request. Once the server has sent an
+// ETag or Last-Modified header, the request is made conditional on it and an
+// unchanged list is answered with 304 Not Modified, without a body; the earlier
+// list is returned then. Servers without caching headers get full requests.
+type conditionalImageList struct {
+	apiClient  *client.APIClient
+	loginToken string
+	sessionId  string
+	options    client.ImageListOptions
+	last       client.ImageListResponse
+	fetched    bool
+}
+
+func newConditionalImageList(apiClient *client.APIClient, loginToken, sessionId string, options client.ImageListOptions) *conditionalImageList {
+	return &conditionalImageList{apiClient: apiClient, loginToken: loginToken, sessionId: sessionId, options: options}
+}
+
+// fetch returns the current list, and whether the server sent it in full rather
+// than answering that it had not changed
+func (l *conditionalImageList) fetch(ctx context.Context) (client.ImageListResponse, bool, *http.Response, error) {
+	options := l.options
+	if l.fetched {
+		options.Validators = l.last.Validators
+	}
+	resp, httpResp, err := l.apiClient.ImageAPI.ListImages(ctx, l.loginToken, l.sessionId, options)
+	if err != nil {
+		return resp, false, httpResp, err
+	}
+	if resp.NotModified {
+		return l.last, false, httpResp, nil
+	}
+	l.last, l.fetched = resp, true
+	return resp, true, httpResp, nil
+}
+
+// validateListWatch rejects the list flags that --watch cannot be combined with
+func validateListWatch(outputFormat output.Format, all, quiet, cached bool) error {
+	var conflict string
+	switch {
+	case cached:
+		conflict = "--cached"
+	case all:
+		conflict = "--all"
+	case quiet:
+		conflict = "--quiet"
+	case outputFormat.IsStructured() || outputFormat == output.FormatWide:
+		conflict = "--output " + string(outputFormat)
+	default:
+		return nil
+	}
+	return printErrorMessage(
+		fmt.Sprintf("[ERROR] --watch cannot be combined with %s", conflict),
+		"",
+		"[TIP] --watch redraws a single page as a table whenever it changes",
+		"[NOTE] Example: agbcloud image list --watch --interval 10s",
+	)
+}
+
+// watchImageList prints the list, then checks it every interval and prints it again
+// whenever it changed, until ctx is cancelled
+func watchImageList(ctx context.Context, progress io.Writer, outputFormat output.Format, lister *conditionalImageList, search string, interval time.Duration) error {
+	fmt.Fprintf(progress, "[MONITOR] Refreshing every %s; press Ctrl-C to stop\n", interval)
+
+	var shown *client.ImageListData
+	refresh := func(ctx context.Context) (bool, error) {
+		fetchCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		resp, _, _, err := lister.fetch(fetchCtx)
+		if err != nil {
+			return false, err
+		}
+		if shown != nil && reflect.DeepEqual(*shown, resp.Data) {
+			return false, nil
+		}
+		data := resp.Data
+		shown = &data
+		fmt.Printf("\n[REFRESH] %s\n", inDisplayZone(time.Now()).Format("15:04:05"))
+		return false, printImageList(outputFormat, data.Images, data, false, search, false)
+	}
+
+	poller := poll.Poller{
+		Name:     "watch image list",
+		Interval: poll.Constant(interval),
+		Progress: func(p poll.Progress) {
+			if p.Err != nil {
+				fmt.Fprintf(progress, "[WARN]  Failed to refresh the image list, retrying: %v\n", p.Err)
+			}
+		},
+	}
+	if _, err := refresh(ctx); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed to list images: %w", err)
+	}
+	err := poller.Until(ctx, refresh)
+	if errors.Is(err, context.Canceled) || ctx.Err() != nil {
+		fmt.Fprintln(progress, "\n[NOTE] Stopped watching")
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+)
+
+// instanceLogPollInterval is how often logs are polled when the server cannot stream them
+const instanceLogPollInterval = 2 * time.Second
+
+var imageLogsCmd = &cobra.Command{
+	Use:   "logs <image-id>",
+	Short: "Show runtime logs of an activated image",
+	Long: `Show the runtime logs produced by an activated image.
+
+Use --follow to keep streaming new lines until interrupted with Ctrl+C.`,
+	Example: `  agbcloud image logs img-7a8b9c1d0e
+  agbcloud image logs img-7a8b9c1d0e -f --tail 50
+  agbcloud image logs img-7a8b9c1d0e --since 30m --timestamps`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return printErrorMessage(
+				"[ERROR] Missing required argument: <image-id>",
+				"",
+				"[TIP] Usage: agbcloud image logs <image-id> [-f] [--tail <n>] [--since <age|time>]",
+				"[NOTE] Example: agbcloud image logs img-7a8b9c1d0e -f",
+			)
+		}
+		if len(args) > 1 {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Too many arguments provided. Expected 1 argument (image ID), got %d", len(args)),
+				"",
+				"[TIP] Usage: agbcloud image logs <image-id> [-f] [--tail <n>] [--since <age|time>]",
+				"[NOTE] Example: agbcloud image logs img-7a8b9c1d0e -f",
+			)
+		}
+		return nil
+	},
+	RunE: runImageLogs,
+}
+
+func init() {
+	imageLogsCmd.Flags().BoolP("follow", "f", false, "Keep streaming new log lines")
+	imageLogsCmd.Flags().IntP("tail", "n", 100, "Number of most recent lines to show (0 for all)")
+	imageLogsCmd.Flags().String("since", "", "Only show lines newer than an age (e.g. 30m, 2h, 1d) or an RFC3339 time")
+	imageLogsCmd.Flags().Bool("timestamps", false, "Prefix each line with its timestamp")
+
+	ImageCmd.AddCommand(imageLogsCmd)
+
+	registerOutputSchema(imageLogsCmd, outputSchema{
+		Command:     "image logs",
+		Version:     1,
+		Description: "Runtime log lines, oldest first. With --follow every line is written as a separate JSON object (NDJSON) matching the item schema.",
+		Result:      []client.InstanceLogLine{},
+	})
+}
+
+// ParseSince converts a --since value (an age such as "30m" or "1d", or an RFC3339 time)
+// into the RFC3339 timestamp sent to the server
+func ParseSince(value string, now time.Time) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC().Format(time.RFC3339), nil
+	}
+	age, err := ParseAge(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid --since value '%s': use an age such as 30m, 2h or 1d, or an RFC3339 time", value)
+	}
+	return now.Add(-age).UTC().Format(time.RFC3339), nil
+}
+
+// FollowInstanceLogs streams instance logs to handler until ctx is cancelled or the
+// stream ends. Servers without a streaming endpoint are polled every pollInterval.
+func FollowInstanceLogs(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string, opts client.InstanceLogOptions, pollInterval time.Duration, handler func(client.InstanceLogLine) error) error {
+	err := apiClient.ImageAPI.StreamInstanceLogs(ctx, loginToken, sessionId, opts, handler)
+	if !errors.Is(err, client.ErrInstanceLogStreamUnsupported) {
+		return err
+	}
+
+	log.Debugf("Log streaming is not available, polling every %v", pollInterval)
+	return pollInstanceLogs(ctx, apiClient, loginToken, sessionId, opts, pollInterval, handler)
+}
+
+// pollInstanceLogs passes instance logs to handler, asking for new lines every
+// pollInterval until ctx is cancelled
+func pollInstanceLogs(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string, opts client.InstanceLogOptions, pollInterval time.Duration, handler func(client.InstanceLogLine) error) error {
+	for {
+		logsResp, _, err := apiClient.ImageAPI.GetInstanceLogs(ctx, loginToken, sessionId, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		for _, line := range logsResp.Data.Lines {
+			if err := handler(line); err != nil {
+				return err
+			}
+		}
+
+		// Later pages continue after the last line instead of re-applying tail/since
+		if logsResp.Data.NextToken != "" {
+			opts.NextToken = logsResp.Data.NextToken
+			opts.Tail, opts.Since = 0, ""
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func runImageLogs(cmd *cobra.Command, args []string) error {
+	imageId := args[0]
+	follow, _ := cmd.Flags().GetBool("follow")
+	tail, _ := cmd.Flags().GetInt("tail")
+	sinceValue, _ := cmd.Flags().GetString("since")
+	timestamps, _ := cmd.Flags().GetBool("timestamps")
+
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if follow && outputFormat.IsStructured() && outputFormat != output.FormatJSON {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] --follow does not support --output %s", outputFormat),
+			"",
+			"[TIP] Use --output table, or --output json for one JSON object per line",
+		)
+	}
+	if tail < 0 {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid --tail value: %d", tail),
+			"",
+			"[TIP] Use a positive number of lines, or 0 for all lines",
+		)
+	}
+	since, err := ParseSince(sinceValue, time.Now())
+	if err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] %v", err),
+			"",
+			"[NOTE] Example: agbcloud image logs img-7a8b9c1d0e --since 30m",
+		)
+	}
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	apiClient := client.NewFromConfig(cfg)
+	opts := client.InstanceLogOptions{ImageId: imageId, Tail: tail, Since: since}
+
+	// Log lines own stdout, so status messages always go to stderr
+	if !follow {
+		ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+		defer cancel()
+
+		fmt.Fprintf(os.Stderr, "[DOC] Fetching logs for image %s...\n", imageId)
+		logsResp, httpResp, err := apiClient.ImageAPI.GetInstanceLogs(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, opts)
+		if err != nil {
+			return requestError(os.Stderr, "failed to get logs", httpResp, err)
+		}
+
+		if outputFormat.IsStructured() {
+			lines := logsResp.Data.Lines
+			if lines == nil {
+				lines = []client.InstanceLogLine{}
+			}
+			return writeResult(outputFormat, lines)
+		}
+		if len(logsResp.Data.Lines) == 0 {
+			fmt.Fprintln(os.Stderr, "[EMPTY] No log lines found.")
+		}
+		for _, line := range logsResp.Data.Lines {
+			printInstanceLogLine(line, timestamps)
+		}
+		return nil
+	}
+
+	// Ctrl+C cancels the command context and ends following
+	ctx := commandContext(cmd)
+
+	encoder := json.NewEncoder(resultOutput())
+	handler := func(line client.InstanceLogLine) error {
+		if outputFormat == output.FormatJSON {
+			return encoder.Encode(line)
+		}
+		printInstanceLogLine(line, timestamps)
+		return nil
+	}
+
+	// Servers that report no log streaming are polled without trying to stream first
+	followLogs := FollowInstanceLogs
+	if capabilities, err := apiClient.Capabilities(ctx, cfg.Token.LoginToken, cfg.Token.SessionId); err == nil {
+		if supported, known := capabilities.SupportsFeature(client.FeatureLogStreaming); known && !supported {
+			followLogs = pollInstanceLogs
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "[MONITOR] Following logs for image %s (press Ctrl+C to stop)...\n", imageId)
+	err = followLogs(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, opts, instanceLogPollInterval, handler)
+	switch {
+	case errors.Is(err, context.Canceled):
+		return nil
+	case err != nil:
+		var failure *client.APIError
+		if errors.As(err, &failure) {
+			return requestError(os.Stderr, "failed to follow logs", nil, err)
+		}
+		if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
+			return fmt.Errorf("failed to follow logs: %s", apiErr.Error())
+		}
+		return fmt.Errorf("failed to follow logs: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "[NOTE] Log stream ended")
+	return nil
+}
+
+// printInstanceLogLine writes one log line to stdout
+func printInstanceLogLine(line client.InstanceLogLine, timestamps bool) {
+	if timestamps {
+		fmt.Printf("%s %s\n", line.Timestamp, line.Message)
+		return
+	}
+	fmt.Println(line.Message)
+}
@@ -0,0 +1,481 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+)
+
+// imageMetadataBackupVersion is the version of the file written by 'image export-metadata'
+const imageMetadataBackupVersion = 1
+
+var imageExportMetadataCmd = &cobra.Command{
+	Use:   "export-metadata",
+	Short: "Export the metadata of all images for backup",
+	Long: `Write the metadata of every image, User and System, to a JSON backup: names, status,
+base images, digests, resources, labels, platforms and timestamps. All pages of the
+image list are exported.
+
+The backup can be compared with the images at any later time with 'image diff-metadata',
+e.g. to check that nothing drifted during a migration.
+
+The backup is written to stdout unless --file is given; --output with a file name
+instead of a format also names the file.`,
+	Example: `  agbcloud image export-metadata --file backup.json
+  agbcloud image export-metadata --output backup.json
+  agbcloud image export-metadata --type user > user-images.json`,
+	Args: cobra.NoArgs,
+	RunE: runImageExportMetadata,
+}
+
+var imageDiffMetadataCmd = &cobra.Command{
+	Use:   "diff-metadata <backup.json>",
+	Short: "Compare a metadata backup with the current images",
+	Long: `Compare a backup written by 'image export-metadata' with the current images and
+report the drift: images that are missing, images that were added and the fields that
+changed. Images are matched by ID, and otherwise by type and name, so that images
+recreated under a new ID are still compared.
+
+Name, type, status, base image, version, digest, resources, platforms, labels and the
+Dockerfile digest are compared. Timestamps change with every operation and are not.`,
+	Example: `  agbcloud image diff-metadata backup.json
+  agbcloud image diff-metadata backup.json --ignore-status --fail-on-drift
+  agbcloud image diff-metadata backup.json -o json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Expected 1 argument (the backup file), got %d", len(args)),
+				"",
+				"[TIP] Usage: agbcloud image diff-metadata <backup.json>",
+				"[NOTE] Create a backup with: agbcloud image export-metadata --file backup.json",
+			)
+		}
+		return nil
+	},
+	RunE: runImageDiffMetadata,
+}
+
+func init() {
+	imageExportMetadataCmd.Flags().String("file", "", "Write the backup to this file instead of stdout")
+	imageExportMetadataCmd.Flags().String("type", "all", "Image types to export: user, system or all")
+
+	imageDiffMetadataCmd.Flags().Bool("ignore-status", false, "Do not report status changes, e.g. images deactivated since the backup")
+	imageDiffMetadataCmd.Flags().Bool("fail-on-drift", false, "Exit with an error if the images drifted from the backup")
+
+	ImageCmd.AddCommand(imageExportMetadataCmd)
+	ImageCmd.AddCommand(imageDiffMetadataCmd)
+
+	registerOutputSchema(imageExportMetadataCmd, outputSchema{
+		Command:     "image export-metadata",
+		Version:     imageMetadataBackupVersion,
+		Description: "The metadata backup. manifests is false when the server did not provide labels, platforms and Dockerfile digests.",
+		Result:      ImageMetadataBackup{},
+	})
+	registerOutputSchema(imageDiffMetadataCmd, outputSchema{
+		Command:     "image diff-metadata",
+		Version:     1,
+		Description: "The drift between a backup and the current images. In changed, left is the backup and right the current value.",
+		Result:      ImageMetadataDrift{},
+	})
+}
+
+// ImageMetadataBackup is the file written by 'image export-metadata'
+type ImageMetadataBackup struct {
+	Version int `json:"version"`
+	// ExportedAt is the time of the export in RFC 3339 format
+	ExportedAt string `json:"exportedAt"`
+	Endpoint   string `json:"endpoint,omitempty"`
+	// Types are the exported image types, User and/or System
+	Types []string `json:"types"`
+	// Manifests is false when the server did not provide labels, platforms and
+	// Dockerfile digests
+	Manifests bool            `json:"manifests"`
+	Images    []ImageMetadata `json:"images"`
+}
+
+// ImageMetadata is the exported metadata of one image: its list entry completed with
+// the labels, platforms and Dockerfile digest of its manifest
+type ImageMetadata struct {
+	client.ImageInfo
+	Labels           map[string]string `json:"labels,omitempty"`
+	Platforms        []string          `json:"platforms,omitempty"`
+	DockerfileDigest string            `json:"dockerfileDigest,omitempty"`
+}
+
+// ImageMetadataDrift is the result of comparing a backup with the current images
+type ImageMetadataDrift struct {
+	// Missing are the images of the backup that no longer exist
+	Missing []ImageMetadata `json:"missing"`
+	// Added are the images that are not in the backup
+	Added   []ImageMetadata       `json:"added"`
+	Changed []ImageMetadataChange `json:"changed"`
+	// Unchanged is the number of images that did not change
+	Unchanged int `json:"unchanged"`
+}
+
+// Drifted reports whether the images differ from the backup
+func (d ImageMetadataDrift) Drifted() bool {
+	return len(d.Missing) > 0 || len(d.Added) > 0 || len(d.Changed) > 0
+}
+
+// ImageMetadataChange lists the fields of one image that changed since the backup
+type ImageMetadataChange struct {
+	ImageID   string           `json:"imageId"`
+	ImageName string           `json:"imageName"`
+	Fields    []ImageFieldDiff `json:"fields"`
+}
+
+// ImageMetadataCompareOptions select what CompareImageMetadata compares
+type ImageMetadataCompareOptions struct {
+	// Manifests compares labels, platforms and Dockerfile digests; they are only
+	// known when both the backup and the current server provide manifests
+	Manifests    bool
+	IgnoreStatus bool
+}
+
+// CompareImageMetadata reports the drift of the current images from a backup. Images
+// are matched by ID first; the remaining ones are matched by type and name when that
+// is unambiguous. The results are sorted by image ID.
+func CompareImageMetadata(backup, current []ImageMetadata, opts ImageMetadataCompareOptions) ImageMetadataDrift {
+	drift := ImageMetadataDrift{Missing: []ImageMetadata{}, Added: []ImageMetadata{}, Changed: []ImageMetadataChange{}}
+
+	currentByID := make(map[string]ImageMetadata, len(current))
+	for _, image := range current {
+		currentByID[image.ImageID] = image
+	}
+	matched := make(map[string]bool, len(current))
+	var pairs [][2]ImageMetadata
+	var unmatched []ImageMetadata
+	for _, image := range backup {
+		if now, ok := currentByID[image.ImageID]; ok {
+			pairs = append(pairs, [2]ImageMetadata{image, now})
+			matched[now.ImageID] = true
+			continue
+		}
+		unmatched = append(unmatched, image)
+	}
+
+	// Images recreated under a new ID keep their type and name
+	nameKey := func(image ImageMetadata) string { return image.Type + "/" + image.ImageName }
+	candidates := make(map[string][]ImageMetadata)
+	for _, image := range current {
+		if !matched[image.ImageID] {
+			candidates[nameKey(image)] = append(candidates[nameKey(image)], image)
+		}
+	}
+	backupNames := make(map[string]int)
+	for _, image := range unmatched {
+		backupNames[nameKey(image)]++
+	}
+	for _, image := range unmatched {
+		if found := candidates[nameKey(image)]; len(found) == 1 && backupNames[nameKey(image)] == 1 {
+			pairs = append(pairs, [2]ImageMetadata{image, found[0]})
+			matched[found[0].ImageID] = true
+			continue
+		}
+		drift.Missing = append(drift.Missing, image)
+	}
+	for _, image := range current {
+		if !matched[image.ImageID] {
+			drift.Added = append(drift.Added, image)
+		}
+	}
+
+	for _, pair := range pairs {
+		fields := compareImageMetadataFields(pair[0], pair[1], opts)
+		if len(fields) == 0 {
+			drift.Unchanged++
+			continue
+		}
+		drift.Changed = append(drift.Changed, ImageMetadataChange{ImageID: pair[0].ImageID, ImageName: pair[0].ImageName, Fields: fields})
+	}
+
+	sort.SliceStable(drift.Missing, func(i, j int) bool { return drift.Missing[i].ImageID < drift.Missing[j].ImageID })
+	sort.SliceStable(drift.Added, func(i, j int) bool { return drift.Added[i].ImageID < drift.Added[j].ImageID })
+	sort.SliceStable(drift.Changed, func(i, j int) bool { return drift.Changed[i].ImageID < drift.Changed[j].ImageID })
+	return drift
+}
+
+// compareImageMetadataFields returns the fields that differ between the backup and
+// the current metadata of an image
+func compareImageMetadataFields(backup, current ImageMetadata, opts ImageMetadataCompareOptions) []ImageFieldDiff {
+	var fields []ImageFieldDiff
+	add := func(field, left, right string) {
+		if left != right {
+			fields = append(fields, ImageFieldDiff{Field: field, Left: left, Right: right, Differs: true})
+		}
+	}
+
+	add("ID", backup.ImageID, current.ImageID)
+	add("Name", backup.ImageName, current.ImageName)
+	add("Type", backup.Type, current.Type)
+	if !opts.IgnoreStatus {
+		add("Status", backup.Status, current.Status)
+	}
+	add("Base Image", FormatSourceImage(backup.ImageInfo), FormatSourceImage(current.ImageInfo))
+	add("Version", valueOrDash(backup.Version), valueOrDash(current.Version))
+	add("Digest", valueOrDash(backup.Digest), valueOrDash(current.Digest))
+	add("Resources", imageMetadataResources(backup), imageMetadataResources(current))
+	if opts.Manifests {
+		add("Platforms", manifestPlatforms(client.ImageManifestData{Platforms: backup.Platforms}), manifestPlatforms(client.ImageManifestData{Platforms: current.Platforms}))
+		for _, key := range labelKeys(backup.Labels, current.Labels) {
+			add("Label "+key, labelValue(backup.Labels, key), labelValue(current.Labels, key))
+		}
+		add("Dockerfile", valueOrDash(backup.DockerfileDigest), valueOrDash(current.DockerfileDigest))
+	}
+	return fields
+}
+
+// imageMetadataResources describes the resources of an activated image, e.g. 2c4g
+func imageMetadataResources(image ImageMetadata) string {
+	return manifestResources(client.ImageManifestData{CPU: image.CPU, Memory: image.Memory})
+}
+
+// parseImageMetadataTypes converts the --type flag into the image types to export
+func parseImageMetadataTypes(value string) ([]string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "all":
+		return []string{"User", "System"}, nil
+	case "user":
+		return []string{"User"}, nil
+	case "system":
+		return []string{"System"}, nil
+	}
+	return nil, printErrorMessage(
+		fmt.Sprintf("[ERROR] Invalid --type value '%s'", value),
+		"",
+		"[TIP] Usage: --type <user|system|all>",
+		"[NOTE] Example: agbcloud image export-metadata --type user --file backup.json",
+	)
+}
+
+// collectImageMetadata lists all images of the given types and completes the User
+// images with their manifests. The second result is false when the server does not
+// provide manifests.
+func collectImageMetadata(ctx context.Context, out io.Writer, apiClient *client.APIClient, loginToken, sessionId string, types []string) ([]ImageMetadata, bool, error) {
+	images := []ImageMetadata{}
+	manifests := true
+	for _, imageType := range types {
+		fmt.Fprintf(out, "[SEARCH] Fetching %s images...\n", imageType)
+		list, err := listAllImages(ctx, apiClient, loginToken, sessionId, client.ImageListOptions{ImageType: imageType})
+		if err != nil {
+			return nil, false, requestError(out, "failed to list "+imageType+" images", nil, err)
+		}
+		for _, image := range list {
+			entry := ImageMetadata{ImageInfo: image}
+			if imageType == "User" && manifests {
+				resp, httpResp, err := apiClient.ImageAPI.GetImageManifest(ctx, loginToken, sessionId, image.ImageID)
+				var apiErr *client.GenericOpenAPIError
+				switch {
+				case err == nil:
+					entry.Labels = resp.Data.Labels
+					entry.Platforms = resp.Data.Platforms
+					entry.DockerfileDigest = dockerfileDigest(resp.Data)
+					if entry.DockerfileDigest == "-" {
+						entry.DockerfileDigest = ""
+					}
+				case errors.As(err, &apiErr) && httpResp != nil &&
+					(httpResp.StatusCode == http.StatusNotFound || httpResp.StatusCode == http.StatusNotImplemented):
+					manifests = false
+				default:
+					return nil, false, requestError(out, "failed to get the manifest of image "+image.ImageID, httpResp, err)
+				}
+			}
+			images = append(images, entry)
+		}
+	}
+	if !manifests {
+		// Labels read before the server stopped answering are incomplete
+		for i := range images {
+			images[i].Labels, images[i].Platforms, images[i].DockerfileDigest = nil, nil, ""
+		}
+	}
+	return images, manifests, nil
+}
+
+// readImageMetadataBackup reads a backup written by 'image export-metadata'
+func readImageMetadataBackup(file string) (ImageMetadataBackup, error) {
+	var backup ImageMetadataBackup
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return backup, err
+	}
+	if err := json.Unmarshal(data, &backup); err != nil {
+		return backup, fmt.Errorf("%s is not a metadata backup: %w", file, err)
+	}
+	if backup.Version != imageMetadataBackupVersion {
+		return backup, fmt.Errorf("%s has unsupported backup version %d (supported: %d)", file, backup.Version, imageMetadataBackupVersion)
+	}
+	return backup, nil
+}
+
+func runImageExportMetadata(cmd *cobra.Command, args []string) error {
+	file, _ := cmd.Flags().GetString("file")
+	typeValue, _ := cmd.Flags().GetString("type")
+
+	// "--output backup.json" names the file; the backup itself is always JSON
+	if file == "" && cmd.Flags().Changed("output") {
+		value, _ := cmd.Flags().GetString("output")
+		if _, err := output.ParseFormat(value); err != nil {
+			file = value
+		}
+	}
+	types, err := parseImageMetadataTypes(typeValue)
+	if err != nil {
+		return err
+	}
+
+	// Progress goes to stderr while the backup is written to stdout
+	var out io.Writer = os.Stdout
+	if file == "" {
+		out = os.Stderr
+	}
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	apiClient := client.NewFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 5*time.Minute)
+	defer cancel()
+
+	images, manifests, err := collectImageMetadata(ctx, out, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, types)
+	if err != nil {
+		return err
+	}
+	if !manifests {
+		fmt.Fprintln(out, "[NOTE] This AgbCloud endpoint does not provide image manifests; labels, platforms and Dockerfile digests are not exported")
+	}
+
+	backup := ImageMetadataBackup{
+		Version:    imageMetadataBackupVersion,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Endpoint:   cfg.EffectiveEndpoint(),
+		Types:      types,
+		Manifests:  manifests,
+		Images:     images,
+	}
+	if file == "" {
+		return output.Write(resultOutput(), output.FormatJSON, backup)
+	}
+
+	data, err := json.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(file, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	fmt.Fprintf(out, "[OK] Exported the metadata of %d image(s) to %s\n", len(images), file)
+	fmt.Fprintf(out, "[TIP] Check for drift later with: agbcloud image diff-metadata %s\n", file)
+	return nil
+}
+
+func runImageDiffMetadata(cmd *cobra.Command, args []string) error {
+	file := args[0]
+	ignoreStatus, _ := cmd.Flags().GetBool("ignore-status")
+	failOnDrift, _ := cmd.Flags().GetBool("fail-on-drift")
+
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	out := progressWriter(outputFormat)
+
+	backup, err := readImageMetadataBackup(file)
+	if err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Failed to read the backup: %v", err),
+			"",
+			"[TIP] Create a backup with: agbcloud image export-metadata --file backup.json",
+		)
+	}
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	apiClient := client.NewFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 5*time.Minute)
+	defer cancel()
+
+	current, manifests, err := collectImageMetadata(ctx, out, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, backup.Types)
+	if err != nil {
+		return err
+	}
+	drift := CompareImageMetadata(backup.Images, current, ImageMetadataCompareOptions{
+		Manifests:    backup.Manifests && manifests,
+		IgnoreStatus: ignoreStatus,
+	})
+
+	if outputFormat.IsStructured() {
+		if err := writeResult(outputFormat, drift); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintf(out, "[DIFF] Comparing %s (exported %s) with the current images\n", file, formatTimestamp(backup.ExportedAt))
+		if backup.Manifests != manifests {
+			fmt.Fprintln(out, "[NOTE] Image manifests are only available on one side; labels, platforms and Dockerfiles are not compared")
+		}
+		printImageMetadataDrift(out, drift)
+	}
+
+	if drift.Drifted() && failOnDrift {
+		return fmt.Errorf("the images drifted from %s (--fail-on-drift)", file)
+	}
+	return nil
+}
+
+// printImageMetadataDrift prints the missing, added and changed images followed by a summary
+func printImageMetadataDrift(w io.Writer, drift ImageMetadataDrift) {
+	for _, image := range drift.Missing {
+		fmt.Fprintf(w, "[WARN]  Missing: %s (%s) is in the backup but no longer exists\n", image.ImageID, image.ImageName)
+	}
+	for _, image := range drift.Added {
+		fmt.Fprintf(w, "[INFO]  Added: %s (%s) is not in the backup\n", image.ImageID, image.ImageName)
+	}
+	for _, change := range drift.Changed {
+		fmt.Fprintf(w, "[DIFF] %s (%s):\n", change.ImageID, change.ImageName)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "FIELD\tBACKUP\tCURRENT")
+		fmt.Fprintln(tw, "-----\t------\t-------")
+		for _, field := range change.Fields {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", field.Field, field.Left, field.Right)
+		}
+		tw.Flush()
+		fmt.Fprintln(w)
+	}
+
+	if !drift.Drifted() {
+		fmt.Fprintf(w, "[OK] No drift: all %d image(s) match the backup\n", drift.Unchanged)
+		return
+	}
+	fmt.Fprintf(w, "[DATA] %d unchanged, %d changed, %d missing, %d added\n", drift.Unchanged, len(drift.Changed), len(drift.Missing), len(drift.Added))
+}
@@ -0,0 +1,247 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var imageOutdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "List user images built on an outdated base image",
+	Long: `Compare the System base image each user image was created from with the latest
+published version of that base, and list the images that should be rebuilt.
+
+Images created before base versions were recorded are reported as unknown.
+Use --all to also list images that are up to date.`,
+	Example: `  # List images whose base image has a newer version
+  agbcloud image outdated
+
+  # Include up-to-date images and print JSON
+  agbcloud image outdated --all -o json`,
+	Args: cobra.NoArgs,
+	RunE: runImageOutdated,
+}
+
+// Base image states reported by 'image outdated'
+const (
+	baseImageOutdated = "outdated"
+	baseImageUpToDate = "up-to-date"
+	baseImageUnknown  = "unknown"
+)
+
+func init() {
+	imageOutdatedCmd.Flags().Bool("all", false, "Also list images whose base image is up to date")
+
+	ImageCmd.AddCommand(imageOutdatedCmd)
+
+	registerOutputSchema(imageOutdatedCmd, outputSchema{
+		Command:     "image outdated",
+		Version:     1,
+		Description: "One entry per user image built from a System image. state is outdated, up-to-date or unknown.",
+		Result:      []OutdatedImage{},
+	})
+}
+
+// OutdatedImage compares the base of one user image with the latest base version
+type OutdatedImage struct {
+	ImageID            string `json:"imageId"`
+	ImageName          string `json:"imageName"`
+	SourceImageID      string `json:"sourceImageId"`
+	SourceImageVersion string `json:"sourceImageVersion"`
+	LatestVersion      string `json:"latestVersion"`
+	State              string `json:"state"`
+}
+
+// CompareImageVersions compares two image versions such as "1.2.0" and "1.10.0".
+// Dot-separated numeric parts are compared numerically, anything else as text.
+// The result is -1, 0 or 1 like strings.Compare.
+func CompareImageVersions(a, b string) int {
+	partsA := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	partsB := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var pa, pb string
+		if i < len(partsA) {
+			pa = partsA[i]
+		}
+		if i < len(partsB) {
+			pb = partsB[i]
+		}
+		na, errA := strconv.Atoi(pa)
+		nb, errB := strconv.Atoi(pb)
+		switch {
+		case errA == nil && errB == nil && na != nb:
+			if na < nb {
+				return -1
+			}
+			return 1
+		case (errA != nil || errB != nil) && pa != pb:
+			return strings.Compare(pa, pb)
+		}
+	}
+	return 0
+}
+
+// PlanOutdatedImages matches user images with the latest versions of their base images.
+// Images without a source image are skipped; the result is sorted by image ID.
+func PlanOutdatedImages(images []client.ImageInfo, latest []client.BaseImageVersion) []OutdatedImage {
+	latestByID := make(map[string]string, len(latest))
+	for _, base := range latest {
+		latestByID[base.ImageID] = base.LatestVersion
+	}
+
+	result := make([]OutdatedImage, 0, len(images))
+	for _, image := range images {
+		if image.SourceImageID == "" {
+			continue
+		}
+		entry := OutdatedImage{
+			ImageID:            image.ImageID,
+			ImageName:          image.ImageName,
+			SourceImageID:      image.SourceImageID,
+			SourceImageVersion: image.SourceImageVersion,
+			LatestVersion:      latestByID[image.SourceImageID],
+			State:              baseImageUnknown,
+		}
+		if entry.SourceImageVersion != "" && entry.LatestVersion != "" {
+			if CompareImageVersions(entry.SourceImageVersion, entry.LatestVersion) < 0 {
+				entry.State = baseImageOutdated
+			} else {
+				entry.State = baseImageUpToDate
+			}
+		}
+		result = append(result, entry)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].ImageID < result[j].ImageID })
+	return result
+}
+
+// sourceImageIDs returns the distinct source image IDs of the given images
+func sourceImageIDs(images []client.ImageInfo) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, image := range images {
+		if image.SourceImageID != "" && !seen[image.SourceImageID] {
+			seen[image.SourceImageID] = true
+			ids = append(ids, image.SourceImageID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func runImageOutdated(cmd *cobra.Command, args []string) error {
+	showAll, _ := cmd.Flags().GetBool("all")
+	defer startPager()()
+
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	out := progressWriter(outputFormat)
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	apiClient := client.NewFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 5*time.Minute)
+	defer cancel()
+
+	fmt.Fprintln(out, "[SEARCH] Fetching user images...")
+	images, err := listAllUserImages(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId)
+	if err != nil {
+		return requestError(out, "failed to list images", nil, err)
+	}
+
+	var latest []client.BaseImageVersion
+	if ids := sourceImageIDs(images); len(ids) > 0 {
+		fmt.Fprintf(out, "[SEARCH] Checking latest versions of %d base image(s)...\n", len(ids))
+		versionsResp, httpResp, err := apiClient.ImageAPI.GetBaseImageVersions(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, ids)
+		if err != nil {
+			return requestError(out, "failed to get base image versions", httpResp, err)
+		}
+		latest = versionsResp.Data
+	}
+
+	entries := PlanOutdatedImages(images, latest)
+	outdated := 0
+	shown := make([]OutdatedImage, 0, len(entries))
+	for _, entry := range entries {
+		if entry.State == baseImageOutdated {
+			outdated++
+		}
+		if showAll || entry.State != baseImageUpToDate {
+			shown = append(shown, entry)
+		}
+	}
+
+	if outputFormat.IsStructured() {
+		return writeResult(outputFormat, shown)
+	}
+
+	printOutdatedImages(out, shown)
+	if outdated == 0 {
+		fmt.Fprintln(out, "[OK] All images with a known base version are up to date")
+		return nil
+	}
+	fmt.Fprintf(out, "[WARN] %d image(s) are built on an outdated base image\n", outdated)
+	fmt.Fprintln(out, "[TIP] Rebuild them with 'agbcloud image create' to pick up the latest base image")
+	return nil
+}
+
+// printOutdatedImages prints the base image state of each user image
+func printOutdatedImages(w io.Writer, entries []OutdatedImage) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "[EMPTY] No images to report.")
+		return
+	}
+
+	fmt.Fprintln(w)
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, []string{
+			entry.ImageID,
+			entry.ImageName,
+			entry.SourceImageID,
+			valueOrDash(entry.SourceImageVersion),
+			valueOrDash(entry.LatestVersion),
+			entry.State,
+		})
+	}
+	printTable(w, []TableColumn{
+		{Header: "IMAGE ID", Width: 25, Truncate: true},
+		{Header: "IMAGE NAME", Width: 25, Truncate: true},
+		{Header: "BASE IMAGE", Width: 25, Truncate: true},
+		{Header: "BUILT ON", Width: 12},
+		{Header: "LATEST", Width: 12},
+		{Header: "STATE"},
+	}, rows)
+	fmt.Fprintln(w)
+}
+
+// valueOrDash returns value, or "-" when it is empty
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,431 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var imagePinCmd = &cobra.Command{
+	Use:   "pin <image-id>",
+	Short: "Pin an image to the top of image list",
+	Long: `Pin a frequently used image. Pinned images are listed first by 'agbcloud image list'
+and 'agbcloud image recent'.
+
+Pins are stored in the local configuration, separately for each profile. The image
+can be given by a unique prefix of its ID, by its content digest or by name with --name.`,
+	Example: `  agbcloud image pin img-7a8b9c1d0e
+  agbcloud image pin --name my-app`,
+	Args: imageReferenceArgs("agbcloud image pin <image-id>", "agbcloud image pin img-7a8b9c1d0e"),
+	RunE: runImagePin,
+}
+
+var imageUnpinCmd = &cobra.Command{
+	Use:   "unpin <image-id>",
+	Short: "Remove an image from the pinned images",
+	Long: `Remove an image from the pinned images of the active profile. The image can be
+given by a unique prefix of its pinned ID; the server is not contacted, so images
+that were deleted can be unpinned too.`,
+	Example: `  agbcloud image unpin img-7a8b9c1d0e`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return printErrorMessage(
+				"[ERROR] Expected exactly one argument: <image-id>",
+				"",
+				"[TIP] Usage: agbcloud image unpin <image-id>",
+				"[TIP] Run 'agbcloud image recent' to see the pinned images",
+			)
+		}
+		return nil
+	},
+	RunE: runImageUnpin,
+}
+
+var imageRecentCmd = &cobra.Command{
+	Use:   "recent",
+	Short: "Show pinned and recently used images",
+	Long: `Show the pinned images followed by the images most recently activated, deactivated
+or inspected with this profile, newest first. The view is built from local history,
+so the server is not contacted and statuses are not shown; use 'agbcloud image status'
+for the current status of an image.`,
+	Example: `  agbcloud image recent
+  agbcloud image recent --limit 5 -o json`,
+	Args: cobra.NoArgs,
+	RunE: runImageRecent,
+}
+
+const (
+	// recentImagesLimit is the number of recently used images remembered per profile and endpoint
+	recentImagesLimit = 20
+	// defaultRecentImagesShown is the number of recently used images shown by 'image recent'
+	defaultRecentImagesShown = 10
+)
+
+func init() {
+	imagePinCmd.Flags().String("name", "", "Select the image by name instead of ID")
+	imageRecentCmd.Flags().Int("limit", defaultRecentImagesShown, "Number of recently used images to show besides the pinned ones (0 for all remembered)")
+
+	ImageCmd.AddCommand(imagePinCmd)
+	ImageCmd.AddCommand(imageUnpinCmd)
+	ImageCmd.AddCommand(imageRecentCmd)
+
+	registerOutputSchema(imageRecentCmd, outputSchema{
+		Command:     "image recent",
+		Version:     1,
+		Description: "Pinned images followed by recently used ones. lastUsed and action are empty for pinned images that were not used with the CLI.",
+		Result:      []RecentImageItem{},
+	})
+}
+
+// RecentImage records the last use of an image with the CLI
+type RecentImage struct {
+	ImageID   string    `json:"imageId"`
+	ImageName string    `json:"imageName,omitempty"`
+	Action    string    `json:"action"`
+	UsedAt    time.Time `json:"usedAt"`
+}
+
+// RecentImageItem is the structured representation of an image in `image recent`
+type RecentImageItem struct {
+	ImageID   string `json:"imageId"`
+	ImageName string `json:"imageName"`
+	Pinned    bool   `json:"pinned"`
+	LastUsed  string `json:"lastUsed"`
+	Action    string `json:"action"`
+}
+
+// recentImagesMu serializes updates of the recent images file by parallel commands
+var recentImagesMu sync.Mutex
+
+// recentImages is the content of the recent images file: the most recently used
+// images, newest first, by profile and endpoint
+type recentImages struct {
+	Images map[string][]RecentImage `json:"images"`
+}
+
+// recentImagesPath returns the path of the recent images file
+func recentImagesPath() (string, error) {
+	configDir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "cache", "recent-images.json"), nil
+}
+
+// recentImagesKey identifies the images used with the active profile and endpoint;
+// image IDs differ between deployments
+func recentImagesKey() string {
+	profile, endpoint := imageListCacheScope()
+	return profile + "|" + endpoint
+}
+
+func readRecentImages(path string) (recentImages, error) {
+	recent := recentImages{Images: map[string][]RecentImage{}}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return recent, err
+	}
+	if err := json.Unmarshal(content, &recent); err != nil {
+		return recentImages{Images: map[string][]RecentImage{}}, fmt.Errorf("invalid recent images %s: %w", path, err)
+	}
+	if recent.Images == nil {
+		recent.Images = map[string][]RecentImage{}
+	}
+	return recent, nil
+}
+
+// RecordRecentImage remembers that an image was used. An empty name keeps the name
+// remembered from an earlier use.
+func RecordRecentImage(imageId, imageName, action string) error {
+	path, err := recentImagesPath()
+	if err != nil {
+		return err
+	}
+	recentImagesMu.Lock()
+	defer recentImagesMu.Unlock()
+	recent, err := readRecentImages(path)
+	if err != nil && !os.IsNotExist(err) {
+		log.Debugf("Replacing unreadable recent images: %v", err)
+	}
+
+	key := recentImagesKey()
+	images := recent.Images[key]
+	if index := slices.IndexFunc(images, func(image RecentImage) bool { return image.ImageID == imageId }); index >= 0 {
+		if imageName == "" {
+			imageName = images[index].ImageName
+		}
+		images = slices.Delete(images, index, index+1)
+	}
+	images = append([]RecentImage{{ImageID: imageId, ImageName: imageName, Action: action, UsedAt: time.Now().UTC()}}, images...)
+	if len(images) > recentImagesLimit {
+		images = images[:recentImagesLimit]
+	}
+	recent.Images[key] = images
+
+	content, err := json.MarshalIndent(recent, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	// Write to a temporary file first so that a concurrent reader never sees half a file
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadRecentImages returns the images used with the active profile and endpoint, newest first
+func LoadRecentImages() ([]RecentImage, error) {
+	path, err := recentImagesPath()
+	if err != nil {
+		return nil, err
+	}
+	recent, err := readRecentImages(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return recent.Images[recentImagesKey()], nil
+}
+
+// recordRecentImage remembers the use of an image. The history is best-effort and
+// never fails the command.
+func recordRecentImage(imageId, imageName, action string) {
+	if err := RecordRecentImage(imageId, imageName, action); err != nil {
+		log.Debugf("Could not record the recently used image: %v", err)
+	}
+}
+
+// pinnedImageIDs returns the images pinned in the active profile; an unreadable
+// configuration has none
+func pinnedImageIDs() []string {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil
+	}
+	return cfg.PinnedImageIDs()
+}
+
+// SortPinnedFirst moves the pinned images to the front in the order they were
+// pinned, keeping the order of the others
+func SortPinnedFirst(images []client.ImageInfo, pinned []string) []client.ImageInfo {
+	if len(pinned) == 0 {
+		return images
+	}
+	sorted := slices.Clone(images)
+	slices.SortStableFunc(sorted, func(a, b client.ImageInfo) int {
+		return pinRank(pinned, a.ImageID) - pinRank(pinned, b.ImageID)
+	})
+	return sorted
+}
+
+// pinRank orders pinned images by pin position before all other images
+func pinRank(pinned []string, imageId string) int {
+	if index := slices.Index(pinned, imageId); index >= 0 {
+		return index
+	}
+	return len(pinned)
+}
+
+func runImagePin(cmd *cobra.Command, args []string) error {
+	imageId, byName := imageReferenceArg(cmd, args)
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	apiClient := client.NewFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+	defer cancel()
+
+	// The image may also be given by ID prefix, content digest or name
+	imageId, err = resolveImageArgument(ctx, os.Stdout, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, byName)
+	if err != nil {
+		return err
+	}
+
+	if !cfg.PinImage(imageId) {
+		fmt.Printf("[OK] Image %s is already pinned\n", imageId)
+		return nil
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	fmt.Printf("[PIN] Pinned image %s\n", imageId)
+	fmt.Println("[TIP] Pinned images are listed first by 'agbcloud image list' and 'agbcloud image recent'")
+	return nil
+}
+
+func runImageUnpin(cmd *cobra.Command, args []string) error {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	ref := args[0]
+	var matches []string
+	for _, pinned := range cfg.PinnedImageIDs() {
+		if pinned == ref {
+			matches = []string{pinned}
+			break
+		}
+		if strings.HasPrefix(pinned, ref) {
+			matches = append(matches, pinned)
+		}
+	}
+	if len(matches) == 0 {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Image '%s' is not pinned", ref),
+			"",
+			"[TIP] Run 'agbcloud image recent' to see the pinned images",
+		)
+	}
+	if len(matches) > 1 {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] '%s' matches %d pinned images: %s", ref, len(matches), strings.Join(matches, ", ")),
+			"",
+			"[TIP] Give more characters of the image ID",
+		)
+	}
+
+	cfg.UnpinImage(matches[0])
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	fmt.Printf("[OK] Unpinned image %s\n", matches[0])
+	return nil
+}
+
+// NewRecentImageItems combines the pinned images and the recently used ones, showing
+// at most limit recently used images that are not pinned (0 for all). Names of pinned
+// images that were not used come from cachedNames.
+func NewRecentImageItems(pinned []string, recent []RecentImage, cachedNames map[string]string, limit int) []RecentImageItem {
+	used := make(map[string]RecentImage, len(recent))
+	for _, image := range recent {
+		used[image.ImageID] = image
+	}
+
+	items := make([]RecentImageItem, 0, len(pinned)+len(recent))
+	for _, imageId := range pinned {
+		item := RecentImageItem{ImageID: imageId, ImageName: cachedNames[imageId], Pinned: true}
+		if image, ok := used[imageId]; ok {
+			item.LastUsed = image.UsedAt.Format(time.RFC3339)
+			item.Action = image.Action
+			if image.ImageName != "" {
+				item.ImageName = image.ImageName
+			}
+		}
+		items = append(items, item)
+	}
+
+	shown := 0
+	for _, image := range recent {
+		if slices.Contains(pinned, image.ImageID) {
+			continue
+		}
+		if limit > 0 && shown == limit {
+			break
+		}
+		shown++
+		items = append(items, RecentImageItem{
+			ImageID:   image.ImageID,
+			ImageName: image.ImageName,
+			LastUsed:  image.UsedAt.Format(time.RFC3339),
+			Action:    image.Action,
+		})
+	}
+	return items
+}
+
+// cachedImageNames returns the names of the images in the cached User image list
+func cachedImageNames() map[string]string {
+	names := map[string]string{}
+	profile, endpoint := imageListCacheScope()
+	entry, err := LoadCachedImageList(profile, endpoint, "User")
+	if err != nil || entry == nil {
+		return names
+	}
+	for _, image := range entry.Images {
+		names[image.ImageID] = image.ImageName
+	}
+	return names
+}
+
+func runImageRecent(cmd *cobra.Command, args []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+	if limit < 0 {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid --limit: %d", limit),
+			"",
+			"[TIP] Use a positive number, or 0 to show all remembered images",
+		)
+	}
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if err := applyTimeFormat(cmd); err != nil {
+		return err
+	}
+
+	defer startPager()()
+
+	recent, err := LoadRecentImages()
+	if err != nil {
+		log.Debugf("Could not read the recently used images: %v", err)
+	}
+	items := NewRecentImageItems(pinnedImageIDs(), recent, cachedImageNames(), limit)
+
+	if outputFormat.IsStructured() {
+		return writeResult(outputFormat, items)
+	}
+
+	if len(items) == 0 {
+		fmt.Println("[EMPTY] No pinned or recently used images.")
+		fmt.Println("[TIP] Pin an image with 'agbcloud image pin <image-id>'")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "IMAGE ID\tIMAGE NAME\tPINNED\tLAST USED\tACTION")
+	for _, item := range items {
+		pinned, lastUsed, action, name := "-", "-", "-", "-"
+		if item.Pinned {
+			pinned = "yes"
+		}
+		if item.LastUsed != "" {
+			lastUsed = formatTimestamp(item.LastUsed)
+			action = item.Action
+		}
+		if item.ImageName != "" {
+			name = item.ImageName
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", item.ImageID, name, pinned, lastUsed, action)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// platformPattern matches an OCI platform: os/arch with an optional variant, e.g. linux/arm64/v8
+var platformPattern = regexp.MustCompile(`^[a-z0-9]+/[a-z0-9_]+(/[a-z0-9]+)?$`)
+
+// ParsePlatforms parses the comma-separated --platform value of 'image create', e.g.
+// "linux/amd64,linux/arm64". Platforms are lower-cased and duplicates are dropped.
+func ParsePlatforms(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var platforms []string
+	for _, part := range strings.Split(value, ",") {
+		platform := strings.ToLower(strings.TrimSpace(part))
+		if platform == "" {
+			return nil, fmt.Errorf("empty platform in %q", value)
+		}
+		if !platformPattern.MatchString(platform) {
+			return nil, fmt.Errorf("invalid platform %q, expected <os>/<arch>[/<variant>], e.g. linux/arm64", part)
+		}
+		if !slices.Contains(platforms, platform) {
+			platforms = append(platforms, platform)
+		}
+	}
+	return platforms, nil
+}
+
+// UnsupportedPlatforms returns the platforms the server cannot build images for
+func UnsupportedPlatforms(platforms []string, capabilities client.ImageCapabilitiesData) []string {
+	var unsupported []string
+	for _, platform := range platforms {
+		if !capabilities.SupportsPlatform(platform) {
+			unsupported = append(unsupported, platform)
+		}
+	}
+	return unsupported
+}
+
+// checkPlatforms verifies that the server can build images for all platforms before
+// anything is uploaded
+func checkPlatforms(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string, platforms []string) error {
+	fmt.Printf("[SEARCH] Checking that %s can be built...\n", strings.Join(platforms, ", "))
+	capabilities, err := apiClient.Capabilities(ctx, loginToken, sessionId)
+	if errors.Is(err, client.ErrCapabilitiesUnsupported) {
+		// Such servers would ignore the platforms and build only the default one
+		return printErrorMessage(
+			"[ERROR] This AgbCloud endpoint does not support multi-platform builds",
+			"",
+			"[TIP] Remove --platform to build the image for the default platform",
+		)
+	}
+	if err != nil {
+		return requestError(os.Stdout, "failed to check supported platforms", nil, err)
+	}
+	if err := requireServerFeature(capabilities, client.FeatureMultiPlatform, "Remove --platform to build the image for the default platform"); err != nil {
+		return err
+	}
+
+	if unsupported := UnsupportedPlatforms(platforms, capabilities); len(unsupported) > 0 {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Unsupported platform(s): %s", strings.Join(unsupported, ", ")),
+			"",
+			fmt.Sprintf("[TIP] Supported platforms: %s", strings.Join(capabilities.Platforms, ", ")),
+		)
+	}
+	return nil
+}
+
+// formatPlatformBuilds renders the build status of each platform, one line per platform
+func formatPlatformBuilds(builds []client.ImagePlatformBuild) []string {
+	lines := make([]string, 0, len(builds))
+	for _, build := range builds {
+		line := fmt.Sprintf("[PLATFORM] %s: %s", build.Platform, build.Status)
+		if build.TaskMsg != "" {
+			line += " - " + build.TaskMsg
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
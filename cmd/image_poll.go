@@ -0,0 +1,307 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/imagestatus"
+	"github.com/agbcloud/agbcloud-cli/internal/poll"
+	"github.com/agbcloud/agbcloud-cli/internal/progress"
+	"github.com/agbcloud/agbcloud-cli/pkg/events"
+)
+
+const (
+	// imagePollInterval is the wait between two status checks
+	imagePollInterval = 5 * time.Second
+	// imagePollTimeout bounds how long an image operation is monitored
+	imagePollTimeout = 45 * time.Minute
+	// imagePollMaxErrors is the number of failed status checks in a row that are tolerated
+	imagePollMaxErrors = 5
+)
+
+// newImagePoller returns the poller shared by all image status loops. Failed
+// status checks are reported as warnings and retried. name identifies the checks
+// in traces.
+func newImagePoller(name string, warnings *WarningRecorder) poll.Poller {
+	return poll.Poller{
+		Name:      name,
+		Interval:  poll.Constant(imagePollInterval),
+		Timeout:   imagePollTimeout,
+		MaxErrors: imagePollMaxErrors,
+		Progress: func(p poll.Progress) {
+			if p.Err != nil {
+				warnings.Warn("%v", p.Err)
+			}
+		},
+	}
+}
+
+// newPollStatusLine returns the status line of an image status loop. With verbose
+// every status check is printed, as with --verbose-poll.
+func newPollStatusLine(verbose bool) *progress.StatusLine {
+	return progress.NewStatusLine(os.Stdout, progress.StatusOptions{Repeat: verbose})
+}
+
+// withStatusLine pauses the spinner of status while check runs, so that check can
+// print, and resumes it while waiting for the next check. After a failed check the
+// spinner stays paused for the warning printed by the poller.
+func withStatusLine(status *progress.StatusLine, check poll.Condition) poll.Condition {
+	return func(ctx context.Context) (bool, error) {
+		status.Pause()
+		done, err := check(ctx)
+		if !done && err == nil {
+			status.Resume()
+		}
+		return done, err
+	}
+}
+
+// statusCheckError describes a failed status request
+func statusCheckError(httpResp *http.Response, err error) error {
+	var failure *client.APIError
+	if errors.As(err, &failure) {
+		return fmt.Errorf("status check failed: %s (request ID: %s)", failure.Code, failure.RequestID)
+	}
+	var apiErr *client.GenericOpenAPIError
+	if errors.As(err, &apiErr) && httpResp != nil {
+		return fmt.Errorf("failed to check status (HTTP %d): %s", httpResp.StatusCode, apiErr.Error())
+	}
+	return fmt.Errorf("failed to check status: %w", err)
+}
+
+// pollError turns the result of an unsuccessful poll into the command error
+func pollError(operation string, err error) error {
+	if errors.Is(err, poll.ErrTimeout) {
+		return fmt.Errorf("timeout waiting for %s to complete: %w", operation, err)
+	}
+	return err
+}
+
+// statusTransitions publishes a poll transition event whenever the status of
+// subject differs from the one seen by the previous check
+type statusTransitions struct {
+	subject string
+	last    string
+}
+
+// observe records the status seen by a check
+func (t *statusTransitions) observe(ctx context.Context, status string) {
+	if status == t.last {
+		return
+	}
+	events.Publish(ctx, events.Event{Kind: events.PollTransition, Subject: t.subject, From: t.last, To: status})
+	t.last = status
+}
+
+// pollImageTask polls the image task status until completion or failure, and returns
+// the ID of the created image when the server reports it. The status and task message
+// are printed when they change, or on every check when verbose.
+func pollImageTask(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, taskId string, warnings *WarningRecorder, verbose bool) (string, error) {
+	statusLine := newPollStatusLine(verbose)
+	defer statusLine.Stop()
+
+	// Platform statuses are printed when they change, to keep multi-platform output short
+	var lastPlatforms []string
+	var imageId string
+	transitions := statusTransitions{subject: taskId}
+	err := newImagePoller("poll image task", warnings).Until(ctx, withStatusLine(statusLine, func(ctx context.Context) (bool, error) {
+		taskResp, httpResp, err := apiClient.ImageAPI.GetImageTask(ctx, loginToken, sessionId, taskId)
+		if err != nil {
+			return false, statusCheckError(httpResp, err)
+		}
+
+		status := taskResp.Data.Status
+		message := taskResp.Data.TaskMsg
+		transitions.observe(ctx, status)
+
+		line := "[DATA] Status: " + status
+		if message != "" {
+			line += " - " + message
+		}
+		statusLine.Update(line)
+		if platforms := formatPlatformBuilds(taskResp.Data.Platforms); !slices.Equal(platforms, lastPlatforms) {
+			for _, line := range platforms {
+				fmt.Println(line)
+			}
+			lastPlatforms = platforms
+		}
+
+		switch status {
+		case "Finished":
+			if taskResp.Data.ImageID != nil {
+				imageId = *taskResp.Data.ImageID
+				fmt.Printf("[SUCCESS] Image created successfully! Image ID: %s\n", imageId)
+			} else {
+				fmt.Println("[SUCCESS] Image created successfully!")
+			}
+			if taskResp.Data.Digest != "" {
+				fmt.Printf("[DATA] Digest: %s\n", taskResp.Data.Digest)
+			}
+			return true, nil
+		case "Failed":
+			fmt.Printf("[SEARCH] Request ID: %s\n", taskResp.RequestID)
+			return false, poll.Stop(fmt.Errorf("%w: %s", errImageTaskFailed, message))
+		case "Inline", "Preparing":
+			// Waiting for or in processing
+			return false, nil
+		default:
+			fmt.Printf("[REFRESH] Unknown status '%s', continuing to monitor...\n", status)
+			return false, nil
+		}
+	}))
+	statusLine.Stop()
+	if err != nil {
+		fmt.Printf("[DOC] Task ID: %s\n", taskId)
+		return "", pollError("image creation", err)
+	}
+	return imageId, nil
+}
+
+// pollImageStatus polls the status of one image until evaluate reports the
+// operation as done or failed. Failures must be wrapped with poll.Stop.
+// Cancelling ctx stops monitoring; the operation continues on the server.
+// The status is printed when it changes, or on every check when verbose.
+func pollImageStatus(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, imageId, operation string, verbose bool, evaluate func(ctx context.Context, status, formattedStatus string) (bool, error)) error {
+	statusLine := newPollStatusLine(verbose)
+	defer statusLine.Stop()
+
+	transitions := statusTransitions{subject: imageId}
+	// Query specific image status using ListImages with imageIds filter. The checks
+	// are conditional, so an unchanged status costs no response body.
+	lister := newConditionalImageList(apiClient, loginToken, sessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{imageId}})
+	err := newImagePoller("poll image "+operation, nil).Until(ctx, withStatusLine(statusLine, func(ctx context.Context) (bool, error) {
+		listResp, _, httpResp, err := lister.fetch(ctx)
+		if err != nil {
+			return false, statusCheckError(httpResp, err)
+		}
+		if len(listResp.Data.Images) == 0 {
+			return false, fmt.Errorf("image not found: %s", imageId)
+		}
+
+		status := listResp.Data.Images[0].Status
+		transitions.observe(ctx, status)
+		formattedStatus := FormatImageStatus(status)
+		statusLine.Update("[DATA] Status: " + formattedStatus)
+
+		done, err := evaluate(ctx, status, formattedStatus)
+		switch {
+		case err != nil:
+			fmt.Printf("[SEARCH] Request ID: %s\n", listResp.RequestID)
+		case done:
+			fmt.Printf("[DATA] Final Status: %s\n", formattedStatus)
+		}
+		return done, err
+	}))
+	statusLine.Stop()
+	if err != nil {
+		fmt.Printf("[DOC] Image ID: %s\n", imageId)
+		return pollError("image "+operation, err)
+	}
+	return nil
+}
+
+// errImageActivationFailed is returned when the server reports that an activation failed
+var errImageActivationFailed = errors.New("image activation failed")
+
+// pollImageActivationStatus polls the image activation status until completion or failure
+func pollImageActivationStatus(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, imageId string, verbose bool) error {
+	queue := NewActivationQueueReporter(apiClient, loginToken, sessionId, imageId, os.Stdout)
+	return pollImageStatus(ctx, apiClient, loginToken, sessionId, imageId, "activation", verbose, func(ctx context.Context, status, formattedStatus string) (bool, error) {
+		switch imagestatus.Status(status) {
+		case imagestatus.Published:
+			fmt.Printf("[SUCCESS] Image activated successfully! Image ID: %s\n", imageId)
+			return true, nil
+		case imagestatus.Failed, imagestatus.Ceased:
+			return false, poll.Stop(fmt.Errorf("%w with status: %s", errImageActivationFailed, formattedStatus))
+		case imagestatus.Deploying:
+			// Tell users waiting for capacity where they stand
+			queue.Report(ctx)
+			return false, nil
+		default:
+			fmt.Printf("[REFRESH] Unknown status '%s', continuing to monitor...\n", formattedStatus)
+			return false, nil
+		}
+	})
+}
+
+// graceCountdown reports how much of the grace period of a deactivation is left;
+// the zero value reports nothing
+type graceCountdown struct {
+	deadline time.Time
+	over     bool
+}
+
+// newGraceCountdown starts the countdown of a grace period granted now
+func newGraceCountdown(grace time.Duration, now time.Time) *graceCountdown {
+	if grace <= 0 {
+		return &graceCountdown{}
+	}
+	return &graceCountdown{deadline: now.Add(grace)}
+}
+
+// report prints the time left for the workload to shut down, and once that the
+// grace period is over
+func (g *graceCountdown) report(now time.Time) {
+	if g.deadline.IsZero() || g.over {
+		return
+	}
+	if left := g.deadline.Sub(now); left > 0 {
+		fmt.Printf("[WAIT] Grace period: %s left for the workload to shut down\n", left.Round(time.Second))
+		return
+	}
+	g.over = true
+	fmt.Println("[WAIT] Grace period over, stopping the instance...")
+}
+
+// pollImageDeactivationStatus polls the image deactivation status until completion or
+// failure, counting down the grace period granted to the workload, if any
+func pollImageDeactivationStatus(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, imageId string, verbose bool, countdown *graceCountdown) error {
+	return pollImageStatus(ctx, apiClient, loginToken, sessionId, imageId, "deactivation", verbose, func(ctx context.Context, status, formattedStatus string) (bool, error) {
+		switch imagestatus.Status(status) {
+		case imagestatus.Available:
+			fmt.Printf("[SUCCESS] Image deactivated successfully! Image ID: %s\n", imageId)
+			return true, nil
+		case imagestatus.Failed:
+			return false, poll.Stop(fmt.Errorf("image deactivation failed with status: %s", formattedStatus))
+		case imagestatus.Deleting:
+			countdown.report(time.Now())
+			return false, nil
+		case imagestatus.Published:
+			// Deactivation may take a moment to be reflected
+			fmt.Printf("[REFRESH] Image still activated, continuing to monitor deactivation...\n")
+			return false, nil
+		default:
+			fmt.Printf("[REFRESH] Unknown status '%s', continuing to monitor...\n", formattedStatus)
+			return false, nil
+		}
+	})
+}
+
+// pollImageRestartStatus polls the status of a restarting image until it is activated again
+func pollImageRestartStatus(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, imageId string, verbose bool) error {
+	return pollImageStatus(ctx, apiClient, loginToken, sessionId, imageId, "restart", verbose, func(ctx context.Context, status, formattedStatus string) (bool, error) {
+		switch imagestatus.Status(status) {
+		case imagestatus.Published:
+			fmt.Printf("[SUCCESS] Image restarted successfully! Image ID: %s\n", imageId)
+			return true, nil
+		case imagestatus.Failed, imagestatus.Ceased:
+			return false, poll.Stop(fmt.Errorf("image restart failed with status: %s", formattedStatus))
+		case imagestatus.Available, imagestatus.Deleting:
+			return false, poll.Stop(fmt.Errorf("image was deactivated during the restart (status: %s)", formattedStatus))
+		case imagestatus.Restarting, imagestatus.Deploying:
+			return false, nil
+		default:
+			fmt.Printf("[REFRESH] Unknown status '%s', continuing to monitor...\n", formattedStatus)
+			return false, nil
+		}
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// activationQueueLongWait is the estimated wait above which users are told that they
+// can stop monitoring and check back later
+const activationQueueLongWait = 10 * time.Minute
+
+// ActivationQueueReporter prints where an activation stands in the deployment queue
+// while the image is activating. Servers without the queue endpoint are detected on
+// the first request and not asked again.
+type ActivationQueueReporter struct {
+	apiClient  *client.APIClient
+	loginToken string
+	sessionId  string
+	imageId    string
+	out        io.Writer
+
+	unsupported bool
+	reported    bool
+	last        client.ImageQueueData
+	tipShown    bool
+}
+
+// NewActivationQueueReporter returns a reporter for the activation of imageId that prints to out
+func NewActivationQueueReporter(apiClient *client.APIClient, loginToken, sessionId, imageId string, out io.Writer) *ActivationQueueReporter {
+	return &ActivationQueueReporter{apiClient: apiClient, loginToken: loginToken, sessionId: sessionId, imageId: imageId, out: out}
+}
+
+// Report fetches the queue position and prints it when it changed since the last report.
+// Queue information is advisory, so failures are only logged.
+func (r *ActivationQueueReporter) Report(ctx context.Context) {
+	if r.unsupported {
+		return
+	}
+	queueResp, httpResp, err := r.apiClient.ImageAPI.GetImageQueue(ctx, r.loginToken, r.sessionId, r.imageId)
+	if err != nil {
+		var apiErr *client.GenericOpenAPIError
+		if errors.As(err, &apiErr) && httpResp != nil && (httpResp.StatusCode == http.StatusNotFound || httpResp.StatusCode == http.StatusNotImplemented) {
+			log.Debugf("The server does not report activation queue positions (HTTP %d)", httpResp.StatusCode)
+			r.unsupported = true
+			return
+		}
+		log.Debugf("Could not get the activation queue position: %v", err)
+		return
+	}
+
+	queue := queueResp.Data
+	changed := !r.reported || queue.Position != r.last.Position || queue.EstimatedWaitSeconds != r.last.EstimatedWaitSeconds
+	wasQueued := r.reported && r.last.Queued()
+	r.reported, r.last = true, queue
+	if !changed {
+		return
+	}
+
+	if !queue.Queued() {
+		// Activations that never waited need no queue report
+		if wasQueued {
+			fmt.Fprintln(r.out, "[QUEUE] Capacity assigned, the image is being deployed")
+		}
+		return
+	}
+	fmt.Fprintf(r.out, "[QUEUE] %s\n", FormatActivationQueue(queue))
+
+	if wait, ok := queue.EstimatedWait(); ok && wait >= activationQueueLongWait && !r.tipShown {
+		r.tipShown = true
+		fmt.Fprintln(r.out, "[TIP] Capacity is constrained. Activation continues on the server if you stop monitoring (Ctrl+C);")
+		fmt.Fprintf(r.out, "      run 'agbcloud image activate %s' later to check on it\n", r.imageId)
+	}
+}
+
+// FormatActivationQueue describes a queued activation, e.g. "Position 3 of 12, estimated wait ~5m"
+func FormatActivationQueue(queue client.ImageQueueData) string {
+	text := fmt.Sprintf("Position %d", queue.Position)
+	if queue.QueueLength >= queue.Position {
+		text += fmt.Sprintf(" of %d", queue.QueueLength)
+	}
+	text += " in the activation queue, "
+	wait, ok := queue.EstimatedWait()
+	if !ok {
+		return text + "no estimated wait yet"
+	}
+	return text + "estimated wait " + formatQueueWait(wait)
+}
+
+// formatQueueWait renders an estimated wait rounded to the minute, e.g. "~1h20m"
+func formatQueueWait(wait time.Duration) string {
+	if wait < time.Minute {
+		return "<1m"
+	}
+	wait = wait.Round(time.Minute)
+	hours, minutes := int(wait/time.Hour), int(wait%time.Hour/time.Minute)
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("~%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("~%dh", hours)
+	default:
+		return fmt.Sprintf("~%dh%dm", hours, minutes)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// maxListedCandidates limits the images listed when a reference is ambiguous
+const maxListedCandidates = 10
+
+// MatchImagePrefix returns the images whose ID starts with prefix. An image whose ID
+// equals prefix is the only match. IDs are compared case-insensitively.
+func MatchImagePrefix(images []client.ImageInfo, prefix string) []client.ImageInfo {
+	prefix = strings.ToLower(prefix)
+	var matches []client.ImageInfo
+	for _, image := range images {
+		id := strings.ToLower(image.ImageID)
+		if id == prefix {
+			return []client.ImageInfo{image}
+		}
+		if strings.HasPrefix(id, prefix) {
+			matches = append(matches, image)
+		}
+	}
+	return matches
+}
+
+// MatchImageName returns the images named name, ignoring case. If there are none, the
+// images whose name contains name are returned instead.
+func MatchImageName(images []client.ImageInfo, name string) []client.ImageInfo {
+	var matches []client.ImageInfo
+	for _, image := range images {
+		if strings.EqualFold(image.ImageName, name) {
+			matches = append(matches, image)
+		}
+	}
+	if len(matches) > 0 {
+		return matches
+	}
+	return filterImagesByName(images, name)
+}
+
+// imageReferenceArgs validates the arguments of commands that take an <image-id>, or
+// the image name with --name instead
+func imageReferenceArgs(usage, example string) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		switch {
+		case name != "" && len(args) > 0:
+			return printErrorMessage(
+				"[ERROR] Give either <image-id> or --name, not both",
+				"",
+				"[TIP] Usage: "+usage,
+				"[NOTE] Example: "+example,
+			)
+		case name != "":
+			return nil
+		case len(args) == 0:
+			return printErrorMessage(
+				"[ERROR] Missing required argument: <image-id>",
+				"",
+				"[TIP] Usage: "+usage,
+				"[NOTE] Example: "+example,
+			)
+		case len(args) > 1:
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Too many arguments provided. Expected 1 argument (image ID), got %d", len(args)),
+				"",
+				"[TIP] Usage: "+usage,
+				"[NOTE] Example: "+example,
+			)
+		}
+		return nil
+	}
+}
+
+// imageReferenceArg returns the image given to a command validated by imageReferenceArgs,
+// and whether it is a name given with --name
+func imageReferenceArg(cmd *cobra.Command, args []string) (string, bool) {
+	if name, _ := cmd.Flags().GetString("name"); name != "" {
+		return name, true
+	}
+	return args[0], false
+}
+
+// resolveImageArgument turns the image given to a command into an image ID; see
+// resolveImageName and resolveImageReference. Lookups are reported on out.
+func resolveImageArgument(ctx context.Context, out io.Writer, apiClient *client.APIClient, loginToken, sessionId, ref string, byName bool) (string, error) {
+	if byName {
+		return resolveImageName(ctx, out, apiClient, loginToken, sessionId, ref)
+	}
+	return resolveImageReference(ctx, out, apiClient, loginToken, sessionId, ref)
+}
+
+// resolveImageReference turns an image reference given on the command line into an
+// image ID. Digests are looked up with resolveImageDigest. Other references are image
+// IDs, or a unique prefix of the ID of one of the user's images.
+func resolveImageReference(ctx context.Context, out io.Writer, apiClient *client.APIClient, loginToken, sessionId, ref string) (string, error) {
+	if IsImageDigest(ref) {
+		return resolveImageDigest(ctx, out, apiClient, loginToken, sessionId, ref)
+	}
+
+	// A complete image ID needs no further lookup. Failed lookups leave the reference
+	// unchanged, so the command reports the error of its own request.
+	listResp, _, err := apiClient.ImageAPI.ListImages(ctx, loginToken, sessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{ref}})
+	if err != nil || (len(listResp.Data.Images) > 0 && listResp.Data.Images[0].ImageID == ref) {
+		return ref, nil
+	}
+	images, err := listAllUserImages(ctx, apiClient, loginToken, sessionId)
+	if err != nil {
+		return ref, nil
+	}
+
+	matches := MatchImagePrefix(images, ref)
+	switch len(matches) {
+	case 0:
+		// Not one of the user's images; the command explains why
+		return ref, nil
+	case 1:
+		fmt.Fprintf(out, "[OK] '%s' is image %s (%s)\n", ref, matches[0].ImageID, matches[0].ImageName)
+		return matches[0].ImageID, nil
+	}
+
+	lines := []string{fmt.Sprintf("[ERROR] Image ID prefix '%s' matches %d images:", ref, len(matches))}
+	lines = append(lines, imageCandidates(matches)...)
+	lines = append(lines, "", "[TIP] Use more characters of the image ID")
+	return "", printErrorMessage(lines...)
+}
+
+// resolveImageName returns the ID of the user's image named name. Names are matched
+// ignoring case; without an exact match, a unique image whose name contains name is used.
+func resolveImageName(ctx context.Context, out io.Writer, apiClient *client.APIClient, loginToken, sessionId, name string) (string, error) {
+	fmt.Fprintf(out, "[SEARCH] Looking up image named '%s'...\n", name)
+	images, err := listAllImages(ctx, apiClient, loginToken, sessionId, client.ImageListOptions{ImageType: "User", NameContains: name})
+	if err != nil {
+		return "", fmt.Errorf("failed to look up image name: %w", err)
+	}
+
+	matches := MatchImageName(images, name)
+	switch len(matches) {
+	case 0:
+		return "", printErrorMessage(
+			fmt.Sprintf("[ERROR] No image named '%s'", name),
+			"",
+			fmt.Sprintf("[TIP] Run 'agbcloud image list --search %s' to find the image", name),
+		)
+	case 1:
+		fmt.Fprintf(out, "[OK] '%s' is image %s (%s)\n", name, matches[0].ImageID, matches[0].ImageName)
+		return matches[0].ImageID, nil
+	}
+
+	lines := []string{fmt.Sprintf("[ERROR] Name '%s' matches %d images:", name, len(matches))}
+	lines = append(lines, imageCandidates(matches)...)
+	lines = append(lines, "", "[TIP] Use the full image name, or give the image ID instead of --name")
+	return "", printErrorMessage(lines...)
+}
+
+// imageCandidates lists the images an ambiguous reference matches, at most
+// maxListedCandidates of them
+func imageCandidates(images []client.ImageInfo) []string {
+	var lines []string
+	for i, image := range images {
+		if i == maxListedCandidates {
+			lines = append(lines, fmt.Sprintf("• ... and %d more", len(images)-maxListedCandidates))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("• %s (%s) %s", image.ImageID, image.ImageName, FormatImageStatus(image.Status)))
+	}
+	return lines
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// resourceSpecPattern matches a CPU/memory spec such as 4c8g
+var resourceSpecPattern = regexp.MustCompile(`^(\d+)c(\d+)g$`)
+
+// ParseResourceSpec parses a CPU/memory spec such as "4c8g" into CPU cores and memory
+// in GB. Only the combinations supported by 'image activate' are accepted.
+func ParseResourceSpec(spec string) (cpu, memory int, err error) {
+	match := resourceSpecPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(spec)))
+	if match == nil {
+		return 0, 0, fmt.Errorf("'%s' is not a spec like 4c8g", spec)
+	}
+	cpu, _ = strconv.Atoi(match[1])
+	memory, _ = strconv.Atoi(match[2])
+	return cpu, memory, nil
+}
+
+// FormatResourceSpec renders CPU cores and memory in GB as a spec, e.g. "4c8g"
+func FormatResourceSpec(cpu, memory int) string {
+	return fmt.Sprintf("%dc%dg", cpu, memory)
+}
+
+// FormatReservation describes a capacity reservation, e.g. "4c8g, Reserved until 2025-10-01 12:00"
+func FormatReservation(reservation client.ImageReservationData) string {
+	text := fmt.Sprintf("%s, %s", FormatResourceSpec(reservation.CPU, reservation.Memory), reservation.Status)
+	if expiresAt, ok := reservation.ExpiresAt(); ok && reservation.Active() {
+		text += " until " + formatTime(expiresAt)
+	}
+	return text
+}
+
+// reserveImageCapacity reserves capacity for the first activation of the image built by
+// the create task taskId
+func reserveImageCapacity(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, taskId string, cpu, memory int) (*client.ImageReservationData, error) {
+	fmt.Printf("[RESERVE] Reserving %s for the first activation...\n", FormatResourceSpec(cpu, memory))
+	resp, httpResp, err := apiClient.ImageAPI.ReserveImageCapacity(ctx, loginToken, sessionId, taskId, cpu, memory)
+	if err != nil {
+		var apiErr *client.GenericOpenAPIError
+		if errors.As(err, &apiErr) && httpResp != nil && (httpResp.StatusCode == http.StatusNotFound || httpResp.StatusCode == http.StatusNotImplemented) {
+			return nil, printErrorMessage(
+				"[ERROR] This AgbCloud endpoint does not support capacity reservations",
+				"",
+				"[TIP] Remove --reserve-spec and choose the resources when activating the image",
+			)
+		}
+		return nil, requestError(os.Stdout, "failed to reserve capacity", httpResp, err)
+	}
+
+	reservation := resp.Data
+	fmt.Printf("[OK] Reserved %s (Reservation ID: %s)\n", FormatResourceSpec(reservation.CPU, reservation.Memory), reservation.ReservationID)
+	if expiresAt, ok := reservation.ExpiresAt(); ok {
+		fmt.Printf("[NOTE] The capacity is held until %s; activate the image before then\n", formatTime(expiresAt))
+	}
+	return &reservation, nil
+}
+
+// releaseImageReservation releases the capacity reserved for a build that failed, since
+// there is no image left to activate on it. A nil reservation is ignored.
+func releaseImageReservation(apiClient *client.APIClient, loginToken, sessionId string, reservation *client.ImageReservationData) {
+	if reservation == nil {
+		return
+	}
+
+	fmt.Printf("[CLEAN] Releasing reservation %s...\n", reservation.ReservationID)
+	// Like task cleanup, the release completes after Ctrl+C
+	ctx, cancel := context.WithTimeout(context.Background(), imageTaskCleanupTimeout)
+	defer cancel()
+	if _, _, err := apiClient.ImageAPI.ReleaseImageReservation(ctx, loginToken, sessionId, reservation.ReservationID); err != nil {
+		fmt.Printf("[WARN] Releasing reservation %s failed: %v\n", reservation.ReservationID, err)
+		fmt.Println("[NOTE] Unused reservations are released when they expire")
+		return
+	}
+	fmt.Printf("[OK] Reservation %s released\n", reservation.ReservationID)
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/imagestatus"
+	"github.com/agbcloud/agbcloud-cli/internal/verbosity"
+)
+
+var imageRestartCmd = &cobra.Command{
+	Use:   "restart <image-id>",
+	Short: "Restart the instance of an activated image",
+	Long: `Restart the running instance of an activated image in place.
+
+A restart recovers a workload that hangs without a full deactivate and activate
+cycle: the instance keeps its resources and is back within seconds to a few
+minutes. The image shows the status Restarting until it is activated again.
+
+The image can also be given by a unique prefix of its ID, by its content digest
+(sha256:<hex>, at least 12 digits) as shown in the DIGEST column of
+'agbcloud image list', or by name with --name.`,
+	Example: `  agbcloud image restart img-7a8b9c1d0e
+  agbcloud image restart --name myImage`,
+	Args: imageReferenceArgs("agbcloud image restart <image-id>", "agbcloud image restart img-7a8b9c1d0e"),
+	RunE: runImageRestart,
+}
+
+func init() {
+	imageRestartCmd.Flags().Bool("verbose-poll", false, "Print every status check instead of only status changes")
+	imageRestartCmd.Flags().String("name", "", "Select the image by name instead of ID")
+	ImageCmd.AddCommand(imageRestartCmd)
+}
+
+func runImageRestart(cmd *cobra.Command, args []string) error {
+	imageId, byName := imageReferenceArg(cmd, args)
+	verbosePoll, _ := cmd.Flags().GetBool("verbose-poll")
+
+	fmt.Printf("[REFRESH] Restarting image '%s'...\n", imageId)
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	apiClient := client.NewFromConfig(cfg)
+	// Requests are bounded on their own; monitoring has its own timeout
+	monitorCtx := commandContext(cmd)
+	ctx, cancel := context.WithTimeout(monitorCtx, 30*time.Second)
+	defer cancel()
+
+	// The image may also be given by ID prefix, content digest or name
+	imageId, err = resolveImageArgument(ctx, os.Stdout, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, byName)
+	if err != nil {
+		return err
+	}
+
+	// Only a running instance can be restarted
+	fmt.Println("[SEARCH] Checking current image status...")
+	listResp, httpResp, err := apiClient.ImageAPI.ListImages(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{imageId}})
+	if err != nil {
+		return requestError(os.Stdout, "failed to check image status", httpResp, err)
+	}
+	if len(listResp.Data.Images) == 0 {
+		return imageNotFoundError(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId)
+	}
+	image := listResp.Data.Images[0]
+	formattedStatus := FormatImageStatus(image.Status)
+	fmt.Printf("[DATA] Current Status: %s\n", formattedStatus)
+
+	switch imagestatus.Status(image.Status) {
+	case imagestatus.Published:
+	case imagestatus.Restarting:
+		fmt.Println("[REFRESH] Image is already restarting, joining the restart...")
+		fmt.Println("[MONITOR] Monitoring image restart status...")
+		return pollImageRestartStatus(monitorCtx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, verbosePoll)
+	default:
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Image '%s' is not activated (status: %s); only a running instance can be restarted", imageId, formattedStatus),
+			"",
+			fmt.Sprintf("[TIP] Activate it with 'agbcloud image activate %s'", imageId),
+		)
+	}
+
+	fmt.Println("[REFRESH] Restarting image instance...")
+	restartResp, httpResp, err := apiClient.ImageAPI.RestartInstance(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, imageId)
+	if err != nil {
+		var apiErr *client.GenericOpenAPIError
+		if errors.As(err, &apiErr) && httpResp != nil && (httpResp.StatusCode == http.StatusNotFound || httpResp.StatusCode == http.StatusNotImplemented) {
+			return printErrorMessage(
+				"[ERROR] This AgbCloud endpoint does not support restarting an instance",
+				"",
+				"[TIP] Deactivate and activate the image instead:",
+				fmt.Sprintf("  agbcloud image deactivate %s && agbcloud image activate %s", imageId, imageId),
+			)
+		}
+		return requestError(os.Stdout, "failed to restart image", httpResp, err)
+	}
+	recordRecentImage(imageId, image.ImageName, "restart")
+
+	fmt.Printf("[OK] Image restart initiated successfully!\n")
+	printDetail(verbosity.Requests, "[DATA] Operation Status: %v\n", restartResp.Data)
+	printDetail(verbosity.Requests, "[SEARCH] Request ID: %s\n", restartResp.RequestID)
+
+	fmt.Println("[MONITOR] Monitoring image restart status...")
+	return pollImageRestartStatus(monitorCtx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, verbosePoll)
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/imagestatus"
+)
+
+var imageSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <image-id> <new-image-name>",
+	Short: "Capture the instance of an activated image into a new image",
+	Long: `Capture the current state of the running instance of an activated image into a
+new custom image, and wait until the new image is built.
+
+Snapshots build environments step by step: activate an image, install and
+configure what you need inside the instance, and snapshot it, without editing a
+Dockerfile. The instance keeps running while the snapshot is taken. Activate the
+new image like any other custom image.
+
+The image can also be given by a unique prefix of its ID or by its content
+digest (sha256:<hex>), as with 'agbcloud image restart'.`,
+	Example: `  agbcloud image snapshot img-7a8b9c1d0e my-env-v2
+  agbcloud image snapshot img-7a8b9c1d0e my-env-v2 --no-wait`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Expected 2 arguments (image ID and new image name), got %d", len(args)),
+				"",
+				"[TIP] Usage: agbcloud image snapshot <image-id> <new-image-name>",
+				"[NOTE] Example: agbcloud image snapshot img-7a8b9c1d0e my-env-v2",
+			)
+		}
+		return nil
+	},
+	RunE: runImageSnapshot,
+}
+
+func init() {
+	imageSnapshotCmd.Flags().Bool("no-wait", false, "Return the task ID once the snapshot has started, without waiting for the build")
+	imageSnapshotCmd.Flags().Bool("verbose-poll", false, "Print every status check instead of only status changes")
+	ImageCmd.AddCommand(imageSnapshotCmd)
+}
+
+func runImageSnapshot(cmd *cobra.Command, args []string) error {
+	imageId, imageName := args[0], args[1]
+	noWait, _ := cmd.Flags().GetBool("no-wait")
+	verbosePoll, _ := cmd.Flags().GetBool("verbose-poll")
+
+	if err := ValidateImageName(imageName); err != nil {
+		return err
+	}
+
+	fmt.Printf("[SNAPSHOT] Capturing image '%s' into a new image '%s'...\n", imageId, imageName)
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	apiClient := client.NewFromConfig(cfg)
+	// Requests are bounded on their own; the build is monitored like 'image create'
+	monitorCtx, cancelMonitor := context.WithTimeout(commandContext(cmd), 45*time.Minute)
+	defer cancelMonitor()
+	ctx, cancel := context.WithTimeout(monitorCtx, 30*time.Second)
+	defer cancel()
+
+	imageId, err = resolveImageReference(ctx, os.Stdout, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId)
+	if err != nil {
+		return err
+	}
+
+	// Only a running instance has a state to capture
+	fmt.Println("[SEARCH] Checking current image status...")
+	listResp, httpResp, err := apiClient.ImageAPI.ListImages(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{imageId}})
+	if err != nil {
+		return requestError(os.Stdout, "failed to check image status", httpResp, err)
+	}
+	if len(listResp.Data.Images) == 0 {
+		return imageNotFoundError(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId)
+	}
+	if image := listResp.Data.Images[0]; imagestatus.Status(image.Status) != imagestatus.Published {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Image '%s' is not activated (status: %s); only a running instance can be captured", imageId, FormatImageStatus(image.Status)),
+			"",
+			fmt.Sprintf("[TIP] Activate it with 'agbcloud image activate %s'", imageId),
+		)
+	}
+
+	existing, err := FindUserImageByName(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageName)
+	if err != nil {
+		// The server still enforces uniqueness, so don't block the snapshot on a failed check
+		fmt.Printf("[WARN]  Could not check for existing images: %v\n", err)
+	} else if existing != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] An image named '%s' already exists (Image ID: %s, Status: %s)", imageName, existing.ImageID, FormatImageStatus(existing.Status)),
+			"",
+			"[TIP] Choose a different name for the snapshot",
+		)
+	}
+
+	snapshotResp, httpResp, err := apiClient.ImageAPI.SnapshotInstance(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, imageName)
+	if err != nil {
+		var apiErr *client.GenericOpenAPIError
+		if errors.As(err, &apiErr) && httpResp != nil && (httpResp.StatusCode == http.StatusNotFound || httpResp.StatusCode == http.StatusNotImplemented) {
+			return printErrorMessage(
+				"[ERROR] This AgbCloud endpoint does not support instance snapshots",
+				"",
+				"[TIP] Capture the changes in a Dockerfile and build it with 'agbcloud image create'",
+			)
+		}
+		return requestError(os.Stdout, "failed to snapshot image", httpResp, err)
+	}
+	taskId := snapshotResp.Data.TaskID
+	fmt.Println("[OK] Snapshot started; the instance keeps running")
+	fmt.Printf("[DOC] Task ID: %s\n", taskId)
+
+	if noWait {
+		fmt.Printf("[TIP] Follow the build with: agbcloud image task %s --watch\n", taskId)
+		return nil
+	}
+	createdId, err := monitorImageTask(monitorCtx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, taskId, nil, false, verbosePoll)
+	if err != nil {
+		return err
+	}
+	recordRecentImage(createdId, imageName, "snapshot")
+	fmt.Printf("[TIP] Activate the snapshot with: agbcloud image activate %s\n", createdId)
+	return nil
+}
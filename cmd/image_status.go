@@ -0,0 +1,122 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var imageStatusCmd = &cobra.Command{
+	Use:   "status <image-id>",
+	Short: "Show the status of an image",
+	Long: `Show the status, resources, default activation settings and capacity reservation
+of an image.
+
+Capacity is reserved with 'agbcloud image create --reserve-spec'. The reservation
+is Reserved until the first activation uses it, then Consumed; a reservation that
+was not used is Released or Expired.
+
+The image can also be given by a unique prefix of its ID, by its content digest
+(sha256:<hex>, at least 12 digits) as shown in the DIGEST column of
+'agbcloud image list', or by name with --name.`,
+	Example: `  agbcloud image status img-7a8b9c1d0e
+  agbcloud image status img-7a8b
+  agbcloud image status --name myImage`,
+	Args: imageReferenceArgs("agbcloud image status <image-id>", "agbcloud image status img-7a8b9c1d0e"),
+	RunE: runImageStatus,
+}
+
+func init() {
+	imageStatusCmd.Flags().String("name", "", "Select the image by name instead of ID")
+	ImageCmd.AddCommand(imageStatusCmd)
+}
+
+// imageReservationStatus returns the latest reservation of an image, or nil if the image
+// has none or the server does not support reservations
+func imageReservationStatus(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, imageId string) (*client.ImageReservationData, error) {
+	resp, httpResp, err := apiClient.ImageAPI.GetImageReservation(ctx, loginToken, sessionId, imageId)
+	if err != nil {
+		var apiErr *client.GenericOpenAPIError
+		if errors.As(err, &apiErr) && httpResp != nil && (httpResp.StatusCode == http.StatusNotFound || httpResp.StatusCode == http.StatusNotImplemented) {
+			return nil, nil
+		}
+		if code, _ := client.ErrorCode(err); normalizeErrorCode(code) == "RESERVATIONNOTFOUND" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+func runImageStatus(cmd *cobra.Command, args []string) error {
+	imageId, byName := imageReferenceArg(cmd, args)
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	apiClient := client.NewFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+	defer cancel()
+
+	// The image may also be given by ID prefix, content digest or name
+	imageId, err = resolveImageArgument(ctx, os.Stdout, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId, byName)
+	if err != nil {
+		return err
+	}
+
+	listResp, httpResp, err := apiClient.ImageAPI.ListImages(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{imageId}})
+	if err != nil {
+		return requestError(os.Stdout, "failed to get image status", httpResp, err)
+	}
+	if len(listResp.Data.Images) == 0 {
+		return fmt.Errorf("image not found: %s", imageId)
+	}
+	image := listResp.Data.Images[0]
+	recordRecentImage(imageId, image.ImageName, "status")
+
+	fmt.Printf("[DATA] Image ID: %s\n", image.ImageID)
+	fmt.Printf("[DATA] Name: %s\n", image.ImageName)
+	fmt.Printf("[DATA] Status: %s\n", FormatImageStatus(image.Status))
+	if image.SourceImageID != "" {
+		fmt.Printf("[DATA] Base Image: %s\n", FormatSourceImage(image))
+	}
+	if image.Digest != "" {
+		fmt.Printf("[DATA] Digest: %s\n", image.Digest)
+	}
+	if image.CPU != nil && image.Memory != nil {
+		fmt.Printf("[DATA] Resources: %s\n", FormatResourceSpec(*image.CPU, *image.Memory))
+	}
+	if image.WarmInstances != nil {
+		fmt.Printf("[DATA] Warm Capacity: %s\n", FormatWarmCapacity(image.WarmInstances))
+	}
+	printActivationDefaults(os.Stdout, image.ActivationDefaults)
+
+	// The reservation is supplementary, so a failed lookup does not fail the command
+	reservation, err := imageReservationStatus(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageId)
+	switch {
+	case err != nil:
+		fmt.Printf("[WARN]  Could not get the capacity reservation: %v\n", err)
+	case reservation == nil:
+		fmt.Println("[DATA] Reservation: none")
+	default:
+		fmt.Printf("[DATA] Reservation: %s (ID: %s)\n", FormatReservation(*reservation), reservation.ReservationID)
+	}
+	return nil
+}
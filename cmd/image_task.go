@@ -0,0 +1,192 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var imageTaskCmd = &cobra.Command{
+	Use:   "task [<task-id>...]",
+	Short: "Show and manage image creation tasks",
+	Long: `Show and manage the server-side tasks that 'agbcloud image create' starts for each
+build.
+
+Given task IDs, the status of each task is shown. With --watch all tasks are polled
+at the same time until every build has finished or failed; the combined status
+table updates in place on a terminal, and prints each change otherwise. This
+follows several builds started at once, e.g. by a CI fan-out with --detach.
+The command fails when any of the builds failed.`,
+	Example: `  agbcloud image task task-1a2b3c4d
+  agbcloud image task task-1a2b3c4d task-5e6f7a8b --watch
+  agbcloud image task task-1a2b3c4d task-5e6f7a8b --watch -o json`,
+	Args: cobra.ArbitraryArgs,
+	RunE: runImageTask,
+}
+
+var imageTaskDeleteCmd = &cobra.Command{
+	Use:   "delete <task-id>",
+	Short: "Delete an image creation task",
+	Long: `Delete an image creation task and the artifacts it left on the server, such as the
+uploaded Dockerfile and intermediate build results. The task ID is printed by
+'agbcloud image create' when a build fails.`,
+	Example: `  agbcloud image task delete task-1a2b3c4d`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return printErrorMessage(
+				"[ERROR] Missing required argument: <task-id>",
+				"",
+				"[TIP] Usage: agbcloud image task delete <task-id>",
+				"[NOTE] The task ID is printed as '[DOC] Task ID' when 'agbcloud image create' fails",
+			)
+		}
+		if len(args) > 1 {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Too many arguments provided. Expected 1 argument (task ID), got %d", len(args)),
+				"",
+				"[TIP] Usage: agbcloud image task delete <task-id>",
+			)
+		}
+		return nil
+	},
+	RunE: runImageTaskDelete,
+}
+
+// imageTaskCleanupTimeout bounds the cleanup request after a failed build, which may run
+// after the create command's own deadline has passed
+const imageTaskCleanupTimeout = 30 * time.Second
+
+// errImageTaskFailed is returned when the server reports that an image build failed
+var errImageTaskFailed = errors.New("image creation failed")
+
+func init() {
+	imageTaskCmd.Flags().Bool("watch", false, "Poll the tasks until every build has finished or failed")
+	imageTaskCmd.AddCommand(imageTaskDeleteCmd)
+	ImageCmd.AddCommand(imageTaskCmd)
+}
+
+// ResolveCleanupOnFailure reports whether failed create tasks are deleted: the
+// --cleanup-on-failure flag when given, otherwise the configured default (off)
+func ResolveCleanupOnFailure(cmd *cobra.Command, cfg *config.Config) bool {
+	if cmd.Flags().Changed("cleanup-on-failure") {
+		cleanup, _ := cmd.Flags().GetBool("cleanup-on-failure")
+		return cleanup
+	}
+	return cfg.CleanupOnFailure != nil && *cfg.CleanupOnFailure
+}
+
+// deleteImageTask deletes a create task on the server
+func deleteImageTask(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, taskId string) error {
+	_, _, err := apiClient.ImageAPI.DeleteImageTask(ctx, loginToken, sessionId, taskId)
+	if err != nil && !serverAnswered(err) {
+		return fmt.Errorf("network error: %v", err)
+	}
+	return err
+}
+
+// cleanupFailedImageTask deletes the task of a failed build when cleanup is enabled,
+// and otherwise tells the user how to delete it later. Cleanup problems are reported
+// but never replace the original build error.
+func cleanupFailedImageTask(apiClient *client.APIClient, loginToken, sessionId, taskId string, enabled bool) {
+	if taskId == "" {
+		return
+	}
+	if !enabled {
+		fmt.Printf("[TIP] Run 'agbcloud image task delete %s' to remove the artifacts of this build, or use --cleanup-on-failure\n", taskId)
+		return
+	}
+
+	fmt.Printf("[CLEAN] Cleaning up failed task %s...\n", taskId)
+	// Cleanup is not tied to the command context so that it completes after Ctrl+C
+	ctx, cancel := context.WithTimeout(context.Background(), imageTaskCleanupTimeout)
+	defer cancel()
+	if err := deleteImageTask(ctx, apiClient, loginToken, sessionId, taskId); err != nil {
+		fmt.Printf("[WARN] Cleanup of task %s failed: %v\n", taskId, err)
+		fmt.Printf("[TIP] Run 'agbcloud image task delete %s' to retry\n", taskId)
+		return
+	}
+	fmt.Printf("[OK] Task %s cleaned up\n", taskId)
+}
+
+// IsImageTaskInProgress reports whether a create task is still queued or building
+func IsImageTaskInProgress(status string) bool {
+	return status == "Inline" || status == "Preparing"
+}
+
+// FindInProgressImageTask returns the most recent create task for imageName that is
+// still queued or building, or nil if there is none
+func FindInProgressImageTask(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, imageName string) (*client.ImageTaskInfo, error) {
+	// Tasks are returned most recent first, so running builds are on the first page
+	resp, _, err := apiClient.ImageAPI.ListImageTasks(ctx, loginToken, sessionId, client.ImageTaskListOptions{ImageName: imageName, Page: 1, PageSize: 20})
+	if err != nil {
+		return nil, err
+	}
+	for i := range resp.Data.Tasks {
+		if resp.Data.Tasks[i].ImageName == imageName && IsImageTaskInProgress(resp.Data.Tasks[i].Status) {
+			task := resp.Data.Tasks[i]
+			return &task, nil
+		}
+	}
+	return nil, nil
+}
+
+// shouldAttachToImageTask tells the user about a build in progress and decides whether
+// to follow it: interactive sessions are asked, others attach automatically
+func shouldAttachToImageTask(task *client.ImageTaskInfo) bool {
+	fmt.Printf("[NOTE] A build of '%s' is already in progress (Task ID: %s, Status: %s)\n", task.ImageName, task.TaskID, task.Status)
+	fmt.Println("[NOTE] Attaching follows that build, which uses the Dockerfile it was started with")
+	if !isInteractiveInput() {
+		fmt.Println("[TIP] Attaching to it; use --force-new to start a new build instead")
+		return true
+	}
+	return Confirm(promptInput, os.Stdout, "Attach to the build in progress instead of starting a new one?", true)
+}
+
+// monitorImageTask follows a create task until it finishes, returns the ID of the created
+// image and cleans up after a failed build. With verbosePoll every status check is printed.
+func monitorImageTask(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, taskId string, warnings *WarningRecorder, cleanupOnFailure, verbosePoll bool) (string, error) {
+	fmt.Println("[MONITOR] Monitoring image creation progress...")
+	imageId, err := pollImageTask(ctx, apiClient, loginToken, sessionId, taskId, warnings, verbosePoll)
+	if err != nil {
+		// Only failed builds are cleaned up; after a timeout the build may still be running
+		if errors.Is(err, errImageTaskFailed) {
+			cleanupFailedImageTask(apiClient, loginToken, sessionId, taskId, cleanupOnFailure)
+		}
+		return "", err
+	}
+	return imageId, nil
+}
+
+func runImageTaskDelete(cmd *cobra.Command, args []string) error {
+	taskId := args[0]
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	apiClient := client.NewFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), imageTaskCleanupTimeout)
+	defer cancel()
+
+	fmt.Printf("[DELETE] Deleting task %s...\n", taskId)
+	if err := deleteImageTask(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, taskId); err != nil {
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+	fmt.Printf("[OK] Task %s deleted\n", taskId)
+	return nil
+}
@@ -0,0 +1,265 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/poll"
+	"github.com/agbcloud/agbcloud-cli/internal/progress"
+)
+
+// imageTaskNamePageSize is the number of recent tasks searched for the image names of
+// the given tasks
+const imageTaskNamePageSize = 100
+
+// ImageTaskState is the status of one create task, as shown by 'image task'
+type ImageTaskState struct {
+	TaskID    string `json:"taskId"`
+	ImageName string `json:"imageName,omitempty"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+	ImageID   string `json:"imageId,omitempty"`
+	Error     string `json:"error,omitempty"`
+	// Webhook is the delivery status of the callback registered with --callback-url
+	Webhook *client.ImageTaskWebhook `json:"webhook,omitempty"`
+}
+
+// uniqueArgs returns args without repetitions, in their original order
+func uniqueArgs(args []string) []string {
+	seen := make(map[string]bool, len(args))
+	unique := make([]string, 0, len(args))
+	for _, arg := range args {
+		if !seen[arg] {
+			seen[arg] = true
+			unique = append(unique, arg)
+		}
+	}
+	return unique
+}
+
+// imageTaskNames looks up the image names of recent tasks. The names only label the
+// output, so a failed lookup leaves them out.
+func imageTaskNames(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string) map[string]string {
+	names := map[string]string{}
+	resp, _, err := apiClient.ImageAPI.ListImageTasks(ctx, loginToken, sessionId, client.ImageTaskListOptions{Page: 1, PageSize: imageTaskNamePageSize})
+	if err != nil {
+		return names
+	}
+	for _, task := range resp.Data.Tasks {
+		names[task.TaskID] = task.ImageName
+	}
+	return names
+}
+
+// imageTaskLabel names the progress item of a task
+func imageTaskLabel(state ImageTaskState) string {
+	if state.ImageName == "" {
+		return state.TaskID
+	}
+	return fmt.Sprintf("%s (%s)", state.TaskID, state.ImageName)
+}
+
+// readImageTaskState fills state with the current status of its task
+func readImageTaskState(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string, state *ImageTaskState) error {
+	taskResp, httpResp, err := apiClient.ImageAPI.GetImageTask(ctx, loginToken, sessionId, state.TaskID)
+	if err != nil {
+		return statusCheckError(httpResp, err)
+	}
+	state.Status = taskResp.Data.Status
+	state.Message = taskResp.Data.TaskMsg
+	if taskResp.Data.ImageID != nil {
+		state.ImageID = *taskResp.Data.ImageID
+	}
+	state.Webhook = taskResp.Data.Webhook
+	return nil
+}
+
+// watchImageTask polls one task until its build has finished or failed, reporting
+// every change through item
+func watchImageTask(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string, state *ImageTaskState, item *progress.Item) {
+	item.Start("checking status")
+	poller := newImagePoller("poll image task", nil)
+	poller.Progress = func(p poll.Progress) {
+		if p.Err != nil {
+			item.Update(fmt.Sprintf("status check failed, retrying: %v", p.Err))
+		}
+	}
+	err := poller.Until(ctx, func(ctx context.Context) (bool, error) {
+		if err := readImageTaskState(ctx, apiClient, loginToken, sessionId, state); err != nil {
+			return false, err
+		}
+		switch state.Status {
+		case "Finished":
+			return true, nil
+		case "Failed":
+			return false, poll.Stop(fmt.Errorf("%w: %s", errImageTaskFailed, state.Message))
+		}
+		message := state.Status
+		if state.Message != "" {
+			message += " - " + state.Message
+		}
+		item.Update(message)
+		return false, nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			// Interrupted; the build goes on and the item keeps its last status
+			return
+		}
+		err = pollError("image creation", err)
+		state.Error = err.Error()
+		item.Fail(err)
+		return
+	}
+	if state.ImageID != "" {
+		item.Succeed("finished, image " + state.ImageID)
+	} else {
+		item.Succeed("finished")
+	}
+}
+
+// printImageTaskStates prints one line per task with its status
+func printImageTaskStates(w io.Writer, states []ImageTaskState) {
+	fmt.Fprintln(w)
+	rows := make([][]string, 0, len(states))
+	for _, state := range states {
+		status := state.Status
+		detail := state.Message
+		switch {
+		case state.Error != "":
+			if status == "" {
+				status = "-"
+			}
+			detail = state.Error
+		case state.ImageID != "":
+			detail = state.ImageID
+		}
+		rows = append(rows, []string{state.TaskID, valueOrDash(state.ImageName), status, valueOrDash(detail)})
+	}
+	printTable(w, []TableColumn{
+		{Header: "TASK ID", Width: 20},
+		{Header: "IMAGE NAME", Width: 25, Truncate: true},
+		{Header: "STATUS", Width: 10},
+		{Header: "IMAGE ID / MESSAGE"},
+	}, rows)
+	fmt.Fprintln(w)
+	for _, state := range states {
+		if state.Webhook != nil {
+			fmt.Fprintf(w, "[HOOK] %s: %s\n", state.TaskID, FormatWebhookDelivery(*state.Webhook))
+		}
+	}
+}
+
+func runImageTask(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return cmd.Help()
+	}
+	watch, _ := cmd.Flags().GetBool("watch")
+	taskIds := uniqueArgs(args)
+
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	out := progressWriter(outputFormat)
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+	loginToken, sessionId := cfg.Token.LoginToken, cfg.Token.SessionId
+	apiClient := client.NewFromConfig(cfg)
+
+	lookupCtx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+	names := imageTaskNames(lookupCtx, apiClient, loginToken, sessionId)
+	cancel()
+	states := make([]ImageTaskState, len(taskIds))
+	for i, taskId := range taskIds {
+		states[i] = ImageTaskState{TaskID: taskId, ImageName: names[taskId]}
+	}
+
+	var failed int
+	if watch {
+		// Ctrl-C stops monitoring; the builds go on on the server
+		ctx := commandContext(cmd)
+		fmt.Fprintf(out, "[MONITOR] Watching %d task(s)...\n", len(states))
+		tracker := progress.New(out, progress.Options{})
+		var wg sync.WaitGroup
+		for i := range states {
+			item := tracker.Add(imageTaskLabel(states[i]))
+			wg.Add(1)
+			go func(state *ImageTaskState) {
+				defer wg.Done()
+				watchImageTask(ctx, apiClient, loginToken, sessionId, state, item)
+			}(&states[i])
+		}
+		wg.Wait()
+		tracker.Stop()
+		if ctx.Err() != nil {
+			fmt.Fprintln(out, "[NOTE] Stopped watching; the builds continue on the server")
+			return ctx.Err()
+		}
+		var finished int
+		finished, failed = tracker.Counts()
+		if outputFormat.IsStructured() {
+			if err := writeResult(outputFormat, states); err != nil {
+				return err
+			}
+		} else {
+			printImageTaskStates(out, states)
+		}
+		fmt.Fprintf(out, "[DATA] Summary: %d finished, %d failed\n", finished, failed)
+	} else {
+		ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+		defer cancel()
+		var wg sync.WaitGroup
+		for i := range states {
+			wg.Add(1)
+			go func(state *ImageTaskState) {
+				defer wg.Done()
+				if err := readImageTaskState(ctx, apiClient, loginToken, sessionId, state); err != nil {
+					state.Error = err.Error()
+				}
+			}(&states[i])
+		}
+		wg.Wait()
+		for _, state := range states {
+			if state.Error != "" || state.Status == "Failed" {
+				failed++
+			}
+		}
+		if outputFormat.IsStructured() {
+			if err := writeResult(outputFormat, states); err != nil {
+				return err
+			}
+		} else {
+			printImageTaskStates(out, states)
+		}
+	}
+
+	if failed > 0 {
+		var failedIds []string
+		for _, state := range states {
+			if state.Error != "" || state.Status == "Failed" {
+				failedIds = append(failedIds, state.TaskID)
+			}
+		}
+		return fmt.Errorf("%d of %d task(s) failed: %s", failed, len(states), strings.Join(failedIds, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/tracing"
+)
+
+const (
+	// s3MinPartSize is the smallest part S3 accepts, except for the last one
+	s3MinPartSize = 5 * 1024 * 1024
+	// storageRequestTimeout bounds every request to the storage service
+	storageRequestTimeout = 60 * time.Second
+)
+
+// DockerfileUploader uploads a Dockerfile to the storage service described by the
+// upload credentials. Each call is a single attempt; callers retry failed uploads.
+// A request rejected by the storage service is returned as *UploadError.
+type DockerfileUploader interface {
+	Upload(ctx context.Context, content []byte) error
+}
+
+// ParseStorageBackend normalizes the name of an upload protocol. An empty name, as sent
+// by older backends, as well as "oss" and "s3" mean a presigned PUT.
+func ParseStorageBackend(name string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "oss", "s3", client.StoragePresignedPut:
+		return client.StoragePresignedPut, nil
+	case client.StorageS3Multipart:
+		return client.StorageS3Multipart, nil
+	case client.StorageAzureBlob:
+		return client.StorageAzureBlob, nil
+	}
+	return "", fmt.Errorf("unsupported upload storage '%s', expected %s, %s or %s",
+		name, client.StoragePresignedPut, client.StorageS3Multipart, client.StorageAzureBlob)
+}
+
+// NewDockerfileUploader returns the uploader for credential. The backend configured in
+// storage takes precedence over the one announced by the server, and the configured
+// endpoint replaces the scheme and host of all upload URLs. storage may be nil.
+func NewDockerfileUploader(credential client.ImageUploadCredentialData, storage *config.UploadStorage) (DockerfileUploader, error) {
+	name := credential.StorageType
+	var endpoint string
+	if storage != nil {
+		if storage.Backend != "" {
+			name = storage.Backend
+		}
+		endpoint = storage.Endpoint
+	}
+	backend, err := ParseStorageBackend(name)
+	if err != nil {
+		return nil, err
+	}
+
+	rewrite := func(rawURL string) (string, error) {
+		if endpoint == "" || rawURL == "" {
+			return rawURL, nil
+		}
+		return rewriteUploadURL(rawURL, endpoint)
+	}
+
+	switch backend {
+	case client.StorageS3Multipart:
+		if len(credential.PartURLs) == 0 || credential.CompleteURL == "" {
+			return nil, fmt.Errorf("upload credentials for %s contain no part or completion URLs", backend)
+		}
+		uploader := &s3MultipartUploader{partSize: credential.PartSize}
+		for _, partURL := range credential.PartURLs {
+			rewritten, err := rewrite(partURL)
+			if err != nil {
+				return nil, err
+			}
+			uploader.partURLs = append(uploader.partURLs, rewritten)
+		}
+		if uploader.completeURL, err = rewrite(credential.CompleteURL); err != nil {
+			return nil, err
+		}
+		return uploader, nil
+	default:
+		if credential.OssURL == "" {
+			return nil, fmt.Errorf("upload credentials for %s contain no upload URL", backend)
+		}
+		uploadURL, err := rewrite(credential.OssURL)
+		if err != nil {
+			return nil, err
+		}
+		if backend == client.StorageAzureBlob {
+			return &azureBlobUploader{url: uploadURL}, nil
+		}
+		return &presignedPutUploader{url: uploadURL}, nil
+	}
+}
+
+// rewriteUploadURL sends an upload to endpoint instead of the host in rawURL. Path and
+// query, which carry the signature, are kept.
+func rewriteUploadURL(rawURL, endpoint string) (string, error) {
+	if err := config.ValidateEndpoint(endpoint); err != nil {
+		return "", fmt.Errorf("invalid upload endpoint: %w", err)
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	target, _ := url.Parse(endpoint)
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid upload URL: %w", err)
+	}
+	u.Scheme, u.Host = target.Scheme, target.Host
+	return u.String(), nil
+}
+
+// presignedPutUploader uploads with a single PUT to a presigned Alibaba OSS or S3 URL
+type presignedPutUploader struct {
+	url string
+}
+
+// Upload implements DockerfileUploader
+func (u *presignedPutUploader) Upload(ctx context.Context, content []byte) error {
+	_, _, err := sendToStorage(ctx, "upload Dockerfile", http.MethodPut, u.url, content, map[string]string{
+		"Content-Type": "application/octet-stream",
+	})
+	return err
+}
+
+// azureBlobUploader uploads a block blob to an Azure Blob Storage SAS URL
+type azureBlobUploader struct {
+	url string
+}
+
+// Upload implements DockerfileUploader
+func (u *azureBlobUploader) Upload(ctx context.Context, content []byte) error {
+	_, _, err := sendToStorage(ctx, "upload Dockerfile", http.MethodPut, u.url, content, map[string]string{
+		"Content-Type":   "application/octet-stream",
+		"x-ms-blob-type": "BlockBlob",
+	})
+	return err
+}
+
+// s3MultipartUploader uploads to an S3 multipart upload the server has already
+// created, using presigned UploadPart and CompleteMultipartUpload URLs. With a journal,
+// stored parts are saved and skipped when the upload is resumed.
+type s3MultipartUploader struct {
+	partURLs    []string
+	partSize    int64
+	completeURL string
+	journal     *UploadJournal
+}
+
+type completeMultipartUpload struct {
+	XMLName xml.Name         `xml:"CompleteMultipartUpload"`
+	Parts   []multipartEntry `xml:"Part"`
+}
+
+type multipartEntry struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// Upload implements DockerfileUploader
+func (u *s3MultipartUploader) Upload(ctx context.Context, content []byte) error {
+	partSize := u.partSize
+	if partSize < s3MinPartSize {
+		partSize = s3MinPartSize
+	}
+	parts := splitParts(content, partSize)
+	if len(parts) > len(u.partURLs) {
+		return fmt.Errorf("the Dockerfile needs %d parts, but the upload credentials allow %d", len(parts), len(u.partURLs))
+	}
+
+	complete := completeMultipartUpload{}
+	for i, part := range parts {
+		if etag, ok := u.journal.uploaded(i+1, part); ok {
+			complete.Parts = append(complete.Parts, multipartEntry{PartNumber: i + 1, ETag: etag})
+			continue
+		}
+		header, _, err := sendToStorage(ctx, fmt.Sprintf("upload Dockerfile part %d", i+1), http.MethodPut, u.partURLs[i], part, nil)
+		if err != nil {
+			return err
+		}
+		etag := header.Get("ETag")
+		if etag == "" {
+			return fmt.Errorf("storage service returned no ETag for part %d", i+1)
+		}
+		u.journal.record(i+1, part, etag)
+		complete.Parts = append(complete.Parts, multipartEntry{PartNumber: i + 1, ETag: etag})
+	}
+
+	body, err := xml.Marshal(complete)
+	if err != nil {
+		return err
+	}
+	_, respBody, err := sendToStorage(ctx, "complete Dockerfile upload", http.MethodPost, u.completeURL, body, map[string]string{
+		"Content-Type": "application/xml",
+	})
+	if err != nil {
+		return err
+	}
+	// S3 reports some completion failures in the body of a 200 response
+	if bytes.Contains(respBody, []byte("<Error>")) {
+		return &UploadError{StatusCode: http.StatusOK, Body: string(respBody)}
+	}
+	return nil
+}
+
+// splitParts cuts content into parts of partSize bytes; empty content is one empty part
+func splitParts(content []byte, partSize int64) [][]byte {
+	parts := [][]byte{}
+	for int64(len(content)) > partSize {
+		parts = append(parts, content[:partSize])
+		content = content[partSize:]
+	}
+	return append(parts, content)
+}
+
+// sendToStorage makes a single request to the storage service and returns the headers
+// and body of a successful response. A rejected request is returned as *UploadError.
+func sendToStorage(ctx context.Context, operation, method, rawURL string, content []byte, headers map[string]string) (header http.Header, body []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, bytes.NewReader(content))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create upload request: %w", err)
+	}
+
+	// Upload URLs are credentials, so only their host is traced
+	_, span := tracing.Start(ctx, operation, tracing.SpanKindClient,
+		tracing.String("http.request.method", method),
+		tracing.String("server.address", req.URL.Hostname()),
+		tracing.Int("http.request.body.size", len(content)),
+	)
+	defer func() { span.Finish(err) }()
+
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	req.ContentLength = int64(len(content))
+
+	httpClient := &http.Client{Timeout: storageRequestTimeout}
+	sent := time.Now()
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	span.SetAttributes(tracing.Int("http.response.status_code", resp.StatusCode))
+
+	// Read response body for results or error details
+	body, _ = io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.Header, body, nil
+	}
+	uploadErr := &UploadError{StatusCode: resp.StatusCode, Body: string(body)}
+	uploadErr.ClockSkew, uploadErr.ClockSkewKnown = client.ClockSkewFromResponse(resp, sent, time.Now())
+	// OSS and S3 throttle with 503 SlowDown and may say how long to back off
+	uploadErr.RetryAfter, _ = client.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	return nil, nil, uploadErr
+}
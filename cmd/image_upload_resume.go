@@ -0,0 +1,206 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// UploadJournal is the saved progress of an s3-multipart Dockerfile upload. It is
+// written after every stored part, so that 'image create' run again with the same
+// Dockerfile resumes an upload the CLI was killed in, instead of starting over.
+// The journal holds presigned URLs and is only readable by the user.
+type UploadJournal struct {
+	Profile  string `json:"profile,omitempty"`
+	Endpoint string `json:"endpoint"`
+	// ContentDigest is the digest of the whole Dockerfile (sha256:<hex>)
+	ContentDigest string                           `json:"contentDigest"`
+	Credential    client.ImageUploadCredentialData `json:"credential"`
+	Parts         []UploadedPart                   `json:"parts"`
+	StartedAt     time.Time                        `json:"startedAt"`
+
+	path string
+}
+
+// UploadedPart is a part the storage service has confirmed
+type UploadedPart struct {
+	PartNumber int `json:"partNumber"`
+	// Digest is the digest of the content of the part (sha256:<hex>); a part is only
+	// skipped on resume when the content to upload still has this digest
+	Digest string `json:"digest"`
+	ETag   string `json:"etag"`
+}
+
+// contentDigest returns the digest of content in the sha256:<hex> form
+func contentDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return imageDigestPrefix + hex.EncodeToString(sum[:])
+}
+
+// uploadJournalPath returns the path of the journal of an upload of content by the
+// given profile to the given endpoint
+func uploadJournalPath(profile, endpoint, digest string) (string, error) {
+	configDir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	key := sha256.Sum256([]byte(strings.Join([]string{profile, endpoint, digest}, "|")))
+	return filepath.Join(configDir, "uploads", hex.EncodeToString(key[:12])+".json"), nil
+}
+
+// NewUploadJournal starts the journal of an upload of content with credential, for
+// the active profile and endpoint. Nothing is written until the first part is stored.
+func NewUploadJournal(content []byte, credential client.ImageUploadCredentialData) *UploadJournal {
+	journal := &UploadJournal{ContentDigest: contentDigest(content), Credential: credential, Parts: []UploadedPart{}, StartedAt: time.Now().UTC()}
+	journal.Profile, journal.Endpoint = imageListCacheScope()
+	path, err := uploadJournalPath(journal.Profile, journal.Endpoint, journal.ContentDigest)
+	if err != nil {
+		log.Debugf("Upload progress will not be saved: %v", err)
+	}
+	journal.path = path
+	return journal
+}
+
+// LoadUploadJournal returns the journal of an interrupted upload of content by the
+// active profile to the active endpoint, or nil if there is none. An unreadable
+// journal is discarded.
+func LoadUploadJournal(content []byte) (*UploadJournal, error) {
+	profile, endpoint := imageListCacheScope()
+	digest := contentDigest(content)
+	path, err := uploadJournalPath(profile, endpoint, digest)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	journal := &UploadJournal{}
+	if err := json.Unmarshal(data, journal); err != nil || journal.ContentDigest != digest {
+		log.Debugf("Discarding unreadable upload journal %s: %v", path, err)
+		_ = os.Remove(path)
+		return nil, nil
+	}
+	journal.path = path
+	return journal, nil
+}
+
+// Expired reports whether the presigned URLs of the journal are no longer valid long
+// enough to finish the upload
+func (j *UploadJournal) Expired(now time.Time) bool {
+	expiresAt, ok := j.Credential.ExpiresAt()
+	return ok && expiresAt.Sub(now) < uploadCredentialMinValidity
+}
+
+// Remove deletes the journal, once the upload is complete or cannot be resumed
+func (j *UploadJournal) Remove() {
+	if j == nil || j.path == "" {
+		return
+	}
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		log.Debugf("Could not remove upload journal %s: %v", j.path, err)
+	}
+}
+
+// uploaded returns the ETag of a part stored earlier, if the content of the part is
+// unchanged
+func (j *UploadJournal) uploaded(partNumber int, part []byte) (string, bool) {
+	if j == nil {
+		return "", false
+	}
+	for _, entry := range j.Parts {
+		if entry.PartNumber == partNumber {
+			return entry.ETag, entry.ETag != "" && entry.Digest == contentDigest(part)
+		}
+	}
+	return "", false
+}
+
+// record saves a part confirmed by the storage service. Saving is best-effort: an
+// upload that cannot be journaled is only not resumable.
+func (j *UploadJournal) record(partNumber int, part []byte, etag string) {
+	if j == nil || j.path == "" {
+		return
+	}
+	entry := UploadedPart{PartNumber: partNumber, Digest: contentDigest(part), ETag: etag}
+	replaced := false
+	for i := range j.Parts {
+		if j.Parts[i].PartNumber == partNumber {
+			j.Parts[i], replaced = entry, true
+		}
+	}
+	if !replaced {
+		j.Parts = append(j.Parts, entry)
+	}
+	if err := j.save(); err != nil {
+		log.Debugf("Could not save upload progress: %v", err)
+	}
+}
+
+func (j *UploadJournal) save() error {
+	content, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return err
+	}
+	// Write to a temporary file first so that a killed CLI never leaves half a journal
+	tmp := j.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, j.path)
+}
+
+// WithUploadJournal makes uploader save its progress to journal and skip the parts
+// the journal has already stored. Only s3-multipart uploads are resumable; other
+// uploaders are returned unchanged.
+func WithUploadJournal(uploader DockerfileUploader, journal *UploadJournal) DockerfileUploader {
+	multipart, ok := uploader.(*s3MultipartUploader)
+	if !ok || journal == nil {
+		return uploader
+	}
+	resumable := *multipart
+	resumable.journal = journal
+	return &resumable
+}
+
+// resumableUpload returns the journal of an interrupted upload of content that can be
+// resumed, or nil. Interrupted uploads whose presigned URLs expired are forgotten.
+func resumableUpload(content []byte) *UploadJournal {
+	journal, err := LoadUploadJournal(content)
+	if err != nil {
+		log.Debugf("Could not read upload progress: %v", err)
+		return nil
+	}
+	if journal == nil {
+		return nil
+	}
+	if journal.Expired(time.Now()) {
+		fmt.Println("[NOTE] The interrupted upload of this Dockerfile has expired; starting a new upload")
+		journal.Remove()
+		return nil
+	}
+
+	parts := len(splitParts(content, max(journal.Credential.PartSize, s3MinPartSize)))
+	fmt.Printf("[RESUME] Resuming the interrupted upload of this Dockerfile: %d of %d part(s) already uploaded (Task ID: %s)\n",
+		len(journal.Parts), parts, journal.Credential.TaskID)
+	return journal
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// imageUsageBatchSize is the number of images whose usage is requested at once,
+// which keeps the query string of --all lists short
+const imageUsageBatchSize = 100
+
+// imageUsageColumns are the columns 'image list -o wide' adds to the table
+var imageUsageColumns = []TableColumn{
+	{Header: "LAST USED", Width: 20},
+	{Header: "ACTIVE HOURS", Width: 12},
+	{Header: "ACTIVATIONS"},
+}
+
+// addImageUsage fills in the activation history of user images for the wide list.
+// The usage is optional: when it cannot be fetched, the list is shown without it.
+func addImageUsage(ctx context.Context, progress io.Writer, apiClient *client.APIClient, loginToken, sessionId string, images []client.ImageInfo) {
+	if len(images) == 0 {
+		return
+	}
+	fmt.Fprintln(progress, "[SEARCH] Fetching image usage...")
+	usage := make(map[string]client.ImageUsage, len(images))
+	for start := 0; start < len(images); start += imageUsageBatchSize {
+		ids := make([]string, 0, imageUsageBatchSize)
+		for _, image := range images[start:min(start+imageUsageBatchSize, len(images))] {
+			ids = append(ids, image.ImageID)
+		}
+		usageResp, httpResp, err := apiClient.ImageAPI.GetImageUsage(ctx, loginToken, sessionId, ids)
+		if err != nil {
+			var apiErr *client.GenericOpenAPIError
+			if errors.As(err, &apiErr) && httpResp != nil && (httpResp.StatusCode == http.StatusNotFound || httpResp.StatusCode == http.StatusNotImplemented) {
+				fmt.Fprintln(progress, "[NOTE] This server does not report image usage; the usage columns are empty")
+			} else {
+				fmt.Fprintf(progress, "[WARN]  Could not fetch image usage: %v\n", err)
+			}
+			return
+		}
+		for _, entry := range usageResp.Data {
+			usage[entry.ImageID] = entry
+		}
+	}
+
+	for i := range images {
+		entry, ok := usage[images[i].ImageID]
+		if !ok {
+			continue
+		}
+		hours, count := entry.ActiveHours, entry.ActivationCount
+		images[i].ActiveHours, images[i].ActivationCount = &hours, &count
+		if entry.LastUsedTime != "" {
+			lastUsed := entry.LastUsedTime
+			images[i].LastUsedTime = &lastUsed
+		}
+	}
+}
+
+// FormatImageUsage renders the usage columns of an image: when it was last used,
+// for how many hours it has been activated and how often. Unknown usage is shown
+// as "-", and images that were never activated as "never".
+func FormatImageUsage(image client.ImageInfo) (lastUsed, activeHours, activations string) {
+	if image.ActivationCount == nil {
+		return "-", "-", "-"
+	}
+	lastUsed = "never"
+	if image.LastUsedTime != nil && *image.LastUsedTime != "" {
+		lastUsed = formatTimestamp(*image.LastUsedTime)
+	}
+	hours := 0.0
+	if image.ActiveHours != nil {
+		hours = *image.ActiveHours
+	}
+	return lastUsed, fmt.Sprintf("%.1f", hours), fmt.Sprintf("%d", *image.ActivationCount)
+}
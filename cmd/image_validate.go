@@ -0,0 +1,231 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var imageValidateRemoteCmd = &cobra.Command{
+	Use:   "validate-remote <image-name>",
+	Short: "Have the server check a create request without building",
+	Long: `Send the Dockerfile and settings of 'agbcloud image create' to the server for
+validation, without uploading the Dockerfile or starting a build. The server checks
+that the base image exists, that the image quota allows another image and that the
+Dockerfile only uses allowed instructions, and reports every problem it finds.
+
+The command fails when the server reports an error, and with --fail-on-warnings also
+when it reports a warning, which makes it a quick check for pull requests in CI.`,
+	Example: `  agbcloud image validate-remote myImage -f ./Dockerfile -i agb-code-space-1
+  agbcloud image validate-remote myImage -f ./Dockerfile -i agb-code-space-1 --platform linux/amd64,linux/arm64
+  agbcloud image validate-remote myImage -f ./Dockerfile -i agb-code-space-1 --fail-on-warnings -o json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Expected 1 argument (image name), got %d", len(args)),
+				"",
+				"[TIP] Usage: agbcloud image validate-remote <image-name> --dockerfile <path> --imageId <id>",
+				"[NOTE] Example: agbcloud image validate-remote myImage -f ./Dockerfile -i agb-code-space-1",
+			)
+		}
+		return nil
+	},
+	RunE: runImageValidateRemote,
+}
+
+func init() {
+	imageValidateRemoteCmd.Flags().StringP("dockerfile", "f", "", "Path to Dockerfile (required)")
+	imageValidateRemoteCmd.Flags().StringP("imageId", "i", "", "Source image ID (required)")
+	imageValidateRemoteCmd.Flags().String("platform", "", "Comma-separated platforms to build for, e.g. linux/amd64,linux/arm64")
+	imageValidateRemoteCmd.Flags().Bool("fail-on-warnings", false, "Exit with an error if the server reports warnings")
+
+	ImageCmd.AddCommand(imageValidateRemoteCmd)
+
+	registerOutputSchema(imageValidateRemoteCmd, outputSchema{
+		Command:     "image validate-remote",
+		Version:     1,
+		Description: "The create request that was checked and every finding of the server, with its severity (error, warning or info).",
+		Result:      ImageValidation{},
+	})
+}
+
+// ImageValidation is the result of 'image validate-remote'
+type ImageValidation struct {
+	ImageName     string                          `json:"imageName"`
+	SourceImageID string                          `json:"sourceImageId"`
+	Dockerfile    string                          `json:"dockerfile"`
+	Valid         bool                            `json:"valid"`
+	Errors        int                             `json:"errors"`
+	Warnings      int                             `json:"warnings"`
+	Findings      []client.ImageValidationFinding `json:"findings"`
+}
+
+// NewImageValidation summarizes the server's findings on a create request. A finding
+// with the error severity makes the request invalid, whatever the server says.
+func NewImageValidation(imageName, sourceImageId, dockerfile string, data client.ImageValidationData) ImageValidation {
+	validation := ImageValidation{
+		ImageName:     imageName,
+		SourceImageID: sourceImageId,
+		Dockerfile:    dockerfile,
+		Valid:         data.Valid,
+		Findings:      data.Findings,
+	}
+	if validation.Findings == nil {
+		validation.Findings = []client.ImageValidationFinding{}
+	}
+	for _, finding := range validation.Findings {
+		switch finding.Severity {
+		case client.ValidationError:
+			validation.Errors++
+			validation.Valid = false
+		case client.ValidationWarning:
+			validation.Warnings++
+		}
+	}
+	return validation
+}
+
+// FormatValidationFinding renders a finding as a tagged line, e.g.
+// "[ERROR] Dockerfile line 1: FROM is not allowed (ForbiddenInstruction)"
+func FormatValidationFinding(finding client.ImageValidationFinding) string {
+	tag := "[INFO] "
+	switch finding.Severity {
+	case client.ValidationError:
+		tag = "[ERROR]"
+	case client.ValidationWarning:
+		tag = "[WARN] "
+	}
+	message := finding.Message
+	if finding.Line > 0 {
+		message = fmt.Sprintf("Dockerfile line %d: %s", finding.Line, message)
+	}
+	if finding.Code != "" {
+		message = fmt.Sprintf("%s (%s)", message, finding.Code)
+	}
+	return fmt.Sprintf("%s %s", tag, message)
+}
+
+// printImageValidation prints the findings and a summary line
+func printImageValidation(w io.Writer, validation ImageValidation) {
+	for _, finding := range validation.Findings {
+		fmt.Fprintln(w, FormatValidationFinding(finding))
+	}
+	if validation.Valid {
+		fmt.Fprintf(w, "[OK] The server accepts the create request for '%s'\n", validation.ImageName)
+	}
+	fmt.Fprintf(w, "[DATA] Summary: %d error(s), %d warning(s)\n", validation.Errors, validation.Warnings)
+}
+
+func runImageValidateRemote(cmd *cobra.Command, args []string) error {
+	imageName := args[0]
+	dockerfilePath, _ := cmd.Flags().GetString("dockerfile")
+	sourceImageId, _ := cmd.Flags().GetString("imageId")
+	platformValue, _ := cmd.Flags().GetString("platform")
+	failOnWarnings, _ := cmd.Flags().GetBool("fail-on-warnings")
+
+	if dockerfilePath == "" || sourceImageId == "" {
+		return printErrorMessage(
+			"[ERROR] Both --dockerfile and --imageId are required",
+			"",
+			fmt.Sprintf("[TIP] Usage: agbcloud image validate-remote %s --dockerfile <path> --imageId <id>", imageName),
+			fmt.Sprintf("[NOTE] Example: agbcloud image validate-remote %s -f ./Dockerfile -i agb-code-space-1", imageName),
+		)
+	}
+	if err := ValidateImageName(imageName); err != nil {
+		return err
+	}
+	platforms, err := ParsePlatforms(platformValue)
+	if err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid --platform value: %v", err),
+			"",
+			"[NOTE] Example: agbcloud image validate-remote myImage -f ./Dockerfile -i agb-code-space-1 --platform linux/amd64,linux/arm64",
+		)
+	}
+
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	out := progressWriter(outputFormat)
+
+	// A file that is no Dockerfile at all is caught before asking the server
+	var problem *DockerfileProblem
+	if err := CheckDockerfile(dockerfilePath); errors.As(err, &problem) {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] %s", problem.Error()),
+			"",
+			"[TIP] "+problem.Tip,
+		)
+	} else if err != nil {
+		return fmt.Errorf("failed to read dockerfile: %w", err)
+	}
+	content, err := os.ReadFile(dockerfilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read dockerfile: %w", err)
+	}
+
+	// Load configuration and check authentication
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+
+	fmt.Fprintf(out, "[SEARCH] Validating the create request for '%s' on the server...\n", imageName)
+	apiClient := client.NewFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+	defer cancel()
+
+	resp, httpResp, err := apiClient.ImageAPI.ValidateCreate(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, client.ImageValidateOptions{
+		ImageName:     imageName,
+		SourceImageId: sourceImageId,
+		Dockerfile:    string(content),
+		Platforms:     platforms,
+	})
+	var apiErr *client.GenericOpenAPIError
+	if err != nil && errors.As(err, &apiErr) && httpResp != nil &&
+		(httpResp.StatusCode == http.StatusNotFound || httpResp.StatusCode == http.StatusNotImplemented) {
+		return printErrorMessage(
+			"[ERROR] This AgbCloud endpoint does not support server-side validation",
+			"",
+			"[TIP] Check the Dockerfile locally instead: 'agbcloud image create' lints it before uploading",
+		)
+	}
+	if err != nil {
+		return requestError(out, "failed to validate the create request", httpResp, err)
+	}
+
+	validation := NewImageValidation(imageName, sourceImageId, dockerfilePath, resp.Data)
+	if outputFormat.IsStructured() {
+		if err := writeResult(outputFormat, validation); err != nil {
+			return err
+		}
+	} else {
+		printImageValidation(os.Stdout, validation)
+	}
+
+	switch {
+	case validation.Errors > 0:
+		return fmt.Errorf("the server found %d error(s) in the create request", validation.Errors)
+	case !validation.Valid:
+		return fmt.Errorf("the server rejected the create request")
+	case failOnWarnings && validation.Warnings > 0:
+		return fmt.Errorf("the server found %d warning(s) in the create request (--fail-on-warnings)", validation.Warnings)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+)
+
+// WarningRecorder prints warnings as they happen and remembers them so that
+// 'image create --fail-on-warnings' can fail a run that eventually succeeded.
+// A nil recorder only prints.
+type WarningRecorder struct {
+	warnings []string
+}
+
+// Warn prints a [WARN] line and records the warning
+func (r *WarningRecorder) Warn(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	fmt.Printf("[WARN]  %s\n", message)
+	if r != nil {
+		r.warnings = append(r.warnings, message)
+	}
+}
+
+// Warnings returns the recorded warnings in the order they occurred
+func (r *WarningRecorder) Warnings() []string {
+	if r == nil {
+		return nil
+	}
+	return append([]string(nil), r.warnings...)
+}
+
+// Check returns an error listing the recorded warnings when strict is set and any occurred
+func (r *WarningRecorder) Check(strict bool) error {
+	warnings := r.Warnings()
+	if !strict || len(warnings) == 0 {
+		return nil
+	}
+
+	lines := []string{
+		fmt.Sprintf("[ERROR] %d warning(s) occurred and --fail-on-warnings is set:", len(warnings)),
+	}
+	for _, warning := range warnings {
+		lines = append(lines, "• "+warning)
+	}
+	lines = append(lines, "", "[TIP] Fix the warnings above, or run without --fail-on-warnings to accept them")
+	return printErrorMessage(lines...)
+}
+
+// dockerfileInstructions are the instructions accepted by the Dockerfile syntax
+var dockerfileInstructions = map[string]bool{
+	"ADD": true, "ARG": true, "CMD": true, "COPY": true, "ENTRYPOINT": true, "ENV": true,
+	"EXPOSE": true, "FROM": true, "HEALTHCHECK": true, "LABEL": true, "MAINTAINER": true,
+	"ONBUILD": true, "RUN": true, "SHELL": true, "STOPSIGNAL": true, "USER": true,
+	"VOLUME": true, "WORKDIR": true,
+}
+
+// LintDockerfile performs lightweight local checks on a Dockerfile and returns
+// one message per problem found. The server performs the authoritative validation.
+func LintDockerfile(content string) []string {
+	var warnings []string
+	instructions := 0
+	continued := false
+	lastLine := 0
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lastLine = lineNo
+
+		wasContinued := continued
+		continued = strings.HasSuffix(line, "\\")
+		if wasContinued {
+			continue
+		}
+
+		instructions++
+		keyword := strings.ToUpper(strings.Fields(line)[0])
+		switch {
+		case !dockerfileInstructions[keyword]:
+			warnings = append(warnings, fmt.Sprintf("Dockerfile line %d: unknown instruction '%s'", lineNo, strings.Fields(line)[0]))
+		case keyword == "MAINTAINER":
+			warnings = append(warnings, fmt.Sprintf("Dockerfile line %d: MAINTAINER is deprecated, use LABEL instead", lineNo))
+		case keyword == "ADD" && (strings.Contains(line, "http://") || strings.Contains(line, "https://")):
+			warnings = append(warnings, fmt.Sprintf("Dockerfile line %d: prefer RUN curl/wget over ADD with a remote URL", lineNo))
+		}
+	}
+
+	if instructions == 0 {
+		warnings = append(warnings, "Dockerfile contains no instructions")
+	}
+	if continued {
+		warnings = append(warnings, fmt.Sprintf("Dockerfile line %d: line continuation at end of file", lastLine))
+	}
+	return warnings
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// ValidateCallbackURL checks a --callback-url value. The server calls the URL from the
+// internet, so it must be an absolute https URL; credentials are not accepted because
+// the URL is printed and stored with the task.
+func ValidateCallbackURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("not a valid URL: %v", err)
+	}
+	switch {
+	case parsed.Scheme != "https":
+		return fmt.Errorf("%q must be an https:// URL", raw)
+	case parsed.Host == "":
+		return fmt.Errorf("%q has no host", raw)
+	case parsed.User != nil:
+		return fmt.Errorf("%q must not contain credentials; use a secret path or query parameter the receiver checks instead", parsed.Redacted())
+	}
+	return nil
+}
+
+// FormatWebhookDelivery describes the delivery status of a build webhook in one line
+func FormatWebhookDelivery(webhook client.ImageTaskWebhook) string {
+	var details []string
+	if webhook.LastResponseCode != 0 {
+		details = append(details, fmt.Sprintf("HTTP %d", webhook.LastResponseCode))
+	}
+	if webhook.LastError != "" {
+		details = append(details, webhook.LastError)
+	}
+	if webhook.Attempts > 0 {
+		details = append(details, fmt.Sprintf("%d attempt(s)", webhook.Attempts))
+	}
+	if webhook.LastAttemptTime != "" {
+		details = append(details, "last "+formatTimestamp(webhook.LastAttemptTime))
+	}
+
+	line := fmt.Sprintf("%s %s", valueOrDash(webhook.Status), webhook.URL)
+	if len(details) > 0 {
+		line += " (" + strings.Join(details, ", ") + ")"
+	}
+	return line
+}
+
+// verifyImageWebhook checks that the server registered the webhook of a build it
+// accepted. Servers without webhook support ignore the callback URL, which is only
+// found out by reading the task back.
+func verifyImageWebhook(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId, taskId, callbackURL string, warnings *WarningRecorder) {
+	taskResp, _, err := apiClient.ImageAPI.GetImageTask(ctx, loginToken, sessionId, taskId)
+	if err != nil {
+		warnings.Warn("Could not verify that the webhook was registered: %v", err)
+		return
+	}
+	webhook := taskResp.Data.Webhook
+	if webhook == nil || webhook.URL != callbackURL {
+		warnings.Warn("The server did not register the webhook; it does not support --callback-url")
+		fmt.Printf("[TIP] Follow the build with: agbcloud image task %s --watch\n", taskId)
+		return
+	}
+	fmt.Printf("[HOOK] Webhook registered: %s is called when the build finishes or fails\n", webhook.URL)
+	fmt.Printf("[TIP] Check its delivery with: agbcloud image task %s\n", taskId)
+}
@@ -0,0 +1,319 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var InitCmd = &cobra.Command{
+	Use:     "init",
+	Short:   "Set up your first image step by step",
+	GroupID: "core",
+	Long: `Walk through everything needed to get a first image running:
+
+  1. Log in (skipped when already logged in)
+  2. Choose a System base image
+  3. Generate a starter Dockerfile, or use an existing one
+  4. Create the image
+  5. Activate it
+
+Every step prints the command that does the same without questions, so the
+setup can be repeated in scripts. The wizard needs an interactive terminal.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !isInteractiveInput() {
+			lines := []string{
+				"[ERROR] 'agbcloud init' asks questions and needs an interactive terminal",
+				"",
+				"[TIP] Run the same steps without questions:",
+			}
+			for _, command := range initCommandTemplate {
+				lines = append(lines, "  "+command)
+			}
+			return printErrorMessage(lines...)
+		}
+		return RunInitWizard(commandContext(cmd), promptInput, os.Stdout)
+	},
+}
+
+// initCommandTemplate lists the commands that make up the wizard, for non-interactive use
+var initCommandTemplate = []string{
+	"agbcloud login",
+	"agbcloud image list -t System",
+	"agbcloud image create <image-name> --dockerfile ./Dockerfile --imageId <base-image-id>",
+	"agbcloud image activate <image-id> [--cpu <cores> --memory <gb>]",
+}
+
+const (
+	initTotalSteps         = 5
+	initDefaultDockerfile  = "Dockerfile"
+	initDefaultImageName   = "my-first-image"
+	initSystemImagesToList = 50
+)
+
+// starterDockerfile is written by the wizard; %s is the chosen base image
+const starterDockerfile = `# Starter Dockerfile generated by 'agbcloud init'
+# Base image: %s (selected with --imageId when the image is created)
+#
+# Add the tools and files your environment needs, for example:
+#   RUN apt-get update && apt-get install -y --no-install-recommends git && rm -rf /var/lib/apt/lists/*
+#   COPY ./app /opt/app
+RUN echo "Image built with agbcloud init"
+`
+
+// initWizard holds the state of one 'agbcloud init' run
+type initWizard struct {
+	ctx context.Context
+	in  *bufio.Reader
+	out io.Writer
+	// commands are the non-interactive equivalents of the steps taken so far
+	commands []string
+}
+
+// RunInitWizard runs the setup wizard, reading answers from in and writing the
+// questions to out. Commands run by the wizard print to stdout as usual and
+// are cancelled with ctx.
+func RunInitWizard(ctx context.Context, in io.Reader, out io.Writer) error {
+	w := &initWizard{ctx: ctx, in: bufio.NewReader(in), out: out}
+
+	fmt.Fprintln(out, "[>>] Welcome to AgbCloud! This wizard sets up and activates your first image.")
+	fmt.Fprintln(out, "[NOTE] Press Enter to accept the default shown in [brackets]")
+
+	cfg, err := w.login()
+	if err != nil {
+		return err
+	}
+
+	apiClient := client.NewFromConfig(cfg)
+	chooseCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	baseImage, err := w.chooseBaseImage(chooseCtx, apiClient, cfg)
+	if err != nil {
+		return err
+	}
+
+	dockerfilePath, err := w.prepareDockerfile(baseImage)
+	if err != nil {
+		return err
+	}
+
+	imageName := w.chooseImageName(chooseCtx, apiClient, cfg)
+	createCommand := fmt.Sprintf("agbcloud image create %s --dockerfile %s --imageId %s", imageName, dockerfilePath, baseImage)
+	w.step(4, "Create the image", createCommand)
+	if !Confirm(w.in, out, fmt.Sprintf("Create image '%s' now? This can take several minutes", imageName), true) {
+		return w.finish("[NOTE] Skipped; create the image later with the command above")
+	}
+	if err := runInitSubcommand(ctx, imageCreateCmd, []string{imageName}, map[string]string{
+		"dockerfile": dockerfilePath,
+		"imageId":    baseImage,
+	}); err != nil {
+		return err
+	}
+	w.commands = append(w.commands, createCommand)
+
+	lookupCtx, lookupCancel := context.WithTimeout(ctx, 30*time.Second)
+	defer lookupCancel()
+	image, err := FindUserImageByName(lookupCtx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, imageName)
+	if err != nil || image == nil {
+		return w.finish("[WARN]  Could not find the new image; run 'agbcloud image list' to see its ID")
+	}
+
+	return w.activate(image.ImageID)
+}
+
+// step prints the heading of a wizard step with its non-interactive equivalent
+func (w *initWizard) step(n int, title, command string) {
+	fmt.Fprintln(w.out)
+	fmt.Fprintf(w.out, "[STEP] %d/%d %s\n", n, initTotalSteps, title)
+	if command != "" {
+		fmt.Fprintf(w.out, "[TIP] Without the wizard: %s\n", command)
+	}
+}
+
+// login makes sure a session exists, starting the browser login if needed
+func (w *initWizard) login() (*config.Config, error) {
+	w.step(1, "Log in", "agbcloud login")
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token != nil && cfg.Token.LoginToken != "" && cfg.Token.SessionId != "" {
+		fmt.Fprintln(w.out, "[OK] Already logged in")
+		return cfg, nil
+	}
+
+	if !Confirm(w.in, w.out, "You are not logged in. Open the browser to log in now?", true) {
+		return nil, printErrorMessage(
+			"[ERROR] Logging in is required to create images",
+			"",
+			"[TIP] Run 'agbcloud login', then 'agbcloud init' again",
+		)
+	}
+	if err := runInitSubcommand(w.ctx, LoginCmd, nil, nil); err != nil {
+		return nil, err
+	}
+	w.commands = append(w.commands, "agbcloud login")
+
+	cfg, err = config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return nil, fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+	return cfg, nil
+}
+
+// chooseBaseImage lists the System images and lets the user pick one
+func (w *initWizard) chooseBaseImage(ctx context.Context, apiClient *client.APIClient, cfg *config.Config) (string, error) {
+	w.step(2, "Choose a base image", "agbcloud image list -t System")
+
+	listResp, httpResp, err := apiClient.ImageAPI.ListImages(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, client.ImageListOptions{
+		ImageType: "System",
+		Page:      1,
+		PageSize:  initSystemImagesToList,
+	})
+	if err != nil {
+		return "", requestError(w.out, "failed to list base images", httpResp, err)
+	}
+
+	images := listResp.Data.Images
+	if len(images) == 0 {
+		return "", printErrorMessage("[ERROR] No System base images are available")
+	}
+	options := make([]string, len(images))
+	for i, image := range images {
+		options[i] = fmt.Sprintf("%-22s %s", image.ImageID, image.ImageName)
+		if image.Version != "" {
+			options[i] += " (" + image.Version + ")"
+		}
+	}
+	chosen := images[Choose(w.in, w.out, "Base image", options, 0)].ImageID
+	fmt.Fprintf(w.out, "[OK] Base image: %s\n", chosen)
+	return chosen, nil
+}
+
+// prepareDockerfile writes a starter Dockerfile unless the user keeps an existing one
+func (w *initWizard) prepareDockerfile(baseImage string) (string, error) {
+	w.step(3, "Prepare a Dockerfile", "")
+
+	path := Ask(w.in, w.out, "Dockerfile path", initDefaultDockerfile)
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		if Confirm(w.in, w.out, fmt.Sprintf("%s already exists. Keep it? (no replaces it with a starter Dockerfile)", path), true) {
+			fmt.Fprintf(w.out, "[OK] Using %s\n", path)
+			return path, nil
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(fmt.Sprintf(starterDockerfile, baseImage)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write dockerfile: %w", err)
+	}
+	fmt.Fprintf(w.out, "[OK] Starter Dockerfile written to %s\n", path)
+	fmt.Fprintln(w.out, "[TIP] Edit it to add the tools you need before creating more images")
+	return path, nil
+}
+
+// chooseImageName asks for a valid image name that is not taken yet
+func (w *initWizard) chooseImageName(ctx context.Context, apiClient *client.APIClient, cfg *config.Config) string {
+	defaultName := initDefaultImageName
+	for {
+		name := Ask(w.in, w.out, "Image name", defaultName)
+		if len(name) < imageNameMinLength || len(name) > imageNameMaxLength || !imageNamePattern.MatchString(name) {
+			fmt.Fprintf(w.out, "[WARN]  Image names have %d-%d characters, start with a letter and contain only letters, digits, '.', '_' or '-'\n",
+				imageNameMinLength, imageNameMaxLength)
+			continue
+		}
+		existing, err := FindUserImageByName(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, name)
+		if err == nil && existing != nil {
+			fmt.Fprintf(w.out, "[WARN]  An image named '%s' already exists (Image ID: %s)\n", name, existing.ImageID)
+			if name == defaultName {
+				// Offer a fresh default so that pressing Enter cannot loop forever
+				defaultName = fmt.Sprintf("%s-%d", initDefaultImageName, time.Now().Unix()%100000)
+			}
+			continue
+		}
+		return name
+	}
+}
+
+// activate offers to activate the new image with one of the supported resource sizes
+func (w *initWizard) activate(imageId string) error {
+	w.step(5, "Activate the image", fmt.Sprintf("agbcloud image activate %s [--cpu <cores> --memory <gb>]", imageId))
+	if !Confirm(w.in, w.out, "Activate the image now?", true) {
+		return w.finish("[NOTE] Skipped; activate the image later with the command above")
+	}
+
+	sizes := []struct{ label, cpu, memory string }{
+		{"Default resources", "0", "0"},
+		{"2 CPU cores, 4 GB memory", "2", "4"},
+		{"4 CPU cores, 8 GB memory", "4", "8"},
+		{"8 CPU cores, 16 GB memory", "8", "16"},
+	}
+	options := make([]string, len(sizes))
+	for i, size := range sizes {
+		options[i] = size.label
+	}
+	size := sizes[Choose(w.in, w.out, "Resources", options, 0)]
+
+	command := "agbcloud image activate " + imageId
+	if size.cpu != "0" {
+		command += fmt.Sprintf(" --cpu %s --memory %s", size.cpu, size.memory)
+	}
+	if err := runInitSubcommand(w.ctx, imageActivateCmd, []string{imageId}, map[string]string{
+		"cpu":    size.cpu,
+		"memory": size.memory,
+	}); err != nil {
+		return err
+	}
+	w.commands = append(w.commands, command)
+	return w.finish("[OK] All set! Your image is activated")
+}
+
+// finish prints a closing message and the commands that repeat the steps taken
+func (w *initWizard) finish(message string) error {
+	fmt.Fprintln(w.out)
+	fmt.Fprintln(w.out, message)
+	if len(w.commands) > 0 {
+		fmt.Fprintln(w.out, "[TIP] To repeat these steps in a script, run:")
+		for _, command := range w.commands {
+			fmt.Fprintf(w.out, "  %s\n", command)
+		}
+	}
+	return nil
+}
+
+// runInitSubcommand runs another command of the CLI with the given flag values and context
+func runInitSubcommand(ctx context.Context, c *cobra.Command, args []string, flags map[string]string) error {
+	for name, value := range flags {
+		if err := c.Flags().Set(name, value); err != nil {
+			return fmt.Errorf("invalid --%s value %s: %w", name, strconv.Quote(value), err)
+		}
+	}
+	if c.Args != nil {
+		if err := c.Args(c, args); err != nil {
+			return err
+		}
+	}
+	c.SetContext(ctx)
+	return c.RunE(c, args)
+}
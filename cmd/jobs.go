@@ -0,0 +1,611 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/imagestatus"
+)
+
+var JobsCmd = &cobra.Command{
+	Use:   "jobs",
+	Short: "Follow operations started with --detach",
+	Long: `Follow the image builds and activations started with --detach.
+
+'agbcloud image create --detach' and 'agbcloud image activate --detach' return as
+soon as the operation has started on the server and record it as a job in the
+configuration directory. Any terminal can then resume its progress output with
+'agbcloud jobs attach', or stop it with 'agbcloud jobs cancel'.
+
+'agbcloud image activate --lease' records a lease job, which deactivates the image
+when the lease ends. 'agbcloud jobs attach' watches the lease like the activating
+command does, and 'agbcloud jobs enforce' deactivates images whose lease has ended
+while no command was watching it.`,
+	GroupID: "management",
+}
+
+var jobsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List jobs and the status of their operations",
+	Long: `List the jobs of the active profile and endpoint with the current status of their
+operations. As with shell jobs, a job whose operation has ended is shown once more
+with its final status and then removed.`,
+	Example: `  agbcloud jobs list
+  agbcloud jobs list -o json`,
+	Args: cobra.NoArgs,
+	RunE: runJobsList,
+}
+
+var jobsAttachCmd = &cobra.Command{
+	Use:   "attach <job-id>",
+	Short: "Resume the progress output of a job",
+	Long: `Monitor the operation of a job until it ends, printing its progress as the command
+that started it would have. Press Ctrl+C to detach again; the operation continues
+on the server. The job is removed once its operation has ended.`,
+	Example: `  agbcloud jobs attach 3`,
+	Args:    jobIDArgs("agbcloud jobs attach <job-id>"),
+	RunE:    runJobsAttach,
+}
+
+var jobsEnforceCmd = &cobra.Command{
+	Use:   "enforce",
+	Short: "Deactivate images whose lease has ended",
+	Long: `Deactivate the images of lease jobs whose lease has ended, and remove those jobs.
+
+Leases are enforced by the CLI, not by the server: the command that activated the
+image or 'agbcloud jobs attach' watches the lease and deactivates the image when it
+ends. A lease left after Ctrl+C is watched by nothing, so run this command on a
+schedule, e.g. from cron, to make sure such images are deactivated.`,
+	Example: `  agbcloud jobs enforce
+
+  # crontab entry that ends leases every 5 minutes
+  */5 * * * * agbcloud jobs enforce`,
+	Args: cobra.NoArgs,
+	RunE: runJobsEnforce,
+}
+
+var jobsCancelCmd = &cobra.Command{
+	Use:   "cancel <job-id>",
+	Short: "Stop the operation of a job",
+	Long: `Stop the operation of a job and remove the job. The build task of an image creation
+is deleted on the server; an activation or a lease is stopped by deactivating the
+image. A job whose operation has already ended is only removed.`,
+	Example: `  agbcloud jobs cancel 3`,
+	Args:    jobIDArgs("agbcloud jobs cancel <job-id>"),
+	RunE:    runJobsCancel,
+}
+
+// Job types
+const (
+	JobCreate   = "create"
+	JobActivate = "activate"
+	// JobLease deactivates an image activated with --lease when the lease ends
+	JobLease = "lease"
+)
+
+func init() {
+	jobsAttachCmd.Flags().Bool("verbose-poll", false, "Print every status check instead of only status changes")
+
+	JobsCmd.AddCommand(jobsListCmd)
+	JobsCmd.AddCommand(jobsAttachCmd)
+	JobsCmd.AddCommand(jobsEnforceCmd)
+	JobsCmd.AddCommand(jobsCancelCmd)
+
+	registerOutputSchema(jobsListCmd, outputSchema{
+		Command:     "jobs list",
+		Version:     1,
+		Description: "Jobs of the active profile and endpoint. status is empty when it could not be checked; ended jobs are removed after being listed.",
+		Result:      []JobListItem{},
+	})
+}
+
+// jobIDArgs validates the single job ID argument of a jobs subcommand
+func jobIDArgs(usage string) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return printErrorMessage(
+				"[ERROR] Expected exactly one argument: <job-id>",
+				"",
+				"[TIP] Usage: "+usage,
+				"[TIP] Run 'agbcloud jobs list' to see your jobs",
+			)
+		}
+		return nil
+	}
+}
+
+// Job is a long-running operation started with --detach
+type Job struct {
+	ID   int    `json:"id"`
+	Type string `json:"type"`
+	// ImageName is the image built by a create job, or the name of an activated image if known
+	ImageName string `json:"imageName,omitempty"`
+	// ImageID is the image activated by an activate job
+	ImageID string `json:"imageId,omitempty"`
+	// TaskID is the build task of a create job
+	TaskID string `json:"taskId,omitempty"`
+	// CleanupOnFailure deletes the task of a failed build, as with --cleanup-on-failure
+	CleanupOnFailure bool `json:"cleanupOnFailure,omitempty"`
+	// LeaseExpiresAt is when a lease job deactivates its image
+	LeaseExpiresAt *time.Time `json:"leaseExpiresAt,omitempty"`
+	// LeaseDuration is how long a lease lasts and how much a renewal extends it
+	LeaseDuration string `json:"leaseDuration,omitempty"`
+	// AutoRenewals is how many more times a lease is renewed without asking
+	AutoRenewals int       `json:"autoRenewals,omitempty"`
+	Profile      string    `json:"profile"`
+	Endpoint     string    `json:"endpoint"`
+	StartedAt    time.Time `json:"startedAt"`
+}
+
+// Target names the image the job works on
+func (j Job) Target() string {
+	if j.ImageName != "" {
+		return j.ImageName
+	}
+	return j.ImageID
+}
+
+// JobListItem is the structured representation of a job in `jobs list`
+type JobListItem struct {
+	ID        int    `json:"id"`
+	Type      string `json:"type"`
+	ImageName string `json:"imageName"`
+	ImageID   string `json:"imageId"`
+	TaskID    string `json:"taskId"`
+	Status    string `json:"status"`
+	Ended     bool   `json:"ended"`
+	StartedAt string `json:"startedAt"`
+	// LeaseExpiresAt is when the image of a lease job is deactivated; empty for other jobs
+	LeaseExpiresAt string `json:"leaseExpiresAt"`
+}
+
+// jobsMu serializes updates of the jobs file by parallel commands
+var jobsMu sync.Mutex
+
+// jobsFile is the content of the jobs file
+type jobsFile struct {
+	NextID int   `json:"nextId"`
+	Jobs   []Job `json:"jobs"`
+}
+
+// jobsPath returns the path of the jobs file
+func jobsPath() (string, error) {
+	configDir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "jobs.json"), nil
+}
+
+// readJobs reads the jobs file; a missing file has no jobs
+func readJobs(path string) (jobsFile, error) {
+	var jobs jobsFile
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return jobs, nil
+		}
+		return jobs, err
+	}
+	if err := json.Unmarshal(content, &jobs); err != nil {
+		return jobsFile{}, fmt.Errorf("invalid jobs file %s: %w", path, err)
+	}
+	return jobs, nil
+}
+
+func writeJobs(path string, jobs jobsFile) error {
+	content, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	// Write to a temporary file first so that a concurrent reader never sees half a file
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// updateJobs applies update to the jobs file
+func updateJobs(update func(jobs *jobsFile)) error {
+	path, err := jobsPath()
+	if err != nil {
+		return err
+	}
+	jobsMu.Lock()
+	defer jobsMu.Unlock()
+	jobs, err := readJobs(path)
+	if err != nil {
+		return err
+	}
+	update(&jobs)
+	return writeJobs(path, jobs)
+}
+
+// AddJob records a job for the active profile and endpoint and returns it with its ID
+func AddJob(job Job) (Job, error) {
+	job.Profile, job.Endpoint = imageListCacheScope()
+	if job.StartedAt.IsZero() {
+		job.StartedAt = time.Now().UTC()
+	}
+	err := updateJobs(func(jobs *jobsFile) {
+		jobs.NextID = max(jobs.NextID, 1)
+		job.ID = jobs.NextID
+		jobs.NextID++
+		jobs.Jobs = append(jobs.Jobs, job)
+	})
+	return job, err
+}
+
+// LoadJobs returns the jobs of the active profile and endpoint, oldest first
+func LoadJobs() ([]Job, error) {
+	path, err := jobsPath()
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := readJobs(path)
+	if err != nil {
+		return nil, err
+	}
+	profile, endpoint := imageListCacheScope()
+	var scoped []Job
+	for _, job := range jobs.Jobs {
+		if job.Profile == profile && job.Endpoint == endpoint {
+			scoped = append(scoped, job)
+		}
+	}
+	return scoped, nil
+}
+
+// FindJob returns the job with the given ID. Jobs of another profile or endpoint are
+// rejected, since they cannot be followed with the active credentials.
+func FindJob(ref string) (Job, error) {
+	id, err := strconv.Atoi(ref)
+	if err != nil || id <= 0 {
+		return Job{}, fmt.Errorf("invalid job ID '%s': expected a number as shown by 'agbcloud jobs list'", ref)
+	}
+	path, err := jobsPath()
+	if err != nil {
+		return Job{}, err
+	}
+	jobs, err := readJobs(path)
+	if err != nil {
+		return Job{}, err
+	}
+	index := slices.IndexFunc(jobs.Jobs, func(job Job) bool { return job.ID == id })
+	if index < 0 {
+		return Job{}, fmt.Errorf("job %d not found; run 'agbcloud jobs list' to see your jobs", id)
+	}
+	job := jobs.Jobs[index]
+	if profile, endpoint := imageListCacheScope(); job.Profile != profile || job.Endpoint != endpoint {
+		return Job{}, fmt.Errorf("job %d was started with %s on %s; switch to them to follow it", id, describeJobProfile(job.Profile), job.Endpoint)
+	}
+	return job, nil
+}
+
+func describeJobProfile(profile string) string {
+	if profile == "" {
+		return "the default profile"
+	}
+	return fmt.Sprintf("profile '%s'", profile)
+}
+
+// RemoveJob forgets a job; removing a job that does not exist is not an error
+func RemoveJob(id int) error {
+	return updateJobs(func(jobs *jobsFile) {
+		jobs.Jobs = slices.DeleteFunc(jobs.Jobs, func(job Job) bool { return job.ID == id })
+	})
+}
+
+// removeJob forgets a job whose operation has ended. A job that cannot be removed
+// is shown as ended by the next 'jobs list' and removed then.
+func removeJob(id int) {
+	if err := RemoveJob(id); err != nil {
+		log.Debugf("Could not remove job %d: %v", id, err)
+	}
+}
+
+// detachJob records an operation started with --detach and tells how to follow it
+func detachJob(job Job) error {
+	job, err := AddJob(job)
+	if err != nil {
+		return fmt.Errorf("the operation was started, but could not be recorded as a job: %w", err)
+	}
+	printDetachedJob(job)
+	return nil
+}
+
+// printDetachedJob tells how to follow a job that runs in the background
+func printDetachedJob(job Job) {
+	fmt.Printf("[JOB] Running in the background as job %d\n", job.ID)
+	fmt.Printf("[TIP] Follow it with 'agbcloud jobs attach %d', or stop it with 'agbcloud jobs cancel %d'\n", job.ID, job.ID)
+}
+
+// jobStatus returns the current status of the operation of a job, formatted for
+// display, and whether the operation has ended
+func jobStatus(ctx context.Context, apiClient *client.APIClient, loginToken, sessionId string, job Job) (string, bool, error) {
+	if job.Type == JobCreate {
+		resp, _, err := apiClient.ImageAPI.GetImageTask(ctx, loginToken, sessionId, job.TaskID)
+		if err != nil {
+			if code, _ := client.ErrorCode(err); normalizeErrorCode(code) == "TASKNOTFOUND" {
+				return "Task Not Found", true, nil
+			}
+			return "", false, err
+		}
+		return resp.Data.Status, !IsImageTaskInProgress(resp.Data.Status), nil
+	}
+
+	resp, _, err := apiClient.ImageAPI.ListImages(ctx, loginToken, sessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{job.ImageID}})
+	if err != nil {
+		return "", false, err
+	}
+	if len(resp.Data.Images) == 0 {
+		return "Image Not Found", true, nil
+	}
+	status := imagestatus.Status(resp.Data.Images[0].Status)
+	if job.Type == JobLease {
+		// A lease ends early when the image is deactivated by other means
+		return status.Label(), !status.HasInstance(), nil
+	}
+	return status.Label(), status != imagestatus.Deploying, nil
+}
+
+// jobsClient loads the configuration and creates an API client for the jobs commands
+func jobsClient() (*client.APIClient, *config.Config, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return nil, nil, fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+	return client.NewFromConfig(cfg), cfg, nil
+}
+
+func runJobsList(cmd *cobra.Command, args []string) error {
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if err := applyTimeFormat(cmd); err != nil {
+		return err
+	}
+
+	jobs, err := LoadJobs()
+	if err != nil {
+		return fmt.Errorf("failed to read jobs: %w", err)
+	}
+	items := make([]JobListItem, 0, len(jobs))
+	if len(jobs) > 0 {
+		apiClient, cfg, err := jobsClient()
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+		defer cancel()
+
+		progress := progressWriter(outputFormat)
+		fmt.Fprintln(progress, "[SEARCH] Checking the status of your jobs...")
+		for _, job := range jobs {
+			status, ended, err := jobStatus(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, job)
+			if err != nil {
+				log.Debugf("Could not check the status of job %d: %v", job.ID, err)
+			}
+			if ended {
+				removeJob(job.ID)
+			}
+			item := JobListItem{
+				ID:        job.ID,
+				Type:      job.Type,
+				ImageName: job.ImageName,
+				ImageID:   job.ImageID,
+				TaskID:    job.TaskID,
+				Status:    status,
+				Ended:     ended,
+				StartedAt: job.StartedAt.Format(time.RFC3339),
+			}
+			if job.LeaseExpiresAt != nil {
+				item.LeaseExpiresAt = job.LeaseExpiresAt.Format(time.RFC3339)
+			}
+			items = append(items, item)
+		}
+	}
+
+	if outputFormat.IsStructured() {
+		return writeResult(outputFormat, items)
+	}
+	if len(items) == 0 {
+		fmt.Println("[EMPTY] No jobs.")
+		fmt.Println("[TIP] Start one with 'agbcloud image create --detach' or 'agbcloud image activate --detach'")
+		return nil
+	}
+
+	defer startPager()()
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "JOB\tTYPE\tIMAGE\tSTATUS\tSTARTED")
+	ended, leasesEnded := 0, 0
+	for _, item := range items {
+		target := item.ImageName
+		if target == "" {
+			target = item.ImageID
+		}
+		status := valueOrDash(item.Status)
+		if item.Ended {
+			status += " (ended)"
+			ended++
+		} else if expires, err := time.Parse(time.RFC3339, item.LeaseExpiresAt); err == nil {
+			if expires.After(time.Now()) {
+				status += ", lease until " + formatLeaseEnd(expires)
+			} else {
+				status += ", lease ended at " + formatLeaseEnd(expires)
+				leasesEnded++
+			}
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", item.ID, item.Type, target, status, formatTimestamp(item.StartedAt))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if ended > 0 {
+		fmt.Println()
+		fmt.Println("[NOTE] Jobs that have ended are removed from the list now")
+	}
+	if leasesEnded > 0 {
+		fmt.Println()
+		fmt.Printf("[WARN]  %d lease(s) ended while no command was watching; their images are still activated\n", leasesEnded)
+		fmt.Println("[TIP] Run 'agbcloud jobs enforce' to deactivate them")
+	}
+	return nil
+}
+
+func runJobsEnforce(cmd *cobra.Command, args []string) error {
+	jobs, err := LoadJobs()
+	if err != nil {
+		return fmt.Errorf("failed to read jobs: %w", err)
+	}
+	now := time.Now()
+	jobs = slices.DeleteFunc(jobs, func(job Job) bool { return !job.leaseExpired(now) })
+	if len(jobs) == 0 {
+		fmt.Println("[OK] No lease has ended")
+		return nil
+	}
+
+	apiClient, cfg, err := jobsClient()
+	if err != nil {
+		return err
+	}
+	ctx := commandContext(cmd)
+	ended, failed := 0, 0
+	for _, job := range jobs {
+		statusCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		status, stopped, err := jobStatus(statusCtx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, job)
+		cancel()
+		if err == nil && stopped {
+			// The image was deactivated by other means
+			removeJob(job.ID)
+			fmt.Printf("[NOTE] The lease of '%s' ended and the image is no longer activated (%s)\n", job.Target(), status)
+			ended++
+			continue
+		}
+		if err := expireLease(ctx, os.Stdout, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, job); err != nil {
+			failed++
+			continue
+		}
+		ended++
+	}
+	fmt.Printf("[DATA] Summary: %d lease(s) ended, %d failed\n", ended, failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to end %d of %d lease(s)", failed, len(jobs))
+	}
+	return nil
+}
+
+func runJobsAttach(cmd *cobra.Command, args []string) error {
+	verbosePoll, _ := cmd.Flags().GetBool("verbose-poll")
+	job, err := FindJob(args[0])
+	if err != nil {
+		return err
+	}
+	apiClient, cfg, err := jobsClient()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("[REFRESH] Attaching to job %d (%s %s)...\n", job.ID, job.Type, job.Target())
+	monitorCtx := commandContext(cmd)
+	if job.Type == JobCreate {
+		fmt.Printf("[DOC] Task ID: %s\n", job.TaskID)
+		ctx, cancel := context.WithTimeout(monitorCtx, 45*time.Minute)
+		defer cancel()
+		_, err = monitorImageTask(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, job.TaskID, &WarningRecorder{}, job.CleanupOnFailure, verbosePoll)
+	} else {
+		fmt.Println("[MONITOR] Monitoring image activation status...")
+		err = pollImageActivationStatus(monitorCtx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, job.ImageID, verbosePoll)
+		if err == nil && job.Type == JobLease {
+			// The job ends with the lease, which removes it
+			err = watchLease(monitorCtx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, job)
+			if err == nil {
+				return nil
+			}
+		}
+	}
+
+	switch {
+	case err == nil, errors.Is(err, errImageTaskFailed), errors.Is(err, errImageActivationFailed):
+		removeJob(job.ID)
+	case Interrupted(monitorCtx, err):
+		fmt.Printf("[NOTE] Detached from job %d; the operation continues on the server. Run 'agbcloud jobs attach %d' to follow it again\n", job.ID, job.ID)
+	}
+	return err
+}
+
+func runJobsCancel(cmd *cobra.Command, args []string) error {
+	job, err := FindJob(args[0])
+	if err != nil {
+		return err
+	}
+	apiClient, cfg, err := jobsClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+	defer cancel()
+
+	status, ended, err := jobStatus(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, job)
+	if err != nil {
+		return requestError(os.Stdout, "failed to check the job status", nil, err)
+	}
+	if ended {
+		if err := RemoveJob(job.ID); err != nil {
+			return fmt.Errorf("failed to remove job %d: %w", job.ID, err)
+		}
+		fmt.Printf("[NOTE] Job %d has already ended (status: %s) and was removed\n", job.ID, status)
+		return nil
+	}
+
+	if job.Type == JobCreate {
+		fmt.Printf("[STOP] Cancelling the build of '%s' (task %s)...\n", job.Target(), job.TaskID)
+		if err := deleteImageTask(ctx, apiClient, cfg.Token.LoginToken, cfg.Token.SessionId, job.TaskID); err != nil {
+			return fmt.Errorf("failed to cancel job %d: %w", job.ID, err)
+		}
+	} else if job.Type == JobLease {
+		fmt.Printf("[STOP] Ending the lease of '%s' now by deactivating it...\n", job.Target())
+		if _, httpResp, err := apiClient.ImageAPI.StopImage(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, job.ImageID, client.ImageStopOptions{}); err != nil {
+			return requestError(os.Stdout, "failed to deactivate image", httpResp, err)
+		}
+	} else {
+		fmt.Printf("[STOP] Cancelling the activation of '%s' by deactivating it...\n", job.Target())
+		if _, httpResp, err := apiClient.ImageAPI.StopImage(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, job.ImageID, client.ImageStopOptions{}); err != nil {
+			return requestError(os.Stdout, "failed to deactivate image", httpResp, err)
+		}
+	}
+	if err := RemoveJob(job.ID); err != nil {
+		return fmt.Errorf("failed to remove job %d: %w", job.ID, err)
+	}
+	fmt.Printf("[OK] Job %d cancelled\n", job.ID)
+	if job.Type != JobCreate {
+		fmt.Printf("[TIP] Run 'agbcloud image status %s' to follow the deactivation\n", job.ImageID)
+	}
+	return nil
+}
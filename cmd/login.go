@@ -5,7 +5,12 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/pkg/browser"
@@ -14,23 +19,112 @@ import (
 	"github.com/agbcloud/agbcloud-cli/internal/auth"
 	"github.com/agbcloud/agbcloud-cli/internal/client"
 	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/verbosity"
 )
 
-var LoginCmd = &cobra.Command{
-	Use:   "login",
-	Short: "Log in to AgbCloud",
-	Long:  "Authenticate with AgbCloud using OAuth in your browser",
-	Args:  cobra.NoArgs,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		return runLogin(cmd)
-	},
+// LoginCmd is the top-level 'login', kept as a shortcut for 'auth login'
+var LoginCmd = newLoginCmd()
+
+// maxAccountTokenSize bounds how much of stdin is read for --with-token
+const maxAccountTokenSize = 4096
+
+// newLoginCmd builds the login command. It is registered both at the top level and
+// under 'auth', and cobra needs a separate instance for each parent.
+func newLoginCmd() *cobra.Command {
+	loginCmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in to AgbCloud",
+		Long: `Authenticate with AgbCloud using OAuth in your browser.
+
+The browser redirects to a local callback port. Ports that worked for earlier
+logins are tried first, then the default port and the alternatives offered by
+the server. If all of them are in use, the ports of --port-range (or the
+loginPortRange setting, or AGB_CLI_LOGIN_PORT_RANGE) are scanned.
+
+Pipelines log in as a service account instead: --with-token reads a token issued
+by 'agbcloud service-account token' from stdin, without a browser.`,
+		Example: `  agbcloud login
+  agbcloud auth login --port-range 40000-40100
+  agbcloud login --copy
+  echo "$AGB_TOKEN" | agbcloud login --with-token`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if withToken, _ := cmd.Flags().GetBool("with-token"); withToken {
+				return LoginWithServiceAccountToken(cmd, cmd.InOrStdin())
+			}
+			return runLogin(cmd)
+		},
+	}
+	loginCmd.Flags().String("port-range", "", "Local callback port ranges to scan when the default ports are busy, e.g. 40000-40100")
+	loginCmd.Flags().Bool("with-token", false, "Log in as a service account with a token read from stdin")
+	addCopyFlag(loginCmd, "OAuth URL")
+	return loginCmd
 }
 
-func init() {
-	// No flags needed for login command
+// LoginWithServiceAccountToken logs in as a service account with the token read from
+// in and saves the session like 'agbcloud login'
+func LoginWithServiceAccountToken(cmd *cobra.Command, in io.Reader) error {
+	content, err := io.ReadAll(io.LimitReader(in, maxAccountTokenSize))
+	if err != nil {
+		return fmt.Errorf("failed to read the token from stdin: %w", err)
+	}
+	accountToken := strings.TrimSpace(string(content))
+	if accountToken == "" {
+		return printErrorMessage(
+			"[ERROR] No token on stdin",
+			"",
+			"[TIP] Usage: echo \"$AGB_TOKEN\" | agbcloud login --with-token",
+			"[NOTE] Issue a token with 'agbcloud service-account token <name>'",
+		)
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	apiClient := client.NewFromConfig(cfg)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
+	defer cancel()
+
+	fmt.Println("[SEC] Logging in with a service account token...")
+	loginResp, httpResp, err := apiClient.ServiceAccountAPI.LoginServiceAccount(ctx, accountToken)
+	if err != nil {
+		return requestError(os.Stdout, "service account login failed", httpResp, err)
+	}
+
+	// Service account sessions end with their token and are not kept alive
+	if err := cfg.SaveTokens(loginResp.Data.LoginToken, loginResp.Data.SessionId, "", loginResp.Data.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to save tokens: %w", err)
+	}
+	fmt.Printf("[OK] Logged in as service account '%s' (Account ID: %s)\n", loginResp.Data.Name, loginResp.Data.AccountID)
+	if loginResp.Data.ExpiresAt != "" {
+		fmt.Printf("[NOTE] The session ends when the token expires, %s\n", formatTimestamp(loginResp.Data.ExpiresAt))
+	}
+	if cfg.CredentialProvider != nil {
+		fmt.Printf("[NOTE] Commands use the tokens of the credential provider '%s' while it is configured\n", cfg.CredentialProvider)
+	}
+	return nil
 }
 
 func runLogin(cmd *cobra.Command) error {
+	// Resolve local port preferences before talking to the server
+	portRange, _ := cmd.Flags().GetString("port-range")
+	if portRange == "" {
+		portRange = config.GetLoginPortRange()
+	}
+	ranges, err := auth.ParsePortRanges(portRange)
+	if err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] %v", err),
+			"",
+			"[TIP] Use a range such as 40000-40100, or several separated by commas",
+		)
+	}
+	prefs := auth.PortPreferences{Ranges: ranges}
+	if savedCfg, err := config.GetConfig(); err == nil {
+		prefs.Recent = savedCfg.LoginPorts
+	}
+
 	fmt.Println("[SEC] Starting AgbCloud authentication...")
 
 	// Create client configuration for OAuth
@@ -40,10 +134,10 @@ func runLogin(cmd *cobra.Command) error {
 
 	// Get default callback port (port selection is handled automatically by server)
 	defaultPort := auth.GetCallbackPort()
-	fmt.Printf("[SIGNAL] Default callback port: %s\n", defaultPort)
+	printDetail(verbosity.Requests, "[SIGNAL] Default callback port: %s\n", defaultPort)
 
 	// Create context with timeout for OAuth request
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
 	defer cancel()
 
 	fmt.Println("[WEB] Requesting OAuth login URL...")
@@ -52,79 +146,80 @@ func runLogin(cmd *cobra.Command) error {
 	// The retry mechanism is already built into the API client
 	response, httpResp, err := apiClient.OAuthAPI.GetLoginProviderURL(ctx, fmt.Sprintf("http://localhost:%s", defaultPort), "CLI", "GOOGLE_LOCALHOST")
 	if err != nil {
+		var codeErr *client.APIError
+		if errors.As(err, &codeErr) {
+			return apiCodeError("OAuth request failed", codeErr.Code)
+		}
 		if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
 			fmt.Printf("[ERROR] API Error: %s\n", apiErr.Error())
 			if httpResp != nil {
 				fmt.Printf("[DATA] Status Code: %d\n", httpResp.StatusCode)
 			}
 			if len(apiErr.Body()) > 0 {
-				fmt.Printf("[PAGE] Response Body: %s\n", string(apiErr.Body()))
+				printDetail(verbosity.Bodies, "[PAGE] Response Body: %s\n", client.RedactText(string(apiErr.Body())))
 			}
 			return fmt.Errorf("failed to get OAuth URL after retries: %s", apiErr.Error())
 		}
 		return fmt.Errorf("network error after retries: %v", err)
 	}
 
-	// Verify we got a successful response
-	if !response.Success {
-		return fmt.Errorf("OAuth request failed: %s", response.Code)
+	// Pick the callback port: recent ports, the default, the server's alternatives, then local ranges
+	selectedPort, err := auth.SelectAvailablePort(defaultPort, response.Data.AlternativePorts, prefs)
+	if err != nil {
+		fmt.Printf("[ERROR] Port selection failed:\n")
+		fmt.Printf("   Default port %s is occupied\n", defaultPort)
+		if response.Data.AlternativePorts != "" {
+			fmt.Printf("   Alternative ports provided: %s\n", response.Data.AlternativePorts)
+			fmt.Printf("   All alternative ports are also occupied\n")
+		} else {
+			fmt.Printf("   No alternative ports provided by server\n")
+		}
+		if len(ranges) > 0 {
+			fmt.Printf("   All ports in the configured range %s are also occupied\n", portRange)
+			fmt.Printf("[TIP] Please free up one of these ports or configure another range with --port-range\n")
+		} else {
+			fmt.Printf("[TIP] Please free up one of these ports, or scan a local range with --port-range 40000-40100\n")
+		}
+		return fmt.Errorf("failed to find available port: %v", err)
 	}
 
-	// Check if default port is available
 	var finalPort string
 	var finalResponse client.OAuthLoginProviderResponse
 
-	if !auth.IsPortOccupied(defaultPort) {
-		// Default port is available, use it
+	if selectedPort == defaultPort {
+		// Default port is available, the first response already uses it
 		finalPort = defaultPort
 		finalResponse = response
 		fmt.Printf("[OK] Default port %s is available\n", defaultPort)
 	} else {
-		// Default port is occupied, try alternative ports
-		fmt.Printf("[WARN]  Default port %s is occupied, trying alternative ports...\n", defaultPort)
-
-		if response.Data.AlternativePorts == "" {
-			return fmt.Errorf("default port %s is occupied and no alternative ports provided", defaultPort)
-		}
-
-		// Select an available port from alternatives
-		selectedPort, err := auth.SelectAvailablePort(defaultPort, response.Data.AlternativePorts)
-		if err != nil {
-			fmt.Printf("[ERROR] Port selection failed:\n")
-			fmt.Printf("   Default port %s is occupied\n", defaultPort)
-			if response.Data.AlternativePorts != "" {
-				fmt.Printf("   Alternative ports provided: %s\n", response.Data.AlternativePorts)
-				fmt.Printf("   All alternative ports are also occupied\n")
-				fmt.Printf("[TIP] Please free up one of these ports and try again\n")
-			} else {
-				fmt.Printf("   No alternative ports provided by server\n")
-			}
-			return fmt.Errorf("failed to find available port: %v", err)
+		if slices.Contains(prefs.Recent, selectedPort) {
+			fmt.Printf("[REFRESH] Using port %s from a previous login\n", selectedPort)
+		} else {
+			fmt.Printf("[WARN]  Default port %s is occupied, trying alternative ports...\n", defaultPort)
+			fmt.Printf("[REFRESH] Using alternative port: %s\n", selectedPort)
 		}
 
-		fmt.Printf("[REFRESH] Using alternative port: %s\n", selectedPort)
-
 		// Make second API call with the selected port
 		// The retry mechanism is already built into the API client
 		secondResponse, secondHttpResp, err := apiClient.OAuthAPI.GetLoginProviderURLWithPort(ctx, fmt.Sprintf("http://localhost:%s", selectedPort), "CLI", "GOOGLE_LOCALHOST", selectedPort)
 		if err != nil {
+			var codeErr *client.APIError
+			if errors.As(err, &codeErr) {
+				return apiCodeError("OAuth request with alternative port failed", codeErr.Code)
+			}
 			if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
 				fmt.Printf("[ERROR] API Error on second call: %s\n", apiErr.Error())
 				if secondHttpResp != nil {
 					fmt.Printf("[DATA] Status Code: %d\n", secondHttpResp.StatusCode)
 				}
 				if len(apiErr.Body()) > 0 {
-					fmt.Printf("[PAGE] Response Body: %s\n", string(apiErr.Body()))
+					printDetail(verbosity.Bodies, "[PAGE] Response Body: %s\n", client.RedactText(string(apiErr.Body())))
 				}
 				return fmt.Errorf("failed to get OAuth URL with alternative port after retries: %s", apiErr.Error())
 			}
 			return fmt.Errorf("network error on second call after retries: %v", err)
 		}
 
-		if !secondResponse.Success {
-			return fmt.Errorf("OAuth request with alternative port failed: %s", secondResponse.Code)
-		}
-
 		finalPort = selectedPort
 		finalResponse = secondResponse
 	}
@@ -134,16 +229,16 @@ func runLogin(cmd *cobra.Command) error {
 	}
 
 	fmt.Println("[OK] Successfully retrieved OAuth URL!")
-	fmt.Printf("[DOC] Request ID: %s\n", finalResponse.RequestID)
-	fmt.Printf("[SEARCH] Trace ID: %s\n", finalResponse.TraceID)
-	fmt.Printf("[SIGNAL] Final callback port: %s\n", finalPort)
+	printDetail(verbosity.Requests, "[DOC] Request ID: %s\n", finalResponse.RequestID)
+	printDetail(verbosity.Requests, "[SEARCH] Trace ID: %s\n", finalResponse.TraceID)
+	printDetail(verbosity.Requests, "[SIGNAL] Final callback port: %s\n", finalPort)
 	fmt.Println()
 
 	// Start local callback server
 	fmt.Printf("[>>] Starting local callback server on port %s...\n", finalPort)
 
 	// Create context for callback server with longer timeout
-	callbackCtx, callbackCancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	callbackCtx, callbackCancel := context.WithTimeout(commandContext(cmd), 5*time.Minute)
 	defer callbackCancel()
 
 	// Start callback server in background
@@ -165,6 +260,7 @@ func runLogin(cmd *cobra.Command) error {
 	// Display the URL and open browser
 	fmt.Println("[LINK] OAuth URL:")
 	fmt.Printf("  %s\n\n", finalResponse.Data.InvokeURL)
+	copyToClipboard(cmd, os.Stdout, "OAuth URL", finalResponse.Data.InvokeURL)
 
 	fmt.Println("[WEB] Opening the browser for authentication...")
 	fmt.Println()
@@ -185,54 +281,56 @@ func runLogin(cmd *cobra.Command) error {
 	select {
 	case code := <-codeChan:
 		fmt.Println("[OK] Authentication successful!")
-		fmt.Printf("[KEY] Received authorization code: %s...\n", code[:min(len(code), 20)])
+		printDetail(verbosity.Bodies, "[KEY] Received authorization code: %s\n", MaskSecret(code))
 
 		// Now call LoginTranslate to exchange code for access token
 		fmt.Println("[REFRESH] Exchanging authorization code for access token...")
 
 		// Create context for LoginTranslate request
-		translateCtx, translateCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		translateCtx, translateCancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
 		defer translateCancel()
 
 		// The retry mechanism is already built into the API client
-		translateResponse, translateHttpResp, err := apiClient.OAuthAPI.LoginTranslateWithPort(translateCtx, "CLI", "GOOGLE_LOCALHOST", code, finalPort)
-		if err != nil {
+		translateResponse, translateHttpResp, err := apiClient.OAuthAPI.ExchangeAuthCode(translateCtx, "CLI", "GOOGLE_LOCALHOST", code, finalPort)
+		// A rejected exchange still has a decoded response, whose details are shown below
+		var rejectedErr *client.APIError
+		if err != nil && !errors.As(err, &rejectedErr) {
 			if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
 				fmt.Printf("[ERROR] LoginTranslate API Error: %s\n", apiErr.Error())
 				if translateHttpResp != nil {
 					fmt.Printf("[DATA] Status Code: %d\n", translateHttpResp.StatusCode)
 				}
 				if len(apiErr.Body()) > 0 {
-					fmt.Printf("[PAGE] Response Body: %s\n", string(apiErr.Body()))
+					printDetail(verbosity.Bodies, "[PAGE] Response Body: %s\n", client.RedactText(string(apiErr.Body())))
 				}
 				return fmt.Errorf("failed to exchange code for token after retries: %s", apiErr.Error())
 			}
 			return fmt.Errorf("network error during token exchange after retries: %v", err)
 		}
 
-		// Display detailed response information
-		fmt.Println("\n[TARGET] LoginTranslate Response Details:")
-		fmt.Printf("[DATA] HTTP Status Code: %d\n", translateHttpResp.StatusCode)
-		fmt.Printf("[OK] Success: %v\n", translateResponse.Success)
-		fmt.Printf("[NOTE] Code: %s\n", translateResponse.Code)
-		fmt.Printf("[DOC] Request ID: %s\n", translateResponse.RequestID)
-		fmt.Printf("[SEARCH] Trace ID: %s\n", translateResponse.TraceID)
-		fmt.Printf("[WEB] HTTP Status Code (from response): %d\n", translateResponse.HTTPStatusCode)
+		// Display detailed response information with -v, and the masked tokens with -vvv
+		printDetail(verbosity.Requests, "\n[TARGET] LoginTranslate Response Details:\n")
+		printDetail(verbosity.Requests, "[DATA] HTTP Status Code: %d\n", translateHttpResp.StatusCode)
+		printDetail(verbosity.Requests, "[OK] Success: %v\n", translateResponse.Success)
+		printDetail(verbosity.Requests, "[NOTE] Code: %s\n", translateResponse.Code)
+		printDetail(verbosity.Requests, "[DOC] Request ID: %s\n", translateResponse.RequestID)
+		printDetail(verbosity.Requests, "[SEARCH] Trace ID: %s\n", translateResponse.TraceID)
+		printDetail(verbosity.Requests, "[WEB] HTTP Status Code (from response): %d\n", translateResponse.HTTPStatusCode)
 
 		if translateResponse.Success {
-			fmt.Println("\n[KEY] Authentication Token Information:")
+			printDetail(verbosity.Bodies, "\n[KEY] Authentication Token Information:\n")
 			if translateResponse.Data.LoginToken != "" {
-				fmt.Printf("[TICKET] Login Token: %s\n", translateResponse.Data.LoginToken)
+				printDetail(verbosity.Bodies, "[TICKET] Login Token: %s\n", MaskSecret(translateResponse.Data.LoginToken))
 			} else {
 				fmt.Println("[WARN]  Login Token: (empty)")
 			}
 			if translateResponse.Data.SessionId != "" {
-				fmt.Printf("[ID] Session ID: %s\n", translateResponse.Data.SessionId)
+				printDetail(verbosity.Bodies, "[ID] Session ID: %s\n", MaskSecret(translateResponse.Data.SessionId))
 			} else {
 				fmt.Println("[WARN]  Session ID: (empty)")
 			}
 			if translateResponse.Data.KeepAliveToken != "" {
-				fmt.Printf("[REFRESH] Keep Alive Token: %s", translateResponse.Data.KeepAliveToken)
+				printDetail(verbosity.Bodies, "[REFRESH] Keep Alive Token: %s\n", MaskSecret(translateResponse.Data.KeepAliveToken))
 			} else {
 				fmt.Println("[WARN]  Keep Alive Token: (empty)")
 			}
@@ -247,6 +345,9 @@ func runLogin(cmd *cobra.Command) error {
 				return nil
 			}
 
+			// Remember the callback port so the next login tries it first
+			config.RememberLoginPort(finalPort)
+
 			err = config.SaveTokens(
 				translateResponse.Data.LoginToken,
 				translateResponse.Data.SessionId,
@@ -261,6 +362,9 @@ func runLogin(cmd *cobra.Command) error {
 
 			fmt.Println("[OK] Authentication tokens saved successfully!")
 			fmt.Println("\n[SUCCESS] You are now logged in to AgbCloud!")
+			if config.CredentialProvider != nil {
+				fmt.Printf("[NOTE] Commands use the tokens of the credential provider '%s' while it is configured\n", config.CredentialProvider)
+			}
 		} else {
 			fmt.Printf("\n[ERROR] Token exchange failed: %s\n", translateResponse.Code)
 			return fmt.Errorf("token exchange was not successful")
@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -14,19 +15,24 @@ import (
 	"github.com/agbcloud/agbcloud-cli/internal/config"
 )
 
-var LogoutCmd = &cobra.Command{
-	Use:     "logout",
-	Short:   "Log out from AgbCloud",
-	Long:    "Log out from AgbCloud by invalidating server session and clearing local authentication data",
-	Args:    cobra.NoArgs,
-	GroupID: "core",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		return runLogout(cmd)
-	},
-}
+// LogoutCmd is the top-level 'logout', kept as a shortcut for 'auth logout'
+var LogoutCmd = newLogoutCmd()
 
 func init() {
-	// No flags needed for logout command
+	LogoutCmd.GroupID = "core"
+}
+
+// newLogoutCmd builds the logout command, registered at the top level and under 'auth'
+func newLogoutCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Log out from AgbCloud",
+		Long:  "Log out from AgbCloud by invalidating server session and clearing local authentication data",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLogout(cmd)
+		},
+	}
 }
 
 func runLogout(cmd *cobra.Command) error {
@@ -48,23 +54,24 @@ func runLogout(cmd *cobra.Command) error {
 		fmt.Println("[WEB] Invalidating server session...")
 
 		apiClient := client.NewFromConfig(cfg)
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := context.WithTimeout(commandContext(cmd), 30*time.Second)
 		defer cancel()
 
 		// Call logout API
-		response, httpResp, err := apiClient.OAuthAPI.Logout(ctx,
+		_, httpResp, err := apiClient.OAuthAPI.Logout(ctx,
 			cfg.Token.LoginToken,
 			cfg.Token.SessionId)
 
-		if err != nil {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) {
+			// API call succeeded but logout failed
+			fmt.Printf("[WARN]  Warning: Server session invalidation failed (Code: %s)\n", apiErr.Code)
+		} else if err != nil {
 			// Log warning but continue with local cleanup
 			fmt.Printf("[WARN]  Warning: Could not invalidate server session: %v\n", err)
 			if httpResp != nil {
 				fmt.Printf("[DATA] HTTP Status: %d\n", httpResp.StatusCode)
 			}
-		} else if !response.Success {
-			// API call succeeded but logout failed
-			fmt.Printf("[WARN]  Warning: Server session invalidation failed (Code: %s)\n", response.Code)
 		} else {
 			// Success
 			fmt.Println("[OK] Server session invalidated successfully")
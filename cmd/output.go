@@ -0,0 +1,136 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+)
+
+// getOutputFormat returns the format selected with the global --output flag.
+// When the flag is not given, the output format from the configuration is used.
+func getOutputFormat(cmd *cobra.Command) (output.Format, error) {
+	value, err := cmd.Flags().GetString("output")
+	if err != nil {
+		// Flag not registered (e.g. command used outside the root command)
+		return output.FormatTable, nil
+	}
+	if !cmd.Flags().Changed("output") {
+		if cfg, err := config.GetConfig(); err == nil && cfg.EffectiveOutput() != "" {
+			value = cfg.EffectiveOutput()
+		}
+	}
+
+	format, err := output.ParseFormat(value)
+	if err != nil {
+		return "", printErrorMessage(
+			fmt.Sprintf("[ERROR] %v", err),
+			"",
+			"[TIP] Usage: --output <table|wide|json|csv|pson>",
+			"[NOTE] Example: agbcloud image list --output csv",
+		)
+	}
+	if format == output.FormatJSON {
+		enterStrictJSON()
+	}
+	return format, nil
+}
+
+// ApplyOutputFlag enters strict JSON mode when --output json is given on the command
+// line, also for commands without a JSON result, so that scripts never find progress
+// lines on stdout. Commands with structured output also honor the configured format.
+func ApplyOutputFlag(cmd *cobra.Command) {
+	if !cmd.Flags().Changed("output") {
+		return
+	}
+	value, _ := cmd.Flags().GetString("output")
+	if format, err := output.ParseFormat(value); err == nil && format == output.FormatJSON {
+		enterStrictJSON()
+	}
+}
+
+// strictJSON is the state of strict JSON mode: stdout is the standard output of the
+// process, which only the result document is written to, and redirected is the
+// stderr that os.Stdout points to meanwhile
+var strictJSON struct {
+	stdout     *os.File
+	redirected *os.File
+}
+
+// enterStrictJSON guarantees that stdout carries nothing but the JSON document of
+// the command: from now on everything else printed to stdout, such as progress,
+// warnings and tips, goes to stderr. Only writeResult and resultOutput reach stdout.
+func enterStrictJSON() {
+	if strictJSONActive() {
+		return
+	}
+	strictJSON.stdout, strictJSON.redirected = os.Stdout, os.Stderr
+	os.Stdout = os.Stderr
+}
+
+// strictJSONActive reports whether stdout is redirected by strict JSON mode
+func strictJSONActive() bool {
+	return strictJSON.stdout != nil && os.Stdout == strictJSON.redirected
+}
+
+// resultOutput returns where the result of a command is written: the standard
+// output of the process, also in strict JSON mode
+func resultOutput() io.Writer {
+	if strictJSONActive() {
+		return strictJSON.stdout
+	}
+	return os.Stdout
+}
+
+// progressWriter returns where progress lines should go for the given format.
+// Structured formats keep stdout clean for the result document.
+func progressWriter(format output.Format) io.Writer {
+	if format.IsStructured() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// writeResult writes a command result in a structured format to stdout. With --timing
+// and JSON output the API call timings are embedded next to the result instead of
+// being printed separately.
+func writeResult(format output.Format, v interface{}) error {
+	recorder := client.DefaultTimingRecorder()
+	if format != output.FormatJSON || recorder == nil {
+		return output.Write(resultOutput(), format, v)
+	}
+
+	timingEmbedded = true
+	return output.Write(resultOutput(), format, struct {
+		Result interface{}         `json:"result"`
+		Timing []client.CallTiming `json:"timing"`
+	}{v, recorder.Calls()})
+}
+
+// ApplyCSVFlags applies the global --csv-delimiter and --no-header flags to CSV output
+func ApplyCSVFlags(cmd *cobra.Command) error {
+	opts := output.CSVOptions{}
+	opts.NoHeader, _ = cmd.Flags().GetBool("no-header")
+	if value, err := cmd.Flags().GetString("csv-delimiter"); err == nil && value != "" {
+		delimiter, err := output.ParseCSVDelimiter(value)
+		if err != nil {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] %v", err),
+				"",
+				"[TIP] Usage: --csv-delimiter <char|comma|semicolon|tab|pipe>",
+				"[NOTE] Example: agbcloud image list -o csv --csv-delimiter semicolon",
+			)
+		}
+		opts.Delimiter = delimiter
+	}
+	output.SetCSVOptions(opts)
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"io"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/pager"
+	"github.com/agbcloud/agbcloud-cli/internal/progress"
+)
+
+// pagerDisabled is set by the global --no-pager flag
+var pagerDisabled bool
+
+// pagedTerminal is the terminal stdout is paged to while a pager runs
+var pagedTerminal *os.File
+
+// ApplyPagerFlag turns paging off for this invocation if --no-pager was given
+func ApplyPagerFlag(cmd *cobra.Command) {
+	pagerDisabled, _ = cmd.Flags().GetBool("no-pager")
+}
+
+// PagerCommand returns the pager program for long output: AGB_PAGER, the pager
+// setting of the configuration, PAGER, or less. An empty result means no paging.
+func PagerCommand() string {
+	command, ok := os.LookupEnv("AGB_PAGER")
+	if !ok {
+		if cfg, err := config.GetConfig(); err == nil && cfg.Pager != "" {
+			command, ok = cfg.Pager, true
+		}
+	}
+	if !ok {
+		command, ok = os.LookupEnv("PAGER")
+	}
+	if !ok {
+		command = pager.DefaultCommand
+	}
+	if pager.Disabled(command) || !pager.Available(command) {
+		return ""
+	}
+	return command
+}
+
+// startPager sends what the command prints to stdout through the pager when it is
+// longer than the terminal, like git. It does nothing unless stdout is a terminal.
+// The returned function must be called when the command has printed its output.
+func startPager() (stop func()) {
+	terminal := os.Stdout
+	// In strict JSON mode stdout is redirected to stderr, which is not paged
+	if pagerDisabled || strictJSONActive() || !progress.IsTerminal(terminal) {
+		return func() {}
+	}
+	command := PagerCommand()
+	if command == "" {
+		return func() {}
+	}
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		log.Debugf("Could not start the pager: %v", err)
+		return func() {}
+	}
+
+	p := pager.New(terminal, command, pager.Height(terminal))
+	copied := make(chan struct{})
+	go func() {
+		defer close(copied)
+		_, _ = io.Copy(p, reader)
+	}()
+	os.Stdout = writer
+	pagedTerminal = terminal
+
+	return func() {
+		os.Stdout, pagedTerminal = terminal, nil
+		writer.Close()
+		<-copied
+		reader.Close()
+		if err := p.Close(); err != nil {
+			log.Debugf("Could not write the paged output: %v", err)
+		}
+	}
+}
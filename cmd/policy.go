@@ -0,0 +1,115 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/policy"
+)
+
+// addPolicyFileFlag adds --policy-file to a command whose actions the policy restricts
+func addPolicyFileFlag(cmd *cobra.Command) {
+	cmd.Flags().String("policy-file", "", "Check this policy file instead of the configured one, e.g. to test a new policy")
+}
+
+// loadPolicy loads the policy given with --policy-file, or else the configured one.
+// It returns nil when no policy applies. A policy that cannot be read refuses the
+// action rather than letting it through unchecked.
+func loadPolicy(cmd *cobra.Command, cfg *config.Config) (*policy.Policy, string, error) {
+	file, _ := cmd.Flags().GetString("policy-file")
+	if file == "" && cfg != nil {
+		file = cfg.PolicyFile
+	}
+	if file == "" {
+		return nil, "", nil
+	}
+	p, err := policy.Load(file)
+	if err != nil {
+		return nil, file, printErrorMessage(
+			fmt.Sprintf("[ERROR] Failed to load the organization policy: %v", err),
+			"",
+			"[TIP] Fix the policy file, or set 'policyFile' in the configuration to a valid one",
+		)
+	}
+	return p, file, nil
+}
+
+// policyViolationError refuses an action that breaks the policy, listing every rule it breaks
+func policyViolationError(action, file string, p *policy.Policy, violations []policy.Violation) error {
+	lines := []string{fmt.Sprintf("[ERROR] %s is not allowed by the organization policy:", action)}
+	for _, violation := range violations {
+		lines = append(lines, "• "+violation.String())
+	}
+	lines = append(lines, "", fmt.Sprintf("[NOTE] Policy file: %s", file))
+	if p.Contact != "" {
+		lines = append(lines, fmt.Sprintf("[TIP] Ask %s for an exception", p.Contact))
+	}
+	return printErrorMessage(lines...)
+}
+
+// checkCreatePolicy checks an image about to be built against the policy
+func checkCreatePolicy(cmd *cobra.Command, cfg *config.Config, imageName, sourceImageId, dockerfilePath string) error {
+	p, file, err := loadPolicy(cmd, cfg)
+	if err != nil || p == nil {
+		return err
+	}
+	content, err := os.ReadFile(dockerfilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read dockerfile: %w", err)
+	}
+	violations := p.CheckCreate(policy.CreateRequest{
+		ImageName:     imageName,
+		SourceImageID: sourceImageId,
+		Labels:        policy.DockerfileLabels(string(content)),
+	})
+	if len(violations) > 0 {
+		return policyViolationError(fmt.Sprintf("Creating image '%s'", imageName), file, p, violations)
+	}
+	return nil
+}
+
+// checkActivatePolicy checks the resources of an activation against the policy
+func checkActivatePolicy(cmd *cobra.Command, cfg *config.Config, imageId string, cpu, memory int) error {
+	p, file, err := loadPolicy(cmd, cfg)
+	if err != nil || p == nil {
+		return err
+	}
+	if violations := p.CheckActivate(policy.ActivateRequest{CPU: cpu, Memory: memory}); len(violations) > 0 {
+		return policyViolationError(fmt.Sprintf("Activating image '%s'", imageId), file, p, violations)
+	}
+	return nil
+}
+
+// checkBatchPolicy checks every image of a batch, so that no build starts while
+// any of them breaks the policy
+func checkBatchPolicy(cmd *cobra.Command, cfg *config.Config, entries []ImageBatchEntry) error {
+	p, file, err := loadPolicy(cmd, cfg)
+	if err != nil || p == nil {
+		return err
+	}
+	var violations []policy.Violation
+	for _, entry := range entries {
+		content, err := os.ReadFile(entry.Dockerfile)
+		if err != nil {
+			return fmt.Errorf("failed to read dockerfile: %w", err)
+		}
+		for _, violation := range p.CheckCreate(policy.CreateRequest{
+			ImageName:     entry.Name,
+			SourceImageID: entry.Source,
+			Labels:        policy.DockerfileLabels(string(content)),
+		}) {
+			violation.Rule = entry.Name + ": " + violation.Rule
+			violations = append(violations, violation)
+		}
+	}
+	if len(violations) > 0 {
+		return policyViolationError("The batch", file, p, violations)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// promptInput is where answers to interactive questions are read from
+var promptInput io.Reader = os.Stdin
+
+// isInteractiveInput reports whether stdin is a terminal a user can answer questions on
+func isInteractiveInput() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Confirm asks a yes/no question on w and reads the answer from r. An empty or
+// unreadable answer selects defaultYes.
+func Confirm(r io.Reader, w io.Writer, question string, defaultYes bool) bool {
+	choices := "[y/N]"
+	if defaultYes {
+		choices = "[Y/n]"
+	}
+	fmt.Fprintf(w, "[?] %s %s ", question, choices)
+
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(w)
+		return defaultYes
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	default:
+		return defaultYes
+	}
+}
+
+// Ask asks for a line of text on w and reads the answer from r. An empty or
+// unreadable answer selects defaultValue. Pass the same *bufio.Reader to
+// consecutive questions so that buffered answers are not lost.
+func Ask(r io.Reader, w io.Writer, question, defaultValue string) string {
+	if defaultValue != "" {
+		fmt.Fprintf(w, "[?] %s [%s] ", question, defaultValue)
+	} else {
+		fmt.Fprintf(w, "[?] %s ", question)
+	}
+
+	answer, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(w)
+		return defaultValue
+	}
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer
+	}
+	return defaultValue
+}
+
+// Choose lists options on w as a numbered menu and reads the number of the chosen
+// option from r, asking again after an invalid answer. An empty or unreadable
+// answer selects defaultIndex.
+func Choose(r io.Reader, w io.Writer, question string, options []string, defaultIndex int) int {
+	reader := bufio.NewReader(r)
+	for i, option := range options {
+		fmt.Fprintf(w, "  %d) %s\n", i+1, option)
+	}
+	for {
+		fmt.Fprintf(w, "[?] %s [%d] ", question, defaultIndex+1)
+		answer, err := reader.ReadString('\n')
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			if err != nil {
+				fmt.Fprintln(w)
+			}
+			return defaultIndex
+		}
+		if n, convErr := strconv.Atoi(answer); convErr == nil && n >= 1 && n <= len(options) {
+			return n - 1
+		}
+		fmt.Fprintf(w, "[WARN]  Enter a number between 1 and %d\n", len(options))
+		if err != nil {
+			return defaultIndex
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/pkg/version"
+)
+
+var ReleaseCmd = &cobra.Command{
+	Use:    "release",
+	Short:  "Release helpers for maintainers",
+	Long:   "Prepare the distribution of a release, e.g. the manifests of package managers",
+	Hidden: true,
+}
+
+var releaseManifestCmd = &cobra.Command{
+	Use:   "manifest --brew|--scoop",
+	Short: "Generate a Homebrew formula or Scoop manifest for a release",
+	Long: `Generate the manifest of a package manager from the release artifacts and their
+checksums, and print it to stdout.
+
+--brew generates a Homebrew formula that installs the prebuilt macOS and Linux
+binaries; --scoop generates a Scoop manifest for the Windows binaries. The
+artifacts of the version are looked up in --dir by their release names, e.g.
+agb-1.2.0-darwin-arm64. Checksums are taken from the .sha256 files published with
+the artifacts, and computed for artifacts without one; a checksum that does not
+match its artifact is an error. Platforms without an artifact are left out.
+
+The version defaults to the version of this binary.`,
+	Example: `  agbcloud release manifest --brew --version 1.2.0 --dir bin > agb.rb
+  agbcloud release manifest --scoop --version 1.2.0 --dir bin > agb.json`,
+	Args: cobra.NoArgs,
+	RunE: runReleaseManifest,
+}
+
+func init() {
+	releaseManifestCmd.Flags().Bool("brew", false, "Generate a Homebrew formula")
+	releaseManifestCmd.Flags().Bool("scoop", false, "Generate a Scoop manifest")
+	releaseManifestCmd.Flags().String("version", "", "Release version, e.g. 1.2.0 (default: the version of this binary)")
+	releaseManifestCmd.Flags().String("dir", "bin", "Directory with the release artifacts and their .sha256 files")
+
+	ReleaseCmd.AddCommand(releaseManifestCmd)
+}
+
+func runReleaseManifest(cmd *cobra.Command, args []string) error {
+	brew, _ := cmd.Flags().GetBool("brew")
+	scoop, _ := cmd.Flags().GetBool("scoop")
+	releaseVersion, _ := cmd.Flags().GetString("version")
+	dir, _ := cmd.Flags().GetString("dir")
+
+	if brew == scoop {
+		return printErrorMessage(
+			"[ERROR] Give exactly one of --brew and --scoop",
+			"",
+			"[NOTE] Example: agbcloud release manifest --brew --version 1.2.0 --dir bin",
+		)
+	}
+	if releaseVersion == "" {
+		releaseVersion = Version
+	}
+	if releaseVersion == "" || releaseVersion == "dev" {
+		return printErrorMessage(
+			"[ERROR] This is a development build; give the release version with --version",
+			"",
+			"[NOTE] Example: agbcloud release manifest --brew --version 1.2.0 --dir bin",
+		)
+	}
+	releaseVersion = strings.TrimPrefix(releaseVersion, "v")
+
+	artifacts, err := version.ReadArtifacts(dir, releaseVersion)
+	if err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Failed to read the release artifacts: %v", err),
+			"",
+			fmt.Sprintf("[TIP] Build them with 'make build-all hash', named e.g. %s", version.Target{OS: "darwin", Arch: "arm64"}.ArtifactName(releaseVersion)),
+		)
+	}
+
+	if brew {
+		formula, err := version.BrewFormula(releaseVersion, artifacts)
+		if err != nil {
+			return printErrorMessage(fmt.Sprintf("[ERROR] %v in %s", err, dir))
+		}
+		fmt.Print(formula)
+	} else {
+		manifest, err := version.ScoopManifest(releaseVersion, artifacts)
+		if err != nil {
+			return printErrorMessage(fmt.Sprintf("[ERROR] %v in %s", err, dir))
+		}
+		os.Stdout.Write(manifest)
+	}
+	return nil
+}
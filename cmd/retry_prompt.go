@@ -0,0 +1,153 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+const (
+	// retryPromptAttempts is how often a command is offered to run again
+	retryPromptAttempts = 3
+	// retryPromptDelay is the wait before the first rerun; it doubles for each rerun
+	retryPromptDelay = 2 * time.Second
+	// retryPromptMaxDelay caps the wait before a rerun
+	retryPromptMaxDelay = 15 * time.Second
+	// rerunnableAnnotation marks the commands that are offered to run again
+	rerunnableAnnotation = "agbcloud.rerunnable"
+)
+
+func init() {
+	// Only commands that read, or that check the current state before they change
+	// anything, are run again. A rerun sends new Idempotency-Keys, so commands that
+	// create something, such as 'image create', could otherwise do it twice.
+	markRerunnable(
+		imageListCmd, imageStatusCmd, imageTaskCmd, imageLogsCmd, imageDiffCmd, imageOutdatedCmd,
+		imageDiffMetadataCmd, imageExportMetadataCmd, imageValidateRemoteCmd,
+		imageActivateCmd, imageDeactivateCmd,
+		authStatusCmd, authSessionsListCmd, sshKeyListCmd, serviceAccountListCmd,
+		jobsListCmd, jobsAttachCmd, DoctorCmd,
+	)
+}
+
+// markRerunnable lets commands offer to run again after a transient failure
+func markRerunnable(commands ...*cobra.Command) {
+	for _, command := range commands {
+		if command.Annotations == nil {
+			command.Annotations = map[string]string{}
+		}
+		command.Annotations[rerunnableAnnotation] = "true"
+	}
+}
+
+// IsRerunnable reports whether a command offers to run again after a transient failure
+func IsRerunnable(command *cobra.Command) bool {
+	return command.Annotations[rerunnableAnnotation] == "true"
+}
+
+// TransientError marks a command failure that is likely to go away when the command
+// is run again, such as a network error or a server that stayed unavailable through
+// the automatic retries
+type TransientError struct {
+	Err error
+}
+
+// Error implements the error interface
+func (e *TransientError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the failure
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+// transientError returns err marked as transient when the request failure cause is
+func transientError(cause, err error) error {
+	if client.IsTransientFailure(cause) {
+		return &TransientError{Err: err}
+	}
+	return err
+}
+
+// RetryPrompt offers to run a command again after a transient failure
+type RetryPrompt struct {
+	In  io.Reader
+	Out io.Writer
+	// Attempts is how often the command is offered to run again
+	Attempts int
+	// Delay is the wait before the first rerun; it doubles for each rerun up to MaxDelay
+	Delay    time.Duration
+	MaxDelay time.Duration
+}
+
+// Run calls run, and after a transient failure asks whether to call it again. The
+// last failure is returned when the user declines, the attempts are used up or ctx
+// is cancelled.
+func (p RetryPrompt) Run(ctx context.Context, run func() error) error {
+	reader := bufio.NewReader(p.In)
+	delay := p.Delay
+	for attempt := 1; ; attempt++ {
+		err := run()
+		var transient *TransientError
+		if err == nil || !errors.As(err, &transient) || ctx.Err() != nil {
+			return err
+		}
+		left := p.Attempts - attempt + 1
+		if left <= 0 {
+			fmt.Fprintf(p.Out, "[NOTE] Giving up after %d attempts\n", attempt)
+			return err
+		}
+
+		fmt.Fprintf(p.Out, "[RETRY] The failure looks transient; %d retry attempt(s) left\n", left)
+		if !Confirm(reader, p.Out, "Retry now?", true) {
+			return err
+		}
+		fmt.Fprintf(p.Out, "[WAIT] Retrying in %s (attempt %d of %d)...\n", formatRetryDelay(delay), attempt+1, p.Attempts+1)
+		if sleepErr := sleepUntil(ctx, time.Now().Add(delay)); sleepErr != nil {
+			return err
+		}
+		if delay *= 2; delay > p.MaxDelay {
+			delay = p.MaxDelay
+		}
+	}
+}
+
+// formatRetryDelay shows a backoff delay, e.g. 2s or 500ms
+func formatRetryDelay(d time.Duration) string {
+	if d >= time.Second {
+		return d.Round(time.Second).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// EnableRetryPrompt lets the rerunnable commands of root offer to run again after a
+// transient failure when the CLI runs in an interactive terminal. Scripts and
+// pipelines keep failing right away.
+func EnableRetryPrompt(root *cobra.Command) {
+	for _, command := range root.Commands() {
+		EnableRetryPrompt(command)
+	}
+	run := root.RunE
+	if run == nil || !IsRerunnable(root) {
+		return
+	}
+	root.RunE = func(command *cobra.Command, args []string) error {
+		if !isInteractiveInput() {
+			return run(command, args)
+		}
+		prompt := RetryPrompt{In: promptInput, Out: os.Stderr, Attempts: retryPromptAttempts, Delay: retryPromptDelay, MaxDelay: retryPromptMaxDelay}
+		return prompt.Run(commandContext(command), func() error { return run(command, args) })
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+)
+
+// outputSchema describes the JSON output contract of one command. Version is
+// increased whenever a field is removed, renamed or changes type.
+type outputSchema struct {
+	Command     string
+	Version     int
+	Description string
+	Result      interface{} // Zero value of the type written with -o json
+}
+
+// Schema returns the JSON Schema document of the command output
+func (s outputSchema) Schema() *output.JSONSchema {
+	schema := output.SchemaFor(s.Result)
+	schema.ID = fmt.Sprintf("agbcloud-cli/%s/v%d", strings.ReplaceAll(s.Command, " ", "-"), s.Version)
+	schema.Title = fmt.Sprintf("agbcloud %s output (v%d)", s.Command, s.Version)
+	schema.Description = s.Description
+	return schema
+}
+
+// outputSchemas holds the registered output contracts by command path (e.g. "image list")
+var outputSchemas = map[string]outputSchema{}
+
+var SchemaCmd = &cobra.Command{
+	Use:   "schema [command]",
+	Short: "Show the JSON schema of command output",
+	Long: `Print the versioned JSON Schema of the output a command writes with --output json,
+so automation can validate the documents it parses. Without arguments the commands that
+have an output schema are listed.`,
+	Example: `  agbcloud schema
+  agbcloud schema image list
+  agbcloud image list --schema`,
+	GroupID: "management",
+	RunE:    runSchema,
+}
+
+// registerOutputSchema records the output contract of cmd and adds a --schema flag
+// that prints it instead of running the command
+func registerOutputSchema(cmd *cobra.Command, schema outputSchema) {
+	outputSchemas[schema.Command] = schema
+	cmd.Flags().Bool("schema", false, "Print the JSON schema of the command output and exit")
+
+	args, run := cmd.Args, cmd.RunE
+	cmd.Args = func(cmd *cobra.Command, positional []string) error {
+		if printSchema, _ := cmd.Flags().GetBool("schema"); printSchema || args == nil {
+			return nil
+		}
+		return args(cmd, positional)
+	}
+	cmd.RunE = func(cmd *cobra.Command, positional []string) error {
+		if printSchema, _ := cmd.Flags().GetBool("schema"); printSchema {
+			return writeSchema(schema)
+		}
+		return run(cmd, positional)
+	}
+}
+
+// LookupOutputSchema returns the JSON Schema of a command's output, e.g. for "image list"
+func LookupOutputSchema(command string) (*output.JSONSchema, bool) {
+	schema, ok := outputSchemas[strings.Join(strings.Fields(command), " ")]
+	if !ok {
+		return nil, false
+	}
+	return schema.Schema(), true
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		commands := make([]string, 0, len(outputSchemas))
+		for command := range outputSchemas {
+			commands = append(commands, command)
+		}
+		sort.Strings(commands)
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "COMMAND\tVERSION")
+		for _, command := range commands {
+			fmt.Fprintf(tw, "%s\tv%d\n", command, outputSchemas[command].Version)
+		}
+		return tw.Flush()
+	}
+
+	command := strings.Join(args, " ")
+	schema, ok := outputSchemas[command]
+	if !ok {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] No output schema for command '%s'", command),
+			"",
+			"[TIP] Run 'agbcloud schema' to list the commands with an output schema",
+		)
+	}
+	return writeSchema(schema)
+}
+
+// writeSchema prints the schema document as indented JSON
+func writeSchema(schema outputSchema) error {
+	return output.Write(resultOutput(), output.FormatJSON, schema.Schema())
+}
@@ -0,0 +1,307 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var SelftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Run a smoke test against an API endpoint",
+	Long: `Run a fixed sequence of read-only checks against the API endpoint and report
+which of them pass:
+
+  1. Health           The endpoint answers HTTP requests
+  2. Authentication   The stored session is accepted
+  3. List images      System images can be listed
+  4. Create dry run   A Dockerfile and base image would be accepted by 'image create'
+
+Nothing is created, uploaded or changed. Steps that depend on a failed step are
+skipped. Use the global --endpoint flag to test a new region or proxy before
+switching to it.`,
+	Example: `  # Validate a new region before rollout
+  agbcloud selftest --endpoint https://agb.eu-central.example.com
+
+  # Check that your own Dockerfile would be accepted, and print a JSON report
+  agbcloud selftest --dockerfile ./Dockerfile --imageId agb-code-space-1 -o json`,
+	Args:    cobra.NoArgs,
+	GroupID: "management",
+	RunE:    runSelftest,
+}
+
+// Selftest step results
+const (
+	selftestPass = "pass"
+	selftestFail = "fail"
+	selftestSkip = "skip"
+)
+
+// selftestSampleDockerfile is checked by the create dry run when no --dockerfile is given
+const selftestSampleDockerfile = "FROM %s\nRUN echo selftest\n"
+
+func init() {
+	SelftestCmd.Flags().String("dockerfile", "", "Dockerfile to validate in the create dry run (default: a minimal sample)")
+	SelftestCmd.Flags().String("imageId", "", "Base image for the create dry run (default: the first System image)")
+
+	registerOutputSchema(SelftestCmd, outputSchema{
+		Command:     "selftest",
+		Version:     1,
+		Description: "The result of each smoke test step. result is pass, fail or skip; passed is true when no step failed.",
+		Result:      SelftestReport{},
+	})
+}
+
+// SelftestOptions configures the create dry run of a selftest
+type SelftestOptions struct {
+	Dockerfile  string // Content of the Dockerfile to validate; empty uses a sample
+	BaseImageID string // Empty uses the first System image
+}
+
+// SelftestStep is the result of one selftest step
+type SelftestStep struct {
+	Name       string `json:"name"`
+	Result     string `json:"result"`
+	DurationMs int64  `json:"durationMs"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+// SelftestReport is the result of a selftest run
+type SelftestReport struct {
+	Endpoint string         `json:"endpoint"`
+	Passed   bool           `json:"passed"`
+	Steps    []SelftestStep `json:"steps"`
+}
+
+// selftestState is shared by the steps of one run
+type selftestState struct {
+	apiClient    *client.APIClient
+	loginToken   string
+	sessionId    string
+	options      SelftestOptions
+	systemImages []client.ImageInfo
+}
+
+// selftestStep is a named step of the selftest. Run returns a short description of
+// what was verified, or an error.
+type selftestStep struct {
+	Name string
+	Run  func(ctx context.Context, state *selftestState) (string, error)
+	// Needs lists the steps that must pass before this one can run
+	Needs []string
+}
+
+// selftestSteps lists the steps in the order they are run
+var selftestSteps = []selftestStep{
+	{Name: "Health", Run: selftestHealth},
+	{Name: "Authentication", Run: selftestAuthentication, Needs: []string{"Health"}},
+	{Name: "List images", Run: selftestListImages, Needs: []string{"Health", "Authentication"}},
+	{Name: "Create dry run", Run: selftestCreateDryRun, Needs: []string{"Health", "Authentication", "List images"}},
+}
+
+// RunSelftest runs every selftest step against the primary endpoint of apiClient with
+// the credentials of cfg
+func RunSelftest(ctx context.Context, apiClient *client.APIClient, cfg *config.Config, options SelftestOptions) SelftestReport {
+	state := &selftestState{apiClient: apiClient, options: options}
+	if cfg.Token != nil {
+		state.loginToken, state.sessionId = cfg.Token.LoginToken, cfg.Token.SessionId
+	}
+
+	report := SelftestReport{Endpoint: config.GetEndpoints()[0], Passed: true}
+	results := make(map[string]string, len(selftestSteps))
+	for _, step := range selftestSteps {
+		if blocker := failedDependency(step, results); blocker != "" {
+			results[step.Name] = selftestSkip
+			report.Steps = append(report.Steps, SelftestStep{Name: step.Name, Result: selftestSkip, Detail: fmt.Sprintf("%s did not pass", blocker)})
+			continue
+		}
+
+		start := time.Now()
+		detail, err := step.Run(ctx, state)
+		result := SelftestStep{Name: step.Name, Result: selftestPass, DurationMs: time.Since(start).Milliseconds(), Detail: detail}
+		if err != nil {
+			result.Result, result.Detail = selftestFail, err.Error()
+			report.Passed = false
+		}
+		results[step.Name] = result.Result
+		report.Steps = append(report.Steps, result)
+	}
+	return report
+}
+
+// failedDependency returns the first step needed by step that did not pass
+func failedDependency(step selftestStep, results map[string]string) string {
+	for _, name := range step.Needs {
+		if results[name] != selftestPass {
+			return name
+		}
+	}
+	return ""
+}
+
+// selftestHealth checks that the primary endpoint answers HTTP requests
+func selftestHealth(ctx context.Context, state *selftestState) (string, error) {
+	statuses := state.apiClient.ProbeServers(ctx, 10*time.Second)
+	if len(statuses) == 0 {
+		return "", errors.New("no endpoint is configured")
+	}
+	status := statuses[0]
+	if !status.Reachable {
+		return "", fmt.Errorf("%s is unreachable: %v", status.URL, status.Err)
+	}
+	return fmt.Sprintf("%s answered in %v", status.URL, status.Latency.Round(time.Millisecond)), nil
+}
+
+// selftestAuthentication checks that the stored session is accepted by the endpoint
+func selftestAuthentication(ctx context.Context, state *selftestState) (string, error) {
+	if state.loginToken == "" || state.sessionId == "" {
+		return "", errors.New("not authenticated, run 'agbcloud login' first")
+	}
+	_, _, err := state.apiClient.ImageAPI.ListImages(ctx, state.loginToken, state.sessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1})
+	if err != nil {
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) {
+			return "", fmt.Errorf("the session was rejected (%s)", apiErr.Code)
+		}
+		return "", err
+	}
+	return "The stored session is valid", nil
+}
+
+// selftestListImages lists the System images, which every deployment provides
+func selftestListImages(ctx context.Context, state *selftestState) (string, error) {
+	listResp, _, err := state.apiClient.ImageAPI.ListImages(ctx, state.loginToken, state.sessionId, client.ImageListOptions{ImageType: "System", Page: 1, PageSize: 50})
+	if err != nil {
+		return "", err
+	}
+	if len(listResp.Data.Images) == 0 {
+		return "", errors.New("the endpoint returned no System images")
+	}
+	state.systemImages = listResp.Data.Images
+	return fmt.Sprintf("Listed %d System image(s)", len(listResp.Data.Images)), nil
+}
+
+// selftestCreateDryRun performs the checks 'image create' makes before uploading,
+// without requesting upload credentials or starting a build
+func selftestCreateDryRun(ctx context.Context, state *selftestState) (string, error) {
+	baseImageID := state.options.BaseImageID
+	if baseImageID == "" {
+		baseImageID = state.systemImages[0].ImageID
+	}
+	found := false
+	for _, image := range state.systemImages {
+		if image.ImageID == baseImageID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("base image '%s' is not a System image of this endpoint", baseImageID)
+	}
+
+	dockerfile := state.options.Dockerfile
+	if dockerfile == "" {
+		dockerfile = fmt.Sprintf(selftestSampleDockerfile, baseImageID)
+	}
+	// Lint findings are warnings in 'image create' as well, so they do not fail the step
+	summary := fmt.Sprintf("Dockerfile and base image %s would be accepted", baseImageID)
+	if warnings := LintDockerfile(dockerfile); len(warnings) > 0 {
+		summary += fmt.Sprintf(" with %d warning(s): %s", len(warnings), strings.Join(warnings, "; "))
+	}
+
+	capabilities, err := state.apiClient.Capabilities(ctx, state.loginToken, state.sessionId)
+	switch {
+	case errors.Is(err, client.ErrCapabilitiesUnsupported):
+		// Older deployments build only the default platform and have no capabilities endpoint
+		return summary, nil
+	case err != nil:
+		return "", fmt.Errorf("failed to get build capabilities: %w", err)
+	}
+	summary = fmt.Sprintf("%s; builds for %s", summary, strings.Join(capabilities.Platforms, ", "))
+	if len(capabilities.Features) > 0 {
+		summary += "; server features: " + strings.Join(capabilities.Features, ", ")
+	}
+	return summary, nil
+}
+
+func runSelftest(cmd *cobra.Command, args []string) error {
+	dockerfilePath, _ := cmd.Flags().GetString("dockerfile")
+	baseImageID, _ := cmd.Flags().GetString("imageId")
+
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	out := progressWriter(outputFormat)
+
+	options := SelftestOptions{BaseImageID: strings.TrimSpace(baseImageID)}
+	if dockerfilePath != "" {
+		content, err := os.ReadFile(dockerfilePath)
+		if err != nil {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] Failed to read Dockerfile: %v", err),
+				"",
+				"[TIP] Check that the path given with --dockerfile exists",
+			)
+		}
+		options.Dockerfile = string(content)
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(commandContext(cmd), 2*time.Minute)
+	defer cancel()
+
+	fmt.Fprintf(out, "[SEARCH] Running selftest against %s...\n", config.GetEndpoints()[0])
+	report := RunSelftest(ctx, client.NewFromConfig(cfg), cfg, options)
+
+	if outputFormat.IsStructured() {
+		if err := writeResult(outputFormat, report); err != nil {
+			return err
+		}
+	} else {
+		printSelftestReport(out, report)
+	}
+	if !report.Passed {
+		return fmt.Errorf("selftest failed")
+	}
+	return nil
+}
+
+// printSelftestReport prints one line per step and a summary
+func printSelftestReport(w io.Writer, report SelftestReport) {
+	failed := 0
+	for _, step := range report.Steps {
+		switch step.Result {
+		case selftestPass:
+			fmt.Fprintf(w, "  [OK] %s (%dms): %s\n", step.Name, step.DurationMs, step.Detail)
+		case selftestFail:
+			failed++
+			fmt.Fprintf(w, "  [ERROR] %s (%dms): %s\n", step.Name, step.DurationMs, step.Detail)
+		default:
+			fmt.Fprintf(w, "  [SKIP] %s: %s\n", step.Name, step.Detail)
+		}
+	}
+
+	fmt.Fprintln(w)
+	if failed > 0 {
+		fmt.Fprintf(w, "[ERROR] %d of %d step(s) failed\n", failed, len(report.Steps))
+		return
+	}
+	fmt.Fprintf(w, "[SUCCESS] All %d steps passed\n", len(report.Steps))
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// featureDescriptions names the optional server features in error messages
+var featureDescriptions = map[string]string{
+	client.FeatureMultiPlatform: "multi-platform builds",
+	client.FeatureBuildArgs:     "build arguments",
+	client.FeatureGPU:           "GPU resources",
+	client.FeatureLogStreaming:  "log streaming",
+	client.FeatureCursorPaging:  "cursor paging",
+}
+
+// requireServerFeature fails when the server reports that it lacks an optional
+// feature. Servers that do not report their features may support it, so their
+// answer to the request itself decides. tip tells how to do without the feature.
+func requireServerFeature(capabilities client.ImageCapabilitiesData, feature, tip string) error {
+	if supported, known := capabilities.SupportsFeature(feature); !known || supported {
+		return nil
+	}
+	description, ok := featureDescriptions[feature]
+	if !ok {
+		description = fmt.Sprintf("the '%s' feature", feature)
+	}
+	return printErrorMessage(
+		fmt.Sprintf("[ERROR] Not supported by server %s: %s", config.GetEndpoint(), description),
+		"",
+		"[TIP] "+tip,
+	)
+}
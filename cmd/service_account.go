@@ -0,0 +1,447 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var ServiceAccountCmd = &cobra.Command{
+	Use:     "service-account",
+	Aliases: []string{"sa"},
+	Short:   "Manage service accounts for CI pipelines",
+	Long: `Manage service accounts, the machine identities that pipelines authenticate as
+instead of a developer's personal login session.
+
+A service account is limited to the scopes it was created with. Pipelines log in
+with a token of the account ('agbcloud login --with-token'); tokens expire, and
+deleting the account revokes all of them.`,
+	Example: `  agbcloud service-account create ci-builds --scope image:read,image:build
+  agbcloud service-account token ci-builds --ttl 720h
+  echo "$AGB_TOKEN" | agbcloud login --with-token`,
+	GroupID: "management",
+}
+
+var serviceAccountCreateCmd = &cobra.Command{
+	Use:   "create <name> --scope <scope>[,<scope>...]",
+	Short: "Create a service account",
+	Long: `Create a service account with the given scopes. Scopes:
+
+  image:read      list and inspect images, tasks and logs
+  image:build     create images
+  image:activate  activate, deactivate and restart images
+  image:delete    delete images
+
+Issue a token for the account with 'agbcloud service-account token <name>'.`,
+	Example: `  agbcloud service-account create ci-builds --scope image:read,image:build`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return printErrorMessage(
+				"[ERROR] Expected exactly one service account name",
+				"",
+				"[TIP] Usage: agbcloud service-account create <name> --scope <scope>[,<scope>...]",
+			)
+		}
+		return nil
+	},
+	RunE: runServiceAccountCreate,
+}
+
+var serviceAccountListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List service accounts",
+	Args:    cobra.NoArgs,
+	RunE:    runServiceAccountList,
+}
+
+var serviceAccountDeleteCmd = &cobra.Command{
+	Use:     "delete <account-id|name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a service account and revoke its tokens",
+	Long: `Delete a service account, given by its account ID or name. All tokens of the
+account are revoked at once; pipelines using them can no longer log in.`,
+	Example: `  agbcloud service-account delete ci-builds`,
+	Args:    serviceAccountRefArgs("delete"),
+	RunE:    runServiceAccountDelete,
+}
+
+var serviceAccountTokenCmd = &cobra.Command{
+	Use:   "token <account-id|name>",
+	Short: "Issue a token for a service account",
+	Long: `Issue a token for a service account, given by its account ID or name. The token
+is shown only once; store it in the secret store of your CI system and log the
+pipeline in with:
+
+  echo "$AGB_TOKEN" | agbcloud login --with-token
+
+Tokens expire after --ttl. Issue a new token before then, and delete the account
+to revoke all of its tokens.`,
+	Example: `  agbcloud service-account token ci-builds
+  agbcloud service-account token ci-builds --ttl 2160h -o json`,
+	Args: serviceAccountRefArgs("token"),
+	RunE: runServiceAccountToken,
+}
+
+const (
+	// serviceAccountTimeout bounds the requests of the service-account commands
+	serviceAccountTimeout = 30 * time.Second
+	// defaultServiceAccountTokenTTL is how long issued tokens are valid by default
+	defaultServiceAccountTokenTTL = 30 * 24 * time.Hour
+	// minServiceAccountTokenTTL and maxServiceAccountTokenTTL bound --ttl
+	minServiceAccountTokenTTL = time.Hour
+	maxServiceAccountTokenTTL = 365 * 24 * time.Hour
+)
+
+// ServiceAccountScopes are the scopes a service account can be granted
+var ServiceAccountScopes = []string{"image:read", "image:build", "image:activate", "image:delete"}
+
+// serviceAccountNamePattern restricts account names to characters that are safe in tables and scripts
+var serviceAccountNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+func init() {
+	serviceAccountCreateCmd.Flags().StringSlice("scope", nil, "Scopes of the account, e.g. image:read,image:build (required)")
+	serviceAccountTokenCmd.Flags().Duration("ttl", defaultServiceAccountTokenTTL, "Time until the token expires, between 1h and 8760h")
+	addCopyFlag(serviceAccountTokenCmd, "token")
+
+	ServiceAccountCmd.AddCommand(serviceAccountCreateCmd)
+	ServiceAccountCmd.AddCommand(serviceAccountListCmd)
+	ServiceAccountCmd.AddCommand(serviceAccountDeleteCmd)
+	ServiceAccountCmd.AddCommand(serviceAccountTokenCmd)
+
+	registerOutputSchema(serviceAccountListCmd, outputSchema{
+		Command:     "service-account list",
+		Version:     1,
+		Description: "The service accounts with their scopes. lastUsedTime is empty until a token of the account was used.",
+		Result:      []ServiceAccountListItem{},
+	})
+}
+
+// serviceAccountRefArgs requires exactly one account ID or name
+func serviceAccountRefArgs(subcommand string) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return printErrorMessage(
+				"[ERROR] Expected exactly one service account (account ID or name)",
+				"",
+				fmt.Sprintf("[TIP] Usage: agbcloud service-account %s <account-id|name>", subcommand),
+				"[TIP] Run 'agbcloud service-account list' to see your service accounts",
+			)
+		}
+		return nil
+	}
+}
+
+// ValidateServiceAccountName checks an account name against the naming rules
+func ValidateServiceAccountName(name string) error {
+	if !serviceAccountNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid service account name %q: use 1-63 lower-case letters, digits or '-', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+// ParseServiceAccountScopes normalizes and checks the values of --scope
+func ParseServiceAccountScopes(values []string) ([]string, error) {
+	var scopes []string
+	for _, value := range values {
+		scope := strings.ToLower(strings.TrimSpace(value))
+		if scope == "" {
+			continue
+		}
+		if !slices.Contains(ServiceAccountScopes, scope) {
+			return nil, fmt.Errorf("unknown scope %q; supported: %s", value, strings.Join(ServiceAccountScopes, ", "))
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("at least one scope is required; supported: %s", strings.Join(ServiceAccountScopes, ", "))
+	}
+	return scopes, nil
+}
+
+// ServiceAccountListItem is the structured (json/csv/pson) representation of an account in `service-account list`
+type ServiceAccountListItem struct {
+	AccountID    string `json:"accountId"`
+	Name         string `json:"name"`
+	Scopes       string `json:"scopes"`
+	CreateTime   string `json:"createTime"`
+	LastUsedTime string `json:"lastUsedTime"`
+}
+
+// NewServiceAccountListItems converts API account information into structured list output
+func NewServiceAccountListItems(accounts []client.ServiceAccountInfo) []ServiceAccountListItem {
+	items := make([]ServiceAccountListItem, 0, len(accounts))
+	for _, account := range accounts {
+		items = append(items, ServiceAccountListItem{
+			AccountID:    account.AccountID,
+			Name:         account.Name,
+			Scopes:       strings.Join(account.Scopes, ","),
+			CreateTime:   account.CreateTime,
+			LastUsedTime: account.LastUsedTime,
+		})
+	}
+	return items
+}
+
+// MatchServiceAccount returns the accounts a reference names: by account ID or name
+func MatchServiceAccount(accounts []client.ServiceAccountInfo, ref string) []client.ServiceAccountInfo {
+	var matches []client.ServiceAccountInfo
+	for _, account := range accounts {
+		if account.AccountID == ref || account.Name == ref {
+			matches = append(matches, account)
+		}
+	}
+	return matches
+}
+
+// serviceAccountClient loads the configuration and returns an API client for a logged-in user
+func serviceAccountClient() (*client.APIClient, *config.Config, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return nil, nil, fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+	return client.NewFromConfig(cfg), cfg, nil
+}
+
+// listServiceAccounts fetches the service accounts, reporting failures like the other commands
+func listServiceAccounts(ctx context.Context, apiClient *client.APIClient, cfg *config.Config) ([]client.ServiceAccountInfo, error) {
+	listResp, httpResp, err := apiClient.ServiceAccountAPI.ListServiceAccounts(ctx, cfg.Token.LoginToken, cfg.Token.SessionId)
+	if err != nil {
+		return nil, requestError(os.Stderr, "failed to list service accounts", httpResp, err)
+	}
+	return listResp.Data.Accounts, nil
+}
+
+// resolveServiceAccount finds the one account ref names
+func resolveServiceAccount(ctx context.Context, apiClient *client.APIClient, cfg *config.Config, ref string) (client.ServiceAccountInfo, error) {
+	accounts, err := listServiceAccounts(ctx, apiClient, cfg)
+	if err != nil {
+		return client.ServiceAccountInfo{}, err
+	}
+	matches := MatchServiceAccount(accounts, ref)
+	switch {
+	case len(matches) == 0:
+		return client.ServiceAccountInfo{}, printErrorMessage(
+			fmt.Sprintf("[ERROR] No service account with ID or name '%s'", ref),
+			"",
+			"[TIP] Run 'agbcloud service-account list' to see your service accounts",
+		)
+	case len(matches) > 1:
+		lines := []string{fmt.Sprintf("[ERROR] '%s' matches %d service accounts:", ref, len(matches))}
+		for _, account := range matches {
+			lines = append(lines, fmt.Sprintf("  %s (%s)", account.AccountID, account.Name))
+		}
+		return client.ServiceAccountInfo{}, printErrorMessage(append(lines, "", "[TIP] Give the account by its account ID")...)
+	}
+	return matches[0], nil
+}
+
+func runServiceAccountCreate(cmd *cobra.Command, args []string) error {
+	name := strings.TrimSpace(args[0])
+	scopeValues, _ := cmd.Flags().GetStringSlice("scope")
+
+	if err := ValidateServiceAccountName(name); err != nil {
+		return printErrorMessage(fmt.Sprintf("[ERROR] %v", err))
+	}
+	scopes, err := ParseServiceAccountScopes(scopeValues)
+	if err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid --scope: %v", err),
+			"",
+			"[TIP] Grant only what the pipeline needs, e.g. --scope image:read,image:build",
+		)
+	}
+
+	apiClient, cfg, err := serviceAccountClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(commandContext(cmd), serviceAccountTimeout)
+	defer cancel()
+
+	fmt.Printf("[CREATE] Creating service account '%s' with scopes %s...\n", name, strings.Join(scopes, ", "))
+	createResp, httpResp, err := apiClient.ServiceAccountAPI.CreateServiceAccount(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, name, scopes)
+	if err != nil {
+		return requestError(os.Stdout, "failed to create service account", httpResp, err)
+	}
+
+	fmt.Printf("[OK] Service account '%s' created (Account ID: %s)\n", createResp.Data.Name, createResp.Data.AccountID)
+	fmt.Printf("[TIP] Issue a token for your pipeline with 'agbcloud service-account token %s'\n", createResp.Data.Name)
+	return nil
+}
+
+func runServiceAccountList(cmd *cobra.Command, args []string) error {
+	defer startPager()()
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if err := applyTimeFormat(cmd); err != nil {
+		return err
+	}
+	progress := progressWriter(outputFormat)
+
+	apiClient, cfg, err := serviceAccountClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(commandContext(cmd), serviceAccountTimeout)
+	defer cancel()
+
+	fmt.Fprintln(progress, "[SEARCH] Fetching service accounts...")
+	accounts, err := listServiceAccounts(ctx, apiClient, cfg)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat.IsStructured() {
+		return writeResult(outputFormat, NewServiceAccountListItems(accounts))
+	}
+	printServiceAccounts(os.Stdout, accounts)
+	return nil
+}
+
+// printServiceAccounts prints the service accounts as a table
+func printServiceAccounts(w io.Writer, accounts []client.ServiceAccountInfo) {
+	if len(accounts) == 0 {
+		fmt.Fprintln(w, "[EMPTY] No service accounts.")
+		fmt.Fprintln(w, "[TIP] Create one with 'agbcloud service-account create <name> --scope <scope>'")
+		return
+	}
+
+	fmt.Fprintf(w, "[OK] Found %d service account(s)\n\n", len(accounts))
+	rows := make([][]string, 0, len(accounts))
+	for _, account := range accounts {
+		lastUsed := "never"
+		if account.LastUsedTime != "" {
+			lastUsed = formatTimestamp(account.LastUsedTime)
+		}
+		rows = append(rows, []string{
+			account.AccountID,
+			account.Name,
+			strings.Join(account.Scopes, ","),
+			formatTimestamp(account.CreateTime),
+			lastUsed,
+		})
+	}
+	printTable(w, []TableColumn{
+		{Header: "ACCOUNT ID", Width: 20, Truncate: true},
+		{Header: "NAME", Width: 24, Truncate: true},
+		{Header: "SCOPES", Width: 40, Truncate: true},
+		{Header: "CREATED", Width: 20},
+		{Header: "LAST USED"},
+	}, rows)
+}
+
+func runServiceAccountDelete(cmd *cobra.Command, args []string) error {
+	ref := strings.TrimSpace(args[0])
+
+	apiClient, cfg, err := serviceAccountClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(commandContext(cmd), serviceAccountTimeout)
+	defer cancel()
+
+	account, err := resolveServiceAccount(ctx, apiClient, cfg, ref)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("[DELETE] Deleting service account '%s' (Account ID: %s)...\n", account.Name, account.AccountID)
+	_, httpResp, err := apiClient.ServiceAccountAPI.DeleteServiceAccount(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, account.AccountID)
+	if err != nil {
+		return requestError(os.Stdout, "failed to delete service account", httpResp, err)
+	}
+
+	fmt.Printf("[OK] Service account '%s' deleted\n", account.Name)
+	fmt.Println("[NOTE] All tokens of the account are revoked; pipelines using them can no longer log in")
+	return nil
+}
+
+// ServiceAccountToken is the structured (json/csv/pson) result of `service-account token`
+type ServiceAccountToken struct {
+	AccountID    string `json:"accountId"`
+	Name         string `json:"name"`
+	TokenID      string `json:"tokenId"`
+	AccountToken string `json:"accountToken"`
+	ExpiresAt    string `json:"expiresAt"`
+}
+
+func runServiceAccountToken(cmd *cobra.Command, args []string) error {
+	ref := strings.TrimSpace(args[0])
+	ttl, _ := cmd.Flags().GetDuration("ttl")
+	if ttl < minServiceAccountTokenTTL || ttl > maxServiceAccountTokenTTL {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] Invalid --ttl value: %s", ttl),
+			"",
+			fmt.Sprintf("[TIP] Tokens are valid for between %s and %s, e.g. --ttl 720h", minServiceAccountTokenTTL, maxServiceAccountTokenTTL),
+		)
+	}
+
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if err := applyTimeFormat(cmd); err != nil {
+		return err
+	}
+	progress := progressWriter(outputFormat)
+
+	apiClient, cfg, err := serviceAccountClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(commandContext(cmd), serviceAccountTimeout)
+	defer cancel()
+
+	account, err := resolveServiceAccount(ctx, apiClient, cfg, ref)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(progress, "[KEY] Issuing a token for service account '%s'...\n", account.Name)
+	tokenResp, httpResp, err := apiClient.ServiceAccountAPI.IssueServiceAccountToken(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, account.AccountID, int(ttl.Seconds()))
+	if err != nil {
+		return requestError(progress, "failed to issue token", httpResp, err)
+	}
+	token := ServiceAccountToken{
+		AccountID:    account.AccountID,
+		Name:         account.Name,
+		TokenID:      tokenResp.Data.TokenID,
+		AccountToken: tokenResp.Data.AccountToken,
+		ExpiresAt:    tokenResp.Data.ExpiresAt,
+	}
+
+	if outputFormat.IsStructured() {
+		if err := writeResult(outputFormat, token); err != nil {
+			return err
+		}
+	} else {
+		fmt.Printf("[OK] Token issued (Token ID: %s, expires %s)\n", token.TokenID, formatTimestamp(token.ExpiresAt))
+		fmt.Println()
+		fmt.Printf("  %s\n\n", token.AccountToken)
+		fmt.Println("[WARN]  The token is shown only once. Store it in the secret store of your CI system")
+		fmt.Println("[TIP] Log the pipeline in with: echo \"$AGB_TOKEN\" | agbcloud login --with-token")
+	}
+	copyToClipboard(cmd, progress, "token", token.AccountToken)
+	return nil
+}
@@ -0,0 +1,475 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var SSHKeyCmd = &cobra.Command{
+	Use:   "ssh-key",
+	Short: "Manage SSH keys for instance access",
+	Long: `Register the SSH public keys that are injected into activated instances.
+
+Keys apply to instances activated after they were added; running instances keep
+the keys they were started with. Only public keys are uploaded.`,
+	GroupID: "management",
+}
+
+var sshKeyAddCmd = &cobra.Command{
+	Use:   "add [public-key-file]",
+	Short: "Register an SSH public key",
+	Long: `Register an SSH public key. Without a file, the first of ~/.ssh/id_ed25519.pub,
+~/.ssh/id_ecdsa.pub and ~/.ssh/id_rsa.pub that exists is used. Use '-' to read
+the key from stdin.
+
+The key is named after its comment unless --name is given.`,
+	Example: `  agbcloud ssh-key add
+  agbcloud ssh-key add ~/.ssh/work.pub --name work-laptop
+  cat key.pub | agbcloud ssh-key add - --name ci`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSSHKeyAdd,
+}
+
+var sshKeyListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List registered SSH keys",
+	Args:    cobra.NoArgs,
+	RunE:    runSSHKeyList,
+}
+
+var sshKeyRemoveCmd = &cobra.Command{
+	Use:     "remove <key-id|name|fingerprint>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a registered SSH key",
+	Long: `Remove a registered SSH key, given by its key ID, its name or its SHA256 fingerprint.
+Instances activated afterwards no longer accept the key.`,
+	Example: `  agbcloud ssh-key remove work-laptop
+  agbcloud ssh-key remove SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return printErrorMessage(
+				"[ERROR] Expected exactly one SSH key (key ID, name or fingerprint)",
+				"",
+				"[TIP] Usage: agbcloud ssh-key remove <key-id|name|fingerprint>",
+				"[TIP] Run 'agbcloud ssh-key list' to see your keys",
+			)
+		}
+		return nil
+	},
+	RunE: runSSHKeyRemove,
+}
+
+// sshKeyTimeout bounds the requests of the ssh-key commands
+const sshKeyTimeout = 30 * time.Second
+
+// defaultPublicKeyFiles are tried in order when 'ssh-key add' is given no file
+var defaultPublicKeyFiles = []string{"id_ed25519.pub", "id_ecdsa.pub", "id_rsa.pub"}
+
+// supportedSSHKeyTypes are the public key algorithms instances accept
+var supportedSSHKeyTypes = []string{
+	"ssh-ed25519",
+	"ecdsa-sha2-nistp256",
+	"ecdsa-sha2-nistp384",
+	"ecdsa-sha2-nistp521",
+	"sk-ssh-ed25519@openssh.com",
+	"sk-ecdsa-sha2-nistp256@openssh.com",
+	"ssh-rsa",
+}
+
+// sshKeyNamePattern restricts key names to characters that are safe in tables and scripts
+var sshKeyNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._@-]{0,63}$`)
+
+func init() {
+	sshKeyAddCmd.Flags().String("name", "", "Name of the key (default: the key comment)")
+
+	SSHKeyCmd.AddCommand(sshKeyAddCmd)
+	SSHKeyCmd.AddCommand(sshKeyListCmd)
+	SSHKeyCmd.AddCommand(sshKeyRemoveCmd)
+
+	registerOutputSchema(sshKeyListCmd, outputSchema{
+		Command:     "ssh-key list",
+		Version:     1,
+		Description: "The registered SSH public keys. fingerprint is the OpenSSH SHA256 fingerprint.",
+		Result:      []SSHKeyListItem{},
+	})
+}
+
+// PublicKey is a parsed SSH public key in authorized_keys format
+type PublicKey struct {
+	Type    string
+	Blob    []byte
+	Comment string
+}
+
+// Fingerprint returns the OpenSSH SHA256 fingerprint, e.g. "SHA256:uNiVztks..."
+func (k PublicKey) Fingerprint() string {
+	sum := sha256.Sum256(k.Blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// String returns the key in authorized_keys format
+func (k PublicKey) String() string {
+	line := k.Type + " " + base64.StdEncoding.EncodeToString(k.Blob)
+	if k.Comment != "" {
+		line += " " + k.Comment
+	}
+	return line
+}
+
+// ParsePublicKey parses a single SSH public key in authorized_keys format. Blank lines
+// and comments are ignored; private keys and files with several keys are rejected.
+func ParsePublicKey(content string) (PublicKey, error) {
+	if strings.Contains(content, "PRIVATE KEY") {
+		return PublicKey{}, errors.New("this is a private key; register the public key (the .pub file) instead")
+	}
+
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	switch {
+	case len(lines) == 0:
+		return PublicKey{}, errors.New("no public key found")
+	case len(lines) > 1:
+		return PublicKey{}, fmt.Errorf("found %d keys; add them one at a time", len(lines))
+	}
+
+	fields := strings.Fields(lines[0])
+	if len(fields) < 2 {
+		return PublicKey{}, errors.New("expected '<type> <base64-key> [comment]'")
+	}
+	key := PublicKey{Type: fields[0], Comment: strings.Join(fields[2:], " ")}
+	if !isSupportedSSHKeyType(key.Type) {
+		if key.Type == "ssh-dss" {
+			return PublicKey{}, errors.New("DSA keys are no longer supported; create an Ed25519 key with 'ssh-keygen -t ed25519'")
+		}
+		return PublicKey{}, fmt.Errorf("unsupported key type %q; supported: %s", key.Type, strings.Join(supportedSSHKeyTypes, ", "))
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return PublicKey{}, errors.New("the key data is not valid base64")
+	}
+	// The key data starts with its own type, which must match the declared one
+	if len(blob) < 4 {
+		return PublicKey{}, errors.New("the key data is truncated")
+	}
+	typeLength := binary.BigEndian.Uint32(blob)
+	if uint64(typeLength) > uint64(len(blob)-4) || string(blob[4:4+typeLength]) != key.Type {
+		return PublicKey{}, fmt.Errorf("the key data does not match the key type %s", key.Type)
+	}
+	key.Blob = blob
+	return key, nil
+}
+
+func isSupportedSSHKeyType(keyType string) bool {
+	for _, supported := range supportedSSHKeyTypes {
+		if keyType == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateSSHKeyName checks a key name against the naming rules
+func ValidateSSHKeyName(name string) error {
+	if !sshKeyNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid key name %q: use 1-64 letters, digits, '.', '_', '@' or '-', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+// defaultSSHKeyName names a key after its comment, or after its file when the comment
+// is not a valid name
+func defaultSSHKeyName(key PublicKey, path string) string {
+	if ValidateSSHKeyName(key.Comment) == nil {
+		return key.Comment
+	}
+	if path != "" && path != "-" {
+		name := strings.TrimSuffix(filepath.Base(path), ".pub")
+		if ValidateSSHKeyName(name) == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// findDefaultPublicKey returns the first default public key file in ~/.ssh that exists
+func findDefaultPublicKey() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	for _, name := range defaultPublicKeyFiles {
+		path := filepath.Join(home, ".ssh", name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("none of %s found in %s", strings.Join(defaultPublicKeyFiles, ", "), filepath.Join(home, ".ssh"))
+}
+
+// readPublicKeyFile reads a public key from path, or from in when path is "-"
+func readPublicKeyFile(path string, in io.Reader) (string, error) {
+	if path == "-" {
+		content, err := io.ReadAll(io.LimitReader(in, 64*1024))
+		return string(content), err
+	}
+	content, err := os.ReadFile(path)
+	return string(content), err
+}
+
+// SSHKeyListItem is the structured (json/csv/pson) representation of a key in `ssh-key list`
+type SSHKeyListItem struct {
+	KeyID       string `json:"keyId"`
+	Name        string `json:"name"`
+	KeyType     string `json:"keyType"`
+	Fingerprint string `json:"fingerprint"`
+	CreateTime  string `json:"createTime"`
+}
+
+// NewSSHKeyListItems converts API key information into structured list output
+func NewSSHKeyListItems(keys []client.SSHKeyInfo) []SSHKeyListItem {
+	items := make([]SSHKeyListItem, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, SSHKeyListItem{
+			KeyID:       key.KeyID,
+			Name:        key.Name,
+			KeyType:     key.KeyType,
+			Fingerprint: key.Fingerprint,
+			CreateTime:  key.CreateTime,
+		})
+	}
+	return items
+}
+
+// MatchSSHKey returns the registered keys a reference names: by key ID, name or fingerprint
+func MatchSSHKey(keys []client.SSHKeyInfo, ref string) []client.SSHKeyInfo {
+	var matches []client.SSHKeyInfo
+	for _, key := range keys {
+		if key.KeyID == ref || key.Name == ref || (key.Fingerprint != "" && key.Fingerprint == ref) {
+			matches = append(matches, key)
+		}
+	}
+	return matches
+}
+
+// loggedInClient loads the configuration and returns an API client for a logged-in user
+func loggedInClient() (*client.APIClient, *config.Config, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.Token == nil || cfg.Token.LoginToken == "" || cfg.Token.SessionId == "" {
+		return nil, nil, fmt.Errorf("not authenticated. Please run 'agbcloud login' first")
+	}
+	return client.NewFromConfig(cfg), cfg, nil
+}
+
+// listSSHKeys fetches the registered keys, reporting failures like the other commands
+func listSSHKeys(ctx context.Context, apiClient *client.APIClient, cfg *config.Config) ([]client.SSHKeyInfo, error) {
+	listResp, httpResp, err := apiClient.SSHKeyAPI.ListSSHKeys(ctx, cfg.Token.LoginToken, cfg.Token.SessionId)
+	if err != nil {
+		return nil, requestError(os.Stderr, "failed to list SSH keys", httpResp, err)
+	}
+	return listResp.Data.Keys, nil
+}
+
+func runSSHKeyAdd(cmd *cobra.Command, args []string) error {
+	name, _ := cmd.Flags().GetString("name")
+
+	path := ""
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		found, err := findDefaultPublicKey()
+		if err != nil {
+			return printErrorMessage(
+				"[ERROR] No SSH public key given and no default key found",
+				fmt.Sprintf("[NOTE] %v", err),
+				"",
+				"[TIP] Create a key with 'ssh-keygen -t ed25519', or pass the public key file: agbcloud ssh-key add <file>",
+			)
+		}
+		path = found
+	}
+
+	content, err := readPublicKeyFile(path, cmd.InOrStdin())
+	if err != nil {
+		return printErrorMessage(fmt.Sprintf("[ERROR] Cannot read SSH public key: %v", err))
+	}
+	key, err := ParsePublicKey(content)
+	if err != nil {
+		lines := []string{fmt.Sprintf("[ERROR] Invalid SSH public key in %s: %v", path, err)}
+		if !strings.HasSuffix(path, ".pub") {
+			if _, statErr := os.Stat(path + ".pub"); statErr == nil {
+				lines = append(lines, "", fmt.Sprintf("[TIP] Did you mean %s.pub?", path))
+			}
+		}
+		return printErrorMessage(lines...)
+	}
+
+	if name == "" {
+		name = defaultSSHKeyName(key, path)
+		if name == "" {
+			return printErrorMessage(
+				"[ERROR] The key has no comment that can be used as its name",
+				"",
+				"[TIP] Name it explicitly, e.g. agbcloud ssh-key add "+path+" --name my-laptop",
+			)
+		}
+	} else if err := ValidateSSHKeyName(name); err != nil {
+		return printErrorMessage(fmt.Sprintf("[ERROR] %v", err))
+	}
+
+	apiClient, cfg, err := loggedInClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(commandContext(cmd), sshKeyTimeout)
+	defer cancel()
+
+	fmt.Printf("[KEY] Registering %s key '%s' (%s)...\n", key.Type, name, key.Fingerprint())
+
+	// Registering the same key twice is not an error
+	keys, err := listSSHKeys(ctx, apiClient, cfg)
+	if err != nil {
+		return err
+	}
+	if matches := MatchSSHKey(keys, key.Fingerprint()); len(matches) > 0 {
+		fmt.Printf("[OK] This key is already registered as '%s' (Key ID: %s)\n", matches[0].Name, matches[0].KeyID)
+		return nil
+	}
+
+	addResp, httpResp, err := apiClient.SSHKeyAPI.AddSSHKey(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, name, key.String())
+	if err != nil {
+		return requestError(os.Stdout, "failed to add SSH key", httpResp, err)
+	}
+
+	fmt.Printf("[OK] SSH key '%s' added (Key ID: %s)\n", addResp.Data.Name, addResp.Data.KeyID)
+	fmt.Println("[NOTE] The key is injected into instances activated from now on")
+	return nil
+}
+
+func runSSHKeyList(cmd *cobra.Command, args []string) error {
+	defer startPager()()
+	outputFormat, err := getOutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	if err := applyTimeFormat(cmd); err != nil {
+		return err
+	}
+	progress := progressWriter(outputFormat)
+
+	apiClient, cfg, err := loggedInClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(commandContext(cmd), sshKeyTimeout)
+	defer cancel()
+
+	fmt.Fprintln(progress, "[SEARCH] Fetching SSH keys...")
+	keys, err := listSSHKeys(ctx, apiClient, cfg)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat.IsStructured() {
+		return writeResult(outputFormat, NewSSHKeyListItems(keys))
+	}
+	printSSHKeys(os.Stdout, keys)
+	return nil
+}
+
+// printSSHKeys prints the registered keys as a table
+func printSSHKeys(w io.Writer, keys []client.SSHKeyInfo) {
+	if len(keys) == 0 {
+		fmt.Fprintln(w, "[EMPTY] No SSH keys registered.")
+		fmt.Fprintln(w, "[TIP] Add one with 'agbcloud ssh-key add'")
+		return
+	}
+
+	fmt.Fprintf(w, "[OK] Found %d SSH key(s)\n\n", len(keys))
+	rows := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		rows = append(rows, []string{
+			key.KeyID,
+			key.Name,
+			key.KeyType,
+			valueOrDash(key.Fingerprint),
+			formatTimestamp(key.CreateTime),
+		})
+	}
+	printTable(w, []TableColumn{
+		{Header: "KEY ID", Width: 20, Truncate: true},
+		{Header: "NAME", Width: 24, Truncate: true},
+		{Header: "TYPE", Width: 20, Truncate: true},
+		{Header: "FINGERPRINT", Width: 52},
+		{Header: "ADDED"},
+	}, rows)
+}
+
+func runSSHKeyRemove(cmd *cobra.Command, args []string) error {
+	ref := strings.TrimSpace(args[0])
+
+	apiClient, cfg, err := loggedInClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(commandContext(cmd), sshKeyTimeout)
+	defer cancel()
+
+	keys, err := listSSHKeys(ctx, apiClient, cfg)
+	if err != nil {
+		return err
+	}
+	matches := MatchSSHKey(keys, ref)
+	switch {
+	case len(matches) == 0:
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] No SSH key with ID, name or fingerprint '%s'", ref),
+			"",
+			"[TIP] Run 'agbcloud ssh-key list' to see your keys",
+		)
+	case len(matches) > 1:
+		lines := []string{fmt.Sprintf("[ERROR] '%s' matches %d SSH keys:", ref, len(matches))}
+		for _, key := range matches {
+			lines = append(lines, fmt.Sprintf("  %s (%s)", key.KeyID, key.Name))
+		}
+		return printErrorMessage(append(lines, "", "[TIP] Remove the key by its key ID")...)
+	}
+
+	key := matches[0]
+	fmt.Printf("[DELETE] Removing SSH key '%s' (Key ID: %s)...\n", key.Name, key.KeyID)
+	_, httpResp, err := apiClient.SSHKeyAPI.DeleteSSHKey(ctx, cfg.Token.LoginToken, cfg.Token.SessionId, key.KeyID)
+	if err != nil {
+		return requestError(os.Stdout, "failed to remove SSH key", httpResp, err)
+	}
+
+	fmt.Printf("[OK] SSH key '%s' removed\n", key.Name)
+	fmt.Println("[NOTE] Running instances keep the keys they were activated with until they are deactivated")
+	return nil
+}
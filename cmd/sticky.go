@@ -0,0 +1,265 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// stickyFlags lists, per command path, the flags that are remembered when sticky
+// flags are enabled. Only flags that select a view or a size belong here; flags
+// that change what a command does to images are never reused implicitly.
+var stickyFlags = map[string][]string{
+	"image list":     {"type", "size", "output", "time-format"},
+	"image logs":     {"tail", "timestamps"},
+	"image activate": {"cpu", "memory"},
+	"image outdated": {"output", "time-format"},
+}
+
+var configStickyCmd = &cobra.Command{
+	Use:   "sticky",
+	Short: "Remember and reuse the last-used flags of common commands",
+	Long: `Sticky flags make commands reuse the flags they were last run with, such as
+'--type System' or '-o json' for 'agbcloud image list'. Flags given on the command
+line are remembered and win over remembered ones. Values are stored per profile.
+
+Remembered flags:
+` + stickyFlagsHelp() + `
+Pass --no-sticky to a command to neither use nor remember flags for that run.`,
+}
+
+var configStickyEnableCmd = &cobra.Command{
+	Use:   "enable",
+	Short: "Turn on sticky flags",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setSticky(true)
+	},
+}
+
+var configStickyDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Turn off sticky flags (remembered values are kept)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setSticky(false)
+	},
+}
+
+var configStickyShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the remembered flags of the active profile",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigStickyShow,
+}
+
+var configStickyClearCmd = &cobra.Command{
+	Use:   "clear [command]",
+	Short: "Forget the remembered flags of the active profile",
+	Example: `  agbcloud config sticky clear
+  agbcloud config sticky clear "image list"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigStickyClear,
+}
+
+func init() {
+	configStickyCmd.AddCommand(configStickyEnableCmd)
+	configStickyCmd.AddCommand(configStickyDisableCmd)
+	configStickyCmd.AddCommand(configStickyShowCmd)
+	configStickyCmd.AddCommand(configStickyClearCmd)
+	ConfigCmd.AddCommand(configStickyCmd)
+}
+
+// stickyFlagsHelp lists the remembered flags of each command for the help text
+func stickyFlagsHelp() string {
+	commands := make([]string, 0, len(stickyFlags))
+	for command := range stickyFlags {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+
+	var b strings.Builder
+	for _, command := range commands {
+		fmt.Fprintf(&b, "  %-16s --%s\n", command, strings.Join(stickyFlags[command], ", --"))
+	}
+	return b.String()
+}
+
+// stickyCommandPath returns the path of cmd below the root command, e.g. "image list"
+func stickyCommandPath(cmd *cobra.Command) string {
+	path := cmd.CommandPath()
+	if cmd.HasParent() {
+		path = strings.TrimPrefix(path, cmd.Root().Name()+" ")
+	}
+	return path
+}
+
+// ApplyStickyFlags fills the flags of cmd that were not given with their remembered
+// values, and remembers the ones that were given. It does nothing unless sticky
+// flags are enabled, and is skipped for a single run with --no-sticky.
+func ApplyStickyFlags(cmd *cobra.Command) error {
+	if noSticky, _ := cmd.Flags().GetBool("no-sticky"); noSticky {
+		return nil
+	}
+	command := stickyCommandPath(cmd)
+	names, ok := stickyFlags[command]
+	if !ok {
+		return nil
+	}
+	cfg, err := config.GetConfig()
+	if err != nil || !cfg.Sticky {
+		return nil // A broken configuration is reported by the command itself
+	}
+
+	remembered := cfg.RememberedFlags(command)
+	given := make(map[string]string)
+	var applied []string
+	for _, name := range names {
+		flag := cmd.Flags().Lookup(name)
+		if flag == nil {
+			continue
+		}
+		if flag.Changed {
+			given[name] = flag.Value.String()
+			continue
+		}
+		value, ok := remembered[name]
+		if !ok || value == flag.Value.String() {
+			continue
+		}
+		if err := cmd.Flags().Set(name, value); err != nil {
+			fmt.Fprintf(os.Stderr, "[WARN] Ignoring remembered --%s %s: %v\n", name, value, err)
+			continue
+		}
+		applied = append(applied, formatStickyFlag(flag, value))
+	}
+
+	if len(applied) > 0 {
+		fmt.Fprintf(os.Stderr, "[NOTE] Using remembered flags: %s (--no-sticky to ignore)\n", strings.Join(applied, " "))
+	}
+	if len(given) > 0 && !sameFlagValues(remembered, given) {
+		cfg.RememberFlags(command, given)
+		if err := cfg.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "[WARN] Could not remember flags: %v\n", err)
+		}
+	}
+	return nil
+}
+
+// formatStickyFlag formats a flag as it would be typed, e.g. --type System or --timestamps
+func formatStickyFlag(flag *pflag.Flag, value string) string {
+	if flag.Value.Type() == "bool" && value == "true" {
+		return "--" + flag.Name
+	}
+	return fmt.Sprintf("--%s %s", flag.Name, value)
+}
+
+// sameFlagValues reports whether every value in given is already remembered
+func sameFlagValues(remembered, given map[string]string) bool {
+	for name, value := range given {
+		if existing, ok := remembered[name]; !ok || existing != value {
+			return false
+		}
+	}
+	return true
+}
+
+func setSticky(enabled bool) error {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.Sticky = enabled
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	if enabled {
+		fmt.Println("[OK] Sticky flags enabled")
+		fmt.Println("[TIP] Commands now reuse their last-used flags; pass --no-sticky to ignore them once")
+	} else {
+		fmt.Println("[OK] Sticky flags disabled")
+	}
+	return nil
+}
+
+func runConfigStickyShow(cmd *cobra.Command, args []string) error {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	state := "disabled"
+	if cfg.Sticky {
+		state = "enabled"
+	}
+	profile, _ := cfg.CurrentProfile()
+	if profile == "" {
+		profile = config.DefaultStickyProfile
+	}
+	fmt.Printf("[DATA] Sticky flags: %s (profile: %s)\n", state, profile)
+
+	commands := make([]string, 0, len(stickyFlags))
+	for command := range stickyFlags {
+		if len(cfg.RememberedFlags(command)) > 0 {
+			commands = append(commands, command)
+		}
+	}
+	if len(commands) == 0 {
+		fmt.Println("[NOTE] No flags remembered yet")
+		return nil
+	}
+	sort.Strings(commands)
+
+	for _, command := range commands {
+		remembered := cfg.RememberedFlags(command)
+		names := make([]string, 0, len(remembered))
+		for name := range remembered {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		flags := make([]string, len(names))
+		for i, name := range names {
+			flags[i] = fmt.Sprintf("--%s %s", name, remembered[name])
+		}
+		fmt.Printf("  %-16s %s\n", command, strings.Join(flags, " "))
+	}
+	return nil
+}
+
+func runConfigStickyClear(cmd *cobra.Command, args []string) error {
+	command := ""
+	if len(args) == 1 {
+		command = strings.Join(strings.Fields(args[0]), " ")
+		if _, ok := stickyFlags[command]; !ok {
+			return printErrorMessage(
+				fmt.Sprintf("[ERROR] '%s' does not remember flags", args[0]),
+				"",
+				"[TIP] Run 'agbcloud config sticky --help' to see which commands remember flags",
+			)
+		}
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	cfg.ForgetFlags(command)
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	if command == "" {
+		fmt.Println("[OK] Forgot all remembered flags")
+	} else {
+		fmt.Printf("[OK] Forgot the remembered flags of '%s'\n", command)
+	}
+	return nil
+}
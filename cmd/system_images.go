@@ -0,0 +1,199 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// defaultSystemImageTTL is how long the System image list is reused when the
+// configuration does not say otherwise. System images change rarely.
+const defaultSystemImageTTL = time.Hour
+
+// ParseSystemImageTTL converts the systemImageTTL setting into a duration. Empty
+// selects the default; 0 disables the cache.
+func ParseSystemImageTTL(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return defaultSystemImageTTL, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return 0, fmt.Errorf("invalid duration '%s' (use e.g. 30m or 2h, or 0 to disable the cache)", value)
+	}
+	return ttl, nil
+}
+
+// systemImageCachePath returns the path of the System image list cache file. It has
+// the format of the image list cache, but only holds complete System image lists.
+func systemImageCachePath() (string, error) {
+	configDir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "cache", "system-images.json"), nil
+}
+
+// loadCachedSystemImages returns the cached System image list of the current profile
+// and endpoint, or nil if there is none
+func loadCachedSystemImages() (*CachedImageList, error) {
+	path, err := systemImageCachePath()
+	if err != nil {
+		return nil, err
+	}
+	profile, endpoint := imageListCacheScope()
+	return loadImageListCacheEntry(path, profile, endpoint, "System")
+}
+
+// SystemImageList lists the System images that custom images are built from. The list
+// is cached in the configuration directory for the systemImageTTL setting, so that
+// repeated checks are instant and keep working briefly offline.
+type SystemImageList struct {
+	APIClient  *client.APIClient
+	LoginToken string
+	SessionID  string
+	// Refresh fetches the list even when the cached one is recent enough
+	Refresh bool
+	// Now is the time source (default time.Now)
+	Now func() time.Time
+}
+
+func (l SystemImageList) now() time.Time {
+	if l.Now != nil {
+		return l.Now()
+	}
+	return time.Now()
+}
+
+// ttl returns how long a cached list is used; 0 when caching is off
+func (l SystemImageList) ttl() time.Duration {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return defaultSystemImageTTL
+	}
+	ttl, err := ParseSystemImageTTL(cfg.SystemImageTTL)
+	if err != nil {
+		log.Debugf("Ignoring systemImageTTL setting: %v", err)
+		return defaultSystemImageTTL
+	}
+	return ttl
+}
+
+// Images returns the System images, from the cache when it is recent enough. The
+// second result tells whether the list came from the cache.
+func (l SystemImageList) Images(ctx context.Context) ([]client.ImageInfo, bool, error) {
+	ttl := l.ttl()
+	if ttl > 0 && !l.Refresh {
+		entry, err := loadCachedSystemImages()
+		if err != nil {
+			log.Debugf("Ignoring System image cache: %v", err)
+		} else if entry != nil && l.now().Sub(entry.FetchedAt) < ttl {
+			return entry.Images, true, nil
+		}
+	}
+
+	images, err := listAllImages(ctx, l.APIClient, l.LoginToken, l.SessionID, client.ImageListOptions{ImageType: "System"})
+	if err != nil {
+		return nil, false, err
+	}
+	// Caching is best-effort and never fails the command
+	if ttl > 0 {
+		if err := SaveCachedSystemImages(images, l.now()); err != nil {
+			log.Debugf("Could not cache the System image list: %v", err)
+		}
+	}
+	return images, false, nil
+}
+
+// SaveCachedSystemImages stores the System image list of the current profile and
+// endpoint, fetched at fetchedAt
+func SaveCachedSystemImages(images []client.ImageInfo, fetchedAt time.Time) error {
+	path, err := systemImageCachePath()
+	if err != nil {
+		return err
+	}
+	profile, endpoint := imageListCacheScope()
+	return saveImageListCacheEntry(path, CachedImageList{
+		FetchedAt: fetchedAt.UTC(),
+		Profile:   profile,
+		Endpoint:  endpoint,
+		ImageType: "System",
+		All:       true,
+		Total:     len(images),
+		Images:    images,
+	})
+}
+
+// findImageByID returns the image with the given ID, or nil if there is none
+func findImageByID(images []client.ImageInfo, imageId string) *client.ImageInfo {
+	for i := range images {
+		if images[i].ImageID == imageId {
+			return &images[i]
+		}
+	}
+	return nil
+}
+
+// unknownSourceImages returns the sources that are not in images, each once
+func unknownSourceImages(images []client.ImageInfo, sources []string) []string {
+	var unknown []string
+	for _, source := range sources {
+		if findImageByID(images, source) == nil && !slices.Contains(unknown, source) {
+			unknown = append(unknown, source)
+		}
+	}
+	return unknown
+}
+
+// checkSourceImages makes sure every source image is a System image before anything
+// is uploaded. The server still rejects unknown sources, so a failed lookup is only
+// reported through warn and does not block the build.
+func checkSourceImages(ctx context.Context, list SystemImageList, sources []string, warn func(format string, args ...interface{})) error {
+	images, cached, err := list.Images(ctx)
+	if err != nil {
+		warn("Could not check the source image: %v", err)
+		return nil
+	}
+	// An empty list tells nothing about the sources
+	if len(images) == 0 {
+		return nil
+	}
+
+	unknown := unknownSourceImages(images, sources)
+	if len(unknown) > 0 && cached {
+		// Look again in case a System image was added since the list was cached
+		list.Refresh = true
+		if images, _, err = list.Images(ctx); err != nil {
+			warn("Could not check the source image: %v", err)
+			return nil
+		}
+		unknown = unknownSourceImages(images, sources)
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(images))
+	for _, image := range images {
+		ids = append(ids, image.ImageID)
+	}
+	sort.Strings(ids)
+	return printErrorMessage(
+		fmt.Sprintf("[ERROR] Unknown source image: %s", strings.Join(unknown, ", ")),
+		"",
+		fmt.Sprintf("[TIP] Build from one of the System images: %s", strings.Join(ids, ", ")),
+		"[NOTE] Run 'agbcloud image list --type System' to see them",
+	)
+}
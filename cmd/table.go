@@ -0,0 +1,106 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/pager"
+	"github.com/agbcloud/agbcloud-cli/internal/progress"
+)
+
+// noTrunc is set by the global --no-trunc flag
+var noTrunc bool
+
+// ApplyTruncationFlag turns table truncation off for this invocation if --no-trunc was given
+func ApplyTruncationFlag(cmd *cobra.Command) {
+	noTrunc, _ = cmd.Flags().GetBool("no-trunc")
+}
+
+// TableColumn is a column of a table printed by the list commands
+type TableColumn struct {
+	Header string
+	// Width is the width the column is padded to; 0 leaves the values unpadded
+	Width int
+	// Truncate cuts longer values to Width when the table does not fit the terminal
+	Truncate bool
+}
+
+// FitTableWidths returns the width of each column of a table of rows. Truncated
+// columns are widened to their longest value, left to right, as long as the table
+// stays within maxWidth, so that IDs are shown in full on wide terminals. A maxWidth
+// of 0 means there is no limit, and no value is truncated.
+func FitTableWidths(columns []TableColumn, rows [][]string, maxWidth int) []int {
+	widths := make([]int, len(columns))
+	total := len(columns) - 1
+	for i, column := range columns {
+		widths[i] = column.Width
+		total += column.Width
+	}
+	for i, column := range columns {
+		if !column.Truncate {
+			continue
+		}
+		longest := len(column.Header)
+		for _, row := range rows {
+			longest = max(longest, len(row[i]))
+		}
+		extra := longest - widths[i]
+		if extra > 0 && (maxWidth <= 0 || total+extra <= maxWidth) {
+			widths[i] = longest
+			total += extra
+		}
+	}
+	return widths
+}
+
+// tableWidth returns the width tables written to w have to fit in, or 0 when values
+// must not be truncated: with --no-trunc, and when the output is piped, so that IDs
+// can be copied and processed in full
+func tableWidth(w io.Writer) int {
+	if noTrunc {
+		return 0
+	}
+	// Paged output goes to the terminal through a pipe
+	if w == io.Writer(os.Stdout) && pagedTerminal != nil {
+		w = pagedTerminal
+	}
+	if !progress.IsTerminal(w) {
+		return 0
+	}
+	return pager.Width(w.(*os.File))
+}
+
+// printTable prints a header line, a line of dashes under each header and the rows,
+// fitting the truncated columns to the terminal
+func printTable(w io.Writer, columns []TableColumn, rows [][]string) {
+	widths := FitTableWidths(columns, rows, tableWidth(w))
+	headers := make([]string, len(columns))
+	dashes := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+		dashes[i] = strings.Repeat("-", len(column.Header))
+	}
+	printTableRow(w, columns, widths, headers)
+	printTableRow(w, columns, widths, dashes)
+	for _, row := range rows {
+		printTableRow(w, columns, widths, row)
+	}
+}
+
+func printTableRow(w io.Writer, columns []TableColumn, widths []int, cells []string) {
+	fields := make([]string, len(cells))
+	for i, cell := range cells {
+		if columns[i].Truncate {
+			cell = truncateString(cell, widths[i])
+		}
+		fields[i] = fmt.Sprintf("%-*s", widths[i], cell)
+	}
+	fmt.Fprintln(w, strings.Join(fields, " "))
+}
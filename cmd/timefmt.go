@@ -0,0 +1,215 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// Embedded so that --tz accepts zone names on machines without a zone database, e.g. Windows
+	_ "time/tzdata"
+
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/progress"
+)
+
+// TimeFormat selects how timestamps are displayed in human-readable output
+type TimeFormat string
+
+const (
+	// TimeFormatLocal shows timestamps in the local timezone (default)
+	TimeFormatLocal TimeFormat = "local"
+	// TimeFormatUTC shows timestamps in UTC
+	TimeFormatUTC TimeFormat = "utc"
+	// TimeFormatRelative shows the age of a timestamp, e.g. "2h ago"
+	TimeFormatRelative TimeFormat = "relative"
+	// TimeFormatRaw shows timestamps exactly as returned by the server
+	TimeFormatRaw TimeFormat = "raw"
+)
+
+// displayTimeFormat is the format used by formatTimestamp and formatTime
+var displayTimeFormat = TimeFormatLocal
+
+// displayLocation is the time zone local-format timestamps are shown in; --tz and
+// the timeZone setting change it so that a team sees the same times
+var displayLocation = time.Local
+
+// ParseTimeFormat parses a --time-format value; an empty value selects local time
+func ParseTimeFormat(value string) (TimeFormat, error) {
+	switch TimeFormat(strings.ToLower(strings.TrimSpace(value))) {
+	case "", TimeFormatLocal:
+		return TimeFormatLocal, nil
+	case TimeFormatUTC:
+		return TimeFormatUTC, nil
+	case TimeFormatRelative:
+		return TimeFormatRelative, nil
+	case TimeFormatRaw:
+		return TimeFormatRaw, nil
+	default:
+		return "", fmt.Errorf("unsupported time format '%s' (supported: local, utc, relative, raw)", value)
+	}
+}
+
+// applyTimeFormat selects the timestamp format from the global --time-format flag,
+// falling back to the configured time format
+func applyTimeFormat(cmd *cobra.Command) error {
+	value, err := cmd.Flags().GetString("time-format")
+	if err != nil {
+		// Flag not registered (e.g. command used outside the root command)
+		value = ""
+	}
+	if !cmd.Flags().Changed("time-format") {
+		if cfg, err := config.GetConfig(); err == nil && cfg.TimeFormat != "" {
+			value = cfg.TimeFormat
+		}
+	}
+
+	format, err := ParseTimeFormat(value)
+	if err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] %v", err),
+			"",
+			"[TIP] Usage: --time-format <local|utc|relative|raw>",
+			"[NOTE] Example: agbcloud image list --time-format relative",
+		)
+	}
+	displayTimeFormat = format
+	return nil
+}
+
+// ParseTimeZone parses a --tz value: local (the default), utc or an IANA time zone
+// name such as Asia/Shanghai or America/New_York
+func ParseTimeZone(value string) (*time.Location, error) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "", "local":
+		return time.Local, nil
+	case "utc":
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(value)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone '%s' (use local, utc or an IANA name such as Asia/Shanghai)", value)
+	}
+	return location, nil
+}
+
+// ApplyTimeZoneFlag selects the time zone of displayed timestamps from the global --tz
+// flag, falling back to the timeZone setting of the configuration
+func ApplyTimeZoneFlag(cmd *cobra.Command) error {
+	value, err := cmd.Flags().GetString("tz")
+	if err != nil {
+		value = ""
+	}
+	if !cmd.Flags().Changed("tz") {
+		if cfg, err := config.GetConfig(); err == nil && cfg.TimeZone != "" {
+			value = cfg.TimeZone
+		}
+	}
+
+	location, err := ParseTimeZone(value)
+	if err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] %v", err),
+			"",
+			"[TIP] Usage: --tz <local|utc|Area/City>",
+			"[NOTE] Example: agbcloud image list --tz Asia/Shanghai",
+		)
+	}
+	displayLocation = location
+	progress.SetLocation(location)
+	return nil
+}
+
+// inDisplayZone converts t to the time zone selected with --tz
+func inDisplayZone(t time.Time) time.Time {
+	return t.In(displayLocation)
+}
+
+// ApplyTimestampsFlag prefixes the progress and status lines of long operations with
+// the time they were printed when the global --timestamps flag is given, falling back
+// to the timestamps setting of the configuration
+func ApplyTimestampsFlag(cmd *cobra.Command) {
+	enabled, err := cmd.Flags().GetBool("timestamps")
+	if err == nil && !cmd.Flags().Changed("timestamps") {
+		if cfg, err := config.GetConfig(); err == nil {
+			enabled = cfg.Timestamps
+		}
+	}
+	progress.SetTimestamps(enabled)
+}
+
+// FormatTimestampAs formats an RFC3339 timestamp from the API for display.
+// Timestamps that cannot be parsed are shown as returned, truncated to fit table columns.
+func FormatTimestampAs(timestamp string, format TimeFormat, now time.Time) string {
+	if timestamp == "" {
+		return "-"
+	}
+	if format == TimeFormatRaw {
+		return timestamp
+	}
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return truncateString(timestamp, 20)
+	}
+	return FormatTimeAs(t, format, now)
+}
+
+// FormatTimeAs formats a point in time for display. Local-format times are shown in
+// the zone selected with --tz; a zone other than the machine's is named, e.g.
+// "2025-09-11 13:48 CST", so that pasted output is unambiguous.
+func FormatTimeAs(t time.Time, format TimeFormat, now time.Time) string {
+	switch format {
+	case TimeFormatUTC:
+		return t.UTC().Format("2006-01-02 15:04") + " UTC"
+	case TimeFormatRelative:
+		return formatRelativeTime(now.Sub(t))
+	case TimeFormatRaw:
+		return t.Format(time.RFC3339)
+	default:
+		if displayLocation == time.Local {
+			return t.Local().Format("2006-01-02 15:04")
+		}
+		return t.In(displayLocation).Format("2006-01-02 15:04 MST")
+	}
+}
+
+// formatRelativeTime renders an age such as "5m ago" or, for future times, "in 3d"
+func formatRelativeTime(age time.Duration) string {
+	future := age < 0
+	if future {
+		age = -age
+	}
+
+	var amount string
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		amount = fmt.Sprintf("%dm", int(age/time.Minute))
+	case age < 24*time.Hour:
+		amount = fmt.Sprintf("%dh", int(age/time.Hour))
+	case age < 365*24*time.Hour:
+		amount = fmt.Sprintf("%dd", int(age/(24*time.Hour)))
+	default:
+		amount = fmt.Sprintf("%dy", int(age/(365*24*time.Hour)))
+	}
+
+	if future {
+		return "in " + amount
+	}
+	return amount + " ago"
+}
+
+// formatTimestamp formats an API timestamp with the selected --time-format
+func formatTimestamp(timestamp string) string {
+	return FormatTimestampAs(timestamp, displayTimeFormat, time.Now())
+}
+
+// formatTime formats a point in time with the selected --time-format
+func formatTime(t time.Time) string {
+	return FormatTimeAs(t, displayTimeFormat, time.Now())
+}
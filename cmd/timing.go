@@ -0,0 +1,79 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// timingEmbedded is set once the timings were written as part of a JSON result,
+// so they are not printed a second time after the command
+var timingEmbedded bool
+
+// EnableTiming turns on per-call timing for every API client created by the CLI
+func EnableTiming() {
+	client.EnableTiming()
+	timingEmbedded = false
+}
+
+// ReportTimings prints the recorded API call timings, if --timing was given.
+// It is called after the command finishes, whether it succeeded or not.
+func ReportTimings(w io.Writer) {
+	recorder := client.DefaultTimingRecorder()
+	if recorder == nil || timingEmbedded {
+		return
+	}
+
+	calls := recorder.Calls()
+	fmt.Fprintln(w)
+	if len(calls) == 0 {
+		fmt.Fprintln(w, "[TIME] No API calls were made")
+		return
+	}
+
+	fmt.Fprintf(w, "[TIME] API call timing (%d call(s)):\n", len(calls))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tSTATUS\tCONN\tDNS\tCONNECT\tTLS\tTTFB\tTOTAL")
+
+	var total time.Duration
+	for _, call := range calls {
+		status := fmt.Sprintf("%d", call.StatusCode)
+		if call.Err != "" {
+			status = "ERROR"
+		}
+		conn := "new"
+		if call.Reused {
+			conn = "reused"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			call.Method, call.Path, status, conn,
+			formatPhase(call.DNS), formatPhase(call.Connect), formatPhase(call.TLS),
+			formatPhase(call.TTFB), formatPhase(call.Total))
+		total += call.Total
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "[TIME] Total time in API calls: %s\n", formatPhase(total))
+	for _, call := range calls {
+		if call.Err != "" {
+			fmt.Fprintf(w, "[WARN]  %s %s failed: %s\n", call.Method, call.Path, call.Err)
+		}
+	}
+}
+
+// formatPhase renders a duration with millisecond precision, or "-" when the phase did not happen
+func formatPhase(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	if d < time.Millisecond {
+		return "<1ms"
+	}
+	return d.Round(time.Millisecond).String()
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/tracing"
+)
+
+// traceFlushTimeout bounds how long a finished command waits for the collector
+const traceFlushTimeout = 5 * time.Second
+
+// commandSpan is the root span of the running command, set by StartTracing
+var commandSpan *tracing.Span
+
+// StartTracing enables trace export if an OTLP endpoint is configured through the
+// environment or the configuration file, and starts the root span of command. API
+// calls, uploads and poll cycles made with the command's context become its children.
+func StartTracing(command *cobra.Command) error {
+	var configured string
+	if cfg, err := config.GetConfig(); err == nil {
+		configured = cfg.OTLPEndpoint
+	} else {
+		log.Debugf("[DEBUG] Could not load the configuration for tracing: %v", err)
+	}
+
+	exporter, err := tracing.ExporterFromEnv(configured)
+	if err != nil {
+		return printErrorMessage(
+			fmt.Sprintf("[ERROR] %v", err),
+			"",
+			fmt.Sprintf("[TIP] Set %s or 'otlpEndpoint' in the configuration to a collector URL, or unset it to disable tracing", tracing.EnvEndpoint),
+		)
+	}
+	if exporter == nil {
+		return nil
+	}
+	log.Debugf("[DEBUG] Exporting traces to %s", exporter.URL)
+
+	tracing.Enable(tracing.NewTracer(tracing.Resource{ServiceName: "agbcloud-cli", ServiceVersion: Version}, exporter))
+	ctx, span := tracing.Start(commandContext(command), command.CommandPath(), tracing.SpanKindInternal,
+		tracing.String("cli.command", stickyCommandPath(command)),
+		tracing.String("cli.version", Version),
+	)
+	commandSpan = span
+	command.SetContext(ctx)
+	return nil
+}
+
+// FinishTracing ends the root span with the command's result and exports the trace.
+// It is called after the command finishes and does nothing if tracing is off.
+// Export failures are reported as a warning and never fail the command.
+func FinishTracing(w io.Writer, err error) {
+	tracer := tracing.Default()
+	if tracer == nil {
+		return
+	}
+	commandSpan.Finish(err)
+	commandSpan = nil
+
+	// The command context may already be cancelled, e.g. after Ctrl+C
+	ctx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+	defer cancel()
+	if flushErr := tracer.Flush(ctx); flushErr != nil {
+		fmt.Fprintf(w, "[WARN]  Could not export traces: %v\n", flushErr)
+	}
+	tracing.Disable()
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/internal/verbosity"
+)
+
+// secretPrefixLength is the number of characters of a secret shown with -vvv
+const secretPrefixLength = 4
+
+// ApplyVerboseFlag selects the verbosity tier from the global -v flag, which can be
+// repeated: -v shows request summaries and step details, -vv request and response
+// headers, -vvv bodies. Debug logs and request events are shown from -v on.
+func ApplyVerboseFlag(cmd *cobra.Command) {
+	count, _ := cmd.Flags().GetCount("verbose")
+	verbosity.Set(verbosity.Level(count))
+	if count > 0 {
+		log.SetLevel(log.DebugLevel)
+		// Show requests, retries and status changes as structured events
+		EnableEventLog()
+	} else {
+		log.SetLevel(log.InfoLevel)
+	}
+}
+
+// printDetail prints a step detail, such as a request ID, from the given tier on
+func printDetail(level verbosity.Level, format string, args ...interface{}) {
+	if verbosity.Enabled(level) {
+		fmt.Printf(format, args...)
+	}
+}
+
+// MaskSecret shows only the first characters of a token, e.g. "abcd... (32 characters)",
+// enough to tell two tokens apart in a bug report without revealing them
+func MaskSecret(secret string) string {
+	if len(secret) <= secretPrefixLength*2 {
+		return fmt.Sprintf("[REDACTED] (%d characters)", len(secret))
+	}
+	return fmt.Sprintf("%s... (%d characters)", secret[:secretPrefixLength], len(secret))
+}
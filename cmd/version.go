@@ -5,8 +5,11 @@ package cmd
 
 import (
 	"fmt"
+	"runtime"
 
 	"github.com/spf13/cobra"
+
+	"github.com/agbcloud/agbcloud-cli/pkg/version"
 )
 
 var (
@@ -24,6 +27,11 @@ var VersionCmd = &cobra.Command{
 		fmt.Printf("AgbCloud CLI version %s\n", Version)
 		fmt.Printf("Git commit: %s\n", GitCommit)
 		fmt.Printf("Build date: %s\n", BuildDate)
+		fmt.Printf("Platform: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+		if Version != "dev" {
+			// The build best suited for this machine, e.g. the arm64 build on Windows on ARM
+			fmt.Printf("Release artifact: %s\n", version.DetectTarget().ArtifactName(Version))
+		}
 		return nil
 	},
 }
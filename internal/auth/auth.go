@@ -12,7 +12,6 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 )
 
@@ -28,76 +27,114 @@ func GetCallbackPort() string {
 	return "3000"
 }
 
-// StartCallbackServer starts a local HTTP server to handle OAuth callbacks
+// CallbackIdleTimeout is the longest a callback server stays up without receiving
+// a callback. It bounds the lifetime of the listener even if the caller never
+// cancels its context (e.g. after a crash in the login flow).
+var CallbackIdleTimeout = 10 * time.Minute
+
+// callbackShutdownGrace is how long in-flight callback responses get to finish
+// before the server is closed
+const callbackShutdownGrace = 2 * time.Second
+
+// callbackResult carries the outcome of the OAuth callback request
+type callbackResult struct {
+	code string
+	err  error
+}
+
+// StartCallbackServer starts a local HTTP server to handle OAuth callbacks.
+// The listener is always closed before this function returns, whether a code was
+// received, the context was cancelled, or the idle timeout elapsed.
 func StartCallbackServer(ctx context.Context, port string) (string, error) {
-	var code string
-	var err error
-	var wg sync.WaitGroup
-	wg.Add(1)
+	// Bind explicitly so that port conflicts are reported instead of timing out
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		return "", fmt.Errorf("failed to start callback server on port %s: %w", port, err)
+	}
+
+	// Record the listener so `agbcloud doctor` can identify orphaned servers
+	unregister := registerListener(port)
+	defer unregister()
+
+	resultChan := make(chan callbackResult, 1)
 
 	// Create a new ServeMux to avoid conflicts with global handlers
 	mux := http.NewServeMux()
 	server := &http.Server{
-		Addr:    fmt.Sprintf(":%s", port),
-		Handler: mux,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
 	}
 
 	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
-		defer wg.Done()
-
 		// Get authorization code
-		code = r.URL.Query().Get("code")
+		code := r.URL.Query().Get("code")
 		if code == "" {
-			err = fmt.Errorf("no code in callback")
 			http.Error(w, "No code", http.StatusBadRequest)
+			deliverCallbackResult(resultChan, callbackResult{err: fmt.Errorf("no code in callback")})
 			return
 		}
 
 		// Return success page
+		result := callbackResult{code: code}
 		w.Header().Set("Content-Type", "text/html")
 		w.WriteHeader(http.StatusOK)
 		if _, writeErr := w.Write([]byte(GetSuccessHTML())); writeErr != nil {
 			// Log the error but don't fail the authentication
 			// The code has already been captured successfully
-			err = fmt.Errorf("warning: failed to write success page: %w", writeErr)
+			result.err = fmt.Errorf("warning: failed to write success page: %w", writeErr)
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
 		}
 
-		// Delay server close to ensure browser receives the success page
-		go func() {
-			time.Sleep(500 * time.Millisecond)
-			server.Close()
-		}()
+		deliverCallbackResult(resultChan, result)
 	})
 
 	// Start server in background
+	serveErr := make(chan error, 1)
 	go func() {
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			// Log startup errors for debugging
-			// The callback will timeout if server fails to start
-			_ = err // Error is intentionally not propagated
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			serveErr <- err
 		}
 	}()
 
-	// Wait for callback or timeout
-	done := make(chan struct{})
-	go func() {
-		wg.Wait()
-		close(done)
+	// Shut down gracefully on every exit path so the success page is delivered
+	// to the browser and the port is released
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), callbackShutdownGrace)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			server.Close()
+		}
 	}()
 
+	idleTimer := time.NewTimer(CallbackIdleTimeout)
+	defer idleTimer.Stop()
+
 	select {
-	case <-done:
+	case result := <-resultChan:
 		// Callback received
-		if err != nil {
-			return "", err
+		if result.err != nil {
+			return "", result.err
 		}
-		return code, nil
+		return result.code, nil
+	case err := <-serveErr:
+		return "", fmt.Errorf("callback server stopped unexpectedly: %w", err)
+	case <-idleTimer.C:
+		return "", fmt.Errorf("callback timeout: no callback received within %v", CallbackIdleTimeout)
 	case <-ctx.Done():
-		server.Close()
 		return "", fmt.Errorf("callback timeout: %v", ctx.Err())
 	}
 }
 
+// deliverCallbackResult records the first callback result and ignores any later ones
+func deliverCallbackResult(resultChan chan callbackResult, result callbackResult) {
+	select {
+	case resultChan <- result:
+	default:
+	}
+}
+
 // GenerateRandomState generates a random state parameter for OAuth
 func GenerateRandomState() (string, error) {
 	b := make([]byte, 32)
@@ -324,27 +361,98 @@ func ParseAlternativePorts(alternativePorts string) []string {
 	return validPorts
 }
 
-// SelectAvailablePort selects an available port from default and alternative ports
-func SelectAvailablePort(defaultPort, alternativePorts string) (string, error) {
-	// First try the default port
-	if !IsPortOccupied(defaultPort) {
-		return defaultPort, nil
+// PortRange is an inclusive range of local ports, e.g. 40000-40100
+type PortRange struct {
+	Start int
+	End   int
+}
+
+// String formats the range as "start-end"
+func (r PortRange) String() string {
+	return fmt.Sprintf("%d-%d", r.Start, r.End)
+}
+
+// ParsePortRanges parses a comma-separated list of port ranges such as
+// "40000-40100,50000". A single port is a range of one.
+func ParsePortRanges(value string) ([]PortRange, error) {
+	var ranges []PortRange
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		startText, endText, isRange := strings.Cut(part, "-")
+		if !isRange {
+			endText = startText
+		}
+		start, errStart := strconv.Atoi(strings.TrimSpace(startText))
+		end, errEnd := strconv.Atoi(strings.TrimSpace(endText))
+		if errStart != nil || errEnd != nil || !IsValidPort(strconv.Itoa(start)) || !IsValidPort(strconv.Itoa(end)) {
+			return nil, fmt.Errorf("invalid port range '%s': ports must be between 1 and 65535", part)
+		}
+		if start > end {
+			return nil, fmt.Errorf("invalid port range '%s': start is greater than end", part)
+		}
+		ranges = append(ranges, PortRange{Start: start, End: end})
+	}
+	return ranges, nil
+}
+
+// PortPreferences extends the server-provided ports with local choices
+type PortPreferences struct {
+	// Recent are ports that worked for earlier logins; they are tried first
+	Recent []string
+	// Ranges are scanned after the default and alternative ports
+	Ranges []PortRange
+}
+
+// CandidatePorts returns the callback ports in the order they are tried: recently
+// successful ports, the default port, the server's alternative ports and finally
+// every port of the configured ranges. Duplicates and invalid ports are dropped.
+func CandidatePorts(defaultPort, alternativePorts string, prefs PortPreferences) []string {
+	seen := make(map[string]bool)
+	var candidates []string
+	add := func(port string) {
+		if IsValidPort(port) && !seen[port] {
+			seen[port] = true
+			candidates = append(candidates, port)
+		}
 	}
 
-	// Parse alternative ports
-	altPorts := ParseAlternativePorts(alternativePorts)
+	for _, port := range prefs.Recent {
+		add(port)
+	}
+	add(defaultPort)
+	for _, port := range ParseAlternativePorts(alternativePorts) {
+		add(port)
+	}
+	for _, r := range prefs.Ranges {
+		for port := r.Start; port <= r.End; port++ {
+			add(strconv.Itoa(port))
+		}
+	}
+	return candidates
+}
 
-	// Try each alternative port
-	for _, port := range altPorts {
-		if IsValidPort(port) && !IsPortOccupied(port) {
+// SelectAvailablePort selects the first free port from CandidatePorts
+func SelectAvailablePort(defaultPort, alternativePorts string, prefs PortPreferences) (string, error) {
+	for _, port := range CandidatePorts(defaultPort, alternativePorts, prefs) {
+		if !IsPortOccupied(port) {
 			return port, nil
 		}
 	}
 
 	// No available port found - provide detailed error message
-	if len(altPorts) == 0 {
+	var ranges []string
+	for _, r := range prefs.Ranges {
+		ranges = append(ranges, r.String())
+	}
+	switch {
+	case len(ParseAlternativePorts(alternativePorts)) == 0 && len(ranges) == 0:
 		return "", fmt.Errorf("no available port found: default port %s is occupied and no alternative ports provided", defaultPort)
+	case len(ranges) == 0:
+		return "", fmt.Errorf("no available port found: default port %s and all alternative ports [%s] are occupied. Please check if any of these ports can be freed up", defaultPort, alternativePorts)
+	default:
+		return "", fmt.Errorf("no available port found: default port %s, alternative ports [%s] and port ranges [%s] are all occupied. Please free up a port or configure another range", defaultPort, alternativePorts, strings.Join(ranges, ","))
 	}
-
-	return "", fmt.Errorf("no available port found: default port %s and all alternative ports [%s] are occupied. Please check if any of these ports can be freed up", defaultPort, alternativePorts)
 }
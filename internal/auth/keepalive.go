@@ -0,0 +1,218 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/poll"
+)
+
+// ErrSessionRejected is returned (wrapped) when the server refuses to renew the
+// session, e.g. because it expired or was logged out. Retrying will not help.
+var ErrSessionRejected = errors.New("session renewal rejected")
+
+// RenewSession exchanges the keep-alive token for a new session and saves it.
+// Unlike RefreshTokenIfNeeded it renews regardless of the expiry time and keeps
+// the stored tokens when renewal fails.
+func RenewSession(ctx context.Context) (*config.Token, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Token == nil || cfg.Token.KeepAliveToken == "" {
+		return nil, fmt.Errorf("%w: no keep-alive token found, use 'agbcloud login' to authenticate", ErrSessionRejected)
+	}
+
+	apiClient := client.NewFromConfig(cfg)
+	response, _, err := apiClient.OAuthAPI.RenewSession(ctx, cfg.Token.KeepAliveToken, cfg.Token.SessionId)
+	if err != nil {
+		var codeErr *client.APIError
+		if errors.As(err, &codeErr) {
+			return nil, fmt.Errorf("%w: refresh failed with code %s", ErrSessionRejected, codeErr.Code)
+		}
+		var apiErr *client.GenericOpenAPIError
+		if errors.As(err, &apiErr) && isTokenExpiredError(string(apiErr.Body())) {
+			return nil, fmt.Errorf("%w: %v", ErrSessionRejected, err)
+		}
+		return nil, err
+	}
+
+	err = cfg.SaveTokens(
+		response.Data.LoginToken,
+		response.Data.SessionId,
+		response.Data.KeepAliveToken,
+		response.Data.ExpiresAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save refreshed tokens: %w", err)
+	}
+	return cfg.Token, nil
+}
+
+// KeepAliveEvent describes one renewal attempt and is passed to KeepAlive.OnRenew
+type KeepAliveEvent struct {
+	Attempt   int
+	ExpiresAt time.Time // Expiry of the renewed session, zero if renewal failed
+	Err       error     // Transient error of this attempt, nil if the session was renewed
+}
+
+// KeepAlive renews the session at a fixed interval until its context is done.
+// Transient failures are retried at the next interval; a rejected renewal ends
+// the loop because the user has to log in again.
+type KeepAlive struct {
+	Interval time.Duration        // Time between renewals
+	Clock    poll.Clock           // Time source (default poll.RealClock)
+	OnRenew  func(KeepAliveEvent) // Called after every renewal attempt
+}
+
+// Run renews the session once right away and then every Interval. It returns
+// nil when ctx is cancelled and an error wrapping ErrSessionRejected when the
+// session can no longer be renewed.
+func (k KeepAlive) Run(ctx context.Context) error {
+	attempt := 0
+	renew := func(ctx context.Context) error {
+		attempt++
+		token, err := RenewSession(ctx)
+		if errors.Is(err, ErrSessionRejected) {
+			return err
+		}
+		event := KeepAliveEvent{Attempt: attempt, Err: err}
+		if token != nil {
+			event.ExpiresAt = token.ExpiresAt
+		}
+		if k.OnRenew != nil {
+			k.OnRenew(event)
+		}
+		return err
+	}
+
+	// Renew at once so that a session close to expiry is covered before the first interval
+	if err := renew(ctx); errors.Is(err, ErrSessionRejected) {
+		return err
+	}
+
+	err := poll.Poller{Interval: poll.Constant(k.Interval), Clock: k.Clock}.Until(ctx, func(ctx context.Context) (bool, error) {
+		err := renew(ctx)
+		if errors.Is(err, ErrSessionRejected) {
+			return false, poll.Stop(err)
+		}
+		return false, err
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// KeepAliveRecord describes the keep-alive process holding the lock
+type KeepAliveRecord struct {
+	PID       int       `json:"pid"`
+	StartedAt time.Time `json:"startedAt"`
+	Interval  string    `json:"interval"`
+}
+
+// KeepAliveLockedError is returned by AcquireKeepAliveLock when another running
+// process already keeps the session alive
+type KeepAliveLockedError struct {
+	Record KeepAliveRecord
+}
+
+func (e *KeepAliveLockedError) Error() string {
+	return fmt.Sprintf("session keep-alive already running (PID %d, started %s)", e.Record.PID, e.Record.StartedAt.Format(time.RFC3339))
+}
+
+// keepAliveLockPath returns the path of the keep-alive lockfile. The lock lives
+// next to the configuration, so each configuration directory has one refresher.
+func keepAliveLockPath() (string, error) {
+	configDir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "keepalive.lock"), nil
+}
+
+// AcquireKeepAliveLock creates the keep-alive lockfile and returns a function that
+// removes it. A lockfile left behind by a process that no longer runs is replaced.
+func AcquireKeepAliveLock(interval time.Duration) (func(), error) {
+	path, err := keepAliveLockPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(KeepAliveRecord{PID: os.Getpid(), StartedAt: time.Now(), Interval: interval.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	// A stale lock is removed once; losing the race to another process that
+	// removed it at the same time is reported as locked on the second attempt
+	for retry := 0; ; retry++ {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			_, err = file.Write(content)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				_ = os.Remove(path) // Do not leave a half-written lock behind
+				return nil, err
+			}
+			return func() {
+				if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+					log.Debugf("Could not remove keep-alive lock: %v", err)
+				}
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		record, readErr := ReadKeepAliveLock()
+		if record == nil {
+			record = &KeepAliveRecord{}
+		}
+		if (readErr == nil && isProcessAlive(record.PID)) || retry > 0 {
+			return nil, &KeepAliveLockedError{Record: *record}
+		}
+		log.Debugf("Removing stale keep-alive lock %s", path)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+}
+
+// ReadKeepAliveLock returns the record of the current keep-alive lock, or nil if
+// there is none
+func ReadKeepAliveLock() (*KeepAliveRecord, error) {
+	path, err := keepAliveLockPath()
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var record KeepAliveRecord
+	if err := json.Unmarshal(content, &record); err != nil {
+		return nil, fmt.Errorf("invalid keep-alive lock %s: %w", path, err)
+	}
+	return &record, nil
+}
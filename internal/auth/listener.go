@@ -0,0 +1,178 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// ListenerRecord describes a callback server started by this CLI.
+// Records are written when a callback server starts and removed when it stops,
+// so a record that outlives its process points at an orphaned or crashed login.
+type ListenerRecord struct {
+	Port      string    `json:"port"`
+	PID       int       `json:"pid"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// ListenerStatus is the result of checking a known callback port
+type ListenerStatus struct {
+	Port     string
+	Occupied bool
+	// Record is the listener record for this port, if any
+	Record *ListenerRecord
+	// OwnerAlive reports whether the process that wrote Record is still running
+	OwnerAlive bool
+}
+
+// Orphaned reports whether the port is held by a login process other than the current one
+func (s ListenerStatus) Orphaned() bool {
+	return s.Occupied && s.Record != nil && s.OwnerAlive && s.Record.PID != os.Getpid()
+}
+
+// Stale reports whether the record belongs to a process that no longer exists
+func (s ListenerStatus) Stale() bool {
+	return s.Record != nil && !s.OwnerAlive
+}
+
+// listenerDir returns the directory holding listener records
+func listenerDir() (string, error) {
+	configDir, err := config.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "listeners"), nil
+}
+
+// listenerRecordPath returns the record file path for a port
+func listenerRecordPath(port string) (string, error) {
+	dir, err := listenerDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, port+".json"), nil
+}
+
+// registerListener writes a PID-tagged record for a callback server and returns a
+// function that removes it. Tracking is best-effort and never blocks login.
+func registerListener(port string) func() {
+	path, err := listenerRecordPath(port)
+	if err != nil {
+		log.Debugf("Could not resolve listener record path: %v", err)
+		return func() {}
+	}
+
+	record := ListenerRecord{
+		Port:      port,
+		PID:       os.Getpid(),
+		StartedAt: time.Now(),
+	}
+
+	content, err := json.Marshal(record)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0700)
+	}
+	if err == nil {
+		err = os.WriteFile(path, content, 0600)
+	}
+	if err != nil {
+		log.Debugf("Could not write listener record for port %s: %v", port, err)
+		return func() {}
+	}
+
+	return func() {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			log.Debugf("Could not remove listener record for port %s: %v", port, err)
+		}
+	}
+}
+
+// ListListenerRecords returns all recorded callback servers, sorted by port
+func ListListenerRecords() ([]ListenerRecord, error) {
+	dir, err := listenerDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records []ListenerRecord
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		var record ListenerRecord
+		if err := json.Unmarshal(content, &record); err != nil || record.Port == "" {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	sort.Slice(records, func(i, j int) bool { return records[i].Port < records[j].Port })
+	return records, nil
+}
+
+// RemoveListenerRecord deletes the record for a port
+func RemoveListenerRecord(port string) error {
+	path, err := listenerRecordPath(port)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// CheckCallbackListeners inspects the default callback port and every recorded
+// listener port, reporting whether each is occupied and by whom
+func CheckCallbackListeners() ([]ListenerStatus, error) {
+	records, err := ListListenerRecords()
+	if err != nil {
+		return nil, err
+	}
+
+	byPort := make(map[string]*ListenerRecord)
+	ports := []string{GetCallbackPort()}
+	for i := range records {
+		if _, seen := byPort[records[i].Port]; !seen && records[i].Port != GetCallbackPort() {
+			ports = append(ports, records[i].Port)
+		}
+		byPort[records[i].Port] = &records[i]
+	}
+
+	statuses := make([]ListenerStatus, 0, len(ports))
+	for _, port := range ports {
+		status := ListenerStatus{
+			Port:     port,
+			Occupied: IsPortOccupied(port),
+			Record:   byPort[port],
+		}
+		if status.Record != nil {
+			status.OwnerAlive = isProcessAlive(status.Record.PID)
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
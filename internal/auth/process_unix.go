@@ -0,0 +1,25 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package auth
+
+import (
+	"os"
+	"syscall"
+)
+
+// isProcessAlive reports whether a process with the given PID is running
+func isProcessAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// Signal 0 performs error checking only; EPERM means the process exists
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || err == syscall.EPERM
+}
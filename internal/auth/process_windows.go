@@ -0,0 +1,24 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package auth
+
+import (
+	"os"
+)
+
+// isProcessAlive reports whether a process with the given PID is running
+func isProcessAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	// On Windows FindProcess opens a handle and fails if the process does not exist
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	process.Release()
+	return true
+}
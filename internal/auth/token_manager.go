@@ -27,6 +27,12 @@ func RefreshTokenIfNeeded(ctx context.Context) error {
 		return fmt.Errorf("no valid token found, use 'agbcloud-cli login' to reauthenticate")
 	}
 
+	// Provided tokens are renewed by running the credential provider again
+	if cfg.TokenFromProvider() {
+		log.Debug("Token is supplied by the credential provider, no refresh needed")
+		return nil
+	}
+
 	// Check if token is about to expire (within 5 minutes)
 	if time.Until(cfg.Token.ExpiresAt) > 5*time.Minute {
 		log.Debug("Token is still valid, no refresh needed")
@@ -39,7 +45,7 @@ func RefreshTokenIfNeeded(ctx context.Context) error {
 	apiClient := client.NewFromConfig(cfg)
 
 	// Perform token refresh
-	response, _, err := apiClient.OAuthAPI.RefreshToken(ctx,
+	response, _, err := apiClient.OAuthAPI.RenewSession(ctx,
 		cfg.Token.KeepAliveToken,
 		cfg.Token.SessionId)
 	if err != nil {
@@ -48,12 +54,6 @@ func RefreshTokenIfNeeded(ctx context.Context) error {
 		return fmt.Errorf("use 'agbcloud-cli login' to reauthenticate: %w", err)
 	}
 
-	if !response.Success {
-		// If refresh fails, clear the tokens
-		_ = cfg.ClearTokens() // Ignore error as we're already returning the refresh error
-		return fmt.Errorf("use 'agbcloud-cli login' to reauthenticate: refresh failed with code %s", response.Code)
-	}
-
 	// Save new tokens
 	err = cfg.SaveTokens(
 		response.Data.LoginToken,
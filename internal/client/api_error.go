@@ -0,0 +1,77 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// APIError is an application-level failure: the server answered with success=false and
+// an error code. The backend often sends such answers with HTTP 200, so the HTTP status
+// alone does not tell a failure from a success.
+type APIError struct {
+	Code       string
+	Message    string
+	RequestID  string
+	HTTPStatus int
+}
+
+// Error returns the error code, followed by the message when the server sent one
+func (e *APIError) Error() string {
+	code := e.Code
+	if code == "" {
+		code = "UnknownError"
+	}
+	if e.Message != "" {
+		return code + ": " + e.Message
+	}
+	return code
+}
+
+// responseEnvelope holds the fields shared by all API responses
+type responseEnvelope struct {
+	Success   *bool  `json:"success"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"requestId"`
+}
+
+// applicationError returns an *APIError if body is an API response with success=false,
+// or nil. Bodies without a success field, such as plain text, are never failures.
+func applicationError(body []byte, status int) error {
+	var envelope responseEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Success == nil || *envelope.Success {
+		return nil
+	}
+	return &APIError{Code: envelope.Code, Message: envelope.Message, RequestID: envelope.RequestID, HTTPStatus: status}
+}
+
+// decodeResponse decodes the body of a successful HTTP response into v. A body that
+// reports success=false yields an *APIError; v is filled in either case.
+func (c *APIClient) decodeResponse(v interface{}, body []byte, response *http.Response) error {
+	if err := c.decode(v, body, response.Header.Get("Content-Type")); err != nil {
+		return &GenericOpenAPIError{body: body, error: err.Error()}
+	}
+	return applicationError(body, response.StatusCode)
+}
+
+// ErrorCode returns the backend error code and request ID of err: the fields of an
+// *APIError, or the code in the body of an HTTP error response. Both are empty when
+// err carries no code.
+func ErrorCode(err error) (code, requestID string) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code, apiErr.RequestID
+	}
+	var httpErr *GenericOpenAPIError
+	if errors.As(err, &httpErr) {
+		var envelope responseEnvelope
+		if json.Unmarshal(httpErr.Body(), &envelope) == nil {
+			return envelope.Code, envelope.RequestID
+		}
+	}
+	return "", ""
+}
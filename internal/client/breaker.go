@@ -0,0 +1,196 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Circuit breaker defaults: five consecutive server errors within a minute open the
+// circuit, and a trial request is let through after thirty seconds
+const (
+	DefaultBreakerThreshold = 5
+	DefaultBreakerWindow    = time.Minute
+	DefaultBreakerCooldown  = 30 * time.Second
+)
+
+// ErrCircuitOpen matches every *CircuitOpenError with errors.Is
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitOpenError is returned without contacting the server while its circuit is open
+type CircuitOpenError struct {
+	Host string
+	// Failures is the number of consecutive server errors that opened the circuit
+	Failures int
+	// RetryAfter is how long until a trial request is allowed again
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker open for %s after %d consecutive server errors, retry in %s",
+		e.Host, e.Failures, e.RetryAfter.Round(time.Second))
+}
+
+// Is makes errors.Is(err, ErrCircuitOpen) true
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// hostBreaker is the state of the circuit for one host
+type hostBreaker struct {
+	state breakerState
+	// failures holds the times of the current run of consecutive server errors
+	failures []time.Time
+	openedAt time.Time
+	opened   int
+	// trial is set while the single half-open request is in flight
+	trial bool
+}
+
+// CircuitBreaker stops sending requests to a host that keeps answering with 5xx.
+// After Threshold consecutive server errors within Window the circuit opens and
+// requests fail fast. Once Cooldown has passed one trial request is let through:
+// if it succeeds the circuit closes, otherwise it opens again.
+// Circuits are tracked per host so that failover to other endpoints keeps working.
+// It is safe for concurrent use.
+type CircuitBreaker struct {
+	Threshold int
+	Window    time.Duration
+	Cooldown  time.Duration
+	// Now returns the current time; time.Now when nil
+	Now func() time.Time
+
+	mu    sync.Mutex
+	hosts map[string]*hostBreaker
+}
+
+// NewCircuitBreaker returns a circuit breaker with the default settings
+func NewCircuitBreaker() *CircuitBreaker {
+	return &CircuitBreaker{
+		Threshold: DefaultBreakerThreshold,
+		Window:    DefaultBreakerWindow,
+		Cooldown:  DefaultBreakerCooldown,
+	}
+}
+
+func (b *CircuitBreaker) now() time.Time {
+	if b.Now != nil {
+		return b.Now()
+	}
+	return time.Now()
+}
+
+func (b *CircuitBreaker) host(host string) *hostBreaker {
+	if b.hosts == nil {
+		b.hosts = make(map[string]*hostBreaker)
+	}
+	hb, ok := b.hosts[host]
+	if !ok {
+		hb = &hostBreaker{}
+		b.hosts[host] = hb
+	}
+	return hb
+}
+
+// Allow reports whether a request to host may be sent. It returns a *CircuitOpenError
+// while the circuit is open, and while the half-open trial request is still in flight.
+func (b *CircuitBreaker) Allow(host string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	hb := b.host(host)
+	switch hb.state {
+	case breakerOpen:
+		remaining := hb.openedAt.Add(b.Cooldown).Sub(b.now())
+		if remaining > 0 {
+			return &CircuitOpenError{Host: host, Failures: hb.opened, RetryAfter: remaining}
+		}
+		log.Infof("[CIRCUIT] Cooldown for %s is over, sending a trial request", host)
+		hb.state = breakerHalfOpen
+		hb.trial = true
+	case breakerHalfOpen:
+		if hb.trial {
+			return &CircuitOpenError{Host: host, Failures: hb.opened, RetryAfter: 0}
+		}
+		hb.trial = true
+	}
+	return nil
+}
+
+// Record reports the outcome of a request to host. statusCode is ignored when err is set.
+// Server errors count towards opening the circuit and any other response resets the count.
+// Network errors only count for the half-open trial request, since failover and retries
+// already handle servers that cannot be reached.
+func (b *CircuitBreaker) Record(host string, statusCode int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	hb := b.host(host)
+	failed := err == nil && statusCode >= http.StatusInternalServerError
+	if !failed && err == nil {
+		if hb.state != breakerClosed {
+			log.Infof("[CIRCUIT] %s answered again, closing the circuit", host)
+		}
+		*hb = hostBreaker{}
+		return
+	}
+
+	now := b.now()
+	if hb.state == breakerHalfOpen {
+		hb.trial = false
+		hb.state = breakerOpen
+		hb.openedAt = now
+		log.Warnf("[CIRCUIT] Trial request to %s failed, pausing requests for %s", host, b.Cooldown)
+		return
+	}
+	if !failed || hb.state == breakerOpen {
+		return
+	}
+
+	// Only the failures within the window count
+	recent := hb.failures[:0]
+	for _, at := range hb.failures {
+		if now.Sub(at) < b.Window {
+			recent = append(recent, at)
+		}
+	}
+	hb.failures = append(recent, now)
+	if len(hb.failures) >= b.Threshold {
+		hb.state = breakerOpen
+		hb.openedAt = now
+		hb.opened = len(hb.failures)
+		hb.failures = nil
+		log.Warnf("[CIRCUIT] %s failed %d times in a row, pausing requests for %s", host, hb.opened, b.Cooldown)
+	}
+}
+
+// defaultBreaker is shared by all API clients created with NewFromConfig so that every
+// command in the process sees the same state of the backend
+var defaultBreaker = NewCircuitBreaker()
+
+// DefaultCircuitBreaker returns the process-wide circuit breaker, or nil when it has
+// been turned off with AGB_CLI_CIRCUIT_BREAKER=off
+func DefaultCircuitBreaker() *CircuitBreaker {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("AGB_CLI_CIRCUIT_BREAKER"))) {
+	case "off", "false", "0":
+		return nil
+	}
+	return defaultBreaker
+}
@@ -15,9 +15,12 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+
+	"github.com/agbcloud/agbcloud-cli/internal/verbosity"
 )
 
 var (
@@ -30,8 +33,25 @@ type APIClient struct {
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
 	// API Services
-	OAuthAPI OAuthAPI
-	ImageAPI ImageAPI
+	OAuthAPI          OAuthAPI
+	ImageAPI          ImageAPI
+	SSHKeyAPI         SSHKeyAPI
+	ServiceAccountAPI ServiceAccountAPI
+	SessionAPI        SessionAPI
+
+	// queryTokenExchange is set once the server has rejected a POST token exchange,
+	// so later calls on this client go straight to the query-string endpoints
+	queryTokenExchange atomic.Bool
+
+	// servers tracks which configured servers recently failed, for failover
+	servers serverPool
+
+	// clockSkew is the local clock's offset from the server clock seen in the latest response
+	clockSkew      atomic.Int64
+	clockSkewKnown atomic.Bool
+
+	// capabilities caches what the server reported about its optional features
+	capabilities capabilityCache
 }
 
 type service struct {
@@ -53,6 +73,9 @@ func NewAPIClient(cfg *Configuration) *APIClient {
 	// API Services
 	c.OAuthAPI = (*OAuthAPIService)(&c.common)
 	c.ImageAPI = (*ImageAPIService)(&c.common)
+	c.SSHKeyAPI = (*SSHKeyAPIService)(&c.common)
+	c.ServiceAccountAPI = (*ServiceAccountAPIService)(&c.common)
+	c.SessionAPI = (*SessionAPIService)(&c.common)
 
 	return c
 }
@@ -62,73 +85,140 @@ func (c *APIClient) GetConfig() *Configuration {
 	return c.cfg
 }
 
-// callAPI do the request.
+// callAPI do the request. The response body is buffered and may not exceed the
+// configured maximum response size.
 func (c *APIClient) callAPI(request *http.Request) (*http.Response, error) {
-	// Log request information for debugging (only shown with -v flag)
-	log.Debugf("\n=== HTTP Request Information ===")
-	log.Debugf("URL: %s", request.URL.String())
-	log.Debugf("Method: %s", request.Method)
-	log.Debugf("Headers: %v", request.Header)
+	return c.doCallAPI(request, c.cfg.responseLimit(false), true)
+}
+
+// callLargeAPI does the request for endpoints known to return large payloads. The
+// response body is not buffered, so callers should decode it as a stream; reading
+// beyond the large response limit fails with a ResponseTooLargeError.
+func (c *APIClient) callLargeAPI(request *http.Request) (*http.Response, error) {
+	return c.doCallAPI(request, c.cfg.responseLimit(true), false)
+}
 
-	if request.Body != nil {
+func (c *APIClient) doCallAPI(request *http.Request, limit int64, buffer bool) (*http.Response, error) {
+	request, span := startRequestSpan(request)
+	started := c.emitRequestStarted(request)
+	resp, err := c.sendRequest(request, limit, buffer)
+	finishRequestSpan(span, resp, err)
+	c.emitRequestFinished(request, started, resp, err)
+	return resp, err
+}
+
+// sendRequest sends the request, logging it with -vv and its body with -vvv
+func (c *APIClient) sendRequest(request *http.Request, limit int64, buffer bool) (*http.Response, error) {
+	// Log request information for debugging. The one-line summary of -v comes from the
+	// request events; headers are shown with -vv and bodies with -vvv. Secrets are
+	// redacted because verbose logs are often pasted into bug reports.
+	logHeaders, logBodies := verbosity.Enabled(verbosity.Headers), verbosity.Enabled(verbosity.Bodies)
+	if logHeaders {
+		log.Debugf("\n=== HTTP Request Information ===")
+		log.Debugf("URL: %s", RedactText(request.URL.String()))
+		log.Debugf("Method: %s", request.Method)
+		log.Debugf("Headers: %v", RedactHeaders(request.Header))
+	}
+
+	if logBodies && request.Body != nil {
 		// Read body for logging without consuming it
 		bodyBytes, err := io.ReadAll(request.Body)
 		if err == nil {
-			log.Debugf("Request Body: %s", string(bodyBytes))
+			log.Debugf("Request Body: %s", RedactText(string(bodyBytes)))
 			// Restore the body
 			request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 		}
-	} else {
+	} else if logBodies {
 		log.Debugf("Request Body: None")
 	}
-	log.Debugf("=" + strings.Repeat("=", 49))
+	if logHeaders {
+		log.Debugf("=" + strings.Repeat("=", 49))
+	}
 
 	if c.cfg.Debug {
 		dump, err := httputil.DumpRequestOut(request, true)
 		if err != nil {
 			return nil, err
 		}
-		log.Debugf("\n%s\n", string(dump))
+		log.Debugf("\n%s\n", RedactText(string(dump)))
+	}
+
+	var trace *callTrace
+	if c.cfg.Timing != nil {
+		request, trace = withTimingTrace(request)
 	}
 
-	resp, err := c.cfg.HTTPClient.Do(request)
+	sent := time.Now()
+	resp, err := c.doWithFailover(request, c.cfg.HTTPClient)
+	c.recordClockSkew(resp, sent, time.Now())
 	if err != nil {
-		log.Debugf("\n=== HTTP Request Error ===")
-		log.Debugf("Error Type: %T", err)
-		log.Debugf("Error Message: %s", err.Error())
-		log.Debugf("Request URL: %s", request.URL.String())
-		log.Debugf("=" + strings.Repeat("=", 49))
+		if trace != nil {
+			trace.finish(c.cfg.Timing, nil, err)
+		}
+		if logHeaders {
+			log.Debugf("\n=== HTTP Request Error ===")
+			log.Debugf("Error Type: %T", err)
+			log.Debugf("Error Message: %s", RedactText(err.Error()))
+			log.Debugf("Request URL: %s", RedactText(request.URL.String()))
+			log.Debugf("=" + strings.Repeat("=", 49))
+		}
 		return resp, err
 	}
 
-	// Log response information for debugging (only shown with -v flag)
-	log.Debugf("\n=== HTTP Response Information ===")
-	log.Debugf("Status Code: %d", resp.StatusCode)
-	log.Debugf("Status: %s", resp.Status)
-	log.Debugf("Headers: %v", resp.Header)
+	// Log response information for debugging (shown with -vv, the body with -vvv)
+	if logHeaders {
+		log.Debugf("\n=== HTTP Response Information ===")
+		log.Debugf("Status Code: %d", resp.StatusCode)
+		log.Debugf("Status: %s", resp.Status)
+		log.Debugf("Headers: %v", RedactHeaders(resp.Header))
+	}
 
-	// Log response body for debugging
+	// Read the response body, logging it for debugging
 	if resp.Body != nil {
-		bodyBytes, err := io.ReadAll(resp.Body)
-		if err == nil {
-			log.Debugf("Response Body: %s", string(bodyBytes))
+		resp.Body = newLimitedBody(resp.Body, limit, request.URL.Path)
+		if !buffer {
+			if logBodies {
+				log.Debugf("Response Body: (streamed, not logged)")
+			}
+		} else if bodyBytes, err := io.ReadAll(resp.Body); err == nil {
+			if logBodies {
+				log.Debugf("Response Body: %s", RedactText(string(bodyBytes)))
+			}
 			// Restore the body
+			resp.Body.Close()
 			resp.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 		} else {
+			resp.Body.Close()
+			var tooLarge *ResponseTooLargeError
+			if errors.As(err, &tooLarge) {
+				if trace != nil {
+					trace.finish(c.cfg.Timing, resp, err)
+				}
+				log.Debugf("Response Body: %v", err)
+				return nil, err
+			}
 			log.Debugf("Response Body: Error reading body - %v", err)
 		}
-	} else {
+	} else if logBodies {
 		log.Debugf("Response Body: None")
 	}
 
-	log.Debugf("=" + strings.Repeat("=", 49))
+	// Total time includes reading the body
+	if trace != nil {
+		trace.finish(c.cfg.Timing, resp, nil)
+	}
+
+	if logHeaders {
+		log.Debugf("=" + strings.Repeat("=", 49))
+	}
 
 	if c.cfg.Debug {
-		dump, err := httputil.DumpResponse(resp, true)
+		// Streamed bodies are left unread for the caller
+		dump, err := httputil.DumpResponse(resp, buffer)
 		if err != nil {
 			return resp, err
 		}
-		log.Debugf("\n%s\n", string(dump))
+		log.Debugf("\n%s\n", RedactText(string(dump)))
 	}
 	return resp, err
 }
@@ -219,6 +309,9 @@ func (c *APIClient) prepareRequest(
 	for header, value := range c.cfg.DefaultHeader {
 		localVarRequest.Header.Add(header, value)
 	}
+
+	// Mutations carry an idempotency key so that retries cannot repeat them
+	setIdempotencyKey(localVarRequest)
 	return localVarRequest, nil
 }
 
@@ -0,0 +1,61 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DefaultClockSkewThreshold is the difference between the local clock and the server clock
+// above which presigned upload URLs may be rejected with SignatureDoesNotMatch
+const DefaultClockSkewThreshold = 2 * time.Minute
+
+// ClockSkewFromResponse estimates how far the local clock is ahead of the server clock
+// (negative when it is behind) from the response Date header. sent and received are the
+// local times the request was sent and the response arrived; the server time is compared
+// with their midpoint. The second return value is false when the response has no valid Date.
+func ClockSkewFromResponse(resp *http.Response, sent, received time.Time) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return 0, false
+	}
+	local := sent.Add(received.Sub(sent) / 2)
+	// The Date header has a resolution of one second
+	return local.Sub(serverTime).Round(time.Second), true
+}
+
+// ClockSkewExceeds reports whether skew is larger than threshold in either direction
+func ClockSkewExceeds(skew, threshold time.Duration) bool {
+	return skew > threshold || skew < -threshold
+}
+
+// DescribeClockSkew renders a skew as "5m0s ahead of" or "30s behind" the server
+func DescribeClockSkew(skew time.Duration) string {
+	if skew < 0 {
+		return fmt.Sprintf("%v behind", -skew)
+	}
+	return fmt.Sprintf("%v ahead of", skew)
+}
+
+// recordClockSkew remembers the skew measured from the latest API response
+func (c *APIClient) recordClockSkew(resp *http.Response, sent, received time.Time) {
+	if skew, ok := ClockSkewFromResponse(resp, sent, received); ok {
+		c.clockSkew.Store(int64(skew))
+		c.clockSkewKnown.Store(true)
+	}
+}
+
+// ClockSkew returns how far the local clock was ahead of the server clock in the latest
+// API response. The second return value is false until a response with a Date header arrived.
+func (c *APIClient) ClockSkew() (time.Duration, bool) {
+	if !c.clockSkewKnown.Load() {
+		return 0, false
+	}
+	return time.Duration(c.clockSkew.Load()), true
+}
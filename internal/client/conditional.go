@@ -0,0 +1,44 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"net/http"
+)
+
+// CacheValidators are the caching headers of a response. Sent back with the next
+// request for the same resource, they let the server answer 304 Not Modified instead
+// of repeating a body that has not changed. Backends that send neither header get
+// unconditional requests.
+type CacheValidators struct {
+	ETag         string
+	LastModified string
+}
+
+// CacheValidatorsFrom returns the validators of resp, zero if it has none
+func CacheValidatorsFrom(resp *http.Response) CacheValidators {
+	if resp == nil {
+		return CacheValidators{}
+	}
+	return CacheValidators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+}
+
+// IsZero reports whether there is nothing to make a request conditional with
+func (v CacheValidators) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// setConditionalHeaders makes a request conditional on the validators. The ETag is
+// preferred, as servers ignore If-Modified-Since when If-None-Match is present.
+func (v CacheValidators) setConditionalHeaders(headers map[string]string) {
+	switch {
+	case v.ETag != "":
+		headers["If-None-Match"] = v.ETag
+	case v.LastModified != "":
+		headers["If-Modified-Since"] = v.LastModified
+	}
+}
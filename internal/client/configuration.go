@@ -8,6 +8,9 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
+
+	"github.com/agbcloud/agbcloud-cli/pkg/events"
 )
 
 // contextKeys are used to identify the type of value in the context.
@@ -52,8 +55,23 @@ type Configuration struct {
 	DefaultHeader map[string]string `json:"defaultHeader,omitempty"`
 	UserAgent     string            `json:"userAgent,omitempty"`
 	Debug         bool              `json:"debug,omitempty"`
+	// TokenExchange selects how OAuth tokens are sent to the server (see TokenExchangeMode)
+	TokenExchange TokenExchangeMode `json:"tokenExchange,omitempty"`
 	Servers       ServerConfigurations
-	HTTPClient    *http.Client
+	// ServerStrategy selects the order in which Servers are tried (see ServerStrategy)
+	ServerStrategy ServerStrategy `json:"serverStrategy,omitempty"`
+	// ServerCooldown is how long a failed server is skipped (0 uses DefaultServerCooldown)
+	ServerCooldown time.Duration `json:"serverCooldown,omitempty"`
+	HTTPClient     *http.Client
+	// Timing, when set, records DNS/connect/TLS/TTFB/total durations for every API call
+	Timing *TimingRecorder `json:"-"`
+	// EventSink, when set, receives an event when an API call starts, finishes or is retried
+	EventSink events.Sink `json:"-"`
+	// MaxResponseBytes limits regular response bodies (0 uses DefaultMaxResponseBytes)
+	MaxResponseBytes int64 `json:"maxResponseBytes,omitempty"`
+	// MaxLargeResponseBytes limits endpoints known to return large payloads such as logs
+	// (0 uses DefaultMaxLargeResponseBytes, or MaxResponseBytes if that is larger)
+	MaxLargeResponseBytes int64 `json:"maxLargeResponseBytes,omitempty"`
 }
 
 // NewConfiguration returns a new Configuration object
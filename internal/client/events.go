@@ -0,0 +1,55 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/agbcloud/agbcloud-cli/pkg/events"
+)
+
+// redactedError hides secrets in the message of an error passed to event sinks,
+// which often write to logs, while keeping the original for errors.Is and errors.As
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string { return RedactText(e.err.Error()) }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// redactError returns err with secrets hidden from its message
+func redactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &redactedError{err: err}
+}
+
+// emitRequestStarted reports an API call about to be sent and returns its start time
+func (c *APIClient) emitRequestStarted(request *http.Request) time.Time {
+	started := time.Now()
+	events.Emit(request.Context(), c.cfg.EventSink, events.Event{
+		Kind:   events.RequestStarted,
+		Time:   started,
+		Method: request.Method,
+		Path:   request.URL.Path,
+	})
+	return started
+}
+
+// emitRequestFinished reports the outcome of an API call, including all its retries
+func (c *APIClient) emitRequestFinished(request *http.Request, started time.Time, resp *http.Response, err error) {
+	event := events.Event{
+		Kind:     events.RequestFinished,
+		Method:   request.Method,
+		Path:     request.URL.Path,
+		Duration: time.Since(started),
+		Err:      redactError(err),
+	}
+	if resp != nil {
+		event.StatusCode = resp.StatusCode
+	}
+	events.Emit(request.Context(), c.cfg.EventSink, event)
+}
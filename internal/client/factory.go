@@ -9,7 +9,10 @@ import (
 	"os"
 	"time"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/pkg/events"
 )
 
 // retryTransport implements http.RoundTripper to integrate retry logic
@@ -38,8 +41,40 @@ func shouldSkipSSLVerification() bool {
 func NewFromConfig(cfg *config.Config) *APIClient {
 	configuration := NewConfiguration()
 
-	// Set the server URL from environment variable or default
-	configuration.Servers[0].URL = config.GetEndpoint()
+	// Set the server URLs from environment variable, configuration or default.
+	// Additional endpoints are fallbacks used when the primary one is unavailable.
+	for i, endpoint := range config.GetEndpoints() {
+		if i == 0 {
+			configuration.Servers[0].URL = endpoint
+			continue
+		}
+		configuration.Servers = append(configuration.Servers, ServerConfiguration{
+			URL:         endpoint,
+			Description: "AgbCloud API Server (fallback)",
+		})
+	}
+
+	strategy, err := ParseServerStrategy(config.GetEndpointStrategy())
+	if err != nil {
+		log.Warnf("%v; using %s", err, strategy)
+	}
+	configuration.ServerStrategy = strategy
+
+	// Select how OAuth tokens are exchanged (auto, post or query)
+	mode, err := ParseTokenExchangeMode(os.Getenv("AGB_CLI_TOKEN_EXCHANGE"))
+	if err != nil {
+		log.Warnf("%v; using %s", err, mode)
+	}
+	configuration.TokenExchange = mode
+
+	// Allow larger responses than the built-in limits when explicitly requested
+	if value := os.Getenv("AGB_CLI_MAX_RESPONSE_SIZE"); value != "" {
+		if size, err := ParseByteSize(value); err != nil {
+			log.Warnf("AGB_CLI_MAX_RESPONSE_SIZE: %v; using the default limit of %s", err, FormatByteSize(DefaultMaxResponseBytes))
+		} else {
+			configuration.MaxResponseBytes = size
+		}
+	}
 
 	// Create base HTTP client with optional SSL verification skip
 	baseClient := &http.Client{
@@ -55,8 +90,26 @@ func NewFromConfig(cfg *config.Config) *APIClient {
 		}
 	}
 
+	// Pinned hosts must present a pinned public key, whether or not the chain is verified
+	if len(cfg.TLSPins) > 0 {
+		transport, ok := baseClient.Transport.(*http.Transport)
+		if !ok {
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		}
+		baseClient.Transport = &pinnedTransport{base: transport, pinsFor: cfg.PinnedKeys}
+	}
+
+	// Fail a share of the requests on purpose when AGB_CLI_FAULT_INJECT is set, below
+	// the retries and the circuit breaker so that they handle the injected faults
+	if injector := faultInjectorFromEnv(); injector != nil {
+		injector.Base = baseClient.Transport
+		baseClient.Transport = injector
+	}
+
 	// Wrap with retry functionality
 	retryClient := NewRetryableHTTPClient(baseClient, DefaultRetryConfig())
+	retryClient.SetCircuitBreaker(DefaultCircuitBreaker())
+	retryClient.SetEventSink(events.Default())
 
 	// Create a wrapper that implements http.Client interface
 	configuration.HTTPClient = &http.Client{
@@ -66,6 +119,12 @@ func NewFromConfig(cfg *config.Config) *APIClient {
 		},
 	}
 
+	// Record per-call timings when --timing is enabled
+	configuration.Timing = DefaultTimingRecorder()
+
+	// Report requests and retries to the sinks registered with events.Register
+	configuration.EventSink = events.Default()
+
 	return NewAPIClient(configuration)
 }
 
@@ -0,0 +1,263 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ServerStrategy controls the order in which configured servers are tried
+type ServerStrategy string
+
+const (
+	// ServerStrategyPriority always prefers the first healthy server in configuration order (default)
+	ServerStrategyPriority ServerStrategy = "priority"
+	// ServerStrategyRoundRobin spreads requests over all healthy servers
+	ServerStrategyRoundRobin ServerStrategy = "round-robin"
+)
+
+// DefaultServerCooldown is how long a failed server is skipped before it is tried again
+const DefaultServerCooldown = 30 * time.Second
+
+// ParseServerStrategy converts a user supplied value into a ServerStrategy.
+// An empty value selects ServerStrategyPriority.
+func ParseServerStrategy(value string) (ServerStrategy, error) {
+	switch strategy := ServerStrategy(strings.ToLower(strings.TrimSpace(value))); strategy {
+	case "":
+		return ServerStrategyPriority, nil
+	case ServerStrategyPriority, ServerStrategyRoundRobin:
+		return strategy, nil
+	default:
+		return ServerStrategyPriority, fmt.Errorf("unsupported endpoint strategy '%s' (supported: priority, round-robin)", value)
+	}
+}
+
+// serverPool tracks the health of the configured servers for one APIClient
+type serverPool struct {
+	mu             sync.Mutex
+	next           int               // Round-robin position
+	unhealthyUntil map[int]time.Time // Servers skipped until the given time after a failure
+}
+
+// order returns the server indexes in the order they should be tried: healthy servers
+// by strategy, followed by servers still cooling down, soonest recovery first
+func (p *serverPool) order(count int, strategy ServerStrategy, now time.Time) []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	start := 0
+	if strategy == ServerStrategyRoundRobin {
+		start = p.next % count
+		p.next++
+	}
+
+	var healthy, cooling []int
+	for offset := 0; offset < count; offset++ {
+		index := (start + offset) % count
+		if until, ok := p.unhealthyUntil[index]; ok && now.Before(until) {
+			cooling = append(cooling, index)
+			continue
+		}
+		healthy = append(healthy, index)
+	}
+	sort.SliceStable(cooling, func(a, b int) bool {
+		return p.unhealthyUntil[cooling[a]].Before(p.unhealthyUntil[cooling[b]])
+	})
+	return append(healthy, cooling...)
+}
+
+// markFailed skips the server until the cooldown has passed
+func (p *serverPool) markFailed(index int, until time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.unhealthyUntil == nil {
+		p.unhealthyUntil = make(map[int]time.Time)
+	}
+	p.unhealthyUntil[index] = until
+}
+
+// markHealthy makes the server eligible again immediately
+func (p *serverPool) markHealthy(index int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.unhealthyUntil, index)
+}
+
+// serverCooldown returns the configured cooldown or the default
+func (c *Configuration) serverCooldown() time.Duration {
+	if c.ServerCooldown > 0 {
+		return c.ServerCooldown
+	}
+	return DefaultServerCooldown
+}
+
+// requestServer returns the index and URL of the configured server the request was built for
+func (c *APIClient) requestServer(request *http.Request) (int, string, bool) {
+	requestURL := request.URL.String()
+	for index := range c.cfg.Servers {
+		serverURL, err := c.cfg.Servers.URL(index, nil)
+		if err == nil && serverURL != "" && strings.HasPrefix(requestURL, serverURL) {
+			return index, serverURL, true
+		}
+	}
+	return 0, "", false
+}
+
+// doWithFailover sends the request to the healthiest configured server and moves on to
+// the next one when a server cannot be reached. Requests pinned to a server with
+// ContextServerIndex, and clients with a single server, are sent as they are.
+func (c *APIClient) doWithFailover(request *http.Request, httpClient *http.Client) (*http.Response, error) {
+	origin, originURL, ok := c.requestServer(request)
+	if !ok || len(c.cfg.Servers) < 2 || request.Context().Value(ContextServerIndex) != nil {
+		return httpClient.Do(request)
+	}
+
+	candidates := c.servers.order(len(c.cfg.Servers), c.cfg.ServerStrategy, time.Now())
+	var lastErr error
+	for attempt, index := range candidates {
+		serverRequest, err := c.requestForServer(request, origin, originURL, index, attempt > 0)
+		if err != nil {
+			// The body cannot be replayed, so no other server can be tried
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, err
+		}
+
+		resp, err := httpClient.Do(serverRequest)
+		last := attempt+1 == len(candidates)
+		if err == nil && !isServerUnavailable(resp.StatusCode) {
+			c.servers.markHealthy(index)
+			return resp, nil
+		}
+		if request.Context().Err() != nil {
+			return resp, err
+		}
+		// A pin mismatch may be an interception attempt; report it instead of failing over
+		var pinErr *PinMismatchError
+		if errors.As(err, &pinErr) {
+			return nil, err
+		}
+
+		c.servers.markFailed(index, time.Now().Add(c.cfg.serverCooldown()))
+		if err == nil {
+			if last {
+				return resp, nil
+			}
+			resp.Body.Close()
+			err = fmt.Errorf("HTTP %d", resp.StatusCode)
+		}
+		lastErr = err
+		if !last {
+			next, _ := c.cfg.Servers.URL(candidates[attempt+1], nil)
+			log.Infof("[FAILOVER] %s is unavailable (%s), trying %s", serverRequest.URL.Host, RedactText(err.Error()), next)
+		}
+	}
+	return nil, lastErr
+}
+
+// isServerUnavailable reports whether a status code means the server, rather than the
+// request, is at fault and another server may succeed
+func isServerUnavailable(statusCode int) bool {
+	switch statusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// requestForServer returns the request retargeted from the origin server to the server at
+// index. replay must be set for every attempt after the first so the body is sent again.
+func (c *APIClient) requestForServer(request *http.Request, origin int, originURL string, index int, replay bool) (*http.Request, error) {
+	if index == origin && !replay {
+		return request, nil
+	}
+	serverURL, err := c.cfg.Servers.URL(index, nil)
+	if err != nil {
+		return nil, err
+	}
+	target, err := url.Parse(serverURL + strings.TrimPrefix(request.URL.String(), originURL))
+	if err != nil {
+		return nil, err
+	}
+
+	serverRequest := request.Clone(request.Context())
+	serverRequest.URL = target
+	serverRequest.Host = ""
+	if replay && request.Body != nil && request.Body != http.NoBody {
+		if request.GetBody == nil {
+			return nil, fmt.Errorf("request body cannot be resent to %s", target.Host)
+		}
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		serverRequest.Body = body
+	}
+	return serverRequest, nil
+}
+
+// ServerStatus is the result of probing one configured server
+type ServerStatus struct {
+	URL       string
+	Reachable bool
+	Latency   time.Duration
+	Err       error
+	// ClockSkew is how far the local clock is ahead of the server's, when ClockSkewKnown
+	ClockSkew      time.Duration
+	ClockSkewKnown bool
+}
+
+// ProbeServers checks whether every configured server can be reached. Any HTTP response
+// counts as reachable; only connection-level failures mark a server as down.
+func (c *APIClient) ProbeServers(ctx context.Context, timeout time.Duration) []ServerStatus {
+	probeClient := *c.cfg.HTTPClient
+	probeClient.Timeout = timeout
+
+	statuses := make([]ServerStatus, len(c.cfg.Servers))
+	var wg sync.WaitGroup
+	for index := range c.cfg.Servers {
+		serverURL, err := c.cfg.Servers.URL(index, nil)
+		statuses[index] = ServerStatus{URL: serverURL, Err: err}
+		if err != nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(index int, serverURL string) {
+			defer wg.Done()
+			request, err := http.NewRequestWithContext(ctx, http.MethodGet, serverURL, nil)
+			if err != nil {
+				statuses[index].Err = err
+				return
+			}
+			request.Header.Set("User-Agent", c.cfg.UserAgent)
+
+			started := time.Now()
+			resp, err := probeClient.Do(request)
+			statuses[index].Latency = time.Since(started)
+			if err != nil {
+				statuses[index].Err = err
+				c.servers.markFailed(index, time.Now().Add(c.cfg.serverCooldown()))
+				return
+			}
+			resp.Body.Close()
+			statuses[index].Reachable = true
+			statuses[index].ClockSkew, statuses[index].ClockSkewKnown = ClockSkewFromResponse(resp, started, time.Now())
+			c.servers.markHealthy(index)
+		}(index, serverURL)
+	}
+	wg.Wait()
+	return statuses
+}
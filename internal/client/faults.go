@@ -0,0 +1,195 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// FaultInjectEnv turns on fault injection, e.g. AGB_CLI_FAULT_INJECT=20%,5xx,seed=7.
+// It is a development aid for checking retries, the circuit breaker and polling
+// against the mock backend, and is not meant for production use.
+const FaultInjectEnv = "AGB_CLI_FAULT_INJECT"
+
+// Fault kinds that can be injected
+const (
+	FaultServerError = "5xx"
+	FaultTimeout     = "timeout"
+)
+
+// injectedStatuses are the server errors returned by injected 5xx faults
+var injectedStatuses = []int{
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// FaultTimeoutError is returned for an injected timeout. It behaves like a network
+// timeout, so it is retried and reported as transient.
+type FaultTimeoutError struct {
+	Method string
+	URL    string
+}
+
+func (e *FaultTimeoutError) Error() string {
+	return fmt.Sprintf("%s %s: injected fault: i/o timeout", e.Method, e.URL)
+}
+
+// Timeout implements net.Error
+func (e *FaultTimeoutError) Timeout() bool { return true }
+
+// Temporary implements net.Error
+func (e *FaultTimeoutError) Temporary() bool { return true }
+
+// FaultInjector is an http.RoundTripper that fails a share of the requests with a
+// server error or a timeout before they reach the server. It is safe for concurrent use.
+type FaultInjector struct {
+	// Rate is the share of requests that fail, between 0 and 1
+	Rate float64
+	// Kinds are the faults to pick from (FaultServerError, FaultTimeout)
+	Kinds []string
+	// Base sends the requests that are let through; http.DefaultTransport when nil
+	Base http.RoundTripper
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// ParseFaultInjection parses a fault injection setting: comma-separated items, each
+// a rate ("0.2" or "20%"), a fault kind ("5xx" or "timeout") or a random seed
+// ("seed=7"). Both kinds are injected when none is given. An empty value returns nil.
+func ParseFaultInjection(value string) (*FaultInjector, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	injector := &FaultInjector{Rate: -1}
+	var seed int64
+	seeded := false
+	for _, item := range strings.Split(value, ",") {
+		item = strings.ToLower(strings.TrimSpace(item))
+		switch {
+		case item == FaultServerError || item == FaultTimeout:
+			injector.Kinds = append(injector.Kinds, item)
+		case strings.HasPrefix(item, "seed="):
+			n, err := strconv.ParseInt(strings.TrimPrefix(item, "seed="), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid fault injection seed %q", item)
+			}
+			seed, seeded = n, true
+		default:
+			rate, err := parseFaultRate(item)
+			if err != nil {
+				return nil, err
+			}
+			injector.Rate = rate
+		}
+	}
+	if injector.Rate < 0 {
+		return nil, fmt.Errorf("fault injection setting %q has no rate, e.g. 0.2 or 20%%", value)
+	}
+	if len(injector.Kinds) == 0 {
+		injector.Kinds = []string{FaultServerError, FaultTimeout}
+	}
+	if !seeded {
+		seed = rand.Int63()
+	}
+	injector.rnd = rand.New(rand.NewSource(seed))
+	return injector, nil
+}
+
+// parseFaultRate parses a rate such as 0.2 or 20%
+func parseFaultRate(value string) (float64, error) {
+	percent := strings.HasSuffix(value, "%")
+	rate, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid fault injection item %q: expected a rate, 5xx, timeout or seed=N", value)
+	}
+	if percent {
+		rate /= 100
+	}
+	if rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("fault injection rate %q must be between 0 and 1 (or 0%% and 100%%)", value)
+	}
+	return rate, nil
+}
+
+// pick decides whether a request fails and with which fault; "" lets it through
+func (f *FaultInjector) pick() (string, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.rnd == nil {
+		f.rnd = rand.New(rand.NewSource(rand.Int63()))
+	}
+	if f.rnd.Float64() >= f.Rate || len(f.Kinds) == 0 {
+		return "", 0
+	}
+	kind := f.Kinds[f.rnd.Intn(len(f.Kinds))]
+	return kind, injectedStatuses[f.rnd.Intn(len(injectedStatuses))]
+}
+
+// RoundTrip implements http.RoundTripper
+func (f *FaultInjector) RoundTrip(req *http.Request) (*http.Response, error) {
+	kind, status := f.pick()
+	switch kind {
+	case FaultTimeout:
+		log.Debugf("[FAULT] Injecting a timeout for %s %s", req.Method, req.URL.Path)
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, &FaultTimeoutError{Method: req.Method, URL: RedactText(req.URL.String())}
+	case FaultServerError:
+		log.Debugf("[FAULT] Injecting HTTP %d for %s %s", status, req.Method, req.URL.Path)
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		body := fmt.Sprintf(`{"success":false,"code":"InjectedFault","message":"injected HTTP %d"}`, status)
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			StatusCode:    status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(bytes.NewBufferString(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	base := f.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
+
+// faultWarning makes sure the fault injection warning is printed once per process
+var faultWarning sync.Once
+
+// faultInjectorFromEnv returns the fault injector configured with AGB_CLI_FAULT_INJECT,
+// or nil when it is not set or invalid
+func faultInjectorFromEnv() *FaultInjector {
+	injector, err := ParseFaultInjection(os.Getenv(FaultInjectEnv))
+	if err != nil {
+		log.Warnf("%s: %v; fault injection is off", FaultInjectEnv, err)
+		return nil
+	}
+	if injector != nil {
+		faultWarning.Do(func() {
+			log.Warnf("[FAULT] %s is set: failing %.0f%% of requests with %s", FaultInjectEnv, injector.Rate*100, strings.Join(injector.Kinds, "/"))
+		})
+	}
+	return injector
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+// IdempotencyKeyHeader carries the key that lets the server recognize a mutating
+// request it has already processed, so that re-sending it does not repeat the action
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// NewIdempotencyKey returns a random key in UUID format
+func NewIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])  // crypto/rand does not fail on supported platforms
+	b[6] = b[6]&0x0f | 0x40 // Version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// setIdempotencyKey gives a POST request a new idempotency key unless it has one. The
+// key is part of the request, so every retry and failover attempt sends the same key.
+func setIdempotencyKey(request *http.Request) {
+	if request.Method == http.MethodPost && request.Header.Get(IdempotencyKeyHeader) == "" {
+		request.Header.Set(IdempotencyKeyHeader, NewIdempotencyKey())
+	}
+}
+
+// isReplayable reports whether a request may be sent again after an attempt whose
+// outcome is unknown: requests with an idempotent method, and requests carrying an
+// idempotency key
+func isReplayable(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return true
+	}
+	return request.Header.Get(IdempotencyKeyHeader) != ""
+}
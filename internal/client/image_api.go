@@ -10,16 +10,35 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 )
 
 // ImageAPI interface for image related operations
 type ImageAPI interface {
 	GetUploadCredential(ctx context.Context, loginToken, sessionId string) (ImageUploadCredentialResponse, *http.Response, error)
-	CreateImage(ctx context.Context, loginToken, sessionId, imageName, taskId, sourceImageId string) (ImageCreateResponse, *http.Response, error)
+	CreateImage(ctx context.Context, loginToken, sessionId, imageName, taskId, sourceImageId string, opts ImageCreateOptions) (ImageCreateResponse, *http.Response, error)
 	GetImageTask(ctx context.Context, loginToken, sessionId, taskId string) (ImageTaskResponse, *http.Response, error)
-	ListImages(ctx context.Context, loginToken, sessionId, imageType string, page, pageSize int, imageIds []string) (ImageListResponse, *http.Response, error)
-	StartImage(ctx context.Context, loginToken, sessionId, imageId string, cpu, memory int) (ImageStartResponse, *http.Response, error)
-	StopImage(ctx context.Context, loginToken, sessionId, imageId string) (ImageStopResponse, *http.Response, error)
+	ListImages(ctx context.Context, loginToken, sessionId string, opts ImageListOptions) (ImageListResponse, *http.Response, error)
+	StartImage(ctx context.Context, loginToken, sessionId, imageId string, cpu, memory int, fastStart bool) (ImageStartResponse, *http.Response, error)
+	StopImage(ctx context.Context, loginToken, sessionId, imageId string, opts ImageStopOptions) (ImageStopResponse, *http.Response, error)
+	RestartInstance(ctx context.Context, loginToken, sessionId, imageId string) (ImageRestartResponse, *http.Response, error)
+	SnapshotInstance(ctx context.Context, loginToken, sessionId, imageId, imageName string) (ImageSnapshotResponse, *http.Response, error)
+	UpdateImage(ctx context.Context, loginToken, sessionId, imageId string, defaults ImageActivationDefaults) (ImageUpdateResponse, *http.Response, error)
+	DeleteImage(ctx context.Context, loginToken, sessionId, imageId string) (ImageDeleteResponse, *http.Response, error)
+	GetInstanceLogs(ctx context.Context, loginToken, sessionId string, opts InstanceLogOptions) (InstanceLogsResponse, *http.Response, error)
+	StreamInstanceLogs(ctx context.Context, loginToken, sessionId string, opts InstanceLogOptions, handler func(InstanceLogLine) error) error
+	GetBaseImageVersions(ctx context.Context, loginToken, sessionId string, imageIds []string) (BaseImageVersionsResponse, *http.Response, error)
+	GetImageUsage(ctx context.Context, loginToken, sessionId string, imageIds []string) (ImageUsageResponse, *http.Response, error)
+	DeleteImageTask(ctx context.Context, loginToken, sessionId, taskId string) (ImageTaskDeleteResponse, *http.Response, error)
+	ListImageTasks(ctx context.Context, loginToken, sessionId string, opts ImageTaskListOptions) (ImageTaskListResponse, *http.Response, error)
+	GetImageQueue(ctx context.Context, loginToken, sessionId, imageId string) (ImageQueueResponse, *http.Response, error)
+	GetImageCapabilities(ctx context.Context, loginToken, sessionId string) (ImageCapabilitiesResponse, *http.Response, error)
+	ReserveImageCapacity(ctx context.Context, loginToken, sessionId, taskId string, cpu, memory int) (ImageReservationResponse, *http.Response, error)
+	GetImageReservation(ctx context.Context, loginToken, sessionId, imageId string) (ImageReservationResponse, *http.Response, error)
+	ReleaseImageReservation(ctx context.Context, loginToken, sessionId, reservationId string) (ImageReservationResponse, *http.Response, error)
+	GetImageManifest(ctx context.Context, loginToken, sessionId, imageId string) (ImageManifestResponse, *http.Response, error)
+	ValidateCreate(ctx context.Context, loginToken, sessionId string, opts ImageValidateOptions) (ImageValidateResponse, *http.Response, error)
 }
 
 // ImageAPIService implements ImageAPI interface
@@ -38,8 +57,74 @@ type ImageUploadCredentialResponse struct {
 // ImageUploadCredentialData represents the data field in image upload credential response
 // This structure matches the actual API response structure
 type ImageUploadCredentialData struct {
-	OssURL string `json:"ossUrl"`
-	TaskID string `json:"taskId"`
+	OssURL     string `json:"ossUrl"`
+	TaskID     string `json:"taskId"`
+	ExpireTime string `json:"expireTime,omitempty"` // RFC3339, omitted by older backends
+
+	// StorageType names the upload protocol; older backends omit it and expect a
+	// presigned PUT to OssURL. See the Storage* constants.
+	StorageType string `json:"storageType,omitempty"`
+	// PartURLs are the presigned UploadPart URLs of an s3-multipart upload, in part order
+	PartURLs []string `json:"partUrls,omitempty"`
+	// PartSize is the size of every part but the last of an s3-multipart upload
+	PartSize int64 `json:"partSize,omitempty"`
+	// CompleteURL is the presigned CompleteMultipartUpload URL of an s3-multipart upload
+	CompleteURL string `json:"completeUrl,omitempty"`
+}
+
+// Storage types announced in ImageUploadCredentialData.StorageType
+const (
+	// StoragePresignedPut uploads with a single PUT to a presigned Alibaba OSS or S3 URL
+	StoragePresignedPut = "presigned-put"
+	// StorageS3Multipart uploads the parts to presigned URLs, then completes the upload
+	StorageS3Multipart = "s3-multipart"
+	// StorageAzureBlob uploads a block blob to an Azure SAS URL
+	StorageAzureBlob = "azure-blob"
+)
+
+// ExpiresAt returns when the upload URL stops being valid.
+// The server-provided expireTime is preferred; otherwise the expiry is derived from
+// the presigned URL itself (OSS "Expires" or S3 "X-Amz-Date"/"X-Amz-Expires").
+// The second return value is false when the expiry is unknown.
+func (d ImageUploadCredentialData) ExpiresAt() (time.Time, bool) {
+	if d.ExpireTime != "" {
+		if t, err := time.Parse(time.RFC3339, d.ExpireTime); err == nil {
+			return t, true
+		}
+	}
+
+	signedURL := d.OssURL
+	if signedURL == "" {
+		// Multipart uploads have no single upload URL
+		signedURL = d.CompleteURL
+	}
+	u, err := url.Parse(signedURL)
+	if err != nil {
+		return time.Time{}, false
+	}
+	query := u.Query()
+
+	// Alibaba Cloud OSS V1 signatures carry an absolute Unix timestamp
+	if expires := query.Get("Expires"); expires != "" {
+		if seconds, err := strconv.ParseInt(expires, 10, 64); err == nil {
+			return time.Unix(seconds, 0), true
+		}
+	}
+
+	// S3 / OSS V4 signatures carry a signing time plus a validity in seconds
+	signedAt, validFor := query.Get("X-Amz-Date"), query.Get("X-Amz-Expires")
+	if signedAt == "" {
+		signedAt, validFor = query.Get("x-oss-date"), query.Get("x-oss-expires")
+	}
+	if signedAt != "" && validFor != "" {
+		start, err := time.Parse("20060102T150405Z", signedAt)
+		seconds, convErr := strconv.Atoi(validFor)
+		if err == nil && convErr == nil {
+			return start.Add(time.Duration(seconds) * time.Second), true
+		}
+	}
+
+	return time.Time{}, false
 }
 
 // ImageCreateResponse represents the response from /api/image/create API
@@ -68,6 +153,30 @@ type ImageTaskData struct {
 	Status  string  `json:"status"`
 	TaskMsg string  `json:"taskMsg"`
 	ImageID *string `json:"imageId"`
+	// Digest is the content digest of the image once the build has finished
+	Digest string `json:"digest,omitempty"`
+	// Platforms reports the build of each platform of a multi-platform image
+	Platforms []ImagePlatformBuild `json:"platforms,omitempty"`
+	// Webhook reports the delivery of the callback registered with the build, if any
+	Webhook *ImageTaskWebhook `json:"webhook,omitempty"`
+}
+
+// ImageTaskWebhook is the callback the server calls when a build finishes or fails
+type ImageTaskWebhook struct {
+	URL string `json:"url"`
+	// Status is Pending until the build has ended, then Delivered or Failed
+	Status           string `json:"status"`
+	Attempts         int    `json:"attempts,omitempty"`
+	LastAttemptTime  string `json:"lastAttemptTime,omitempty"`
+	LastResponseCode int    `json:"lastResponseCode,omitempty"`
+	LastError        string `json:"lastError,omitempty"`
+}
+
+// ImagePlatformBuild is the build status of one platform of a multi-platform image
+type ImagePlatformBuild struct {
+	Platform string `json:"platform"`
+	Status   string `json:"status"`
+	TaskMsg  string `json:"taskMsg,omitempty"`
 }
 
 // ImageCreateData represents the data field in image create response
@@ -86,6 +195,12 @@ type ImageListResponse struct {
 	Data           ImageListData `json:"data"`
 	TraceID        string        `json:"traceId"`
 	HTTPStatusCode int           `json:"httpStatusCode"`
+
+	// NotModified is true when the request was conditional and the server answered
+	// 304 Not Modified: Data is empty and the list of the earlier response still applies
+	NotModified bool `json:"-"`
+	// Validators are the caching headers of the response, for the next request
+	Validators CacheValidators `json:"-"`
 }
 
 // ImageListData represents the data field in image list response
@@ -109,6 +224,24 @@ type ImageInfo struct {
 	LastUsedTime *string `json:"lastUsedTime"` // Can be null
 	CPU          *int    `json:"cpu"`          // Can be null
 	Memory       *int    `json:"memory"`       // Can be null, in GB
+	// SourceImageID is the System image a User image was built from (empty for System images)
+	SourceImageID string `json:"sourceImageId,omitempty"`
+	// SourceImageVersion is the version of the source image at build time
+	SourceImageVersion string `json:"sourceImageVersion,omitempty"`
+	// Version is the current version of a System image
+	Version string `json:"version,omitempty"`
+	// Digest is the content digest of the built image (sha256:<hex>), when the backend reports it
+	Digest string `json:"digest,omitempty"`
+	// WarmInstances is the number of warm instances ready for a fast start; nil when the
+	// backend does not report warm capacity
+	WarmInstances *int `json:"warmInstances,omitempty"`
+	// ActivationDefaults are the settings stored with 'image set-defaults'; nil when none are set
+	ActivationDefaults *ImageActivationDefaults `json:"activationDefaults,omitempty"`
+	// ActiveHours is the total time the image has been activated; nil when its usage is unknown
+	ActiveHours *float64 `json:"activeHours,omitempty"`
+	// ActivationCount is the number of times the image has been activated; nil when its
+	// usage is unknown
+	ActivationCount *int `json:"activationCount,omitempty"`
 }
 
 // ImageStartResponse represents the response from /api/image/start API
@@ -145,6 +278,16 @@ type ImageStopData struct {
 	Status     string `json:"status"`
 }
 
+// ImageDeleteResponse represents the response from /api/image/delete API
+type ImageDeleteResponse struct {
+	Code           string `json:"code"`
+	RequestID      string `json:"requestId"`
+	Success        bool   `json:"success"`
+	Data           bool   `json:"data"`
+	TraceID        string `json:"traceId"`
+	HTTPStatusCode int    `json:"httpStatusCode"`
+}
+
 // ImageStartRequest represents the request body for /api/image/start API
 type ImageStartRequest struct {
 	LoginToken string `json:"loginToken"`
@@ -152,10 +295,46 @@ type ImageStartRequest struct {
 	ImageId    string `json:"imageId"`
 	CPU        int    `json:"cpu,omitempty"`
 	Memory     int    `json:"memory,omitempty"`
+	// FastStart requests a warm instance, which starts faster and is billed at a higher rate
+	FastStart bool `json:"fastStart,omitempty"`
+}
+
+// NewImageStartRequest returns the body StartImage sends, e.g. to show it without
+// sending it
+func NewImageStartRequest(loginToken, sessionId, imageId string, cpu, memory int, fastStart bool) ImageStartRequest {
+	return ImageStartRequest{
+		LoginToken: loginToken,
+		SessionId:  sessionId,
+		ImageId:    imageId,
+		CPU:        cpu,
+		Memory:     memory,
+		FastStart:  fastStart,
+	}
 }
 
 // ImageStopRequest represents the request body for /api/image/stop API
 type ImageStopRequest struct {
+	LoginToken         string `json:"loginToken"`
+	SessionId          string `json:"sessionId"`
+	ImageId            string `json:"imageId"`
+	GracePeriodSeconds int    `json:"gracePeriodSeconds,omitempty"` // Time the workload is given to shut down; server default if omitted
+	Force              bool   `json:"force,omitempty"`              // Stop the instance at once, without a grace period
+}
+
+// NewImageStopRequest returns the body StopImage sends, e.g. to show it without
+// sending it
+func NewImageStopRequest(loginToken, sessionId, imageId string, opts ImageStopOptions) ImageStopRequest {
+	return ImageStopRequest{
+		LoginToken:         loginToken,
+		SessionId:          sessionId,
+		ImageId:            imageId,
+		GracePeriodSeconds: int(opts.GracePeriod / time.Second),
+		Force:              opts.Force,
+	}
+}
+
+// ImageDeleteRequest represents the request body for /api/image/delete API
+type ImageDeleteRequest struct {
 	LoginToken string `json:"loginToken"`
 	SessionId  string `json:"sessionId"`
 	ImageId    string `json:"imageId"`
@@ -223,20 +402,21 @@ func (i *ImageAPIService) GetUploadCredential(ctx context.Context, loginToken, s
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	err = i.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+// ImageCreateOptions controls how CreateImage builds an image
+type ImageCreateOptions struct {
+	// Platforms are the platforms (e.g. linux/arm64) the image is built for; the
+	// server's default platform if there are none
+	Platforms []string
+	// CallbackURL is a webhook the server calls when the build finishes or fails
+	CallbackURL string
 }
 
 // CreateImage creates a custom image using the uploaded dockerfile
-func (i *ImageAPIService) CreateImage(ctx context.Context, loginToken, sessionId, imageName, taskId, sourceImageId string) (ImageCreateResponse, *http.Response, error) {
+func (i *ImageAPIService) CreateImage(ctx context.Context, loginToken, sessionId, imageName, taskId, sourceImageId string, opts ImageCreateOptions) (ImageCreateResponse, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodPost
 		localVarReturnValue ImageCreateResponse
@@ -277,13 +457,20 @@ func (i *ImageAPIService) CreateImage(ctx context.Context, loginToken, sessionId
 	}
 
 	// Create request body
-	requestBody := map[string]string{
+	requestBody := map[string]interface{}{
 		"loginToken":    loginToken,
 		"sessionId":     sessionId,
 		"imageName":     imageName,
 		"taskId":        taskId,
 		"sourceImageId": sourceImageId,
 	}
+	if len(opts.Platforms) > 0 {
+		// Omitted otherwise, for servers that only build the default platform
+		requestBody["platforms"] = opts.Platforms
+	}
+	if opts.CallbackURL != "" {
+		requestBody["callbackUrl"] = opts.CallbackURL
+	}
 
 	// Prepare request
 	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
@@ -311,16 +498,8 @@ func (i *ImageAPIService) CreateImage(ctx context.Context, loginToken, sessionId
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	err = i.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
-
-	return localVarReturnValue, localVarHTTPResponse, nil
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
 }
 
 // GetImageTask retrieves the status of an image creation task
@@ -390,20 +569,25 @@ func (i *ImageAPIService) GetImageTask(ctx context.Context, loginToken, sessionI
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	err = i.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+// ImageListOptions selects which images ListImages returns
+type ImageListOptions struct {
+	ImageType string   // "User" or "System" (required)
+	Page      int      // 1-based page number (required)
+	PageSize  int      // Number of images per page (required)
+	ImageIds  []string // Only return these images
+	// NameContains asks the server to return only images whose name contains this text
+	NameContains string
+	// Validators of an earlier response to the same request make the request
+	// conditional; see ImageListResponse.NotModified
+	Validators CacheValidators
 }
 
-// ListImages retrieves a list of images with pagination, optionally filtered by image IDs
-func (i *ImageAPIService) ListImages(ctx context.Context, loginToken, sessionId, imageType string, page, pageSize int, imageIds []string) (ImageListResponse, *http.Response, error) {
+// ListImages retrieves a list of images with pagination, optionally filtered by image IDs or name
+func (i *ImageAPIService) ListImages(ctx context.Context, loginToken, sessionId string, opts ImageListOptions) (ImageListResponse, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
@@ -438,29 +622,37 @@ func (i *ImageAPIService) ListImages(ctx context.Context, loginToken, sessionId,
 	}
 	localVarQueryParams.Add("sessionId", sessionId)
 
-	if imageType == "" {
+	if opts.ImageType == "" {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: "imageType parameter is required"}
 	}
-	localVarQueryParams.Add("imageType", imageType)
+	localVarQueryParams.Add("imageType", opts.ImageType)
 
 	// Validate pagination parameters
-	if page <= 0 {
+	if opts.Page <= 0 {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: "page must be greater than 0"}
 	}
-	localVarQueryParams.Add("page", fmt.Sprintf("%d", page))
+	localVarQueryParams.Add("page", fmt.Sprintf("%d", opts.Page))
 
-	if pageSize <= 0 {
+	if opts.PageSize <= 0 {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: "pageSize must be greater than 0"}
 	}
-	localVarQueryParams.Add("pageSize", fmt.Sprintf("%d", pageSize))
+	localVarQueryParams.Add("pageSize", fmt.Sprintf("%d", opts.PageSize))
 
 	// Add imageIds parameter if provided
-	if len(imageIds) > 0 {
-		for _, imageId := range imageIds {
+	if len(opts.ImageIds) > 0 {
+		for _, imageId := range opts.ImageIds {
 			localVarQueryParams.Add("imageIds", imageId)
 		}
 	}
 
+	// Add name search parameter if provided
+	if opts.NameContains != "" {
+		localVarQueryParams.Add("imageName", opts.NameContains)
+	}
+
+	// Ask for the list only if it changed since the earlier response
+	opts.Validators.setConditionalHeaders(localVarHeaderParams)
+
 	// Prepare request
 	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams)
 	if err != nil {
@@ -479,28 +671,28 @@ func (i *ImageAPIService) ListImages(ctx context.Context, loginToken, sessionId,
 		return localVarReturnValue, localVarHTTPResponse, err
 	}
 
-	if localVarHTTPResponse.StatusCode >= 300 {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: localVarHTTPResponse.Status,
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
+	if localVarHTTPResponse.StatusCode == http.StatusNotModified && !opts.Validators.IsZero() {
+		localVarReturnValue.NotModified = true
+		localVarReturnValue.Validators = opts.Validators
+		return localVarReturnValue, localVarHTTPResponse, nil
 	}
 
-	err = i.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
+	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
-			error: err.Error(),
+			error: localVarHTTPResponse.Status,
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	localVarReturnValue.Validators = CacheValidatorsFrom(localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
 }
 
-// StartImage starts an image with specified resources
-func (i *ImageAPIService) StartImage(ctx context.Context, loginToken, sessionId, imageId string, cpu, memory int) (ImageStartResponse, *http.Response, error) {
+// StartImage starts an image with specified resources. With fastStart a warm instance
+// is requested, which starts faster at a higher cost.
+func (i *ImageAPIService) StartImage(ctx context.Context, loginToken, sessionId, imageId string, cpu, memory int, fastStart bool) (ImageStartResponse, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodPost
 		localVarReturnValue ImageStartResponse
@@ -535,13 +727,7 @@ func (i *ImageAPIService) StartImage(ctx context.Context, loginToken, sessionId,
 	}
 
 	// Create request body
-	requestBody := ImageStartRequest{
-		LoginToken: loginToken,
-		SessionId:  sessionId,
-		ImageId:    imageId,
-		CPU:        cpu,
-		Memory:     memory,
-	}
+	requestBody := NewImageStartRequest(loginToken, sessionId, imageId, cpu, memory, fastStart)
 
 	// Prepare request
 	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
@@ -569,20 +755,21 @@ func (i *ImageAPIService) StartImage(ctx context.Context, loginToken, sessionId,
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	err = i.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+// ImageStopOptions controls how StopImage stops an instance
+type ImageStopOptions struct {
+	// GracePeriod is how long the workload is given to flush its state and shut down
+	// before the instance is stopped, in whole seconds; zero leaves it to the server
+	GracePeriod time.Duration
+	// Force stops the instance at once, skipping any grace period
+	Force bool
 }
 
 // StopImage stops a running image instance
-func (i *ImageAPIService) StopImage(ctx context.Context, loginToken, sessionId, imageId string) (ImageStopResponse, *http.Response, error) {
+func (i *ImageAPIService) StopImage(ctx context.Context, loginToken, sessionId, imageId string, opts ImageStopOptions) (ImageStopResponse, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodPost
 		localVarReturnValue ImageStopResponse
@@ -617,11 +804,7 @@ func (i *ImageAPIService) StopImage(ctx context.Context, loginToken, sessionId,
 	}
 
 	// Create request body
-	requestBody := ImageStopRequest{
-		LoginToken: loginToken,
-		SessionId:  sessionId,
-		ImageId:    imageId,
-	}
+	requestBody := NewImageStopRequest(loginToken, sessionId, imageId, opts)
 
 	// Prepare request
 	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
@@ -649,14 +832,78 @@ func (i *ImageAPIService) StopImage(ctx context.Context, loginToken, sessionId,
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	err = i.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
+
+// DeleteImage permanently deletes a user image
+func (i *ImageAPIService) DeleteImage(ctx context.Context, loginToken, sessionId, imageId string) (ImageDeleteResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue ImageDeleteResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/delete"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "DeleteImage")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if imageId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "imageId parameter is required"}
+	}
+
+	// Create request body
+	requestBody := ImageDeleteRequest{
+		LoginToken: loginToken,
+		SessionId:  sessionId,
+		ImageId:    imageId,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
 	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
-			error: err.Error(),
+			error: localVarHTTPResponse.Status,
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
 }
@@ -0,0 +1,163 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+)
+
+// Optional features a server can report in ImageCapabilitiesData.Features
+const (
+	FeatureMultiPlatform = "multiPlatform" // Images built for several platforms at once
+	FeatureBuildArgs     = "buildArgs"     // Build arguments passed to the Dockerfile
+	FeatureGPU           = "gpu"           // Activating images with GPU resources
+	FeatureLogStreaming  = "logStreaming"  // Streaming runtime logs instead of polling them
+	FeatureCursorPaging  = "cursorPaging"  // Listing images by cursor instead of page number
+)
+
+// ErrCapabilitiesUnsupported is returned by Capabilities when the server predates the
+// capabilities endpoint, so it builds only the default platform
+var ErrCapabilitiesUnsupported = errors.New("the server does not report its capabilities")
+
+// ImageCapabilitiesResponse represents the response from /api/image/capabilities API
+type ImageCapabilitiesResponse struct {
+	Code           string                `json:"code"`
+	RequestID      string                `json:"requestId"`
+	Success        bool                  `json:"success"`
+	Data           ImageCapabilitiesData `json:"data"`
+	TraceID        string                `json:"traceId"`
+	HTTPStatusCode int                   `json:"httpStatusCode"`
+}
+
+// ImageCapabilitiesData describes what image builds the server supports
+type ImageCapabilitiesData struct {
+	// Platforms lists the platforms images can be built for, e.g. linux/amd64
+	Platforms []string `json:"platforms"`
+	// DefaultPlatform is the platform built when none is requested
+	DefaultPlatform string `json:"defaultPlatform,omitempty"`
+	// Features lists the optional features the server supports, such as logStreaming.
+	// It is nil when the server does not report features.
+	Features []string `json:"features,omitempty"`
+}
+
+// SupportsPlatform reports whether images can be built for platform
+func (d ImageCapabilitiesData) SupportsPlatform(platform string) bool {
+	return slices.Contains(d.Platforms, platform)
+}
+
+// SupportsFeature reports whether the server supports an optional feature. The second
+// result is false when the server does not report its features, so support is unknown.
+func (d ImageCapabilitiesData) SupportsFeature(feature string) (supported, known bool) {
+	if d.Features == nil {
+		return false, false
+	}
+	return slices.Contains(d.Features, feature), true
+}
+
+// capabilityCache holds the result of the first capabilities lookup of a client
+type capabilityCache struct {
+	mu     sync.Mutex
+	loaded bool
+	data   ImageCapabilitiesData
+	err    error
+}
+
+// Capabilities returns the capabilities of the server, asking it only once per client.
+// Servers without the capabilities endpoint give ErrCapabilitiesUnsupported. Other
+// failures are not cached, so a later call asks again.
+func (c *APIClient) Capabilities(ctx context.Context, loginToken, sessionId string) (ImageCapabilitiesData, error) {
+	c.capabilities.mu.Lock()
+	defer c.capabilities.mu.Unlock()
+	if c.capabilities.loaded {
+		return c.capabilities.data, c.capabilities.err
+	}
+
+	resp, httpResp, err := c.ImageAPI.GetImageCapabilities(ctx, loginToken, sessionId)
+	var apiErr *GenericOpenAPIError
+	switch {
+	case err != nil && errors.As(err, &apiErr) && httpResp != nil &&
+		(httpResp.StatusCode == http.StatusNotFound || httpResp.StatusCode == http.StatusNotImplemented):
+		c.capabilities.err = ErrCapabilitiesUnsupported
+	case err != nil:
+		return ImageCapabilitiesData{}, err
+	default:
+		c.capabilities.data = resp.Data
+	}
+	c.capabilities.loaded = true
+	return c.capabilities.data, c.capabilities.err
+}
+
+// GetImageCapabilities retrieves the build capabilities of the server, such as the
+// supported platforms
+func (i *ImageAPIService) GetImageCapabilities(ctx context.Context, loginToken, sessionId string) (ImageCapabilitiesResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		localVarReturnValue ImageCapabilitiesResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/capabilities"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "GetImageCapabilities")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	localVarQueryParams.Add("loginToken", loginToken)
+
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	localVarQueryParams.Add("sessionId", sessionId)
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
@@ -0,0 +1,275 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/agbcloud/agbcloud-cli/internal/verbosity"
+)
+
+// ErrInstanceLogStreamUnsupported is returned by StreamInstanceLogs when the server
+// has no streaming endpoint; callers should fall back to polling GetInstanceLogs
+var ErrInstanceLogStreamUnsupported = errors.New("instance log streaming is not supported by the server")
+
+// InstanceLogOptions selects which runtime log lines of an activated image are returned
+type InstanceLogOptions struct {
+	ImageId string // Image whose instance logs are read (required)
+	Tail    int    // Only return the last N lines; 0 uses the server default
+	Since   string // Only return lines at or after this RFC3339 timestamp
+	// NextToken continues after the last line of a previous GetInstanceLogs call
+	NextToken string
+}
+
+// InstanceLogLine is a single runtime log line
+type InstanceLogLine struct {
+	Timestamp string `json:"timestamp"`
+	Stream    string `json:"stream,omitempty"` // "stdout" or "stderr"
+	Message   string `json:"message"`
+}
+
+// InstanceLogsResponse represents the response from /api/image/logs API
+type InstanceLogsResponse struct {
+	Code           string           `json:"code"`
+	RequestID      string           `json:"requestId"`
+	Success        bool             `json:"success"`
+	Data           InstanceLogsData `json:"data"`
+	TraceID        string           `json:"traceId"`
+	HTTPStatusCode int              `json:"httpStatusCode"`
+}
+
+// InstanceLogsData represents the data field in instance logs response
+type InstanceLogsData struct {
+	Lines     []InstanceLogLine `json:"lines"`
+	NextToken string            `json:"nextToken"`
+}
+
+// instanceLogQuery validates the options and builds the common query parameters
+func instanceLogQuery(loginToken, sessionId string, opts InstanceLogOptions) (url.Values, error) {
+	if loginToken == "" {
+		return nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if opts.ImageId == "" {
+		return nil, &GenericOpenAPIError{error: "imageId parameter is required"}
+	}
+	if opts.Tail < 0 {
+		return nil, &GenericOpenAPIError{error: "tail must not be negative"}
+	}
+
+	query := url.Values{}
+	query.Add("loginToken", loginToken)
+	query.Add("sessionId", sessionId)
+	query.Add("imageId", opts.ImageId)
+	if opts.Tail > 0 {
+		query.Add("tail", fmt.Sprintf("%d", opts.Tail))
+	}
+	if opts.Since != "" {
+		query.Add("since", opts.Since)
+	}
+	if opts.NextToken != "" {
+		query.Add("nextToken", opts.NextToken)
+	}
+	return query, nil
+}
+
+// GetInstanceLogs retrieves a page of runtime logs of an activated image
+func (i *ImageAPIService) GetInstanceLogs(ctx context.Context, loginToken, sessionId string, opts InstanceLogOptions) (InstanceLogsResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		localVarReturnValue InstanceLogsResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/logs"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "GetInstanceLogs")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+
+	// Validate required parameters
+	localVarQueryParams, err := instanceLogQuery(loginToken, sessionId, opts)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	// Log pages can be large, so the body is decoded as a stream instead of buffered
+	localVarHTTPResponse, err := i.client.callLargeAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+	defer localVarHTTPResponse.Body.Close()
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		localVarBody, _ := io.ReadAll(io.LimitReader(localVarHTTPResponse.Body, 64*1024))
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = json.NewDecoder(localVarHTTPResponse.Body).Decode(&localVarReturnValue)
+	if err != nil {
+		var tooLarge *ResponseTooLargeError
+		if errors.As(err, &tooLarge) {
+			return localVarReturnValue, localVarHTTPResponse, err
+		}
+		newErr := &GenericOpenAPIError{
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+// StreamInstanceLogs follows the runtime logs of an activated image over a chunked
+// response of newline-delimited JSON log lines, calling handler for every line until
+// the server ends the stream, ctx is cancelled or handler returns an error.
+// ErrInstanceLogStreamUnsupported is returned when the server has no streaming endpoint.
+func (i *ImageAPIService) StreamInstanceLogs(ctx context.Context, loginToken, sessionId string, opts InstanceLogOptions, handler func(InstanceLogLine) error) error {
+	localVarPath := "/api/image/logs/stream"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "StreamInstanceLogs")
+	if err != nil {
+		return &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := map[string]string{"Accept": "application/x-ndjson"}
+
+	// Validate required parameters
+	localVarQueryParams, err := instanceLogQuery(loginToken, sessionId, opts)
+	if err != nil {
+		return err
+	}
+	localVarQueryParams.Add("follow", "true")
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, http.MethodGet, nil, localVarHeaderParams, localVarQueryParams)
+	if err != nil {
+		return err
+	}
+
+	resp, err := i.client.callStreamAPI(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return ErrInstanceLogStreamUnsupported
+	}
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		return &GenericOpenAPIError{body: body, error: resp.Status}
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			// Keep-alive heartbeat
+			continue
+		}
+
+		var logLine InstanceLogLine
+		if err := json.Unmarshal([]byte(line), &logLine); err != nil {
+			return &GenericOpenAPIError{body: []byte(line), error: fmt.Sprintf("invalid log line: %v", err)}
+		}
+		// The server reports a failure during the stream as an error response line
+		if err := applicationError([]byte(line), resp.StatusCode); err != nil {
+			return err
+		}
+		if err := handler(logLine); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// callStreamAPI executes a request whose response body is consumed incrementally.
+// Unlike callAPI it neither buffers nor logs the response body, and the client
+// timeout does not apply so long-lived streams are not cut off.
+func (c *APIClient) callStreamAPI(request *http.Request) (*http.Response, error) {
+	// Streams are logged with -vv like other requests
+	logHeaders := verbosity.Enabled(verbosity.Headers)
+	if logHeaders {
+		log.Debugf("\n=== HTTP Request Information (stream) ===")
+		log.Debugf("URL: %s", RedactText(request.URL.String()))
+		log.Debugf("Method: %s", request.Method)
+		log.Debugf("Headers: %v", RedactHeaders(request.Header))
+		log.Debugf("=" + strings.Repeat("=", 49))
+	}
+
+	var trace *callTrace
+	if c.cfg.Timing != nil {
+		request, trace = withTimingTrace(request)
+	}
+
+	streamClient := *c.cfg.HTTPClient
+	streamClient.Timeout = 0
+
+	resp, err := c.doWithFailover(request, &streamClient)
+	if trace != nil {
+		// Total time covers establishing the stream, not how long it stays open
+		trace.finish(c.cfg.Timing, resp, err)
+	}
+	if err != nil {
+		if logHeaders {
+			log.Debugf("\n=== HTTP Request Error ===")
+			log.Debugf("Error Message: %s", RedactText(err.Error()))
+			log.Debugf("=" + strings.Repeat("=", 49))
+		}
+		return nil, err
+	}
+
+	if logHeaders {
+		log.Debugf("\n=== HTTP Response Information (stream) ===")
+		log.Debugf("Status Code: %d", resp.StatusCode)
+		log.Debugf("Headers: %v", RedactHeaders(resp.Header))
+		log.Debugf("=" + strings.Repeat("=", 49))
+	}
+	return resp, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ImageManifestResponse represents the response from /api/image/manifest API
+type ImageManifestResponse struct {
+	Code           string            `json:"code"`
+	RequestID      string            `json:"requestId"`
+	Success        bool              `json:"success"`
+	Data           ImageManifestData `json:"data"`
+	TraceID        string            `json:"traceId"`
+	HTTPStatusCode int               `json:"httpStatusCode"`
+}
+
+// ImageManifestData describes what an image was built from and how it runs
+type ImageManifestData struct {
+	ImageID            string   `json:"imageId"`
+	ImageName          string   `json:"imageName"`
+	SourceImageID      string   `json:"sourceImageId,omitempty"`
+	SourceImageVersion string   `json:"sourceImageVersion,omitempty"`
+	Digest             string   `json:"digest,omitempty"`
+	Platforms          []string `json:"platforms,omitempty"`
+	CPU                *int     `json:"cpu"`    // Can be null
+	Memory             *int     `json:"memory"` // Can be null, in GB
+	// Labels are the key/value labels set on the image
+	Labels map[string]string `json:"labels,omitempty"`
+	// DockerfileDigest is the SHA-256 digest of the Dockerfile (sha256:<hex>)
+	DockerfileDigest string `json:"dockerfileDigest,omitempty"`
+	// Dockerfile is the content of the Dockerfile; empty when the caller may not read it
+	Dockerfile string `json:"dockerfile,omitempty"`
+}
+
+// GetImageManifest retrieves the build inputs and settings of an image
+func (i *ImageAPIService) GetImageManifest(ctx context.Context, loginToken, sessionId, imageId string) (ImageManifestResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		localVarReturnValue ImageManifestResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/manifest"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "GetImageManifest")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	localVarQueryParams.Add("loginToken", loginToken)
+
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	localVarQueryParams.Add("sessionId", sessionId)
+
+	if imageId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "imageId parameter is required"}
+	}
+	localVarQueryParams.Add("imageId", imageId)
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ImageQueueResponse represents the response from /api/image/queue API
+type ImageQueueResponse struct {
+	Code           string         `json:"code"`
+	RequestID      string         `json:"requestId"`
+	Success        bool           `json:"success"`
+	Data           ImageQueueData `json:"data"`
+	TraceID        string         `json:"traceId"`
+	HTTPStatusCode int            `json:"httpStatusCode"`
+}
+
+// ImageQueueData is the place of an activation in the deployment queue. Activations
+// wait in the queue while capacity is constrained; Position is 0 once capacity has been
+// assigned and the image is being deployed.
+type ImageQueueData struct {
+	ImageID     string `json:"imageId"`
+	Position    int    `json:"position"`
+	QueueLength int    `json:"queueLength"`
+	// EstimatedWaitSeconds is the expected time until deployment starts, 0 if unknown
+	EstimatedWaitSeconds int `json:"estimatedWaitSeconds,omitempty"`
+}
+
+// Queued reports whether the activation is still waiting for capacity
+func (d ImageQueueData) Queued() bool {
+	return d.Position > 0
+}
+
+// EstimatedWait returns the expected time until deployment starts, if the server estimated it
+func (d ImageQueueData) EstimatedWait() (time.Duration, bool) {
+	if d.EstimatedWaitSeconds <= 0 {
+		return 0, false
+	}
+	return time.Duration(d.EstimatedWaitSeconds) * time.Second, true
+}
+
+// GetImageQueue retrieves the queue position and estimated wait of an activation
+func (i *ImageAPIService) GetImageQueue(ctx context.Context, loginToken, sessionId, imageId string) (ImageQueueResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		localVarReturnValue ImageQueueResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/queue"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "GetImageQueue")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	localVarQueryParams.Add("loginToken", loginToken)
+
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	localVarQueryParams.Add("sessionId", sessionId)
+
+	if imageId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "imageId parameter is required"}
+	}
+	localVarQueryParams.Add("imageId", imageId)
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
@@ -0,0 +1,296 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Reservation statuses reported by the server
+const (
+	ReservationReserved = "Reserved" // Capacity is held for the next activation
+	ReservationConsumed = "Consumed" // The image was activated on the reserved capacity
+	ReservationReleased = "Released" // The reservation was released before it was used
+	ReservationExpired  = "Expired"  // The reservation was not used in time
+)
+
+// ImageReservationResponse represents the response from the /api/image/reservation APIs
+type ImageReservationResponse struct {
+	Code           string               `json:"code"`
+	RequestID      string               `json:"requestId"`
+	Success        bool                 `json:"success"`
+	Data           ImageReservationData `json:"data"`
+	TraceID        string               `json:"traceId"`
+	HTTPStatusCode int                  `json:"httpStatusCode"`
+}
+
+// ImageReservationData is capacity reserved for the activation of an image. A
+// reservation is made for the create task of an image; ImageID is set once the
+// server has created the image.
+type ImageReservationData struct {
+	ReservationID string `json:"reservationId"`
+	TaskID        string `json:"taskId"`
+	ImageID       string `json:"imageId,omitempty"`
+	CPU           int    `json:"cpu"`
+	Memory        int    `json:"memory"` // In GB
+	Status        string `json:"status"`
+	// ExpireTime is when unused reserved capacity is released (RFC 3339), empty if never
+	ExpireTime string `json:"expireTime,omitempty"`
+}
+
+// Active reports whether the capacity is still held for the next activation
+func (d ImageReservationData) Active() bool {
+	return d.Status == ReservationReserved
+}
+
+// ExpiresAt returns when unused reserved capacity is released, if the server reported it
+func (d ImageReservationData) ExpiresAt() (time.Time, bool) {
+	if d.ExpireTime == "" {
+		return time.Time{}, false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, d.ExpireTime)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expiresAt, true
+}
+
+// ImageReservationRequest represents the request body for /api/image/reservation/create API
+type ImageReservationRequest struct {
+	LoginToken string `json:"loginToken"`
+	SessionId  string `json:"sessionId"`
+	TaskId     string `json:"taskId"`
+	CPU        int    `json:"cpu"`
+	Memory     int    `json:"memory"`
+}
+
+// ImageReservationReleaseRequest represents the request body for /api/image/reservation/release API
+type ImageReservationReleaseRequest struct {
+	LoginToken    string `json:"loginToken"`
+	SessionId     string `json:"sessionId"`
+	ReservationId string `json:"reservationId"`
+}
+
+// ReserveImageCapacity reserves cpu cores and memory GB for the first activation of the
+// image built by the create task taskId
+func (i *ImageAPIService) ReserveImageCapacity(ctx context.Context, loginToken, sessionId, taskId string, cpu, memory int) (ImageReservationResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue ImageReservationResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/reservation/create"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "ReserveImageCapacity")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if taskId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "taskId parameter is required"}
+	}
+
+	// Create request body
+	requestBody := ImageReservationRequest{
+		LoginToken: loginToken,
+		SessionId:  sessionId,
+		TaskId:     taskId,
+		CPU:        cpu,
+		Memory:     memory,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
+
+// GetImageReservation retrieves the most recent capacity reservation of an image
+func (i *ImageAPIService) GetImageReservation(ctx context.Context, loginToken, sessionId, imageId string) (ImageReservationResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		localVarReturnValue ImageReservationResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/reservation"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "GetImageReservation")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	localVarQueryParams.Add("loginToken", loginToken)
+
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	localVarQueryParams.Add("sessionId", sessionId)
+
+	if imageId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "imageId parameter is required"}
+	}
+	localVarQueryParams.Add("imageId", imageId)
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
+
+// ReleaseImageReservation releases reserved capacity that is no longer needed
+func (i *ImageAPIService) ReleaseImageReservation(ctx context.Context, loginToken, sessionId, reservationId string) (ImageReservationResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue ImageReservationResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/reservation/release"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "ReleaseImageReservation")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if reservationId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "reservationId parameter is required"}
+	}
+
+	// Create request body
+	requestBody := ImageReservationReleaseRequest{
+		LoginToken:    loginToken,
+		SessionId:     sessionId,
+		ReservationId: reservationId,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ImageRestartRequest represents the request body for /api/image/restart API
+type ImageRestartRequest struct {
+	LoginToken string `json:"loginToken"`
+	SessionId  string `json:"sessionId"`
+	ImageId    string `json:"imageId"`
+}
+
+// ImageRestartResponse represents the response from /api/image/restart API
+type ImageRestartResponse struct {
+	Code           string `json:"code"`
+	RequestID      string `json:"requestId"`
+	Success        bool   `json:"success"`
+	Data           bool   `json:"data"`
+	TraceID        string `json:"traceId"`
+	HTTPStatusCode int    `json:"httpStatusCode"`
+}
+
+// RestartInstance restarts the instance of an activated image in place. The instance
+// keeps its resources; the image reports RESOURCE_RESTARTING until it is activated again.
+func (i *ImageAPIService) RestartInstance(ctx context.Context, loginToken, sessionId, imageId string) (ImageRestartResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue ImageRestartResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/restart"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "RestartInstance")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if imageId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "imageId parameter is required"}
+	}
+
+	// Create request body
+	requestBody := ImageRestartRequest{
+		LoginToken: loginToken,
+		SessionId:  sessionId,
+		ImageId:    imageId,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
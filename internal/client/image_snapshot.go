@@ -0,0 +1,114 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ImageSnapshotRequest represents the request body for /api/image/snapshot API
+type ImageSnapshotRequest struct {
+	LoginToken string `json:"loginToken"`
+	SessionId  string `json:"sessionId"`
+	ImageId    string `json:"imageId"`
+	ImageName  string `json:"imageName"`
+}
+
+// ImageSnapshotResponse represents the response from /api/image/snapshot API
+type ImageSnapshotResponse struct {
+	Code           string            `json:"code"`
+	RequestID      string            `json:"requestId"`
+	Success        bool              `json:"success"`
+	Data           ImageSnapshotData `json:"data"`
+	TraceID        string            `json:"traceId"`
+	HTTPStatusCode int               `json:"httpStatusCode"`
+}
+
+// ImageSnapshotData represents the data field in the snapshot response
+type ImageSnapshotData struct {
+	// TaskID is the image task that builds the snapshot; poll it with GetImageTask
+	TaskID string `json:"taskId"`
+}
+
+// SnapshotInstance captures the current state of the instance of an activated image into
+// a new custom image named imageName. The snapshot is built by an image task like
+// CreateImage; the instance keeps running.
+func (i *ImageAPIService) SnapshotInstance(ctx context.Context, loginToken, sessionId, imageId, imageName string) (ImageSnapshotResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue ImageSnapshotResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/snapshot"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "SnapshotInstance")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if imageId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "imageId parameter is required"}
+	}
+	if imageName == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "imageName parameter is required"}
+	}
+
+	// Create request body
+	requestBody := ImageSnapshotRequest{
+		LoginToken: loginToken,
+		SessionId:  sessionId,
+		ImageId:    imageId,
+		ImageName:  imageName,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
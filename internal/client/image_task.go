@@ -0,0 +1,221 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ImageTaskDeleteResponse represents the response from /api/image/task/delete API
+type ImageTaskDeleteResponse struct {
+	Code           string `json:"code"`
+	RequestID      string `json:"requestId"`
+	Success        bool   `json:"success"`
+	Data           bool   `json:"data"`
+	TraceID        string `json:"traceId"`
+	HTTPStatusCode int    `json:"httpStatusCode"`
+}
+
+// ImageTaskDeleteRequest represents the request body for /api/image/task/delete API
+type ImageTaskDeleteRequest struct {
+	LoginToken string `json:"loginToken"`
+	SessionId  string `json:"sessionId"`
+	TaskId     string `json:"taskId"`
+}
+
+// DeleteImageTask deletes an image creation task and the server-side artifacts it left
+// behind, such as the uploaded Dockerfile and intermediate build results
+func (i *ImageAPIService) DeleteImageTask(ctx context.Context, loginToken, sessionId, taskId string) (ImageTaskDeleteResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue ImageTaskDeleteResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/task/delete"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "DeleteImageTask")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if taskId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "taskId parameter is required"}
+	}
+
+	// Create request body
+	requestBody := ImageTaskDeleteRequest{
+		LoginToken: loginToken,
+		SessionId:  sessionId,
+		TaskId:     taskId,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
+
+// ImageTaskListResponse represents the response from /api/image/task/list API
+type ImageTaskListResponse struct {
+	Code           string            `json:"code"`
+	RequestID      string            `json:"requestId"`
+	Success        bool              `json:"success"`
+	Data           ImageTaskListData `json:"data"`
+	TraceID        string            `json:"traceId"`
+	HTTPStatusCode int               `json:"httpStatusCode"`
+}
+
+// ImageTaskListData represents the data field in image task list response
+type ImageTaskListData struct {
+	Tasks    []ImageTaskInfo `json:"tasks"`
+	Total    int             `json:"total"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"pageSize"`
+}
+
+// ImageTaskInfo describes an image creation task
+type ImageTaskInfo struct {
+	TaskID        string  `json:"taskId"`
+	ImageName     string  `json:"imageName"`
+	Status        string  `json:"status"`
+	TaskMsg       string  `json:"taskMsg"`
+	SourceImageID string  `json:"sourceImageId"`
+	ImageID       *string `json:"imageId"`
+	CreateTime    string  `json:"createTime"`
+}
+
+// ImageTaskListOptions selects which tasks ListImageTasks returns
+type ImageTaskListOptions struct {
+	ImageName string // Only return tasks creating an image with this name
+	Page      int    // 1-based page number (required)
+	PageSize  int    // Number of tasks per page (required)
+}
+
+// ListImageTasks retrieves the user's image creation tasks, most recent first
+func (i *ImageAPIService) ListImageTasks(ctx context.Context, loginToken, sessionId string, opts ImageTaskListOptions) (ImageTaskListResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		localVarReturnValue ImageTaskListResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/task/list"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "ListImageTasks")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	localVarQueryParams.Add("loginToken", loginToken)
+
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	localVarQueryParams.Add("sessionId", sessionId)
+
+	// Validate pagination parameters
+	if opts.Page <= 0 {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "page must be greater than 0"}
+	}
+	localVarQueryParams.Add("page", fmt.Sprintf("%d", opts.Page))
+
+	if opts.PageSize <= 0 {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "pageSize must be greater than 0"}
+	}
+	localVarQueryParams.Add("pageSize", fmt.Sprintf("%d", opts.PageSize))
+
+	// Add image name filter if provided
+	if opts.ImageName != "" {
+		localVarQueryParams.Add("imageName", opts.ImageName)
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ImageActivationDefaults are the settings an image is activated with when the
+// activation does not give its own. The server stores them with the image and sets
+// the environment variables in every instance it starts.
+type ImageActivationDefaults struct {
+	CPU    int               `json:"cpu,omitempty"`
+	Memory int               `json:"memory,omitempty"` // In GB
+	Env    map[string]string `json:"env,omitempty"`
+}
+
+// IsZero reports whether no default is set
+func (d ImageActivationDefaults) IsZero() bool {
+	return d.CPU == 0 && d.Memory == 0 && len(d.Env) == 0
+}
+
+// ImageUpdateRequest represents the request body for /api/image/update API
+type ImageUpdateRequest struct {
+	LoginToken string `json:"loginToken"`
+	SessionId  string `json:"sessionId"`
+	ImageId    string `json:"imageId"`
+	// ActivationDefaults replaces the stored defaults; an empty value removes them
+	ActivationDefaults ImageActivationDefaults `json:"activationDefaults"`
+}
+
+// ImageUpdateResponse represents the response from /api/image/update API
+type ImageUpdateResponse struct {
+	Code           string `json:"code"`
+	RequestID      string `json:"requestId"`
+	Success        bool   `json:"success"`
+	Data           bool   `json:"data"`
+	TraceID        string `json:"traceId"`
+	HTTPStatusCode int    `json:"httpStatusCode"`
+}
+
+// UpdateImage stores the default activation settings of a user image, replacing the
+// previous ones. Older backends answer 404 or 501.
+func (i *ImageAPIService) UpdateImage(ctx context.Context, loginToken, sessionId, imageId string, defaults ImageActivationDefaults) (ImageUpdateResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue ImageUpdateResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/update"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "UpdateImage")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if imageId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "imageId parameter is required"}
+	}
+
+	// Create request body
+	requestBody := ImageUpdateRequest{
+		LoginToken: loginToken,
+		SessionId:  sessionId,
+		ImageId:    imageId,
+
+		ActivationDefaults: defaults,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ImageUsageResponse represents the response from /api/image/usage API
+type ImageUsageResponse struct {
+	Code           string       `json:"code"`
+	RequestID      string       `json:"requestId"`
+	Success        bool         `json:"success"`
+	Data           []ImageUsage `json:"data"`
+	TraceID        string       `json:"traceId"`
+	HTTPStatusCode int          `json:"httpStatusCode"`
+}
+
+// ImageUsage is the activation history of a user image
+type ImageUsage struct {
+	ImageID string `json:"imageId"`
+	// LastUsedTime is when the image was last activated or deactivated; empty if it
+	// was never activated
+	LastUsedTime string `json:"lastUsedTime,omitempty"`
+	// ActiveHours is the total time the image has been activated, including the
+	// current activation
+	ActiveHours float64 `json:"activeHours"`
+	// ActivationCount is the number of times the image has been activated
+	ActivationCount int `json:"activationCount"`
+}
+
+// GetImageUsage retrieves the activation history of the given user images
+func (i *ImageAPIService) GetImageUsage(ctx context.Context, loginToken, sessionId string, imageIds []string) (ImageUsageResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		localVarReturnValue ImageUsageResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/usage"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "GetImageUsage")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	localVarQueryParams.Add("loginToken", loginToken)
+
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	localVarQueryParams.Add("sessionId", sessionId)
+
+	if len(imageIds) == 0 {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "at least one imageId is required"}
+	}
+	for _, imageId := range imageIds {
+		localVarQueryParams.Add("imageIds", imageId)
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Severities of the findings reported by ValidateCreate
+const (
+	ValidationError   = "error"   // The create request would be rejected or the build would fail
+	ValidationWarning = "warning" // The build would run, but probably not as intended
+	ValidationInfo    = "info"    // Nothing to fix
+)
+
+// ImageValidateResponse represents the response from /api/image/validate API
+type ImageValidateResponse struct {
+	Code           string              `json:"code"`
+	RequestID      string              `json:"requestId"`
+	Success        bool                `json:"success"`
+	Data           ImageValidationData `json:"data"`
+	TraceID        string              `json:"traceId"`
+	HTTPStatusCode int                 `json:"httpStatusCode"`
+}
+
+// ImageValidationData is the server's verdict on a create request
+type ImageValidationData struct {
+	// Valid is true when no finding has the error severity
+	Valid    bool                     `json:"valid"`
+	Findings []ImageValidationFinding `json:"findings"`
+}
+
+// ImageValidationFinding is one problem found in a create request
+type ImageValidationFinding struct {
+	Severity string `json:"severity"`
+	// Code identifies the check, e.g. ForbiddenInstruction or ImageQuotaExceeded
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Line is the Dockerfile line the finding is about, 0 if it is not about a line
+	Line int `json:"line,omitempty"`
+}
+
+// ImageValidateOptions is the create request checked by ValidateCreate
+type ImageValidateOptions struct {
+	ImageName     string   // Name of the image to create (required)
+	SourceImageId string   // Base image (required)
+	Dockerfile    string   // Content of the Dockerfile (required)
+	Platforms     []string // Platforms to build for; empty for the server's default
+}
+
+// ImageValidateRequest represents the request body for /api/image/validate API
+type ImageValidateRequest struct {
+	LoginToken    string   `json:"loginToken"`
+	SessionId     string   `json:"sessionId"`
+	ImageName     string   `json:"imageName"`
+	SourceImageId string   `json:"sourceImageId"`
+	Dockerfile    string   `json:"dockerfile"`
+	Platforms     []string `json:"platforms,omitempty"`
+}
+
+// ValidateCreate has the server check a create request without starting a build: that
+// the base image exists, that the image quota allows another image and that the
+// Dockerfile only uses allowed instructions
+func (i *ImageAPIService) ValidateCreate(ctx context.Context, loginToken, sessionId string, opts ImageValidateOptions) (ImageValidateResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue ImageValidateResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/image/validate"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "ValidateCreate")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if opts.ImageName == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "imageName parameter is required"}
+	}
+	if opts.SourceImageId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sourceImageId parameter is required"}
+	}
+
+	// Create request body
+	requestBody := ImageValidateRequest{
+		LoginToken:    loginToken,
+		SessionId:     sessionId,
+		ImageName:     opts.ImageName,
+		SourceImageId: opts.SourceImageId,
+		Dockerfile:    opts.Dockerfile,
+		Platforms:     opts.Platforms,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
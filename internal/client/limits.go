@@ -0,0 +1,129 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Response size limits protect the CLI from accidentally buffering huge payloads
+const (
+	// DefaultMaxResponseBytes limits regular API responses, which are small JSON documents
+	DefaultMaxResponseBytes int64 = 16 << 20
+	// DefaultMaxLargeResponseBytes limits endpoints known to return large payloads (e.g. logs)
+	DefaultMaxLargeResponseBytes int64 = 256 << 20
+)
+
+// ResponseTooLargeError is returned when a response body exceeds the configured limit
+type ResponseTooLargeError struct {
+	Path  string
+	Limit int64
+}
+
+// Error implements the error interface
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response from %s exceeds the maximum size of %s; set AGB_CLI_MAX_RESPONSE_SIZE (e.g. 64MB) to allow larger responses",
+		e.Path, FormatByteSize(e.Limit))
+}
+
+// responseLimit returns the maximum response size for regular or large endpoints
+func (c *Configuration) responseLimit(large bool) int64 {
+	if large {
+		if c.MaxLargeResponseBytes > 0 {
+			return c.MaxLargeResponseBytes
+		}
+		// A raised regular limit also raises the large limit
+		if c.MaxResponseBytes > DefaultMaxLargeResponseBytes {
+			return c.MaxResponseBytes
+		}
+		return DefaultMaxLargeResponseBytes
+	}
+	if c.MaxResponseBytes > 0 {
+		return c.MaxResponseBytes
+	}
+	return DefaultMaxResponseBytes
+}
+
+// limitedBody fails reads with a ResponseTooLargeError once more than limit bytes were read
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	limit     int64
+	path      string
+}
+
+func newLimitedBody(body io.ReadCloser, limit int64, path string) io.ReadCloser {
+	return &limitedBody{body: body, remaining: limit, limit: limit, path: path}
+}
+
+// Read implements io.Reader
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, &ResponseTooLargeError{Path: l.path, Limit: l.limit}
+	}
+	// Read one byte past the limit to tell "exactly at the limit" from "over it"
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.body.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n + int(l.remaining), &ResponseTooLargeError{Path: l.path, Limit: l.limit}
+	}
+	return n, err
+}
+
+// Close implements io.Closer
+func (l *limitedBody) Close() error {
+	return l.body.Close()
+}
+
+var byteSizeUnits = map[string]int64{
+	"":    1,
+	"b":   1,
+	"k":   1 << 10,
+	"kb":  1 << 10,
+	"kib": 1 << 10,
+	"m":   1 << 20,
+	"mb":  1 << 20,
+	"mib": 1 << 20,
+	"g":   1 << 30,
+	"gb":  1 << 30,
+	"gib": 1 << 30,
+}
+
+// ParseByteSize parses sizes such as "1048576", "512KB", "64MB" or "1GiB".
+// Units are binary: 1KB is 1024 bytes.
+func ParseByteSize(value string) (int64, error) {
+	trimmed := strings.ToLower(strings.TrimSpace(value))
+	split := strings.IndexFunc(trimmed, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	number, unit := trimmed, ""
+	if split >= 0 {
+		number, unit = trimmed[:split], strings.TrimSpace(trimmed[split:])
+	}
+
+	multiplier, ok := byteSizeUnits[unit]
+	amount, err := strconv.ParseFloat(number, 64)
+	if !ok || err != nil || amount <= 0 {
+		return 0, fmt.Errorf("invalid size '%s' (examples: 1048576, 512KB, 64MB, 1GB)", value)
+	}
+	return int64(amount * float64(multiplier)), nil
+}
+
+// FormatByteSize renders a byte count with a binary unit, e.g. "16 MB"
+func FormatByteSize(size int64) string {
+	switch {
+	case size >= 1<<30 && size%(1<<30) == 0:
+		return fmt.Sprintf("%d GB", size>>30)
+	case size >= 1<<20:
+		return fmt.Sprintf("%.4g MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.4g KB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d bytes", size)
+	}
+}
@@ -17,7 +17,11 @@ type OAuthAPI interface {
 	GetLoginProviderURLWithPort(ctx context.Context, fromUrlPath, loginClient, oauthProvider, localhostPort string) (OAuthLoginProviderResponse, *http.Response, error)
 	LoginTranslate(ctx context.Context, loginClient, oauthProvider, authCode string) (OAuthLoginTranslateResponse, *http.Response, error)
 	LoginTranslateWithPort(ctx context.Context, loginClient, oauthProvider, authCode, localhostPort string) (OAuthLoginTranslateResponse, *http.Response, error)
+	LoginTranslateWithBody(ctx context.Context, loginClient, oauthProvider, authCode, localhostPort string) (OAuthLoginTranslateResponse, *http.Response, error)
 	RefreshToken(ctx context.Context, keepAliveToken, sessionId string) (OAuthRefreshTokenResponse, *http.Response, error)
+	RefreshTokenWithBody(ctx context.Context, keepAliveToken, sessionId string) (OAuthRefreshTokenResponse, *http.Response, error)
+	ExchangeAuthCode(ctx context.Context, loginClient, oauthProvider, authCode, localhostPort string) (OAuthLoginTranslateResponse, *http.Response, error)
+	RenewSession(ctx context.Context, keepAliveToken, sessionId string) (OAuthRefreshTokenResponse, *http.Response, error)
 	Logout(ctx context.Context, loginToken, sessionId string) (OAuthLogoutResponse, *http.Response, error)
 }
 
@@ -50,6 +54,14 @@ type OAuthLoginTranslateResponse struct {
 	HTTPStatusCode int                     `json:"httpStatusCode"`
 }
 
+// OAuthLoginTranslateRequest is the JSON body sent by LoginTranslateWithBody
+type OAuthLoginTranslateRequest struct {
+	LoginClient   string `json:"loginClient"`
+	OauthProvider string `json:"oauthProvider"`
+	AuthCode      string `json:"authCode"`
+	LocalhostPort string `json:"localhostPort,omitempty"`
+}
+
 // OAuthLoginTranslateData represents the data field in OAuth login translate response
 // This matches the actual AgbCloud API response format
 type OAuthLoginTranslateData struct {
@@ -69,6 +81,12 @@ type OAuthRefreshTokenResponse struct {
 	HTTPStatusCode int                   `json:"httpStatusCode"`
 }
 
+// OAuthRefreshTokenRequest is the JSON body sent by RefreshTokenWithBody
+type OAuthRefreshTokenRequest struct {
+	KeepAliveToken string `json:"keepAliveToken"`
+	SessionId      string `json:"sessionId"`
+}
+
 // OAuthRefreshTokenData represents the data field in OAuth refresh token response
 // This matches the actual AgbCloud API response format
 type OAuthRefreshTokenData struct {
@@ -163,16 +181,8 @@ func (o *OAuthAPIService) GetLoginProviderURL(ctx context.Context, fromUrlPath,
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	err = o.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
-
-	return localVarReturnValue, localVarHTTPResponse, nil
+	err = o.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
 }
 
 // GetLoginProviderURLWithPort retrieves the OAuth login provider URL with localhostPort parameter
@@ -250,16 +260,8 @@ func (o *OAuthAPIService) GetLoginProviderURLWithPort(ctx context.Context, fromU
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	err = o.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
-
-	return localVarReturnValue, localVarHTTPResponse, nil
+	err = o.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
 }
 
 // RefreshToken refreshes the login session using keepAliveToken and sessionId
@@ -324,16 +326,8 @@ func (o *OAuthAPIService) RefreshToken(ctx context.Context, keepAliveToken, sess
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	err = o.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
-
-	return localVarReturnValue, localVarHTTPResponse, nil
+	err = o.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
 }
 
 // LoginTranslate translates OAuth authorization code to access token
@@ -403,16 +397,8 @@ func (o *OAuthAPIService) LoginTranslate(ctx context.Context, loginClient, oauth
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	err = o.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
-
-	return localVarReturnValue, localVarHTTPResponse, nil
+	err = o.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
 }
 
 // LoginTranslateWithPort translates OAuth authorization code to access token with localhostPort parameter
@@ -487,16 +473,8 @@ func (o *OAuthAPIService) LoginTranslateWithPort(ctx context.Context, loginClien
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	err = o.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
-
-	return localVarReturnValue, localVarHTTPResponse, nil
+	err = o.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
 }
 
 // Logout logs out the user by invalidating the session
@@ -561,14 +539,149 @@ func (o *OAuthAPIService) Logout(ctx context.Context, loginToken, sessionId stri
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	err = o.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	err = o.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
+
+// RefreshTokenWithBody refreshes the login session by POSTing keepAliveToken and sessionId
+// in a JSON body, so the tokens never appear in URLs recorded by proxies or access logs
+func (o *OAuthAPIService) RefreshTokenWithBody(ctx context.Context, keepAliveToken, sessionId string) (OAuthRefreshTokenResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue OAuthRefreshTokenResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/biz_login/refresh"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := o.client.cfg.ServerURLWithContext(ctx, "RefreshTokenWithBody")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if keepAliveToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "keepAliveToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+
+	localVarPostBody := OAuthRefreshTokenRequest{
+		KeepAliveToken: keepAliveToken,
+		SessionId:      sessionId,
+	}
+
+	// Prepare request
+	req, err := o.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams)
 	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := o.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
 		newErr := &GenericOpenAPIError{
 			body:  localVarBody,
-			error: err.Error(),
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = o.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
+
+// LoginTranslateWithBody translates OAuth authorization code to access token by POSTing
+// the parameters in a JSON body instead of the query string
+func (o *OAuthAPIService) LoginTranslateWithBody(ctx context.Context, loginClient, oauthProvider, authCode, localhostPort string) (OAuthLoginTranslateResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue OAuthLoginTranslateResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/oauth/auth_code/login_translate"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := o.client.cfg.ServerURLWithContext(ctx, "LoginTranslateWithBody")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginClient == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginClient parameter is required"}
+	}
+	if oauthProvider == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "oauthProvider parameter is required"}
+	}
+	if authCode == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "authCode parameter is required"}
+	}
+
+	localVarPostBody := OAuthLoginTranslateRequest{
+		LoginClient:   loginClient,
+		OauthProvider: oauthProvider,
+		AuthCode:      authCode,
+		LocalhostPort: localhostPort,
+	}
+
+	// Prepare request
+	req, err := o.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := o.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
 		}
 		return localVarReturnValue, localVarHTTPResponse, newErr
 	}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+	err = o.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
 }
@@ -0,0 +1,167 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PinMismatchError is returned when a host with public key pins presents none of
+// them, or is contacted without TLS. It is never retried.
+type PinMismatchError struct {
+	Host      string
+	Presented []string // Pins of the certificates that were checked; empty for plain HTTP
+	PlainHTTP bool
+}
+
+// Error implements the error interface
+func (e *PinMismatchError) Error() string {
+	if e.PlainHTTP {
+		return fmt.Sprintf("TLS pins are configured for %s, but the request uses plain HTTP", e.Host)
+	}
+	return fmt.Sprintf("TLS pin mismatch for %s: the server presented %s, none of which is pinned",
+		e.Host, strings.Join(e.Presented, ", "))
+}
+
+// SPKIPin returns the pin of a certificate's public key: "sha256/" followed by the
+// base64 encoded SHA-256 hash of its DER SubjectPublicKeyInfo, as used by curl's --pinnedpubkey
+func SPKIPin(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return "sha256/" + base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// PinVerifier returns a tls.Config.VerifyConnection function that accepts a connection
+// to host only if the server's key or a key of its verified chain is one of pins.
+// Without chain verification (AGB_CLI_SKIP_SSL_VERIFY) only the server's own key counts,
+// since the other certificates it sends prove nothing; the pins still fail closed.
+func PinVerifier(host string, pins []string) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return &PinMismatchError{Host: host}
+		}
+
+		candidates := []*x509.Certificate{state.PeerCertificates[0]}
+		for _, chain := range state.VerifiedChains {
+			candidates = append(candidates, chain...)
+		}
+		var presented []string
+		seen := make(map[string]bool)
+		for _, cert := range candidates {
+			pin := SPKIPin(cert)
+			for _, pinned := range pins {
+				if pin == pinned {
+					return nil
+				}
+			}
+			if !seen[pin] {
+				seen[pin] = true
+				presented = append(presented, pin)
+			}
+		}
+		return &PinMismatchError{Host: host, Presented: presented}
+	}
+}
+
+// pinnedTransport sends requests to pinned hosts through a transport of their own that
+// checks the pins during the TLS handshake, and refuses plain HTTP requests to them.
+// The server name of the handshake is empty for IP addresses, so the host is bound to
+// each transport instead of being looked up during the handshake.
+type pinnedTransport struct {
+	base    *http.Transport
+	pinsFor func(host string) []string
+
+	mu     sync.Mutex
+	pinned map[string]*http.Transport
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (t *pinnedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := strings.ToLower(req.URL.Hostname())
+	pins := t.pinsFor(host)
+	if len(pins) == 0 {
+		return t.base.RoundTrip(req)
+	}
+	if req.URL.Scheme != "https" {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, &PinMismatchError{Host: host, PlainHTTP: true}
+	}
+	return t.transportFor(host, pins).RoundTrip(req)
+}
+
+// transportFor returns the transport that checks the pins of host, creating it on first use
+func (t *pinnedTransport) transportFor(host string, pins []string) *http.Transport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if transport, ok := t.pinned[host]; ok {
+		return transport
+	}
+	transport := t.base.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.VerifyConnection = PinVerifier(host, pins)
+	if t.pinned == nil {
+		t.pinned = make(map[string]*http.Transport)
+	}
+	t.pinned[host] = transport
+	return transport
+}
+
+// CertificatePin is the pin of one certificate a server presented
+type CertificatePin struct {
+	Subject string
+	Pin     string
+}
+
+// FetchCertificatePins connects to an endpoint and returns the pins of the certificates
+// it presents, the server's own first. The chain is verified unless AGB_CLI_SKIP_SSL_VERIFY
+// is set; configured pins are not applied, so that rotated keys can be inspected.
+func FetchCertificatePins(ctx context.Context, endpoint string) ([]CertificatePin, error) {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid endpoint '%s'", endpoint)
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("endpoint %s does not use TLS", endpoint)
+	}
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: 10 * time.Second},
+		Config: &tls.Config{
+			ServerName:         u.Hostname(),
+			InsecureSkipVerify: shouldSkipSSLVerification(),
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var pins []CertificatePin
+	for _, cert := range conn.(*tls.Conn).ConnectionState().PeerCertificates {
+		pins = append(pins, CertificatePin{Subject: cert.Subject.String(), Pin: SPKIPin(cert)})
+	}
+	return pins, nil
+}
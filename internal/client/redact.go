@@ -0,0 +1,99 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue replaces secret values in debug output
+const RedactedValue = "[REDACTED]"
+
+// secretParams are query parameters and JSON fields whose values must never be logged.
+// Upload URL signatures are included because a signed URL grants write access on its own.
+var secretParams = []string{
+	"loginToken",
+	"sessionId",
+	"keepAliveToken",
+	"authCode",
+	"accessToken",
+	"refreshToken",
+	"accountToken",
+	"password",
+	"Signature",
+	"OSSAccessKeyId",
+	"security-token",
+	"X-Amz-Signature",
+	"X-Amz-Credential",
+	"X-Amz-Security-Token",
+}
+
+// secretHeaders are HTTP headers whose values must never be logged
+var secretHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Oss-Security-Token",
+	"X-Amz-Security-Token",
+}
+
+var (
+	secretParamPattern  = strings.Join(quoteAll(secretParams), "|")
+	secretHeaderPattern = strings.Join(quoteAll(secretHeaders), "|")
+
+	// key=value pairs in URLs, form bodies and error messages
+	queryParamRegexp = regexp.MustCompile(`(?i)(^|[?&;\s"'(])(` + secretParamPattern + `)=([^&\s"'#)]*)`)
+	// "key": "value" pairs in JSON documents
+	jsonFieldRegexp = regexp.MustCompile(`(?i)("(?:` + secretParamPattern + `)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// "Header: value" lines in raw HTTP dumps
+	headerLineRegexp = regexp.MustCompile(`(?im)^((?:` + secretHeaderPattern + `):[ \t]*)[^\r\n]*`)
+	// bearer credentials anywhere in free text
+	bearerRegexp = regexp.MustCompile(`(?i)(Bearer\s+)[A-Za-z0-9._~+/=-]+`)
+)
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = regexp.QuoteMeta(v)
+	}
+	return quoted
+}
+
+// RedactText masks known secrets in free text such as URLs, request and response
+// bodies, raw HTTP dumps and error messages
+func RedactText(text string) string {
+	text = queryParamRegexp.ReplaceAllString(text, "${1}${2}="+RedactedValue)
+	text = jsonFieldRegexp.ReplaceAllString(text, `${1}"`+RedactedValue+`"`)
+	text = headerLineRegexp.ReplaceAllString(text, "${1}"+RedactedValue)
+	text = bearerRegexp.ReplaceAllString(text, "${1}"+RedactedValue)
+	return text
+}
+
+// RedactHeaders returns a copy of h with the values of secret headers masked
+func RedactHeaders(h http.Header) http.Header {
+	redacted := h.Clone()
+	for name, values := range redacted {
+		if !isSecretHeader(name) {
+			continue
+		}
+		masked := make([]string, len(values))
+		for i := range values {
+			masked[i] = RedactedValue
+		}
+		redacted[name] = masked
+	}
+	return redacted
+}
+
+func isSecretHeader(name string) bool {
+	for _, secret := range secretHeaders {
+		if strings.EqualFold(name, secret) {
+			return true
+		}
+	}
+	return false
+}
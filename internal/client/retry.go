@@ -4,14 +4,19 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+
+	"github.com/agbcloud/agbcloud-cli/pkg/events"
 )
 
 // RetryConfig defines retry behavior
@@ -20,6 +25,133 @@ type RetryConfig struct {
 	InitialDelay  time.Duration
 	MaxDelay      time.Duration
 	BackoffFactor float64
+	// MaxRetryAfter caps the wait a server asks for with Retry-After (0 = one minute)
+	MaxRetryAfter time.Duration
+}
+
+// defaultMaxRetryAfter is the longest Retry-After wait honored when MaxRetryAfter is not set
+const defaultMaxRetryAfter = time.Minute
+
+// RetryAfterHint is implemented by errors of responses that ask the client to wait a
+// given time before trying again, e.g. with a Retry-After header. A zero hint means
+// that the response did not ask for a wait.
+type RetryAfterHint interface {
+	RetryAfterHint() time.Duration
+}
+
+// ParseRetryAfter parses the value of a Retry-After header, either a number of seconds
+// or an HTTP date. ok is false for missing or malformed values.
+func ParseRetryAfter(value string, now time.Time) (wait time.Duration, ok bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if wait = date.Sub(now); wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// RetryAttempt describes a failed attempt of an operation run by Retry that is retried
+type RetryAttempt struct {
+	Attempt     int           // Number of the failed attempt, starting at 1
+	MaxAttempts int           // Attempts allowed in total
+	Err         error         // Error of the attempt
+	Latency     time.Duration // How long the attempt took
+	Delay       time.Duration // Wait before the next attempt
+	// RetryAfter is set when Delay is the wait the server asked for rather than the backoff
+	RetryAfter bool
+}
+
+// RetryOptions customizes Retry
+type RetryOptions struct {
+	// Retryable reports whether a failed attempt is worth repeating (default IsRetryableError).
+	// It is not asked about the last attempt.
+	Retryable func(err error) bool
+	// OnRetry is called before waiting for the next attempt
+	OnRetry func(RetryAttempt)
+	// After waits before the next attempt (default time.After); tests replace it to not wait
+	After func(d time.Duration) <-chan time.Time
+}
+
+// Retry calls op until it succeeds, returns an error that is not retryable, or has been
+// called MaxRetries+1 times. The wait between attempts grows by BackoffFactor up to
+// MaxDelay; an error with a RetryAfterHint waits as long as the server asked instead,
+// up to MaxRetryAfter. It returns the number of attempts made and the error of the last
+// one, or the context error if ctx is done while waiting.
+func Retry(ctx context.Context, config *RetryConfig, opts RetryOptions, op func(attempt int) error) (attempts int, err error) {
+	if config == nil {
+		config = DefaultRetryConfig()
+	}
+	retryable := opts.Retryable
+	if retryable == nil {
+		retryable = IsRetryableError
+	}
+	after := opts.After
+	if after == nil {
+		after = time.After
+	}
+	maxRetryAfter := config.MaxRetryAfter
+	if maxRetryAfter <= 0 {
+		maxRetryAfter = defaultMaxRetryAfter
+	}
+
+	maxAttempts := config.MaxRetries + 1
+	delay := config.InitialDelay
+	for attempt := 1; ; attempt++ {
+		started := time.Now()
+		err = op(attempt)
+		if err == nil || attempt == maxAttempts || !retryable(err) {
+			return attempt, err
+		}
+
+		retry := RetryAttempt{Attempt: attempt, MaxAttempts: maxAttempts, Err: err, Latency: time.Since(started), Delay: delay}
+		var hint RetryAfterHint
+		if errors.As(err, &hint) && hint.RetryAfterHint() > 0 {
+			retry.Delay, retry.RetryAfter = min(hint.RetryAfterHint(), maxRetryAfter), true
+		}
+		if opts.OnRetry != nil {
+			opts.OnRetry(retry)
+		}
+
+		select {
+		case <-after(retry.Delay):
+		case <-ctx.Done():
+			return attempt, ctx.Err()
+		}
+
+		delay = time.Duration(float64(delay) * config.BackoffFactor)
+		if delay > config.MaxDelay {
+			delay = config.MaxDelay
+		}
+	}
+}
+
+// retryableStatusError is the failure of an attempt that got a retryable HTTP status
+type retryableStatusError struct {
+	statusCode int
+	status     string
+	retryAfter time.Duration
+}
+
+// Error implements the error interface
+func (e *retryableStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.statusCode, e.status)
+}
+
+// RetryAfterHint implements RetryAfterHint
+func (e *retryableStatusError) RetryAfterHint() time.Duration {
+	return e.retryAfter
 }
 
 // DefaultRetryConfig returns a sensible default retry configuration
@@ -38,6 +170,12 @@ func IsRetryableError(err error) bool {
 		return false
 	}
 
+	// A pin mismatch will not go away by asking again
+	var pinErr *PinMismatchError
+	if errors.As(err, &pinErr) {
+		return false
+	}
+
 	// Network connection errors that are typically transient
 	errorStr := err.Error()
 
@@ -114,6 +252,8 @@ func IsRetryableHTTPStatus(statusCode int) bool {
 type RetryableHTTPClient struct {
 	client      *http.Client
 	retryConfig *RetryConfig
+	breaker     *CircuitBreaker
+	events      events.Sink
 }
 
 // NewRetryableHTTPClient creates a new HTTP client with retry capability
@@ -131,79 +271,145 @@ func NewRetryableHTTPClient(client *http.Client, config *RetryConfig) *Retryable
 	}
 }
 
+// SetCircuitBreaker makes the client fail fast while the breaker's circuit for the
+// request host is open. A nil breaker turns this off.
+func (r *RetryableHTTPClient) SetCircuitBreaker(breaker *CircuitBreaker) {
+	r.breaker = breaker
+}
+
+// SetEventSink reports every retry to sink. A nil sink turns this off.
+func (r *RetryableHTTPClient) SetEventSink(sink events.Sink) {
+	r.events = sink
+}
+
 // Do executes an HTTP request with retry logic
 func (r *RetryableHTTPClient) Do(req *http.Request) (*http.Response, error) {
-	var lastErr error
-	delay := r.retryConfig.InitialDelay
-
-	for attempt := 0; attempt <= r.retryConfig.MaxRetries; attempt++ {
+	var resp *http.Response
+	attempts, err := Retry(req.Context(), r.retryConfig, RetryOptions{
+		Retryable: func(err error) bool {
+			// A mutation without an idempotency key may have been carried out already
+			if !isReplayable(req) {
+				log.Debugf("[RETRY] %s request has no %s header, stopping attempts", req.Method, IdempotencyKeyHeader)
+				return false
+			}
+			var statusErr *retryableStatusError
+			if errors.As(err, &statusErr) {
+				return true
+			}
+			if errors.Is(err, ErrCircuitOpen) || !IsRetryableError(err) {
+				log.Debugf("[RETRY] Error is not retryable, stopping attempts")
+				return false
+			}
+			return true
+		},
+		OnRetry: func(attempt RetryAttempt) {
+			retry := events.Event{Kind: events.RequestRetry, Method: req.Method, Path: req.URL.Path, Attempt: attempt.Attempt, Delay: attempt.Delay, Duration: attempt.Latency}
+			var statusErr *retryableStatusError
+			if errors.As(attempt.Err, &statusErr) {
+				retry.StatusCode = statusErr.statusCode
+			} else {
+				retry.Err = redactError(attempt.Err)
+			}
+			events.Emit(req.Context(), r.events, retry)
+			log.Infof("[RETRY] Request failed (attempt %d/%d), retrying in %v...",
+				attempt.Attempt, attempt.MaxAttempts, attempt.Delay)
+		},
+	}, func(attempt int) error {
 		// Clone the request for each attempt (in case body needs to be re-read)
 		reqClone := req.Clone(req.Context())
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return err
+			}
+			reqClone.Body = body
+		}
 
-		log.Debugf("[RETRY] Attempt %d/%d for %s %s",
-			attempt+1, r.retryConfig.MaxRetries+1, req.Method, req.URL.String())
+		// Stop hammering a backend that keeps failing
+		if r.breaker != nil {
+			if err := r.breaker.Allow(req.URL.Host); err != nil {
+				return err
+			}
+		}
 
-		resp, err := r.client.Do(reqClone)
+		log.Debugf("[RETRY] Attempt %d/%d for %s %s",
+			attempt, r.retryConfig.MaxRetries+1, req.Method, RedactText(req.URL.String()))
 
-		// Success case
-		if err == nil && !IsRetryableHTTPStatus(resp.StatusCode) {
-			if attempt > 0 {
-				log.Infof("[RETRY] Request succeeded on attempt %d", attempt+1)
+		started := time.Now()
+		attemptResp, err := r.client.Do(reqClone)
+		if r.breaker != nil {
+			statusCode := 0
+			if err == nil {
+				statusCode = attemptResp.StatusCode
 			}
-			return resp, nil
+			r.breaker.Record(req.URL.Host, statusCode, err)
 		}
-
-		// Store the error for potential retry
 		if err != nil {
-			lastErr = err
-			log.Debugf("[RETRY] Attempt %d failed with error: %v", attempt+1, err)
-		} else {
-			lastErr = fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
-			log.Debugf("[RETRY] Attempt %d failed with HTTP status: %d", attempt+1, resp.StatusCode)
+			log.Debugf("[RETRY] Attempt %d failed after %v with error: %s", attempt, time.Since(started).Round(time.Millisecond), RedactText(err.Error()))
+			return err
+		}
+		if IsRetryableHTTPStatus(attemptResp.StatusCode) {
+			log.Debugf("[RETRY] Attempt %d failed after %v with HTTP status: %d", attempt, time.Since(started).Round(time.Millisecond), attemptResp.StatusCode)
+			statusErr := &retryableStatusError{statusCode: attemptResp.StatusCode, status: attemptResp.Status}
+			statusErr.retryAfter, _ = ParseRetryAfter(attemptResp.Header.Get("Retry-After"), time.Now())
 			// Close the response body to avoid resource leak
-			resp.Body.Close()
+			attemptResp.Body.Close()
+			return statusErr
 		}
+		resp = attemptResp
+		return nil
+	})
 
-		// Don't retry if this is the last attempt
-		if attempt == r.retryConfig.MaxRetries {
-			break
+	switch {
+	case err == nil:
+		if attempts > 1 {
+			log.Infof("[RETRY] Request succeeded on attempt %d", attempts)
 		}
-
-		// Check if the error is retryable
-		shouldRetry := false
-		if err != nil {
-			shouldRetry = IsRetryableError(err)
-			if !shouldRetry {
-				log.Debugf("[RETRY] Error is not retryable, stopping attempts")
-				break
-			}
-		} else if resp != nil {
-			shouldRetry = IsRetryableHTTPStatus(resp.StatusCode)
-			if !shouldRetry {
-				log.Debugf("[RETRY] HTTP status is not retryable, stopping attempts")
-				break
-			}
+		return resp, nil
+	case errors.Is(err, ErrCircuitOpen):
+		if attempts > 1 {
+			log.Warnf("[RETRY] Giving up after %d attempts: %v", attempts-1, err)
 		}
+		return nil, err
+	case err == req.Context().Err():
+		// Cancelled while waiting for the next attempt
+		return nil, err
+	}
+
+	log.Warnf("[RETRY] All %d attempts failed, giving up", attempts)
+	exhausted := &RetriesExhaustedError{Attempts: attempts, Err: err}
+	var statusErr *retryableStatusError
+	if errors.As(err, &statusErr) {
+		exhausted.StatusCode = statusErr.statusCode
+	}
+	return nil, exhausted
+}
 
-		// Wait before retrying
-		log.Infof("[RETRY] Request failed (attempt %d/%d), retrying in %v...",
-			attempt+1, r.retryConfig.MaxRetries+1, delay)
+// RetriesExhaustedError is returned when a request still failed after its retries
+type RetriesExhaustedError struct {
+	Attempts int
+	Err      error
+	// StatusCode is the HTTP status of the last attempt, or 0 if it got no response
+	StatusCode int
+}
 
-		select {
-		case <-time.After(delay):
-			// Delay elapsed, continue to next attempt
-		case <-req.Context().Done():
-			return nil, req.Context().Err()
-		}
+// Error implements the error interface
+func (e *RetriesExhaustedError) Error() string {
+	return fmt.Sprintf("request failed after %d attempts, last error: %v", e.Attempts, e.Err)
+}
 
-		// Calculate next delay with exponential backoff
-		delay = time.Duration(float64(delay) * r.retryConfig.BackoffFactor)
-		if delay > r.retryConfig.MaxDelay {
-			delay = r.retryConfig.MaxDelay
-		}
-	}
+// Unwrap returns the error of the last attempt
+func (e *RetriesExhaustedError) Unwrap() error {
+	return e.Err
+}
 
-	log.Warnf("[RETRY] All %d attempts failed, giving up", r.retryConfig.MaxRetries+1)
-	return nil, fmt.Errorf("request failed after %d attempts, last error: %w",
-		r.retryConfig.MaxRetries+1, lastErr)
+// IsTransientFailure reports whether a request failure is likely to go away when the
+// request is sent again later: a transient network error, or a retryable HTTP status
+// that persisted through the automatic retries
+func IsTransientFailure(err error) bool {
+	var exhausted *RetriesExhaustedError
+	if errors.As(err, &exhausted) && exhausted.StatusCode != 0 {
+		return IsRetryableHTTPStatus(exhausted.StatusCode)
+	}
+	return IsRetryableError(err)
 }
@@ -0,0 +1,491 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ServiceAccountAPI manages machine accounts, which authenticate pipelines with scoped,
+// revocable tokens instead of a personal login session
+type ServiceAccountAPI interface {
+	CreateServiceAccount(ctx context.Context, loginToken, sessionId, name string, scopes []string) (ServiceAccountResponse, *http.Response, error)
+	ListServiceAccounts(ctx context.Context, loginToken, sessionId string) (ServiceAccountListResponse, *http.Response, error)
+	DeleteServiceAccount(ctx context.Context, loginToken, sessionId, accountId string) (ServiceAccountDeleteResponse, *http.Response, error)
+	IssueServiceAccountToken(ctx context.Context, loginToken, sessionId, accountId string, ttlSeconds int) (ServiceAccountTokenResponse, *http.Response, error)
+	LoginServiceAccount(ctx context.Context, accountToken string) (ServiceAccountLoginResponse, *http.Response, error)
+}
+
+// ServiceAccountAPIService implements ServiceAccountAPI interface
+type ServiceAccountAPIService service
+
+// ServiceAccountInfo describes a service account
+type ServiceAccountInfo struct {
+	AccountID    string   `json:"accountId"`
+	Name         string   `json:"name"`
+	Scopes       []string `json:"scopes"`
+	CreateTime   string   `json:"createTime"`
+	LastUsedTime string   `json:"lastUsedTime,omitempty"`
+}
+
+// ServiceAccountCreateRequest represents the request body for /api/serviceaccount/create API
+type ServiceAccountCreateRequest struct {
+	LoginToken string   `json:"loginToken"`
+	SessionId  string   `json:"sessionId"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+}
+
+// ServiceAccountResponse represents the response from /api/serviceaccount/create API
+type ServiceAccountResponse struct {
+	Code           string             `json:"code"`
+	RequestID      string             `json:"requestId"`
+	Success        bool               `json:"success"`
+	Data           ServiceAccountInfo `json:"data"`
+	TraceID        string             `json:"traceId"`
+	HTTPStatusCode int                `json:"httpStatusCode"`
+}
+
+// ServiceAccountListResponse represents the response from /api/serviceaccount/list API
+type ServiceAccountListResponse struct {
+	Code           string                 `json:"code"`
+	RequestID      string                 `json:"requestId"`
+	Success        bool                   `json:"success"`
+	Data           ServiceAccountListData `json:"data"`
+	TraceID        string                 `json:"traceId"`
+	HTTPStatusCode int                    `json:"httpStatusCode"`
+}
+
+// ServiceAccountListData represents the data field in the service account list response
+type ServiceAccountListData struct {
+	Accounts []ServiceAccountInfo `json:"accounts"`
+	Total    int                  `json:"total"`
+}
+
+// ServiceAccountDeleteRequest represents the request body for /api/serviceaccount/delete API
+type ServiceAccountDeleteRequest struct {
+	LoginToken string `json:"loginToken"`
+	SessionId  string `json:"sessionId"`
+	AccountId  string `json:"accountId"`
+}
+
+// ServiceAccountDeleteResponse represents the response from /api/serviceaccount/delete API
+type ServiceAccountDeleteResponse struct {
+	Code           string `json:"code"`
+	RequestID      string `json:"requestId"`
+	Success        bool   `json:"success"`
+	Data           bool   `json:"data"`
+	TraceID        string `json:"traceId"`
+	HTTPStatusCode int    `json:"httpStatusCode"`
+}
+
+// ServiceAccountTokenRequest represents the request body for /api/serviceaccount/token/issue API
+type ServiceAccountTokenRequest struct {
+	LoginToken string `json:"loginToken"`
+	SessionId  string `json:"sessionId"`
+	AccountId  string `json:"accountId"`
+	TTLSeconds int    `json:"ttlSeconds"`
+}
+
+// ServiceAccountTokenResponse represents the response from /api/serviceaccount/token/issue API
+type ServiceAccountTokenResponse struct {
+	Code           string                  `json:"code"`
+	RequestID      string                  `json:"requestId"`
+	Success        bool                    `json:"success"`
+	Data           ServiceAccountTokenData `json:"data"`
+	TraceID        string                  `json:"traceId"`
+	HTTPStatusCode int                     `json:"httpStatusCode"`
+}
+
+// ServiceAccountTokenData is an issued token. The token itself is only ever returned
+// by the request that issued it.
+type ServiceAccountTokenData struct {
+	TokenID      string `json:"tokenId"`
+	AccountToken string `json:"accountToken"`
+	ExpiresAt    string `json:"expiresAt"` // RFC 3339
+}
+
+// ServiceAccountLoginRequest represents the request body for /api/serviceaccount/login API
+type ServiceAccountLoginRequest struct {
+	AccountToken string `json:"accountToken"`
+}
+
+// ServiceAccountLoginResponse represents the response from /api/serviceaccount/login API
+type ServiceAccountLoginResponse struct {
+	Code           string                  `json:"code"`
+	RequestID      string                  `json:"requestId"`
+	Success        bool                    `json:"success"`
+	Data           ServiceAccountLoginData `json:"data"`
+	TraceID        string                  `json:"traceId"`
+	HTTPStatusCode int                     `json:"httpStatusCode"`
+}
+
+// ServiceAccountLoginData holds the session of a service account
+type ServiceAccountLoginData struct {
+	LoginToken string `json:"loginToken"`
+	SessionId  string `json:"sessionId"`
+	AccountID  string `json:"accountId"`
+	Name       string `json:"name"`
+	ExpiresAt  string `json:"expiresAt"` // RFC 3339
+}
+
+// CreateServiceAccount creates a service account with the given scopes
+func (i *ServiceAccountAPIService) CreateServiceAccount(ctx context.Context, loginToken, sessionId, name string, scopes []string) (ServiceAccountResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue ServiceAccountResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/serviceaccount/create"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "CreateServiceAccount")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if name == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "name parameter is required"}
+	}
+	if len(scopes) == 0 {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "scopes parameter is required"}
+	}
+
+	// Create request body
+	requestBody := ServiceAccountCreateRequest{
+		LoginToken: loginToken,
+		SessionId:  sessionId,
+		Name:       name,
+		Scopes:     scopes,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
+
+// ListServiceAccounts retrieves the service accounts of the user
+func (i *ServiceAccountAPIService) ListServiceAccounts(ctx context.Context, loginToken, sessionId string) (ServiceAccountListResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		localVarReturnValue ServiceAccountListResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/serviceaccount/list"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "ListServiceAccounts")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	localVarQueryParams.Add("loginToken", loginToken)
+
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	localVarQueryParams.Add("sessionId", sessionId)
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
+
+// DeleteServiceAccount deletes a service account and revokes all of its tokens
+func (i *ServiceAccountAPIService) DeleteServiceAccount(ctx context.Context, loginToken, sessionId, accountId string) (ServiceAccountDeleteResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue ServiceAccountDeleteResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/serviceaccount/delete"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "DeleteServiceAccount")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if accountId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "accountId parameter is required"}
+	}
+
+	// Create request body
+	requestBody := ServiceAccountDeleteRequest{
+		LoginToken: loginToken,
+		SessionId:  sessionId,
+		AccountId:  accountId,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
+
+// IssueServiceAccountToken issues a token of a service account that expires after
+// ttlSeconds. The token is only returned by this request.
+func (i *ServiceAccountAPIService) IssueServiceAccountToken(ctx context.Context, loginToken, sessionId, accountId string, ttlSeconds int) (ServiceAccountTokenResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue ServiceAccountTokenResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/serviceaccount/token/issue"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "IssueServiceAccountToken")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if accountId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "accountId parameter is required"}
+	}
+	if ttlSeconds <= 0 {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "ttlSeconds parameter must be positive"}
+	}
+
+	// Create request body
+	requestBody := ServiceAccountTokenRequest{
+		LoginToken: loginToken,
+		SessionId:  sessionId,
+		AccountId:  accountId,
+		TTLSeconds: ttlSeconds,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
+
+// LoginServiceAccount exchanges a service account token for a session. The token is
+// sent in the request body so that it never appears in URLs or access logs.
+func (i *ServiceAccountAPIService) LoginServiceAccount(ctx context.Context, accountToken string) (ServiceAccountLoginResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue ServiceAccountLoginResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/serviceaccount/login"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "LoginServiceAccount")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if accountToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "accountToken parameter is required"}
+	}
+
+	// Create request body
+	requestBody := ServiceAccountLoginRequest{
+		AccountToken: accountToken,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
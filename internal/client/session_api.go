@@ -0,0 +1,204 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// SessionAPI lists and revokes the login sessions of the user, on this and other machines
+type SessionAPI interface {
+	ListSessions(ctx context.Context, loginToken, sessionId string) (SessionListResponse, *http.Response, error)
+	RevokeSession(ctx context.Context, loginToken, sessionId, targetSessionId string) (SessionRevokeResponse, *http.Response, error)
+}
+
+// SessionAPIService implements SessionAPI interface
+type SessionAPIService service
+
+// SessionInfo describes an active login session
+type SessionInfo struct {
+	SessionID string `json:"sessionId"`
+	// Device describes the machine the session was created on, as reported at login
+	Device       string `json:"device"`
+	CreateTime   string `json:"createTime"`
+	LastUsedTime string `json:"lastUsedTime"`
+	// Current is set for the session the request was made with
+	Current bool `json:"current"`
+}
+
+// SessionListResponse represents the response from /api/session/list API
+type SessionListResponse struct {
+	Code           string          `json:"code"`
+	RequestID      string          `json:"requestId"`
+	Success        bool            `json:"success"`
+	Data           SessionListData `json:"data"`
+	TraceID        string          `json:"traceId"`
+	HTTPStatusCode int             `json:"httpStatusCode"`
+}
+
+// SessionListData represents the data field in the session list response
+type SessionListData struct {
+	Sessions []SessionInfo `json:"sessions"`
+	Total    int           `json:"total"`
+}
+
+// SessionRevokeRequest represents the request body for /api/session/revoke API
+type SessionRevokeRequest struct {
+	LoginToken      string `json:"loginToken"`
+	SessionId       string `json:"sessionId"`
+	TargetSessionId string `json:"targetSessionId"`
+}
+
+// SessionRevokeResponse represents the response from /api/session/revoke API
+type SessionRevokeResponse struct {
+	Code           string `json:"code"`
+	RequestID      string `json:"requestId"`
+	Success        bool   `json:"success"`
+	Data           bool   `json:"data"`
+	TraceID        string `json:"traceId"`
+	HTTPStatusCode int    `json:"httpStatusCode"`
+}
+
+// ListSessions retrieves the active login sessions of the user
+func (i *SessionAPIService) ListSessions(ctx context.Context, loginToken, sessionId string) (SessionListResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		localVarReturnValue SessionListResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/session/list"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "ListSessions")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	localVarQueryParams.Add("loginToken", loginToken)
+
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	localVarQueryParams.Add("sessionId", sessionId)
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
+
+// RevokeSession ends a login session of the user, given by its session ID. The tokens of
+// the session are rejected from then on, wherever they are stored.
+func (i *SessionAPIService) RevokeSession(ctx context.Context, loginToken, sessionId, targetSessionId string) (SessionRevokeResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue SessionRevokeResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/session/revoke"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "RevokeSession")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if targetSessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "targetSessionId parameter is required"}
+	}
+
+	// Create request body
+	requestBody := SessionRevokeRequest{
+		LoginToken:      loginToken,
+		SessionId:       sessionId,
+		TargetSessionId: targetSessionId,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
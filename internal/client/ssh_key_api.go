@@ -0,0 +1,295 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// SSHKeyAPI registers the public keys that are injected into activated instances
+type SSHKeyAPI interface {
+	AddSSHKey(ctx context.Context, loginToken, sessionId, name, publicKey string) (SSHKeyAddResponse, *http.Response, error)
+	ListSSHKeys(ctx context.Context, loginToken, sessionId string) (SSHKeyListResponse, *http.Response, error)
+	DeleteSSHKey(ctx context.Context, loginToken, sessionId, keyId string) (SSHKeyDeleteResponse, *http.Response, error)
+}
+
+// SSHKeyAPIService implements SSHKeyAPI interface
+type SSHKeyAPIService service
+
+// SSHKeyInfo describes a registered public key
+type SSHKeyInfo struct {
+	KeyID       string `json:"keyId"`
+	Name        string `json:"name"`
+	KeyType     string `json:"keyType"`
+	Fingerprint string `json:"fingerprint"`
+	PublicKey   string `json:"publicKey,omitempty"`
+	CreateTime  string `json:"createTime"`
+}
+
+// SSHKeyAddRequest represents the request body for /api/sshkey/add API
+type SSHKeyAddRequest struct {
+	LoginToken string `json:"loginToken"`
+	SessionId  string `json:"sessionId"`
+	Name       string `json:"name"`
+	PublicKey  string `json:"publicKey"`
+}
+
+// SSHKeyAddResponse represents the response from /api/sshkey/add API
+type SSHKeyAddResponse struct {
+	Code           string     `json:"code"`
+	RequestID      string     `json:"requestId"`
+	Success        bool       `json:"success"`
+	Data           SSHKeyInfo `json:"data"`
+	TraceID        string     `json:"traceId"`
+	HTTPStatusCode int        `json:"httpStatusCode"`
+}
+
+// SSHKeyListResponse represents the response from /api/sshkey/list API
+type SSHKeyListResponse struct {
+	Code           string         `json:"code"`
+	RequestID      string         `json:"requestId"`
+	Success        bool           `json:"success"`
+	Data           SSHKeyListData `json:"data"`
+	TraceID        string         `json:"traceId"`
+	HTTPStatusCode int            `json:"httpStatusCode"`
+}
+
+// SSHKeyListData represents the data field in the SSH key list response
+type SSHKeyListData struct {
+	Keys  []SSHKeyInfo `json:"keys"`
+	Total int          `json:"total"`
+}
+
+// SSHKeyDeleteRequest represents the request body for /api/sshkey/delete API
+type SSHKeyDeleteRequest struct {
+	LoginToken string `json:"loginToken"`
+	SessionId  string `json:"sessionId"`
+	KeyId      string `json:"keyId"`
+}
+
+// SSHKeyDeleteResponse represents the response from /api/sshkey/delete API
+type SSHKeyDeleteResponse struct {
+	Code           string `json:"code"`
+	RequestID      string `json:"requestId"`
+	Success        bool   `json:"success"`
+	Data           bool   `json:"data"`
+	TraceID        string `json:"traceId"`
+	HTTPStatusCode int    `json:"httpStatusCode"`
+}
+
+// AddSSHKey registers a public key under a name
+func (i *SSHKeyAPIService) AddSSHKey(ctx context.Context, loginToken, sessionId, name, publicKey string) (SSHKeyAddResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue SSHKeyAddResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/sshkey/add"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "AddSSHKey")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if publicKey == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "publicKey parameter is required"}
+	}
+
+	// Create request body
+	requestBody := SSHKeyAddRequest{
+		LoginToken: loginToken,
+		SessionId:  sessionId,
+		Name:       name,
+		PublicKey:  publicKey,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
+
+// ListSSHKeys retrieves the registered public keys
+func (i *SSHKeyAPIService) ListSSHKeys(ctx context.Context, loginToken, sessionId string) (SSHKeyListResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		localVarReturnValue SSHKeyListResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/sshkey/list"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "ListSSHKeys")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	localVarQueryParams.Add("loginToken", loginToken)
+
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	localVarQueryParams.Add("sessionId", sessionId)
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
+
+// DeleteSSHKey removes a registered public key. Instances activated afterwards no longer
+// accept it; running instances keep the keys they were started with.
+func (i *SSHKeyAPIService) DeleteSSHKey(ctx context.Context, loginToken, sessionId, keyId string) (SSHKeyDeleteResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarReturnValue SSHKeyDeleteResponse
+	)
+
+	// Build the request path
+	localVarPath := "/api/sshkey/delete"
+
+	// Use the configured server URL (defaults to agb.cloud)
+	serverURL, err := i.client.cfg.ServerURLWithContext(ctx, "DeleteSSHKey")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath = serverURL + localVarPath
+
+	localVarHeaderParams := make(map[string]string)
+
+	// Set headers
+	localVarHeaderParams["Accept"] = "application/json"
+	localVarHeaderParams["Content-Type"] = "application/json"
+
+	// Validate required parameters
+	if loginToken == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "loginToken parameter is required"}
+	}
+	if sessionId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "sessionId parameter is required"}
+	}
+	if keyId == "" {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: "keyId parameter is required"}
+	}
+
+	// Create request body
+	requestBody := SSHKeyDeleteRequest{
+		LoginToken: loginToken,
+		SessionId:  sessionId,
+		KeyId:      keyId,
+	}
+
+	// Prepare request
+	req, err := i.client.prepareRequest(ctx, localVarPath, localVarHTTPMethod, requestBody, localVarHeaderParams, url.Values{})
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := i.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = i.client.decodeResponse(&localVarReturnValue, localVarBody, localVarHTTPResponse)
+	return localVarReturnValue, localVarHTTPResponse, err
+}
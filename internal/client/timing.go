@@ -0,0 +1,163 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CallTiming holds the phases of a single API call as observed by net/http/httptrace.
+// Phases that did not happen (e.g. DNS and TLS on a reused connection) are zero.
+type CallTiming struct {
+	Method     string
+	Path       string
+	StatusCode int
+	// Reused reports whether the call went over an existing keep-alive connection
+	Reused  bool
+	DNS     time.Duration
+	Connect time.Duration
+	TLS     time.Duration
+	// TTFB is the time from sending the request until the first response byte
+	TTFB  time.Duration
+	Total time.Duration
+	Err   string
+}
+
+// MarshalJSON renders durations as fractional milliseconds
+func (t CallTiming) MarshalJSON() ([]byte, error) {
+	ms := func(d time.Duration) float64 { return float64(d.Microseconds()) / 1000 }
+	return json.Marshal(struct {
+		Method           string  `json:"method"`
+		Path             string  `json:"path"`
+		StatusCode       int     `json:"statusCode,omitempty"`
+		ConnectionReused bool    `json:"connectionReused"`
+		DNSMs            float64 `json:"dnsMs"`
+		ConnectMs        float64 `json:"connectMs"`
+		TLSMs            float64 `json:"tlsMs"`
+		TTFBMs           float64 `json:"ttfbMs"`
+		TotalMs          float64 `json:"totalMs"`
+		Error            string  `json:"error,omitempty"`
+	}{t.Method, t.Path, t.StatusCode, t.Reused, ms(t.DNS), ms(t.Connect), ms(t.TLS), ms(t.TTFB), ms(t.Total), t.Err})
+}
+
+// TimingRecorder collects CallTiming entries. It is safe for concurrent use.
+type TimingRecorder struct {
+	mu    sync.Mutex
+	calls []CallTiming
+}
+
+// Calls returns a copy of the recorded timings in call order
+func (r *TimingRecorder) Calls() []CallTiming {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]CallTiming(nil), r.calls...)
+}
+
+func (r *TimingRecorder) record(t CallTiming) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, t)
+}
+
+// defaultTiming is attached to every client created by NewFromConfig once EnableTiming is called
+var defaultTiming atomic.Pointer[TimingRecorder]
+
+// EnableTiming starts recording timings for all API clients created afterwards with NewFromConfig
+func EnableTiming() *TimingRecorder {
+	recorder := &TimingRecorder{}
+	defaultTiming.Store(recorder)
+	return recorder
+}
+
+// DisableTiming stops attaching a recorder to new API clients
+func DisableTiming() {
+	defaultTiming.Store(nil)
+}
+
+// DefaultTimingRecorder returns the recorder installed by EnableTiming, or nil if timing is disabled
+func DefaultTimingRecorder() *TimingRecorder {
+	return defaultTiming.Load()
+}
+
+// callTrace measures the phases of one request. When the request is retried the
+// hooks fire again and the last attempt wins, which is the one that produced the response.
+type callTrace struct {
+	mu                     sync.Mutex
+	start                  time.Time
+	dnsStart, connectStart time.Time
+	tlsStart, wroteRequest time.Time
+	timing                 CallTiming
+}
+
+// withTimingTrace attaches an httptrace.ClientTrace to the request
+func withTimingTrace(req *http.Request) (*http.Request, *callTrace) {
+	ct := &callTrace{start: time.Now()}
+	ct.timing.Method = req.Method
+	ct.timing.Path = req.URL.Path
+
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { ct.mark(&ct.dnsStart) },
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			ct.since(&ct.dnsStart, &ct.timing.DNS)
+		},
+		ConnectStart: func(string, string) { ct.mark(&ct.connectStart) },
+		ConnectDone: func(string, string, error) {
+			ct.since(&ct.connectStart, &ct.timing.Connect)
+		},
+		TLSHandshakeStart: func() { ct.mark(&ct.tlsStart) },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			ct.since(&ct.tlsStart, &ct.timing.TLS)
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			ct.mu.Lock()
+			ct.timing.Reused = info.Reused
+			if info.Reused {
+				// Phases from an earlier attempt do not apply to this connection
+				ct.timing.DNS, ct.timing.Connect, ct.timing.TLS = 0, 0, 0
+			}
+			ct.mu.Unlock()
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) { ct.mark(&ct.wroteRequest) },
+		GotFirstResponseByte: func() {
+			ct.since(&ct.wroteRequest, &ct.timing.TTFB)
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace)), ct
+}
+
+func (ct *callTrace) mark(t *time.Time) {
+	ct.mu.Lock()
+	*t = time.Now()
+	ct.mu.Unlock()
+}
+
+func (ct *callTrace) since(start *time.Time, d *time.Duration) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	if !start.IsZero() {
+		*d = time.Since(*start)
+	}
+}
+
+// finish completes the measurement and stores it in the recorder
+func (ct *callTrace) finish(recorder *TimingRecorder, resp *http.Response, err error) {
+	ct.mu.Lock()
+	timing := ct.timing
+	ct.mu.Unlock()
+
+	timing.Total = time.Since(ct.start)
+	if resp != nil {
+		timing.StatusCode = resp.StatusCode
+	}
+	if err != nil {
+		timing.Err = RedactText(err.Error())
+	}
+	recorder.record(timing)
+}
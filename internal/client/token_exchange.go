@@ -0,0 +1,95 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// TokenExchangeMode controls whether OAuth tokens are exchanged with a JSON body or query parameters
+type TokenExchangeMode string
+
+const (
+	// TokenExchangeAuto prefers POST with a JSON body and falls back to query
+	// parameters when the server does not support it (default)
+	TokenExchangeAuto TokenExchangeMode = "auto"
+	// TokenExchangePost always sends tokens in a JSON body
+	TokenExchangePost TokenExchangeMode = "post"
+	// TokenExchangeQuery always sends tokens as query parameters (legacy servers)
+	TokenExchangeQuery TokenExchangeMode = "query"
+)
+
+// ParseTokenExchangeMode converts a user supplied value into a TokenExchangeMode.
+// An empty value selects TokenExchangeAuto.
+func ParseTokenExchangeMode(value string) (TokenExchangeMode, error) {
+	switch mode := TokenExchangeMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return TokenExchangeAuto, nil
+	case TokenExchangeAuto, TokenExchangePost, TokenExchangeQuery:
+		return mode, nil
+	default:
+		return TokenExchangeAuto, fmt.Errorf("unsupported token exchange mode '%s' (supported: auto, post, query)", value)
+	}
+}
+
+// isBodyExchangeUnsupported reports whether a failed POST exchange means the server
+// only offers the query-string variant of the endpoint
+func isBodyExchangeUnsupported(httpResp *http.Response, err error) bool {
+	var apiErr *GenericOpenAPIError
+	if httpResp == nil || !errors.As(err, &apiErr) {
+		return false
+	}
+	switch httpResp.StatusCode {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusUnsupportedMediaType, http.StatusNotImplemented:
+		return true
+	}
+	return false
+}
+
+// useBodyExchange reports whether the next token exchange should try the POST variant
+func (o *OAuthAPIService) useBodyExchange() bool {
+	switch o.client.cfg.TokenExchange {
+	case TokenExchangeQuery:
+		return false
+	case TokenExchangePost:
+		return true
+	default:
+		return !o.client.queryTokenExchange.Load()
+	}
+}
+
+// ExchangeAuthCode exchanges an OAuth authorization code for session tokens using the
+// configured TokenExchangeMode. In auto mode the POST endpoint is tried first and the
+// query-string endpoint is used if the server does not support it.
+func (o *OAuthAPIService) ExchangeAuthCode(ctx context.Context, loginClient, oauthProvider, authCode, localhostPort string) (OAuthLoginTranslateResponse, *http.Response, error) {
+	if o.useBodyExchange() {
+		response, httpResp, err := o.LoginTranslateWithBody(ctx, loginClient, oauthProvider, authCode, localhostPort)
+		if o.client.cfg.TokenExchange == TokenExchangePost || !isBodyExchangeUnsupported(httpResp, err) {
+			return response, httpResp, err
+		}
+		log.Debugf("Server does not support POST login translate (HTTP %d), falling back to query parameters", httpResp.StatusCode)
+		o.client.queryTokenExchange.Store(true)
+	}
+	return o.LoginTranslateWithPort(ctx, loginClient, oauthProvider, authCode, localhostPort)
+}
+
+// RenewSession refreshes the login session using the configured TokenExchangeMode,
+// preferring the POST endpoint when the server supports it
+func (o *OAuthAPIService) RenewSession(ctx context.Context, keepAliveToken, sessionId string) (OAuthRefreshTokenResponse, *http.Response, error) {
+	if o.useBodyExchange() {
+		response, httpResp, err := o.RefreshTokenWithBody(ctx, keepAliveToken, sessionId)
+		if o.client.cfg.TokenExchange == TokenExchangePost || !isBodyExchangeUnsupported(httpResp, err) {
+			return response, httpResp, err
+		}
+		log.Debugf("Server does not support POST token refresh (HTTP %d), falling back to query parameters", httpResp.StatusCode)
+		o.client.queryTokenExchange.Store(true)
+	}
+	return o.RefreshToken(ctx, keepAliveToken, sessionId)
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/agbcloud/agbcloud-cli/internal/tracing"
+)
+
+// startRequestSpan starts a span for an API call when tracing is enabled and passes
+// the trace on to the server in the W3C traceparent header. The span records the path
+// but not the query, which carries credentials.
+func startRequestSpan(request *http.Request) (*http.Request, *tracing.Span) {
+	ctx, span := tracing.Start(request.Context(), request.Method+" "+request.URL.Path, tracing.SpanKindClient,
+		tracing.String("http.request.method", request.Method),
+		tracing.String("url.path", request.URL.Path),
+		tracing.String("server.address", request.URL.Hostname()),
+	)
+	if span == nil {
+		return request, nil
+	}
+	request = request.WithContext(ctx)
+	request.Header.Set("traceparent", span.Traceparent())
+	return request, span
+}
+
+// finishRequestSpan ends the span of an API call; error responses mark it as failed
+func finishRequestSpan(span *tracing.Span, resp *http.Response, err error) {
+	if span == nil {
+		return
+	}
+	if resp != nil {
+		span.SetAttributes(tracing.Int("http.response.status_code", resp.StatusCode))
+		if err == nil && resp.StatusCode >= 400 {
+			err = fmt.Errorf("HTTP %d", resp.StatusCode)
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("%s", RedactText(err.Error()))
+	}
+	span.Finish(err)
+}
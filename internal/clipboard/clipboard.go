@@ -0,0 +1,91 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clipboard places text on the system clipboard through the clipboard
+// program of the platform: pbcopy on macOS, clip on Windows, and wl-copy, xclip
+// or xsel on Linux and other Unix systems.
+//
+// Systems without a clipboard, such as servers reached over SSH and containers,
+// report ErrUnavailable, which callers treat as a no-op.
+package clipboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// EnvCommand overrides the clipboard program; the text is written to its standard
+// input. "off" disables the clipboard.
+const EnvCommand = "AGB_CLI_CLIPBOARD"
+
+// timeout bounds how long the clipboard program may take
+const timeout = 5 * time.Second
+
+// ErrUnavailable is returned when the system has no clipboard that can be used
+var ErrUnavailable = errors.New("no clipboard is available")
+
+// candidate is a clipboard program and its arguments
+type candidate []string
+
+// candidates returns the clipboard programs to try, in order of preference
+func candidates() []candidate {
+	if line := strings.TrimSpace(os.Getenv(EnvCommand)); line != "" {
+		if strings.EqualFold(line, "off") {
+			return nil
+		}
+		return []candidate{strings.Fields(line)}
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return []candidate{{"pbcopy"}}
+	case "windows":
+		return []candidate{{"clip.exe"}}
+	}
+	// Without a display server there is no clipboard, even when the programs are installed
+	var found []candidate
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		found = append(found, candidate{"wl-copy"})
+	}
+	if os.Getenv("DISPLAY") != "" {
+		found = append(found, candidate{"xclip", "-selection", "clipboard"}, candidate{"xsel", "--clipboard", "--input"})
+	}
+	return found
+}
+
+// Available reports whether a clipboard program can be found
+func Available() bool {
+	for _, c := range candidates() {
+		if _, err := exec.LookPath(c[0]); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Copy places text on the clipboard. It returns ErrUnavailable when no clipboard
+// program can be found, and an error describing the failure when the program fails.
+func Copy(text string) error {
+	for _, c := range candidates() {
+		path, err := exec.LookPath(c[0])
+		if err != nil {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, path, c[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		// Output is not captured: xclip and xsel leave a process behind that serves the
+		// clipboard and would keep an output pipe open
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s failed: %w", c[0], err)
+		}
+		return nil
+	}
+	return ErrUnavailable
+}
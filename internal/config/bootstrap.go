@@ -0,0 +1,106 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ExampleFileName is the commented reference of every setting, written next to
+// config.json on first run. config.json itself is plain JSON, which has no comments.
+const ExampleFileName = "config.example.jsonc"
+
+// exampleConfig is the content of ExampleFileName
+const exampleConfig = `// AgbCloud CLI configuration reference, written on first run.
+//
+// The CLI reads its settings from config.json in this directory. That file is plain
+// JSON without comments and is rewritten by the CLI, e.g. when you log in. Copy the
+// settings you need into it, or change them with 'agbcloud config set --json'.
+// Every setting is optional; the values below are examples.
+{
+  // API endpoint; AGB_CLI_ENDPOINT and --endpoint take precedence
+  "endpoint": "https://agb.cloud",
+
+  // Endpoints tried in order when the endpoint above is unavailable
+  "fallbackEndpoints": [],
+
+  // How requests are spread over the endpoints: priority or round-robin
+  "endpointStrategy": "priority",
+
+  // Default output format: table, wide, json, csv or pson
+  "output": "table",
+
+  // Timestamp display: local, utc, relative or raw
+  "timeFormat": "local",
+
+  // Time zone of displayed timestamps: local, UTC or a name such as Asia/Shanghai
+  "timeZone": "local",
+
+  // Program that pages long output, or "off"; AGB_PAGER takes precedence
+  "pager": "less -R",
+
+  // Prefix progress and status lines with the time they were printed (--timestamps)
+  "timestamps": false,
+
+  // Remember the flags of commands that support it (see 'agbcloud config sticky')
+  "sticky": false,
+
+  // Images batch commands work on at the same time (--concurrency), 1-10
+  "concurrency": 3,
+
+  // How long the System image list checked by 'image create' is reused, or "0"
+  "systemImageTTL": "1h",
+
+  // Default for 'image create --cleanup-on-failure'
+  "cleanupOnFailure": false,
+
+  // Local ports scanned for the login callback
+  "loginPortRange": "40000-40100",
+
+  // Values 'image list' uses when --type and --size are not given
+  "defaults": {
+    "imageType": "User",
+    "pageSize": 10
+  },
+
+  // OpenTelemetry collector that receives command traces
+  "otlpEndpoint": "http://localhost:4318",
+
+  // Named sets of settings, selected with AGB_CLI_PROFILE or "activeProfile"
+  "profiles": {
+    "staging": {
+      "endpoint": "https://staging.agb.example.com",
+      "output": "json"
+    }
+  },
+  "activeProfile": ""
+}
+`
+
+// Bootstrap prepares the configuration directory on first run: when config.json does
+// not exist yet it creates the directory, an empty config.json and the commented
+// reference ExampleFileName. It reports whether this was the first run.
+func Bootstrap() (bool, error) {
+	configFilePath, err := getConfigPath()
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(configFilePath); err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+
+	dir := filepath.Dir(configFilePath)
+	if err := os.MkdirAll(dir, privateDirMode); err != nil {
+		return false, err
+	}
+	// The reference is only a help; a failure to write it does not stop the CLI
+	_ = os.WriteFile(filepath.Join(dir, ExampleFileName), []byte(exampleConfig), privateFileMode)
+	if err := os.WriteFile(configFilePath, []byte("{}\n"), privateFileMode); err != nil {
+		return false, err
+	}
+	return true, nil
+}
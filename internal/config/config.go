@@ -7,16 +7,91 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
+
+	log "github.com/sirupsen/logrus"
 )
 
 // Config represents the CLI configuration
-// Only stores authentication tokens, endpoint is determined from environment variables
+// Stores authentication tokens and shareable settings; the --endpoint flag and the AGB_CLI_ENDPOINT environment variable override the configured endpoint
 type Config struct {
-	Token *Token `json:"token,omitempty"` // OAuth token authentication
+	Token              *Token              `json:"token,omitempty"`              // OAuth token authentication
+	Endpoint           string              `json:"endpoint,omitempty"`           // API endpoint used when no profile overrides it
+	FallbackEndpoints  []string            `json:"fallbackEndpoints,omitempty"`  // Endpoints tried in order when Endpoint is unavailable
+	EndpointStrategy   string              `json:"endpointStrategy,omitempty"`   // How requests are spread over endpoints: priority or round-robin
+	Output             string              `json:"output,omitempty"`             // Default output format
+	TimeFormat         string              `json:"timeFormat,omitempty"`         // Default timestamp display format
+	TimeZone           string              `json:"timeZone,omitempty"`           // Time zone timestamps are shown in, e.g. "UTC" or "Asia/Shanghai"; --tz overrides it
+	Pager              string              `json:"pager,omitempty"`              // Program that pages long output, e.g. "less -R"; "off" disables paging
+	Timestamps         bool                `json:"timestamps,omitempty"`         // Prefix progress and status lines with the time they were printed, as with --timestamps
+	Concurrency        int                 `json:"concurrency,omitempty"`        // Images batch commands work on at the same time; --concurrency overrides it
+	SystemImageTTL     string              `json:"systemImageTTL,omitempty"`     // How long the cached System image list is used before it is fetched again, e.g. "1h"; "0" disables the cache
+	ActiveProfile      string              `json:"activeProfile,omitempty"`      // Profile used when AGB_CLI_PROFILE is not set
+	Profiles           map[string]Profile  `json:"profiles,omitempty"`           // Named sets of settings
+	ImageGC            *ImageGCPolicy      `json:"imageGC,omitempty"`            // Saved policy for 'image gc'
+	CleanupOnFailure   *bool               `json:"cleanupOnFailure,omitempty"`   // Default for 'image create --cleanup-on-failure'
+	LoginPortRange     string              `json:"loginPortRange,omitempty"`     // Local ports scanned for the login callback, e.g. "40000-40100"
+	LoginPorts         []string            `json:"loginPorts,omitempty"`         // Login callback ports that worked before, most recent first
+	Sticky             bool                `json:"sticky,omitempty"`             // Remember the flags of commands that support it and reuse them
+	StickyFlags        map[string]FlagSet  `json:"stickyFlags,omitempty"`        // Remembered flags per profile; DefaultStickyProfile when none is active
+	PinnedImages       map[string][]string `json:"pinnedImages,omitempty"`       // Pinned image IDs per profile, most recently pinned first; DefaultStickyProfile when none is active
+	OTLPEndpoint       string              `json:"otlpEndpoint,omitempty"`       // OpenTelemetry collector that receives command traces, e.g. "http://localhost:4318"
+	UploadStorage      *UploadStorage      `json:"uploadStorage,omitempty"`      // Overrides for Dockerfile uploads in private deployments
+	Defaults           *ListDefaults       `json:"defaults,omitempty"`           // Values used when list flags are not given
+	CredentialProvider *CredentialProvider `json:"credentialProvider,omitempty"` // Command that supplies the tokens instead of 'agbcloud login'; never shared, since importing it would run a command
+	TLSPins            map[string][]string `json:"tlsPins,omitempty"`            // Public key pins ("sha256/<base64>") per endpoint host; connections to a pinned host fail unless the server presents one of them
+	PolicyFile         string              `json:"policyFile,omitempty"`         // Organization policy checked before 'image create' and 'image activate'; --policy-file overrides it
+
+	savedToken    *Token // Token read from the file while a provided token is in use
+	providedToken *Token // Token printed by CredentialProvider
+}
+
+// DefaultStickyProfile is the StickyFlags key used while no profile is active
+const DefaultStickyProfile = "default"
+
+// FlagSet holds remembered flag values by command path (e.g. "image list") and flag name
+type FlagSet map[string]map[string]string
+
+// Profile is a named set of settings that override the top-level ones while active
+type Profile struct {
+	Endpoint          string        `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	FallbackEndpoints []string      `json:"fallbackEndpoints,omitempty" yaml:"fallbackEndpoints,omitempty"`
+	EndpointStrategy  string        `json:"endpointStrategy,omitempty" yaml:"endpointStrategy,omitempty"`
+	Output            string        `json:"output,omitempty" yaml:"output,omitempty"`
+	Defaults          *ListDefaults `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+}
+
+// ListDefaults are the values 'image list' uses for flags that are not given
+type ListDefaults struct {
+	// ImageType is the default --type: User or System
+	ImageType string `json:"imageType,omitempty" yaml:"imageType,omitempty"`
+	// PageSize is the default --size
+	PageSize int `json:"pageSize,omitempty" yaml:"pageSize,omitempty"`
+}
+
+// ImageGCPolicy describes which user images 'image gc' may delete
+type ImageGCPolicy struct {
+	// KeepLast is the number of most recently updated images that are always kept
+	KeepLast int `json:"keepLast" yaml:"keepLast"`
+	// KeepActivated protects activated (and activating) images from deletion
+	KeepActivated bool `json:"keepActivated" yaml:"keepActivated"`
+	// OlderThan only allows deleting images not updated within this duration (e.g. "30d", "72h")
+	OlderThan string `json:"olderThan,omitempty" yaml:"olderThan,omitempty"`
+}
+
+// UploadStorage overrides how Dockerfiles are uploaded, for private deployments whose
+// storage differs from what the upload credentials describe
+type UploadStorage struct {
+	// Backend is the upload protocol: presigned-put, s3-multipart or azure-blob.
+	// Default: the one announced with the upload credentials.
+	Backend string `json:"backend,omitempty" yaml:"backend,omitempty"`
+	// Endpoint replaces the scheme and host of the upload URLs, e.g. an internal OSS endpoint
+	Endpoint string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
 }
 
 // Token represents AgbCloud authentication tokens
@@ -56,26 +131,196 @@ func GetConfig() (*Config, error) {
 
 		err = json.Unmarshal(configContent, &c)
 		if err != nil {
+			// Report where the file is broken instead of the bare decoder error
+			if issues := errorIssues(ValidateConfigData(configContent)); len(issues) > 0 {
+				logIssuesOnce(configFilePath, log.ErrorLevel, func() []Issue { return issues })
+				return nil, &ValidationError{File: configFilePath, Issues: issues}
+			}
 			return nil, err
 		}
+		logIssuesOnce(configFilePath, log.WarnLevel, func() []Issue { return ValidateConfigData(configContent) })
+		// The file holds tokens, which other users on the machine must not read
+		warnPermissionsOnce(configFilePath)
 	}
 
+	c.applyCredentialProvider()
 	return &c, nil
 }
 
-// GetEndpoint returns the endpoint from environment variable or default
+// endpointOverride holds the endpoints given with the global --endpoint flag
+var endpointOverride string
+
+// SetEndpointOverride makes the endpoints of this invocation take precedence over
+// AGB_CLI_ENDPOINT and the configuration. Like the environment variable, value may
+// hold a comma-separated list; an empty value removes the override.
+func SetEndpointOverride(value string) {
+	endpointOverride = value
+}
+
+// GetEndpoint returns the primary endpoint from the --endpoint flag, environment variable, configuration or default
 func GetEndpoint() string {
-	endpoint := os.Getenv("AGB_CLI_ENDPOINT")
-	if endpoint == "" {
-		endpoint = "agb.cloud"
+	return GetEndpoints()[0]
+}
+
+// GetEndpoints returns the primary endpoint followed by any fallback endpoints.
+// AGB_CLI_ENDPOINT may hold a comma-separated list and overrides the configuration;
+// the --endpoint flag overrides both.
+func GetEndpoints() []string {
+	var endpoints []string
+	if endpointOverride != "" {
+		endpoints = strings.Split(endpointOverride, ",")
+	} else if env := os.Getenv("AGB_CLI_ENDPOINT"); env != "" {
+		endpoints = strings.Split(env, ",")
+	} else if c, err := GetConfig(); err == nil {
+		// Configuration is optional here; an unreadable file falls back to the default
+		endpoints = c.EffectiveEndpoints()
+	}
+	if len(endpoints) == 0 || strings.TrimSpace(endpoints[0]) == "" {
+		endpoints = append([]string{"agb.cloud"}, endpoints...)
+	}
+
+	seen := make(map[string]bool, len(endpoints))
+	normalized := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" {
+			continue
+		}
+		// Ensure endpoint has https:// prefix
+		if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+			endpoint = "https://" + endpoint
+		}
+		endpoint = strings.TrimSuffix(endpoint, "/")
+		if !seen[endpoint] {
+			seen[endpoint] = true
+			normalized = append(normalized, endpoint)
+		}
+	}
+	return normalized
+}
+
+// GetEndpointStrategy returns the endpoint selection strategy from AGB_CLI_ENDPOINT_STRATEGY or configuration
+func GetEndpointStrategy() string {
+	if strategy := os.Getenv("AGB_CLI_ENDPOINT_STRATEGY"); strategy != "" {
+		return strategy
+	}
+	if c, err := GetConfig(); err == nil {
+		return c.EffectiveEndpointStrategy()
+	}
+	return ""
+}
+
+// GetLoginPortRange returns the login callback port ranges from AGB_CLI_LOGIN_PORT_RANGE or configuration
+func GetLoginPortRange() string {
+	if ports := os.Getenv("AGB_CLI_LOGIN_PORT_RANGE"); ports != "" {
+		return ports
+	}
+	if c, err := GetConfig(); err == nil {
+		return c.LoginPortRange
+	}
+	return ""
+}
+
+// maxRecentLoginPorts is the number of successful login callback ports remembered
+const maxRecentLoginPorts = 3
+
+// RememberLoginPort records port as the most recently successful login callback port
+func (c *Config) RememberLoginPort(port string) {
+	ports := []string{port}
+	for _, recent := range c.LoginPorts {
+		if recent != port && len(ports) < maxRecentLoginPorts {
+			ports = append(ports, recent)
+		}
+	}
+	c.LoginPorts = ports
+}
+
+// stickyProfile returns the StickyFlags key of the active profile
+func (c *Config) stickyProfile() string {
+	if name, _ := c.CurrentProfile(); name != "" {
+		return name
+	}
+	return DefaultStickyProfile
+}
+
+// RememberedFlags returns the remembered flag values of a command in the active profile
+func (c *Config) RememberedFlags(command string) map[string]string {
+	return c.StickyFlags[c.stickyProfile()][command]
+}
+
+// RememberFlags stores flag values of a command in the active profile, keeping
+// remembered values of other flags
+func (c *Config) RememberFlags(command string, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+	profile := c.stickyProfile()
+	if c.StickyFlags == nil {
+		c.StickyFlags = make(map[string]FlagSet)
+	}
+	if c.StickyFlags[profile] == nil {
+		c.StickyFlags[profile] = make(FlagSet)
+	}
+	if c.StickyFlags[profile][command] == nil {
+		c.StickyFlags[profile][command] = make(map[string]string)
+	}
+	for name, value := range values {
+		c.StickyFlags[profile][command][name] = value
+	}
+}
+
+// ForgetFlags removes the remembered flags of a command in the active profile, or
+// of every command when command is empty
+func (c *Config) ForgetFlags(command string) {
+	profile := c.stickyProfile()
+	if command == "" {
+		delete(c.StickyFlags, profile)
+	} else {
+		delete(c.StickyFlags[profile], command)
+		if len(c.StickyFlags[profile]) == 0 {
+			delete(c.StickyFlags, profile)
+		}
 	}
+	if len(c.StickyFlags) == 0 {
+		c.StickyFlags = nil
+	}
+}
 
-	// Ensure endpoint has https:// prefix
-	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
-		endpoint = "https://" + endpoint
+// PinnedImageIDs returns the image IDs pinned in the active profile, most recently pinned first
+func (c *Config) PinnedImageIDs() []string {
+	return c.PinnedImages[c.stickyProfile()]
+}
+
+// PinImage pins an image in the active profile. It reports false if the image was
+// already pinned.
+func (c *Config) PinImage(imageId string) bool {
+	profile := c.stickyProfile()
+	if slices.Contains(c.PinnedImages[profile], imageId) {
+		return false
+	}
+	if c.PinnedImages == nil {
+		c.PinnedImages = make(map[string][]string)
 	}
+	c.PinnedImages[profile] = append([]string{imageId}, c.PinnedImages[profile]...)
+	return true
+}
 
-	return endpoint
+// UnpinImage removes an image from the pins of the active profile. It reports false
+// if the image was not pinned.
+func (c *Config) UnpinImage(imageId string) bool {
+	profile := c.stickyProfile()
+	index := slices.Index(c.PinnedImages[profile], imageId)
+	if index < 0 {
+		return false
+	}
+	c.PinnedImages[profile] = slices.Delete(c.PinnedImages[profile], index, index+1)
+	if len(c.PinnedImages[profile]) == 0 {
+		delete(c.PinnedImages, profile)
+	}
+	if len(c.PinnedImages) == 0 {
+		c.PinnedImages = nil
+	}
+	return true
 }
 
 // Save writes the configuration to file
@@ -85,17 +330,127 @@ func (c *Config) Save() error {
 		return err
 	}
 
-	err = os.MkdirAll(filepath.Dir(configFilePath), 0755)
+	err = os.MkdirAll(filepath.Dir(configFilePath), privateDirMode)
 	if err != nil {
 		return err
 	}
 
-	configContent, err := json.MarshalIndent(c, "", "  ")
+	// Provided tokens stay in memory; the tokens read from the file are written back
+	saved := *c
+	if c.TokenFromProvider() {
+		saved.Token = c.savedToken
+	}
+	configContent, err := json.MarshalIndent(&saved, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(configFilePath, configContent, 0600) // More secure permissions for auth data
+	// Write to a temporary file first so that an interrupted save never leaves half a configuration
+	tmp := configFilePath + ".tmp"
+	if err := os.WriteFile(tmp, configContent, 0600); err != nil { // More secure permissions for auth data
+		return err
+	}
+	return os.Rename(tmp, configFilePath)
+}
+
+// CurrentProfile returns the name and settings of the active profile.
+// AGB_CLI_PROFILE takes precedence over the saved active profile. The returned
+// profile is nil when no profile is active or the named profile does not exist.
+func (c *Config) CurrentProfile() (string, *Profile) {
+	name := os.Getenv("AGB_CLI_PROFILE")
+	if name == "" {
+		name = c.ActiveProfile
+	}
+	if name == "" {
+		return "", nil
+	}
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return name, nil
+	}
+	return name, &profile
+}
+
+// EffectiveEndpoint returns the configured endpoint, preferring the active profile's
+func (c *Config) EffectiveEndpoint() string {
+	if _, profile := c.CurrentProfile(); profile != nil && profile.Endpoint != "" {
+		return profile.Endpoint
+	}
+	return c.Endpoint
+}
+
+// EffectiveEndpoints returns the configured primary and fallback endpoints. A profile that
+// sets any endpoint replaces the top-level endpoints as a whole.
+func (c *Config) EffectiveEndpoints() []string {
+	endpoint, fallbacks := c.Endpoint, c.FallbackEndpoints
+	if _, profile := c.CurrentProfile(); profile != nil && (profile.Endpoint != "" || len(profile.FallbackEndpoints) > 0) {
+		endpoint, fallbacks = profile.Endpoint, profile.FallbackEndpoints
+	}
+	if endpoint == "" && len(fallbacks) == 0 {
+		return nil
+	}
+	return append([]string{endpoint}, fallbacks...)
+}
+
+// EffectiveEndpointStrategy returns the configured endpoint strategy, preferring the active profile's
+func (c *Config) EffectiveEndpointStrategy() string {
+	if _, profile := c.CurrentProfile(); profile != nil && profile.EndpointStrategy != "" {
+		return profile.EndpointStrategy
+	}
+	return c.EndpointStrategy
+}
+
+// EffectiveOutput returns the configured default output format, preferring the active profile's
+func (c *Config) EffectiveOutput() string {
+	if _, profile := c.CurrentProfile(); profile != nil && profile.Output != "" {
+		return profile.Output
+	}
+	return c.Output
+}
+
+// EffectiveListDefaults returns the configured list defaults; each value of the active
+// profile overrides the top-level one
+func (c *Config) EffectiveListDefaults() ListDefaults {
+	var defaults ListDefaults
+	if c.Defaults != nil {
+		defaults = *c.Defaults
+	}
+	if _, profile := c.CurrentProfile(); profile != nil && profile.Defaults != nil {
+		if profile.Defaults.ImageType != "" {
+			defaults.ImageType = profile.Defaults.ImageType
+		}
+		if profile.Defaults.PageSize != 0 {
+			defaults.PageSize = profile.Defaults.PageSize
+		}
+	}
+	return defaults
+}
+
+// PinnedKeys returns the public key pins configured for host, which may include a
+// port. Keys of the tlsPins setting are matched case-insensitively and may be given
+// as a host, host:port or URL.
+func (c *Config) PinnedKeys(host string) []string {
+	host = TLSPinHost(host)
+	var pins []string
+	for key, keyPins := range c.TLSPins {
+		if TLSPinHost(key) == host {
+			pins = append(pins, keyPins...)
+		}
+	}
+	return pins
+}
+
+// TLSPinHost reduces a tlsPins key or endpoint to the lower-case host name it pins
+func TLSPinHost(value string) string {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, "://") {
+		value = "https://" + value
+	}
+	u, err := url.Parse(value)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
 }
 
 // GetTokens retrieves authentication tokens
@@ -0,0 +1,163 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// CredentialProviderTimeout limits how long a credential provider may run
+const CredentialProviderTimeout = 30 * time.Second
+
+// credentialRefreshMargin is how long before it expires a provided token is requested again
+const credentialRefreshMargin = time.Minute
+
+// CredentialProvider is an external command that prints the tokens to use instead of the
+// tokens saved by 'agbcloud login', like the exec plugins of kubeconfig. The command must
+// print a JSON object to stdout:
+//
+//	{"loginToken": "...", "sessionId": "...", "expiresAt": "2025-10-01T12:00:00Z"}
+//
+// expiresAt is optional; without it the tokens are used until the process exits.
+// The command inherits stderr, so it can report problems to the user.
+type CredentialProvider struct {
+	// Command is the executable, looked up in PATH unless it contains a path separator
+	Command string `json:"command"`
+	// Args are passed to the command
+	Args []string `json:"args,omitempty"`
+	// Env holds variables set for the command in addition to the CLI's environment
+	Env map[string]string `json:"env,omitempty"`
+}
+
+// ProvidedCredential is the output expected from a credential provider
+type ProvidedCredential struct {
+	LoginToken string `json:"loginToken"`
+	SessionId  string `json:"sessionId"`
+	ExpiresAt  string `json:"expiresAt,omitempty"` // RFC 3339
+}
+
+// String describes the provider for messages, e.g. "corp-agb-token --team ml"
+func (p *CredentialProvider) String() string {
+	return strings.Join(append([]string{p.Command}, p.Args...), " ")
+}
+
+// env returns Env as name=value entries sorted by name, so that the command and the
+// token cache see the same variables in the same order on every run
+func (p *CredentialProvider) env() []string {
+	names := make([]string, 0, len(p.Env))
+	for name := range p.Env {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	entries := make([]string, 0, len(names))
+	for _, name := range names {
+		entries = append(entries, name+"="+p.Env[name])
+	}
+	return entries
+}
+
+// Token runs the provider and returns the tokens it printed
+func (p *CredentialProvider) Token(ctx context.Context) (*Token, error) {
+	if p.Command == "" {
+		return nil, errors.New("credentialProvider.command is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, CredentialProviderTimeout)
+	defer cancel()
+
+	var stdout bytes.Buffer
+	command := exec.CommandContext(ctx, p.Command, p.Args...)
+	command.Stdout = &stdout
+	command.Stderr = os.Stderr
+	command.Env = append(os.Environ(), p.env()...)
+	if err := command.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("timed out after %s", CredentialProviderTimeout)
+		}
+		return nil, err
+	}
+
+	var credential ProvidedCredential
+	if err := json.Unmarshal(stdout.Bytes(), &credential); err != nil {
+		return nil, fmt.Errorf("output is not a JSON credential: %w", err)
+	}
+	if credential.LoginToken == "" || credential.SessionId == "" {
+		return nil, errors.New("output must contain loginToken and sessionId")
+	}
+
+	token := &Token{LoginToken: credential.LoginToken, SessionId: credential.SessionId}
+	if credential.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, credential.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("expiresAt is not an RFC 3339 time: %w", err)
+		}
+		token.ExpiresAt = expiresAt
+	}
+	return token, nil
+}
+
+// providedTokens caches the tokens of each provider for this process, since the
+// configuration is loaded several times per command
+var providedTokens sync.Map
+
+// providerFailures records the providers whose failure was already logged
+var providerFailures sync.Map
+
+// providedToken returns the cached tokens of p, running it when there are none or they
+// are about to expire. Failures are logged once per process and return nil.
+func providedToken(p *CredentialProvider) *Token {
+	key := strings.Join(append([]string{p.String()}, p.env()...), "\x00")
+	if cached, ok := providedTokens.Load(key); ok {
+		token := cached.(*Token)
+		if token.ExpiresAt.IsZero() || time.Until(token.ExpiresAt) > credentialRefreshMargin {
+			return token
+		}
+	}
+
+	token, err := p.Token(context.Background())
+	if err != nil {
+		providedTokens.Delete(key)
+		if _, logged := providerFailures.LoadOrStore(key, true); !logged {
+			log.Errorf("Credential provider '%s' failed: %v", p, err)
+		}
+		return nil
+	}
+	providedTokens.Store(key, token)
+	return token
+}
+
+// applyCredentialProvider replaces the saved tokens with the tokens of the configured
+// credential provider. The saved tokens are kept when the provider fails.
+func (c *Config) applyCredentialProvider() {
+	if c.CredentialProvider == nil {
+		return
+	}
+	token := providedToken(c.CredentialProvider)
+	if token == nil {
+		return
+	}
+	c.savedToken = c.Token
+	// Each configuration gets its own copy, so callers cannot change the cache
+	provided := *token
+	c.Token = &provided
+	c.providedToken = c.Token
+}
+
+// TokenFromProvider reports whether the tokens in use were printed by the credential
+// provider. Such tokens are never written to the configuration file.
+func (c *Config) TokenFromProvider() bool {
+	return c.providedToken != nil && c.Token == c.providedToken
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// MergePatch applies a JSON merge patch (RFC 7386) to the JSON object doc: objects in
+// patch are merged into doc recursively, null removes a key, and any other value
+// replaces the value in doc. An empty doc is treated as {}. patch must be an object.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	target := map[string]interface{}{}
+	if len(bytes.TrimSpace(doc)) > 0 {
+		if err := decodeJSONObject(doc, &target); err != nil {
+			return nil, fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+	var changes map[string]interface{}
+	if err := decodeJSONObject(patch, &changes); err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+	return json.MarshalIndent(mergeObject(target, changes), "", "  ")
+}
+
+// PatchPaths returns the key paths a merge patch sets and removes, such as
+// "defaults.pageSize", sorted
+func PatchPaths(patch []byte) (set, removed []string, err error) {
+	var changes map[string]interface{}
+	if err := decodeJSONObject(patch, &changes); err != nil {
+		return nil, nil, fmt.Errorf("invalid patch: %w", err)
+	}
+	var walk func(prefix string, object map[string]interface{})
+	walk = func(prefix string, object map[string]interface{}) {
+		for key, value := range object {
+			path := prefix + key
+			switch value := value.(type) {
+			case nil:
+				removed = append(removed, path)
+			case map[string]interface{}:
+				walk(path+".", value)
+			default:
+				set = append(set, path)
+			}
+		}
+	}
+	walk("", changes)
+	sort.Strings(set)
+	sort.Strings(removed)
+	return set, removed, nil
+}
+
+// decodeJSONObject decodes a single JSON object, keeping numbers as written
+func decodeJSONObject(data []byte, object *map[string]interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("unexpected data after the JSON object")
+	}
+	decoded, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.New("expected a JSON object")
+	}
+	*object = decoded
+	return nil
+}
+
+// mergeObject merges patch into target as described by RFC 7386
+func mergeObject(target, patch map[string]interface{}) map[string]interface{} {
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			existing, _ := target[key].(map[string]interface{})
+			if existing == nil {
+				existing = map[string]interface{}{}
+			}
+			target[key] = mergeObject(existing, nested)
+			continue
+		}
+		target[key] = value
+	}
+	return target
+}
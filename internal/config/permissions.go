@@ -0,0 +1,103 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// The configuration directory holds tokens, so it and everything in it should only
+// be accessible by the user who owns it
+const (
+	privateDirMode  os.FileMode = 0700
+	privateFileMode os.FileMode = 0600
+)
+
+// PermissionIssue describes a file or directory in the configuration directory
+// that other users can access
+type PermissionIssue struct {
+	Path string
+	Dir  bool
+	// Detail says who else has access, e.g. "mode 0644, want 0600"
+	Detail string
+}
+
+func (i PermissionIssue) String() string {
+	return fmt.Sprintf("%s is accessible by other users (%s)", i.Path, i.Detail)
+}
+
+// CheckPermissions reports the files and directories in the configuration directory,
+// including the directory itself, that other users can access. A missing directory
+// has no issues.
+func CheckPermissions() ([]PermissionIssue, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	var issues []PermissionIssue
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && path == dir {
+				return fs.SkipAll
+			}
+			return err
+		}
+		// Symbolic links have no permissions of their own
+		if !entry.IsDir() && !entry.Type().IsRegular() {
+			return nil
+		}
+		issue, err := checkPrivate(path, entry.IsDir())
+		if err != nil {
+			return err
+		}
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+		return nil
+	})
+	return issues, err
+}
+
+// FixPermissions restricts the files and directories of issues to their owner
+func FixPermissions(issues []PermissionIssue) error {
+	var errs []error
+	for _, issue := range issues {
+		if err := makePrivate(issue.Path, issue.Dir); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", issue.Path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// permissionsChecked remembers configuration files whose permissions were already
+// checked, since the configuration is loaded several times per command
+var permissionsChecked sync.Map
+
+// warnPermissionsOnce warns once per process when the configuration file or its
+// directory can be accessed by other users
+func warnPermissionsOnce(file string) {
+	if _, seen := permissionsChecked.LoadOrStore(file, true); seen {
+		return
+	}
+	for _, target := range []struct {
+		path string
+		dir  bool
+	}{{filepath.Dir(file), true}, {file, false}} {
+		issue, err := checkPrivate(target.path, target.dir)
+		if err != nil {
+			log.Debugf("Could not check the permissions of %s: %v", target.path, err)
+			continue
+		}
+		if issue != nil {
+			log.Warnf("%s; run 'agbcloud doctor --fix' to restrict it to your user", issue)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// checkPrivate returns an issue when group or other users have any access to path
+func checkPrivate(path string, dir bool) (*PermissionIssue, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	want := privateFileMode
+	if dir {
+		want = privateDirMode
+	}
+	mode := info.Mode().Perm()
+	if mode&0077 == 0 {
+		return nil, nil
+	}
+	return &PermissionIssue{Path: path, Dir: dir, Detail: fmt.Sprintf("mode %04o, want %04o", mode, want)}, nil
+}
+
+// makePrivate gives the owner of path sole access to it
+func makePrivate(path string, dir bool) error {
+	if dir {
+		return os.Chmod(path, privateDirMode)
+	}
+	return os.Chmod(path, privateFileMode)
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package config
+
+import (
+	"fmt"
+	"os/exec"
+	"os/user"
+	"strings"
+)
+
+// Windows ignores file mode bits, so access is read from and written to the ACL
+// with icacls. Besides the owner, the system account and administrators always
+// have access to user profiles, and CREATOR OWNER stands for the owner.
+var trustedPrincipals = []string{`NT AUTHORITY\SYSTEM`, `BUILTIN\Administrators`, `CREATOR OWNER`}
+
+// checkPrivate returns an issue when the ACL of path grants access to other principals
+func checkPrivate(path string, dir bool) (*PermissionIssue, error) {
+	others, err := otherPrincipals(path)
+	if err != nil || len(others) == 0 {
+		return nil, err
+	}
+	return &PermissionIssue{Path: path, Dir: dir, Detail: "ACL grants access to " + strings.Join(others, ", ")}, nil
+}
+
+// makePrivate replaces the ACL of path with full control for the current user,
+// dropping inherited entries and entries of other principals
+func makePrivate(path string, dir bool) error {
+	current, err := user.Current()
+	if err != nil {
+		return err
+	}
+	others, err := otherPrincipals(path)
+	if err != nil {
+		return err
+	}
+	grant := current.Username + ":F"
+	if dir {
+		grant = current.Username + ":(OI)(CI)F"
+	}
+	args := []string{path, "/inheritance:r", "/grant:r", grant}
+	for _, principal := range others {
+		args = append(args, "/remove:g", principal)
+	}
+	if out, err := exec.Command("icacls", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("icacls failed: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// otherPrincipals lists the principals in the ACL of path other than the current
+// user and the trusted ones
+func otherPrincipals(path string) ([]string, error) {
+	current, err := user.Current()
+	if err != nil {
+		return nil, err
+	}
+	out, err := exec.Command("icacls", path).Output()
+	if err != nil {
+		return nil, fmt.Errorf("icacls failed: %w", err)
+	}
+
+	// Each entry is "PRINCIPAL:(FLAGS)(RIGHTS)"; the first one follows the path
+	var others []string
+	seen := map[string]bool{}
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), path))
+		end := strings.Index(line, ":(")
+		if end <= 0 {
+			continue
+		}
+		principal := line[:end]
+		if seen[principal] || strings.EqualFold(principal, current.Username) || isTrustedPrincipal(principal) {
+			continue
+		}
+		seen[principal] = true
+		others = append(others, principal)
+	}
+	return others, nil
+}
+
+func isTrustedPrincipal(principal string) bool {
+	for _, trusted := range trustedPrincipals {
+		if strings.EqualFold(principal, trusted) {
+			return true
+		}
+	}
+	return false
+}
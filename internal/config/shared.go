@@ -0,0 +1,272 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SharedConfig is the part of the configuration that can be shared between users.
+// It deliberately has no field for tokens or other credentials.
+type SharedConfig struct {
+	Endpoint          string              `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	FallbackEndpoints []string            `json:"fallbackEndpoints,omitempty" yaml:"fallbackEndpoints,omitempty"`
+	EndpointStrategy  string              `json:"endpointStrategy,omitempty" yaml:"endpointStrategy,omitempty"`
+	Output            string              `json:"output,omitempty" yaml:"output,omitempty"`
+	TimeFormat        string              `json:"timeFormat,omitempty" yaml:"timeFormat,omitempty"`
+	TimeZone          string              `json:"timeZone,omitempty" yaml:"timeZone,omitempty"`
+	Concurrency       int                 `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+	SystemImageTTL    string              `json:"systemImageTTL,omitempty" yaml:"systemImageTTL,omitempty"`
+	ActiveProfile     string              `json:"activeProfile,omitempty" yaml:"activeProfile,omitempty"`
+	Profiles          map[string]Profile  `json:"profiles,omitempty" yaml:"profiles,omitempty"`
+	ImageGC           *ImageGCPolicy      `json:"imageGC,omitempty" yaml:"imageGC,omitempty"`
+	CleanupOnFailure  *bool               `json:"cleanupOnFailure,omitempty" yaml:"cleanupOnFailure,omitempty"`
+	OTLPEndpoint      string              `json:"otlpEndpoint,omitempty" yaml:"otlpEndpoint,omitempty"`
+	UploadStorage     *UploadStorage      `json:"uploadStorage,omitempty" yaml:"uploadStorage,omitempty"`
+	Defaults          *ListDefaults       `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+	TLSPins           map[string][]string `json:"tlsPins,omitempty" yaml:"tlsPins,omitempty"`
+	PolicyFile        string              `json:"policyFile,omitempty" yaml:"policyFile,omitempty"`
+}
+
+// ImportMode selects how an imported configuration is combined with the existing one
+type ImportMode string
+
+const (
+	// ImportMerge overrides only the settings present in the imported file
+	ImportMerge ImportMode = "merge"
+	// ImportOverwrite replaces all shareable settings; tokens are always kept
+	ImportOverwrite ImportMode = "overwrite"
+)
+
+// ErrSecretsInSharedConfig is returned when an imported file contains credentials
+var ErrSecretsInSharedConfig = errors.New("configuration file contains credentials; tokens are never imported, remove them and share the file again")
+
+// secretKeys are keys that must never appear in a shared configuration file
+var secretKeys = map[string]bool{
+	"token":          true,
+	"logintoken":     true,
+	"sessionid":      true,
+	"keepalivetoken": true,
+}
+
+// Export returns a copy of the shareable settings without any credentials
+func (c *Config) Export() SharedConfig {
+	shared := SharedConfig{
+		Endpoint:          c.Endpoint,
+		FallbackEndpoints: append([]string(nil), c.FallbackEndpoints...),
+		EndpointStrategy:  c.EndpointStrategy,
+		Output:            c.Output,
+		TimeFormat:        c.TimeFormat,
+		TimeZone:          c.TimeZone,
+		Concurrency:       c.Concurrency,
+		SystemImageTTL:    c.SystemImageTTL,
+		ActiveProfile:     c.ActiveProfile,
+		OTLPEndpoint:      c.OTLPEndpoint,
+		PolicyFile:        c.PolicyFile,
+	}
+	if len(c.Profiles) > 0 {
+		shared.Profiles = make(map[string]Profile, len(c.Profiles))
+		for name, profile := range c.Profiles {
+			shared.Profiles[name] = profile
+		}
+	}
+	if c.ImageGC != nil {
+		policy := *c.ImageGC
+		shared.ImageGC = &policy
+	}
+	if c.CleanupOnFailure != nil {
+		cleanup := *c.CleanupOnFailure
+		shared.CleanupOnFailure = &cleanup
+	}
+	if c.UploadStorage != nil {
+		storage := *c.UploadStorage
+		shared.UploadStorage = &storage
+	}
+	if c.Defaults != nil {
+		defaults := *c.Defaults
+		shared.Defaults = &defaults
+	}
+	if len(c.TLSPins) > 0 {
+		shared.TLSPins = make(map[string][]string, len(c.TLSPins))
+		for host, pins := range c.TLSPins {
+			shared.TLSPins[host] = append([]string(nil), pins...)
+		}
+	}
+	return shared
+}
+
+// Import applies shared settings to the configuration. Authentication tokens are never touched.
+func (c *Config) Import(shared SharedConfig, mode ImportMode) {
+	if mode == ImportOverwrite {
+		c.Endpoint = ""
+		c.FallbackEndpoints = nil
+		c.EndpointStrategy = ""
+		c.Output = ""
+		c.TimeFormat = ""
+		c.TimeZone = ""
+		c.Concurrency = 0
+		c.SystemImageTTL = ""
+		c.ActiveProfile = ""
+		c.Profiles = nil
+		c.ImageGC = nil
+		c.CleanupOnFailure = nil
+		c.OTLPEndpoint = ""
+		c.UploadStorage = nil
+		c.Defaults = nil
+		c.TLSPins = nil
+		c.PolicyFile = ""
+	}
+
+	if shared.Endpoint != "" {
+		c.Endpoint = shared.Endpoint
+	}
+	if len(shared.FallbackEndpoints) > 0 {
+		c.FallbackEndpoints = append([]string(nil), shared.FallbackEndpoints...)
+	}
+	if shared.EndpointStrategy != "" {
+		c.EndpointStrategy = shared.EndpointStrategy
+	}
+	if shared.Output != "" {
+		c.Output = shared.Output
+	}
+	if shared.TimeFormat != "" {
+		c.TimeFormat = shared.TimeFormat
+	}
+	if shared.TimeZone != "" {
+		c.TimeZone = shared.TimeZone
+	}
+	if shared.Concurrency != 0 {
+		c.Concurrency = shared.Concurrency
+	}
+	if shared.SystemImageTTL != "" {
+		c.SystemImageTTL = shared.SystemImageTTL
+	}
+	if shared.ActiveProfile != "" {
+		c.ActiveProfile = shared.ActiveProfile
+	}
+	if shared.ImageGC != nil {
+		policy := *shared.ImageGC
+		c.ImageGC = &policy
+	}
+	if shared.CleanupOnFailure != nil {
+		cleanup := *shared.CleanupOnFailure
+		c.CleanupOnFailure = &cleanup
+	}
+	if shared.OTLPEndpoint != "" {
+		c.OTLPEndpoint = shared.OTLPEndpoint
+	}
+	if shared.PolicyFile != "" {
+		c.PolicyFile = shared.PolicyFile
+	}
+	if shared.UploadStorage != nil {
+		storage := *shared.UploadStorage
+		c.UploadStorage = &storage
+	}
+	if shared.Defaults != nil {
+		defaults := *shared.Defaults
+		c.Defaults = &defaults
+	}
+	for host, pins := range shared.TLSPins {
+		if c.TLSPins == nil {
+			c.TLSPins = make(map[string][]string)
+		}
+		c.TLSPins[host] = append([]string(nil), pins...)
+	}
+
+	for name, imported := range shared.Profiles {
+		if c.Profiles == nil {
+			c.Profiles = make(map[string]Profile)
+		}
+		profile := c.Profiles[name]
+		if imported.Endpoint != "" {
+			profile.Endpoint = imported.Endpoint
+		}
+		if len(imported.FallbackEndpoints) > 0 {
+			profile.FallbackEndpoints = append([]string(nil), imported.FallbackEndpoints...)
+		}
+		if imported.EndpointStrategy != "" {
+			profile.EndpointStrategy = imported.EndpointStrategy
+		}
+		if imported.Output != "" {
+			profile.Output = imported.Output
+		}
+		if imported.Defaults != nil {
+			defaults := *imported.Defaults
+			profile.Defaults = &defaults
+		}
+		c.Profiles[name] = profile
+	}
+}
+
+// MarshalShared encodes shared settings as "yaml" or "json"
+func MarshalShared(shared SharedConfig, format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case "", "yaml", "yml":
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(shared); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case "json":
+		data, err := json.MarshalIndent(shared, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format '%s' (supported: yaml, json)", format)
+	}
+}
+
+// ParseShared decodes a shared configuration in YAML or JSON.
+// Unknown keys are rejected, and credentials produce ErrSecretsInSharedConfig.
+func ParseShared(data []byte) (SharedConfig, error) {
+	var shared SharedConfig
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return shared, fmt.Errorf("invalid configuration file: %w", err)
+	}
+	if containsSecret(raw) {
+		return shared, ErrSecretsInSharedConfig
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&shared); err != nil && !errors.Is(err, io.EOF) {
+		return shared, fmt.Errorf("invalid configuration file: %w", err)
+	}
+	return shared, nil
+}
+
+// containsSecret reports whether any key at any depth names a credential
+func containsSecret(value interface{}) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if secretKeys[strings.ToLower(key)] || containsSecret(child) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if containsSecret(child) {
+				return true
+			}
+		}
+	}
+	return false
+}
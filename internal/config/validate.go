@@ -0,0 +1,448 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Severity tells whether a validation issue prevents the configuration from being used
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Position is a 1-based line and column in a configuration file
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Issue is a single problem found in a configuration file
+type Issue struct {
+	Severity Severity
+	Path     string // Key path such as "profiles.dev.endpoint" or "fallbackEndpoints[1]"; empty for the whole file
+	Position Position
+	Message  string
+}
+
+// String formats the issue as "line:column: path: message"
+func (i Issue) String() string {
+	var b strings.Builder
+	if i.Position.Line > 0 {
+		fmt.Fprintf(&b, "%d:%d: ", i.Position.Line, i.Position.Column)
+	}
+	if i.Path != "" {
+		b.WriteString(i.Path + ": ")
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// ValidationError is returned when a configuration file cannot be loaded
+type ValidationError struct {
+	File   string
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	lines := []string{fmt.Sprintf("invalid configuration file %s:", e.File)}
+	for _, issue := range e.Issues {
+		lines = append(lines, fmt.Sprintf("  %s:%s", e.File, issue))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ValidateEndpoint checks that an endpoint is a host or an http(s) URL
+func ValidateEndpoint(endpoint string) error {
+	candidate := endpoint
+	if !strings.Contains(candidate, "://") {
+		candidate = "https://" + candidate
+	}
+	u, err := url.Parse(candidate)
+	if err != nil || u.Host == "" || strings.ContainsAny(endpoint, " \t") {
+		return fmt.Errorf("invalid endpoint '%s'", endpoint)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme '%s'", u.Scheme)
+	}
+	return nil
+}
+
+// ValidateTLSPin checks that a public key pin has the form "sha256/<base64>", where
+// the base64 text encodes a 32-byte SHA-256 hash of a certificate's public key
+func ValidateTLSPin(pin string) error {
+	encoded, ok := strings.CutPrefix(pin, "sha256/")
+	if !ok {
+		return fmt.Errorf("invalid pin '%s', expected sha256/<base64 SHA-256 of the public key>", pin)
+	}
+	hash, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(hash) != sha256.Size {
+		return fmt.Errorf("invalid pin '%s', the hash must be %d bytes encoded as base64", pin, sha256.Size)
+	}
+	return nil
+}
+
+// ValidateConfigData checks a config.json document against the Config structure.
+// Syntax errors and values of the wrong type are errors, unknown keys are warnings,
+// and endpoints that are not valid URLs and malformed TLS pins are errors. Issues are sorted by position.
+func ValidateConfigData(data []byte) []Issue {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+
+	v := &validator{data: data, positions: make(map[string]Position)}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := v.walk(dec, "", reflect.TypeOf(Config{})); err != nil {
+		return []Issue{v.syntaxIssue(err, dec)}
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return []Issue{{Severity: SeverityError, Position: v.position(dec.InputOffset()), Message: "unexpected data after the configuration object"}}
+	}
+
+	// Endpoints are only checked once the structure is known to be valid
+	var c Config
+	if len(errorIssues(v.issues)) == 0 && json.Unmarshal(data, &c) == nil {
+		v.checkEndpoint("endpoint", c.Endpoint)
+		for i, endpoint := range c.FallbackEndpoints {
+			v.checkEndpoint(fmt.Sprintf("fallbackEndpoints[%d]", i), endpoint)
+		}
+		if c.UploadStorage != nil {
+			v.checkEndpoint("uploadStorage.endpoint", c.UploadStorage.Endpoint)
+		}
+		for name, profile := range c.Profiles {
+			v.checkEndpoint("profiles."+name+".endpoint", profile.Endpoint)
+			for i, endpoint := range profile.FallbackEndpoints {
+				v.checkEndpoint(fmt.Sprintf("profiles.%s.fallbackEndpoints[%d]", name, i), endpoint)
+			}
+		}
+		for host, pins := range c.TLSPins {
+			if TLSPinHost(host) == "" {
+				v.add(SeverityError, "tlsPins."+host, fmt.Sprintf("invalid host '%s'", host))
+			}
+			if len(pins) == 0 {
+				v.add(SeverityError, "tlsPins."+host, "no pins given; remove the host to stop pinning it")
+			}
+			for i, pin := range pins {
+				if err := ValidateTLSPin(pin); err != nil {
+					v.add(SeverityError, fmt.Sprintf("tlsPins.%s[%d]", host, i), err.Error())
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(v.issues, func(i, j int) bool {
+		a, b := v.issues[i].Position, v.issues[j].Position
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return v.issues
+}
+
+// LocateKeys returns the position of every key path in a JSON document, e.g.
+// "profiles.dev.endpoint" or "fallbackEndpoints[0]". Invalid documents yield
+// the positions found before the first syntax error.
+func LocateKeys(data []byte) map[string]Position {
+	v := &validator{data: data, positions: make(map[string]Position)}
+	_ = v.walk(json.NewDecoder(bytes.NewReader(data)), "", nil) // Positions before a syntax error are still useful
+	return v.positions
+}
+
+// validator walks a JSON document token by token, so that every issue can be
+// reported with the position of the key it belongs to
+type validator struct {
+	data      []byte
+	positions map[string]Position
+	issues    []Issue
+}
+
+// position converts a byte offset into a line and column
+func (v *validator) position(offset int64) Position {
+	if offset > int64(len(v.data)) {
+		offset = int64(len(v.data))
+	}
+	before := v.data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+	return Position{Line: line, Column: column}
+}
+
+// nextValue skips whitespace and separators to the start of the next value
+func (v *validator) nextValue(offset int64) int64 {
+	for offset < int64(len(v.data)) && strings.IndexByte(" \t\r\n,", v.data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+func (v *validator) add(severity Severity, path, message string) {
+	v.issues = append(v.issues, Issue{Severity: severity, Path: path, Position: v.positions[path], Message: message})
+}
+
+func (v *validator) checkEndpoint(path, endpoint string) {
+	if endpoint == "" {
+		return
+	}
+	if err := ValidateEndpoint(endpoint); err != nil {
+		v.add(SeverityError, path, err.Error())
+	}
+}
+
+// syntaxIssue describes a malformed document at the offset the decoder stopped
+func (v *validator) syntaxIssue(err error, dec *json.Decoder) Issue {
+	offset := dec.InputOffset()
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		offset = syntaxErr.Offset
+	}
+	message := err.Error()
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		message = "unexpected end of file"
+	}
+	return Issue{Severity: SeverityError, Position: v.position(offset), Message: "invalid JSON: " + message}
+}
+
+// walk reads one JSON value and checks it against typ; a nil typ accepts anything
+func (v *validator) walk(dec *json.Decoder, path string, typ reflect.Type) error {
+	for typ != nil && typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch tok := tok.(type) {
+	case json.Delim:
+		if tok == '{' {
+			if typ != nil && typ.Kind() != reflect.Struct && typ.Kind() != reflect.Map {
+				v.add(SeverityError, path, fmt.Sprintf("expected %s, got an object", kindName(typ)))
+				typ = nil
+			}
+			return v.walkObject(dec, path, typ)
+		}
+		if typ != nil && typ.Kind() != reflect.Slice {
+			v.add(SeverityError, path, fmt.Sprintf("expected %s, got an array", kindName(typ)))
+			typ = nil
+		}
+		var elem reflect.Type
+		if typ != nil {
+			elem = typ.Elem()
+		}
+		for i := 0; dec.More(); i++ {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			v.positions[itemPath] = v.position(v.nextValue(dec.InputOffset()))
+			if err := v.walk(dec, itemPath, elem); err != nil {
+				return err
+			}
+		}
+		_, err := dec.Token() // Closing bracket
+		return err
+	case nil:
+		return nil // null leaves the field at its zero value
+	default:
+		if typ != nil && !scalarMatches(typ, tok) {
+			v.add(SeverityError, path, fmt.Sprintf("expected %s, got %s", kindName(typ), jsonKindName(tok)))
+		}
+		return nil
+	}
+}
+
+// walkObject reads the members of an object whose opening brace was consumed
+func (v *validator) walkObject(dec *json.Decoder, path string, typ reflect.Type) error {
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+		// The decoder stops right after the key; point at its opening quote
+		start := dec.InputOffset() - int64(len(strconv.Quote(key)))
+		if start < 0 {
+			start = 0
+		}
+		v.positions[keyPath] = v.position(start)
+
+		var fieldType reflect.Type
+		if typ != nil {
+			if typ.Kind() == reflect.Map {
+				fieldType = typ.Elem()
+			} else if field, ok := jsonField(typ, key); ok {
+				fieldType = field
+			} else {
+				v.add(SeverityWarning, keyPath, fmt.Sprintf("unknown key '%s' is ignored%s", key, suggestKey(typ, key)))
+			}
+		}
+		if err := v.walk(dec, keyPath, fieldType); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token() // Closing brace
+	return err
+}
+
+// jsonField finds the struct field for a JSON key, matching case-insensitively like encoding/json
+func jsonField(typ reflect.Type, key string) (reflect.Type, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.EqualFold(name, key) {
+			return field.Type, true
+		}
+	}
+	return nil, false
+}
+
+// suggestKey proposes a known key for a misspelled one, e.g. "endpont" -> "endpoint"
+func suggestKey(typ reflect.Type, key string) string {
+	best, bestDistance := "", 3 // Only suggest close matches
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		if d := editDistance(strings.ToLower(name), strings.ToLower(key)); d < bestDistance {
+			best, bestDistance = name, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean '%s'?)", best)
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// scalarMatches reports whether a JSON scalar can be decoded into typ
+func scalarMatches(typ reflect.Type, tok json.Token) bool {
+	if typ == reflect.TypeOf(time.Time{}) {
+		_, ok := tok.(string)
+		return ok
+	}
+	switch tok.(type) {
+	case string:
+		return typ.Kind() == reflect.String
+	case bool:
+		return typ.Kind() == reflect.Bool
+	case float64:
+		switch typ.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return true
+		}
+	}
+	return typ.Kind() == reflect.Interface
+}
+
+// kindName describes a Go type in JSON terms
+func kindName(typ reflect.Type) string {
+	if typ == reflect.TypeOf(time.Time{}) {
+		return "a timestamp string"
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+// jsonKindName describes a JSON scalar token
+func jsonKindName(tok json.Token) string {
+	switch tok := tok.(type) {
+	case string:
+		return fmt.Sprintf("the string %q", tok)
+	case bool:
+		return fmt.Sprintf("%v", tok)
+	case float64:
+		return "a number"
+	default:
+		return "an unsupported value"
+	}
+}
+
+// loggedFiles remembers configuration files whose issues were already logged,
+// since the configuration is loaded several times per command
+var loggedFiles sync.Map
+
+// logIssuesOnce logs the issues of a configuration file once per process at the
+// given level; issues is only evaluated the first time.
+// Run 'agbcloud config validate' for the full report.
+func logIssuesOnce(file string, level log.Level, issues func() []Issue) {
+	if _, seen := loggedFiles.LoadOrStore(file, true); seen {
+		return
+	}
+	for _, issue := range issues() {
+		log.StandardLogger().Logf(level, "%s:%s", file, issue)
+	}
+}
+
+// errorIssues returns the issues with error severity
+func errorIssues(issues []Issue) []Issue {
+	var errs []Issue
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			errs = append(errs, issue)
+		}
+	}
+	return errs
+}
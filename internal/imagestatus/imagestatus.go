@@ -0,0 +1,103 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package imagestatus describes the statuses of a user image and how they change,
+// so that the commands, the pollers and the mock server agree on them.
+//
+// An image is built (Creating, then Available or CreateFailed), activated
+// (Deploying, then Published or Failed), restarted (Restarting, then Published)
+// and deactivated (Deleting, then Available). Ceased images had their instance
+// stopped by the server, e.g. for billing reasons, and can be activated again.
+package imagestatus
+
+// Status is the status of an image as reported by the API
+type Status string
+
+// The statuses the API reports
+const (
+	Creating     Status = "IMAGE_CREATING"
+	CreateFailed Status = "IMAGE_CREATE_FAILED"
+	Available    Status = "IMAGE_AVAILABLE"
+	Deploying    Status = "RESOURCE_DEPLOYING"
+	Published    Status = "RESOURCE_PUBLISHED"
+	Deleting     Status = "RESOURCE_DELETING"
+	Restarting   Status = "RESOURCE_RESTARTING"
+	Failed       Status = "RESOURCE_FAILED"
+	Ceased       Status = "RESOURCE_CEASED"
+)
+
+// All lists every known status
+var All = []Status{Creating, CreateFailed, Available, Deploying, Published, Deleting, Restarting, Failed, Ceased}
+
+// labels are the names statuses are shown with
+var labels = map[Status]string{
+	Creating:     "Creating",
+	CreateFailed: "Create Failed",
+	Available:    "Available",
+	Deploying:    "Activating",
+	Published:    "Activated",
+	Deleting:     "Deactivating",
+	Restarting:   "Restarting",
+	Failed:       "Activate Failed",
+	Ceased:       "Ceased Billing",
+}
+
+// nextExpected is the status each transitional status settles in when the
+// operation in progress succeeds
+var nextExpected = map[Status]Status{
+	Creating:   Available,
+	Deploying:  Published,
+	Deleting:   Available,
+	Restarting: Published,
+}
+
+// Known reports whether s is one of the statuses the CLI knows
+func (s Status) Known() bool {
+	_, ok := labels[s]
+	return ok
+}
+
+// Label returns the name s is shown with, or s itself for unknown statuses
+func (s Status) Label() string {
+	if label, ok := labels[s]; ok {
+		return label
+	}
+	return string(s)
+}
+
+// IsTransitional reports whether an operation on the image is in progress, so that
+// the status is going to change without any request
+func (s Status) IsTransitional() bool {
+	_, ok := nextExpected[s]
+	return ok
+}
+
+// IsTerminal reports whether s is a known status that only changes on request.
+// Unknown statuses are neither transitional nor terminal; pollers keep watching them.
+func (s Status) IsTerminal() bool {
+	return s.Known() && !s.IsTransitional()
+}
+
+// NextExpected returns the status a transitional status settles in when the
+// operation in progress succeeds; ok is false for other statuses
+func (s Status) NextExpected() (next Status, ok bool) {
+	next, ok = nextExpected[s]
+	return next, ok
+}
+
+// IsFailed reports whether the last build or activation of the image failed
+func (s Status) IsFailed() bool {
+	return s == CreateFailed || s == Failed
+}
+
+// HasInstance reports whether the image has an instance that is running or being
+// started, i.e. whether a deactivation has something to stop
+func (s Status) HasInstance() bool {
+	return s == Deploying || s == Published || s == Restarting
+}
+
+// CanActivate reports whether an activation request starts a new instance of the
+// image: it is built and has no instance
+func (s Status) CanActivate() bool {
+	return s == Available || s == Failed || s == Ceased
+}
@@ -0,0 +1,1589 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package mockserver is an in-memory stand-in for the AgbCloud image API.
+//
+// It serves the endpoints the CLI uses for listing, creating, activating,
+// deactivating and deleting images and for managing SSH keys, so that QA
+// and demos can run the CLI without an account. Its state is filled with
+// generated images through the /dev/seed endpoint (see 'agbcloud dev seed').
+// Any non-empty login token and session ID are accepted.
+//
+// Operations that take time on the real backend complete one status check
+// later: an activated image is reported as Activating once, then as
+// Activated, and a build is Preparing until its task is checked once.
+// Activating images share a deployment queue of capacity one, in the order
+// they were activated. Images can be built for the platforms in Platforms.
+// At most ReservationCapacity capacity reservations can be held at a time, and
+// new images are only created while there are fewer than ImageQuota user images.
+// Create requests can be checked without building through the validate endpoint,
+// which rejects Dockerfiles using ForbiddenInstructions.
+// Dockerfiles uploaded to UploadPath are kept with the image they build and
+// returned by the manifest endpoint.
+// A POST request repeating the Idempotency-Key of an earlier one is not
+// carried out again; it gets the response to the first request.
+// A deactivation with a grace period stays Deactivating until the period is over.
+// Image lists carry an ETag, and a list request with a matching If-None-Match
+// header is answered with 304 Not Modified.
+// Snapshots of activated images are built by an image task like created images.
+// The usage endpoint reports the activation history of user images: seeded images
+// get a generated history, which every activation and deactivation extends.
+// Webhooks registered with a build are reported as delivered once the build has
+// ended; the mock does not call them.
+// The session list holds the session of the request and the OtherSessions; revoked
+// sessions are no longer listed.
+package mockserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/imagestatus"
+)
+
+// Paths of the endpoints that only the mock server provides
+const (
+	SeedPath   = "/dev/seed"
+	UploadPath = "/dev/upload"
+)
+
+// ErrNotMockServer is returned by RequestSeed when the endpoint is not a mock server
+var ErrNotMockServer = errors.New("the endpoint is not a mock server")
+
+// Statuses are the image statuses a seeded image can have
+var Statuses = []string{
+	string(imagestatus.Available),
+	string(imagestatus.Published),
+	string(imagestatus.Creating),
+	string(imagestatus.CreateFailed),
+	string(imagestatus.Deploying),
+	string(imagestatus.Deleting),
+	string(imagestatus.Failed),
+	string(imagestatus.Ceased),
+}
+
+// Platforms are the platforms images can be built for; the first is the default
+var Platforms = []string{"linux/amd64", "linux/arm64"}
+
+// ReservationCapacity is the number of capacity reservations that can be held at a time
+const ReservationCapacity = 2
+
+// ImageQuota is the number of user images that can exist at a time
+const ImageQuota = 500
+
+// ForbiddenInstructions are the Dockerfile instructions the validate endpoint rejects:
+// the base image is chosen with sourceImageId, and instances have no volumes
+var ForbiddenInstructions = []string{"FROM", "VOLUME"}
+
+// reservationSpecs are the CPU/memory combinations that can be reserved
+var reservationSpecs = map[int]int{2: 4, 4: 8, 8: 16}
+
+// reservationValidity is how long unused reserved capacity is held
+const reservationValidity = 24 * time.Hour
+
+// systemImages are the base images every seeded state contains
+var systemImages = []client.ImageInfo{
+	{ImageID: "agb-code-space-1", ImageName: "Code Space", Status: string(imagestatus.Available), Type: "System", OSType: "Linux", Version: "1.3.0"},
+	{ImageID: "agb-browser-use-1", ImageName: "Browser Use", Status: string(imagestatus.Available), Type: "System", OSType: "Linux", Version: "2.1.0"},
+	{ImageID: "agb-computer-use-1", ImageName: "Computer Use", Status: string(imagestatus.Available), Type: "System", OSType: "Linux", Version: "1.0.4"},
+}
+
+// OtherSessions are the login sessions of the user on other machines
+var OtherSessions = []client.SessionInfo{
+	{SessionID: "session-mock-laptop", Device: "agbcloud-cli/darwin (dev-laptop)", CreateTime: "2025-01-06T09:12:00Z", LastUsedTime: "2025-01-20T17:45:00Z"},
+	{SessionID: "session-mock-ci", Device: "agbcloud-cli/linux (ci-runner-3)", CreateTime: "2025-01-15T02:00:00Z", LastUsedTime: "2025-01-21T02:03:00Z"},
+}
+
+// Demo credentials returned by /dev/seed; any non-empty values are accepted
+const (
+	DemoLoginToken = "mock-login-token"
+	DemoSessionID  = "mock-session-id"
+)
+
+// SeedRequest is the body of a /dev/seed request
+type SeedRequest struct {
+	Images   int      `json:"images"`   // Number of user images to generate
+	Statuses []string `json:"statuses"` // Statuses assigned round-robin (default: all Statuses)
+	Seed     int64    `json:"seed"`     // Random seed for names, resources and timestamps
+}
+
+// SeedResponse is the answer to a /dev/seed request
+type SeedResponse struct {
+	Success    bool           `json:"success"`
+	Images     int            `json:"images"`
+	ByStatus   map[string]int `json:"byStatus"`
+	LoginToken string         `json:"loginToken"`
+	SessionID  string         `json:"sessionId"`
+}
+
+// ParseStatuses parses the --statuses value of 'dev seed': "mixed" for every
+// status, or a comma-separated list of statuses
+func ParseStatuses(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.EqualFold(value, "mixed") {
+		return append([]string(nil), Statuses...), nil
+	}
+
+	var statuses []string
+	for _, part := range strings.Split(value, ",") {
+		status := strings.ToUpper(strings.TrimSpace(part))
+		if !isKnownStatus(status) {
+			return nil, fmt.Errorf("unknown status %q; use mixed or a comma-separated list of %s", part, strings.Join(Statuses, ", "))
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func isKnownStatus(status string) bool {
+	return slices.Contains(Statuses, status)
+}
+
+// Server holds the fake backend state. Use New to create one.
+type Server struct {
+	mu       sync.Mutex
+	images   []client.ImageInfo
+	tasks    []client.ImageTaskInfo
+	sshKeys  []client.SSHKeyInfo
+	accounts []client.ServiceAccountInfo
+	sessions []client.SessionInfo    // Sessions on other machines
+	revoked  map[string]bool         // Session IDs that were revoked
+	tokens   map[string]accountToken // Service account token -> account and expiry
+	reserved []client.ImageReservationData
+	pending  map[string]string                  // Image ID -> status reached after the next status check
+	grace    map[string]time.Time               // Image ID -> end of the grace period of a deactivation
+	builds   map[string][]string                // Task ID -> platforms of a multi-platform build
+	webhooks map[string]client.ImageTaskWebhook // Task ID -> webhook registered with the build
+	uploads  map[string][]byte                  // Task ID -> uploaded Dockerfile, until the image is created
+	files    map[string][]byte                  // Image ID -> Dockerfile the image was built from
+	usage    map[string]client.ImageUsage       // Image ID -> activation history, without the current activation
+	active   map[string]time.Time               // Image ID -> start of the current activation
+	nextID   int                                // Last number used for generated image and task IDs
+	requests int                                // Number of API requests answered, for request IDs
+	now      func() time.Time
+
+	keysMu    sync.Mutex
+	responses map[string]recordedResponse // Idempotency key -> response to the first request
+}
+
+// accountToken is an issued service account token
+type accountToken struct {
+	accountID string
+	expiresAt time.Time
+}
+
+// recordedResponse is the response to a POST request that carried an idempotency key
+type recordedResponse struct {
+	path   string
+	status int
+	header http.Header
+	body   []byte
+}
+
+// responseRecorder passes a response through while keeping a copy of it
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+// New returns a server containing only the System images
+func New() *Server {
+	s := &Server{now: time.Now}
+	s.reset()
+	return s
+}
+
+func (s *Server) reset() {
+	s.images = append([]client.ImageInfo(nil), systemImages...)
+	s.tasks = nil
+	s.sshKeys = nil
+	s.accounts = nil
+	s.sessions = append([]client.SessionInfo(nil), OtherSessions...)
+	s.revoked = make(map[string]bool)
+	s.tokens = make(map[string]accountToken)
+	s.reserved = nil
+	s.pending = make(map[string]string)
+	s.grace = make(map[string]time.Time)
+	s.builds = make(map[string][]string)
+	s.webhooks = make(map[string]client.ImageTaskWebhook)
+	s.uploads = make(map[string][]byte)
+	s.files = make(map[string][]byte)
+	s.usage = make(map[string]client.ImageUsage)
+	s.active = make(map[string]time.Time)
+	s.nextID = 0
+
+	s.keysMu.Lock()
+	s.responses = make(map[string]recordedResponse)
+	s.keysMu.Unlock()
+}
+
+// Seed replaces all user images with req.Images generated images
+func (s *Server) Seed(req SeedRequest) (SeedResponse, error) {
+	if req.Images < 0 {
+		return SeedResponse{}, fmt.Errorf("images must not be negative")
+	}
+	statuses := req.Statuses
+	if len(statuses) == 0 {
+		statuses = Statuses
+	}
+	for _, status := range statuses {
+		if !isKnownStatus(status) {
+			return SeedResponse{}, fmt.Errorf("unknown status %q", status)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reset()
+
+	rng := rand.New(rand.NewSource(req.Seed))
+	// Usage is drawn separately, so that it does not change the generated images
+	usageRng := rand.New(rand.NewSource(req.Seed + 1))
+	prefixes := []string{"web", "api", "worker", "ml", "data", "test", "demo", "ci"}
+	byStatus := make(map[string]int)
+	now := s.now().UTC()
+	for i := 0; i < req.Images; i++ {
+		status := statuses[i%len(statuses)]
+		base := systemImages[rng.Intn(len(systemImages))]
+		updated := now.Add(-time.Duration(rng.Intn(90*24)) * time.Hour).Format(time.RFC3339)
+
+		image := client.ImageInfo{
+			ImageID:            fmt.Sprintf("img-mock%04d", i+1),
+			ImageName:          fmt.Sprintf("%s-%03d", prefixes[rng.Intn(len(prefixes))], i+1),
+			Status:             status,
+			Type:               "User",
+			OSType:             "Linux",
+			UpdateTime:         updated,
+			SourceImageID:      base.ImageID,
+			SourceImageVersion: olderVersion(base.Version, rng.Intn(3)),
+		}
+		if imagestatus.Status(status).HasInstance() {
+			cpu, memory := 2<<rng.Intn(2), 4<<rng.Intn(2)
+			image.CPU, image.Memory = &cpu, &memory
+		}
+		if status != string(imagestatus.Creating) && status != string(imagestatus.CreateFailed) {
+			image.Digest = imageDigest(image)
+			// Every third built image has no warm capacity, so fast starts can fail
+			warm := i % 3
+			image.WarmInstances = &warm
+			s.usage[image.ImageID] = seededUsage(usageRng, image.ImageID, now)
+		}
+		// Images in a transitional status settle after their first status check
+		if target, ok := imagestatus.Status(status).NextExpected(); ok {
+			s.pending[image.ImageID] = string(target)
+		}
+		s.images = append(s.images, image)
+		byStatus[status]++
+	}
+	s.nextID = req.Images
+
+	return SeedResponse{Success: true, Images: req.Images, ByStatus: byStatus, LoginToken: DemoLoginToken, SessionID: DemoSessionID}, nil
+}
+
+// seededUsage draws the activation history of a seeded image; about a quarter of the
+// images were never activated, so that stale images can be found
+func seededUsage(rng *rand.Rand, imageID string, now time.Time) client.ImageUsage {
+	usage := client.ImageUsage{ImageID: imageID, ActivationCount: max(rng.Intn(40)-10, 0)}
+	if usage.ActivationCount > 0 {
+		usage.ActiveHours = float64(usage.ActivationCount*rng.Intn(80)) / 10
+		usage.LastUsedTime = now.Add(-time.Duration(rng.Intn(120*24)) * time.Hour).Format(time.RFC3339)
+	}
+	return usage
+}
+
+// imageDigest derives a stable content digest for a built image
+func imageDigest(image client.ImageInfo) string {
+	sum := sha256.Sum256([]byte(image.ImageID + "/" + image.ImageName + "/" + image.SourceImageID))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// olderVersion lowers the minor version by n, so some images are built on outdated bases
+func olderVersion(version string, n int) string {
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return version
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil || minor < n {
+		return version
+	}
+	parts[1] = strconv.Itoa(minor - n)
+	return strings.Join(parts, ".")
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get(client.IdempotencyKeyHeader)
+	if r.Method != http.MethodPost || key == "" {
+		s.route(w, r)
+		return
+	}
+
+	s.keysMu.Lock()
+	recorded, seen := s.responses[key]
+	s.keysMu.Unlock()
+	if seen {
+		s.replay(w, r, recorded)
+		return
+	}
+	recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+	s.route(recorder, r)
+	s.keysMu.Lock()
+	s.responses[key] = recordedResponse{path: r.URL.Path, status: recorder.status, header: w.Header().Clone(), body: recorder.body.Bytes()}
+	s.keysMu.Unlock()
+}
+
+// replay answers a repeated request with the response to the first request with its
+// idempotency key. Reusing a key for a different endpoint is an error.
+func (s *Server) replay(w http.ResponseWriter, r *http.Request, recorded recordedResponse) {
+	if recorded.path != r.URL.Path {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.reply(w, "IdempotencyKeyReused", nil)
+		return
+	}
+	for name, values := range recorded.header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(recorded.status)
+	_, _ = w.Write(recorded.body) // The client going away is not an error of the mock
+}
+
+// route passes a request to the handler of its endpoint
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case SeedPath:
+		s.handleSeed(w, r)
+	case UploadPath:
+		s.handleUpload(w, r)
+	case "/api/image/list":
+		s.handleList(w, r)
+	case "/api/image/start":
+		s.handleTransition(w, r, imagestatus.Deploying)
+	case "/api/image/stop":
+		s.handleTransition(w, r, imagestatus.Deleting)
+	case "/api/image/restart":
+		s.handleRestart(w, r)
+	case "/api/image/snapshot":
+		s.handleSnapshot(w, r)
+	case "/api/image/update":
+		s.handleUpdate(w, r)
+	case "/api/image/delete":
+		s.handleDelete(w, r)
+	case "/api/image/getUploadCredential":
+		s.handleUploadCredential(w, r)
+	case "/api/image/create":
+		s.handleCreate(w, r)
+	case "/api/image/task":
+		s.handleTask(w, r)
+	case "/api/image/task/list":
+		s.handleTaskList(w, r)
+	case "/api/image/task/delete":
+		s.handleTaskDelete(w, r)
+	case "/api/image/base/versions":
+		s.handleBaseVersions(w, r)
+	case "/api/image/usage":
+		s.handleUsage(w, r)
+	case "/api/image/queue":
+		s.handleQueue(w, r)
+	case "/api/image/manifest":
+		s.handleManifest(w, r)
+	case "/api/image/capabilities":
+		s.handleCapabilities(w, r)
+	case "/api/image/validate":
+		s.handleValidate(w, r)
+	case "/api/image/reservation/create":
+		s.handleReserve(w, r)
+	case "/api/image/reservation":
+		s.handleReservation(w, r)
+	case "/api/image/reservation/release":
+		s.handleReservationRelease(w, r)
+	case "/api/sshkey/add":
+		s.handleSSHKeyAdd(w, r)
+	case "/api/sshkey/list":
+		s.handleSSHKeyList(w, r)
+	case "/api/sshkey/delete":
+		s.handleSSHKeyDelete(w, r)
+	case "/api/session/list":
+		s.handleSessionList(w, r)
+	case "/api/session/revoke":
+		s.handleSessionRevoke(w, r)
+	case "/api/serviceaccount/create":
+		s.handleServiceAccountCreate(w, r)
+	case "/api/serviceaccount/list":
+		s.handleServiceAccountList(w, r)
+	case "/api/serviceaccount/delete":
+		s.handleServiceAccountDelete(w, r)
+	case "/api/serviceaccount/token/issue":
+		s.handleServiceAccountToken(w, r)
+	case "/api/serviceaccount/login":
+		s.handleServiceAccountLogin(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// envelope is the response format shared by all API endpoints
+type envelope struct {
+	Code      string      `json:"code"`
+	RequestID string      `json:"requestId"`
+	Success   bool        `json:"success"`
+	Data      interface{} `json:"data"`
+}
+
+func (s *Server) reply(w http.ResponseWriter, code string, data interface{}) {
+	s.requests++
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(envelope{ // The client going away is not an error of the mock
+		Code:      code,
+		RequestID: fmt.Sprintf("mock-request-%d", s.requests),
+		Success:   code == "success",
+		Data:      data,
+	})
+}
+
+// authorized checks that credentials were sent, as query parameters or in the JSON body
+func authorized(query url.Values, body map[string]interface{}) bool {
+	if query.Get("loginToken") != "" && query.Get("sessionId") != "" {
+		return true
+	}
+	login, _ := body["loginToken"].(string)
+	session, _ := body["sessionId"].(string)
+	return login != "" && session != ""
+}
+
+// decodeBody reads a JSON request body; an empty or invalid body yields an empty map
+func decodeBody(r *http.Request) map[string]interface{} {
+	body := make(map[string]interface{})
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body) // Invalid bodies fail the authorization check
+	}
+	return body
+}
+
+func (s *Server) handleSeed(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var req SeedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resp, err := s.Seed(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp) // The client going away is not an error of the mock
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(query, nil) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+
+	imageType := query.Get("imageType")
+	name := strings.ToLower(query.Get("imageName"))
+	ids := query["imageIds"]
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("pageSize"))
+	if page <= 0 || pageSize <= 0 {
+		s.reply(w, "InvalidParameter", nil)
+		return
+	}
+
+	var matches []client.ImageInfo
+	for _, image := range s.images {
+		if image.Type != imageType {
+			continue
+		}
+		if name != "" && !strings.Contains(strings.ToLower(image.ImageName), name) {
+			continue
+		}
+		if len(ids) > 0 && !slices.Contains(ids, image.ImageID) {
+			continue
+		}
+		matches = append(matches, image)
+	}
+
+	start := min((page-1)*pageSize, len(matches))
+	end := min(start+pageSize, len(matches))
+	pageImages := append([]client.ImageInfo{}, matches[start:end]...)
+
+	// Transitions complete once their intermediate status has been reported
+	for _, image := range pageImages {
+		if end, ok := s.grace[image.ImageID]; ok && s.now().Before(end) {
+			continue
+		}
+		if target, ok := s.pending[image.ImageID]; ok {
+			s.setStatus(image.ImageID, target)
+			delete(s.pending, image.ImageID)
+			delete(s.grace, image.ImageID)
+		}
+	}
+
+	data := client.ImageListData{Images: pageImages, Total: len(matches), Page: page, PageSize: pageSize}
+	etag := listETag(data)
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		s.requests++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	s.reply(w, "success", data)
+}
+
+// listETag derives the entity tag of a list page from its content
+func listETag(data client.ImageListData) string {
+	encoded, _ := json.Marshal(data) // Image lists always encode
+	sum := sha256.Sum256(encoded)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// handleTransition moves a user image to an intermediate status that settles in the
+// status it is expected to reach after the next status check
+func (s *Server) handleTransition(w http.ResponseWriter, r *http.Request, intermediate imagestatus.Status) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", false)
+		return
+	}
+	imageID, _ := body["imageId"].(string)
+	image := s.find(imageID)
+	if image == nil || image.Type != "User" {
+		s.reply(w, "ImageNotFound", false)
+		return
+	}
+
+	if intermediate == imagestatus.Deploying {
+		if fastStart, _ := body["fastStart"].(bool); fastStart {
+			if image.WarmInstances == nil || *image.WarmInstances == 0 {
+				s.reply(w, "WarmCapacityUnavailable", false)
+				return
+			}
+			warm := *image.WarmInstances - 1
+			image.WarmInstances = &warm
+		}
+		cpu, memory := 2, 4
+		if defaults := image.ActivationDefaults; defaults != nil && defaults.CPU > 0 {
+			cpu, memory = defaults.CPU, defaults.Memory
+		}
+		// The first activation uses the reserved capacity and its size
+		if reservation := s.findReservation(imageID); reservation != nil && reservation.Active() {
+			reservation.Status = client.ReservationConsumed
+			cpu, memory = reservation.CPU, reservation.Memory
+		}
+		if value, ok := body["cpu"].(float64); ok && value > 0 {
+			cpu = int(value)
+		}
+		if value, ok := body["memory"].(float64); ok && value > 0 {
+			memory = int(value)
+		}
+		image.CPU, image.Memory = &cpu, &memory
+	}
+	delete(s.grace, imageID)
+	if seconds, ok := body["gracePeriodSeconds"].(float64); ok && seconds > 0 && intermediate == imagestatus.Deleting {
+		if force, _ := body["force"].(bool); !force {
+			s.grace[imageID] = s.now().Add(time.Duration(seconds) * time.Second)
+		}
+	}
+	s.recordUsage(imageID, intermediate)
+	target, _ := intermediate.NextExpected()
+	image.Status = string(intermediate)
+	image.UpdateTime = s.now().UTC().Format(time.RFC3339)
+	s.pending[imageID] = string(target)
+	s.reply(w, "success", true)
+}
+
+// handleRestart restarts the instance of an activated user image, which reports
+// RESOURCE_RESTARTING until the next status check
+func (s *Server) handleRestart(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", false)
+		return
+	}
+	imageID, _ := body["imageId"].(string)
+	image := s.find(imageID)
+	if image == nil || image.Type != "User" {
+		s.reply(w, "ImageNotFound", false)
+		return
+	}
+	if image.Status != string(imagestatus.Published) {
+		s.reply(w, "ImageNotActivated", false)
+		return
+	}
+	target, _ := imagestatus.Restarting.NextExpected()
+	image.Status = string(imagestatus.Restarting)
+	image.UpdateTime = s.now().UTC().Format(time.RFC3339)
+	s.pending[imageID] = string(target)
+	s.reply(w, "success", true)
+}
+
+// handleSnapshot starts a task that captures the instance of an activated user image
+// into a new image, built like the images of create requests
+func (s *Server) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	imageID, _ := body["imageId"].(string)
+	name, _ := body["imageName"].(string)
+	if name == "" {
+		s.reply(w, "InvalidParameter", nil)
+		return
+	}
+	image := s.find(imageID)
+	if image == nil || image.Type != "User" {
+		s.reply(w, "ImageNotFound", nil)
+		return
+	}
+	if image.Status != string(imagestatus.Published) {
+		s.reply(w, "ImageNotActivated", nil)
+		return
+	}
+	if s.userImageCount() >= ImageQuota {
+		s.reply(w, "ImageQuotaExceeded", nil)
+		return
+	}
+
+	s.nextID++
+	taskID := fmt.Sprintf("task-mock%04d", s.nextID)
+	snapshotID := fmt.Sprintf("img-mock%04d", s.nextID)
+	now := s.now().UTC().Format(time.RFC3339)
+	s.images = append(s.images, client.ImageInfo{
+		ImageID: snapshotID, ImageName: name, Status: string(imagestatus.Creating), Type: "User", OSType: image.OSType,
+		UpdateTime: now, SourceImageID: image.SourceImageID, SourceImageVersion: image.SourceImageVersion,
+	})
+	s.tasks = append(s.tasks, client.ImageTaskInfo{
+		TaskID: taskID, ImageName: name, Status: "Preparing", SourceImageID: image.SourceImageID, ImageID: &snapshotID, CreateTime: now,
+	})
+	s.reply(w, "success", client.ImageSnapshotData{TaskID: taskID})
+}
+
+// handleUpdate stores the default activation settings of a user image; empty settings
+// remove them
+func (s *Server) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		LoginToken         string                         `json:"loginToken"`
+		SessionId          string                         `json:"sessionId"`
+		ImageId            string                         `json:"imageId"`
+		ActivationDefaults client.ImageActivationDefaults `json:"activationDefaults"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body) // A malformed body fails authorization below
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if body.LoginToken == "" || body.SessionId == "" {
+		s.reply(w, "InvalidSession", false)
+		return
+	}
+	image := s.find(body.ImageId)
+	if image == nil || image.Type != "User" {
+		s.reply(w, "ImageNotFound", false)
+		return
+	}
+	image.ActivationDefaults = nil
+	if !body.ActivationDefaults.IsZero() {
+		defaults := body.ActivationDefaults
+		image.ActivationDefaults = &defaults
+	}
+	image.UpdateTime = s.now().UTC().Format(time.RFC3339)
+	s.reply(w, "success", true)
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", false)
+		return
+	}
+	imageID, _ := body["imageId"].(string)
+	for i, image := range s.images {
+		if image.ImageID == imageID && image.Type == "User" {
+			s.images = append(s.images[:i], s.images[i+1:]...)
+			delete(s.pending, imageID)
+			delete(s.files, imageID)
+			s.reply(w, "success", true)
+			return
+		}
+	}
+	s.reply(w, "ImageNotFound", false)
+}
+
+func (s *Server) handleUploadCredential(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(r.URL.Query(), nil) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	s.nextID++
+	taskID := fmt.Sprintf("task-mock%04d", s.nextID)
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	s.reply(w, "success", client.ImageUploadCredentialData{
+		OssURL:     fmt.Sprintf("%s://%s%s?task=%s", scheme, r.Host, UploadPath, taskID),
+		TaskID:     taskID,
+		ExpireTime: s.now().Add(15 * time.Minute).UTC().Format(time.RFC3339),
+	})
+}
+
+// handleUpload keeps an uploaded Dockerfile for the image its task creates
+func (s *Server) handleUpload(w http.ResponseWriter, r *http.Request) {
+	content, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if task := r.URL.Query().Get("task"); task != "" {
+		s.mu.Lock()
+		s.uploads[task] = content
+		s.mu.Unlock()
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	name, _ := body["imageName"].(string)
+	taskID, _ := body["taskId"].(string)
+	sourceID, _ := body["sourceImageId"].(string)
+	if name == "" || taskID == "" {
+		s.reply(w, "InvalidParameter", nil)
+		return
+	}
+	source := s.find(sourceID)
+	if source == nil || source.Type != "System" {
+		s.reply(w, "SourceImageNotFound", nil)
+		return
+	}
+	if s.userImageCount() >= ImageQuota {
+		s.reply(w, "ImageQuotaExceeded", nil)
+		return
+	}
+	var platforms []string
+	if requested, ok := body["platforms"].([]interface{}); ok {
+		for _, value := range requested {
+			platform, _ := value.(string)
+			if !slices.Contains(Platforms, platform) {
+				s.reply(w, "UnsupportedPlatform", nil)
+				return
+			}
+			platforms = append(platforms, platform)
+		}
+	}
+
+	s.nextID++
+	imageID := fmt.Sprintf("img-mock%04d", s.nextID)
+	now := s.now().UTC().Format(time.RFC3339)
+	s.images = append(s.images, client.ImageInfo{
+		ImageID: imageID, ImageName: name, Status: string(imagestatus.Creating), Type: "User", OSType: "Linux",
+		UpdateTime: now, SourceImageID: source.ImageID, SourceImageVersion: source.Version,
+	})
+	s.tasks = append(s.tasks, client.ImageTaskInfo{
+		TaskID: taskID, ImageName: name, Status: "Preparing", SourceImageID: source.ImageID, ImageID: &imageID, CreateTime: now,
+	})
+	if len(platforms) > 0 {
+		s.builds[taskID] = platforms
+	}
+	if callbackURL, _ := body["callbackUrl"].(string); callbackURL != "" {
+		s.webhooks[taskID] = client.ImageTaskWebhook{URL: callbackURL, Status: "Pending"}
+	}
+	if dockerfile, ok := s.uploads[taskID]; ok {
+		s.files[imageID] = dockerfile
+		delete(s.uploads, taskID)
+	}
+	for i := range s.reserved {
+		if s.reserved[i].TaskID == taskID {
+			s.reserved[i].ImageID = imageID
+		}
+	}
+	s.reply(w, "success", imageID)
+}
+
+func (s *Server) handleTask(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(query, nil) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	task := s.findTask(query.Get("taskId"))
+	if task == nil {
+		s.reply(w, "TaskNotFound", nil)
+		return
+	}
+
+	data := client.ImageTaskData{Status: task.Status, TaskMsg: task.TaskMsg, ImageID: task.ImageID}
+	// The first platform is built before the others
+	for i, platform := range s.builds[task.TaskID] {
+		status := task.Status
+		if status == "Preparing" && i > 0 {
+			status = "Inline"
+		}
+		data.Platforms = append(data.Platforms, client.ImagePlatformBuild{Platform: platform, Status: status})
+	}
+	if task.Status == "Finished" && task.ImageID != nil {
+		if image := s.find(*task.ImageID); image != nil {
+			data.Digest = image.Digest
+		}
+	}
+	if webhook, ok := s.webhooks[task.TaskID]; ok {
+		data.Webhook = &webhook
+	}
+	// A build finishes once it has been reported as Preparing
+	if task.Status == "Preparing" {
+		task.Status = "Finished"
+		if webhook, ok := s.webhooks[task.TaskID]; ok {
+			webhook.Status, webhook.Attempts, webhook.LastResponseCode = "Delivered", 1, http.StatusOK
+			webhook.LastAttemptTime = s.now().UTC().Format(time.RFC3339)
+			s.webhooks[task.TaskID] = webhook
+		}
+		if task.ImageID != nil {
+			s.setStatus(*task.ImageID, string(imagestatus.Available))
+			if image := s.find(*task.ImageID); image != nil {
+				image.Digest = imageDigest(*image)
+			}
+		}
+	}
+	s.reply(w, "success", data)
+}
+
+func (s *Server) handleTaskList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(query, nil) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	name := query.Get("imageName")
+	page, _ := strconv.Atoi(query.Get("page"))
+	pageSize, _ := strconv.Atoi(query.Get("pageSize"))
+	if page <= 0 || pageSize <= 0 {
+		s.reply(w, "InvalidParameter", nil)
+		return
+	}
+
+	// Most recent first
+	var matches []client.ImageTaskInfo
+	for i := len(s.tasks) - 1; i >= 0; i-- {
+		if name == "" || s.tasks[i].ImageName == name {
+			matches = append(matches, s.tasks[i])
+		}
+	}
+	start := min((page-1)*pageSize, len(matches))
+	end := min(start+pageSize, len(matches))
+	s.reply(w, "success", client.ImageTaskListData{Tasks: matches[start:end], Total: len(matches), Page: page, PageSize: pageSize})
+}
+
+func (s *Server) handleTaskDelete(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", false)
+		return
+	}
+	taskID, _ := body["taskId"].(string)
+	for i, task := range s.tasks {
+		if task.TaskID == taskID {
+			s.tasks = append(s.tasks[:i], s.tasks[i+1:]...)
+			delete(s.builds, taskID)
+			delete(s.webhooks, taskID)
+			s.reply(w, "success", true)
+			return
+		}
+	}
+	s.reply(w, "TaskNotFound", false)
+}
+
+func (s *Server) handleBaseVersions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(query, nil) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	versions := []client.BaseImageVersion{}
+	for _, image := range s.images {
+		if image.Type == "System" && (len(query["imageIds"]) == 0 || slices.Contains(query["imageIds"], image.ImageID)) {
+			versions = append(versions, client.BaseImageVersion{ImageID: image.ImageID, LatestVersion: image.Version})
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].ImageID < versions[j].ImageID })
+	s.reply(w, "success", versions)
+}
+
+// recordUsage updates the activation history of an image that starts being activated
+// or deactivated
+func (s *Server) recordUsage(imageID string, intermediate imagestatus.Status) {
+	now := s.now()
+	usage := s.usage[imageID]
+	usage.ImageID = imageID
+	usage.LastUsedTime = now.UTC().Format(time.RFC3339)
+	if since, ok := s.active[imageID]; ok {
+		usage.ActiveHours += now.Sub(since).Hours()
+		delete(s.active, imageID)
+	}
+	if intermediate == imagestatus.Deploying {
+		usage.ActivationCount++
+		s.active[imageID] = now
+	}
+	s.usage[imageID] = usage
+}
+
+// handleUsage reports the activation history of the requested user images, or of
+// all user images; the current activation counts up to now
+func (s *Server) handleUsage(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(query, nil) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	usages := []client.ImageUsage{}
+	for _, image := range s.images {
+		if image.Type != "User" || (len(query["imageIds"]) > 0 && !slices.Contains(query["imageIds"], image.ImageID)) {
+			continue
+		}
+		usage := s.usage[image.ImageID]
+		usage.ImageID = image.ImageID
+		if since, ok := s.active[image.ImageID]; ok {
+			usage.ActiveHours += s.now().Sub(since).Hours()
+		}
+		usages = append(usages, usage)
+	}
+	s.reply(w, "success", usages)
+}
+
+// queueWaitPerImage is the estimated deployment time of each activation ahead in the queue
+const queueWaitPerImage = 90 * time.Second
+
+func (s *Server) handleQueue(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(query, nil) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	imageID := query.Get("imageId")
+	if image := s.find(imageID); image == nil || image.Type != "User" {
+		s.reply(w, "ImageNotFound", nil)
+		return
+	}
+
+	// The first activating image is deployed, the others wait in activation order
+	var deploying []client.ImageInfo
+	for _, image := range s.images {
+		if image.Status == string(imagestatus.Deploying) {
+			deploying = append(deploying, image)
+		}
+	}
+	sort.SliceStable(deploying, func(i, j int) bool { return deploying[i].UpdateTime < deploying[j].UpdateTime })
+
+	data := client.ImageQueueData{ImageID: imageID, QueueLength: max(len(deploying)-1, 0)}
+	for position, image := range deploying {
+		if image.ImageID == imageID {
+			data.Position = position
+			data.EstimatedWaitSeconds = int((time.Duration(position) * queueWaitPerImage).Seconds())
+		}
+	}
+	s.reply(w, "success", data)
+}
+
+func (s *Server) handleCapabilities(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(r.URL.Query(), nil) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	s.reply(w, "success", client.ImageCapabilitiesData{
+		Platforms:       Platforms,
+		DefaultPlatform: Platforms[0],
+		Features:        []string{client.FeatureMultiPlatform},
+	})
+}
+
+func (s *Server) handleManifest(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(query, nil) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	image := s.find(query.Get("imageId"))
+	if image == nil {
+		s.reply(w, "ImageNotFound", nil)
+		return
+	}
+
+	manifest := client.ImageManifestData{
+		ImageID:            image.ImageID,
+		ImageName:          image.ImageName,
+		SourceImageID:      image.SourceImageID,
+		SourceImageVersion: image.SourceImageVersion,
+		Digest:             image.Digest,
+		CPU:                image.CPU,
+		Memory:             image.Memory,
+	}
+	for _, task := range s.tasks {
+		if task.ImageID != nil && *task.ImageID == image.ImageID {
+			manifest.Platforms = s.builds[task.TaskID]
+		}
+	}
+	if dockerfile, ok := s.files[image.ImageID]; ok {
+		sum := sha256.Sum256(dockerfile)
+		manifest.DockerfileDigest = "sha256:" + hex.EncodeToString(sum[:])
+		manifest.Dockerfile = string(dockerfile)
+	}
+	s.reply(w, "success", manifest)
+}
+
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	name, _ := body["imageName"].(string)
+	sourceID, _ := body["sourceImageId"].(string)
+	dockerfile, _ := body["dockerfile"].(string)
+	if name == "" || sourceID == "" {
+		s.reply(w, "InvalidParameter", nil)
+		return
+	}
+
+	var findings []client.ImageValidationFinding
+	add := func(severity, code string, line int, format string, args ...interface{}) {
+		findings = append(findings, client.ImageValidationFinding{Severity: severity, Code: code, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+	if source := s.find(sourceID); source == nil || source.Type != "System" {
+		add(client.ValidationError, "SourceImageNotFound", 0, "base image '%s' does not exist", sourceID)
+	}
+	if count := s.userImageCount(); count >= ImageQuota {
+		add(client.ValidationError, "ImageQuotaExceeded", 0, "the image quota of %d images is used up", ImageQuota)
+	}
+	for _, image := range s.images {
+		if image.Type == "User" && image.ImageName == name {
+			add(client.ValidationWarning, "ImageNameExists", 0, "an image named '%s' already exists (%s)", name, image.ImageID)
+			break
+		}
+	}
+	if requested, ok := body["platforms"].([]interface{}); ok {
+		for _, value := range requested {
+			if platform, _ := value.(string); !slices.Contains(Platforms, platform) {
+				add(client.ValidationError, "UnsupportedPlatform", 0, "platform '%s' is not supported", platform)
+			}
+		}
+	}
+	instructions := 0
+	continued := false
+	for i, line := range strings.Split(dockerfile, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		wasContinued := continued
+		continued = strings.HasSuffix(line, "\\")
+		if wasContinued {
+			continue
+		}
+		instructions++
+		keyword := strings.ToUpper(strings.Fields(line)[0])
+		if slices.Contains(ForbiddenInstructions, keyword) {
+			add(client.ValidationError, "ForbiddenInstruction", i+1, "%s is not allowed", keyword)
+		}
+	}
+	if instructions == 0 {
+		add(client.ValidationError, "EmptyDockerfile", 0, "the Dockerfile contains no instructions")
+	}
+
+	valid := true
+	for _, finding := range findings {
+		if finding.Severity == client.ValidationError {
+			valid = false
+		}
+	}
+	if findings == nil {
+		findings = []client.ImageValidationFinding{}
+	}
+	s.reply(w, "success", client.ImageValidationData{Valid: valid, Findings: findings})
+}
+
+func (s *Server) handleReserve(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	taskID, _ := body["taskId"].(string)
+	cpu, _ := body["cpu"].(float64)
+	memory, _ := body["memory"].(float64)
+	if taskID == "" || reservationSpecs[int(cpu)] != int(memory) || memory == 0 {
+		s.reply(w, "InvalidParameter", nil)
+		return
+	}
+	active := 0
+	for _, reservation := range s.reserved {
+		if reservation.Active() {
+			active++
+		}
+	}
+	if active >= ReservationCapacity {
+		s.reply(w, "InsufficientCapacity", nil)
+		return
+	}
+
+	s.nextID++
+	reservation := client.ImageReservationData{
+		ReservationID: fmt.Sprintf("rsv-mock%04d", s.nextID),
+		TaskID:        taskID,
+		CPU:           int(cpu),
+		Memory:        int(memory),
+		Status:        client.ReservationReserved,
+		ExpireTime:    s.now().Add(reservationValidity).UTC().Format(time.RFC3339),
+	}
+	s.reserved = append(s.reserved, reservation)
+	s.reply(w, "success", reservation)
+}
+
+func (s *Server) handleReservation(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(query, nil) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	reservation := s.findReservation(query.Get("imageId"))
+	if reservation == nil {
+		s.reply(w, "ReservationNotFound", nil)
+		return
+	}
+	s.reply(w, "success", *reservation)
+}
+
+func (s *Server) handleReservationRelease(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	reservationID, _ := body["reservationId"].(string)
+	for i := range s.reserved {
+		if s.reserved[i].ReservationID != reservationID {
+			continue
+		}
+		if !s.reserved[i].Active() {
+			s.reply(w, "ReservationNotActive", s.reserved[i])
+			return
+		}
+		s.reserved[i].Status = client.ReservationReleased
+		s.reply(w, "success", s.reserved[i])
+		return
+	}
+	s.reply(w, "ReservationNotFound", nil)
+}
+
+func (s *Server) handleSSHKeyAdd(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	name, _ := body["name"].(string)
+	publicKey, _ := body["publicKey"].(string)
+	fields := strings.Fields(publicKey)
+	if name == "" || len(fields) < 2 {
+		s.reply(w, "InvalidParameter", nil)
+		return
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		s.reply(w, "InvalidSSHKey", nil)
+		return
+	}
+	sum := sha256.Sum256(blob)
+	fingerprint := "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+	for _, key := range s.sshKeys {
+		switch {
+		case key.Fingerprint == fingerprint:
+			s.reply(w, "SSHKeyExists", nil)
+			return
+		case key.Name == name:
+			s.reply(w, "SSHKeyNameExists", nil)
+			return
+		}
+	}
+
+	s.nextID++
+	key := client.SSHKeyInfo{
+		KeyID:       fmt.Sprintf("key-mock%04d", s.nextID),
+		Name:        name,
+		KeyType:     fields[0],
+		Fingerprint: fingerprint,
+		PublicKey:   publicKey,
+		CreateTime:  s.now().UTC().Format(time.RFC3339),
+	}
+	s.sshKeys = append(s.sshKeys, key)
+	s.reply(w, "success", key)
+}
+
+func (s *Server) handleSSHKeyList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(query, nil) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	keys := append([]client.SSHKeyInfo{}, s.sshKeys...)
+	s.reply(w, "success", client.SSHKeyListData{Keys: keys, Total: len(keys)})
+}
+
+func (s *Server) handleSSHKeyDelete(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", false)
+		return
+	}
+	keyID, _ := body["keyId"].(string)
+	for i, key := range s.sshKeys {
+		if key.KeyID == keyID {
+			s.sshKeys = append(s.sshKeys[:i], s.sshKeys[i+1:]...)
+			s.reply(w, "success", true)
+			return
+		}
+	}
+	s.reply(w, "SSHKeyNotFound", false)
+}
+
+func (s *Server) handleSessionList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(query, nil) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	var sessions []client.SessionInfo
+	if current := query.Get("sessionId"); !s.revoked[current] {
+		now := s.now().UTC().Format(time.RFC3339)
+		sessions = append(sessions, client.SessionInfo{SessionID: current, Device: "agbcloud-cli", CreateTime: now, LastUsedTime: now, Current: true})
+	}
+	for _, session := range s.sessions {
+		if !s.revoked[session.SessionID] {
+			sessions = append(sessions, session)
+		}
+	}
+	s.reply(w, "success", client.SessionListData{Sessions: sessions, Total: len(sessions)})
+}
+
+func (s *Server) handleSessionRevoke(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", false)
+		return
+	}
+	target, _ := body["targetSessionId"].(string)
+	current, _ := body["sessionId"].(string)
+	known := target == current
+	for _, session := range s.sessions {
+		known = known || session.SessionID == target
+	}
+	if !known || s.revoked[target] {
+		s.reply(w, "SessionNotFound", false)
+		return
+	}
+	s.revoked[target] = true
+	s.reply(w, "success", true)
+}
+
+func (s *Server) handleServiceAccountCreate(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	name, _ := body["name"].(string)
+	rawScopes, _ := body["scopes"].([]interface{})
+	var scopes []string
+	for _, scope := range rawScopes {
+		if value, ok := scope.(string); ok && value != "" {
+			scopes = append(scopes, value)
+		}
+	}
+	if name == "" || len(scopes) == 0 {
+		s.reply(w, "InvalidParameter", nil)
+		return
+	}
+	for _, account := range s.accounts {
+		if account.Name == name {
+			s.reply(w, "ServiceAccountNameExists", nil)
+			return
+		}
+	}
+
+	s.nextID++
+	account := client.ServiceAccountInfo{
+		AccountID:  fmt.Sprintf("sa-mock%04d", s.nextID),
+		Name:       name,
+		Scopes:     scopes,
+		CreateTime: s.now().UTC().Format(time.RFC3339),
+	}
+	s.accounts = append(s.accounts, account)
+	s.reply(w, "success", account)
+}
+
+func (s *Server) handleServiceAccountList(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(query, nil) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	accounts := append([]client.ServiceAccountInfo{}, s.accounts...)
+	s.reply(w, "success", client.ServiceAccountListData{Accounts: accounts, Total: len(accounts)})
+}
+
+func (s *Server) handleServiceAccountDelete(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", false)
+		return
+	}
+	accountID, _ := body["accountId"].(string)
+	for i, account := range s.accounts {
+		if account.AccountID == accountID {
+			s.accounts = append(s.accounts[:i], s.accounts[i+1:]...)
+			// Deleting an account revokes its tokens
+			for token, issued := range s.tokens {
+				if issued.accountID == accountID {
+					delete(s.tokens, token)
+				}
+			}
+			s.reply(w, "success", true)
+			return
+		}
+	}
+	s.reply(w, "ServiceAccountNotFound", false)
+}
+
+func (s *Server) handleServiceAccountToken(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !authorized(nil, body) {
+		s.reply(w, "InvalidSession", nil)
+		return
+	}
+	accountID, _ := body["accountId"].(string)
+	ttl, _ := body["ttlSeconds"].(float64)
+	if ttl <= 0 {
+		s.reply(w, "InvalidParameter", nil)
+		return
+	}
+	if s.findAccount(accountID) == nil {
+		s.reply(w, "ServiceAccountNotFound", nil)
+		return
+	}
+
+	s.nextID++
+	token := fmt.Sprintf("agbsa_mock%04d", s.nextID)
+	expiresAt := s.now().Add(time.Duration(ttl) * time.Second).UTC()
+	s.tokens[token] = accountToken{accountID: accountID, expiresAt: expiresAt}
+	s.reply(w, "success", client.ServiceAccountTokenData{
+		TokenID:      fmt.Sprintf("sat-mock%04d", s.nextID),
+		AccountToken: token,
+		ExpiresAt:    expiresAt.Format(time.RFC3339),
+	})
+}
+
+func (s *Server) handleServiceAccountLogin(w http.ResponseWriter, r *http.Request) {
+	body := decodeBody(r)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, _ := body["accountToken"].(string)
+	issued, ok := s.tokens[token]
+	if !ok || !s.now().Before(issued.expiresAt) {
+		s.reply(w, "InvalidServiceAccountToken", nil)
+		return
+	}
+	account := s.findAccount(issued.accountID)
+	if account == nil {
+		s.reply(w, "InvalidServiceAccountToken", nil)
+		return
+	}
+	account.LastUsedTime = s.now().UTC().Format(time.RFC3339)
+	s.reply(w, "success", client.ServiceAccountLoginData{
+		LoginToken: "login-" + account.AccountID,
+		SessionId:  "session-" + account.AccountID,
+		AccountID:  account.AccountID,
+		Name:       account.Name,
+		ExpiresAt:  issued.expiresAt.Format(time.RFC3339),
+	})
+}
+
+func (s *Server) findAccount(accountID string) *client.ServiceAccountInfo {
+	for i := range s.accounts {
+		if s.accounts[i].AccountID == accountID {
+			return &s.accounts[i]
+		}
+	}
+	return nil
+}
+
+// userImageCount returns the number of user images, which count towards ImageQuota
+func (s *Server) userImageCount() int {
+	count := 0
+	for _, image := range s.images {
+		if image.Type == "User" {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Server) find(imageID string) *client.ImageInfo {
+	for i := range s.images {
+		if s.images[i].ImageID == imageID {
+			return &s.images[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) findTask(taskID string) *client.ImageTaskInfo {
+	for i := range s.tasks {
+		if s.tasks[i].TaskID == taskID {
+			return &s.tasks[i]
+		}
+	}
+	return nil
+}
+
+// findReservation returns the most recent reservation of an image, or nil
+func (s *Server) findReservation(imageID string) *client.ImageReservationData {
+	for i := len(s.reserved) - 1; i >= 0; i-- {
+		if imageID != "" && s.reserved[i].ImageID == imageID {
+			return &s.reserved[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) setStatus(imageID, status string) {
+	if image := s.find(imageID); image != nil {
+		image.Status = status
+		image.UpdateTime = s.now().UTC().Format(time.RFC3339)
+	}
+}
+
+// RequestSeed asks the mock server at endpoint to replace its user images
+func RequestSeed(ctx context.Context, endpoint string, req SeedRequest) (SeedResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return SeedResponse{}, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(endpoint, "/")+SeedPath, bytes.NewReader(body))
+	if err != nil {
+		return SeedResponse{}, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return SeedResponse{}, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode == http.StatusNotFound {
+		return SeedResponse{}, ErrNotMockServer
+	}
+	if httpResp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(httpResp.Body, 1024))
+		return SeedResponse{}, fmt.Errorf("%s: %s", httpResp.Status, strings.TrimSpace(string(message)))
+	}
+
+	var resp SeedResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil || !resp.Success {
+		return SeedResponse{}, ErrNotMockServer
+	}
+	return resp, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Line ending choices of the global --eol flag and AGB_CLI_EOL
+const (
+	// EOLAuto uses the platform convention, see PlatformNewline
+	EOLAuto = "auto"
+	// EOLLF ends lines with "\n"
+	EOLLF = "lf"
+	// EOLCRLF ends lines with "\r\n"
+	EOLCRLF = "crlf"
+)
+
+// newline is the line terminator chosen with SetNewline; empty for the platform default
+var newline string
+
+// PlatformNewline returns the line terminator expected by the tools of a platform.
+// Windows-native tooling (cmd, PowerShell, Excel) expects CRLF line endings, while
+// Git Bash and other MSYS2 shells, recognized by MSYSTEM, expect LF like Unix.
+func PlatformNewline(goos, msystem string) string {
+	if goos == "windows" && msystem == "" {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// ParseEOL converts a user supplied line ending, auto, lf or crlf, into the line
+// terminator; auto yields the platform convention
+func ParseEOL(value string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", EOLAuto:
+		return PlatformNewline(runtime.GOOS, os.Getenv("MSYSTEM")), nil
+	case EOLLF:
+		return "\n", nil
+	case EOLCRLF:
+		return "\r\n", nil
+	}
+	return "", fmt.Errorf("invalid line ending '%s' (supported: %s, %s, %s)", value, EOLAuto, EOLLF, EOLCRLF)
+}
+
+// SetNewline sets the line terminator of all output for the rest of the process;
+// an empty value restores the platform default
+func SetNewline(nl string) {
+	newline = nl
+}
+
+// Newline returns the line terminator of structured output, error messages and,
+// when stdout is not a terminal, of everything else printed to stdout
+func Newline() string {
+	if newline != "" {
+		return newline
+	}
+	return PlatformNewline(runtime.GOOS, os.Getenv("MSYSTEM"))
+}
+
+// NewlineWriter writes to w with every bare "\n" replaced by Newline(); line breaks
+// that already are "\r\n" are passed through. It returns w itself when lines end
+// with "\n", so that text is only rewritten when it has to be.
+func NewlineWriter(w io.Writer) io.Writer {
+	if Newline() == "\n" {
+		return w
+	}
+	return &newlineWriter{w: w, nl: []byte(Newline())}
+}
+
+// newlineWriter translates line breaks; afterCR remembers a "\r" that ended the
+// previous write, so that a "\r\n" split across two writes is not doubled
+type newlineWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	nl      []byte
+	afterCR bool
+}
+
+func (n *newlineWriter) Write(p []byte) (int, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	out := make([]byte, 0, len(p)+len(p)/16)
+	for i, b := range p {
+		if b == '\n' && !(i == 0 && n.afterCR) && !(i > 0 && p[i-1] == '\r') {
+			out = append(out, n.nl...)
+			continue
+		}
+		out = append(out, b)
+	}
+	if len(p) > 0 {
+		n.afterCR = p[len(p)-1] == '\r'
+	}
+	if _, err := n.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,329 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Format identifies how command results are rendered
+type Format string
+
+const (
+	// FormatTable renders human-readable aligned tables (default)
+	FormatTable Format = "table"
+	// FormatWide renders the tables with additional columns, where a command has them
+	FormatWide Format = "wide"
+	// FormatJSON renders indented JSON documents
+	FormatJSON Format = "json"
+	// FormatCSV renders comma-separated values with a header row (see CSVOptions)
+	FormatCSV Format = "csv"
+	// FormatPSON renders PowerShell object notation ([pscustomobject] literals)
+	FormatPSON Format = "pson"
+)
+
+// CSVOptions controls how FormatCSV output is written, e.g. for spreadsheets in
+// locales that expect semicolons
+type CSVOptions struct {
+	// Delimiter separates the fields of a record; ',' if zero
+	Delimiter rune
+	// NoHeader omits the header row with the column names
+	NoHeader bool
+}
+
+// csvOptions are the CSV options used by Write
+var csvOptions CSVOptions
+
+// SetCSVOptions sets how Write renders FormatCSV for the rest of the process
+func SetCSVOptions(opts CSVOptions) {
+	csvOptions = opts
+}
+
+// csvDelimiterNames are the delimiters that are awkward to type on a command line
+var csvDelimiterNames = map[string]rune{
+	"comma":     ',',
+	"semicolon": ';',
+	"tab":       '\t',
+	`\t`:        '\t',
+	"pipe":      '|',
+}
+
+// ParseCSVDelimiter converts a user supplied delimiter, a single character or one of
+// comma, semicolon, tab and pipe, into a rune
+func ParseCSVDelimiter(value string) (rune, error) {
+	if r, ok := csvDelimiterNames[strings.ToLower(value)]; ok {
+		return r, nil
+	}
+	r, size := utf8.DecodeRuneInString(value)
+	if size == 0 || size != len(value) {
+		return 0, fmt.Errorf("invalid CSV delimiter '%s': use a single character, or comma, semicolon, tab or pipe", value)
+	}
+	if r == '"' || r == '\r' || r == '\n' || r == utf8.RuneError {
+		return 0, fmt.Errorf("invalid CSV delimiter '%s': quotes and line breaks cannot separate fields", value)
+	}
+	return r, nil
+}
+
+// SupportedFormats lists all formats accepted by ParseFormat
+var SupportedFormats = []Format{FormatTable, FormatWide, FormatJSON, FormatCSV, FormatPSON}
+
+// ParseFormat converts a user supplied format name into a Format
+func ParseFormat(value string) (Format, error) {
+	normalized := Format(strings.ToLower(strings.TrimSpace(value)))
+	if normalized == "" {
+		return FormatTable, nil
+	}
+	for _, format := range SupportedFormats {
+		if normalized == format {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported output format '%s' (supported: %s)", value, formatNames())
+}
+
+// IsStructured reports whether the format is intended for machine consumption
+func (f Format) IsStructured() bool {
+	return f != FormatTable && f != FormatWide && f != ""
+}
+
+// Write renders v in the given structured format.
+// v must be a struct, a pointer to a struct, or a slice of structs. Column names
+// and property names are taken from the `json` struct tags so that every format
+// exposes the same field names. Output is always UTF-8 without a byte order mark.
+func Write(w io.Writer, format Format, v interface{}) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, v)
+	case FormatCSV:
+		columns, rows, err := tabulate(v)
+		if err != nil {
+			return err
+		}
+		return writeCSV(w, columns, rows)
+	case FormatPSON:
+		columns, rows, err := tabulate(v)
+		if err != nil {
+			return err
+		}
+		return writePSON(w, columns, rows)
+	default:
+		return fmt.Errorf("format '%s' cannot be written as structured output", format)
+	}
+}
+
+// writeJSON writes v as an indented JSON document
+func writeJSON(w io.Writer, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	text := string(data)
+	if nl := Newline(); nl != "\n" {
+		text = strings.ReplaceAll(text, "\n", nl)
+	}
+	_, err = io.WriteString(w, text+Newline())
+	return err
+}
+
+// writeCSV writes a header row, unless disabled, followed by one row per record
+func writeCSV(w io.Writer, columns []string, rows [][]cell) error {
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = Newline() == "\r\n"
+	if csvOptions.Delimiter != 0 {
+		writer.Comma = csvOptions.Delimiter
+	}
+
+	if !csvOptions.NoHeader {
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, c := range row {
+			record[i] = c.text
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writePSON writes an array of [pscustomobject] literals that PowerShell can evaluate directly
+func writePSON(w io.Writer, columns []string, rows [][]cell) error {
+	nl := Newline()
+	var b strings.Builder
+
+	b.WriteString("@(" + nl)
+	for _, row := range rows {
+		b.WriteString("  [pscustomobject]@{" + nl)
+		for i, c := range row {
+			fmt.Fprintf(&b, "    %s = %s%s", columns[i], psonValue(c), nl)
+		}
+		b.WriteString("  }" + nl)
+	}
+	b.WriteString(")" + nl)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// psonValue renders a cell as a PowerShell literal
+func psonValue(c cell) string {
+	switch c.kind {
+	case cellNull:
+		return "$null"
+	case cellBool:
+		return "$" + c.text
+	case cellNumber:
+		return c.text
+	default:
+		return "'" + strings.ReplaceAll(c.text, "'", "''") + "'"
+	}
+}
+
+type cellKind int
+
+const (
+	cellString cellKind = iota
+	cellNumber
+	cellBool
+	cellNull
+)
+
+// cell is a single rendered value together with its original kind
+type cell struct {
+	text string
+	kind cellKind
+}
+
+// tabulate flattens a struct or slice of structs into column names and rows
+func tabulate(v interface{}) ([]string, [][]cell, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return nil, nil, fmt.Errorf("cannot render nil value")
+		}
+		value = value.Elem()
+	}
+
+	var items []reflect.Value
+	var elemType reflect.Type
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		elemType = value.Type().Elem()
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, value.Index(i))
+		}
+	case reflect.Struct:
+		elemType = value.Type()
+		items = append(items, value)
+	default:
+		return nil, nil, fmt.Errorf("cannot render %s as a table", value.Kind())
+	}
+
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("cannot render %s as a table", elemType.Kind())
+	}
+
+	fields := structFields(elemType)
+	columns := make([]string, len(fields))
+	for i, f := range fields {
+		columns[i] = f.name
+	}
+
+	rows := make([][]cell, 0, len(items))
+	for _, item := range items {
+		for item.Kind() == reflect.Ptr {
+			item = item.Elem()
+		}
+		row := make([]cell, len(fields))
+		for i, f := range fields {
+			row[i] = toCell(item.Field(f.index))
+		}
+		rows = append(rows, row)
+	}
+
+	return columns, rows, nil
+}
+
+type structField struct {
+	name  string
+	index int
+}
+
+// structFields returns the exported fields of t named after their json tags
+func structFields(t reflect.Type) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		name := field.Name
+		if tag := field.Tag.Get("json"); tag != "" {
+			tagName := strings.Split(tag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		fields = append(fields, structField{name: name, index: i})
+	}
+	return fields
+}
+
+// toCell converts a field value into its textual representation
+func toCell(v reflect.Value) cell {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return cell{kind: cellNull}
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return cell{text: v.String(), kind: cellString}
+	case reflect.Bool:
+		return cell{text: strconv.FormatBool(v.Bool()), kind: cellBool}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cell{text: strconv.FormatInt(v.Int(), 10), kind: cellNumber}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return cell{text: strconv.FormatUint(v.Uint(), 10), kind: cellNumber}
+	case reflect.Float32, reflect.Float64:
+		return cell{text: strconv.FormatFloat(v.Float(), 'f', -1, 64), kind: cellNumber}
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			parts[i] = toCell(v.Index(i)).text
+		}
+		return cell{text: strings.Join(parts, ";"), kind: cellString}
+	default:
+		return cell{text: fmt.Sprintf("%v", v.Interface()), kind: cellString}
+	}
+}
+
+// formatNames returns the supported format names as a comma-separated list
+func formatNames() string {
+	names := make([]string, len(SupportedFormats))
+	for i, f := range SupportedFormats {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pager shows long command output through a pager program such as less,
+// the way git does.
+//
+// Output is buffered until it no longer fits on the terminal. Output that fits is
+// written to the terminal unchanged when the pager is closed; longer output is
+// passed to the pager program, which lets the user scroll and search it.
+package pager
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultCommand is the pager used when none is configured. -F quits when the
+// output fits on one screen, -R keeps colors and -X leaves the output on screen.
+const DefaultCommand = "less -FRX"
+
+// DefaultHeight is the terminal height assumed when it cannot be determined
+const DefaultHeight = 24
+
+// DefaultWidth is the terminal width assumed when it cannot be determined
+const DefaultWidth = 80
+
+// Disabled reports whether a configured pager command turns paging off: an empty
+// command, "cat" or "off"
+func Disabled(command string) bool {
+	switch strings.TrimSpace(strings.ToLower(command)) {
+	case "", "cat", "off", "false", "never":
+		return true
+	}
+	return false
+}
+
+// Available reports whether the program of a pager command can be found
+func Available(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return false
+	}
+	_, err := exec.LookPath(fields[0])
+	return err == nil
+}
+
+// Pager is a writer that pages its output once it exceeds the terminal height
+type Pager struct {
+	mu      sync.Mutex
+	command string
+	height  int
+	out     io.Writer
+	buf     bytes.Buffer
+	lines   int
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	// closed is set once the pager program stopped reading, e.g. because the user quit
+	closed bool
+}
+
+// New returns a pager that writes to the terminal out and runs command when the
+// output has more lines than fit in height
+func New(out io.Writer, command string, height int) *Pager {
+	if height <= 0 {
+		height = DefaultHeight
+	}
+	return &Pager{command: command, height: height, out: out}
+}
+
+// Write buffers p until the output exceeds the terminal height, then starts the
+// pager program and writes to it. Output the pager no longer reads is discarded.
+func (p *Pager) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch {
+	case p.closed:
+		return len(b), nil
+	case p.stdin != nil:
+		if _, err := p.stdin.Write(b); err != nil {
+			p.closed = true
+		}
+		return len(b), nil
+	}
+
+	p.buf.Write(b)
+	p.lines += bytes.Count(b, []byte("\n"))
+	// One line is left for the shell prompt
+	if p.lines >= p.height {
+		p.start()
+	}
+	return len(b), nil
+}
+
+// start runs the pager program and hands it the buffered output. If the program
+// cannot be started, the output goes to the terminal instead.
+func (p *Pager) start() {
+	cmd := command(p.command)
+	cmd.Stdout = p.out
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err != nil {
+		p.stdin = nopWriteCloser{p.out}
+	} else {
+		p.cmd, p.stdin = cmd, stdin
+	}
+	if _, err := p.stdin.Write(p.buf.Bytes()); err != nil {
+		p.closed = true
+	}
+	p.buf.Reset()
+}
+
+// Close writes output that fit on the terminal, or waits until the user quits the pager
+func (p *Pager) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stdin == nil {
+		_, err := p.out.Write(p.buf.Bytes())
+		p.buf.Reset()
+		return err
+	}
+	if p.cmd == nil {
+		return nil
+	}
+	p.stdin.Close()
+	// The exit status of the pager does not change the result of the command
+	_ = p.cmd.Wait()
+	return nil
+}
+
+// Paging reports whether the pager program was started
+func (p *Pager) Paging() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cmd != nil
+}
+
+// command runs a pager command line through the shell, like git does, so that it
+// may contain arguments and quotes
+func command(line string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		fields := strings.Fields(line)
+		return exec.Command(fields[0], fields[1:]...)
+	}
+	return exec.Command("sh", "-c", line)
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// Height returns the number of rows of the terminal f: the LINES environment
+// variable when set, otherwise the size reported by the terminal, or DefaultHeight
+func Height(f *os.File) int {
+	if lines, err := strconv.Atoi(os.Getenv("LINES")); err == nil && lines > 0 {
+		return lines
+	}
+	if rows, _ := terminalSize(f); rows > 0 {
+		return rows
+	}
+	return DefaultHeight
+}
+
+// Width returns the number of columns of the terminal f: the COLUMNS environment
+// variable when set, otherwise the size reported by the terminal, or DefaultWidth
+func Width(f *os.File) int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if _, cols := terminalSize(f); cols > 0 {
+		return cols
+	}
+	return DefaultWidth
+}
@@ -0,0 +1,14 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package pager
+
+import "os"
+
+// terminalSize returns zeros because the terminal size is not queried on this platform;
+// LINES and COLUMNS or the defaults are used instead
+func terminalSize(f *os.File) (rows, cols int) {
+	return 0, 0
+}
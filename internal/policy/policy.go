@@ -0,0 +1,265 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package policy evaluates an organization's rules for image operations on the
+// client, before any request is sent. A policy file holds simple rules per
+// operation, e.g. resource specs that may not be activated or Dockerfile labels
+// every image must carry; an action breaking any rule is refused with one
+// violation per broken rule. The server is not aware of the policy, so it guards
+// against mistakes rather than against users who remove it from their configuration.
+package policy
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy is the content of a policy file, in YAML or JSON
+type Policy struct {
+	// Contact tells users whom to ask for an exception, e.g. "#platform-team"
+	Contact string `json:"contact,omitempty" yaml:"contact,omitempty"`
+	// Create holds the rules checked before 'image create' and 'image create-batch'
+	Create *CreateRules `json:"create,omitempty" yaml:"create,omitempty"`
+	// Activate holds the rules checked before 'image activate'
+	Activate *ActivateRules `json:"activate,omitempty" yaml:"activate,omitempty"`
+}
+
+// CreateRules restrict which images may be built
+type CreateRules struct {
+	// RequiredLabels are LABEL keys the Dockerfile must set, e.g. "team" or "cost-center"
+	RequiredLabels []string `json:"requiredLabels,omitempty" yaml:"requiredLabels,omitempty"`
+	// AllowedSourceImages are patterns of the source image IDs that may be built on,
+	// e.g. "agb-code-space-*"; any source image is allowed when empty
+	AllowedSourceImages []string `json:"allowedSourceImages,omitempty" yaml:"allowedSourceImages,omitempty"`
+	// ImageNamePattern is a regular expression every image name must match, e.g. "^team-"
+	ImageNamePattern string `json:"imageNamePattern,omitempty" yaml:"imageNamePattern,omitempty"`
+}
+
+// ActivateRules restrict the resources of activated images
+type ActivateRules struct {
+	// ForbiddenSpecs are resource specs that may not be activated, e.g. "8c16g"
+	ForbiddenSpecs []string `json:"forbiddenSpecs,omitempty" yaml:"forbiddenSpecs,omitempty"`
+	// MaxCPU is the largest number of CPU cores; 0 means no limit
+	MaxCPU int `json:"maxCpu,omitempty" yaml:"maxCpu,omitempty"`
+	// MaxMemory is the largest memory in GB; 0 means no limit
+	MaxMemory int `json:"maxMemory,omitempty" yaml:"maxMemory,omitempty"`
+}
+
+// Violation is one rule an action breaks
+type Violation struct {
+	// Rule is the path of the rule in the policy file, e.g. "activate.forbiddenSpecs"
+	Rule string `json:"rule"`
+	// Message explains what breaks the rule
+	Message string `json:"message"`
+}
+
+// String formats the violation as "rule: message"
+func (v Violation) String() string {
+	return v.Rule + ": " + v.Message
+}
+
+// CreateRequest describes an image about to be built
+type CreateRequest struct {
+	ImageName     string
+	SourceImageID string
+	// Labels are the LABEL instructions of the Dockerfile, see DockerfileLabels
+	Labels map[string]string
+}
+
+// ActivateRequest describes an activation about to be requested; CPU and Memory are
+// 0 when the server chooses the resources
+type ActivateRequest struct {
+	CPU    int
+	Memory int
+}
+
+// specPattern matches resource specs such as "8c16g"
+var specPattern = regexp.MustCompile(`^(\d+)c(\d+)g$`)
+
+// Load reads and checks a policy file. Unknown keys are rejected, so that a
+// misspelled rule is not silently ignored.
+func Load(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var p Policy
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid policy file %s: %w", file, err)
+	}
+	if err := p.validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", file, err)
+	}
+	return &p, nil
+}
+
+// validate rejects rules that cannot be evaluated
+func (p *Policy) validate() error {
+	if p.Create != nil {
+		for _, pattern := range p.Create.AllowedSourceImages {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("create.allowedSourceImages: invalid pattern '%s'", pattern)
+			}
+		}
+		if p.Create.ImageNamePattern != "" {
+			if _, err := regexp.Compile(p.Create.ImageNamePattern); err != nil {
+				return fmt.Errorf("create.imageNamePattern: %w", err)
+			}
+		}
+	}
+	if p.Activate != nil {
+		for _, spec := range p.Activate.ForbiddenSpecs {
+			if !specPattern.MatchString(strings.ToLower(spec)) {
+				return fmt.Errorf("activate.forbiddenSpecs: invalid spec '%s', expected e.g. 8c16g", spec)
+			}
+		}
+		if p.Activate.MaxCPU < 0 || p.Activate.MaxMemory < 0 {
+			return errors.New("activate.maxCpu and activate.maxMemory must not be negative")
+		}
+	}
+	return nil
+}
+
+// CheckCreate returns the rules building the image would break
+func (p *Policy) CheckCreate(req CreateRequest) []Violation {
+	if p == nil || p.Create == nil {
+		return nil
+	}
+	rules := p.Create
+	var violations []Violation
+
+	for _, label := range rules.RequiredLabels {
+		if _, ok := req.Labels[label]; !ok {
+			violations = append(violations, Violation{"create.requiredLabels", fmt.Sprintf("the Dockerfile has no LABEL %s", label)})
+		}
+	}
+	if len(rules.AllowedSourceImages) > 0 {
+		allowed := false
+		for _, pattern := range rules.AllowedSourceImages {
+			if matched, _ := path.Match(pattern, req.SourceImageID); matched {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			violations = append(violations, Violation{"create.allowedSourceImages", fmt.Sprintf("source image '%s' is not one of %s", req.SourceImageID, strings.Join(rules.AllowedSourceImages, ", "))})
+		}
+	}
+	if rules.ImageNamePattern != "" {
+		if pattern, err := regexp.Compile(rules.ImageNamePattern); err == nil && !pattern.MatchString(req.ImageName) {
+			violations = append(violations, Violation{"create.imageNamePattern", fmt.Sprintf("image name '%s' does not match %s", req.ImageName, rules.ImageNamePattern)})
+		}
+	}
+	return violations
+}
+
+// CheckActivate returns the rules the activation would break. The resource rules
+// only apply when the resources are known; the server's default is not.
+func (p *Policy) CheckActivate(req ActivateRequest) []Violation {
+	if p == nil || p.Activate == nil || (req.CPU == 0 && req.Memory == 0) {
+		return nil
+	}
+	rules := p.Activate
+	var violations []Violation
+
+	spec := fmt.Sprintf("%dc%dg", req.CPU, req.Memory)
+	for _, forbidden := range rules.ForbiddenSpecs {
+		if strings.EqualFold(forbidden, spec) {
+			violations = append(violations, Violation{"activate.forbiddenSpecs", fmt.Sprintf("the %s spec (%d cores, %d GB) is forbidden", spec, req.CPU, req.Memory)})
+		}
+	}
+	if rules.MaxCPU > 0 && req.CPU > rules.MaxCPU {
+		violations = append(violations, Violation{"activate.maxCpu", fmt.Sprintf("%d CPU cores exceed the limit of %d", req.CPU, rules.MaxCPU)})
+	}
+	if rules.MaxMemory > 0 && req.Memory > rules.MaxMemory {
+		violations = append(violations, Violation{"activate.maxMemory", fmt.Sprintf("%d GB of memory exceed the limit of %d GB", req.Memory, rules.MaxMemory)})
+	}
+	return violations
+}
+
+// DockerfileLabels returns the labels set by the LABEL instructions of a Dockerfile,
+// in both the key=value form and the legacy "LABEL key value" form. Quotes around
+// keys and values are removed; later labels override earlier ones.
+func DockerfileLabels(content string) map[string]string {
+	labels := make(map[string]string)
+
+	var instruction strings.Builder
+	flush := func() {
+		fields := splitLabelArgs(instruction.String())
+		instruction.Reset()
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "LABEL") {
+			return
+		}
+		args := fields[1:]
+		if !strings.Contains(args[0], "=") {
+			// Legacy form: the rest of the line is the value
+			labels[args[0]] = strings.Join(args[1:], " ")
+			return
+		}
+		for _, arg := range args {
+			if key, value, ok := strings.Cut(arg, "="); ok {
+				labels[key] = value
+			}
+		}
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasSuffix(line, "\\") {
+			instruction.WriteString(strings.TrimSuffix(line, "\\") + " ")
+			continue
+		}
+		instruction.WriteString(line)
+		flush()
+	}
+	flush()
+	return labels
+}
+
+// splitLabelArgs splits an instruction at unquoted whitespace and removes the quotes
+func splitLabelArgs(s string) []string {
+	var fields []string
+	var current strings.Builder
+	var quote rune
+	inField := false
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, inField = r, true
+		case r == ' ' || r == '\t':
+			if inField {
+				fields = append(fields, current.String())
+				current.Reset()
+				inField = false
+			}
+		default:
+			current.WriteRune(r)
+			inField = true
+		}
+	}
+	if inField {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package poll repeatedly checks a condition until it is met, fails permanently
+// or runs out of time.
+//
+// A condition returns done=true when the awaited state is reached. Errors are
+// treated as transient and the condition is checked again, unless they are
+// wrapped with Stop or more than MaxErrors happen in a row. Time is read from a
+// Clock so that pollers can be tested without waiting.
+package poll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/agbcloud/agbcloud-cli/internal/tracing"
+)
+
+// ErrTimeout is returned (wrapped) when the condition was not met within the timeout
+var ErrTimeout = errors.New("timed out")
+
+// Condition checks the awaited state once
+type Condition func(ctx context.Context) (done bool, err error)
+
+// Interval returns how long to wait before the given attempt (starting at 1)
+type Interval func(attempt int) time.Duration
+
+// Constant waits the same duration before every attempt
+func Constant(d time.Duration) Interval {
+	return func(int) time.Duration { return d }
+}
+
+// Backoff starts with initial and multiplies the wait by factor after every
+// attempt, never waiting longer than max
+func Backoff(initial, max time.Duration, factor float64) Interval {
+	return func(attempt int) time.Duration {
+		d := float64(initial)
+		for i := 1; i < attempt && d < float64(max); i++ {
+			d *= factor
+		}
+		if d > float64(max) {
+			return max
+		}
+		return time.Duration(d)
+	}
+}
+
+// Clock is the source of time used by a Poller
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// RealClock is the wall clock, used when Poller.Clock is nil
+var RealClock Clock = realClock{}
+
+// Progress describes a completed check and is passed to Poller.Progress
+type Progress struct {
+	Attempt int
+	Elapsed time.Duration
+	Err     error // Transient error of this check, nil if the check succeeded
+}
+
+// Poller checks a condition at intervals. The zero value checks every second,
+// without a timeout, and tolerates any number of transient errors.
+type Poller struct {
+	Interval  Interval       // Wait before each check (default one second)
+	Timeout   time.Duration  // Give up after this long (0 = only when ctx is done)
+	MaxErrors int            // Consecutive transient errors tolerated (0 = unlimited)
+	Clock     Clock          // Time source (default RealClock)
+	Progress  func(Progress) // Called after every check, e.g. to report transient errors
+	Name      string         // Name of the span of each check when tracing (default "poll")
+}
+
+// stopError marks an error that ends polling immediately
+type stopError struct{ err error }
+
+func (e *stopError) Error() string { return e.err.Error() }
+func (e *stopError) Unwrap() error { return e.err }
+
+// Stop wraps err so that Until returns it at once instead of checking again
+func Stop(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &stopError{err: err}
+}
+
+// Until checks condition until it reports done, returns a Stop error, fails
+// more than MaxErrors times in a row or the timeout expires. The returned error
+// wraps ErrTimeout on timeout, and the last transient error if there was one.
+func (p Poller) Until(ctx context.Context, condition Condition) error {
+	clock := p.Clock
+	if clock == nil {
+		clock = RealClock
+	}
+	interval := p.Interval
+	if interval == nil {
+		interval = Constant(time.Second)
+	}
+	if p.Timeout > 0 {
+		// Bound requests made by the condition as well
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+
+	start := clock.Now()
+	deadline := start.Add(p.Timeout)
+	var lastErr error
+	consecutiveErrors := 0
+
+	for attempt := 1; ; attempt++ {
+		wait := interval(attempt)
+		if p.Timeout > 0 {
+			remaining := deadline.Sub(clock.Now())
+			if remaining <= 0 {
+				return timeoutError(clock.Now().Sub(start), lastErr)
+			}
+			if wait > remaining {
+				wait = remaining
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return timeoutError(clock.Now().Sub(start), lastErr)
+			}
+			return ctx.Err()
+		case <-clock.After(wait):
+		}
+
+		name := p.Name
+		if name == "" {
+			name = "poll"
+		}
+		checkCtx, span := tracing.Start(ctx, name, tracing.SpanKindInternal, tracing.Int("poll.attempt", attempt))
+		done, err := condition(checkCtx)
+		span.Finish(err)
+		if p.Progress != nil {
+			p.Progress(Progress{Attempt: attempt, Elapsed: clock.Now().Sub(start), Err: err})
+		}
+
+		var stop *stopError
+		switch {
+		case errors.As(err, &stop):
+			return stop.err
+		case err != nil:
+			lastErr = err
+			consecutiveErrors++
+			if p.MaxErrors > 0 && consecutiveErrors > p.MaxErrors {
+				return fmt.Errorf("giving up after %d consecutive errors: %w", consecutiveErrors, err)
+			}
+		case done:
+			return nil
+		default:
+			consecutiveErrors = 0
+		}
+	}
+}
+
+// timeoutError reports a timeout, including the last transient error if any
+func timeoutError(elapsed time.Duration, lastErr error) error {
+	elapsed = elapsed.Round(time.Second)
+	if lastErr != nil {
+		return fmt.Errorf("%w after %s (last error: %w)", ErrTimeout, elapsed, lastErr)
+	}
+	return fmt.Errorf("%w after %s", ErrTimeout, elapsed)
+}
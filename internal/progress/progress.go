@@ -0,0 +1,331 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package progress renders the status of running operations: a Multiplexer for
+// several concurrent operations and a StatusLine for a single one.
+//
+// On an interactive terminal every item owns one line that is redrawn in place
+// with a spinner. When output is redirected (pipes, CI logs, files) each state
+// change is printed as its own line instead, so logs stay readable and never
+// contain cursor control sequences.
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mode selects how progress is rendered
+type Mode int
+
+const (
+	// ModeAuto renders interactively when the writer is a terminal, sequentially otherwise
+	ModeAuto Mode = iota
+	// ModeInteractive redraws one line per item in place
+	ModeInteractive
+	// ModeSequential prints one line per state change
+	ModeSequential
+)
+
+// State is the lifecycle state of a tracked item
+type State int
+
+const (
+	StatePending State = iota
+	StateRunning
+	StateSucceeded
+	StateFailed
+)
+
+// String returns the label used when rendering the state
+func (s State) String() string {
+	switch s {
+	case StateRunning:
+		return "RUNNING"
+	case StateSucceeded:
+		return "OK"
+	case StateFailed:
+		return "ERROR"
+	default:
+		return "WAIT"
+	}
+}
+
+// DefaultInterval is the spinner refresh rate used when Options.Interval is zero
+const DefaultInterval = 120 * time.Millisecond
+
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// Options configures a Multiplexer
+type Options struct {
+	Mode     Mode
+	Interval time.Duration
+}
+
+// Multiplexer tracks a set of items and renders their state to a single writer.
+// All methods are safe for concurrent use.
+type Multiplexer struct {
+	mu          sync.Mutex
+	w           io.Writer
+	interactive bool
+	items       []*Item
+	nameWidth   int
+	rendered    int
+	frame       int
+	stopped     bool
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+// Item is a single tracked operation
+type Item struct {
+	m       *Multiplexer
+	name    string
+	state   State
+	message string
+	changed time.Time
+}
+
+// New creates a Multiplexer writing to w. In interactive mode a background
+// goroutine animates spinners until Stop is called.
+func New(w io.Writer, opts Options) *Multiplexer {
+	interactive := false
+	switch opts.Mode {
+	case ModeInteractive:
+		interactive = true
+	case ModeAuto:
+		interactive = IsTerminal(w)
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	m := &Multiplexer{
+		w:           w,
+		interactive: interactive,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	if interactive {
+		go m.animate(interval)
+	} else {
+		close(m.done)
+	}
+	return m
+}
+
+// Interactive reports whether the multiplexer redraws lines in place
+func (m *Multiplexer) Interactive() bool {
+	return m.interactive
+}
+
+// Add registers a new pending item
+func (m *Multiplexer) Add(name string) *Item {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item := &Item{m: m, name: name, state: StatePending}
+	m.items = append(m.items, item)
+	if len(name) > m.nameWidth {
+		m.nameWidth = len(name)
+	}
+	if m.interactive && !m.stopped {
+		m.redraw()
+	}
+	return item
+}
+
+// Stop halts the spinner and renders the final state of every item.
+// It is safe to call Stop more than once.
+func (m *Multiplexer) Stop() {
+	m.mu.Lock()
+	if m.stopped {
+		m.mu.Unlock()
+		return
+	}
+	m.stopped = true
+	close(m.stop)
+	m.mu.Unlock()
+
+	<-m.done
+
+	if m.interactive {
+		m.mu.Lock()
+		m.redraw()
+		m.mu.Unlock()
+	}
+}
+
+// Counts returns how many items finished successfully and how many failed
+func (m *Multiplexer) Counts() (succeeded, failed int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, item := range m.items {
+		switch item.state {
+		case StateSucceeded:
+			succeeded++
+		case StateFailed:
+			failed++
+		}
+	}
+	return succeeded, failed
+}
+
+// Name returns the item's label
+func (i *Item) Name() string {
+	return i.name
+}
+
+// State returns the item's current state
+func (i *Item) State() State {
+	i.m.mu.Lock()
+	defer i.m.mu.Unlock()
+	return i.state
+}
+
+// Start marks the item as running
+func (i *Item) Start(message string) {
+	i.set(StateRunning, message)
+}
+
+// Update changes the message of a running item without changing its state
+func (i *Item) Update(message string) {
+	i.m.mu.Lock()
+	state := i.state
+	i.m.mu.Unlock()
+	if state == StatePending {
+		state = StateRunning
+	}
+	i.set(state, message)
+}
+
+// Succeed marks the item as finished successfully
+func (i *Item) Succeed(message string) {
+	i.set(StateSucceeded, message)
+}
+
+// Fail marks the item as failed with the given error
+func (i *Item) Fail(err error) {
+	message := "failed"
+	if err != nil {
+		message = err.Error()
+	}
+	i.set(StateFailed, message)
+}
+
+// set records a state change and renders it. Finished items are never reopened.
+func (i *Item) set(state State, message string) {
+	m := i.m
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if i.state == StateSucceeded || i.state == StateFailed {
+		return
+	}
+	if i.state == state && i.message == message {
+		return
+	}
+	i.state = state
+	i.message = message
+	i.changed = time.Now()
+
+	if m.interactive {
+		if !m.stopped {
+			m.redraw()
+		}
+		return
+	}
+	fmt.Fprintf(m.w, "%s\n", m.line(i, ""))
+}
+
+// animate advances the spinner until Stop is called
+func (m *Multiplexer) animate(interval time.Duration) {
+	defer close(m.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.mu.Lock()
+			m.frame = (m.frame + 1) % len(spinnerFrames)
+			m.redraw()
+			m.mu.Unlock()
+		}
+	}
+}
+
+// redraw rewrites every item line in place. Callers must hold m.mu.
+func (m *Multiplexer) redraw() {
+	var b strings.Builder
+	if m.rendered > 0 {
+		fmt.Fprintf(&b, "\033[%dA", m.rendered)
+	}
+	spinner := spinnerFrames[m.frame]
+	for _, item := range m.items {
+		b.WriteString("\r\033[2K")
+		b.WriteString(m.line(item, spinner))
+		b.WriteString("\n")
+	}
+	m.rendered = len(m.items)
+	io.WriteString(m.w, b.String())
+}
+
+// line formats a single item. Callers must hold m.mu.
+func (m *Multiplexer) line(item *Item, spinner string) string {
+	indicator := "[" + item.state.String() + "]"
+	if item.state == StateRunning && spinner != "" {
+		indicator = "[" + spinner + "]"
+	}
+
+	// Names are aligned in interactive mode only; sequential lines are printed
+	// before all items are known
+	text := fmt.Sprintf("%-9s %s", indicator, item.name)
+	if m.interactive {
+		text = fmt.Sprintf("%-9s %-*s", indicator, m.nameWidth, item.name)
+	}
+	if item.message != "" {
+		text += "  " + item.message
+	}
+	if item.state == StatePending && Timestamps() {
+		// Pending items have not changed yet; keep them aligned with the others
+		text = strings.Repeat(" ", len(TimestampLayout)+1) + text
+	} else {
+		text = stamp(text, item.changed)
+	}
+	return strings.TrimRight(text, " ")
+}
+
+// IsTerminal reports whether w is an interactive terminal that understands
+// cursor movement sequences
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	if term := os.Getenv("TERM"); term == "dumb" {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		// Legacy consoles do not process ANSI escapes; Windows Terminal and
+		// terminal emulators that set TERM do
+		return os.Getenv("WT_SESSION") != "" || os.Getenv("TERM") != ""
+	}
+	return true
+}
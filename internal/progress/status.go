@@ -0,0 +1,170 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package progress
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// StatusOptions configures a StatusLine
+type StatusOptions struct {
+	Mode     Mode
+	Interval time.Duration
+	// Repeat prints every status, even when it did not change, and draws no spinner
+	Repeat bool
+}
+
+// StatusLine reports the status of one long-running operation. A status is printed
+// only when it differs from the previous one. On an interactive terminal a spinner
+// with the time since the last change is drawn below it while the StatusLine is
+// resumed; when output is redirected nothing is printed between changes.
+// All methods are safe for concurrent use.
+type StatusLine struct {
+	mu          sync.Mutex
+	w           io.Writer
+	interactive bool
+	repeat      bool
+	last        string
+	changed     time.Time
+	frame       int
+	drawn       bool
+	running     bool
+	stopped     bool
+	stop        chan struct{}
+	done        chan struct{}
+	now         func() time.Time
+}
+
+// NewStatusLine creates a StatusLine writing to w. The spinner starts paused; other
+// output may be written to w while it is paused.
+func NewStatusLine(w io.Writer, opts StatusOptions) *StatusLine {
+	interactive := false
+	switch opts.Mode {
+	case ModeInteractive:
+		interactive = true
+	case ModeAuto:
+		interactive = IsTerminal(w)
+	}
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	s := &StatusLine{
+		w:           w,
+		interactive: interactive && !opts.Repeat,
+		repeat:      opts.Repeat,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+		now:         time.Now,
+	}
+	if s.interactive {
+		go s.animate(interval)
+	} else {
+		close(s.done)
+	}
+	return s
+}
+
+// Update prints status if it differs from the last status, or always with
+// StatusOptions.Repeat. It reports whether the status was printed.
+func (s *StatusLine) Update(status string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.repeat && status == s.last {
+		return false
+	}
+	s.clear()
+	s.changed = s.now()
+	fmt.Fprintln(s.w, stamp(status, s.changed))
+	s.last = status
+	if s.running {
+		s.draw()
+	}
+	return true
+}
+
+// Pause removes the spinner so that other output can be written
+func (s *StatusLine) Pause() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = false
+	s.clear()
+}
+
+// Resume draws the spinner again after Pause
+func (s *StatusLine) Resume() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return
+	}
+	s.running = true
+	s.draw()
+}
+
+// Stop removes the spinner and ends its animation. It is safe to call Stop more than once.
+func (s *StatusLine) Stop() {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	s.stopped = true
+	s.running = false
+	close(s.stop)
+	s.mu.Unlock()
+
+	<-s.done
+
+	s.mu.Lock()
+	s.clear()
+	s.mu.Unlock()
+}
+
+// animate advances the spinner until Stop is called
+func (s *StatusLine) animate(interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			if s.running {
+				s.frame = (s.frame + 1) % len(spinnerFrames)
+				s.draw()
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// draw rewrites the spinner line in place. Callers must hold s.mu.
+func (s *StatusLine) draw() {
+	if !s.interactive || s.last == "" {
+		return
+	}
+	elapsed := s.now().Sub(s.changed).Round(time.Second)
+	fmt.Fprintf(s.w, "\r\033[2K[%s] %s since the last change", spinnerFrames[s.frame], elapsed)
+	s.drawn = true
+}
+
+// clear removes the spinner line. Callers must hold s.mu.
+func (s *StatusLine) clear() {
+	if !s.drawn {
+		return
+	}
+	io.WriteString(s.w, "\r\033[2K")
+	s.drawn = false
+}
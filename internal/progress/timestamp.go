@@ -0,0 +1,47 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package progress
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// TimestampLayout is the layout of the time prefixed to lines with SetTimestamps
+const TimestampLayout = "15:04:05"
+
+// location is the time zone of the prefixed times; nil means local time
+var location atomic.Pointer[time.Location]
+
+// timestamps is whether printed lines are prefixed with the time
+var timestamps atomic.Bool
+
+// SetTimestamps makes every line a StatusLine or Multiplexer prints start with the
+// time it was printed at (local time, or the zone given to SetLocation), e.g. "14:03:27 [DATA] Status: Preparing", so that
+// long logs show when each step happened. Items redrawn in place show the time of
+// their last change.
+func SetTimestamps(enabled bool) {
+	timestamps.Store(enabled)
+}
+
+// Timestamps reports whether lines are prefixed with the time
+func Timestamps() bool {
+	return timestamps.Load()
+}
+
+// SetLocation shows the prefixed times in the given time zone instead of local time
+func SetLocation(loc *time.Location) {
+	location.Store(loc)
+}
+
+// stamp prefixes line with t when timestamps are enabled
+func stamp(line string, t time.Time) string {
+	if !Timestamps() {
+		return line
+	}
+	if loc := location.Load(); loc != nil {
+		t = t.In(loc)
+	}
+	return t.Format(TimestampLayout) + " " + line
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables that configure trace export. AGB_CLI_OTLP_ENDPOINT takes
+// precedence over the standard OpenTelemetry variables.
+const (
+	EnvEndpoint       = "AGB_CLI_OTLP_ENDPOINT"
+	EnvOTelEndpoint   = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	EnvOTelTracesURL  = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	EnvOTelHeaders    = "OTEL_EXPORTER_OTLP_HEADERS"
+	tracesPath        = "/v1/traces"
+	exportBodyLimit   = 4 * 1024
+	defaultExportWait = 5 * time.Second
+)
+
+// Exporter sends spans to an OpenTelemetry collector using OTLP/HTTP with JSON encoding
+type Exporter struct {
+	URL        string            // Full URL of the traces endpoint, e.g. http://localhost:4318/v1/traces
+	Headers    map[string]string // Extra request headers, e.g. for authentication
+	HTTPClient *http.Client      // Default: a client with a 5 second timeout
+}
+
+// TracesURL returns the traces endpoint for a configured endpoint. Like the OpenTelemetry
+// SDKs, a base URL such as http://localhost:4318 gets /v1/traces appended.
+func TracesURL(endpoint string) (string, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("invalid OTLP endpoint '%s': expected an http(s) URL such as http://localhost:4318", endpoint)
+	}
+	if !strings.HasSuffix(parsed.Path, tracesPath) {
+		parsed.Path = strings.TrimRight(parsed.Path, "/") + tracesPath
+	}
+	return parsed.String(), nil
+}
+
+// ExporterFromEnv returns the exporter configured through the environment, falling back
+// to configured (e.g. from the configuration file). It returns nil if no endpoint is set.
+func ExporterFromEnv(configured string) (*Exporter, error) {
+	var tracesURL string
+	var err error
+	switch {
+	case os.Getenv(EnvEndpoint) != "":
+		tracesURL, err = TracesURL(os.Getenv(EnvEndpoint))
+	case os.Getenv(EnvOTelTracesURL) != "":
+		// The signal-specific variable is used as-is
+		tracesURL = os.Getenv(EnvOTelTracesURL)
+	case os.Getenv(EnvOTelEndpoint) != "":
+		tracesURL, err = TracesURL(os.Getenv(EnvOTelEndpoint))
+	case configured != "":
+		tracesURL, err = TracesURL(configured)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{URL: tracesURL, Headers: ParseHeaders(os.Getenv(EnvOTelHeaders))}, nil
+}
+
+// ParseHeaders parses the OTEL_EXPORTER_OTLP_HEADERS format: comma-separated key=value
+// pairs with URL-encoded values. Malformed entries are skipped.
+func ParseHeaders(value string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		key, val, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		if decoded, err := url.QueryUnescape(strings.TrimSpace(val)); err == nil {
+			val = decoded
+		}
+		headers[key] = val
+	}
+	return headers
+}
+
+// Export sends spans in a single OTLP request
+func (e *Exporter) Export(ctx context.Context, resource Resource, spans []*Span) error {
+	body, err := json.Marshal(otlpRequest(resource, spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.Headers {
+		req.Header.Set(key, value)
+	}
+
+	httpClient := e.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultExportWait}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, exportBodyLimit))
+		return fmt.Errorf("collector returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// The types below are the JSON encoding of the OTLP ExportTraceServiceRequest
+
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              SpanKind       `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"` // 0 unset, 1 ok, 2 error
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"` // int64 values are strings in OTLP JSON
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+}
+
+func otlpRequest(resource Resource, spans []*Span) otlpTraces {
+	resourceAttrs := []Attribute{String("service.name", resource.ServiceName)}
+	if resource.ServiceVersion != "" {
+		resourceAttrs = append(resourceAttrs, String("service.version", resource.ServiceVersion))
+	}
+
+	encoded := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		s.mu.Lock()
+		span := otlpSpan{
+			TraceID:           hex.EncodeToString(s.TraceID[:]),
+			SpanID:            hex.EncodeToString(s.SpanID[:]),
+			Name:              s.Name,
+			Kind:              s.Kind,
+			StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.End.UnixNano(), 10),
+			Attributes:        otlpAttributes(s.Attributes),
+		}
+		if s.ParentSpanID != [8]byte{} {
+			span.ParentSpanID = hex.EncodeToString(s.ParentSpanID[:])
+		}
+		if s.Err != "" {
+			span.Status = otlpStatus{Code: 2, Message: s.Err}
+		}
+		s.mu.Unlock()
+		encoded = append(encoded, span)
+	}
+
+	return otlpTraces{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: otlpAttributes(resourceAttrs)},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: resource.ServiceName, Version: resource.ServiceVersion}, Spans: encoded}},
+	}}}
+}
+
+func otlpAttributes(attrs []Attribute) []otlpKeyValue {
+	values := make([]otlpKeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		var value otlpValue
+		switch v := attr.Value.(type) {
+		case string:
+			value.StringValue = &v
+		case int64:
+			text := strconv.FormatInt(v, 10)
+			value.IntValue = &text
+		case bool:
+			value.BoolValue = &v
+		default:
+			text := fmt.Sprint(v)
+			value.StringValue = &text
+		}
+		values = append(values, otlpKeyValue{Key: attr.Key, Value: value})
+	}
+	return values
+}
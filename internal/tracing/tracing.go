@@ -0,0 +1,212 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing records spans of CLI operations and exports them to an
+// OpenTelemetry collector over OTLP/HTTP.
+//
+// Tracing is off until Enable installs a Tracer. Until then Start returns a nil
+// *Span, and all Span methods accept a nil receiver, so instrumented code needs
+// no checks. A CLI invocation is short-lived, so spans are kept in memory and
+// exported once by Tracer.Flush when the command finishes.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SpanKind tells whether a span is local work or a request to another service
+type SpanKind int
+
+const (
+	// SpanKindInternal is work done by the CLI itself, e.g. a command or a poll cycle
+	SpanKindInternal SpanKind = 1
+	// SpanKindClient is a request to a remote service, e.g. an API call
+	SpanKindClient SpanKind = 3
+)
+
+// Attribute is a key-value pair describing a span. Value is a string, int64 or bool.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string attribute
+func String(key, value string) Attribute { return Attribute{Key: key, Value: value} }
+
+// Int returns an integer attribute
+func Int(key string, value int) Attribute { return Attribute{Key: key, Value: int64(value)} }
+
+// Bool returns a boolean attribute
+func Bool(key string, value bool) Attribute { return Attribute{Key: key, Value: value} }
+
+// Span is one timed operation within a trace
+type Span struct {
+	tracer *Tracer
+
+	TraceID      [16]byte
+	SpanID       [8]byte
+	ParentSpanID [8]byte // Zero for the root span
+	Name         string
+	Kind         SpanKind
+	Start        time.Time
+	End          time.Time
+	Attributes   []Attribute
+	Err          string // Error the operation failed with, empty on success
+
+	mu    sync.Mutex
+	ended bool
+}
+
+// SetAttributes adds attributes to the span
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Attributes = append(s.Attributes, attrs...)
+}
+
+// Finish ends the span, marking it as failed if err is not nil. Only the first call counts.
+func (s *Span) Finish(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.End = s.tracer.now()
+	if err != nil {
+		s.Err = err.Error()
+	}
+	s.mu.Unlock()
+	s.tracer.record(s)
+}
+
+// Traceparent returns the W3C Trace Context header value that continues this span's
+// trace in the called service, or "" for a nil span
+func (s *Span) Traceparent() string {
+	if s == nil {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", hex.EncodeToString(s.TraceID[:]), hex.EncodeToString(s.SpanID[:]))
+}
+
+// Resource describes the process that produced the spans
+type Resource struct {
+	ServiceName    string
+	ServiceVersion string
+}
+
+// Tracer collects the finished spans of one CLI invocation. It is safe for concurrent use.
+type Tracer struct {
+	Resource Resource
+	Exporter *Exporter // Where Flush sends the spans; nil keeps them in memory only
+	Now      func() time.Time
+
+	mu    sync.Mutex
+	spans []*Span
+}
+
+// NewTracer returns a tracer that exports its spans with exporter
+func NewTracer(resource Resource, exporter *Exporter) *Tracer {
+	return &Tracer{Resource: resource, Exporter: exporter}
+}
+
+func (t *Tracer) now() time.Time {
+	if t.Now != nil {
+		return t.Now()
+	}
+	return time.Now()
+}
+
+func (t *Tracer) record(s *Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = append(t.spans, s)
+}
+
+// Spans returns the finished spans in the order they ended
+func (t *Tracer) Spans() []*Span {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*Span(nil), t.spans...)
+}
+
+// Flush exports the finished spans and forgets them
+func (t *Tracer) Flush(ctx context.Context) error {
+	t.mu.Lock()
+	spans := t.spans
+	t.spans = nil
+	t.mu.Unlock()
+	if t.Exporter == nil || len(spans) == 0 {
+		return nil
+	}
+	return t.Exporter.Export(ctx, t.Resource, spans)
+}
+
+// defaultTracer is used by Start once Enable is called
+var defaultTracer atomic.Pointer[Tracer]
+
+// Enable installs tracer for all spans started afterwards
+func Enable(tracer *Tracer) {
+	defaultTracer.Store(tracer)
+}
+
+// Disable stops recording spans
+func Disable() {
+	defaultTracer.Store(nil)
+}
+
+// Default returns the tracer installed by Enable, or nil if tracing is off
+func Default() *Tracer {
+	return defaultTracer.Load()
+}
+
+type spanContextKey struct{}
+
+// SpanFromContext returns the span stored in ctx by Start, or nil
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+// Start begins a span as a child of the span in ctx, or as the root of a new trace.
+// The returned context carries the new span. When tracing is off, ctx and a nil span
+// are returned.
+func Start(ctx context.Context, name string, kind SpanKind, attrs ...Attribute) (context.Context, *Span) {
+	tracer := Default()
+	if tracer == nil {
+		return ctx, nil
+	}
+	span := &Span{tracer: tracer, Name: name, Kind: kind, Start: tracer.now(), Attributes: attrs}
+	if parent := SpanFromContext(ctx); parent != nil {
+		span.TraceID = parent.TraceID
+		span.ParentSpanID = parent.SpanID
+	} else {
+		randomID(span.TraceID[:])
+	}
+	randomID(span.SpanID[:])
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+// randomID fills id with random bytes; IDs must not be all zero
+func randomID(id []byte) {
+	for {
+		_, _ = rand.Read(id) // crypto/rand does not fail on supported platforms
+		for _, b := range id {
+			if b != 0 {
+				return
+			}
+		}
+	}
+}
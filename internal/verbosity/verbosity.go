@@ -0,0 +1,48 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package verbosity holds the level of detail selected with the global -v flag,
+// which can be repeated: -v shows a summary of every API request and the details
+// of each step of a command, -vv adds the request and response headers, and -vvv
+// the bodies. Headers and bodies are redacted before they are logged.
+package verbosity
+
+import "sync/atomic"
+
+// Level is a verbosity tier; every tier includes the ones below it
+type Level int
+
+const (
+	// Normal is the output without -v
+	Normal Level = iota
+	// Requests (-v) adds one line per API request and step details such as request IDs
+	Requests
+	// Headers (-vv) adds the request and response headers
+	Headers
+	// Bodies (-vvv) adds the request and response bodies
+	Bodies
+)
+
+// level is the current tier
+var level atomic.Int32
+
+// Set selects the tier; counts beyond Bodies are treated as Bodies
+func Set(l Level) {
+	if l < Normal {
+		l = Normal
+	}
+	if l > Bodies {
+		l = Bodies
+	}
+	level.Store(int32(l))
+}
+
+// Get returns the current tier
+func Get() Level {
+	return Level(level.Load())
+}
+
+// Enabled reports whether output of tier l is shown
+func Enabled(l Level) bool {
+	return Get() >= l
+}
@@ -0,0 +1,36 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package workpool runs the items of a batch on a bounded number of workers, so that
+// all batch commands limit the requests they send at the same time the same way.
+package workpool
+
+import "sync"
+
+// Run calls work for every index from 0 to n-1 on at most workers goroutines and
+// returns once all calls have returned. Indexes are handed out in order, so with one
+// worker the items run one after the other. workers below 1 means one worker.
+//
+// Run does not stop early: work is expected to check its context and report items it
+// skips, so that every item has a result.
+func Run(workers, n int, work func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				work(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
@@ -4,6 +4,8 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"os"
 
 	log "github.com/sirupsen/logrus"
@@ -32,31 +34,33 @@ func init() {
 
 	// Add commands
 	rootCmd.AddCommand(cmd.VersionCmd)
+	rootCmd.AddCommand(cmd.InitCmd)
 	rootCmd.AddCommand(cmd.LoginCmd)
 	rootCmd.AddCommand(cmd.LogoutCmd)
+	rootCmd.AddCommand(cmd.AuthCmd)
 	rootCmd.AddCommand(cmd.ImageCmd)
+	rootCmd.AddCommand(cmd.SSHKeyCmd)
+	rootCmd.AddCommand(cmd.ServiceAccountCmd)
+	rootCmd.AddCommand(cmd.JobsCmd)
+	rootCmd.AddCommand(cmd.DoctorCmd)
+	rootCmd.AddCommand(cmd.SelftestCmd)
+	rootCmd.AddCommand(cmd.ConfigCmd)
+	rootCmd.AddCommand(cmd.SchemaCmd)
+	rootCmd.AddCommand(cmd.DevCmd)
+	rootCmd.AddCommand(cmd.ReleaseCmd)
+
+	// Offer to run a command again after a transient failure in a terminal
+	cmd.EnableRetryPrompt(rootCmd)
 
 	// Global flags
 	rootCmd.CompletionOptions.HiddenDefaultCmd = true
 	rootCmd.PersistentFlags().BoolP("help", "", false, "help for agb")
-	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
+	cmd.AddGlobalFlags(rootCmd)
 	rootCmd.Flags().BoolP("version", "", false, "Display the version of AgbCloud CLI")
 
-	// Handle version flag and verbose flag
-	rootCmd.PersistentPreRun = func(command *cobra.Command, args []string) {
-		// Set up logging based on verbose flag
-		verbose, _ := command.Flags().GetBool("verbose")
-		if verbose {
-			log.SetLevel(log.DebugLevel)
-		} else {
-			log.SetLevel(log.InfoLevel)
-		}
-
-		// Set log format to be more CLI-friendly
-		log.SetFormatter(&log.TextFormatter{
-			DisableTimestamp: true,
-			DisableColors:    false,
-		})
+	// Handle verbose, timing, time zone, timestamps, pager, truncation, CSV, line ending, endpoint, first run, tracing, sticky and output flags
+	rootCmd.PersistentPreRunE = func(command *cobra.Command, args []string) error {
+		return cmd.ApplyGlobalFlags(command)
 	}
 
 	// Handle version flag
@@ -64,6 +68,7 @@ func init() {
 		versionFlag, _ := command.Flags().GetBool("version")
 		if versionFlag {
 			err := cmd.VersionCmd.RunE(command, []string{})
+			cmd.FlushOutput()
 			if err != nil {
 				log.Fatal(err)
 			}
@@ -76,11 +81,27 @@ func main() {
 	// Load environment variables
 	_ = godotenv.Load()
 
+	// Ctrl+C cancels the requests and monitoring of the running command
+	ctx, stop := cmd.InterruptContext(context.Background())
+	defer stop()
+
 	// Execute root command
-	err := rootCmd.Execute()
+	err := rootCmd.ExecuteContext(ctx)
+	cmd.FlushOutput()
+
+	// Timings go to stderr so they never mix with structured output
+	cmd.ReportTimings(os.Stderr)
+	cmd.FinishTracing(os.Stderr, err)
+
+	if cmd.Interrupted(ctx, err) {
+		fmt.Fprintln(os.Stderr, "[STOP] Interrupted")
+		stop()
+		os.Exit(cmd.ExitCodeInterrupted)
+	}
 	if err != nil {
 		// Exit with error code without logging the error again
 		// Error messages are already handled by individual commands
-		os.Exit(1)
+		// Batch commands exit with 2 when only some of their images failed
+		os.Exit(cmd.ExitCode(err))
 	}
 }
@@ -0,0 +1,212 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events publishes structured events about what the AgbCloud client is
+// doing: API requests starting and finishing, retries, and status changes seen
+// while monitoring a long-running operation.
+//
+// Programs embedding the client register a Sink to feed the events into their own
+// logging or metrics. NewLogSink writes them to a log/slog logger:
+//
+//	unregister := events.Register(events.NewLogSink(slog.Default()))
+//	defer unregister()
+//
+// Sinks are called synchronously on the goroutine that emits the event, so they
+// should return quickly and must be safe for concurrent use.
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Kind identifies what an event reports
+type Kind string
+
+const (
+	// RequestStarted is emitted before an API request is sent
+	RequestStarted Kind = "request.started"
+	// RequestFinished is emitted when an API request has a response or has failed
+	RequestFinished Kind = "request.finished"
+	// RequestRetry is emitted when a failed attempt is about to be retried
+	RequestRetry Kind = "request.retry"
+	// PollTransition is emitted when a monitored task or image changes status
+	PollTransition Kind = "poll.transition"
+)
+
+// Event describes one thing that happened. Fields that do not apply to the kind are zero.
+type Event struct {
+	Kind Kind
+	Time time.Time
+
+	// Method and Path identify the request; the query is left out because it may carry credentials
+	Method string
+	Path   string
+	// StatusCode is the HTTP status of the response, 0 if there was none
+	StatusCode int
+	// Attempt is the number of the failed attempt of a retry, starting at 1
+	Attempt int
+	// Delay is the wait before the next attempt of a retry
+	Delay time.Duration
+	// Duration is how long a finished request took
+	Duration time.Duration
+	// Err is why a request or attempt failed
+	Err error
+
+	// Subject is the task or image ID of a poll transition; From is empty on the first status
+	Subject string
+	From    string
+	To      string
+}
+
+// Attrs returns the fields of the event that are set, as slog attributes
+func (e Event) Attrs() []slog.Attr {
+	attrs := []slog.Attr{slog.String("kind", string(e.Kind))}
+	if e.Method != "" {
+		attrs = append(attrs, slog.String("method", e.Method))
+	}
+	if e.Path != "" {
+		attrs = append(attrs, slog.String("path", e.Path))
+	}
+	if e.StatusCode != 0 {
+		attrs = append(attrs, slog.Int("status", e.StatusCode))
+	}
+	if e.Attempt != 0 {
+		attrs = append(attrs, slog.Int("attempt", e.Attempt))
+	}
+	if e.Delay != 0 {
+		attrs = append(attrs, slog.Duration("delay", e.Delay))
+	}
+	if e.Duration != 0 {
+		attrs = append(attrs, slog.Duration("duration", e.Duration))
+	}
+	if e.Subject != "" {
+		attrs = append(attrs, slog.String("subject", e.Subject))
+	}
+	if e.From != "" {
+		attrs = append(attrs, slog.String("from", e.From))
+	}
+	if e.To != "" {
+		attrs = append(attrs, slog.String("to", e.To))
+	}
+	if e.Err != nil {
+		attrs = append(attrs, slog.String("error", e.Err.Error()))
+	}
+	return attrs
+}
+
+// LogValue implements slog.LogValuer, so that an event can be logged as one attribute
+func (e Event) LogValue() slog.Value {
+	return slog.GroupValue(e.Attrs()...)
+}
+
+// Failed reports whether the event describes a failure
+func (e Event) Failed() bool {
+	return e.Err != nil || e.StatusCode >= 400
+}
+
+// Sink receives events
+type Sink interface {
+	HandleEvent(ctx context.Context, e Event)
+}
+
+// SinkFunc adapts a function to the Sink interface
+type SinkFunc func(ctx context.Context, e Event)
+
+// HandleEvent calls f(ctx, e)
+func (f SinkFunc) HandleEvent(ctx context.Context, e Event) {
+	f(ctx, e)
+}
+
+// NewLogSink returns a sink that logs events to logger with the kind as message.
+// Started requests are logged at debug level, retries and failed requests at warn
+// level, and everything else at info level.
+func NewLogSink(logger *slog.Logger) Sink {
+	return SinkFunc(func(ctx context.Context, e Event) {
+		level := slog.LevelInfo
+		switch {
+		case e.Kind == RequestStarted:
+			level = slog.LevelDebug
+		case e.Kind == RequestRetry, e.Failed():
+			level = slog.LevelWarn
+		}
+		logger.LogAttrs(ctx, level, string(e.Kind), e.Attrs()[1:]...)
+	})
+}
+
+// Emit stamps the event with the current time unless it has one and passes it to
+// sink. A nil sink drops the event.
+func Emit(ctx context.Context, sink Sink, e Event) {
+	if sink == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	sink.HandleEvent(ctx, e)
+}
+
+// registry holds the process-wide sinks added with Register
+var registry struct {
+	mu    sync.RWMutex
+	next  int
+	sinks map[int]Sink
+	order []int
+}
+
+// Register adds a process-wide sink that receives every event published through
+// Publish or Default. The returned function removes it again.
+func Register(sink Sink) (unregister func()) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if registry.sinks == nil {
+		registry.sinks = map[int]Sink{}
+	}
+	id := registry.next
+	registry.next++
+	registry.sinks[id] = sink
+	registry.order = append(registry.order, id)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			registry.mu.Lock()
+			defer registry.mu.Unlock()
+			delete(registry.sinks, id)
+			for i, registered := range registry.order {
+				if registered == id {
+					registry.order = append(registry.order[:i:i], registry.order[i+1:]...)
+					break
+				}
+			}
+		})
+	}
+}
+
+// Publish passes the event to every registered sink, in the order they were registered
+func Publish(ctx context.Context, e Event) {
+	Emit(ctx, Default(), e)
+}
+
+// Default returns the sink that forwards events to the sinks registered at the
+// time of each event. API clients created by the CLI use it.
+func Default() Sink {
+	return defaultSink{}
+}
+
+type defaultSink struct{}
+
+func (defaultSink) HandleEvent(ctx context.Context, e Event) {
+	registry.mu.RLock()
+	sinks := make([]Sink, 0, len(registry.order))
+	for _, id := range registry.order {
+		sinks = append(sinks, registry.sinks[id])
+	}
+	registry.mu.RUnlock()
+
+	for _, sink := range sinks {
+		sink.HandleEvent(ctx, e)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package version
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// BinaryName is the name of the CLI binary in release artifacts
+const BinaryName = "agb"
+
+// ReleaseDownloadURL is the base URL of the release artifacts
+const ReleaseDownloadURL = "https://github.com/agbcloud/agbcloud-cli/releases/download"
+
+// Target identifies the platform a release artifact is built for
+type Target struct {
+	OS   string // GOOS, e.g. "linux"
+	Arch string // GOARCH, e.g. "arm64"
+}
+
+// String returns the platform as os/arch
+func (t Target) String() string {
+	return fmt.Sprintf("%s/%s", t.OS, t.Arch)
+}
+
+// ArtifactName returns the file name of the release artifact of the given version,
+// e.g. agb-1.2.0-windows-arm64.exe or agb-1.2.0-linux-amd64
+func (t Target) ArtifactName(version string) string {
+	name := fmt.Sprintf("%s-%s-%s-%s", BinaryName, strings.TrimPrefix(version, "v"), t.OS, t.Arch)
+	if t.OS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// ChecksumName returns the file name of the SHA-256 checksum of the release artifact
+func (t Target) ChecksumName(version string) string {
+	return t.ArtifactName(version) + ".sha256"
+}
+
+// ArtifactURL returns the download URL of the release artifact for a release tag
+func (t Target) ArtifactURL(tag string) string {
+	return fmt.Sprintf("%s/%s/%s", ReleaseDownloadURL, tag, t.ArtifactName(tag))
+}
+
+// ParseArtifactName extracts the version and target from a release artifact file name
+func ParseArtifactName(name string) (string, Target, error) {
+	base := strings.TrimSuffix(name, ".exe")
+	parts := strings.Split(strings.TrimPrefix(base, BinaryName+"-"), "-")
+	if !strings.HasPrefix(base, BinaryName+"-") || len(parts) != 3 {
+		return "", Target{}, fmt.Errorf("invalid artifact name %q", name)
+	}
+
+	target := Target{OS: parts[1], Arch: parts[2]}
+	if (target.OS == "windows") != strings.HasSuffix(name, ".exe") {
+		return "", Target{}, fmt.Errorf("invalid artifact name %q: only Windows artifacts end in .exe", name)
+	}
+	return parts[0], target, nil
+}
+
+// HostProbe gives access to the facts about the host that select the artifact
+type HostProbe struct {
+	Getenv func(key string) string
+	Exists func(path string) bool
+}
+
+// DetectTarget returns the release artifact target best suited for this machine
+func DetectTarget() Target {
+	return DetectTargetWith(runtime.GOOS, runtime.GOARCH, HostProbe{
+		Getenv: os.Getenv,
+		Exists: func(path string) bool {
+			_, err := os.Stat(path)
+			return err == nil
+		},
+	})
+}
+
+// DetectTargetWith returns the artifact target for a binary built for goos/goarch
+// running on the host described by probe. An amd64 binary on Windows on ARM runs
+// emulated, so the native arm64 build is selected. Linux builds are static and
+// need no C library, so musl-based hosts such as Alpine get the same build as any
+// other Linux host.
+func DetectTargetWith(goos, goarch string, probe HostProbe) Target {
+	target := Target{OS: goos, Arch: goarch}
+	if goos == "windows" {
+		identifier := strings.ToUpper(probe.Getenv("PROCESSOR_IDENTIFIER"))
+		if goarch == "amd64" && (probe.Getenv("PROCESSOR_ARCHITECTURE") == "ARM64" || strings.HasPrefix(identifier, "ARM")) {
+			target.Arch = "arm64"
+		}
+	}
+	return target
+}
+
+// VerifyChecksum checks data against a checksum file in sha256sum format. The
+// file may list several artifacts; the line for artifact is used.
+func VerifyChecksum(data, checksumFile []byte, artifact string) error {
+	expected := ""
+	scanner := bufio.NewScanner(bytes.NewReader(checksumFile))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		// sha256sum marks binary mode with '*' and is often run with a ./ prefix
+		name := strings.TrimPrefix(strings.TrimPrefix(fields[1], "*"), "./")
+		if name == artifact {
+			expected = strings.ToLower(fields[0])
+			break
+		}
+	}
+	if expected == "" {
+		return fmt.Errorf("no checksum for %s", artifact)
+	}
+
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", artifact, expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package version
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Package metadata shared by the package manager manifests
+const (
+	ManifestDescription = "Secure infrastructure for running AI-generated code"
+	ManifestHomepage    = "https://github.com/agbcloud/agbcloud-cli"
+	ManifestLicense     = "Apache-2.0"
+)
+
+// Artifact is a release artifact with its SHA-256 checksum
+type Artifact struct {
+	Target Target
+	Name   string
+	SHA256 string
+}
+
+// URL returns the download URL of the artifact for a release tag
+func (a Artifact) URL(tag string) string {
+	return fmt.Sprintf("%s/%s/%s", ReleaseDownloadURL, tag, a.Name)
+}
+
+// ReleaseTag returns the git tag of a release version, e.g. v1.2.0
+func ReleaseTag(version string) string {
+	return "v" + strings.TrimPrefix(version, "v")
+}
+
+// ReadArtifacts collects the artifacts of a release version in dir with their
+// checksums. Artifacts have their release names, e.g. agb-1.2.0-darwin-arm64, or
+// the names of local builds without the version, e.g. agb-darwin-arm64. Checksums
+// are read from the .sha256 files published with the artifacts, which may list
+// several artifacts each; artifacts without one are hashed. A published checksum
+// that does not match its artifact is an error.
+func ReadArtifacts(dir, version string) ([]Artifact, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	version = strings.TrimPrefix(version, "v")
+
+	published := map[string]string{}
+	files := map[string]string{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if strings.HasSuffix(entry.Name(), ".sha256") {
+			content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				return nil, err
+			}
+			for name, sum := range parseChecksumFile(content) {
+				if releaseName, ok := releaseArtifactName(name, version); ok {
+					published[releaseName] = sum
+				}
+			}
+			continue
+		}
+		if releaseName, ok := releaseArtifactName(entry.Name(), version); ok {
+			files[releaseName] = entry.Name()
+		}
+	}
+
+	// Artifacts that are only known from a checksum file are listed too
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	for name := range published {
+		if _, ok := files[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no release artifacts of version %s in %s", version, dir)
+	}
+	sort.Strings(names)
+
+	artifacts := make([]Artifact, 0, len(names))
+	for _, name := range names {
+		_, target, _ := ParseArtifactName(name)
+		artifact := Artifact{Target: target, Name: name, SHA256: published[name]}
+		if file, ok := files[name]; ok {
+			data, err := os.ReadFile(filepath.Join(dir, file))
+			if err != nil {
+				return nil, err
+			}
+			sum := sha256.Sum256(data)
+			actual := hex.EncodeToString(sum[:])
+			if artifact.SHA256 != "" && artifact.SHA256 != actual {
+				return nil, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", file, artifact.SHA256, actual)
+			}
+			artifact.SHA256 = actual
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts, nil
+}
+
+// releaseArtifactName returns the release name of an artifact of version, given by
+// its release name or by the name of a local build without the version
+func releaseArtifactName(name, version string) (string, bool) {
+	if artifactVersion, _, err := ParseArtifactName(name); err == nil {
+		return name, artifactVersion == version
+	}
+	// Local builds are named agb-<os>-<arch>[.exe], as by 'make build-all'
+	if _, target, err := ParseArtifactName(strings.Replace(name, BinaryName+"-", BinaryName+"-"+version+"-", 1)); err == nil && strings.HasPrefix(name, BinaryName+"-") {
+		return target.ArtifactName(version), true
+	}
+	return "", false
+}
+
+// parseChecksumFile reads the artifact checksums of a file in sha256sum format
+func parseChecksumFile(content []byte) map[string]string {
+	sums := map[string]string{}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		// sha256sum marks binary mode with '*' and is often run with a ./ prefix
+		name := strings.TrimPrefix(strings.TrimPrefix(fields[1], "*"), "./")
+		sums[filepath.Base(name)] = strings.ToLower(fields[0])
+	}
+	return sums
+}
+
+// findArtifact returns the artifact built for os/arch with the default C library
+func findArtifact(artifacts []Artifact, goos, goarch string) (Artifact, bool) {
+	for _, artifact := range artifacts {
+		if artifact.Target == (Target{OS: goos, Arch: goarch}) {
+			return artifact, true
+		}
+	}
+	return Artifact{}, false
+}
+
+// brewPlatforms are the platforms of the Homebrew formula, in the order of its blocks
+var brewPlatforms = []struct {
+	OS, Arch, Block, CPU string
+}{
+	{"darwin", "arm64", "on_macos", "on_arm"},
+	{"darwin", "amd64", "on_macos", "on_intel"},
+	{"linux", "arm64", "on_linux", "on_arm"},
+	{"linux", "amd64", "on_linux", "on_intel"},
+}
+
+// BrewFormula generates a Homebrew formula that installs the prebuilt macOS and
+// Linux binaries of a release. Platforms without an artifact are left out; at
+// least one is required.
+func BrewFormula(version string, artifacts []Artifact) (string, error) {
+	tag := ReleaseTag(version)
+	var b strings.Builder
+	fmt.Fprintf(&b, "class Agb < Formula\n")
+	fmt.Fprintf(&b, "  desc %q\n", ManifestDescription)
+	fmt.Fprintf(&b, "  homepage %q\n", ManifestHomepage)
+	fmt.Fprintf(&b, "  version %q\n", strings.TrimPrefix(version, "v"))
+	fmt.Fprintf(&b, "  license %q\n", ManifestLicense)
+
+	found := 0
+	block := ""
+	for _, platform := range brewPlatforms {
+		artifact, ok := findArtifact(artifacts, platform.OS, platform.Arch)
+		if !ok {
+			continue
+		}
+		if platform.Block != block {
+			if block != "" {
+				b.WriteString("  end\n")
+			}
+			fmt.Fprintf(&b, "\n  %s do\n", platform.Block)
+			block = platform.Block
+		} else {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "    %s do\n", platform.CPU)
+		fmt.Fprintf(&b, "      url %q\n", artifact.URL(tag))
+		fmt.Fprintf(&b, "      sha256 %q\n", artifact.SHA256)
+		b.WriteString("\n")
+		b.WriteString("      def install\n")
+		fmt.Fprintf(&b, "        bin.install %q => %q\n", artifact.Name, BinaryName)
+		b.WriteString("      end\n")
+		b.WriteString("    end\n")
+		found++
+	}
+	if found == 0 {
+		return "", fmt.Errorf("no macOS or Linux artifacts for the Homebrew formula")
+	}
+	b.WriteString("  end\n")
+
+	b.WriteString("\n  test do\n")
+	fmt.Fprintf(&b, "    assert_match version.to_s, shell_output(\"#{bin}/%s version\")\n", BinaryName)
+	b.WriteString("  end\n")
+	b.WriteString("end\n")
+	return b.String(), nil
+}
+
+// scoopArchitectures maps Windows artifact architectures to Scoop architecture names
+var scoopArchitectures = []struct {
+	Arch, Scoop string
+}{
+	{"amd64", "64bit"},
+	{"386", "32bit"},
+	{"arm64", "arm64"},
+}
+
+// ScoopManifest generates a Scoop manifest that installs the Windows binaries of a
+// release. Architectures without an artifact are left out; at least one is required.
+func ScoopManifest(version string, artifacts []Artifact) ([]byte, error) {
+	type scoopArchitecture struct {
+		URL  string     `json:"url"`
+		Hash string     `json:"hash"`
+		Bin  [][]string `json:"bin"`
+	}
+	type scoopAutoupdateArchitecture struct {
+		URL string `json:"url"`
+	}
+	type scoopManifest struct {
+		Version      string                                            `json:"version"`
+		Description  string                                            `json:"description"`
+		Homepage     string                                            `json:"homepage"`
+		License      string                                            `json:"license"`
+		Architecture map[string]scoopArchitecture                      `json:"architecture"`
+		Checkver     string                                            `json:"checkver"`
+		Autoupdate   map[string]map[string]scoopAutoupdateArchitecture `json:"autoupdate"`
+	}
+
+	tag := ReleaseTag(version)
+	manifest := scoopManifest{
+		Version:      strings.TrimPrefix(version, "v"),
+		Description:  ManifestDescription,
+		Homepage:     ManifestHomepage,
+		License:      ManifestLicense,
+		Architecture: map[string]scoopArchitecture{},
+		Checkver:     "github",
+		Autoupdate:   map[string]map[string]scoopAutoupdateArchitecture{"architecture": {}},
+	}
+	for _, arch := range scoopArchitectures {
+		artifact, ok := findArtifact(artifacts, "windows", arch.Arch)
+		if !ok {
+			continue
+		}
+		manifest.Architecture[arch.Scoop] = scoopArchitecture{
+			URL:  artifact.URL(tag),
+			Hash: artifact.SHA256,
+			// Install the binary as agb.exe whatever the name of the artifact
+			Bin: [][]string{{artifact.Name, BinaryName}},
+		}
+		manifest.Autoupdate["architecture"][arch.Scoop] = scoopAutoupdateArchitecture{
+			URL: fmt.Sprintf("%s/v$version/%s", ReleaseDownloadURL, artifact.Target.ArtifactName("$version")),
+		}
+	}
+	if len(manifest.Architecture) == 0 {
+		return nil, fmt.Errorf("no Windows artifacts for the Scoop manifest")
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	if err := encoder.Encode(manifest); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
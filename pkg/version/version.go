@@ -24,6 +24,9 @@ type Info struct {
 	BuildDate string `json:"build_date"`
 	GoVersion string `json:"go_version"`
 	Platform  string `json:"platform"`
+	// UpdateTarget is the platform whose release artifacts suit this machine, which
+	// differs from Platform for emulated Windows on ARM
+	UpdateTarget string `json:"update_target"`
 }
 
 // Get returns version information
@@ -34,6 +37,8 @@ func Get() Info {
 		BuildDate: BuildDate,
 		GoVersion: runtime.Version(),
 		Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+
+		UpdateTarget: DetectTarget().String(),
 	}
 }
 
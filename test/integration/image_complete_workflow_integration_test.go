@@ -136,7 +136,8 @@ func TestImageCompleteWorkflowIntegration(t *testing.T) {
 			tokens.SessionId,
 			imageName,
 			taskId,
-			sourceImageId)
+			sourceImageId,
+			client.ImageCreateOptions{})
 
 		if err != nil {
 			if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
@@ -76,6 +76,7 @@ func TestImageDeactivateIntegration(t *testing.T) {
 				tokens.LoginToken,
 				tokens.SessionId,
 				tt.imageId,
+				client.ImageStopOptions{},
 			)
 
 			// Log request details
@@ -181,6 +182,7 @@ func TestImageDeactivateParameterValidation(t *testing.T) {
 				tt.loginToken,
 				tt.sessionId,
 				tt.imageId,
+				client.ImageStopOptions{},
 			)
 
 			if tt.expectError {
@@ -227,7 +229,7 @@ func TestImageDeactivateRealWorkflow(t *testing.T) {
 	t.Run("list_and_deactivate_running_image", func(t *testing.T) {
 		// First, try to list available images
 		t.Log("[SEARCH] Fetching available images...")
-		listResp, _, err := apiClient.ImageAPI.ListImages(ctx, tokens.LoginToken, tokens.SessionId, "User", 1, 5)
+		listResp, _, err := apiClient.ImageAPI.ListImages(ctx, tokens.LoginToken, tokens.SessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 5})
 
 		if err != nil {
 			t.Logf("[WARN]  Could not list images: %v", err)
@@ -250,6 +252,7 @@ func TestImageDeactivateRealWorkflow(t *testing.T) {
 			tokens.LoginToken,
 			tokens.SessionId,
 			testImage.ImageID,
+			client.ImageStopOptions{},
 		)
 
 		// Log the results regardless of success/failure
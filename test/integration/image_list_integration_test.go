@@ -52,7 +52,7 @@ func TestImageListIntegration(t *testing.T) {
 
 		// Test ListImages API call
 		t.Logf("\n[DOC] Calling ListImages API...")
-		response, httpResp, err := apiClient.ImageAPI.ListImages(ctx, tokens.LoginToken, tokens.SessionId, "User", 1, 10)
+		response, httpResp, err := apiClient.ImageAPI.ListImages(ctx, tokens.LoginToken, tokens.SessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
 
 		if err != nil {
 			if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
@@ -104,7 +104,7 @@ func TestImageListIntegration(t *testing.T) {
 		pageSizes := []int{1, 5, 20}
 		for _, pageSize := range pageSizes {
 			t.Logf("\n[PAGE] Testing with page size: %d", pageSize)
-			response, _, err := apiClient.ImageAPI.ListImages(ctx, tokens.LoginToken, tokens.SessionId, "User", 1, pageSize)
+			response, _, err := apiClient.ImageAPI.ListImages(ctx, tokens.LoginToken, tokens.SessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: pageSize})
 
 			if err != nil {
 				t.Logf("[WARN]  Error with page size %d: %v", pageSize, err)
@@ -135,7 +135,7 @@ func TestImageListIntegration(t *testing.T) {
 
 		// Test System image type
 		t.Logf("\n[DOC] Calling ListImages API with System type...")
-		response, httpResp, err := apiClient.ImageAPI.ListImages(ctx, tokens.LoginToken, tokens.SessionId, "System", 1, 10)
+		response, httpResp, err := apiClient.ImageAPI.ListImages(ctx, tokens.LoginToken, tokens.SessionId, client.ImageListOptions{ImageType: "System", Page: 1, PageSize: 10})
 
 		if err != nil {
 			if apiErr, ok := err.(*client.GenericOpenAPIError); ok {
@@ -367,7 +367,7 @@ func TestImageListErrorHandling(t *testing.T) {
 		t.Logf("🔒 Testing ListImages without authentication")
 
 		// This should fail because we don't have valid tokens
-		_, _, err := apiClient.ImageAPI.ListImages(ctx, "", "", "User", 1, 10)
+		_, _, err := apiClient.ImageAPI.ListImages(ctx, "", "", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
 
 		if err == nil {
 			t.Errorf("Expected error when calling ListImages without auth, but got none")
@@ -380,7 +380,7 @@ func TestImageListErrorHandling(t *testing.T) {
 		t.Logf("🚫 Testing ListImages with invalid parameters")
 
 		// Test invalid page
-		_, _, err := apiClient.ImageAPI.ListImages(ctx, "fake-token", "fake-session", "User", 0, 10)
+		_, _, err := apiClient.ImageAPI.ListImages(ctx, "fake-token", "fake-session", client.ImageListOptions{ImageType: "User", Page: 0, PageSize: 10})
 		if err == nil {
 			t.Errorf("Expected error for invalid page (0), but got none")
 		} else {
@@ -388,7 +388,7 @@ func TestImageListErrorHandling(t *testing.T) {
 		}
 
 		// Test invalid page size
-		_, _, err = apiClient.ImageAPI.ListImages(ctx, "fake-token", "fake-session", "User", 1, 0)
+		_, _, err = apiClient.ImageAPI.ListImages(ctx, "fake-token", "fake-session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 0})
 		if err == nil {
 			t.Errorf("Expected error for invalid page size (0), but got none")
 		} else {
@@ -396,7 +396,7 @@ func TestImageListErrorHandling(t *testing.T) {
 		}
 
 		// Test empty image type
-		_, _, err = apiClient.ImageAPI.ListImages(ctx, "fake-token", "fake-session", "", 1, 10)
+		_, _, err = apiClient.ImageAPI.ListImages(ctx, "fake-token", "fake-session", client.ImageListOptions{ImageType: "", Page: 1, PageSize: 10})
 		if err == nil {
 			t.Errorf("Expected error for empty image type, but got none")
 		} else {
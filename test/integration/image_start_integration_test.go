@@ -92,6 +92,7 @@ func TestImageStartIntegration(t *testing.T) {
 				tt.imageId,
 				tt.cpu,
 				tt.memory,
+				false,
 			)
 
 			// Log request details
@@ -199,6 +200,7 @@ func TestImageStartParameterValidation(t *testing.T) {
 				tt.imageId,
 				1,
 				2,
+				false,
 			)
 
 			if tt.expectError {
@@ -245,7 +247,7 @@ func TestImageStartRealWorkflow(t *testing.T) {
 	t.Run("list_and_start_real_image", func(t *testing.T) {
 		// First, try to list available images
 		t.Log("[SEARCH] Fetching available images...")
-		listResp, _, err := apiClient.ImageAPI.ListImages(ctx, tokens.LoginToken, tokens.SessionId, "User", 1, 5)
+		listResp, _, err := apiClient.ImageAPI.ListImages(ctx, tokens.LoginToken, tokens.SessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 5})
 
 		if err != nil {
 			t.Logf("[WARN]  Could not list images: %v", err)
@@ -276,6 +278,7 @@ func TestImageStartRealWorkflow(t *testing.T) {
 			testImage.ImageID,
 			2, // 2 CPU cores
 			4, // 4 GB memory
+			false,
 		)
 
 		// Log the results regardless of success/failure
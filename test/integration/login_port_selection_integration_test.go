@@ -138,7 +138,7 @@ func TestLoginPortSelectionIntegration(t *testing.T) {
 				finalResponse = response1
 			} else {
 				// Select available port from alternatives
-				selectedPort, err := auth.SelectAvailablePort(defaultPort, response1.Data.AlternativePorts)
+				selectedPort, err := auth.SelectAvailablePort(defaultPort, response1.Data.AlternativePorts, auth.PortPreferences{})
 				require.NoError(t, err)
 
 				// Make second API call with selected port
@@ -223,7 +223,7 @@ func TestPortSelectionWithRealPorts(t *testing.T) {
 
 		// Test port selection with alternatives
 		alternativePorts := "51152,53152,55152,57152"
-		selectedPort, err := auth.SelectAvailablePort(testPort, alternativePorts)
+		selectedPort, err := auth.SelectAvailablePort(testPort, alternativePorts, auth.PortPreferences{})
 		require.NoError(t, err)
 
 		// Should select first alternative since test port is occupied
@@ -0,0 +1,69 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestSuccessFalseResponseIsAPIError(t *testing.T) {
+	server := newErrorCodeServer(t, http.StatusOK, "ImageNotFound")
+	apiClient := newLogsTestClient(server.URL)
+
+	resp, httpResp, err := apiClient.ImageAPI.StartImage(context.Background(), "token", "session", "img-1", 0, 0, false)
+	require.Error(t, err, "an HTTP 200 answer with success=false is a failure")
+	var apiErr *client.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "ImageNotFound", apiErr.Code)
+	assert.Equal(t, "req-1", apiErr.RequestID)
+	assert.Equal(t, http.StatusOK, apiErr.HTTPStatus)
+	assert.Equal(t, http.StatusOK, httpResp.StatusCode)
+
+	// The response is decoded as well
+	assert.False(t, resp.Success)
+	assert.Equal(t, "ImageNotFound", resp.Code)
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	assert.Equal(t, "ImageNotFound", (&client.APIError{Code: "ImageNotFound"}).Error())
+	assert.Equal(t, "ImageNotFound: no such image", (&client.APIError{Code: "ImageNotFound", Message: "no such image"}).Error())
+	assert.Equal(t, "UnknownError", (&client.APIError{}).Error())
+}
+
+func TestErrorCode(t *testing.T) {
+	// Application failures and HTTP errors carry their code the same way
+	for _, status := range []int{http.StatusOK, http.StatusForbidden} {
+		server := newErrorCodeServer(t, status, "INVALID_TOKEN")
+		apiClient := newLogsTestClient(server.URL)
+
+		_, _, err := apiClient.ImageAPI.StopImage(context.Background(), "token", "session", "img-1", client.ImageStopOptions{})
+		code, requestID := client.ErrorCode(err)
+		assert.Equal(t, "INVALID_TOKEN", code, "HTTP %d", status)
+		assert.Equal(t, "req-1", requestID, "HTTP %d", status)
+	}
+
+	code, requestID := client.ErrorCode(errors.New("connection refused"))
+	assert.Empty(t, code)
+	assert.Empty(t, requestID)
+}
+
+func TestCommandReportsRequestIDOfFailedResponse(t *testing.T) {
+	useTempConfigDir(t)
+	server := newErrorCodeServer(t, http.StatusOK, "INVALID_IMAGE_ID")
+	saveTestTokens(t)
+
+	stdout, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "deactivate", []string{"img-1"})
+	require.Error(t, err)
+	assert.Contains(t, stdout+stderr, "[SEARCH] Request ID: req-1")
+	assert.Contains(t, stderr, "[ERROR] Failed to deactivate image: INVALID_IMAGE_ID")
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+func TestAuthCommandTree(t *testing.T) {
+	var names []string
+	for _, sub := range cmd.AuthCmd.Commands() {
+		names = append(names, sub.Name())
+	}
+	assert.ElementsMatch(t, []string{"login", "logout", "refresh", "status", "token", "keepalive", "sessions"}, names)
+
+	// 'auth login' is the same command as the top-level shortcut
+	authLogin := findSubcommand(t, cmd.AuthCmd, "login")
+	assert.NotSame(t, cmd.LoginCmd, authLogin)
+	for _, flag := range []string{"port-range", "with-token", "copy"} {
+		assert.NotNil(t, authLogin.Flags().Lookup(flag), flag)
+		assert.NotNil(t, cmd.LoginCmd.Flags().Lookup(flag), flag)
+	}
+	assert.Equal(t, "core", cmd.LogoutCmd.GroupID)
+	assert.Contains(t, findSubcommand(t, cmd.AuthCmd, "status").Aliases, "whoami")
+}
+
+func TestNewAuthStatus(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := &config.Config{Endpoint: "https://agb.example.com"}
+
+	status := cmd.NewAuthStatus(cfg, now)
+	assert.False(t, status.LoggedIn)
+	assert.Equal(t, "https://agb.example.com", status.Endpoint)
+
+	cfg.Token = &config.Token{LoginToken: "login-token", SessionId: "session-0123456789", KeepAliveToken: "keepalive", ExpiresAt: now.Add(time.Hour)}
+	status = cmd.NewAuthStatus(cfg, now)
+	assert.True(t, status.LoggedIn)
+	assert.Equal(t, "login", status.Source)
+	assert.Equal(t, "2025-03-01T13:00:00Z", status.ExpiresAt)
+	assert.False(t, status.Expired)
+	assert.True(t, status.Renewable)
+	assert.NotContains(t, status.SessionID, "session-0123456789", "the session ID is masked")
+
+	status = cmd.NewAuthStatus(cfg, now.Add(2*time.Hour))
+	assert.True(t, status.Expired)
+}
+
+func TestAuthStatusAndToken(t *testing.T) {
+	useTempConfigDir(t)
+	server, _ := newRenewalServer(t, http.StatusOK)
+
+	stdout, _, err := runSubcommand(t, cmd.AuthCmd, server.URL, "status", nil)
+	require.Error(t, err, "scripts can check the exit code")
+	assert.Contains(t, stdout, "[INFO]  Not logged in")
+	_, _, err = runSubcommand(t, cmd.AuthCmd, server.URL, "token", nil)
+	require.Error(t, err)
+
+	saveTestTokens(t)
+	stdout, _, err = runSubcommand(t, cmd.AuthCmd, server.URL, "status", nil)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Logged in")
+	assert.Contains(t, stdout, "Endpoint:    "+server.URL)
+	assert.NotContains(t, stdout, "session-0")
+
+	stdout, _, err = runSubcommand(t, cmd.AuthCmd, server.URL, "token", nil)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[INFO]  Login token: [REDACTED] (7 characters)")
+	assert.NotContains(t, stdout, "login-0", "the token is masked without --print")
+
+	stdout, _, err = runSubcommand(t, cmd.AuthCmd, server.URL, "token", nil, "--print", "--yes")
+	require.NoError(t, err)
+	assert.Equal(t, "login-0", strings.TrimSpace(stdout), "only the token is printed")
+
+	stdout, _, err = runSubcommand(t, cmd.AuthCmd, server.URL, "refresh", nil)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Session renewed")
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "login-1", cfg.Token.LoginToken)
+
+	// The renewal server rejects every further renewal
+	_, _, err = runSubcommand(t, cmd.AuthCmd, server.URL, "refresh", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "can no longer be renewed")
+}
+
+func TestAuthTokenPrint(t *testing.T) {
+	useTempConfigDir(t)
+	server, calls := newRenewalServer(t, http.StatusOK)
+	saveTestTokens(t)
+
+	// Without a terminal to ask on, --print needs --yes
+	stdin, input, err := os.Pipe()
+	require.NoError(t, err)
+	defer stdin.Close()
+	defer input.Close()
+	originalStdin := os.Stdin
+	os.Stdin = stdin
+	defer func() { os.Stdin = originalStdin }()
+
+	stdout, _, err := runSubcommand(t, cmd.AuthCmd, server.URL, "token", nil, "--print")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "needs confirmation")
+	assert.Contains(t, err.Error(), "--yes")
+	assert.Empty(t, stdout)
+
+	// A session that is about to expire is renewed before the token is printed
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	require.NoError(t, cfg.SaveTokens("login-0", "session-0", "keepalive-0", time.Now().Add(time.Minute).UTC().Format(time.RFC3339)))
+	stdout, _, err = runSubcommand(t, cmd.AuthCmd, server.URL, "token", nil, "--print", "--yes")
+	require.NoError(t, err)
+	assert.Equal(t, "login-1", strings.TrimSpace(stdout))
+	assert.Equal(t, 1, *calls)
+
+	// The renewed session is far from expiry and is printed as it is
+	stdout, _, err = runSubcommand(t, cmd.AuthCmd, server.URL, "token", nil, "--print", "--yes")
+	require.NoError(t, err)
+	assert.Equal(t, "login-1", strings.TrimSpace(stdout))
+	assert.Equal(t, 1, *calls)
+
+	// A rejected renewal prints no token
+	require.NoError(t, cfg.SaveTokens("login-1", "session-1", "keepalive-1", time.Now().Add(time.Minute).UTC().Format(time.RFC3339)))
+	stdout, _, err = runSubcommand(t, cmd.AuthCmd, server.URL, "token", nil, "--print", "--yes")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "can no longer be renewed")
+	assert.Empty(t, stdout)
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+)
+
+func TestAuthSessionsListAndRevoke(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 0)
+	sessions := findSubcommand(t, cmd.AuthCmd, "sessions")
+
+	stdout, _, err := runSubcommand(t, sessions, server.URL, "list", nil)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Found 3 active session(s)")
+	assert.Regexp(t, `\* session-0\s+agbcloud-cli\s`, stdout, "the session of this machine is marked")
+	assert.Regexp(t, `  session-mock-ci\s+agbcloud-cli/linux \(ci-runner-3\)\s+2025-01-15`, stdout)
+
+	stdout, _, err = runSubcommand(t, sessions, server.URL, "revoke", []string{"session-mock-ci"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Session session-mock-ci revoked")
+
+	stdout, _, err = runSubcommand(t, sessions, server.URL, "list", nil)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Found 2 active session(s)")
+	assert.NotContains(t, stdout, "session-mock-ci")
+
+	_, _, err = runSubcommand(t, sessions, server.URL, "revoke", []string{"session-mock-ci"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SessionNotFound")
+
+	// The session of this machine is ended with logout, which also removes the tokens
+	_, _, err = runSubcommand(t, sessions, server.URL, "revoke", []string{"session-0"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "agbcloud logout")
+
+	_, _, err = runSubcommand(t, sessions, server.URL, "revoke", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Expected exactly one session ID")
+}
+
+func TestAuthSessionsUnsupportedServer(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+	sessions := findSubcommand(t, cmd.AuthCmd, "sessions")
+
+	_, _, err := runSubcommand(t, sessions, server.URL, "list", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support listing or revoking login sessions")
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+)
+
+func TestBatchErrorExitCode(t *testing.T) {
+	partial := &cmd.BatchError{Operation: "activate", Total: 3, Errors: []cmd.BatchItemError{{Item: "img-1", Code: "ImageNotFound"}}}
+	assert.Equal(t, "failed to activate 1 of 3 image(s)", partial.Error())
+	assert.False(t, partial.AllFailed())
+	assert.Equal(t, cmd.ExitCodePartialFailure, cmd.ExitCode(partial))
+	assert.Equal(t, cmd.ExitCodePartialFailure, cmd.ExitCode(fmt.Errorf("batch: %w", partial)), "wrapped batch errors are found")
+
+	all := &cmd.BatchError{Operation: "create", Total: 1, Errors: []cmd.BatchItemError{{Item: "web", Code: "Timeout"}}}
+	assert.True(t, all.AllFailed())
+	assert.Equal(t, 1, cmd.ExitCode(all))
+	assert.Equal(t, 1, cmd.ExitCode(errors.New("not authenticated")))
+}
+
+func TestImageBatchCommandReportsFailures(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 1, "IMAGE_AVAILABLE")
+	refs := []string{"img-mock0001", "img-missing"}
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "deactivate", refs)
+	require.Error(t, err)
+	assert.Equal(t, cmd.ExitCodePartialFailure, cmd.ExitCode(err))
+	assert.Contains(t, stdout, "[ERROR] Failed to deactivate 1 of 2 image(s):")
+	assert.Contains(t, stdout, "REQUEST ID")
+	assert.Contains(t, stdout, "ImageNotFound")
+	assert.Contains(t, stdout, "[DATA] Summary: 0 deactivated, 1 unchanged, 1 failed")
+
+	stdout, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "deactivate", refs, "-o", "json")
+	require.Error(t, err)
+	var results []cmd.ImageOperationResult
+	decodeSingleJSONDocument(t, stdout, &results)
+	require.Len(t, results, 2)
+	assert.Equal(t, "unchanged", results[0].Result)
+	assert.Empty(t, results[0].Code)
+	assert.Equal(t, "failed", results[1].Result)
+	assert.Equal(t, "ImageNotFound", results[1].Code)
+	assert.Contains(t, stderr, "[DATA] Summary:", "progress stays off stdout")
+
+	// Every image failed
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "deactivate", []string{"img-missing", "img-gone"})
+	require.Error(t, err)
+	assert.Equal(t, 1, cmd.ExitCode(err))
+}
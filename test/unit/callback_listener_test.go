@@ -0,0 +1,151 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/internal/auth"
+)
+
+// useTempConfigDir points the CLI config directory at a temporary directory for the test
+func useTempConfigDir(t *testing.T) string {
+	dir := t.TempDir()
+	t.Setenv("AGB_CLI_CONFIG_DIR", dir)
+	return dir
+}
+
+func TestCallbackServerTracksListener(t *testing.T) {
+	useTempConfigDir(t)
+	port := "3004"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := auth.StartCallbackServer(ctx, port)
+		errChan <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+
+	// While running, the listener is recorded with our PID
+	records, err := auth.ListListenerRecords()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, port, records[0].Port)
+	assert.Equal(t, os.Getpid(), records[0].PID)
+
+	resp, err := http.Get(fmt.Sprintf("http://localhost:%s/callback?code=test-code", port))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	select {
+	case err := <-errChan:
+		require.NoError(t, err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("Timeout waiting for callback server to return")
+	}
+
+	// After returning, the record is removed and the port is released
+	records, err = auth.ListListenerRecords()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	assert.False(t, auth.IsPortOccupied(port), "port should be released after the server returns")
+}
+
+func TestCallbackServerIdleTimeout(t *testing.T) {
+	useTempConfigDir(t)
+	port := "3005"
+
+	original := auth.CallbackIdleTimeout
+	auth.CallbackIdleTimeout = 200 * time.Millisecond
+	defer func() { auth.CallbackIdleTimeout = original }()
+
+	// The caller's context never expires; the idle timeout must still stop the server
+	start := time.Now()
+	_, err := auth.StartCallbackServer(context.Background(), port)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "callback timeout")
+	assert.Less(t, time.Since(start), 3*time.Second)
+	assert.False(t, auth.IsPortOccupied(port), "port should be released after idle timeout")
+}
+
+func TestCallbackServerCancelReleasesPort(t *testing.T) {
+	useTempConfigDir(t)
+	port := "3006"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := auth.StartCallbackServer(ctx, port)
+		errChan <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	assert.True(t, auth.IsPortOccupied(port))
+
+	cancel()
+	select {
+	case err := <-errChan:
+		assert.Error(t, err)
+	case <-time.After(3 * time.Second):
+		t.Fatal("Timeout waiting for callback server to stop")
+	}
+	assert.False(t, auth.IsPortOccupied(port), "port should be released after cancellation")
+}
+
+func TestCallbackServerPortInUse(t *testing.T) {
+	useTempConfigDir(t)
+	port := "3007"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _, _ = auth.StartCallbackServer(ctx, port) }()
+	time.Sleep(100 * time.Millisecond)
+
+	// A second server on the same port fails immediately instead of waiting for a timeout
+	_, err := auth.StartCallbackServer(context.Background(), port)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to start callback server")
+}
+
+func TestCheckCallbackListenersStaleRecord(t *testing.T) {
+	dir := useTempConfigDir(t)
+
+	// Simulate a record left behind by a crashed login
+	record := auth.ListenerRecord{Port: "3008", PID: 0x7ffffff0, StartedAt: time.Now().Add(-time.Hour)}
+	content, err := json.Marshal(record)
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "listeners"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "listeners", "3008.json"), content, 0600))
+
+	statuses, err := auth.CheckCallbackListeners()
+	require.NoError(t, err)
+
+	var found *auth.ListenerStatus
+	for i := range statuses {
+		if statuses[i].Port == "3008" {
+			found = &statuses[i]
+		}
+	}
+	require.NotNil(t, found, "recorded port should be checked")
+	assert.True(t, found.Stale())
+	assert.False(t, found.Orphaned())
+	assert.Equal(t, auth.GetCallbackPort(), statuses[0].Port, "default port is always checked first")
+
+	require.NoError(t, auth.RemoveListenerRecord("3008"))
+	records, err := auth.ListListenerRecords()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
@@ -0,0 +1,138 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestCircuitBreakerStates(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	breaker := client.NewCircuitBreaker()
+	breaker.Threshold = 3
+	breaker.Now = func() time.Time { return now }
+
+	// Failures spread wider than the window do not open the circuit
+	breaker.Record("api", http.StatusBadGateway, nil)
+	now = now.Add(2 * time.Minute)
+	breaker.Record("api", http.StatusBadGateway, nil)
+	breaker.Record("api", http.StatusBadGateway, nil)
+	require.NoError(t, breaker.Allow("api"))
+
+	// A successful response resets the count
+	breaker.Record("api", http.StatusOK, nil)
+	breaker.Record("api", http.StatusInternalServerError, nil)
+	breaker.Record("api", http.StatusInternalServerError, nil)
+	require.NoError(t, breaker.Allow("api"))
+
+	breaker.Record("api", http.StatusServiceUnavailable, nil)
+	err := breaker.Allow("api")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, client.ErrCircuitOpen))
+	var open *client.CircuitOpenError
+	require.True(t, errors.As(err, &open))
+	assert.Equal(t, 3, open.Failures)
+	assert.Equal(t, 30*time.Second, open.RetryAfter)
+	assert.NoError(t, breaker.Allow("other"), "circuits are tracked per host")
+
+	// After the cooldown a single trial request is let through
+	now = now.Add(30 * time.Second)
+	require.NoError(t, breaker.Allow("api"))
+	assert.Error(t, breaker.Allow("api"), "only one trial request at a time")
+
+	// A failed trial opens the circuit again
+	breaker.Record("api", http.StatusBadGateway, nil)
+	assert.Error(t, breaker.Allow("api"))
+
+	now = now.Add(30 * time.Second)
+	require.NoError(t, breaker.Allow("api"))
+	breaker.Record("api", http.StatusNotFound, nil)
+	assert.NoError(t, breaker.Allow("api"), "any non-5xx answer closes the circuit")
+	assert.NoError(t, breaker.Allow("api"))
+}
+
+func TestRetryClientFailsFastWhenCircuitOpen(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	retryClient := client.NewRetryableHTTPClient(nil, &client.RetryConfig{
+		MaxRetries:    3,
+		InitialDelay:  time.Millisecond,
+		MaxDelay:      time.Millisecond,
+		BackoffFactor: 1,
+	})
+	breaker := client.NewCircuitBreaker()
+	breaker.Threshold = 6
+	retryClient.SetCircuitBreaker(breaker)
+
+	request := func() error {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := retryClient.Do(req)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	err := request()
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, client.ErrCircuitOpen))
+	assert.Equal(t, int32(4), requests.Load())
+
+	// The second request stops retrying once the threshold is reached
+	err = request()
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, client.ErrCircuitOpen))
+	assert.Equal(t, int32(6), requests.Load())
+
+	// Further requests do not reach the server at all
+	err = request()
+	assert.True(t, errors.Is(err, client.ErrCircuitOpen))
+	assert.Equal(t, int32(6), requests.Load())
+	assert.Contains(t, err.Error(), "after 6 consecutive server errors")
+}
+
+func TestCircuitBreakerCanBeDisabled(t *testing.T) {
+	t.Setenv("AGB_CLI_CIRCUIT_BREAKER", "off")
+	assert.Nil(t, client.DefaultCircuitBreaker())
+
+	t.Setenv("AGB_CLI_CIRCUIT_BREAKER", "")
+	assert.NotNil(t, client.DefaultCircuitBreaker())
+}
+
+func TestNetworkErrorExplainsOpenCircuit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	useTempConfigDir(t)
+	saveTestTokens(t)
+
+	// Each run retries four times, so the second one trips the process-wide breaker
+	var stderr string
+	for i := 0; i < 3 && !strings.Contains(stderr, "failing repeatedly"); i++ {
+		_, stderr, _ = runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil)
+	}
+	assert.Contains(t, stderr, "[ERROR] The AgbCloud API at "+strings.TrimPrefix(server.URL, "http://")+" is failing repeatedly")
+	assert.Contains(t, stderr, "[TIP] Set AGB_CLI_CIRCUIT_BREAKER=off")
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/clipboard"
+)
+
+// useFileClipboard makes the clipboard write to a file and returns its path
+func useFileClipboard(t *testing.T) string {
+	if _, err := exec.LookPath("tee"); err != nil {
+		t.Skip("tee is not available")
+	}
+	path := filepath.Join(t.TempDir(), "clipboard")
+	t.Setenv(clipboard.EnvCommand, "tee "+path)
+	return path
+}
+
+func TestClipboardCopyUsesConfiguredCommand(t *testing.T) {
+	path := useFileClipboard(t)
+	require.NoError(t, clipboard.Copy("img-7a8b9c1d0e"))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "img-7a8b9c1d0e", string(content))
+	assert.True(t, clipboard.Available())
+}
+
+func TestClipboardUnavailableIsReported(t *testing.T) {
+	t.Setenv(clipboard.EnvCommand, "off")
+	assert.True(t, errors.Is(clipboard.Copy("x"), clipboard.ErrUnavailable))
+	assert.False(t, clipboard.Available())
+
+	if runtime.GOOS == "linux" {
+		// Without a display server there is no clipboard
+		t.Setenv(clipboard.EnvCommand, "")
+		t.Setenv("DISPLAY", "")
+		t.Setenv("WAYLAND_DISPLAY", "")
+		assert.True(t, errors.Is(clipboard.Copy("x"), clipboard.ErrUnavailable))
+	}
+
+	t.Setenv(clipboard.EnvCommand, "agb-no-such-clipboard-program")
+	assert.True(t, errors.Is(clipboard.Copy("x"), clipboard.ErrUnavailable))
+}
+
+func TestImageCreateCopiesIDs(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 0)
+	dockerfile := filepath.Join(t.TempDir(), "Dockerfile")
+	require.NoError(t, os.WriteFile(dockerfile, []byte("FROM agb-code-space-1\n"), 0644))
+	path := useFileClipboard(t)
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "create", []string{"copied"}, "-f", dockerfile, "-i", "agb-code-space-1", "--force", "--force-new", "--detach", "--copy")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[COPY] Copied the task ID to the clipboard")
+	jobs, err := cmd.LoadJobs()
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, jobs[0].TaskID, string(content))
+
+	// Without a clipboard the value is only printed
+	t.Setenv(clipboard.EnvCommand, "off")
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "create", []string{"headless"}, "-f", dockerfile, "-i", "agb-code-space-1", "--force", "--force-new", "--detach", "--copy")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[NOTE] No clipboard is available, so the task ID was not copied")
+}
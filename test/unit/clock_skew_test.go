@@ -0,0 +1,89 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// newSkewedServer answers every request with a Date header offset from the local clock
+func newSkewedServer(offset time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(offset).UTC().Format(http.TimeFormat))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success": true, "data": {"images": [], "total": 0}}`)) // Ignore errors in test mock server
+	}))
+}
+
+func TestClockSkewFromResponse(t *testing.T) {
+	sent := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+	received := sent.Add(2 * time.Second)
+
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Date", sent.Add(-5*time.Minute).Format(http.TimeFormat))
+	skew, ok := client.ClockSkewFromResponse(resp, sent, received)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Minute+time.Second, skew, "the server time is compared with the midpoint of the request")
+
+	resp.Header.Set("Date", "not a date")
+	_, ok = client.ClockSkewFromResponse(resp, sent, received)
+	assert.False(t, ok)
+
+	_, ok = client.ClockSkewFromResponse(nil, sent, received)
+	assert.False(t, ok)
+}
+
+func TestDescribeClockSkew(t *testing.T) {
+	assert.Equal(t, "5m0s ahead of", client.DescribeClockSkew(5*time.Minute))
+	assert.Equal(t, "30s behind", client.DescribeClockSkew(-30*time.Second))
+	assert.True(t, client.ClockSkewExceeds(-3*time.Minute, client.DefaultClockSkewThreshold))
+	assert.False(t, client.ClockSkewExceeds(90*time.Second, client.DefaultClockSkewThreshold))
+}
+
+func TestAPIClientRecordsClockSkew(t *testing.T) {
+	server := newSkewedServer(10 * time.Minute)
+	defer server.Close()
+
+	cfg := client.NewConfiguration()
+	cfg.Servers[0].URL = server.URL
+	apiClient := client.NewAPIClient(cfg)
+
+	_, ok := apiClient.ClockSkew()
+	assert.False(t, ok, "unknown before the first response")
+
+	_, _, err := apiClient.ImageAPI.ListImages(context.Background(), "test-login-token", "test-session-id",
+		client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+
+	skew, ok := apiClient.ClockSkew()
+	require.True(t, ok)
+	assert.InDelta(t, float64(-10*time.Minute), float64(skew), float64(2*time.Second), "local clock is behind the server")
+
+	statuses := apiClient.ProbeServers(context.Background(), time.Second)
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].ClockSkewKnown)
+	assert.True(t, client.ClockSkewExceeds(statuses[0].ClockSkew, client.DefaultClockSkewThreshold))
+}
+
+func TestUploadErrorMentionsClockSkew(t *testing.T) {
+	err := &cmd.UploadError{StatusCode: http.StatusForbidden, Body: "<Code>SignatureDoesNotMatch</Code>"}
+	assert.NotContains(t, err.Error(), "clock")
+
+	err.ClockSkew, err.ClockSkewKnown = 20*time.Second, true
+	assert.NotContains(t, err.Error(), "clock", "small offsets are not reported")
+
+	err.ClockSkew = 15 * time.Minute
+	assert.Contains(t, err.Error(), "local clock is 15m0s ahead of the storage service")
+	assert.True(t, cmd.IsUploadCredentialExpiredError(err))
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+func TestConfigSaveIsPrivate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows access is controlled by ACLs")
+	}
+	dir := filepath.Join(useTempConfigDir(t), "nested")
+	t.Setenv("AGB_CLI_CONFIG_DIR", dir)
+
+	require.NoError(t, (&config.Config{Endpoint: "agb.example.com"}).Save())
+
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+	info, err = os.Stat(filepath.Join(dir, "config.json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	issues, err := config.CheckPermissions()
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestCheckAndFixPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows access is controlled by ACLs")
+	}
+	dir := useTempConfigDir(t)
+	require.NoError(t, os.Chmod(dir, 0755))
+	configFile := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(configFile, []byte(`{"endpoint": "agb.example.com"}`), 0644))
+	cacheFile := filepath.Join(dir, "cache", "images.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(cacheFile), 0700))
+	require.NoError(t, os.WriteFile(cacheFile, []byte(`{}`), 0400))
+
+	issues, err := config.CheckPermissions()
+	require.NoError(t, err)
+	require.Len(t, issues, 2, "read-only and private files are fine")
+	assert.Equal(t, dir, issues[0].Path)
+	assert.True(t, issues[0].Dir)
+	assert.Equal(t, "mode 0755, want 0700", issues[0].Detail)
+	assert.Equal(t, configFile+" is accessible by other users (mode 0644, want 0600)", issues[1].String())
+
+	require.NoError(t, config.FixPermissions(issues))
+	issues, err = config.CheckPermissions()
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+	info, err := os.Stat(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestCheckPermissionsWithoutConfigDir(t *testing.T) {
+	t.Setenv("AGB_CLI_CONFIG_DIR", filepath.Join(t.TempDir(), "missing"))
+	issues, err := config.CheckPermissions()
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestLoadWarnsAboutOpenConfigFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows access is controlled by ACLs")
+	}
+	dir := useTempConfigDir(t)
+	require.NoError(t, os.Chmod(dir, 0700))
+	configFile := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(configFile, []byte(`{"endpoint": "agb.example.com"}`), 0644))
+	logs := captureDebugLog(t)
+
+	_, err := config.GetConfig()
+	require.NoError(t, err)
+	_, err = config.GetConfig()
+	require.NoError(t, err)
+
+	assert.Contains(t, logs.String(), configFile+" is accessible by other users (mode 0644, want 0600)")
+	assert.Contains(t, logs.String(), "agbcloud doctor --fix")
+	assert.Equal(t, 1, bytes.Count(logs.Bytes(), []byte("accessible by other users")), "the warning is shown once")
+}
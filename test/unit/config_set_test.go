@@ -0,0 +1,179 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+func TestSetConfigValue(t *testing.T) {
+	cfg := &config.Config{}
+
+	require.NoError(t, cmd.SetConfigValue(cfg, "", "defaults.imageType", "system"))
+	require.NoError(t, cmd.SetConfigValue(cfg, "", "defaults.pageSize", "50"))
+	assert.Equal(t, &config.ListDefaults{ImageType: "System", PageSize: 50}, cfg.Defaults)
+
+	require.NoError(t, cmd.SetConfigValue(cfg, "sre", "defaults.pageSize", "100"))
+	assert.Equal(t, &config.ListDefaults{PageSize: 100}, cfg.Profiles["sre"].Defaults, "missing profiles are created")
+
+	// An empty value removes the setting, and empty defaults are dropped
+	require.NoError(t, cmd.SetConfigValue(cfg, "sre", "defaults.pageSize", ""))
+	assert.Nil(t, cfg.Profiles["sre"].Defaults)
+
+	for _, tt := range []struct{ key, value string }{
+		{"defaults.imageType", "Custom"},
+		{"defaults.pageSize", "0"},
+		{"defaults.pageSize", "101"},
+		{"defaults.pageSize", "many"},
+		{"defaults.output", "json"},
+	} {
+		assert.Error(t, cmd.SetConfigValue(cfg, "", tt.key, tt.value), tt.key+"="+tt.value)
+	}
+	assert.Equal(t, &config.ListDefaults{ImageType: "System", PageSize: 50}, cfg.Defaults, "invalid values change nothing")
+}
+
+func TestEffectiveListDefaults(t *testing.T) {
+	t.Setenv("AGB_CLI_PROFILE", "")
+	cfg := &config.Config{
+		Defaults: &config.ListDefaults{ImageType: "User", PageSize: 20},
+		Profiles: map[string]config.Profile{"sre": {Defaults: &config.ListDefaults{ImageType: "System"}}},
+	}
+	assert.Equal(t, config.ListDefaults{ImageType: "User", PageSize: 20}, cfg.EffectiveListDefaults())
+
+	cfg.ActiveProfile = "sre"
+	assert.Equal(t, config.ListDefaults{ImageType: "System", PageSize: 20}, cfg.EffectiveListDefaults(), "profile values override one by one")
+}
+
+func TestConfigSetCommandStoresInActiveProfile(t *testing.T) {
+	useTempConfigDir(t)
+	t.Setenv("AGB_CLI_PROFILE", "sre")
+
+	out, _, err := runSubcommand(t, cmd.ConfigCmd, "", "set", []string{"defaults.imageType", "System"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "[OK] Set defaults.imageType to System for profile 'sre'")
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.Nil(t, cfg.Defaults)
+	assert.Equal(t, "System", cfg.Profiles["sre"].Defaults.ImageType)
+
+	_, stderr, err := runSubcommand(t, cmd.ConfigCmd, "", "set", []string{"defaults.pageSize", "1000"})
+	assert.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] invalid page size '1000', expected a number from 1 to 100")
+}
+
+func TestImageListUsesConfiguredDefaults(t *testing.T) {
+	var queries []url.Values
+	server := newSearchImageServer(t, &queries)
+	defer server.Close()
+
+	useTempConfigDir(t)
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+	t.Setenv("AGB_CLI_PROFILE", "")
+	saveTestTokens(t)
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cfg.Defaults = &config.ListDefaults{ImageType: "System", PageSize: 50}
+	require.NoError(t, cfg.Save())
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil)
+	require.NoError(t, err)
+
+	// Flags that are given win over the defaults
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--type", "User")
+	require.NoError(t, err)
+
+	require.Len(t, queries, 2)
+	assert.Equal(t, "System", queries[0].Get("imageType"))
+	assert.Equal(t, "50", queries[0].Get("pageSize"))
+	assert.Equal(t, "User", queries[1].Get("imageType"))
+	assert.Equal(t, "50", queries[1].Get("pageSize"))
+}
+
+func TestValidateSharedConfigListDefaults(t *testing.T) {
+	assert.NoError(t, cmd.ValidateSharedConfig(config.SharedConfig{Defaults: &config.ListDefaults{ImageType: "System", PageSize: 50}}))
+	assert.Error(t, cmd.ValidateSharedConfig(config.SharedConfig{Defaults: &config.ListDefaults{ImageType: "Shared"}}))
+	assert.Error(t, cmd.ValidateSharedConfig(config.SharedConfig{Profiles: map[string]config.Profile{"sre": {Defaults: &config.ListDefaults{PageSize: 500}}}}))
+}
+
+func TestMergePatch(t *testing.T) {
+	doc := []byte(`{"output": "table", "defaults": {"imageType": "System", "pageSize": 20}, "fallbackEndpoints": ["a.example"]}`)
+	patched, err := config.MergePatch(doc, []byte(`{"output": null, "defaults": {"pageSize": 50}, "fallbackEndpoints": ["b.example"], "endpointStrategy": "round-robin"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"defaults": {"imageType": "System", "pageSize": 50}, "fallbackEndpoints": ["b.example"], "endpointStrategy": "round-robin"}`, string(patched),
+		"objects are merged, null removes keys and arrays are replaced")
+
+	patched, err = config.MergePatch(nil, []byte(`{"profiles": {"sre": {"endpoint": "sre.example", "output": null}}}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"profiles": {"sre": {"endpoint": "sre.example"}}}`, string(patched))
+
+	for _, patch := range []string{`[1]`, `"text"`, `{"output": }`, `{} {}`} {
+		_, err := config.MergePatch(doc, []byte(patch))
+		assert.Error(t, err, patch)
+	}
+
+	set, removed, err := config.PatchPaths([]byte(`{"output": null, "defaults": {"pageSize": 50, "imageType": "User"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"defaults.imageType", "defaults.pageSize"}, set)
+	assert.Equal(t, []string{"output"}, removed)
+}
+
+func TestPatchConfigDocument(t *testing.T) {
+	doc := []byte(`{"token": {"loginToken": "l", "sessionId": "s"}, "endpoint": "broken endpoint"}`)
+
+	// A patch is only rejected for problems it adds
+	patched, issues, err := cmd.PatchConfigDocument(doc, []byte(`{"output": "json"}`), "")
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+	assert.Contains(t, string(patched), `"loginToken": "l"`, "tokens are kept")
+
+	_, issues, err = cmd.PatchConfigDocument(doc, []byte(`{"defaults": {"output": "json"}}`), "")
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "defaults.output", issues[0].Path, "unknown keys are rejected")
+
+	_, issues, err = cmd.PatchConfigDocument(doc, []byte(`{"endpoint": "ftp://x"}`), "sre")
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "profiles.sre.endpoint", issues[0].Path, "the patch applies to the profile")
+
+	_, _, err = cmd.PatchConfigDocument(doc, []byte(`{"Token": null}`), "")
+	assert.Error(t, err, "tokens cannot be patched")
+}
+
+func TestConfigSetJSONCommand(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+
+	out, _, err := runSubcommand(t, cmd.ConfigCmd, "", "set", nil, "--json", `{"output": "json", "defaults": {"pageSize": 50}}`)
+	require.NoError(t, err)
+	assert.Contains(t, out, "[OK] Set defaults.pageSize")
+	assert.Contains(t, out, "[OK] Set output")
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "json", cfg.Output)
+	assert.Equal(t, 50, cfg.Defaults.PageSize)
+	require.NotNil(t, cfg.Token, "tokens are kept")
+
+	// An invalid result leaves the file unchanged
+	out, stderr, err := runSubcommand(t, cmd.ConfigCmd, "", "set", nil, "--json", `{"output": null, "defaults": {"pageSize": 1000}}`)
+	require.Error(t, err)
+	assert.Contains(t, out, "defaults.pageSize: must be from 1 to 100")
+	assert.Contains(t, stderr, "[ERROR] The patch was not applied")
+	cfg, err = config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "json", cfg.Output)
+
+	_, stderr, err = runSubcommand(t, cmd.ConfigCmd, "", "set", []string{"output", "json"}, "--json", `{"output": "table"}`)
+	assert.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] Give either <key> <value> or --json, not both")
+}
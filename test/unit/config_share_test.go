@@ -0,0 +1,235 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+func sampleSharedConfig() *config.Config {
+	return &config.Config{
+		Token: &config.Token{
+			LoginToken:     "secret-login-token",
+			SessionId:      "secret-session-id",
+			KeepAliveToken: "secret-keep-alive",
+		},
+		Endpoint:      "agb.example.com",
+		Output:        "json",
+		ActiveProfile: "staging",
+		Profiles: map[string]config.Profile{
+			"staging": {Endpoint: "staging.agb.example.com"},
+			"prod":    {Endpoint: "agb.example.com", Output: "table"},
+		},
+		ImageGC:          &config.ImageGCPolicy{KeepLast: 3, KeepActivated: true, OlderThan: "30d"},
+		CleanupOnFailure: new(bool), // An explicit false must survive a round trip
+	}
+}
+
+// findSubcommand returns the named subcommand of parent
+func findSubcommand(t *testing.T, parent *cobra.Command, name string) *cobra.Command {
+	for _, sub := range parent.Commands() {
+		if sub.Name() == name {
+			return sub
+		}
+	}
+	t.Fatalf("subcommand %q not found", name)
+	return nil
+}
+
+// testRootCmd stands in for the agb root command; the commands under test hang off it
+// so that they inherit its global flags such as --output
+var testRootCmd = &cobra.Command{Use: "agb"}
+
+func init() {
+	cmd.AddGlobalFlags(testRootCmd)
+	testRootCmd.PersistentPreRunE = func(command *cobra.Command, args []string) error {
+		return cmd.ApplyGlobalFlags(command)
+	}
+	testRootCmd.AddCommand(cmd.AuthCmd, cmd.ConfigCmd, cmd.DevCmd, cmd.ImageCmd, cmd.JobsCmd, cmd.ReleaseCmd, cmd.SelftestCmd, cmd.ServiceAccountCmd, cmd.SSHKeyCmd)
+}
+
+// resetFlags restores the default value of every flag set on c
+func resetFlags(c *cobra.Command) {
+	c.Flags().Visit(func(f *pflag.Flag) {
+		// Setting a repeatable flag appends to it, so its values are replaced instead
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			_ = slice.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
+
+// runSubcommand runs the named subcommand of parent against endpoint and returns its stdout
+// and stderr; the global flags are applied first, as the agb root command does
+func runSubcommand(t *testing.T, parent *cobra.Command, endpoint, name string, args []string, flags ...string) (string, string, error) {
+	t.Setenv("AGB_CLI_ENDPOINT", endpoint)
+	subcommand := findSubcommand(t, parent, name)
+	resetFlags(subcommand)
+	t.Cleanup(func() { resetFlags(subcommand) })
+	require.NoError(t, subcommand.ParseFlags(flags))
+
+	var runErr error
+	var stdout string
+	stderr := captureStderr(func() {
+		stdout = captureStdout(func() {
+			if runErr = subcommand.ValidateArgs(args); runErr == nil {
+				runErr = testRootCmd.PersistentPreRunE(subcommand, args)
+			}
+			if runErr == nil {
+				runErr = subcommand.RunE(subcommand, args)
+			}
+			cmd.FlushOutput()
+		})
+	})
+	return stdout, stderr, runErr
+}
+
+func TestConfigExportExcludesTokens(t *testing.T) {
+	cfg := sampleSharedConfig()
+
+	for _, format := range []string{"yaml", "json"} {
+		data, err := config.MarshalShared(cfg.Export(), format)
+		require.NoError(t, err, format)
+
+		assert.NotContains(t, string(data), "secret-", format)
+		assert.NotContains(t, string(data), "token", format)
+		assert.Contains(t, string(data), "staging.agb.example.com", format)
+
+		// Round trip through the parser used by import
+		shared, err := config.ParseShared(data)
+		require.NoError(t, err, format)
+		assert.Equal(t, cfg.Export(), shared, format)
+	}
+
+	_, err := config.MarshalShared(cfg.Export(), "xml")
+	assert.Error(t, err)
+}
+
+func TestConfigParseSharedRejectsInvalidFiles(t *testing.T) {
+	_, err := config.ParseShared([]byte("endpoint: agb.example.com\ntoken:\n  loginToken: abc\n"))
+	assert.ErrorIs(t, err, config.ErrSecretsInSharedConfig)
+
+	_, err = config.ParseShared([]byte(`{"profiles": {"dev": {"sessionId": "abc"}}}`))
+	assert.ErrorIs(t, err, config.ErrSecretsInSharedConfig, "credentials are detected at any depth")
+
+	_, err = config.ParseShared([]byte("endpont: agb.example.com\n"))
+	assert.Error(t, err, "unknown keys are rejected")
+
+	shared, err := config.ParseShared([]byte(""))
+	assert.NoError(t, err, "an empty file is a valid (empty) configuration")
+	assert.Equal(t, config.SharedConfig{}, shared)
+}
+
+func TestConfigImportModes(t *testing.T) {
+	incoming := config.SharedConfig{
+		Output: "csv",
+		Profiles: map[string]config.Profile{
+			"staging": {Output: "pson"},
+			"dev":     {Endpoint: "localhost:8080"},
+		},
+	}
+
+	t.Run("Merge", func(t *testing.T) {
+		cfg := sampleSharedConfig()
+		cfg.Import(incoming, config.ImportMerge)
+
+		assert.Equal(t, "agb.example.com", cfg.Endpoint, "settings missing from the file are kept")
+		assert.Equal(t, "csv", cfg.Output)
+		assert.Equal(t, "staging", cfg.ActiveProfile)
+		assert.Equal(t, config.Profile{Endpoint: "staging.agb.example.com", Output: "pson"}, cfg.Profiles["staging"])
+		assert.Equal(t, config.Profile{Endpoint: "localhost:8080"}, cfg.Profiles["dev"])
+		assert.Contains(t, cfg.Profiles, "prod")
+		require.NotNil(t, cfg.ImageGC)
+		assert.Equal(t, "secret-login-token", cfg.Token.LoginToken)
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		cfg := sampleSharedConfig()
+		cfg.Import(incoming, config.ImportOverwrite)
+
+		assert.Empty(t, cfg.Endpoint)
+		assert.Equal(t, "csv", cfg.Output)
+		assert.Empty(t, cfg.ActiveProfile)
+		assert.Equal(t, map[string]config.Profile{
+			"staging": {Output: "pson"},
+			"dev":     {Endpoint: "localhost:8080"},
+		}, cfg.Profiles)
+		assert.Nil(t, cfg.ImageGC)
+		assert.Nil(t, cfg.CleanupOnFailure)
+		assert.Equal(t, "secret-login-token", cfg.Token.LoginToken, "tokens survive an overwrite")
+	})
+}
+
+func TestValidateSharedConfig(t *testing.T) {
+	assert.NoError(t, cmd.ValidateSharedConfig(sampleSharedConfig().Export()))
+
+	tests := []struct {
+		name   string
+		shared config.SharedConfig
+	}{
+		{"output format", config.SharedConfig{Output: "xml"}},
+		{"endpoint scheme", config.SharedConfig{Endpoint: "ftp://agb.example.com"}},
+		{"missing active profile", config.SharedConfig{ActiveProfile: "nope"}},
+		{"profile name", config.SharedConfig{Profiles: map[string]config.Profile{"bad name": {}}}},
+		{"profile output", config.SharedConfig{Profiles: map[string]config.Profile{"dev": {Output: "yaml"}}}},
+		{"gc age", config.SharedConfig{ImageGC: &config.ImageGCPolicy{OlderThan: "soon"}}},
+		{"upload backend", config.SharedConfig{UploadStorage: &config.UploadStorage{Backend: "gcs"}}},
+		{"upload endpoint", config.SharedConfig{UploadStorage: &config.UploadStorage{Endpoint: "ftp://oss.internal"}}},
+		{"otlp endpoint", config.SharedConfig{OTLPEndpoint: "localhost:4318"}},
+	}
+	for _, tt := range tests {
+		assert.Error(t, cmd.ValidateSharedConfig(tt.shared), tt.name)
+	}
+}
+
+func TestConfigImportCommand(t *testing.T) {
+	dir := useTempConfigDir(t)
+	t.Setenv("AGB_CLI_ENDPOINT", "")
+	t.Setenv("AGB_CLI_PROFILE", "")
+
+	cfg := &config.Config{}
+	require.NoError(t, cfg.SaveTokens("test-login-token", "test-session-id", "test-keep-alive", ""))
+
+	file := filepath.Join(dir, "team.yaml")
+	data, err := config.MarshalShared(sampleSharedConfig().Export(), "yaml")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, data, 0644))
+
+	importCmd := findSubcommand(t, cmd.ConfigCmd, "import")
+	require.NoError(t, importCmd.RunE(importCmd, []string{file}))
+
+	saved, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "test-login-token", saved.Token.LoginToken, "import keeps the local tokens")
+	assert.Equal(t, "staging", saved.ActiveProfile)
+
+	// The active profile selects the endpoint; AGB_CLI_PROFILE and AGB_CLI_ENDPOINT override it
+	assert.Equal(t, "https://staging.agb.example.com", config.GetEndpoint())
+	t.Setenv("AGB_CLI_PROFILE", "prod")
+	assert.Equal(t, "https://agb.example.com", config.GetEndpoint())
+	assert.Equal(t, "table", saved.EffectiveOutput())
+	t.Setenv("AGB_CLI_ENDPOINT", "http://localhost:9999")
+	assert.Equal(t, "http://localhost:9999", config.GetEndpoint())
+
+	// A file with credentials is refused and leaves the configuration untouched
+	require.NoError(t, os.WriteFile(file, []byte("output: csv\ntoken:\n  loginToken: stolen\n"), 0644))
+	captureStderr(func() {
+		assert.Error(t, importCmd.RunE(importCmd, []string{file}))
+	})
+	saved, err = config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "json", saved.Output)
+}
@@ -7,6 +7,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/agbcloud/agbcloud-cli/internal/config"
 )
 
@@ -126,3 +129,21 @@ func TestConfigTokenOperations(t *testing.T) {
 
 	t.Logf("[OK] Token operations test passed")
 }
+
+func TestRememberLoginPort(t *testing.T) {
+	cfg := &config.Config{}
+	for _, port := range []string{"3000", "51152", "40001", "51152", "40002"} {
+		cfg.RememberLoginPort(port)
+	}
+	assert.Equal(t, []string{"40002", "51152", "40001"}, cfg.LoginPorts, "most recent first, without duplicates, capped")
+}
+
+func TestGetLoginPortRange(t *testing.T) {
+	useTempConfigDir(t)
+	cfg := &config.Config{LoginPortRange: "40000-40100"}
+	require.NoError(t, cfg.Save())
+	assert.Equal(t, "40000-40100", config.GetLoginPortRange())
+
+	t.Setenv("AGB_CLI_LOGIN_PORT_RANGE", "50000-50010")
+	assert.Equal(t, "50000-50010", config.GetLoginPortRange(), "the environment overrides the config")
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+func TestValidateConfigData(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		data := `{
+  "token": {"loginToken": "a", "sessionId": "b", "keepAliveToken": "c", "expiresAt": "2025-09-30T10:00:00Z"},
+  "endpoint": "agb.cloud",
+  "profiles": {"dev": {"endpoint": "http://localhost:8080"}},
+  "imageGC": {"keepLast": 3, "keepActivated": true},
+  "cleanupOnFailure": null
+}`
+		assert.Empty(t, config.ValidateConfigData([]byte(data)))
+		assert.Empty(t, config.ValidateConfigData(nil), "an empty file is valid")
+	})
+
+	t.Run("Syntax", func(t *testing.T) {
+		issues := config.ValidateConfigData([]byte("{\n  \"endpoint\": \"agb.cloud\",\n  \"output\": json\n}"))
+		require.Len(t, issues, 1)
+		assert.Equal(t, config.SeverityError, issues[0].Severity)
+		assert.Equal(t, config.Position{Line: 3, Column: 14}, issues[0].Position)
+		assert.Contains(t, issues[0].Message, "invalid JSON")
+
+		issues = config.ValidateConfigData([]byte("{\n  \"endpoint\": \"agb.cloud\""))
+		require.Len(t, issues, 1)
+		assert.Contains(t, issues[0].Message, "unexpected end")
+	})
+
+	t.Run("TypesUnknownKeysAndEndpoints", func(t *testing.T) {
+		data := `{
+  "endpont": "agb.cloud",
+  "imageGC": {"keepLast": "5"},
+  "fallbackEndpoints": [
+    "bad host"
+  ]
+}`
+		issues := config.ValidateConfigData([]byte(data))
+		require.Len(t, issues, 2, "endpoints are only checked when the types are valid")
+
+		assert.Equal(t, config.SeverityWarning, issues[0].Severity)
+		assert.Equal(t, "endpont", issues[0].Path)
+		assert.Equal(t, config.Position{Line: 2, Column: 3}, issues[0].Position)
+		assert.Contains(t, issues[0].Message, "did you mean 'endpoint'")
+
+		assert.Equal(t, config.SeverityError, issues[1].Severity)
+		assert.Equal(t, "imageGC.keepLast", issues[1].Path)
+		assert.Equal(t, 3, issues[1].Position.Line)
+		assert.Contains(t, issues[1].Message, "expected a number")
+
+		issues = config.ValidateConfigData([]byte(`{"fallbackEndpoints": ["ok.example.com", "ftp://x"]}`))
+		require.Len(t, issues, 1)
+		assert.Equal(t, "fallbackEndpoints[1]", issues[0].Path)
+		assert.Equal(t, "1:42: fallbackEndpoints[1]: unsupported scheme 'ftp'", issues[0].String())
+	})
+}
+
+func TestGetConfigReportsInvalidFile(t *testing.T) {
+	dir := useTempConfigDir(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte("{\n  \"output\": [\"json\"]\n}"), 0600))
+
+	_, err := config.GetConfig()
+	var validationErr *config.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Len(t, validationErr.Issues, 1)
+	assert.Contains(t, err.Error(), "config.json:2:3: output: expected a string, got an array")
+}
+
+func TestValidateConfigFile(t *testing.T) {
+	data := `{
+  "output": "xml",
+  "endpoint": "bad host",
+  "activeProfile": "prod",
+  "loginPortRange": "9-3"
+}`
+	issues := cmd.ValidateConfigFile([]byte(data))
+	require.Len(t, issues, 4, "the invalid endpoint is reported once")
+
+	paths := make([]string, len(issues))
+	for i, issue := range issues {
+		paths[i] = issue.Path
+		assert.Equal(t, i+2, issue.Position.Line, issue.Path)
+	}
+	assert.Equal(t, []string{"output", "endpoint", "activeProfile", "loginPortRange"}, paths)
+}
+
+func TestConfigValidateCommand(t *testing.T) {
+	dir := useTempConfigDir(t)
+
+	file := filepath.Join(dir, "config.json")
+	out, _, err := runSubcommand(t, cmd.ConfigCmd, "", "validate", nil)
+	require.NoError(t, err)
+	assert.Contains(t, out, "[OK] "+file+" is valid", "the file created on first run is valid")
+
+	require.NoError(t, os.WriteFile(file, []byte(`{"endpont": "agb.cloud"}`), 0600))
+	out, _, err = runSubcommand(t, cmd.ConfigCmd, "", "validate", []string{file})
+	require.NoError(t, err)
+	assert.Contains(t, out, "[WARN] "+file+":1:2: endpont")
+	assert.Contains(t, out, "valid, with 1 warning(s)")
+
+	require.NoError(t, os.WriteFile(file, []byte(`{"output": "xml"}`), 0600))
+	out, _, err = runSubcommand(t, cmd.ConfigCmd, "", "validate", []string{file})
+	assert.Error(t, err)
+	assert.Contains(t, out, "[ERROR] "+file+":1:2: output")
+}
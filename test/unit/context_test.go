@@ -0,0 +1,53 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+)
+
+func TestCancelledCommandContextStopsMonitoring(t *testing.T) {
+	useTempConfigDir(t)
+	server, _ := newSeededMockServer(t, 1, "IMAGE_AVAILABLE")
+	saveTestTokens(t)
+
+	activateCmd := findSubcommand(t, cmd.ImageCmd, "activate")
+	ctx, cancel := context.WithCancel(context.Background())
+	activateCmd.SetContext(ctx)
+	t.Cleanup(func() { activateCmd.SetContext(context.Background()) })
+
+	// Ctrl+C arrives while waiting for the first status check
+	time.AfterFunc(200*time.Millisecond, cancel)
+	start := time.Now()
+	out, _, runErr := runSubcommand(t, cmd.ImageCmd, server.URL, "activate", []string{"img-mock0001"})
+	assert.Contains(t, out, "[MONITOR] Monitoring image activation status...")
+	assert.True(t, errors.Is(runErr, context.Canceled), "got %v", runErr)
+	assert.Less(t, time.Since(start), 3*time.Second, "monitoring stops without waiting for the next check")
+	assert.True(t, cmd.Interrupted(ctx, runErr))
+}
+
+func TestInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := errors.New("network error")
+	assert.False(t, cmd.Interrupted(ctx, err))
+
+	cancel()
+	assert.True(t, cmd.Interrupted(ctx, err))
+	assert.False(t, cmd.Interrupted(ctx, nil), "commands that handle Ctrl+C themselves succeed")
+
+	// Timeouts of a command are not interruptions
+	timeoutCtx, timeoutCancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer timeoutCancel()
+	<-timeoutCtx.Done()
+	require.Error(t, timeoutCtx.Err())
+	assert.False(t, cmd.Interrupted(timeoutCtx, err))
+}
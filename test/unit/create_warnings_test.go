@@ -0,0 +1,108 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+)
+
+// captureStdout temporarily redirects stdout to capture output during tests
+func captureStdout(f func()) string {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	f()
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	var buf bytes.Buffer
+	_, _ = buf.ReadFrom(r) // Ignore errors in test helper
+	return buf.String()
+}
+
+func TestLintDockerfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "clean",
+			content:  "# base\nFROM ubuntu:22.04\nRUN apt-get update && \\\n    apt-get install -y curl\nCMD [\"bash\"]\n",
+			expected: nil,
+		},
+		{
+			name:     "empty",
+			content:  "# only a comment\n\n",
+			expected: []string{"Dockerfile contains no instructions"},
+		},
+		{
+			name:    "unknown and deprecated instructions",
+			content: "FROM ubuntu\nRUNN echo hi\nMAINTAINER me@example.com\n",
+			expected: []string{
+				"Dockerfile line 2: unknown instruction 'RUNN'",
+				"Dockerfile line 3: MAINTAINER is deprecated, use LABEL instead",
+			},
+		},
+		{
+			name:     "remote ADD",
+			content:  "FROM ubuntu\nadd https://example.com/tool.tgz /opt/\n",
+			expected: []string{"Dockerfile line 2: prefer RUN curl/wget over ADD with a remote URL"},
+		},
+		{
+			name:     "dangling continuation",
+			content:  "FROM ubuntu\nRUN echo hi \\\n",
+			expected: []string{"Dockerfile line 2: line continuation at end of file"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cmd.LintDockerfile(tt.content))
+		})
+	}
+}
+
+func TestWarningRecorder(t *testing.T) {
+	recorder := &cmd.WarningRecorder{}
+	assert.NoError(t, recorder.Check(true), "no warnings never fails")
+
+	captureStdout(func() {
+		recorder.Warn("Upload attempt %d/%d failed: %s", 1, 4, "503")
+		recorder.Warn("Task status check failed: %s", "THROTTLED")
+	})
+	assert.Equal(t, []string{"Upload attempt 1/4 failed: 503", "Task status check failed: THROTTLED"}, recorder.Warnings())
+
+	assert.NoError(t, recorder.Check(false), "warnings are accepted without --fail-on-warnings")
+
+	var err error
+	stderr := captureStderr(func() {
+		err = recorder.Check(true)
+	})
+	assert.Error(t, err)
+	assert.Contains(t, stderr, "2 warning(s) occurred and --fail-on-warnings is set")
+	assert.Contains(t, stderr, "• Upload attempt 1/4 failed: 503")
+
+	// A nil recorder only prints
+	var none *cmd.WarningRecorder
+	captureStdout(func() { none.Warn("ignored") })
+	assert.Empty(t, none.Warnings())
+	assert.NoError(t, none.Check(true))
+}
+
+func TestImageCreateFailOnWarningsFlag(t *testing.T) {
+	createCmd := findSubcommand(t, cmd.ImageCmd, "create")
+	flag := createCmd.Flags().Lookup("fail-on-warnings")
+	if assert.NotNil(t, flag) {
+		assert.Equal(t, "false", flag.DefValue)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// writeCredentialProvider writes a shell script that counts its runs in a file next to
+// it and prints output, and configures it as the credential provider
+func writeCredentialProvider(t *testing.T, output string) (*config.CredentialProvider, string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credential provider tests use a shell script")
+	}
+	dir := t.TempDir()
+	counter := filepath.Join(dir, "runs")
+	script := filepath.Join(dir, "provider.sh")
+	content := "#!/bin/sh\necho run >> " + counter + "\ncat <<'EOF'\n" + output + "\nEOF\n"
+	require.NoError(t, os.WriteFile(script, []byte(content), 0755))
+
+	provider := &config.CredentialProvider{Command: script}
+	cfg := &config.Config{CredentialProvider: provider}
+	require.NoError(t, cfg.SaveTokens("saved-login", "saved-session", "saved-keepalive", ""))
+	return provider, counter
+}
+
+// providerRuns returns how often the provider script ran
+func providerRuns(t *testing.T, counter string) int {
+	data, err := os.ReadFile(counter)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	require.NoError(t, err)
+	return strings.Count(string(data), "run")
+}
+
+func TestCredentialProviderSuppliesTokens(t *testing.T) {
+	useTempConfigDir(t)
+	_, counter := writeCredentialProvider(t, `{"loginToken": "provided-login", "sessionId": "provided-session"}`)
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	require.NotNil(t, cfg.Token)
+	assert.Equal(t, "provided-login", cfg.Token.LoginToken)
+	assert.Equal(t, "provided-session", cfg.Token.SessionId)
+	assert.True(t, cfg.TokenFromProvider())
+
+	// The tokens are cached for the process
+	_, err = config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 1, providerRuns(t, counter))
+
+	// Saving other settings keeps the provided tokens out of the file
+	cfg.Output = "json"
+	require.NoError(t, cfg.Save())
+	file, err := config.ConfigFile()
+	require.NoError(t, err)
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "provided-login")
+	var saved config.Config
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, "saved-login", saved.Token.LoginToken)
+	assert.Equal(t, "json", saved.Output)
+}
+
+func TestCredentialProviderWithEnvIsCached(t *testing.T) {
+	useTempConfigDir(t)
+	provider, counter := writeCredentialProvider(t, `{"loginToken": "provided-login", "sessionId": "provided-session"}`)
+	provider.Env = map[string]string{"TEAM": "ml", "REGION": "eu", "ROLE": "ci", "STAGE": "prod", "ZONE": "b"}
+	cfg := &config.Config{CredentialProvider: provider}
+	require.NoError(t, cfg.SaveTokens("saved-login", "saved-session", "saved-keepalive", ""))
+
+	for i := 0; i < 10; i++ {
+		cfg, err := config.GetConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "provided-login", cfg.Token.LoginToken)
+	}
+	assert.Equal(t, 1, providerRuns(t, counter), "the order of Env does not change the cached provider")
+}
+
+func TestCredentialProviderRunsAgainWhenExpired(t *testing.T) {
+	useTempConfigDir(t)
+	expiresAt := time.Now().Add(30 * time.Second).UTC().Format(time.RFC3339)
+	_, counter := writeCredentialProvider(t, `{"loginToken": "short", "sessionId": "s", "expiresAt": "`+expiresAt+`"}`)
+
+	for i := 0; i < 2; i++ {
+		cfg, err := config.GetConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "short", cfg.Token.LoginToken)
+		assert.False(t, cfg.Token.ExpiresAt.IsZero())
+	}
+	assert.Equal(t, 2, providerRuns(t, counter), "tokens about to expire are requested again")
+}
+
+func TestCredentialProviderFailureKeepsSavedTokens(t *testing.T) {
+	useTempConfigDir(t)
+	writeCredentialProvider(t, `{"loginToken": "no-session"}`)
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.False(t, cfg.TokenFromProvider())
+	assert.Equal(t, "saved-login", cfg.Token.LoginToken)
+}
+
+func TestCredentialProviderOutput(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credential provider tests use a shell script")
+	}
+	ctx := context.Background()
+
+	provider := &config.CredentialProvider{
+		Command: "sh",
+		Args:    []string{"-c", `printf '{"loginToken": "%s", "sessionId": "s"}' "$TEAM"`},
+		Env:     map[string]string{"TEAM": "ml"},
+	}
+	token, err := provider.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "ml", token.LoginToken, "Env is passed to the command")
+	assert.True(t, token.ExpiresAt.IsZero())
+
+	for output, message := range map[string]string{
+		"not json":           "output is not a JSON credential",
+		`{"sessionId": "s"}`: "output must contain loginToken and sessionId",
+		`{"loginToken": "l", "sessionId": "s", "expiresAt": "soon"}`: "expiresAt is not an RFC 3339 time",
+	} {
+		provider := &config.CredentialProvider{Command: "sh", Args: []string{"-c", "echo '" + output + "'"}}
+		_, err := provider.Token(ctx)
+		require.Error(t, err, output)
+		assert.Contains(t, err.Error(), message)
+	}
+
+	_, err = (&config.CredentialProvider{Command: "sh", Args: []string{"-c", "exit 3"}}).Token(ctx)
+	assert.Error(t, err, "a failing command supplies no tokens")
+}
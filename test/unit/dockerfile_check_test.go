@@ -0,0 +1,125 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+)
+
+// writeTestFile writes content to name in dir and returns its path
+func writeTestFile(t *testing.T, dir, name string, content []byte) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, content, 0644))
+	return path
+}
+
+func TestCheckDockerfile(t *testing.T) {
+	dir := t.TempDir()
+	withDockerfile := filepath.Join(dir, "app")
+	require.NoError(t, os.Mkdir(withDockerfile, 0755))
+	writeTestFile(t, withDockerfile, "Dockerfile", []byte("FROM agb-code-space-1\n"))
+
+	tests := []struct {
+		name     string
+		path     string
+		reason   string
+		forcible bool
+	}{
+		{"valid", writeTestFile(t, dir, "Dockerfile", []byte("# syntax=docker/dockerfile:1\n\nfrom agb-code-space-1\nRUN apt-get update && \\\n    apt-get install -y git\n")), "", false},
+		{"empty directory", t.TempDir(), "is a directory", false},
+		{"directory with a Dockerfile", withDockerfile, "is a directory", false},
+		{"binary", writeTestFile(t, dir, "agb", []byte("\x7fELF\x02\x01\x01\x00\x00\x00")), "binary file", true},
+		{"invalid UTF-8", writeTestFile(t, dir, "image.png", []byte("FROM \xff\xfe\n")), "binary file", true},
+		{"too large", writeTestFile(t, dir, "big", bytes.Repeat([]byte("RUN true\n"), 200000)), "too large for a Dockerfile", true},
+		{"no instructions", writeTestFile(t, dir, "README.md", []byte("# My image\n\nBuild it with agbcloud.\n")), "no Dockerfile instructions", true},
+		{"only comments", writeTestFile(t, dir, "empty", []byte("# nothing here\n")), "no Dockerfile instructions", true},
+	}
+
+	for _, tt := range tests {
+		err := cmd.CheckDockerfile(tt.path)
+		if tt.reason == "" {
+			assert.NoError(t, err, tt.name)
+			continue
+		}
+		var problem *cmd.DockerfileProblem
+		if assert.True(t, errors.As(err, &problem), "%s: unexpected error %v", tt.name, err) {
+			assert.Contains(t, problem.Reason, tt.reason, tt.name)
+			assert.Equal(t, tt.forcible, problem.Forcible, tt.name)
+		}
+	}
+
+	var problem *cmd.DockerfileProblem
+	require.True(t, errors.As(cmd.CheckDockerfile(withDockerfile), &problem))
+	assert.Contains(t, problem.Tip, "contains a Dockerfile; pass it with -f "+filepath.Join(withDockerfile, "Dockerfile"))
+
+	assert.True(t, errors.Is(cmd.CheckDockerfile(filepath.Join(dir, "missing")), os.ErrNotExist))
+}
+
+// runImageCreate runs 'image create' for an image named name against endpoint with a
+// Dockerfile that only has a FROM line; flags come after the defaults, so a later -f or
+// -i takes precedence
+func runImageCreate(t *testing.T, endpoint, name string, flags ...string) (string, string, error) {
+	saveTestTokens(t)
+	dockerfile := writeTestFile(t, t.TempDir(), "Dockerfile", []byte("FROM agb-code-space-1\n"))
+	defaults := []string{"-f", dockerfile, "-i", "agb-code-space-1", "--force-new"}
+	return runSubcommand(t, cmd.ImageCmd, endpoint, "create", []string{name}, append(defaults, flags...)...)
+}
+
+// newRejectingServer starts a server that rejects every request and reports whether an
+// upload credential was requested
+func newRejectingServer(t *testing.T) (*httptest.Server, *atomic.Bool) {
+	var uploadRequested atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "Upload") {
+			uploadRequested.Store(true)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	t.Cleanup(server.Close)
+	return server, &uploadRequested
+}
+
+func TestImageCreateRejectsDirectoryAsDockerfile(t *testing.T) {
+	useTempConfigDir(t)
+	server, uploaded := newRejectingServer(t)
+	dir := t.TempDir()
+	writeTestFile(t, dir, "Dockerfile", []byte("FROM agb-code-space-1\n"))
+
+	_, stderr, err := runImageCreate(t, server.URL, "checked", "-f", dir, "--force")
+	require.Error(t, err)
+	assert.False(t, uploaded.Load())
+	assert.Contains(t, stderr, "[ERROR] "+dir+" is a directory, not a Dockerfile")
+	assert.Contains(t, stderr, "[TIP] The directory contains a Dockerfile; pass it with -f "+filepath.Join(dir, "Dockerfile"))
+}
+
+func TestImageCreateBlocksBinaryDockerfileUnlessForced(t *testing.T) {
+	useTempConfigDir(t)
+	server, uploaded := newRejectingServer(t)
+	binary := writeTestFile(t, t.TempDir(), "agb", []byte("\x7fELF\x02\x01\x01\x00\x00\x00"))
+
+	_, stderr, err := runImageCreate(t, server.URL, "checked", "-f", binary)
+	require.Error(t, err)
+	assert.False(t, uploaded.Load())
+	assert.Contains(t, stderr, "[ERROR] "+binary+" looks like a binary file, not a Dockerfile")
+	assert.Contains(t, stderr, "[NOTE] Use --force to upload the file anyway")
+
+	stdout, _, err := runImageCreate(t, server.URL, "checked", "-f", binary, "--force")
+	require.Error(t, err, "the test server rejects every request")
+	assert.True(t, uploaded.Load())
+	assert.Contains(t, stdout, "[WARN]  "+binary+" looks like a binary file, not a Dockerfile; uploading it anyway because of --force")
+	assert.NotContains(t, stdout, "unknown instruction", "a forced file is not linted")
+}
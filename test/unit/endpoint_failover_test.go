@@ -0,0 +1,273 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// newCountingServer answers every request with status and counts the requests it received
+func newCountingServer(status int, hits *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"success": true, "data": {"images": [], "total": 0}}`)) // Ignore errors in test mock server
+	}))
+}
+
+func newFailoverTestClient(strategy client.ServerStrategy, urls ...string) *client.APIClient {
+	cfg := client.NewConfiguration()
+	cfg.Servers = nil
+	for _, url := range urls {
+		cfg.Servers = append(cfg.Servers, client.ServerConfiguration{URL: url})
+	}
+	cfg.ServerStrategy = strategy
+	return client.NewAPIClient(cfg)
+}
+
+func listImagesForFailover(apiClient *client.APIClient) error {
+	_, _, err := apiClient.ImageAPI.ListImages(context.Background(), "test-login-token", "test-session-id",
+		client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	return err
+}
+
+func TestEndpointFailover(t *testing.T) {
+	t.Run("UnreachablePrimary", func(t *testing.T) {
+		down := httptest.NewServer(http.NotFoundHandler())
+		down.Close()
+		var backupHits int32
+		backup := newCountingServer(http.StatusOK, &backupHits)
+		defer backup.Close()
+
+		apiClient := newFailoverTestClient(client.ServerStrategyPriority, down.URL, backup.URL)
+		require.NoError(t, listImagesForFailover(apiClient))
+		require.NoError(t, listImagesForFailover(apiClient))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&backupHits))
+	})
+
+	t.Run("UnavailablePrimaryIsSkippedDuringCooldown", func(t *testing.T) {
+		var primaryHits, backupHits int32
+		primary := newCountingServer(http.StatusServiceUnavailable, &primaryHits)
+		defer primary.Close()
+		backup := newCountingServer(http.StatusOK, &backupHits)
+		defer backup.Close()
+
+		apiClient := newFailoverTestClient(client.ServerStrategyPriority, primary.URL, backup.URL)
+		apiClient.GetConfig().ServerCooldown = 500 * time.Millisecond
+		for i := 0; i < 3; i++ {
+			require.NoError(t, listImagesForFailover(apiClient))
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&primaryHits), "a failed server is not retried while cooling down")
+		assert.Equal(t, int32(3), atomic.LoadInt32(&backupHits))
+
+		// After the cooldown the primary is tried again
+		time.Sleep(600 * time.Millisecond)
+		require.NoError(t, listImagesForFailover(apiClient))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&primaryHits))
+	})
+
+	t.Run("AllServersUnavailable", func(t *testing.T) {
+		var hits int32
+		first := newCountingServer(http.StatusBadGateway, &hits)
+		defer first.Close()
+		second := newCountingServer(http.StatusBadGateway, &hits)
+		defer second.Close()
+
+		apiClient := newFailoverTestClient(client.ServerStrategyPriority, first.URL, second.URL)
+		assert.Error(t, listImagesForFailover(apiClient), "the last server's error response is returned")
+		assert.Equal(t, int32(2), atomic.LoadInt32(&hits))
+	})
+
+	t.Run("ClientErrorsDoNotFailOver", func(t *testing.T) {
+		var primaryHits, backupHits int32
+		primary := newCountingServer(http.StatusBadRequest, &primaryHits)
+		defer primary.Close()
+		backup := newCountingServer(http.StatusOK, &backupHits)
+		defer backup.Close()
+
+		apiClient := newFailoverTestClient(client.ServerStrategyPriority, primary.URL, backup.URL)
+		assert.Error(t, listImagesForFailover(apiClient))
+		assert.Zero(t, atomic.LoadInt32(&backupHits))
+	})
+
+	t.Run("RoundRobin", func(t *testing.T) {
+		var firstHits, secondHits int32
+		first := newCountingServer(http.StatusOK, &firstHits)
+		defer first.Close()
+		second := newCountingServer(http.StatusOK, &secondHits)
+		defer second.Close()
+
+		apiClient := newFailoverTestClient(client.ServerStrategyRoundRobin, first.URL, second.URL)
+		for i := 0; i < 4; i++ {
+			require.NoError(t, listImagesForFailover(apiClient))
+		}
+		assert.Equal(t, int32(2), atomic.LoadInt32(&firstHits))
+		assert.Equal(t, int32(2), atomic.LoadInt32(&secondHits))
+	})
+
+	t.Run("RequestBodyIsResent", func(t *testing.T) {
+		var primaryHits int32
+		primary := newCountingServer(http.StatusServiceUnavailable, &primaryHits)
+		defer primary.Close()
+
+		var received client.OAuthRefreshTokenRequest
+		backup := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"success": true, "data": {"loginToken": "new-login-token"}}`)) // Ignore errors in test mock server
+		}))
+		defer backup.Close()
+
+		apiClient := newFailoverTestClient(client.ServerStrategyPriority, primary.URL, backup.URL)
+		resp, _, err := apiClient.OAuthAPI.RefreshTokenWithBody(context.Background(), "keep-alive-token", "session-id")
+		require.NoError(t, err)
+		assert.Equal(t, "new-login-token", resp.Data.LoginToken)
+		assert.Equal(t, "keep-alive-token", received.KeepAliveToken)
+		assert.Equal(t, "session-id", received.SessionId)
+	})
+
+	t.Run("PinnedServerIndex", func(t *testing.T) {
+		var primaryHits, backupHits int32
+		primary := newCountingServer(http.StatusServiceUnavailable, &primaryHits)
+		defer primary.Close()
+		backup := newCountingServer(http.StatusOK, &backupHits)
+		defer backup.Close()
+
+		apiClient := newFailoverTestClient(client.ServerStrategyPriority, primary.URL, backup.URL)
+		ctx := context.WithValue(context.Background(), client.ContextServerIndex, 0)
+		_, _, err := apiClient.ImageAPI.ListImages(ctx, "test-login-token", "test-session-id",
+			client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+		assert.Error(t, err)
+		assert.Zero(t, atomic.LoadInt32(&backupHits), "requests pinned to a server never fail over")
+	})
+}
+
+func TestProbeServers(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	var hits int32
+	up := newCountingServer(http.StatusNotFound, &hits)
+	defer up.Close()
+
+	apiClient := newFailoverTestClient(client.ServerStrategyPriority, down.URL, up.URL)
+	statuses := apiClient.ProbeServers(context.Background(), time.Second)
+	require.Len(t, statuses, 2)
+	assert.False(t, statuses[0].Reachable)
+	assert.Error(t, statuses[0].Err)
+	assert.True(t, statuses[1].Reachable, "any HTTP response means the server is reachable")
+	assert.NoError(t, statuses[1].Err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+}
+
+func TestParseServerStrategy(t *testing.T) {
+	strategy, err := client.ParseServerStrategy("")
+	assert.NoError(t, err)
+	assert.Equal(t, client.ServerStrategyPriority, strategy)
+
+	strategy, err = client.ParseServerStrategy(" Round-Robin ")
+	assert.NoError(t, err)
+	assert.Equal(t, client.ServerStrategyRoundRobin, strategy)
+
+	strategy, err = client.ParseServerStrategy("random")
+	assert.Error(t, err)
+	assert.Equal(t, client.ServerStrategyPriority, strategy)
+}
+
+func TestGetEndpoints(t *testing.T) {
+	useTempConfigDir(t)
+
+	t.Setenv("AGB_CLI_ENDPOINT", "")
+	assert.Equal(t, []string{"https://agb.cloud"}, config.GetEndpoints())
+
+	cfg := &config.Config{
+		FallbackEndpoints: []string{"backup.agb.cloud", "http://10.0.0.5:8080/"},
+		EndpointStrategy:  "round-robin",
+		Profiles: map[string]config.Profile{
+			"eu": {Endpoint: "eu.agb.cloud", FallbackEndpoints: []string{"eu-backup.agb.cloud"}},
+		},
+	}
+	require.NoError(t, cfg.Save())
+	assert.Equal(t, []string{"https://agb.cloud", "https://backup.agb.cloud", "http://10.0.0.5:8080"}, config.GetEndpoints(),
+		"fallbacks follow the default primary endpoint")
+	assert.Equal(t, "round-robin", config.GetEndpointStrategy())
+
+	t.Setenv("AGB_CLI_PROFILE", "eu")
+	assert.Equal(t, []string{"https://eu.agb.cloud", "https://eu-backup.agb.cloud"}, config.GetEndpoints(),
+		"a profile's endpoints replace the top-level ones")
+
+	t.Setenv("AGB_CLI_ENDPOINT", "a.example.com, b.example.com,a.example.com")
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, config.GetEndpoints())
+	assert.Equal(t, "https://a.example.com", config.GetEndpoint())
+
+	t.Setenv("AGB_CLI_ENDPOINT_STRATEGY", "priority")
+	assert.Equal(t, "priority", config.GetEndpointStrategy())
+
+	apiClient := client.NewFromConfig(cfg)
+	require.Len(t, apiClient.GetConfig().Servers, 2)
+	assert.Equal(t, "https://b.example.com", apiClient.GetConfig().Servers[1].URL)
+	assert.Equal(t, client.ServerStrategyPriority, apiClient.GetConfig().ServerStrategy)
+}
+
+func TestEndpointFlag(t *testing.T) {
+	useTempConfigDir(t)
+	t.Cleanup(func() { config.SetEndpointOverride("") })
+	validate := func(flags ...string) (string, error) {
+		_, stderr, err := runSubcommand(t, cmd.ConfigCmd, "env.agb.cloud", "validate", nil, flags...)
+		return stderr, err
+	}
+
+	_, err := validate("--endpoint", "https://staging.agb.cloud/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://staging.agb.cloud"}, config.GetEndpoints(), "the flag takes precedence over AGB_CLI_ENDPOINT")
+
+	_, err = validate("--endpoint", "staging.agb.cloud,http://127.0.0.1:8089")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://staging.agb.cloud", "http://127.0.0.1:8089"}, config.GetEndpoints())
+
+	_, err = validate()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://env.agb.cloud"}, config.GetEndpoints(), "without the flag the override is removed")
+
+	for _, value := range []string{"ftp://staging.agb.cloud", "staging.agb.cloud,", "not a host"} {
+		stderr, err := validate("--endpoint", value)
+		require.Error(t, err, value)
+		assert.Contains(t, stderr, "Invalid --endpoint value", value)
+	}
+	assert.Equal(t, []string{"https://env.agb.cloud"}, config.GetEndpoints(), "an invalid flag does not change the endpoint")
+}
+
+func TestValidateSharedConfigEndpoints(t *testing.T) {
+	assert.NoError(t, cmd.ValidateSharedConfig(config.SharedConfig{
+		Endpoint:          "agb.cloud",
+		FallbackEndpoints: []string{"backup.agb.cloud"},
+		EndpointStrategy:  "round-robin",
+	}))
+
+	err := cmd.ValidateSharedConfig(config.SharedConfig{
+		FallbackEndpoints: []string{"ftp://backup.agb.cloud", ""},
+		EndpointStrategy:  "random",
+		Profiles: map[string]config.Profile{
+			"eu": {EndpointStrategy: "fastest"},
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fallbackEndpoints[0]: unsupported scheme 'ftp'")
+	assert.Contains(t, err.Error(), "fallbackEndpoints[1]: endpoint must not be empty")
+	assert.Contains(t, err.Error(), "endpointStrategy: unsupported endpoint strategy 'random'")
+	assert.Contains(t, err.Error(), "profiles.eu.endpointStrategy")
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+)
+
+// useNewline selects a line ending for the duration of the test
+func useNewline(t *testing.T, nl string) {
+	output.SetNewline(nl)
+	t.Cleanup(func() { output.SetNewline("") })
+}
+
+// assertCRLF fails if text has a line break that is not "\r\n"
+func assertCRLF(t *testing.T, text string) {
+	require.Contains(t, text, "\r\n")
+	assert.NotContains(t, strings.ReplaceAll(text, "\r\n", ""), "\n", "bare LF in %q", text)
+}
+
+func TestPlatformNewline(t *testing.T) {
+	assert.Equal(t, "\r\n", output.PlatformNewline("windows", ""), "cmd and PowerShell")
+	assert.Equal(t, "\n", output.PlatformNewline("windows", "MINGW64"), "Git Bash")
+	assert.Equal(t, "\n", output.PlatformNewline("linux", ""))
+	assert.Equal(t, "\n", output.PlatformNewline("darwin", ""))
+}
+
+func TestParseEOL(t *testing.T) {
+	for value, expected := range map[string]string{"lf": "\n", "CRLF": "\r\n", " crlf ": "\r\n"} {
+		nl, err := output.ParseEOL(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, nl, value)
+	}
+	nl, err := output.ParseEOL("auto")
+	require.NoError(t, err)
+	assert.Equal(t, output.Newline(), nl)
+
+	_, err = output.ParseEOL("cr")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid line ending 'cr'")
+}
+
+func TestNewlineWriter(t *testing.T) {
+	var buf bytes.Buffer
+	useNewline(t, "\n")
+	assert.Same(t, &buf, output.NewlineWriter(&buf), "LF output is not rewritten")
+
+	useNewline(t, "\r\n")
+	w := output.NewlineWriter(&buf)
+	for _, part := range []string{"a\nb\r\n", "c\r", "\nd\n", "\n"} {
+		n, err := w.Write([]byte(part))
+		require.NoError(t, err)
+		assert.Equal(t, len(part), n)
+	}
+	assert.Equal(t, "a\r\nb\r\nc\r\nd\r\n\r\n", buf.String(), "existing CRLF is kept, also across writes")
+}
+
+func TestStructuredOutputCRLF(t *testing.T) {
+	useNewline(t, "\r\n")
+	for _, format := range []output.Format{output.FormatJSON, output.FormatCSV, output.FormatPSON} {
+		var buf bytes.Buffer
+		require.NoError(t, output.Write(&buf, format, sampleListItems()))
+		assertCRLF(t, buf.String())
+	}
+
+	var err error
+	captureStderr(func() { err = cmd.ValidateCPUMemoryCombo(2, 0) })
+	require.Error(t, err)
+	assertCRLF(t, err.Error())
+}
+
+// listImageIDs runs 'image list -q' with the given flags and returns what reached stdout
+func listImageIDs(t *testing.T, flags ...string) (string, error) {
+	t.Cleanup(func() { output.SetNewline("") })
+	var queries []url.Values
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server := newListQueryServer(t, 2, &queries)
+	defer server.Close()
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, append([]string{"-q"}, flags...)...)
+	return stdout, err
+}
+
+func TestApplyEOLFlagTranslatesPipedStdout(t *testing.T) {
+	t.Setenv("AGB_CLI_EOL", "")
+	stdout, err := listImageIDs(t, "--eol", "crlf")
+	require.NoError(t, err)
+	assert.Equal(t, "img-1\r\nimg-2\r\n", stdout)
+
+	stdout, err = listImageIDs(t, "--eol", "lf")
+	require.NoError(t, err)
+	assert.Equal(t, "img-1\nimg-2\n", stdout, "LF output is passed through unchanged")
+
+	// The environment variable applies without the flag, and the flag overrides it
+	t.Setenv("AGB_CLI_EOL", "crlf")
+	stdout, err = listImageIDs(t)
+	require.NoError(t, err)
+	assertCRLF(t, stdout)
+	stdout, err = listImageIDs(t, "--eol", "lf")
+	require.NoError(t, err)
+	assert.Equal(t, "img-1\nimg-2\n", stdout)
+
+	_, err = listImageIDs(t, "--eol", "unix")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "[TIP] Usage: --eol <auto|lf|crlf>")
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// newErrorCodeServer answers every request with the given HTTP status and error code
+func newErrorCodeServer(t *testing.T, status int, code string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"success": false, "code": "` + code + `", "requestId": "req-1"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestErrorHints(t *testing.T) {
+	hints := cmd.ErrorHints("INVALID_SOURCE_IMAGE")
+	require.Len(t, hints, 1)
+	assert.Contains(t, hints[0], "agbcloud image list -t System")
+
+	// Codes match regardless of case and separators
+	assert.Equal(t, cmd.ErrorHints("IMAGE_NOT_FOUND"), cmd.ErrorHints("ImageNotFound"))
+	assert.Equal(t, cmd.ErrorHints("RESOURCE_QUOTA_EXCEEDED"), cmd.ErrorHints("resource.quota.exceeded"))
+	assert.NotEmpty(t, cmd.ErrorHints("UserLogin.Expired"))
+
+	assert.Nil(t, cmd.ErrorHints("SOMETHING_UNEXPECTED"))
+	assert.Nil(t, cmd.ErrorHints(""))
+}
+
+func TestAPIErrorCode(t *testing.T) {
+	server := newErrorCodeServer(t, http.StatusForbidden, "INVALID_TOKEN")
+	apiClient := newLogsTestClient(server.URL)
+
+	_, _, err := apiClient.ImageAPI.StopImage(context.Background(), "token", "session", "img-1", client.ImageStopOptions{})
+	var apiErr *client.GenericOpenAPIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "INVALID_TOKEN", cmd.APIErrorCode(apiErr))
+}
+
+func TestCommandsPrintErrorHints(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		code     string
+		expected []string
+	}{
+		{
+			name:     "failed response",
+			status:   http.StatusOK,
+			code:     "RESOURCE_QUOTA_EXCEEDED",
+			expected: []string{"[ERROR] Failed to deactivate image: RESOURCE_QUOTA_EXCEEDED", "agbcloud image deactivate <image-id>"},
+		},
+		{
+			name:     "HTTP error with a code",
+			status:   http.StatusBadRequest,
+			code:     "INVALID_IMAGE_ID",
+			expected: []string{"[ERROR] Failed to deactivate image: INVALID_IMAGE_ID", "[TIP] Run 'agbcloud image list' to see the IDs of your images"},
+		},
+		{
+			name:     "unknown code",
+			status:   http.StatusOK,
+			code:     "SOMETHING_UNEXPECTED",
+			expected: []string{"[ERROR] Failed to deactivate image: SOMETHING_UNEXPECTED"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempConfigDir(t)
+			server := newErrorCodeServer(t, tt.status, tt.code)
+			saveTestTokens(t)
+
+			_, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "deactivate", []string{"img-1"})
+			require.Error(t, err)
+			for _, line := range tt.expected {
+				assert.Contains(t, stderr, line)
+			}
+			if cmd.ErrorHints(tt.code) == nil {
+				assert.NotContains(t, stderr, "[TIP]")
+			}
+		})
+	}
+}
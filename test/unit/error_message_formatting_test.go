@@ -7,11 +7,11 @@ import (
 	"bytes"
 	"fmt"
 	"os"
-	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/output"
 )
 
 // captureStderrErr temporarily redirects stderr to capture output during tests
@@ -32,10 +32,7 @@ func captureStderrErr(f func()) string {
 
 func TestErrorMessageFormatting(t *testing.T) {
 	// Get expected newline for current platform
-	expectedNewline := "\n"
-	if runtime.GOOS == "windows" {
-		expectedNewline = "\r\n"
-	}
+	expectedNewline := output.Newline()
 
 	tests := []struct {
 		name     string
@@ -97,7 +94,7 @@ func TestErrorMessageFormatting(t *testing.T) {
 
 			// Check that the error message uses the correct platform-specific newlines
 			if !strings.Contains(errMsg, expectedNewline) {
-				t.Errorf("Error message does not contain expected newline sequence for platform %s", runtime.GOOS)
+				t.Errorf("Error message does not contain expected newline sequence %q", expectedNewline)
 			}
 
 			// Count the number of lines in the error message
@@ -145,9 +142,9 @@ func TestPlatformSpecificNewlines(t *testing.T) {
 
 	errMsg := err.Error()
 
-	// On Windows, we should see \r\n
+	// On Windows, we should see \r\n, except in Git Bash
 	// On other platforms, we should see \n
-	if runtime.GOOS == "windows" {
+	if output.Newline() == "\r\n" {
 		if !strings.Contains(errMsg, "\r\n") {
 			t.Error("On Windows, error message should contain \\r\\n sequences")
 		}
@@ -0,0 +1,167 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/mockserver"
+	"github.com/agbcloud/agbcloud-cli/pkg/events"
+)
+
+// eventCollector is a sink that keeps the events it receives
+type eventCollector struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func (c *eventCollector) HandleEvent(_ context.Context, e events.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, e)
+}
+
+func (c *eventCollector) kinds() []events.Kind {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var kinds []events.Kind
+	for _, e := range c.events {
+		kinds = append(kinds, e.Kind)
+	}
+	return kinds
+}
+
+func TestLogSink(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	sink := events.NewLogSink(logger)
+
+	events.Emit(context.Background(), sink, events.Event{
+		Kind:       events.RequestFinished,
+		Method:     http.MethodGet,
+		Path:       "/api/image/list",
+		StatusCode: http.StatusInternalServerError,
+		Duration:   120 * time.Millisecond,
+	})
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"], "failed requests are warnings")
+	assert.Equal(t, "request.finished", record["msg"])
+	assert.Equal(t, "GET", record["method"])
+	assert.Equal(t, "/api/image/list", record["path"])
+	assert.Equal(t, float64(500), record["status"])
+	assert.Equal(t, float64(120*time.Millisecond), record["duration"])
+	assert.NotContains(t, record, "attempt", "fields that are not set are left out")
+
+	buf.Reset()
+	events.Emit(context.Background(), sink, events.Event{Kind: events.PollTransition, Subject: "img-1", From: "IMAGE_AVAILABLE", To: "RESOURCE_DEPLOYING"})
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "img-1", record["subject"])
+	assert.Equal(t, "RESOURCE_DEPLOYING", record["to"])
+}
+
+func TestEmitStampsTime(t *testing.T) {
+	collector := &eventCollector{}
+	events.Emit(context.Background(), collector, events.Event{Kind: events.RequestStarted})
+	events.Emit(context.Background(), nil, events.Event{Kind: events.RequestStarted})
+
+	require.Len(t, collector.events, 1)
+	assert.False(t, collector.events[0].Time.IsZero())
+}
+
+func TestRegisterAndUnregister(t *testing.T) {
+	first, second := &eventCollector{}, &eventCollector{}
+	unregisterFirst := events.Register(first)
+	unregisterSecond := events.Register(second)
+	defer unregisterSecond()
+
+	events.Publish(context.Background(), events.Event{Kind: events.RequestStarted})
+	unregisterFirst()
+	unregisterFirst()
+	events.Publish(context.Background(), events.Event{Kind: events.RequestFinished})
+
+	assert.Equal(t, []events.Kind{events.RequestStarted}, first.kinds())
+	assert.Equal(t, []events.Kind{events.RequestStarted, events.RequestFinished}, second.kinds())
+}
+
+func TestClientEmitsRequestEvents(t *testing.T) {
+	useTempConfigDir(t)
+	backend := mockserver.New()
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		backend.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+
+	collector := &eventCollector{}
+	defer events.Register(collector)()
+
+	apiClient := client.NewFromConfig(&config.Config{})
+	_, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+
+	require.Equal(t, []events.Kind{events.RequestStarted, events.RequestRetry, events.RequestFinished}, collector.kinds())
+	retry := collector.events[1]
+	assert.Equal(t, "/api/image/list", retry.Path)
+	assert.Equal(t, 1, retry.Attempt)
+	assert.Equal(t, http.StatusServiceUnavailable, retry.StatusCode)
+	assert.Positive(t, retry.Delay)
+
+	finished := collector.events[2]
+	assert.Equal(t, http.MethodGet, finished.Method)
+	assert.Equal(t, http.StatusOK, finished.StatusCode)
+	assert.NoError(t, finished.Err)
+	assert.GreaterOrEqual(t, finished.Duration, retry.Delay)
+}
+
+func TestClientEventsRedactErrors(t *testing.T) {
+	collector := &eventCollector{}
+	cfg := client.NewConfiguration()
+	cfg.Servers[0].URL = "http://127.0.0.1:1"
+	cfg.EventSink = collector
+	apiClient := client.NewAPIClient(cfg)
+
+	_, _, err := apiClient.ImageAPI.ListImages(context.Background(), "secret-login-token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	require.Error(t, err)
+
+	require.Equal(t, []events.Kind{events.RequestStarted, events.RequestFinished}, collector.kinds())
+	finished := collector.events[1]
+	require.Error(t, finished.Err)
+	assert.NotContains(t, finished.Err.Error(), "secret-login-token")
+	var urlErr *url.Error
+	assert.True(t, errors.As(finished.Err, &urlErr), "the original error stays reachable")
+}
+
+func TestFormatEvent(t *testing.T) {
+	assert.Equal(t, "[EVENT] request.finished GET /api/image/list status=200 duration=120ms",
+		cmd.FormatEvent(events.Event{Kind: events.RequestFinished, Method: "GET", Path: "/api/image/list", StatusCode: 200, Duration: 120 * time.Millisecond}))
+	assert.Equal(t, "[EVENT] request.retry POST /api/image/create attempt=1 status=503 delay=500ms",
+		cmd.FormatEvent(events.Event{Kind: events.RequestRetry, Method: "POST", Path: "/api/image/create", Attempt: 1, StatusCode: 503, Delay: 500 * time.Millisecond}))
+	assert.Equal(t, "[EVENT] poll.transition task-1 (start) -> Preparing",
+		cmd.FormatEvent(events.Event{Kind: events.PollTransition, Subject: "task-1", To: "Preparing"}))
+}
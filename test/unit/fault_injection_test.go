@@ -0,0 +1,122 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestParseFaultInjection(t *testing.T) {
+	injector, err := client.ParseFaultInjection("")
+	require.NoError(t, err)
+	assert.Nil(t, injector, "an empty setting turns fault injection off")
+
+	injector, err = client.ParseFaultInjection("0.25")
+	require.NoError(t, err)
+	assert.Equal(t, 0.25, injector.Rate)
+	assert.Equal(t, []string{client.FaultServerError, client.FaultTimeout}, injector.Kinds)
+
+	injector, err = client.ParseFaultInjection(" 20%, 5xx , seed=7")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.2, injector.Rate, 1e-9)
+	assert.Equal(t, []string{client.FaultServerError}, injector.Kinds)
+
+	for _, value := range []string{"5xx", "1.5", "150%", "often", "0.1,seed=x"} {
+		_, err := client.ParseFaultInjection(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestFaultInjectorRoundTrip(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	injector, err := client.ParseFaultInjection("100%,5xx")
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: injector}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.GreaterOrEqual(t, resp.StatusCode, http.StatusInternalServerError)
+	assert.True(t, client.IsRetryableHTTPStatus(resp.StatusCode))
+
+	injector, err = client.ParseFaultInjection("1,timeout")
+	require.NoError(t, err)
+	_, err = (&http.Client{Transport: injector}).Get(server.URL)
+	require.Error(t, err)
+	var netErr net.Error
+	require.True(t, errors.As(err, &netErr))
+	assert.True(t, netErr.Timeout())
+	assert.Contains(t, err.Error(), "injected fault")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&hits), "injected faults never reach the server")
+
+	injector, err = client.ParseFaultInjection("0")
+	require.NoError(t, err)
+	resp, err = (&http.Client{Transport: injector}).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&hits))
+}
+
+func TestFaultInjectorIsSeeded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	outcomes := func() []int {
+		injector, err := client.ParseFaultInjection("50%,5xx,seed=42")
+		require.NoError(t, err)
+		httpClient := &http.Client{Transport: injector}
+		var codes []int
+		for i := 0; i < 20; i++ {
+			resp, err := httpClient.Get(server.URL)
+			require.NoError(t, err)
+			resp.Body.Close()
+			codes = append(codes, resp.StatusCode)
+		}
+		return codes
+	}
+	first := outcomes()
+	assert.Equal(t, first, outcomes(), "the same seed injects the same faults")
+	assert.Contains(t, first, http.StatusOK)
+}
+
+func TestFaultInjectionExercisesRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	injector, err := client.ParseFaultInjection("1,timeout")
+	require.NoError(t, err)
+	retryClient := client.NewRetryableHTTPClient(&http.Client{Transport: injector}, &client.RetryConfig{
+		MaxRetries:    2,
+		InitialDelay:  time.Millisecond,
+		MaxDelay:      time.Millisecond,
+		BackoffFactor: 2,
+	})
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err = retryClient.Do(req)
+	require.Error(t, err)
+	var exhausted *client.RetriesExhaustedError
+	require.True(t, errors.As(err, &exhausted))
+	assert.Equal(t, 3, exhausted.Attempts)
+	assert.True(t, client.IsTransientFailure(err))
+}
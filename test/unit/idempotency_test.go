@@ -0,0 +1,135 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/mockserver"
+)
+
+// discardResponse is a ResponseWriter that drops everything written to it
+type discardResponse struct{ header http.Header }
+
+func (d *discardResponse) Header() http.Header         { return d.header }
+func (d *discardResponse) Write(p []byte) (int, error) { return len(p), nil }
+func (d *discardResponse) WriteHeader(int)             {}
+
+func TestNewIdempotencyKey(t *testing.T) {
+	key := client.NewIdempotencyKey()
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), key)
+	assert.NotEqual(t, key, client.NewIdempotencyKey())
+}
+
+func TestIdempotencyKeySurvivesRetries(t *testing.T) {
+	useTempConfigDir(t)
+	backend := mockserver.New()
+
+	// The first create request is carried out, but its response is lost
+	var mu sync.Mutex
+	keys := map[string][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys[r.URL.Path] = append(keys[r.URL.Path], r.Header.Get(client.IdempotencyKeyHeader))
+		first := r.URL.Path == "/api/image/create" && len(keys[r.URL.Path]) == 1
+		mu.Unlock()
+		if first {
+			backend.ServeHTTP(&discardResponse{header: http.Header{}}, r)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		backend.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+
+	apiClient := client.NewFromConfig(&config.Config{})
+	ctx := context.Background()
+	resp, _, err := apiClient.ImageAPI.CreateImage(ctx, "token", "session", "retried", "task-1", "agb-code-space-1", client.ImageCreateOptions{})
+	require.NoError(t, err)
+
+	createKeys := keys["/api/image/create"]
+	require.Len(t, createKeys, 2, "the failed create request is retried")
+	assert.NotEmpty(t, createKeys[0])
+	assert.Equal(t, createKeys[0], createKeys[1], "retries send the same key")
+
+	listResp, _, err := apiClient.ImageAPI.ListImages(ctx, "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, listResp.Data.Images, 1, "the retry does not create a second image")
+	assert.Equal(t, resp.Data, listResp.Data.Images[0].ImageID)
+	assert.Equal(t, []string{""}, keys["/api/image/list"], "requests that are not POSTs carry no key")
+}
+
+func TestRetryRequiresIdempotencyKeyForPOST(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	retryClient := client.NewRetryableHTTPClient(&http.Client{Timeout: 5 * time.Second}, &client.RetryConfig{
+		MaxRetries:    2,
+		InitialDelay:  time.Millisecond,
+		MaxDelay:      time.Millisecond,
+		BackoffFactor: 1,
+	})
+	send := func(key string) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, strings.NewReader("{}"))
+		require.NoError(t, err)
+		if key != "" {
+			req.Header.Set(client.IdempotencyKeyHeader, key)
+		}
+		resp, err := retryClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+
+	send("")
+	assert.Equal(t, 1, attempts, "a POST without a key is sent once")
+
+	attempts = 0
+	send(client.NewIdempotencyKey())
+	assert.Equal(t, 3, attempts, "a POST with a key is retried")
+}
+
+func TestMockServerReplaysIdempotentRequests(t *testing.T) {
+	server := httptest.NewServer(mockserver.New())
+	defer server.Close()
+
+	post := func(path, key, body string) string {
+		req, err := http.NewRequest(http.MethodPost, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(client.IdempotencyKeyHeader, key)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	create := `{"loginToken": "t", "sessionId": "s", "imageName": "web", "taskId": "task-1", "sourceImageId": "agb-code-space-1"}`
+	first := post("/api/image/create", "key-1", create)
+	assert.Contains(t, first, `"success":true`)
+	assert.Equal(t, first, post("/api/image/create", "key-1", create), "a repeated key gets the first response")
+	assert.NotEqual(t, first, post("/api/image/create", "key-2", create), "a new key is a new request")
+
+	assert.Contains(t, post("/api/sshkey/add", "key-1", `{}`), `"code":"IdempotencyKeyReused"`)
+}
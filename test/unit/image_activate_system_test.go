@@ -0,0 +1,36 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+)
+
+func TestImageActivateRejectsSystemImage(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 1, "IMAGE_AVAILABLE")
+
+	stdout, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "activate", []string{"agb-code-space-1"})
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] 'agb-code-space-1' is a System image, which cannot be activated directly")
+	assert.Contains(t, stderr, "agbcloud image create <image-name> --dockerfile ./Dockerfile --imageId agb-code-space-1")
+	assert.NotContains(t, stdout, "Starting image activation")
+}
+
+func TestImageActivateUnknownImage(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 1, "IMAGE_AVAILABLE")
+
+	_, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "activate", []string{"img-missing"})
+	require.Error(t, err)
+	assert.Equal(t, "image not found: img-missing", err.Error())
+	assert.NotContains(t, stderr, "System image")
+}
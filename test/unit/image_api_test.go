@@ -175,7 +175,8 @@ func TestImageAPICreateImage(t *testing.T) {
 		"test-session-id",
 		"test-image-name",
 		"test-task-id",
-		"agb-code-space-2")
+		"agb-code-space-2",
+		client.ImageCreateOptions{})
 
 	// Verify no error
 	if err != nil {
@@ -254,7 +255,7 @@ func TestImageAPIParameterValidation(t *testing.T) {
 
 		for _, tc := range testCases {
 			t.Run(tc.name, func(t *testing.T) {
-				_, _, err := apiClient.ImageAPI.CreateImage(ctx, tc.loginToken, tc.sessionId, tc.imageName, tc.taskId, tc.sourceImageId)
+				_, _, err := apiClient.ImageAPI.CreateImage(ctx, tc.loginToken, tc.sessionId, tc.imageName, tc.taskId, tc.sourceImageId, client.ImageCreateOptions{})
 				if err == nil {
 					t.Errorf("Expected error for %s", tc.name)
 				}
@@ -444,7 +445,7 @@ func TestImageAPIListImages(t *testing.T) {
 	defer cancel()
 
 	// Call ListImages
-	response, httpResp, err := apiClient.ImageAPI.ListImages(ctx, "test-login-token", "test-session-id", "User", 1, 10, nil)
+	response, httpResp, err := apiClient.ImageAPI.ListImages(ctx, "test-login-token", "test-session-id", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
 
 	// Verify no error
 	if err != nil {
@@ -508,7 +509,7 @@ func TestImageAPIListImagesParameterValidation(t *testing.T) {
 	defer cancel()
 
 	t.Run("ListImages_EmptyLoginToken", func(t *testing.T) {
-		_, _, err := apiClient.ImageAPI.ListImages(ctx, "", "test-session-id", "User", 1, 10, nil)
+		_, _, err := apiClient.ImageAPI.ListImages(ctx, "", "test-session-id", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
 		if err == nil {
 			t.Error("Expected error for empty loginToken")
 		}
@@ -518,7 +519,7 @@ func TestImageAPIListImagesParameterValidation(t *testing.T) {
 	})
 
 	t.Run("ListImages_EmptySessionId", func(t *testing.T) {
-		_, _, err := apiClient.ImageAPI.ListImages(ctx, "test-login-token", "", "User", 1, 10, nil)
+		_, _, err := apiClient.ImageAPI.ListImages(ctx, "test-login-token", "", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
 		if err == nil {
 			t.Error("Expected error for empty sessionId")
 		}
@@ -528,7 +529,7 @@ func TestImageAPIListImagesParameterValidation(t *testing.T) {
 	})
 
 	t.Run("ListImages_EmptyImageType", func(t *testing.T) {
-		_, _, err := apiClient.ImageAPI.ListImages(ctx, "test-login-token", "test-session-id", "", 1, 10, nil)
+		_, _, err := apiClient.ImageAPI.ListImages(ctx, "test-login-token", "test-session-id", client.ImageListOptions{ImageType: "", Page: 1, PageSize: 10})
 		if err == nil {
 			t.Error("Expected error for empty imageType")
 		}
@@ -538,7 +539,7 @@ func TestImageAPIListImagesParameterValidation(t *testing.T) {
 	})
 
 	t.Run("ListImages_InvalidPage", func(t *testing.T) {
-		_, _, err := apiClient.ImageAPI.ListImages(ctx, "test-login-token", "test-session-id", "User", 0, 10, nil)
+		_, _, err := apiClient.ImageAPI.ListImages(ctx, "test-login-token", "test-session-id", client.ImageListOptions{ImageType: "User", Page: 0, PageSize: 10})
 		if err == nil {
 			t.Error("Expected error for invalid page")
 		}
@@ -548,7 +549,7 @@ func TestImageAPIListImagesParameterValidation(t *testing.T) {
 	})
 
 	t.Run("ListImages_InvalidPageSize", func(t *testing.T) {
-		_, _, err := apiClient.ImageAPI.ListImages(ctx, "test-login-token", "test-session-id", "User", 1, 0, nil)
+		_, _, err := apiClient.ImageAPI.ListImages(ctx, "test-login-token", "test-session-id", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 0})
 		if err == nil {
 			t.Error("Expected error for invalid pageSize")
 		}
@@ -610,7 +611,7 @@ func TestImageAPIService_StartImage(t *testing.T) {
 
 		// Call StartImage API
 		ctx := context.Background()
-		resp, httpResp, err := apiClient.ImageAPI.StartImage(ctx, "test-login-token", "test-session-id", "test-image-id", 2, 4)
+		resp, httpResp, err := apiClient.ImageAPI.StartImage(ctx, "test-login-token", "test-session-id", "test-image-id", 2, 4, false)
 
 		// Verify results
 		assert.NoError(t, err)
@@ -664,7 +665,7 @@ func TestImageAPIService_StartImage(t *testing.T) {
 
 		// Call StartImage API with zero values for optional parameters
 		ctx := context.Background()
-		resp, httpResp, err := apiClient.ImageAPI.StartImage(ctx, "test-login-token", "test-session-id", "test-image-id", 0, 0)
+		resp, httpResp, err := apiClient.ImageAPI.StartImage(ctx, "test-login-token", "test-session-id", "test-image-id", 0, 0, false)
 
 		// Verify results
 		assert.NoError(t, err)
@@ -679,7 +680,7 @@ func TestImageAPIService_StartImage(t *testing.T) {
 		apiClient := client.NewAPIClient(cfg)
 
 		ctx := context.Background()
-		_, _, err := apiClient.ImageAPI.StartImage(ctx, "", "test-session-id", "test-image-id", 2, 4)
+		_, _, err := apiClient.ImageAPI.StartImage(ctx, "", "test-session-id", "test-image-id", 2, 4, false)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "loginToken parameter is required")
@@ -690,7 +691,7 @@ func TestImageAPIService_StartImage(t *testing.T) {
 		apiClient := client.NewAPIClient(cfg)
 
 		ctx := context.Background()
-		_, _, err := apiClient.ImageAPI.StartImage(ctx, "test-login-token", "", "test-image-id", 2, 4)
+		_, _, err := apiClient.ImageAPI.StartImage(ctx, "test-login-token", "", "test-image-id", 2, 4, false)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "sessionId parameter is required")
@@ -701,7 +702,7 @@ func TestImageAPIService_StartImage(t *testing.T) {
 		apiClient := client.NewAPIClient(cfg)
 
 		ctx := context.Background()
-		_, _, err := apiClient.ImageAPI.StartImage(ctx, "test-login-token", "test-session-id", "", 2, 4)
+		_, _, err := apiClient.ImageAPI.StartImage(ctx, "test-login-token", "test-session-id", "", 2, 4, false)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "imageId parameter is required")
@@ -729,7 +730,7 @@ func TestImageAPIService_StartImage(t *testing.T) {
 		apiClient := client.NewAPIClient(cfg)
 
 		ctx := context.Background()
-		_, httpResp, err := apiClient.ImageAPI.StartImage(ctx, "test-login-token", "test-session-id", "invalid-image-id", 2, 4)
+		_, httpResp, err := apiClient.ImageAPI.StartImage(ctx, "test-login-token", "test-session-id", "invalid-image-id", 2, 4, false)
 
 		// Should return error due to HTTP status >= 300
 		assert.Error(t, err)
@@ -749,7 +750,7 @@ func TestImageAPIService_StartImage(t *testing.T) {
 		apiClient := client.NewAPIClient(cfg)
 
 		ctx := context.Background()
-		_, _, err := apiClient.ImageAPI.StartImage(ctx, "test-login-token", "test-session-id", "test-image-id", 2, 4)
+		_, _, err := apiClient.ImageAPI.StartImage(ctx, "test-login-token", "test-session-id", "test-image-id", 2, 4, false)
 
 		assert.Error(t, err)
 		// Should be a network error, not an API error
@@ -806,7 +807,7 @@ func TestImageAPIService_StopImage(t *testing.T) {
 
 		// Call StopImage API
 		ctx := context.Background()
-		resp, httpResp, err := apiClient.ImageAPI.StopImage(ctx, "test-login-token", "test-session-id", "test-image-id")
+		resp, httpResp, err := apiClient.ImageAPI.StopImage(ctx, "test-login-token", "test-session-id", "test-image-id", client.ImageStopOptions{})
 
 		// Verify results
 		assert.NoError(t, err)
@@ -825,7 +826,7 @@ func TestImageAPIService_StopImage(t *testing.T) {
 		apiClient := client.NewAPIClient(cfg)
 
 		ctx := context.Background()
-		_, _, err := apiClient.ImageAPI.StopImage(ctx, "", "test-session-id", "test-image-id")
+		_, _, err := apiClient.ImageAPI.StopImage(ctx, "", "test-session-id", "test-image-id", client.ImageStopOptions{})
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "loginToken parameter is required")
@@ -836,7 +837,7 @@ func TestImageAPIService_StopImage(t *testing.T) {
 		apiClient := client.NewAPIClient(cfg)
 
 		ctx := context.Background()
-		_, _, err := apiClient.ImageAPI.StopImage(ctx, "test-login-token", "", "test-image-id")
+		_, _, err := apiClient.ImageAPI.StopImage(ctx, "test-login-token", "", "test-image-id", client.ImageStopOptions{})
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "sessionId parameter is required")
@@ -847,7 +848,7 @@ func TestImageAPIService_StopImage(t *testing.T) {
 		apiClient := client.NewAPIClient(cfg)
 
 		ctx := context.Background()
-		_, _, err := apiClient.ImageAPI.StopImage(ctx, "test-login-token", "test-session-id", "")
+		_, _, err := apiClient.ImageAPI.StopImage(ctx, "test-login-token", "test-session-id", "", client.ImageStopOptions{})
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "imageId parameter is required")
@@ -875,7 +876,7 @@ func TestImageAPIService_StopImage(t *testing.T) {
 		apiClient := client.NewAPIClient(cfg)
 
 		ctx := context.Background()
-		_, httpResp, err := apiClient.ImageAPI.StopImage(ctx, "test-login-token", "test-session-id", "invalid-image-id")
+		_, httpResp, err := apiClient.ImageAPI.StopImage(ctx, "test-login-token", "test-session-id", "invalid-image-id", client.ImageStopOptions{})
 
 		// Should return error due to HTTP status >= 300
 		assert.Error(t, err)
@@ -895,7 +896,7 @@ func TestImageAPIService_StopImage(t *testing.T) {
 		apiClient := client.NewAPIClient(cfg)
 
 		ctx := context.Background()
-		_, _, err := apiClient.ImageAPI.StopImage(ctx, "test-login-token", "test-session-id", "test-image-id")
+		_, _, err := apiClient.ImageAPI.StopImage(ctx, "test-login-token", "test-session-id", "test-image-id", client.ImageStopOptions{})
 
 		assert.Error(t, err)
 		// Should be a network error, not an API error
@@ -0,0 +1,130 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/workpool"
+)
+
+func TestWorkpoolRun(t *testing.T) {
+	var mu sync.Mutex
+	running, peak := 0, 0
+	done := make([]bool, 20)
+	workpool.Run(3, len(done), func(i int) {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+
+		done[i] = true
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+	assert.LessOrEqual(t, peak, 3)
+	for i, ok := range done {
+		assert.True(t, ok, "item %d", i)
+	}
+
+	var order []int
+	workpool.Run(0, 4, func(i int) { order = append(order, i) })
+	assert.Equal(t, []int{0, 1, 2, 3}, order, "one worker runs the items in order")
+}
+
+func TestRunImageBatchOperation(t *testing.T) {
+	useTempConfigDir(t)
+	_, apiClient := newSeededMockServer(t, 3, "IMAGE_AVAILABLE", "RESOURCE_PUBLISHED", "IMAGE_AVAILABLE")
+	refs := []string{"img-mock0001", "img-mock0002", "img-mock0003", "img-missing"}
+
+	results := cmd.RunImageBatchOperation(context.Background(), apiClient, "token", "session", refs, cmd.ImageOperationOptions{
+		Operation:   cmd.ImageOperationActivate,
+		Concurrency: 2,
+		Clock:       instantClock{},
+	})
+	require.Len(t, results, 4)
+	assert.Equal(t, "activated", results[0].Result)
+	assert.Equal(t, "RESOURCE_PUBLISHED", results[0].Status)
+	assert.Equal(t, "unchanged", results[1].Result, "activated images are left alone")
+	assert.Equal(t, "activated", results[2].Result)
+	assert.Equal(t, "img-missing", results[3].Image, "results keep the order of the references")
+	assert.Equal(t, "failed", results[3].Result)
+	assert.Contains(t, results[3].Error, "image not found")
+	assert.Equal(t, "ImageNotFound", results[3].Code)
+	for _, id := range refs[:3] {
+		assert.Equal(t, "RESOURCE_PUBLISHED", imageStatus(t, apiClient, id))
+	}
+
+	results = cmd.RunImageBatchOperation(context.Background(), apiClient, "token", "session", refs[:3], cmd.ImageOperationOptions{
+		Operation:   cmd.ImageOperationDeactivate,
+		Concurrency: 1,
+		Clock:       instantClock{},
+	})
+	for i, result := range results {
+		assert.Equal(t, "deactivated", result.Result, refs[i])
+		assert.Equal(t, "IMAGE_AVAILABLE", imageStatus(t, apiClient, refs[i]))
+	}
+
+	// Deactivating images without an instance changes nothing
+	results = cmd.RunImageBatchOperation(context.Background(), apiClient, "token", "session", refs[:1], cmd.ImageOperationOptions{
+		Operation: cmd.ImageOperationDeactivate,
+		Clock:     instantClock{},
+	})
+	assert.Equal(t, "unchanged", results[0].Result)
+}
+
+func TestRunImageBatchOperationCancelled(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 1, "IMAGE_AVAILABLE")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results := cmd.RunImageBatchOperation(ctx, apiClient, "token", "session", []string{"img-mock0001"}, cmd.ImageOperationOptions{Operation: cmd.ImageOperationActivate})
+	assert.Equal(t, "failed", results[0].Result)
+	assert.Contains(t, results[0].Error, "interrupted before the request was sent")
+	assert.Equal(t, "Interrupted", results[0].Code)
+	assert.Equal(t, "IMAGE_AVAILABLE", imageStatus(t, apiClient, "img-mock0001"))
+}
+
+func TestImageBatchCommandValidation(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 2, "IMAGE_AVAILABLE")
+	ids := []string{"img-mock0001", "img-mock0002"}
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "activate", ids, "--lease", "2h")
+	require.Error(t, err)
+	assert.Contains(t, stdout+err.Error(), "--lease works with a single image, got 2")
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "deactivate", ids, "--concurrency", "0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid --concurrency value: 0")
+
+	// The configured concurrency is checked as well
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cfg.Concurrency = 50
+	require.NoError(t, cfg.Save())
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "activate", ids)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid concurrency setting value: 50")
+
+	for _, id := range ids {
+		assert.Equal(t, "IMAGE_AVAILABLE", imageStatus(t, apiClient, id), "nothing was sent")
+	}
+}
+
+func TestConcurrencyIsShareable(t *testing.T) {
+	cfg := &config.Config{Concurrency: 2}
+	assert.Equal(t, 2, cfg.Export().Concurrency)
+	assert.NoError(t, cmd.ValidateSharedConfig(cfg.Export()))
+	assert.Error(t, cmd.ValidateSharedConfig(config.SharedConfig{Concurrency: 11}))
+}
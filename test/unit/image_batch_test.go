@@ -0,0 +1,178 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+)
+
+// instantClock fires every wait immediately and is safe for concurrent use
+type instantClock struct{}
+
+func (instantClock) Now() time.Time { return time.Now() }
+
+func (instantClock) After(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+// writeBatchFile writes a batch file and a Dockerfile for each of dockerfiles into a temporary directory
+func writeBatchFile(t *testing.T, content string, dockerfiles ...string) string {
+	dir := t.TempDir()
+	for _, name := range dockerfiles {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("FROM ubuntu:22.04\nRUN echo hello\n"), 0644))
+	}
+	path := filepath.Join(dir, "batch.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoadImageBatch(t *testing.T) {
+	path := writeBatchFile(t, `images:
+  - name: web-server
+    dockerfile: web/Dockerfile
+    source: agb-code-space-1
+  - name: browser-tools
+    dockerfile: Dockerfile
+    source: agb-browser-use-1
+`, "web/Dockerfile", "Dockerfile")
+
+	entries, err := cmd.LoadImageBatch(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "web-server", entries[0].Name)
+	assert.Equal(t, filepath.Join(filepath.Dir(path), "web", "Dockerfile"), entries[0].Dockerfile,
+		"dockerfile paths are relative to the batch file")
+	assert.Equal(t, "agb-browser-use-1", entries[1].Source)
+}
+
+func TestLoadImageBatchReportsEveryProblem(t *testing.T) {
+	path := writeBatchFile(t, `images:
+  - name: web
+    dockerfile: Dockerfile
+    source: agb-code-space-1
+  - name: web
+    dockerfile: Dockerfile
+    source: agb-code-space-1
+  - name: 1-bad
+    dockerfile: missing/Dockerfile
+  - dockerfile: Dockerfile
+    source: agb-code-space-1
+`, "Dockerfile")
+
+	_, err := cmd.LoadImageBatch(path)
+	require.Error(t, err)
+	problems := strings.Split(err.Error(), "\n")
+	assert.Len(t, problems, 5)
+	assert.Contains(t, err.Error(), "images[1] (web): duplicate image name, already used by images[0]")
+	assert.Contains(t, err.Error(), "images[2] (1-bad): invalid image name")
+	assert.Contains(t, err.Error(), "images[2] (1-bad): source is required")
+	assert.Contains(t, err.Error(), "images[2] (1-bad): dockerfile not found")
+	assert.Contains(t, err.Error(), "images[3]: name is required")
+
+	_, err = cmd.LoadImageBatch(writeBatchFile(t, "images:\n  - name: web\n    imageId: agb-code-space-1\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "field imageId not found", "unknown keys are rejected")
+
+	_, err = cmd.LoadImageBatch(writeBatchFile(t, ""))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "lists no images")
+}
+
+func TestBuildImageBatch(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 0)
+	path := writeBatchFile(t, `images:
+  - name: web-server
+    dockerfile: Dockerfile
+    source: agb-code-space-1
+  - name: broken
+    dockerfile: Dockerfile
+    source: no-such-image
+  - name: browser-tools
+    dockerfile: Dockerfile
+    source: agb-browser-use-1
+`, "Dockerfile")
+	entries, err := cmd.LoadImageBatch(path)
+	require.NoError(t, err)
+
+	results := cmd.BuildImageBatch(context.Background(), apiClient, "token", "session", entries, cmd.ImageBatchOptions{
+		Parallel: 2,
+		Clock:    instantClock{},
+	})
+	require.Len(t, results, 3)
+
+	assert.Equal(t, "web-server", results[0].Name, "results keep the order of the batch file")
+	assert.Equal(t, "created", results[0].Result)
+	assert.NotEmpty(t, results[0].TaskID)
+	assert.NotEmpty(t, results[0].ImageID)
+	assert.True(t, strings.HasPrefix(results[0].Digest, "sha256:"), "the digest of the built image is reported")
+
+	assert.Equal(t, "failed", results[1].Result)
+	assert.NotEmpty(t, results[1].TaskID, "the task ID of a failed build is reported")
+	assert.Contains(t, results[1].Error, "failed to create image: SourceImageNotFound")
+	assert.Equal(t, "SourceImageNotFound", results[1].Code, "failed builds have the error code of the server")
+	assert.True(t, strings.HasPrefix(results[1].RequestID, "mock-request-"), "and its request ID")
+
+	assert.Equal(t, "created", results[2].Result)
+	assert.NotEqual(t, results[0].TaskID, results[2].TaskID)
+
+	// Building the batch again finds the images that already exist
+	results = cmd.BuildImageBatch(context.Background(), apiClient, "token", "session", entries[:1], cmd.ImageBatchOptions{Clock: instantClock{}})
+	assert.Equal(t, "failed", results[0].Result)
+	assert.Empty(t, results[0].TaskID)
+	assert.Contains(t, results[0].Error, "already exists")
+	assert.Equal(t, "ImageAlreadyExists", results[0].Code)
+	assert.Empty(t, results[0].RequestID)
+}
+
+func TestBuildImageBatchCancelled(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 0)
+	entries, err := cmd.LoadImageBatch(writeBatchFile(t, `images:
+  - name: web-server
+    dockerfile: Dockerfile
+    source: agb-code-space-1
+`, "Dockerfile"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results := cmd.BuildImageBatch(ctx, apiClient, "token", "session", entries, cmd.ImageBatchOptions{})
+	assert.Equal(t, "failed", results[0].Result)
+	assert.Contains(t, results[0].Error, "interrupted before the build started")
+}
+
+func TestImageCreateBatchValidation(t *testing.T) {
+	useTempConfigDir(t)
+
+	_, stderr, err := runSubcommand(t, cmd.ImageCmd, "", "create-batch", nil)
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] Missing required flag: --file")
+
+	_, stderr, err = runSubcommand(t, cmd.ImageCmd, "", "create-batch", nil, "--file", "batch.yaml", "--parallel", "0")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] Invalid --parallel value: 0")
+
+	_, stderr, err = runSubcommand(t, cmd.ImageCmd, "", "create-batch", nil, "--file", "batch.yaml", "--concurrency", "11")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] Invalid --concurrency value: 11")
+
+	path := writeBatchFile(t, "images:\n  - name: web\n    dockerfile: Dockerfile\n")
+	_, stderr, err = runSubcommand(t, cmd.ImageCmd, "", "create-batch", nil, "--file", path)
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] Invalid batch file")
+	assert.Contains(t, stderr, "• images[0] (web): source is required")
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+)
+
+func TestEstimateBuildDuration(t *testing.T) {
+	useTempConfigDir(t)
+	const endpoint = "https://agb.example.com"
+
+	estimate, err := cmd.EstimateBuildDuration(endpoint, "agb-code-space-2")
+	require.NoError(t, err)
+	assert.Nil(t, estimate, "no estimate without history")
+
+	require.NoError(t, cmd.RecordBuildDuration(endpoint, "agb-code-space-2", 11*time.Minute))
+	estimate, err = cmd.EstimateBuildDuration(endpoint, "agb-code-space-2")
+	require.NoError(t, err)
+	assert.Nil(t, estimate, "a single build is not enough")
+
+	require.NoError(t, cmd.RecordBuildDuration(endpoint, "agb-code-space-2", 12*time.Minute))
+	require.NoError(t, cmd.RecordBuildDuration(endpoint, "agb-code-space-2", 50*time.Minute))
+	estimate, err = cmd.EstimateBuildDuration(endpoint, "agb-code-space-2")
+	require.NoError(t, err)
+	require.NotNil(t, estimate)
+	assert.Equal(t, 12*time.Minute, estimate.Typical, "one slow build does not skew the estimate")
+	assert.Equal(t, 3, estimate.Samples)
+	assert.Equal(t, "Builds from agb-code-space-2 typically take ~12m (based on 3 builds)", cmd.FormatBuildEstimate(*estimate))
+
+	// Other source images and endpoints have their own history
+	estimate, err = cmd.EstimateBuildDuration(endpoint, "agb-browser-use-1")
+	require.NoError(t, err)
+	assert.Nil(t, estimate)
+	estimate, err = cmd.EstimateBuildDuration("https://other.example.com", "agb-code-space-2")
+	require.NoError(t, err)
+	assert.Nil(t, estimate)
+}
+
+func TestBuildHistoryKeepsRecentBuilds(t *testing.T) {
+	useTempConfigDir(t)
+	const endpoint = "https://agb.example.com"
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, cmd.RecordBuildDuration(endpoint, "agb-code-space-1", time.Hour))
+	}
+	for i := 0; i < 20; i++ {
+		require.NoError(t, cmd.RecordBuildDuration(endpoint, "agb-code-space-1", 90*time.Second))
+	}
+
+	estimate, err := cmd.EstimateBuildDuration(endpoint, "agb-code-space-1")
+	require.NoError(t, err)
+	require.NotNil(t, estimate)
+	assert.Equal(t, 20, estimate.Samples)
+	assert.Equal(t, 90*time.Second, estimate.Typical, "old builds are forgotten")
+}
+
+func TestFormatBuildEstimateDurations(t *testing.T) {
+	for typical, expected := range map[time.Duration]string{
+		45 * time.Second:                "~45s",
+		12*time.Minute + 20*time.Second: "~12m",
+		59*time.Minute + 50*time.Second: "~1h",
+		80 * time.Minute:                "~1h20m",
+		2*time.Hour + 10*time.Second:    "~2h",
+	} {
+		text := cmd.FormatBuildEstimate(cmd.BuildEstimate{SourceImageID: "agb-code-space-1", Typical: typical, Samples: 2})
+		assert.Contains(t, text, "typically take "+expected+" ", typical.String())
+	}
+}
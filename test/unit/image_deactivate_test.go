@@ -0,0 +1,96 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/mockserver"
+)
+
+func TestStopImageGracePeriod(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 2, "RESOURCE_PUBLISHED")
+	ctx := context.Background()
+
+	_, _, err := apiClient.ImageAPI.StopImage(ctx, "token", "session", "img-mock0001", client.ImageStopOptions{GracePeriod: time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, "RESOURCE_DELETING", imageStatus(t, apiClient, "img-mock0001"))
+	assert.Equal(t, "RESOURCE_DELETING", imageStatus(t, apiClient, "img-mock0001"), "the workload is still shutting down")
+
+	_, _, err = apiClient.ImageAPI.StopImage(ctx, "token", "session", "img-mock0002", client.ImageStopOptions{GracePeriod: time.Hour, Force: true})
+	require.NoError(t, err)
+	assert.Equal(t, "RESOURCE_DELETING", imageStatus(t, apiClient, "img-mock0002"))
+	assert.Equal(t, "IMAGE_AVAILABLE", imageStatus(t, apiClient, "img-mock0002"), "--force skips the grace period")
+}
+
+func TestValidateDeactivateGrace(t *testing.T) {
+	for _, grace := range []time.Duration{0, time.Second, 90 * time.Second, time.Hour} {
+		assert.NoError(t, cmd.ValidateDeactivateGrace(grace, false), grace)
+	}
+	assert.NoError(t, cmd.ValidateDeactivateGrace(0, true))
+
+	captureStderr(func() {
+		for _, grace := range []time.Duration{-time.Second, 500 * time.Millisecond, 1500 * time.Millisecond, 2 * time.Hour} {
+			err := cmd.ValidateDeactivateGrace(grace, false)
+			require.Error(t, err, grace)
+			assert.Contains(t, err.Error(), "Invalid --wait-grace value")
+		}
+		err := cmd.ValidateDeactivateGrace(30*time.Second, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--wait-grace cannot be combined with --force")
+	})
+}
+
+func TestImageDeactivateWaitGrace(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	backend := mockserver.New()
+	_, err := backend.Seed(mockserver.SeedRequest{Images: 1, Statuses: []string{"RESOURCE_PUBLISHED"}, Seed: 1})
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var stopRequest client.ImageStopRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/image/stop" {
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			_ = json.Unmarshal(body, &stopRequest)
+			mu.Unlock()
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		backend.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	// The first status check comes after one poll interval; stop watching after it
+	deactivateCmd := findSubcommand(t, cmd.ImageCmd, "deactivate")
+	ctx, cancel := context.WithTimeout(context.Background(), 6*time.Second)
+	defer cancel()
+	deactivateCmd.SetContext(ctx)
+	t.Cleanup(func() { deactivateCmd.SetContext(context.Background()) })
+
+	stdout, _, _ := runSubcommand(t, cmd.ImageCmd, server.URL, "deactivate", []string{"img-mock0001"}, "--wait-grace", "10m")
+	assert.Contains(t, stdout, "[WAIT] The workload has 10m0s to shut down")
+	assert.Regexp(t, `\[WAIT\] Grace period: 9m5\ds left for the workload to shut down`, stdout)
+	assert.NotContains(t, stdout, "[SUCCESS]")
+	assert.Equal(t, "RESOURCE_DELETING", imageStatus(t, newLogsTestClient(server.URL), "img-mock0001"))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 600, stopRequest.GracePeriodSeconds)
+	assert.False(t, stopRequest.Force)
+}
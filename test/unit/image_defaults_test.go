@@ -0,0 +1,131 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/mockserver"
+)
+
+func TestParseEnvAssignment(t *testing.T) {
+	name, value, err := cmd.ParseEnvAssignment("LOG_LEVEL=debug")
+	require.NoError(t, err)
+	assert.Equal(t, "LOG_LEVEL", name)
+	assert.Equal(t, "debug", value)
+
+	name, value, err = cmd.ParseEnvAssignment("URL=http://x?a=b")
+	require.NoError(t, err)
+	assert.Equal(t, "URL", name)
+	assert.Equal(t, "http://x?a=b", value, "only the first '=' separates the value")
+
+	_, value, err = cmd.ParseEnvAssignment("EMPTY=")
+	require.NoError(t, err)
+	assert.Empty(t, value)
+
+	for _, invalid := range []string{"NOVALUE", "=x", "1ST=x", "MY-VAR=x"} {
+		_, _, err := cmd.ParseEnvAssignment(invalid)
+		assert.Error(t, err, "assignment %q", invalid)
+	}
+}
+
+func TestFormatActivationDefaults(t *testing.T) {
+	assert.Equal(t, "none", cmd.FormatActivationDefaults(nil))
+	assert.Equal(t, "none", cmd.FormatActivationDefaults(&client.ImageActivationDefaults{}))
+	assert.Equal(t, "4c8g", cmd.FormatActivationDefaults(&client.ImageActivationDefaults{CPU: 4, Memory: 8}))
+	assert.Equal(t, "2c4g, env A=1 B=", cmd.FormatActivationDefaults(&client.ImageActivationDefaults{CPU: 2, Memory: 4, Env: map[string]string{"B": "", "A": "1"}}))
+}
+
+func TestImageSetDefaultsAndActivate(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 1, "IMAGE_AVAILABLE")
+	listResp, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, listResp.Data.Images, 1)
+	imageId := listResp.Data.Images[0].ImageID
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "set-defaults", []string{imageId}, "--spec", "4c8g", "--env", "LOG_LEVEL=debug", "--env", "REGION=eu")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[DATA] Activation Defaults: 4c8g, env LOG_LEVEL=debug REGION=eu")
+
+	// Later changes keep the other defaults
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "set-defaults", []string{imageId}, "--unset-env", "REGION", "--env", "LOG_LEVEL=info")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[DATA] Activation Defaults: 4c8g, env LOG_LEVEL=info")
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "status", []string{imageId})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[DATA] Activation Defaults: 4c8g, env LOG_LEVEL=info")
+
+	// An activation without --cpu and --memory uses the default spec
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "activate", []string{imageId}, "--detach")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[SAVE] CPU: 4 cores, Memory: 8 GB (default of the image)")
+	assert.Contains(t, stdout, "[NOTE] The server sets 1 default environment variable(s) of the image")
+	listResp, _, err = apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{imageId}})
+	require.NoError(t, err)
+	require.NotNil(t, listResp.Data.Images[0].CPU)
+	assert.Equal(t, 4, *listResp.Data.Images[0].CPU)
+	assert.Equal(t, 8, *listResp.Data.Images[0].Memory)
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "set-defaults", []string{imageId}, "--clear")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Default activation settings of")
+	assert.Contains(t, stdout, "removed")
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "status", []string{imageId})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[DATA] Activation Defaults: none")
+}
+
+func TestImageSetDefaultsValidatesFlags(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+
+	_, _, err := runSubcommand(t, cmd.ImageCmd, "http://127.0.0.1:1", "set-defaults", []string{"img-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Nothing to change")
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, "http://127.0.0.1:1", "set-defaults", []string{"img-1"}, "--clear", "--spec", "2c4g")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--clear cannot be combined")
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, "http://127.0.0.1:1", "set-defaults", []string{"img-1"}, "--spec", "3c5g")
+	require.Error(t, err)
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, "http://127.0.0.1:1", "set-defaults", []string{"img-1"}, "--env", "NOVALUE")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid --env value")
+}
+
+func TestImageSetDefaultsUnsupportedServer(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	backend := mockserver.New()
+	_, err := backend.Seed(mockserver.SeedRequest{Images: 1, Seed: 1})
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/image/update" {
+			http.NotFound(w, r)
+			return
+		}
+		backend.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	listResp, _, err := newLogsTestClient(server.URL).ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, listResp.Data.Images, 1)
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "set-defaults", []string{listResp.Data.Images[0].ImageID}, "--spec", "2c4g")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support default activation settings")
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	assert.Empty(t, cmd.UnifiedDiff("FROM a\nRUN b\n", "FROM a\r\nRUN b", "l", "r"), "line endings are ignored")
+
+	left := "FROM agb-code-space-1\nRUN apt-get update\nRUN pip install flask\nCOPY . /app\nWORKDIR /app\nEXPOSE 80\nENV A=1\nENV B=2\nCMD [\"python\", \"app.py\"]\n"
+	right := "FROM agb-code-space-1\nRUN apt-get update\nRUN pip install django\nCOPY . /app\nWORKDIR /app\nEXPOSE 80\nENV A=1\nENV B=2\nCMD [\"python\", \"app.py\"]\nUSER app\n"
+	assert.Equal(t, `--- img-1/Dockerfile
++++ img-2/Dockerfile
+@@ -1,6 +1,6 @@
+ FROM agb-code-space-1
+ RUN apt-get update
+-RUN pip install flask
++RUN pip install django
+ COPY . /app
+ WORKDIR /app
+ EXPOSE 80
+@@ -7,3 +7,4 @@
+ ENV A=1
+ ENV B=2
+ CMD ["python", "app.py"]
++USER app
+`, cmd.UnifiedDiff(left, right, "img-1/Dockerfile", "img-2/Dockerfile"))
+
+	assert.Equal(t, "--- l\n+++ r\n@@ -0,0 +1,1 @@\n+FROM a\n", cmd.UnifiedDiff("", "FROM a", "l", "r"))
+}
+
+func TestCompareImageManifests(t *testing.T) {
+	cpu, memory := 2, 4
+	left := client.ImageManifestData{
+		ImageID: "img-1", ImageName: "web", SourceImageID: "agb-code-space-1", SourceImageVersion: "1.1.0",
+		CPU: &cpu, Memory: &memory, Labels: map[string]string{"team": "ml", "tier": "dev"},
+		Platforms: []string{"linux/arm64", "linux/amd64"}, Dockerfile: "FROM agb-code-space-1\n",
+	}
+	right := client.ImageManifestData{
+		ImageID: "img-2", ImageName: "web", SourceImageID: "agb-code-space-1", SourceImageVersion: "1.2.0",
+		Labels:    map[string]string{"team": "ml", "owner": "ops"},
+		Platforms: []string{"linux/amd64", "linux/arm64"}, DockerfileDigest: "sha256:abc",
+	}
+
+	diff := cmd.CompareImageManifests(left, right)
+	fields := map[string]cmd.ImageFieldDiff{}
+	for _, field := range diff.Fields {
+		fields[field.Field] = field
+	}
+	assert.False(t, fields["Name"].Differs)
+	assert.Equal(t, "agb-code-space-1@1.1.0", fields["Base Image"].Left)
+	assert.True(t, fields["Base Image"].Differs)
+	assert.Equal(t, "2c4g", fields["Resources"].Left)
+	assert.Equal(t, "-", fields["Resources"].Right)
+	assert.False(t, fields["Platforms"].Differs, "platform order does not matter")
+	assert.False(t, fields["Label team"].Differs)
+	assert.Equal(t, "-", fields["Label owner"].Left)
+	assert.Equal(t, "-", fields["Label tier"].Right)
+	assert.True(t, strings.HasPrefix(fields["Dockerfile"].Left, "sha256:"), "the digest is computed from the content")
+	assert.Equal(t, "sha256:abc", fields["Dockerfile"].Right)
+	assert.Equal(t, 5, diff.Differences)
+	assert.Empty(t, diff.DockerfileDiff, "only readable Dockerfiles are compared line by line")
+}
+
+// createMockImage uploads dockerfile to the mock server and creates an image from it
+func createMockImage(t *testing.T, apiClient *client.APIClient, name, dockerfile string) string {
+	ctx := context.Background()
+	credential, _, err := apiClient.ImageAPI.GetUploadCredential(ctx, "token", "session")
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPut, credential.Data.OssURL, strings.NewReader(dockerfile))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	created, _, err := apiClient.ImageAPI.CreateImage(ctx, "token", "session", name, credential.Data.TaskID, "agb-code-space-1", client.ImageCreateOptions{})
+	require.NoError(t, err)
+	return created.Data
+}
+
+func TestImageDiffCommand(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 0)
+	first := createMockImage(t, apiClient, "web", "FROM agb-code-space-1\nRUN pip install flask\n")
+	second := createMockImage(t, apiClient, "web", "FROM agb-code-space-1\nRUN pip install django\n")
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "diff", []string{first, second})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[DIFF] Comparing "+first+" (web) with "+second+" (web)")
+	assert.Contains(t, stdout, "Dockerfile  sha256:")
+	assert.NotContains(t, stdout, "Name ", "fields that are equal are not listed")
+	assert.Contains(t, stdout, "-RUN pip install flask\n+RUN pip install django\n")
+	assert.Contains(t, stdout, "[DATA] 1 field(s) differ")
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "diff", []string{first, first}, "--all")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Name ")
+	assert.Contains(t, stdout, "[OK] The images do not differ")
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "diff", []string{first})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Expected 2 arguments")
+}
+
+func TestImageDiffWithoutManifests(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	_, apiClient := newSeededMockServer(t, 0)
+	first := createMockImage(t, apiClient, "a", "FROM agb-code-space-1\n")
+	second := createMockImage(t, apiClient, "b", "FROM agb-code-space-1\n")
+	backend := apiClient.GetConfig().Servers[0].URL
+
+	// An older server without the manifest endpoint
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/image/manifest" {
+			http.NotFound(w, r)
+			return
+		}
+		http.Redirect(w, r, backend+r.URL.RequestURI(), http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "diff", []string{first, second})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[NOTE] This AgbCloud endpoint does not provide image manifests")
+	assert.Regexp(t, `Name\s+a\s+b`, stdout)
+	assert.NotContains(t, stdout, "[DIFF] Dockerfile:")
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+const testDigest = "sha256:3f2a9c1b7e4d5a6b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f0a1b"
+
+func TestShortDigest(t *testing.T) {
+	assert.Equal(t, "3f2a9c1b7e4d", cmd.ShortDigest(testDigest))
+	assert.Equal(t, "-", cmd.ShortDigest(""), "images without a reported digest")
+
+	assert.True(t, cmd.IsImageDigest(testDigest))
+	assert.True(t, cmd.IsImageDigest("SHA256:3f2a9c1b7e4d"))
+	assert.False(t, cmd.IsImageDigest("img-7a8b9c1d0e"))
+}
+
+func TestMatchImageDigest(t *testing.T) {
+	images := []client.ImageInfo{
+		{ImageID: "img-1", Digest: testDigest},
+		{ImageID: "img-2", Digest: "sha256:3f2a9c1b7e4d000000000000000000000000000000000000000000000000ffff"},
+		{ImageID: "img-3"},
+	}
+
+	matches := cmd.MatchImageDigest(images, testDigest)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "img-1", matches[0].ImageID)
+
+	assert.Len(t, cmd.MatchImageDigest(images, "sha256:3f2a9c1b7e4d"), 2, "a short prefix can be ambiguous")
+	assert.Len(t, cmd.MatchImageDigest(images, strings.ToUpper(testDigest[:20])), 1, "digests are compared case-insensitively")
+	assert.Empty(t, cmd.MatchImageDigest(images, "sha256:ffffffffffff"))
+}
+
+func TestMockServerReportsDigests(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 4, "IMAGE_AVAILABLE", "IMAGE_CREATE_FAILED")
+
+	resp, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	items := cmd.NewImageListItems(resp.Data.Images)
+	require.Len(t, items, 4)
+	assert.True(t, strings.HasPrefix(items[0].Digest, "sha256:"), "built images have a digest")
+	assert.Empty(t, items[1].Digest, "failed builds have none")
+
+	matches := cmd.MatchImageDigest(resp.Data.Images, items[0].Digest[:19])
+	require.Len(t, matches, 1)
+	assert.Equal(t, items[0].ImageID, matches[0].ImageID)
+}
+
+func TestDeactivateByDigest(t *testing.T) {
+	tests := []struct {
+		name     string
+		ref      string
+		expected string
+	}{
+		{"too short", "sha256:3f2a", "[ERROR] Invalid image digest 'sha256:3f2a'"},
+		{"not hex", "sha256:zzzzzzzzzzzzzz", "is not a hex digest"},
+		{"unknown", "sha256:ffffffffffffffff", "[ERROR] No image with digest sha256:ffffffffffffffff"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useTempConfigDir(t)
+			server, _ := newSeededMockServer(t, 3, "RESOURCE_PUBLISHED")
+			saveTestTokens(t)
+
+			_, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "deactivate", []string{tt.ref})
+			require.Error(t, err)
+			assert.Contains(t, stderr, tt.expected)
+		})
+	}
+}
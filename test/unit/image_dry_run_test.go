@@ -0,0 +1,74 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestFormatDryRunRequestMasksTokens(t *testing.T) {
+	request := client.NewImageStopRequest("login-token-0123456789", "session-0123456789", "img-1", client.ImageStopOptions{GracePeriod: 30 * time.Second})
+	rendered, err := cmd.FormatDryRunRequest(request)
+	require.NoError(t, err)
+	assert.Contains(t, rendered, `"imageId": "img-1"`)
+	assert.Contains(t, rendered, `"gracePeriodSeconds": 30`)
+	assert.NotContains(t, rendered, "login-token-0123456789")
+	assert.NotContains(t, rendered, "session-0123456789")
+	assert.NotContains(t, rendered, "force", "omitted fields are not shown")
+}
+
+func TestImageActivateDryRun(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 2, "IMAGE_AVAILABLE", "RESOURCE_PUBLISHED")
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "activate", []string{"img-mock0001"}, "--cpu", "4", "--memory", "8", "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[DATA] Current Status: Available")
+	assert.Contains(t, stdout, "[DRY-RUN] Would send POST "+server.URL+"/api/image/start with:")
+	assert.Contains(t, stdout, `"imageId": "img-mock0001"`)
+	assert.Contains(t, stdout, `"cpu": 4`)
+	assert.Contains(t, stdout, `"memory": 8`)
+	assert.Contains(t, stdout, "[DRY-RUN] The image was not activated")
+	assert.NotContains(t, stdout, "login-0", "tokens are masked")
+	assert.Equal(t, "IMAGE_AVAILABLE", imageStatus(t, apiClient, "img-mock0001"))
+
+	// The defaults derived by the CLI are part of the request
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "activate", []string{"img-mock0001"}, "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "the server's default resources would be used")
+	assert.NotContains(t, stdout, `"cpu"`)
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "activate", []string{"img-mock0002"}, "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Image is already activated!")
+	assert.Contains(t, stdout, "[DRY-RUN] No request would be sent")
+	assert.NotContains(t, stdout, "Would send")
+}
+
+func TestImageDeactivateDryRun(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 2, "RESOURCE_PUBLISHED", "IMAGE_AVAILABLE")
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "deactivate", []string{"img-mock0001"}, "--wait-grace", "30s", "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[DATA] Current Status: Activated")
+	assert.Contains(t, stdout, "[OK] The image has an instance to deactivate")
+	assert.Contains(t, stdout, "[DRY-RUN] Would send POST "+server.URL+"/api/image/stop with:")
+	assert.Contains(t, stdout, `"gracePeriodSeconds": 30`)
+	assert.Contains(t, stdout, "[DRY-RUN] The image was not deactivated")
+	assert.Equal(t, "RESOURCE_PUBLISHED", imageStatus(t, apiClient, "img-mock0001"))
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "deactivate", []string{"img-mock0002"}, "--dry-run")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "The image is not activated")
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestFormatWarmCapacity(t *testing.T) {
+	none, two := 0, 2
+	assert.Equal(t, "-", cmd.FormatWarmCapacity(nil))
+	assert.Equal(t, "none", cmd.FormatWarmCapacity(&none))
+	assert.Equal(t, "2 ready", cmd.FormatWarmCapacity(&two))
+}
+
+func TestStartImageFastStartUsesWarmCapacity(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 3, "IMAGE_AVAILABLE")
+	ctx := context.Background()
+
+	listResp, _, err := apiClient.ImageAPI.ListImages(ctx, "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10, ImageIds: []string{"img-mock0002"}})
+	require.NoError(t, err)
+	require.Len(t, listResp.Data.Images, 1)
+	require.NotNil(t, listResp.Data.Images[0].WarmInstances)
+	assert.Equal(t, 1, *listResp.Data.Images[0].WarmInstances)
+
+	startResp, _, err := apiClient.ImageAPI.StartImage(ctx, "token", "session", "img-mock0002", 0, 0, true)
+	require.NoError(t, err)
+	assert.True(t, startResp.Success)
+
+	listResp, _, err = apiClient.ImageAPI.ListImages(ctx, "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10, ImageIds: []string{"img-mock0002"}})
+	require.NoError(t, err)
+	assert.Equal(t, 0, *listResp.Data.Images[0].WarmInstances, "the warm instance was used")
+
+	// Without warm capacity the fast start is rejected; a regular start still works
+	_, _, err = apiClient.ImageAPI.StartImage(ctx, "token", "session", "img-mock0001", 0, 0, true)
+	require.Error(t, err)
+	code, _ := client.ErrorCode(err)
+	assert.Equal(t, "WarmCapacityUnavailable", code)
+
+	startResp, _, err = apiClient.ImageAPI.StartImage(ctx, "token", "session", "img-mock0001", 0, 0, false)
+	require.NoError(t, err)
+	assert.True(t, startResp.Success)
+}
+
+func TestImageActivateFastStartWithoutWarmCapacity(t *testing.T) {
+	useTempConfigDir(t)
+	server, _ := newSeededMockServer(t, 1, "IMAGE_AVAILABLE")
+	saveTestTokens(t)
+
+	stdout, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "activate", []string{"img-mock0001"}, "--fast-start")
+	require.Error(t, err)
+	assert.Contains(t, stdout, "[NOTE] Fast start: a warm instance is requested, which is billed at a higher rate")
+	assert.Contains(t, stdout, "[DATA] Warm Capacity: none")
+	assert.Contains(t, stdout, "[WARN]  No warm instance is ready for this image")
+	assert.Contains(t, stderr, "[ERROR] Failed to start image: WarmCapacityUnavailable")
+	assert.Contains(t, stderr, "Activate it without --fast-start")
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+var gcNow = time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+
+func gcImage(id, status string, age time.Duration) client.ImageInfo {
+	return client.ImageInfo{
+		ImageID:    id,
+		ImageName:  "name-" + id,
+		Status:     status,
+		Type:       "User",
+		UpdateTime: gcNow.Add(-age).Format(time.RFC3339),
+	}
+}
+
+func gcSampleImages() []client.ImageInfo {
+	day := 24 * time.Hour
+	return []client.ImageInfo{
+		gcImage("img-old-failed", "IMAGE_CREATE_FAILED", 60*day),
+		gcImage("img-newest", "IMAGE_AVAILABLE", 1*day),
+		gcImage("img-activated", "RESOURCE_PUBLISHED", 40*day),
+		gcImage("img-creating", "IMAGE_CREATING", 50*day),
+		gcImage("img-recent-unused", "IMAGE_AVAILABLE", 10*day),
+		gcImage("img-old-unused", "IMAGE_AVAILABLE", 45*day),
+		gcImage("img-second", "RESOURCE_FAILED", 2*day),
+	}
+}
+
+func gcActions(decisions []cmd.ImageGCDecision) map[string]string {
+	actions := make(map[string]string)
+	for _, d := range decisions {
+		actions[d.ImageID] = d.Action + ": " + d.Reason
+	}
+	return actions
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  time.Duration
+		expectErr bool
+	}{
+		{"", 0, false},
+		{"30d", 30 * 24 * time.Hour, false},
+		{"72h", 72 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"xd", 0, true},
+		{"-1d", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		age, err := cmd.ParseAge(tt.input)
+		if tt.expectErr {
+			assert.Error(t, err, "input %q", tt.input)
+			continue
+		}
+		assert.NoError(t, err, "input %q", tt.input)
+		assert.Equal(t, tt.expected, age, "input %q", tt.input)
+	}
+}
+
+func TestPlanImageGC(t *testing.T) {
+	t.Run("KeepLastAndActivated", func(t *testing.T) {
+		decisions, err := cmd.PlanImageGC(gcSampleImages(), config.ImageGCPolicy{KeepLast: 2, KeepActivated: true}, gcNow)
+		require.NoError(t, err)
+
+		assert.Equal(t, "img-newest", decisions[0].ImageID, "decisions are sorted newest first")
+		assert.Equal(t, map[string]string{
+			"img-newest":        "keep: among the 2 most recent",
+			"img-second":        "keep: among the 2 most recent",
+			"img-recent-unused": "delete: unused",
+			"img-activated":     "keep: activated",
+			"img-old-unused":    "delete: unused",
+			"img-creating":      "keep: operation in progress",
+			"img-old-failed":    "delete: failed",
+		}, gcActions(decisions))
+	})
+
+	t.Run("OlderThan", func(t *testing.T) {
+		decisions, err := cmd.PlanImageGC(gcSampleImages(), config.ImageGCPolicy{KeepLast: 0, KeepActivated: true, OlderThan: "30d"}, gcNow)
+		require.NoError(t, err)
+
+		actions := gcActions(decisions)
+		assert.Equal(t, "keep: updated within 30d", actions["img-newest"])
+		assert.Equal(t, "keep: updated within 30d", actions["img-second"])
+		assert.Equal(t, "keep: updated within 30d", actions["img-recent-unused"])
+		assert.Equal(t, "delete: unused", actions["img-old-unused"])
+		assert.Equal(t, "delete: failed", actions["img-old-failed"])
+	})
+
+	t.Run("ActivatedDeletedWhenNotKept", func(t *testing.T) {
+		decisions, err := cmd.PlanImageGC(gcSampleImages(), config.ImageGCPolicy{KeepLast: 0, KeepActivated: false}, gcNow)
+		require.NoError(t, err)
+
+		actions := gcActions(decisions)
+		assert.Equal(t, "delete: activated (--keep-activated=false)", actions["img-activated"])
+		assert.Equal(t, "keep: operation in progress", actions["img-creating"], "in-progress images are never deleted")
+	})
+
+	t.Run("UnknownUpdateTime", func(t *testing.T) {
+		images := []client.ImageInfo{{ImageID: "img-no-time", Status: "IMAGE_CREATE_FAILED"}}
+
+		decisions, err := cmd.PlanImageGC(images, config.ImageGCPolicy{OlderThan: "7d"}, gcNow)
+		require.NoError(t, err)
+		assert.Equal(t, "keep: update time unknown", gcActions(decisions)["img-no-time"])
+
+		decisions, err = cmd.PlanImageGC(images, config.ImageGCPolicy{}, gcNow)
+		require.NoError(t, err)
+		assert.Equal(t, "delete: failed", gcActions(decisions)["img-no-time"])
+	})
+
+	t.Run("InvalidPolicy", func(t *testing.T) {
+		_, err := cmd.PlanImageGC(gcSampleImages(), config.ImageGCPolicy{OlderThan: "forever"}, gcNow)
+		assert.Error(t, err)
+	})
+}
+
+func newGCPolicyCommand() *cobra.Command {
+	c := &cobra.Command{Use: "gc"}
+	c.Flags().Int("keep-last", 5, "")
+	c.Flags().Bool("keep-activated", true, "")
+	c.Flags().String("older-than", "", "")
+	return c
+}
+
+func TestResolveImageGCPolicy(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		policy, err := cmd.ResolveImageGCPolicy(newGCPolicyCommand(), nil)
+		require.NoError(t, err)
+		assert.Equal(t, config.ImageGCPolicy{KeepLast: 5, KeepActivated: true}, policy)
+	})
+
+	t.Run("SavedPolicyOverriddenByFlags", func(t *testing.T) {
+		c := newGCPolicyCommand()
+		require.NoError(t, c.Flags().Set("keep-last", "1"))
+
+		saved := &config.ImageGCPolicy{KeepLast: 3, KeepActivated: false, OlderThan: "14d"}
+		policy, err := cmd.ResolveImageGCPolicy(c, saved)
+		require.NoError(t, err)
+		assert.Equal(t, config.ImageGCPolicy{KeepLast: 1, KeepActivated: false, OlderThan: "14d"}, policy)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		c := newGCPolicyCommand()
+		require.NoError(t, c.Flags().Set("keep-last", "-1"))
+		captureStderr(func() {
+			_, err := cmd.ResolveImageGCPolicy(c, nil)
+			assert.Error(t, err)
+		})
+
+		c = newGCPolicyCommand()
+		require.NoError(t, c.Flags().Set("older-than", "someday"))
+		captureStderr(func() {
+			_, err := cmd.ResolveImageGCPolicy(c, nil)
+			assert.Error(t, err)
+		})
+	})
+}
+
+func TestImageGCCommand(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/image/list":
+			images := gcSampleImages()
+			_ = json.NewEncoder(w).Encode(client.ImageListResponse{ // Ignore errors in test mock server
+				Success: true,
+				Data:    client.ImageListData{Images: images, Total: len(images), Page: 1, PageSize: 50},
+			})
+		case "/api/image/delete":
+			var body client.ImageDeleteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			mu.Lock()
+			deleted = append(deleted, body.ImageId)
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"success": true, "data": true}`)) // Ignore errors in test mock server
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	useTempConfigDir(t)
+	saveTestTokens(t)
+
+	// Dry run deletes nothing but saves the policy
+	_, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "gc", nil, "--keep-last", "2", "--dry-run", "--save-policy")
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+
+	saved, err := config.GetConfig()
+	require.NoError(t, err)
+	require.NotNil(t, saved.ImageGC)
+	assert.Equal(t, config.ImageGCPolicy{KeepLast: 2, KeepActivated: true}, *saved.ImageGC)
+
+	// A real run deletes exactly the planned images
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "gc", nil, "--keep-last", "2")
+	require.NoError(t, err)
+
+	sort.Strings(deleted)
+	assert.Equal(t, []string{"img-old-failed", "img-old-unused", "img-recent-unused"}, deleted)
+}
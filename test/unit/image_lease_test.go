@@ -0,0 +1,155 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestParseLease(t *testing.T) {
+	lease, err := cmd.ParseLease("2h")
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, lease)
+
+	lease, err = cmd.ParseLease(" 90m ")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, lease)
+
+	for _, invalid := range []string{"", "2", "two hours", "10m", "200h", "-1h"} {
+		_, err := cmd.ParseLease(invalid)
+		assert.Error(t, err, "lease %q", invalid)
+	}
+}
+
+// leaseTestImage returns the only image of a seeded mock server
+func leaseTestImage(t *testing.T, apiClient *client.APIClient) client.ImageInfo {
+	listResp, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, listResp.Data.Images, 1)
+	return listResp.Data.Images[0]
+}
+
+// imageStatus returns the status of an image on the mock server
+func imageStatus(t *testing.T, apiClient *client.APIClient, imageId string) string {
+	listResp, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{imageId}})
+	require.NoError(t, err)
+	require.Len(t, listResp.Data.Images, 1)
+	return listResp.Data.Images[0].Status
+}
+
+func TestImageActivateLeaseNeedsAttachedCommand(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 1, "IMAGE_AVAILABLE")
+	image := leaseTestImage(t, apiClient)
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "activate", []string{image.ImageID}, "--lease", "2h", "--auto-renew", "1", "--detach")
+	require.Error(t, err)
+	assert.Contains(t, stdout+err.Error(), "--lease cannot be used with --detach")
+	assert.Equal(t, "IMAGE_AVAILABLE", imageStatus(t, apiClient, image.ImageID), "nothing was sent")
+	jobs, err := cmd.LoadJobs()
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestImageDeactivateEndsLease(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 1, "RESOURCE_PUBLISHED")
+	image := leaseTestImage(t, apiClient)
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+	expires := time.Now().Add(2 * time.Hour)
+	_, err := cmd.AddJob(cmd.Job{Type: cmd.JobLease, ImageID: image.ImageID, ImageName: image.ImageName, LeaseExpiresAt: &expires, LeaseDuration: "2h0m0s"})
+	require.NoError(t, err)
+
+	stdout, _, err := runSubcommand(t, cmd.JobsCmd, server.URL, "list", nil)
+	require.NoError(t, err)
+	assert.Regexp(t, `1\s+lease\s+`+image.ImageName+`\s+\S+, lease until `, stdout)
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "deactivate", []string{image.ImageID})
+	require.NoError(t, err)
+	jobs, err := cmd.LoadJobs()
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestImageActivateLeaseValidatesFlags(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+
+	_, _, err := runSubcommand(t, cmd.ImageCmd, "http://127.0.0.1:1", "activate", []string{"img-1"}, "--lease", "5m")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid lease '5m'")
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, "http://127.0.0.1:1", "activate", []string{"img-1"}, "--auto-renew", "2")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--auto-renew needs a lease")
+}
+
+func TestJobsListLeavesExpiredLeaseToEnforce(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 2, "RESOURCE_PUBLISHED", "IMAGE_AVAILABLE")
+	listResp, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, listResp.Data.Images, 2)
+	running, stopped := listResp.Data.Images[0], listResp.Data.Images[1]
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+	ended := time.Now().Add(-time.Minute)
+	for _, image := range []client.ImageInfo{running, stopped} {
+		_, err := cmd.AddJob(cmd.Job{Type: cmd.JobLease, ImageID: image.ImageID, ImageName: image.ImageName, LeaseExpiresAt: &ended, LeaseDuration: "2h0m0s"})
+		require.NoError(t, err)
+	}
+
+	// Listing jobs never deactivates anything
+	stdout, _, err := runSubcommand(t, cmd.JobsCmd, server.URL, "list", nil)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, ", lease ended at ")
+	assert.Contains(t, stdout, "[WARN]  1 lease(s) ended while no command was watching")
+	assert.Contains(t, stdout, "agbcloud jobs enforce")
+	assert.Equal(t, "RESOURCE_PUBLISHED", imageStatus(t, apiClient, running.ImageID))
+	jobs, err := cmd.LoadJobs()
+	require.NoError(t, err)
+	require.Len(t, jobs, 1, "only the lease of the image that is no longer activated was removed")
+
+	stdout, _, err = runSubcommand(t, cmd.JobsCmd, server.URL, "enforce", nil)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[LEASE] The lease of '"+running.ImageName+"' ended")
+	assert.Contains(t, stdout, "[DATA] Summary: 1 lease(s) ended, 0 failed")
+	assert.NotEqual(t, "RESOURCE_PUBLISHED", imageStatus(t, apiClient, running.ImageID), "the image is deactivated")
+	jobs, err = cmd.LoadJobs()
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	stdout, _, err = runSubcommand(t, cmd.JobsCmd, server.URL, "enforce", nil)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] No lease has ended")
+}
+
+func TestJobsCancelEndsLease(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 1, "RESOURCE_PUBLISHED")
+	image := leaseTestImage(t, apiClient)
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+	expires := time.Now().Add(time.Hour)
+	_, err := cmd.AddJob(cmd.Job{Type: cmd.JobLease, ImageID: image.ImageID, ImageName: image.ImageName, LeaseExpiresAt: &expires, LeaseDuration: "1h0m0s"})
+	require.NoError(t, err)
+
+	stdout, _, err := runSubcommand(t, cmd.JobsCmd, server.URL, "cancel", []string{"1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Ending the lease of '"+image.ImageName+"' now")
+	jobs, err := cmd.LoadJobs()
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+	assert.NotEqual(t, "RESOURCE_PUBLISHED", imageStatus(t, apiClient, image.ImageID))
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestImageListCachedShowsLastResult(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	var queries []url.Values
+	server := newListQueryServer(t, 3, &queries)
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--size", "2")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "image-2")
+
+	// The result was cached in the configuration directory
+	info, err := os.Stat(filepath.Join(os.Getenv("AGB_CLI_CONFIG_DIR"), "cache", "image-list.json"))
+	require.NoError(t, err)
+	if os.PathSeparator == '/' {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	// Viewing the cache does not contact the server
+	server.Close()
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--cached")
+	require.NoError(t, err)
+	assert.Len(t, queries, 1)
+	assert.Contains(t, stdout, "[CACHED] Showing User images fetched just now")
+	assert.Contains(t, stdout, "page 1, size 2")
+	assert.Contains(t, stdout, "[NOTE] The server was not contacted")
+	assert.Contains(t, stdout, "[OK] Found 2 images (Total: 3)")
+	assert.Contains(t, stdout, "image-1")
+	assert.NotContains(t, stdout, "image-3")
+
+	// A search is applied to the cached images
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--cached", "--search", "IMAGE-2")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[SEARCH] 1 of 2 cached images match 'IMAGE-2'")
+	assert.NotContains(t, stdout, "image-1")
+
+	// Other image types have their own cache entry
+	_, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--cached", "--type", "System")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] No cached list of System images for "+server.URL)
+	assert.Contains(t, stderr, "[TIP] Run 'agbcloud image list --type System' while online")
+}
+
+func TestImageListCacheIsScopedToEndpoint(t *testing.T) {
+	useTempConfigDir(t)
+
+	fetched := time.Now().Add(-3 * time.Hour).UTC()
+	require.NoError(t, cmd.SaveCachedImageList(cmd.CachedImageList{
+		FetchedAt: fetched,
+		Endpoint:  "https://a.example.com",
+		ImageType: "User",
+		All:       true,
+		Total:     1,
+		Images:    []client.ImageInfo{{ImageID: "img-a"}},
+	}))
+	require.NoError(t, cmd.SaveCachedImageList(cmd.CachedImageList{
+		FetchedAt: fetched,
+		Endpoint:  "https://b.example.com",
+		ImageType: "User",
+		Images:    []client.ImageInfo{{ImageID: "img-b"}},
+	}))
+
+	entry, err := cmd.LoadCachedImageList("", "https://a.example.com", "user")
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, "img-a", entry.Images[0].ImageID)
+	assert.True(t, entry.FetchedAt.Equal(fetched))
+
+	entry, err = cmd.LoadCachedImageList("staging", "https://a.example.com", "User")
+	require.NoError(t, err)
+	assert.Nil(t, entry, "profiles have their own cache entries")
+
+	// An unreadable cache is reported rather than shown as empty
+	path := filepath.Join(os.Getenv("AGB_CLI_CONFIG_DIR"), "cache", "image-list.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+	_, err = cmd.LoadCachedImageList("", "https://a.example.com", "User")
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// newListQueryServer records each list query and serves total images named img-1..img-N page by page
+func newListQueryServer(t *testing.T, total int, queries *[]url.Values) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/image/list", r.URL.Path)
+		query := r.URL.Query()
+		*queries = append(*queries, query)
+
+		page, _ := strconv.Atoi(query.Get("page"))
+		pageSize, _ := strconv.Atoi(query.Get("pageSize"))
+		var images []client.ImageInfo
+		for i := (page-1)*pageSize + 1; i <= total && i <= page*pageSize; i++ {
+			images = append(images, client.ImageInfo{ImageID: fmt.Sprintf("img-%d", i), ImageName: fmt.Sprintf("image-%d", i), Type: "User"})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.ImageListResponse{ // Ignore errors in test mock server
+			Success: true,
+			Data:    client.ImageListData{Images: images, Page: page, PageSize: pageSize, Total: total},
+		})
+	}))
+}
+
+func TestImageListQuiet(t *testing.T) {
+	var queries []url.Values
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server := newListQueryServer(t, 3, &queries)
+	defer server.Close()
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "-q", "--search", "image")
+	require.NoError(t, err)
+	assert.Equal(t, "img-1\nimg-2\nimg-3\n", stdout, "only IDs are printed on stdout")
+	require.Len(t, queries, 1)
+	assert.Equal(t, "image", queries[0].Get("imageName"), "filters still apply")
+}
+
+func TestImageListQuietAll(t *testing.T) {
+	var queries []url.Values
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server := newListQueryServer(t, 120, &queries)
+	defer server.Close()
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--all", "--quiet", "--type", "System")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, lines, 120)
+	assert.Equal(t, "img-1", lines[0])
+	assert.Equal(t, "img-120", lines[119])
+
+	require.Len(t, queries, 3, "every page is fetched")
+	for i, query := range queries {
+		assert.Equal(t, strconv.Itoa(i+1), query.Get("page"))
+		assert.Equal(t, "System", query.Get("imageType"))
+	}
+}
+
+func TestImageListAllTable(t *testing.T) {
+	var queries []url.Values
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server := newListQueryServer(t, 2, &queries)
+	defer server.Close()
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--all")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Found 2 images")
+	assert.Contains(t, stdout, "image-2")
+	assert.NotContains(t, stdout, "[PAGE]", "there is no single page with --all")
+}
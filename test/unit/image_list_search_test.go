@@ -0,0 +1,86 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// newSearchImageServer records the query of each list request and answers with an empty page
+func newSearchImageServer(t *testing.T, queries *[]url.Values) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/image/list", r.URL.Path)
+		*queries = append(*queries, r.URL.Query())
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.ImageListResponse{ // Ignore errors in test mock server
+			Success: true,
+			Data:    client.ImageListData{Page: 1, PageSize: 10},
+		})
+	}))
+}
+
+func TestListImagesNameSearch(t *testing.T) {
+	var queries []url.Values
+	server := newSearchImageServer(t, &queries)
+	defer server.Close()
+
+	cfg := client.NewConfiguration()
+	cfg.Servers[0].URL = server.URL
+	apiClient := client.NewAPIClient(cfg)
+
+	_, _, err := apiClient.ImageAPI.ListImages(context.Background(), "test-login-token", "test-session-id", client.ImageListOptions{
+		ImageType:    "User",
+		Page:         2,
+		PageSize:     20,
+		NameContains: "web app",
+	})
+	require.NoError(t, err)
+
+	_, _, err = apiClient.ImageAPI.ListImages(context.Background(), "test-login-token", "test-session-id", client.ImageListOptions{
+		ImageType: "User",
+		Page:      1,
+		PageSize:  10,
+		ImageIds:  []string{"img-1", "img-2"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, queries, 2)
+	assert.Equal(t, "web app", queries[0].Get("imageName"))
+	assert.Equal(t, "2", queries[0].Get("page"))
+	assert.Equal(t, "20", queries[0].Get("pageSize"))
+
+	assert.False(t, queries[1].Has("imageName"), "no search parameter without NameContains")
+	assert.Equal(t, []string{"img-1", "img-2"}, queries[1]["imageIds"])
+}
+
+func TestImageListSearchFlag(t *testing.T) {
+	var queries []url.Values
+	server := newSearchImageServer(t, &queries)
+	defer server.Close()
+
+	useTempConfigDir(t)
+	saveTestTokens(t)
+
+	// --name-contains is an alias of --search
+	_, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--name-contains", " web ")
+	require.NoError(t, err)
+	search, err := findSubcommand(t, cmd.ImageCmd, "list").Flags().GetString("search")
+	require.NoError(t, err)
+	assert.Equal(t, " web ", search)
+
+	require.Len(t, queries, 1)
+	assert.Equal(t, "web", queries[0].Get("imageName"), "search text is trimmed")
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/mockserver"
+)
+
+func TestListImagesConditional(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 2, "IMAGE_AVAILABLE")
+	ctx := context.Background()
+	options := client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10}
+
+	first, _, err := apiClient.ImageAPI.ListImages(ctx, "token", "session", options)
+	require.NoError(t, err)
+	assert.False(t, first.NotModified)
+	require.NotEmpty(t, first.Validators.ETag, "the mock server sends an ETag")
+	require.Len(t, first.Data.Images, 2)
+
+	options.Validators = first.Validators
+	unchanged, httpResp, err := apiClient.ImageAPI.ListImages(ctx, "token", "session", options)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, httpResp.StatusCode)
+	assert.True(t, unchanged.NotModified)
+	assert.Empty(t, unchanged.Data.Images, "an unchanged list has no body")
+	assert.Equal(t, first.Validators, unchanged.Validators)
+
+	_, _, err = apiClient.ImageAPI.StartImage(ctx, "token", "session", first.Data.Images[0].ImageID, 2, 4, false)
+	require.NoError(t, err)
+	changed, _, err := apiClient.ImageAPI.ListImages(ctx, "token", "session", options)
+	require.NoError(t, err)
+	assert.False(t, changed.NotModified)
+	assert.NotEqual(t, first.Validators.ETag, changed.Validators.ETag)
+	assert.Equal(t, "RESOURCE_DEPLOYING", changed.Data.Images[0].Status)
+}
+
+// newConditionalHeaderServer serves a fixed list with the given response headers and
+// records the conditional headers of every request
+func newConditionalHeaderServer(t *testing.T, header http.Header, conditions *[]string) *httptest.Server {
+	var mu sync.Mutex
+	backend := mockserver.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*conditions = append(*conditions, r.Header.Get("If-None-Match")+r.Header.Get("If-Modified-Since"))
+		mu.Unlock()
+		if r.Header.Get("If-Modified-Since") != "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		r.Header.Del("If-None-Match")
+		recorder := httptest.NewRecorder()
+		backend.ServeHTTP(recorder, r)
+		w.Header().Set("Content-Type", recorder.Header().Get("Content-Type"))
+		for name, values := range header {
+			w.Header()[name] = values
+		}
+		w.WriteHeader(recorder.Code)
+		_, _ = w.Write(recorder.Body.Bytes())
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestListImagesFallsBackWithoutCachingHeaders(t *testing.T) {
+	var conditions []string
+	server := newConditionalHeaderServer(t, http.Header{}, &conditions)
+	apiClient := newLogsTestClient(server.URL)
+
+	resp, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "System", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.True(t, resp.Validators.IsZero())
+	assert.Len(t, resp.Data.Images, 3)
+
+	resp, _, err = apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "System", Page: 1, PageSize: 10, Validators: resp.Validators})
+	require.NoError(t, err)
+	assert.False(t, resp.NotModified)
+	assert.Len(t, resp.Data.Images, 3, "without validators every list is fetched in full")
+	assert.Equal(t, []string{"", ""}, conditions)
+}
+
+func TestListImagesLastModified(t *testing.T) {
+	var conditions []string
+	lastModified := "Wed, 14 Oct 2026 08:00:00 GMT"
+	server := newConditionalHeaderServer(t, http.Header{"Last-Modified": {lastModified}}, &conditions)
+	apiClient := newLogsTestClient(server.URL)
+
+	resp, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "System", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, client.CacheValidators{LastModified: lastModified}, resp.Validators)
+
+	resp, _, err = apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "System", Page: 1, PageSize: 10, Validators: resp.Validators})
+	require.NoError(t, err)
+	assert.True(t, resp.NotModified)
+	assert.Equal(t, []string{"", lastModified}, conditions)
+}
+
+func TestImageListWatch(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	var conditions []string
+	server := newConditionalHeaderServer(t, http.Header{"Last-Modified": {"Wed, 14 Oct 2026 08:00:00 GMT"}}, &conditions)
+
+	listCmd := findSubcommand(t, cmd.ImageCmd, "list")
+	ctx, cancel := context.WithTimeout(context.Background(), 2500*time.Millisecond)
+	defer cancel()
+	listCmd.SetContext(ctx)
+	t.Cleanup(func() { listCmd.SetContext(context.Background()) })
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--type", "System", "--watch", "--interval", "1s")
+	require.NoError(t, err, "Ctrl-C is the normal way to stop watching")
+	assert.Equal(t, 1, strings.Count(stdout, "[REFRESH]"), "an unchanged list is printed once")
+	assert.Contains(t, stdout, "agb-code-space-1")
+	require.GreaterOrEqual(t, len(conditions), 2)
+	assert.Empty(t, conditions[0])
+	for _, condition := range conditions[1:] {
+		assert.NotEmpty(t, condition, "later checks are conditional")
+	}
+}
+
+func TestImageListWatchConflicts(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 1)
+
+	for _, flag := range []string{"--all", "--quiet", "--cached"} {
+		_, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--watch", flag)
+		require.Error(t, err, flag)
+		assert.Contains(t, err.Error(), "--watch cannot be combined with "+flag)
+	}
+	_, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--watch", "--interval", "100ms")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid --interval value")
+}
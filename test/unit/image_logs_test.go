@@ -0,0 +1,175 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func newLogsTestClient(serverURL string) *client.APIClient {
+	cfg := client.NewConfiguration()
+	cfg.Servers[0].URL = serverURL
+	return client.NewAPIClient(cfg)
+}
+
+func TestGetInstanceLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/image/logs", r.URL.Path)
+		query := r.URL.Query()
+		assert.Equal(t, "img-1", query.Get("imageId"))
+		assert.Equal(t, "20", query.Get("tail"))
+		assert.Equal(t, "2025-09-30T10:00:00Z", query.Get("since"))
+		assert.False(t, query.Has("follow"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success": true, "data": {"lines": [
+			{"timestamp": "2025-09-30T10:00:01Z", "stream": "stdout", "message": "server started"},
+			{"timestamp": "2025-09-30T10:00:02Z", "stream": "stderr", "message": "warning: low memory"}
+		], "nextToken": "t-2"}}`)) // Ignore errors in test mock server
+	}))
+	defer server.Close()
+
+	resp, _, err := newLogsTestClient(server.URL).ImageAPI.GetInstanceLogs(context.Background(), "test-login-token", "test-session-id",
+		client.InstanceLogOptions{ImageId: "img-1", Tail: 20, Since: "2025-09-30T10:00:00Z"})
+	require.NoError(t, err)
+	require.Len(t, resp.Data.Lines, 2)
+	assert.Equal(t, "warning: low memory", resp.Data.Lines[1].Message)
+	assert.Equal(t, "stderr", resp.Data.Lines[1].Stream)
+	assert.Equal(t, "t-2", resp.Data.NextToken)
+
+	_, _, err = newLogsTestClient(server.URL).ImageAPI.GetInstanceLogs(context.Background(), "test-login-token", "test-session-id",
+		client.InstanceLogOptions{})
+	assert.Error(t, err, "imageId is required")
+}
+
+func TestStreamInstanceLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/image/logs/stream", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("follow"))
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		flusher := w.(http.Flusher)
+		for i := 1; i <= 3; i++ {
+			fmt.Fprintf(w, `{"timestamp": "2025-09-30T10:00:0%dZ", "message": "line %d"}`+"\n", i, i)
+			if i == 2 {
+				fmt.Fprint(w, "\n") // heartbeat
+			}
+			flusher.Flush()
+		}
+	}))
+	defer server.Close()
+
+	var messages []string
+	err := newLogsTestClient(server.URL).ImageAPI.StreamInstanceLogs(context.Background(), "test-login-token", "test-session-id",
+		client.InstanceLogOptions{ImageId: "img-1"}, func(line client.InstanceLogLine) error {
+			messages = append(messages, line.Message)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"line 1", "line 2", "line 3"}, messages)
+
+	t.Run("HandlerErrorStopsStream", func(t *testing.T) {
+		stop := errors.New("stop")
+		count := 0
+		err := newLogsTestClient(server.URL).ImageAPI.StreamInstanceLogs(context.Background(), "test-login-token", "test-session-id",
+			client.InstanceLogOptions{ImageId: "img-1"}, func(line client.InstanceLogLine) error {
+				count++
+				return stop
+			})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		notFound := httptest.NewServer(http.NotFoundHandler())
+		defer notFound.Close()
+
+		err := newLogsTestClient(notFound.URL).ImageAPI.StreamInstanceLogs(context.Background(), "test-login-token", "test-session-id",
+			client.InstanceLogOptions{ImageId: "img-1"}, func(client.InstanceLogLine) error { return nil })
+		assert.ErrorIs(t, err, client.ErrInstanceLogStreamUnsupported)
+	})
+}
+
+func TestFollowInstanceLogsFallsBackToPolling(t *testing.T) {
+	var mu sync.Mutex
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/image/logs/stream" {
+			http.NotFound(w, r)
+			return
+		}
+
+		mu.Lock()
+		token := r.URL.Query().Get("nextToken")
+		tokens = append(tokens, token+"|tail="+r.URL.Query().Get("tail"))
+		page := len(tokens)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.InstanceLogsResponse{ // Ignore errors in test mock server
+			Success: true,
+			Data: client.InstanceLogsData{
+				Lines:     []client.InstanceLogLine{{Message: fmt.Sprintf("page %d", page)}},
+				NextToken: fmt.Sprintf("t-%d", page),
+			},
+		})
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var messages []string
+	err := cmd.FollowInstanceLogs(ctx, newLogsTestClient(server.URL), "test-login-token", "test-session-id",
+		client.InstanceLogOptions{ImageId: "img-1", Tail: 10}, time.Millisecond, func(line client.InstanceLogLine) error {
+			messages = append(messages, line.Message)
+			if len(messages) == 3 {
+				cancel()
+			}
+			return nil
+		})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{"page 1", "page 2", "page 3"}, messages)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"|tail=10", "t-1|tail=", "t-2|tail="}, tokens, "later polls continue from the previous page")
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+
+	since, err := cmd.ParseSince("", now)
+	assert.NoError(t, err)
+	assert.Empty(t, since)
+
+	since, err = cmd.ParseSince("30m", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-09-30T11:30:00Z", since)
+
+	since, err = cmd.ParseSince("1d", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-09-29T12:00:00Z", since)
+
+	since, err = cmd.ParseSince("2025-09-30T14:00:00+02:00", now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-09-30T12:00:00Z", since)
+
+	_, err = cmd.ParseSince("yesterday", now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestCompareImageMetadata(t *testing.T) {
+	image := func(id, name, status string, labels map[string]string) cmd.ImageMetadata {
+		return cmd.ImageMetadata{ImageInfo: client.ImageInfo{ImageID: id, ImageName: name, Type: "User", Status: status}, Labels: labels}
+	}
+	backup := []cmd.ImageMetadata{
+		image("img-1", "web", "IMAGE_AVAILABLE", map[string]string{"team": "web"}),
+		image("img-2", "api", "RESOURCE_PUBLISHED", nil),
+		image("img-3", "db", "IMAGE_AVAILABLE", nil),
+		image("img-4", "gone", "IMAGE_AVAILABLE", nil),
+	}
+	current := []cmd.ImageMetadata{
+		image("img-1", "web", "IMAGE_AVAILABLE", map[string]string{"team": "platform"}),
+		image("img-2", "api", "IMAGE_AVAILABLE", nil),
+		image("img-9", "db", "IMAGE_AVAILABLE", nil),
+		image("img-5", "new", "IMAGE_AVAILABLE", nil),
+	}
+
+	drift := cmd.CompareImageMetadata(backup, current, cmd.ImageMetadataCompareOptions{Manifests: true})
+	assert.True(t, drift.Drifted())
+	require.Len(t, drift.Missing, 1)
+	assert.Equal(t, "img-4", drift.Missing[0].ImageID)
+	require.Len(t, drift.Added, 1)
+	assert.Equal(t, "img-5", drift.Added[0].ImageID)
+	require.Len(t, drift.Changed, 3)
+	assert.Equal(t, []cmd.ImageFieldDiff{{Field: "Label team", Left: "web", Right: "platform", Differs: true}}, drift.Changed[0].Fields)
+	assert.Equal(t, "Status", drift.Changed[1].Fields[0].Field)
+	assert.Equal(t, []cmd.ImageFieldDiff{{Field: "ID", Left: "img-3", Right: "img-9", Differs: true}}, drift.Changed[2].Fields, "recreated images are matched by name")
+
+	// Labels are only compared when both sides have manifests
+	drift = cmd.CompareImageMetadata(backup[:3], current[:3], cmd.ImageMetadataCompareOptions{IgnoreStatus: true})
+	assert.Equal(t, 2, drift.Unchanged)
+	require.Len(t, drift.Changed, 1)
+	assert.Equal(t, "img-3", drift.Changed[0].ImageID)
+}
+
+func TestImageExportAndDiffMetadata(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 55, "IMAGE_AVAILABLE")
+	file := filepath.Join(t.TempDir(), "backup.json")
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "export-metadata", nil, "--file", file)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Exported the metadata of 58 image(s) to "+file)
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	var backup cmd.ImageMetadataBackup
+	require.NoError(t, json.Unmarshal(data, &backup))
+	assert.Equal(t, 1, backup.Version)
+	assert.Equal(t, []string{"User", "System"}, backup.Types)
+	assert.True(t, backup.Manifests)
+	require.Len(t, backup.Images, 58, "all pages are exported")
+	assert.Equal(t, "IMAGE_AVAILABLE", backup.Images[0].Status)
+	assert.NotEmpty(t, backup.Images[0].UpdateTime)
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "diff-metadata", []string{file})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] No drift: all 58 image(s) match the backup")
+
+	// Simulate drift by editing the backup
+	backup.Images[0].ImageName = "renamed"
+	backup.Images = backup.Images[1:]
+	backup.Images[0].Status = "RESOURCE_PUBLISHED"
+	data, err = json.Marshal(backup)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(file, data, 0644))
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "diff-metadata", []string{file}, "--fail-on-drift")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the images drifted from "+file)
+	assert.Contains(t, stdout, "[INFO]  Added: ")
+	assert.Regexp(t, `Status\s+RESOURCE_PUBLISHED\s+IMAGE_AVAILABLE`, stdout)
+	assert.Contains(t, stdout, "[DATA] 56 unchanged, 1 changed, 0 missing, 1 added")
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "diff-metadata", []string{file}, "--ignore-status")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[DATA] 57 unchanged, 0 changed, 0 missing, 1 added")
+
+	// Only backups are accepted
+	require.NoError(t, os.WriteFile(file, []byte(`{"version": 2}`), 0644))
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "diff-metadata", []string{file})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported backup version 2")
+
+	// --output names the file when it is not a format
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "export-metadata", nil, "--output", file, "--type", "system")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Exported the metadata of 3 image(s) to "+file)
+}
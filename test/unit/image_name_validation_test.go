@@ -0,0 +1,149 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestValidateImageName(t *testing.T) {
+	tests := []struct {
+		name      string
+		imageName string
+		expectErr bool
+	}{
+		{"Simple name", "myImage", false},
+		{"Name with digits and separators", "my-image_v1.0", false},
+		{"Minimum length", "ab", false},
+		{"Maximum length", "a" + strings.Repeat("b", 63), false},
+		{"Too short", "a", true},
+		{"Too long", "a" + strings.Repeat("b", 64), true},
+		{"Starts with digit", "1image", true},
+		{"Starts with dash", "-image", true},
+		{"Contains space", "my image", true},
+		{"Contains slash", "my/image", true},
+		{"Contains unicode", "镜像image", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			captureStderr(func() {
+				err = cmd.ValidateImageName(tt.imageName)
+			})
+
+			if tt.expectErr {
+				assert.Error(t, err, "expected %q to be rejected", tt.imageName)
+				assert.Contains(t, err.Error(), "[ERROR]")
+			} else {
+				assert.NoError(t, err, "expected %q to be accepted", tt.imageName)
+			}
+		})
+	}
+}
+
+// newPagedImageServer returns a mock server that serves total user images named image-1..image-N
+func newPagedImageServer(t *testing.T, total int, requestedPages *[]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/image/list", r.URL.Path)
+		assert.Equal(t, "User", r.URL.Query().Get("imageType"))
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		*requestedPages = append(*requestedPages, page)
+
+		var images []client.ImageInfo
+		for i := (page-1)*pageSize + 1; i <= total && i <= page*pageSize; i++ {
+			images = append(images, client.ImageInfo{
+				ImageID:   fmt.Sprintf("img-%d", i),
+				ImageName: fmt.Sprintf("image-%d", i),
+				Status:    "IMAGE_AVAILABLE",
+				Type:      "User",
+			})
+		}
+
+		response := client.ImageListResponse{
+			Code:      "success",
+			RequestID: "test-request-id",
+			Success:   true,
+			Data: client.ImageListData{
+				Images:   images,
+				Total:    total,
+				Page:     page,
+				PageSize: pageSize,
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response) // Ignore errors in test mock server
+	}))
+}
+
+func TestFindUserImageByName(t *testing.T) {
+	t.Run("FoundOnLaterPage", func(t *testing.T) {
+		var pages []int
+		server := newPagedImageServer(t, 120, &pages)
+		defer server.Close()
+
+		cfg := client.NewConfiguration()
+		cfg.Servers[0].URL = server.URL
+		apiClient := client.NewAPIClient(cfg)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		image, err := cmd.FindUserImageByName(ctx, apiClient, "test-login-token", "test-session-id", "image-75")
+		require.NoError(t, err)
+		require.NotNil(t, image)
+		assert.Equal(t, "img-75", image.ImageID)
+		assert.Equal(t, []int{1, 2}, pages, "search should stop at the page containing the match")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		var pages []int
+		server := newPagedImageServer(t, 120, &pages)
+		defer server.Close()
+
+		cfg := client.NewConfiguration()
+		cfg.Servers[0].URL = server.URL
+		apiClient := client.NewAPIClient(cfg)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		image, err := cmd.FindUserImageByName(ctx, apiClient, "test-login-token", "test-session-id", "missing-image")
+		require.NoError(t, err)
+		assert.Nil(t, image)
+		assert.Equal(t, []int{1, 2, 3}, pages, "all pages should be searched")
+	})
+
+	t.Run("NoImages", func(t *testing.T) {
+		var pages []int
+		server := newPagedImageServer(t, 0, &pages)
+		defer server.Close()
+
+		cfg := client.NewConfiguration()
+		cfg.Servers[0].URL = server.URL
+		apiClient := client.NewAPIClient(cfg)
+
+		image, err := cmd.FindUserImageByName(context.Background(), apiClient, "test-login-token", "test-session-id", "image-1")
+		require.NoError(t, err)
+		assert.Nil(t, image)
+		assert.Equal(t, []int{1}, pages)
+	})
+}
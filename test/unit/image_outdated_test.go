@@ -0,0 +1,83 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestCompareImageVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.0", "1.2.0", 0},
+		{"1.2.0", "1.10.0", -1},
+		{"v2.0", "1.9.9", 1},
+		{"1.2", "1.2.1", -1},
+		{"20250901", "20250930", -1},
+		{"1.0-beta", "1.0-rc", -1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, cmd.CompareImageVersions(tt.a, tt.b), "%s vs %s", tt.a, tt.b)
+	}
+}
+
+func TestPlanOutdatedImages(t *testing.T) {
+	images := []client.ImageInfo{
+		{ImageID: "img-c", SourceImageID: "agb-code-space-1", SourceImageVersion: "1.2.0"},
+		{ImageID: "img-a", SourceImageID: "agb-code-space-1", SourceImageVersion: "1.1.0"},
+		{ImageID: "img-b", SourceImageID: "agb-browser-use-1"},
+		{ImageID: "img-d"},
+	}
+	latest := []client.BaseImageVersion{{ImageID: "agb-code-space-1", LatestVersion: "1.2.0"}}
+
+	entries := cmd.PlanOutdatedImages(images, latest)
+	require.Len(t, entries, 3, "images without a source image are skipped")
+
+	assert.Equal(t, "img-a", entries[0].ImageID)
+	assert.Equal(t, "outdated", entries[0].State)
+	assert.Equal(t, "1.2.0", entries[0].LatestVersion)
+	assert.Equal(t, "unknown", entries[1].State, "no recorded base version")
+	assert.Equal(t, "up-to-date", entries[2].State)
+}
+
+func TestFormatSourceImage(t *testing.T) {
+	assert.Equal(t, "-", cmd.FormatSourceImage(client.ImageInfo{}))
+	assert.Equal(t, "agb-code-space-1", cmd.FormatSourceImage(client.ImageInfo{SourceImageID: "agb-code-space-1"}))
+	assert.Equal(t, "agb-code-space-1@1.2.0", cmd.FormatSourceImage(client.ImageInfo{SourceImageID: "agb-code-space-1", SourceImageVersion: "1.2.0"}))
+}
+
+func TestGetBaseImageVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/image/base/versions", r.URL.Path)
+		assert.Equal(t, []string{"agb-browser-use-1", "agb-code-space-1"}, r.URL.Query()["imageIds"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success": true, "data": [
+			{"imageId": "agb-browser-use-1", "latestVersion": "2.0.1"},
+			{"imageId": "agb-code-space-1", "latestVersion": "1.3.0", "releaseTime": "2025-09-30T10:00:00Z"}
+		]}`)) // Ignore errors in test mock server
+	}))
+	defer server.Close()
+
+	apiClient := newLogsTestClient(server.URL)
+	resp, _, err := apiClient.ImageAPI.GetBaseImageVersions(context.Background(), "test-login-token", "test-session-id",
+		[]string{"agb-browser-use-1", "agb-code-space-1"})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 2)
+	assert.Equal(t, "1.3.0", resp.Data[1].LatestVersion)
+
+	_, _, err = apiClient.ImageAPI.GetBaseImageVersions(context.Background(), "test-login-token", "test-session-id", nil)
+	assert.Error(t, err, "at least one image ID is required")
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+func TestConfigPinImage(t *testing.T) {
+	t.Setenv("AGB_CLI_PROFILE", "")
+	cfg := &config.Config{}
+	assert.True(t, cfg.PinImage("img-1"))
+	assert.True(t, cfg.PinImage("img-2"))
+	assert.False(t, cfg.PinImage("img-1"), "pinning twice is a no-op")
+	assert.Equal(t, []string{"img-2", "img-1"}, cfg.PinnedImageIDs(), "most recently pinned first")
+
+	t.Setenv("AGB_CLI_PROFILE", "prod")
+	assert.Empty(t, cfg.PinnedImageIDs(), "pins are kept per profile")
+	assert.True(t, cfg.PinImage("img-9"))
+	assert.False(t, cfg.UnpinImage("img-1"))
+
+	t.Setenv("AGB_CLI_PROFILE", "")
+	assert.True(t, cfg.UnpinImage("img-1"))
+	assert.True(t, cfg.UnpinImage("img-2"))
+	assert.Equal(t, map[string][]string{"prod": {"img-9"}}, cfg.PinnedImages)
+}
+
+func TestSortPinnedFirst(t *testing.T) {
+	images := []client.ImageInfo{{ImageID: "a"}, {ImageID: "b"}, {ImageID: "c"}, {ImageID: "d"}}
+	ids := func(images []client.ImageInfo) []string {
+		var ids []string
+		for _, image := range images {
+			ids = append(ids, image.ImageID)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"d", "b", "a", "c"}, ids(cmd.SortPinnedFirst(images, []string{"d", "x", "b"})))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, ids(images), "the input is not modified")
+}
+
+func TestNewRecentImageItems(t *testing.T) {
+	used := time.Date(2025, 9, 11, 5, 48, 8, 0, time.UTC)
+	recent := []cmd.RecentImage{
+		{ImageID: "img-3", ImageName: "api", Action: "activate", UsedAt: used},
+		{ImageID: "img-1", ImageName: "web", Action: "status", UsedAt: used.Add(-time.Hour)},
+		{ImageID: "img-4", Action: "deactivate", UsedAt: used.Add(-2 * time.Hour)},
+	}
+	items := cmd.NewRecentImageItems([]string{"img-1", "img-2"}, recent, map[string]string{"img-2": "worker"}, 1)
+
+	require.Len(t, items, 3, "pinned images are always shown, recent ones up to the limit")
+	assert.Equal(t, cmd.RecentImageItem{ImageID: "img-1", ImageName: "web", Pinned: true, LastUsed: "2025-09-11T04:48:08Z", Action: "status"}, items[0])
+	assert.Equal(t, cmd.RecentImageItem{ImageID: "img-2", ImageName: "worker", Pinned: true}, items[1])
+	assert.Equal(t, "img-3", items[2].ImageID)
+}
+
+func TestRecordRecentImage(t *testing.T) {
+	useTempConfigDir(t)
+	t.Setenv("AGB_CLI_ENDPOINT", "https://agb.example.com")
+
+	require.NoError(t, cmd.RecordRecentImage("img-1", "web", "activate"))
+	require.NoError(t, cmd.RecordRecentImage("img-2", "api", "status"))
+	require.NoError(t, cmd.RecordRecentImage("img-1", "", "deactivate"))
+
+	recent, err := cmd.LoadRecentImages()
+	require.NoError(t, err)
+	require.Len(t, recent, 2, "an image is remembered once")
+	assert.Equal(t, "img-1", recent[0].ImageID)
+	assert.Equal(t, "web", recent[0].ImageName, "the name is kept when not known")
+	assert.Equal(t, "deactivate", recent[0].Action)
+
+	t.Setenv("AGB_CLI_ENDPOINT", "https://other.example.com")
+	recent, err = cmd.LoadRecentImages()
+	require.NoError(t, err)
+	assert.Empty(t, recent, "history is kept per endpoint")
+}
+
+func TestImagePinCommands(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 3)
+	listResp, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, listResp.Data.Images, 3)
+	last := listResp.Data.Images[2]
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "pin", []string{last.ImageID})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[PIN] Pinned image "+last.ImageID)
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "pin", []string{last.ImageID})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "is already pinned")
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "-q")
+	require.NoError(t, err)
+	assert.Equal(t, last.ImageID, strings.Split(stdout, "\n")[0], "pinned images are listed first")
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil)
+	require.NoError(t, err)
+	assert.Regexp(t, last.ImageID+`\s+\* `, stdout, "pinned images are marked")
+	assert.Contains(t, stdout, "[PIN] * Pinned images are listed first")
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "recent", nil)
+	require.NoError(t, err)
+	assert.Regexp(t, last.ImageID+`\s+\S+\s+yes\s+-\s+-`, stdout)
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "unpin", []string{last.ImageID[:len(last.ImageID)-2]})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Unpinned image "+last.ImageID)
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "unpin", []string{last.ImageID})
+	require.Error(t, err)
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "recent", nil)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[EMPTY] No pinned or recently used images.")
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestParsePlatforms(t *testing.T) {
+	platforms, err := cmd.ParsePlatforms(" linux/amd64, Linux/ARM64/v8 ,linux/amd64")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"linux/amd64", "linux/arm64/v8"}, platforms)
+
+	platforms, err = cmd.ParsePlatforms("")
+	require.NoError(t, err)
+	assert.Nil(t, platforms, "no platforms builds the default one")
+
+	for _, value := range []string{"linux", "linux/amd64,", "linux/amd64/v8/extra", "linux/amd 64"} {
+		_, err := cmd.ParsePlatforms(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestUnsupportedPlatforms(t *testing.T) {
+	capabilities := client.ImageCapabilitiesData{Platforms: []string{"linux/amd64", "linux/arm64"}}
+	assert.Empty(t, cmd.UnsupportedPlatforms([]string{"linux/arm64"}, capabilities))
+	assert.Equal(t, []string{"linux/s390x"}, cmd.UnsupportedPlatforms([]string{"linux/amd64", "linux/s390x"}, capabilities))
+}
+
+func TestMockServerMultiPlatformBuild(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 0)
+	ctx := context.Background()
+
+	capabilities, _, err := apiClient.ImageAPI.GetImageCapabilities(ctx, "token", "session")
+	require.NoError(t, err)
+	assert.Equal(t, "linux/amd64", capabilities.Data.DefaultPlatform)
+
+	credential, _, err := apiClient.ImageAPI.GetUploadCredential(ctx, "token", "session")
+	require.NoError(t, err)
+	createResp, _, err := apiClient.ImageAPI.CreateImage(ctx, "token", "session", "demo", credential.Data.TaskID, "agb-code-space-1", client.ImageCreateOptions{Platforms: []string{"linux/amd64", "linux/arm64"}})
+	require.NoError(t, err)
+	require.True(t, createResp.Success)
+
+	taskResp, _, err := apiClient.ImageAPI.GetImageTask(ctx, "token", "session", credential.Data.TaskID)
+	require.NoError(t, err)
+	assert.Equal(t, []client.ImagePlatformBuild{
+		{Platform: "linux/amd64", Status: "Preparing"},
+		{Platform: "linux/arm64", Status: "Inline"},
+	}, taskResp.Data.Platforms)
+
+	taskResp, _, err = apiClient.ImageAPI.GetImageTask(ctx, "token", "session", credential.Data.TaskID)
+	require.NoError(t, err)
+	assert.Equal(t, "Finished", taskResp.Data.Status)
+	assert.Len(t, taskResp.Data.Platforms, 2)
+	for _, build := range taskResp.Data.Platforms {
+		assert.Equal(t, "Finished", build.Status, build.Platform)
+	}
+
+	// Unsupported platforms are rejected
+	credential, _, err = apiClient.ImageAPI.GetUploadCredential(ctx, "token", "session")
+	require.NoError(t, err)
+	_, _, err = apiClient.ImageAPI.CreateImage(ctx, "token", "session", "demo-2", credential.Data.TaskID, "agb-code-space-1", client.ImageCreateOptions{Platforms: []string{"linux/s390x"}})
+	require.Error(t, err)
+	code, _ := client.ErrorCode(err)
+	assert.Equal(t, "UnsupportedPlatform", code)
+}
+
+func TestImageCreateRejectsUnsupportedPlatform(t *testing.T) {
+	useTempConfigDir(t)
+	server, _ := newSeededMockServer(t, 0)
+
+	_, stderr, err := runImageCreate(t, server.URL, "multi-arch", "--force", "--platform", "linux/amd64,linux/s390x")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] Unsupported platform(s): linux/s390x")
+	assert.Contains(t, stderr, "[TIP] Supported platforms: linux/amd64, linux/arm64")
+
+	_, stderr, err = runImageCreate(t, server.URL, "multi-arch", "--force", "--platform", "linux")
+	require.Error(t, err)
+	assert.Contains(t, stderr, `[ERROR] Invalid --platform value: invalid platform "linux"`)
+}
+
+func TestImageCreatePlatformNeedsCapabilitiesEndpoint(t *testing.T) {
+	useTempConfigDir(t)
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, stderr, err := runImageCreate(t, server.URL, "multi-arch", "--force", "--platform", "linux/arm64")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] This AgbCloud endpoint does not support multi-platform builds")
+	assert.Contains(t, stderr, "[TIP] Remove --platform")
+}
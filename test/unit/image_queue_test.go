@@ -0,0 +1,86 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestFormatActivationQueue(t *testing.T) {
+	assert.Equal(t, "Position 3 of 12 in the activation queue, estimated wait ~5m",
+		cmd.FormatActivationQueue(client.ImageQueueData{Position: 3, QueueLength: 12, EstimatedWaitSeconds: 290}))
+	assert.Equal(t, "Position 1 of 1 in the activation queue, estimated wait <1m",
+		cmd.FormatActivationQueue(client.ImageQueueData{Position: 1, QueueLength: 1, EstimatedWaitSeconds: 20}))
+	assert.Equal(t, "Position 2 in the activation queue, estimated wait ~1h20m",
+		cmd.FormatActivationQueue(client.ImageQueueData{Position: 2, EstimatedWaitSeconds: 4800}))
+	assert.Equal(t, "Position 4 of 9 in the activation queue, no estimated wait yet",
+		cmd.FormatActivationQueue(client.ImageQueueData{Position: 4, QueueLength: 9}))
+}
+
+func TestActivationQueueReporter(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 8, "RESOURCE_DEPLOYING")
+	ctx := context.Background()
+
+	// Find the activation at the end of the queue
+	var last client.ImageQueueData
+	var others []string
+	for i := 1; i <= 8; i++ {
+		resp, _, err := apiClient.ImageAPI.GetImageQueue(ctx, "token", "session", fmt.Sprintf("img-mock%04d", i))
+		require.NoError(t, err)
+		require.True(t, resp.Success)
+		assert.Equal(t, 7, resp.Data.QueueLength, "one activation is deployed, the others wait")
+		if resp.Data.Position == 7 {
+			last = resp.Data
+		} else {
+			others = append(others, resp.Data.ImageID)
+		}
+	}
+	require.NotEmpty(t, last.ImageID)
+
+	var out bytes.Buffer
+	reporter := cmd.NewActivationQueueReporter(apiClient, "token", "session", last.ImageID, &out)
+	reporter.Report(ctx)
+	assert.Contains(t, out.String(), "[QUEUE] Position 7 of 7 in the activation queue, estimated wait ~11m")
+	assert.Contains(t, out.String(), "[TIP] Capacity is constrained")
+	assert.Contains(t, out.String(), "run 'agbcloud image activate "+last.ImageID+"' later")
+
+	out.Reset()
+	reporter.Report(ctx)
+	assert.Empty(t, out.String(), "an unchanged position is not repeated")
+
+	// Checking the other images settles their activations, so capacity is assigned
+	_, _, err := apiClient.ImageAPI.ListImages(ctx, "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 7, ImageIds: others})
+	require.NoError(t, err)
+	reporter.Report(ctx)
+	assert.Equal(t, "[QUEUE] Capacity assigned, the image is being deployed\n", out.String())
+}
+
+func TestActivationQueueReporterWithoutQueueEndpoint(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	apiClient := newLogsTestClient(server.URL)
+
+	var out bytes.Buffer
+	reporter := cmd.NewActivationQueueReporter(apiClient, "token", "session", "img-1", &out)
+	reporter.Report(context.Background())
+	reporter.Report(context.Background())
+	assert.Empty(t, out.String())
+	assert.Equal(t, 1, requests, "servers without the endpoint are asked only once")
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestMatchImagePrefix(t *testing.T) {
+	images := []client.ImageInfo{
+		{ImageID: "img-7a8b9c1d0e"},
+		{ImageID: "img-7a8b00ffee"},
+		{ImageID: "img-7a8b"},
+		{ImageID: "img-1234567890"},
+	}
+
+	assert.Len(t, cmd.MatchImagePrefix(images, "img-7a8b9"), 1)
+	assert.Len(t, cmd.MatchImagePrefix(images, "IMG-1234"), 1, "IDs are compared case-insensitively")
+	assert.Empty(t, cmd.MatchImagePrefix(images, "img-ffff"))
+
+	matches := cmd.MatchImagePrefix(images, "img-7a8b")
+	require.Len(t, matches, 1, "an exact ID wins over longer IDs with the same prefix")
+	assert.Equal(t, "img-7a8b", matches[0].ImageID)
+	assert.Len(t, cmd.MatchImagePrefix(images, "img-7a"), 3)
+}
+
+func TestMatchImageName(t *testing.T) {
+	images := []client.ImageInfo{
+		{ImageID: "img-1", ImageName: "web-frontend"},
+		{ImageID: "img-2", ImageName: "web-frontend-v2"},
+		{ImageID: "img-3", ImageName: "api"},
+	}
+
+	matches := cmd.MatchImageName(images, "WEB-FRONTEND")
+	require.Len(t, matches, 1, "an exact name wins over names containing it")
+	assert.Equal(t, "img-1", matches[0].ImageID)
+	assert.Len(t, cmd.MatchImageName(images, "frontend"), 2, "without an exact match, names containing the text match")
+	assert.Empty(t, cmd.MatchImageName(images, "worker"))
+}
+
+func TestImageStatusByIDPrefix(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 10, "IMAGE_AVAILABLE")
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "status", []string{"img-mock0010"})
+	require.NoError(t, err)
+	assert.NotContains(t, stdout, "' is image", "complete IDs are used as given")
+	assert.Contains(t, stdout, "[DATA] Image ID: img-mock0010")
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "status", []string{"IMG-MOCK001"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] 'IMG-MOCK001' is image img-mock0010")
+	assert.Contains(t, stdout, "[DATA] Image ID: img-mock0010")
+
+	stdout, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "status", []string{"img-mock00"})
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] Image ID prefix 'img-mock00' matches 10 images:")
+	assert.Contains(t, stderr, "• img-mock0001 (")
+	assert.Contains(t, stderr, "[TIP] Use more characters of the image ID")
+	assert.NotContains(t, stdout, "[DATA] Image ID:")
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "status", []string{"img-unknown"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "image not found: img-unknown")
+}
+
+func TestImageStatusByName(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 0)
+	ctx := context.Background()
+	var ids []string
+	for _, name := range []string{"web-frontend", "web-frontend-v2"} {
+		resp, _, err := apiClient.ImageAPI.CreateImage(ctx, "token", "session", name, "task-"+name, "agb-code-space-1", client.ImageCreateOptions{})
+		require.NoError(t, err)
+		ids = append(ids, resp.Data)
+	}
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "status", nil, "--name", "Web-Frontend")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[SEARCH] Looking up image named 'Web-Frontend'...")
+	assert.Contains(t, stdout, "[OK] 'Web-Frontend' is image "+ids[0]+" (web-frontend)")
+	assert.Contains(t, stdout, "[DATA] Image ID: "+ids[0])
+
+	_, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "status", nil, "--name", "frontend")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] Name 'frontend' matches 2 images:")
+	assert.Contains(t, stderr, "• "+ids[1]+" (web-frontend-v2)")
+
+	_, stderr, err = runSubcommand(t, cmd.ImageCmd, server.URL, "status", nil, "--name", "backend")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] No image named 'backend'")
+
+	_, stderr, err = runSubcommand(t, cmd.ImageCmd, server.URL, "status", []string{ids[0]}, "--name", "web-frontend")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] Give either <image-id> or --name, not both")
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/mockserver"
+)
+
+func TestParseResourceSpec(t *testing.T) {
+	cpu, memory, err := cmd.ParseResourceSpec("4c8g")
+	require.NoError(t, err)
+	assert.Equal(t, 4, cpu)
+	assert.Equal(t, 8, memory)
+
+	cpu, memory, err = cmd.ParseResourceSpec(" 8C16G ")
+	require.NoError(t, err)
+	assert.Equal(t, 8, cpu)
+	assert.Equal(t, 16, memory)
+
+	for _, spec := range []string{"", "4c", "8g", "4 cores", "c8g", "4c8gb"} {
+		_, _, err := cmd.ParseResourceSpec(spec)
+		assert.Error(t, err, spec)
+	}
+	assert.Equal(t, "2c4g", cmd.FormatResourceSpec(2, 4))
+}
+
+func TestMockServerReservation(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 0)
+	ctx := context.Background()
+
+	reserveResp, _, err := apiClient.ImageAPI.ReserveImageCapacity(ctx, "token", "session", "task-1", 4, 8)
+	require.NoError(t, err)
+	reservation := reserveResp.Data
+	assert.Equal(t, client.ReservationReserved, reservation.Status)
+	assert.True(t, reservation.Active())
+	_, ok := reservation.ExpiresAt()
+	assert.True(t, ok)
+
+	_, _, err = apiClient.ImageAPI.ReserveImageCapacity(ctx, "token", "session", "task-2", 4, 16)
+	code, _ := client.ErrorCode(err)
+	assert.Equal(t, "InvalidParameter", code, "only activation specs can be reserved")
+
+	// The reservation belongs to the image created by its task, and its first activation uses it
+	createResp, _, err := apiClient.ImageAPI.CreateImage(ctx, "token", "session", "reserved", "task-1", "agb-code-space-1", client.ImageCreateOptions{})
+	require.NoError(t, err)
+	imageID := createResp.Data
+	getResp, _, err := apiClient.ImageAPI.GetImageReservation(ctx, "token", "session", imageID)
+	require.NoError(t, err)
+	assert.Equal(t, reservation.ReservationID, getResp.Data.ReservationID)
+	assert.Equal(t, imageID, getResp.Data.ImageID)
+
+	_, _, err = apiClient.ImageAPI.StartImage(ctx, "token", "session", imageID, 0, 0, false)
+	require.NoError(t, err)
+	getResp, _, err = apiClient.ImageAPI.GetImageReservation(ctx, "token", "session", imageID)
+	require.NoError(t, err)
+	assert.Equal(t, client.ReservationConsumed, getResp.Data.Status)
+	listResp, _, err := apiClient.ImageAPI.ListImages(ctx, "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{imageID}})
+	require.NoError(t, err)
+	assert.Equal(t, 4, *listResp.Data.Images[0].CPU, "the activation has the reserved size")
+
+	_, _, err = apiClient.ImageAPI.ReleaseImageReservation(ctx, "token", "session", reservation.ReservationID)
+	code, _ = client.ErrorCode(err)
+	assert.Equal(t, "ReservationNotActive", code, "consumed capacity cannot be released")
+}
+
+func TestMockServerReservationCapacity(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 0)
+	ctx := context.Background()
+
+	var last client.ImageReservationData
+	for i := 0; i < mockserver.ReservationCapacity; i++ {
+		resp, _, err := apiClient.ImageAPI.ReserveImageCapacity(ctx, "token", "session", "task-1", 2, 4)
+		require.NoError(t, err)
+		last = resp.Data
+	}
+	_, _, err := apiClient.ImageAPI.ReserveImageCapacity(ctx, "token", "session", "task-2", 2, 4)
+	code, _ := client.ErrorCode(err)
+	assert.Equal(t, "InsufficientCapacity", code)
+
+	releaseResp, _, err := apiClient.ImageAPI.ReleaseImageReservation(ctx, "token", "session", last.ReservationID)
+	require.NoError(t, err)
+	assert.Equal(t, client.ReservationReleased, releaseResp.Data.Status)
+	_, _, err = apiClient.ImageAPI.ReserveImageCapacity(ctx, "token", "session", "task-2", 2, 4)
+	assert.NoError(t, err, "released capacity can be reserved again")
+}
+
+func TestImageCreateReleasesReservationWhenCreateFails(t *testing.T) {
+	useTempConfigDir(t)
+	server, apiClient := newSeededMockServer(t, 0)
+
+	// A stale System image list lets the source through; the mock rejects it after the reservation
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+	require.NoError(t, cmd.SaveCachedSystemImages([]client.ImageInfo{{ImageID: "agb-missing-1", Type: "System"}}, time.Now()))
+	stdout, stderr, err := runImageCreate(t, server.URL, "reserved", "--force", "-i", "agb-missing-1", "--reserve-spec", "4c8g")
+	require.Error(t, err)
+	assert.Contains(t, stdout, "[RESERVE] Reserving 4c8g for the first activation...")
+	assert.Contains(t, stdout, "[OK] Reserved 4c8g (Reservation ID: rsv-mock")
+	assert.Contains(t, stderr, "[ERROR] Failed to create image: SourceImageNotFound")
+	assert.Contains(t, stdout, "[CLEAN] Releasing reservation rsv-mock")
+	assert.Contains(t, stdout, "released")
+
+	// The released capacity is available again
+	for i := 0; i < mockserver.ReservationCapacity; i++ {
+		_, _, err := apiClient.ImageAPI.ReserveImageCapacity(context.Background(), "token", "session", "task-x", 8, 16)
+		require.NoError(t, err)
+	}
+}
+
+func TestImageCreateValidatesReserveSpec(t *testing.T) {
+	useTempConfigDir(t)
+	server, _ := newSeededMockServer(t, 0)
+
+	_, stderr, err := runImageCreate(t, server.URL, "reserved", "--force", "-i", "agb-code-space-1", "--reserve-spec", "large")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] Invalid --reserve-spec value: 'large' is not a spec like 4c8g")
+
+	_, stderr, err = runImageCreate(t, server.URL, "reserved", "--force", "-i", "agb-code-space-1", "--reserve-spec", "4c16g")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] Invalid CPU/Memory combination: 4c16g")
+}
+
+func TestImageCreateReserveSpecNeedsReservationEndpoint(t *testing.T) {
+	useTempConfigDir(t)
+	backend := mockserver.New()
+	// A server that predates reservations
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/image/reservation/create" {
+			http.NotFound(w, r)
+			return
+		}
+		backend.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	_, stderr, err := runImageCreate(t, server.URL, "reserved", "--force", "-i", "agb-code-space-1", "--reserve-spec", "2c4g")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] This AgbCloud endpoint does not support capacity reservations")
+}
+
+func TestImageStatusShowsReservation(t *testing.T) {
+	useTempConfigDir(t)
+	server, apiClient := newSeededMockServer(t, 1, "IMAGE_AVAILABLE")
+	saveTestTokens(t)
+	ctx := context.Background()
+
+	out, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "status", []string{"img-mock0001"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "[DATA] Image ID: img-mock0001")
+	assert.Contains(t, out, "[DATA] Status: Available")
+	assert.Contains(t, out, "[DATA] Reservation: none")
+
+	reserveResp, _, err := apiClient.ImageAPI.ReserveImageCapacity(ctx, "token", "session", "task-r", 8, 16)
+	require.NoError(t, err)
+	createResp, _, err := apiClient.ImageAPI.CreateImage(ctx, "token", "session", "reserved", "task-r", "agb-code-space-1", client.ImageCreateOptions{})
+	require.NoError(t, err)
+
+	out, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "status", []string{createResp.Data})
+	require.NoError(t, err)
+	assert.Contains(t, out, "[DATA] Reservation: 8c16g, Reserved until ")
+	assert.Contains(t, out, "(ID: "+reserveResp.Data.ReservationID+")")
+}
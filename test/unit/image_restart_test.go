@@ -0,0 +1,138 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/mockserver"
+)
+
+func TestMockServerRestartInstance(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 2, "RESOURCE_PUBLISHED", "IMAGE_AVAILABLE")
+	ctx := context.Background()
+	listResp, _, err := apiClient.ImageAPI.ListImages(ctx, "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, listResp.Data.Images, 2)
+	statusOf := func(imageId string) string {
+		resp, _, err := apiClient.ImageAPI.ListImages(ctx, "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{imageId}})
+		require.NoError(t, err)
+		require.Len(t, resp.Data.Images, 1)
+		return resp.Data.Images[0].Status
+	}
+
+	var activated, available string
+	for _, image := range listResp.Data.Images {
+		if image.Status == "RESOURCE_PUBLISHED" {
+			activated = image.ImageID
+		} else {
+			available = image.ImageID
+		}
+	}
+
+	resp, _, err := apiClient.ImageAPI.RestartInstance(ctx, "token", "session", activated)
+	require.NoError(t, err)
+	assert.True(t, resp.Data)
+	assert.Equal(t, "RESOURCE_RESTARTING", statusOf(activated))
+	assert.Equal(t, "RESOURCE_PUBLISHED", statusOf(activated), "the restart completes after one status check")
+
+	_, _, err = apiClient.ImageAPI.RestartInstance(ctx, "token", "session", available)
+	require.Error(t, err)
+	code, _ := client.ErrorCode(err)
+	assert.Equal(t, "ImageNotActivated", code)
+}
+
+// newRestartTestServer serves a mock backend with an activated and an available image.
+// Restarts complete before the first status check, so that the command waits for a
+// single poll interval. With unsupported set the restart endpoint does not exist.
+func newRestartTestServer(t *testing.T, unsupported bool) (server *httptest.Server, activated, available string, restarts *atomic.Int32) {
+	backend := mockserver.New()
+	seeded, err := backend.Seed(mockserver.SeedRequest{Images: 2, Statuses: []string{"RESOURCE_PUBLISHED", "IMAGE_AVAILABLE"}, Seed: 1})
+	require.NoError(t, err)
+	restarts = &atomic.Int32{}
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/image/restart" {
+			backend.ServeHTTP(w, r)
+			return
+		}
+		restarts.Add(1)
+		if unsupported {
+			http.NotFound(w, r)
+			return
+		}
+		backend.ServeHTTP(w, r)
+		settle, _ := http.NewRequest(http.MethodGet, "/api/image/list?loginToken=t&sessionId=s&imageType=User&page=1&pageSize=10", nil)
+		backend.ServeHTTP(&discardResponse{header: http.Header{}}, settle)
+	}))
+	t.Cleanup(server.Close)
+
+	apiClient := newLogsTestClient(server.URL)
+	listResp, _, err := apiClient.ImageAPI.ListImages(context.Background(), seeded.LoginToken, seeded.SessionID, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	for _, image := range listResp.Data.Images {
+		if image.Status == "RESOURCE_PUBLISHED" {
+			activated = image.ImageID
+		} else {
+			available = image.ImageID
+		}
+	}
+	require.NotEmpty(t, activated)
+	require.NotEmpty(t, available)
+	return server, activated, available, restarts
+}
+
+func TestImageRestartCommand(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, activated, _, restarts := newRestartTestServer(t, false)
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "restart", []string{activated})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), restarts.Load())
+	assert.Contains(t, stdout, "[OK] Image restart initiated successfully!")
+	assert.Contains(t, stdout, "[SUCCESS] Image restarted successfully! Image ID: "+activated)
+
+	recent, err := cmd.LoadRecentImages()
+	require.NoError(t, err)
+	require.NotEmpty(t, recent)
+	assert.Equal(t, activated, recent[0].ImageID)
+	assert.Equal(t, "restart", recent[0].Action)
+}
+
+func TestImageRestartRequiresActivatedImage(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _, available, restarts := newRestartTestServer(t, false)
+
+	_, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "restart", []string{available})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not activated (status: Available)")
+	assert.Contains(t, err.Error(), "agbcloud image activate "+available)
+	assert.Zero(t, restarts.Load(), "no restart is requested")
+}
+
+func TestImageRestartUnsupportedServer(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, activated, _, _ := newRestartTestServer(t, true)
+
+	_, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "restart", []string{activated})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support restarting an instance")
+	assert.True(t, strings.Contains(err.Error(), "agbcloud image deactivate "+activated+" && agbcloud image activate "+activated))
+}
+
+func TestFormatImageStatusRestarting(t *testing.T) {
+	assert.Equal(t, "Restarting", cmd.FormatImageStatus("RESOURCE_RESTARTING"))
+}
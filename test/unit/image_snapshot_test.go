@@ -0,0 +1,69 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/mockserver"
+)
+
+func TestImageSnapshot(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 2, "RESOURCE_PUBLISHED", "IMAGE_AVAILABLE")
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "snapshot", []string{"img-mock0001", "my-env-v2"}, "--no-wait")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Snapshot started; the instance keeps running")
+	match := regexp.MustCompile(`\[DOC\] Task ID: (\S+)`).FindStringSubmatch(stdout)
+	require.Len(t, match, 2)
+	assert.Contains(t, stdout, "agbcloud image task "+match[1]+" --watch")
+
+	// The snapshot is built by an image task like a created image
+	taskResp, _, err := apiClient.ImageAPI.GetImageTask(context.Background(), "token", "session", match[1])
+	require.NoError(t, err)
+	require.NotNil(t, taskResp.Data.ImageID)
+	assert.Equal(t, "RESOURCE_PUBLISHED", imageStatus(t, apiClient, "img-mock0001"), "the instance keeps running")
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "snapshot", []string{"img-mock0001", "my-env-v2"}, "--no-wait")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "An image named 'my-env-v2' already exists")
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "snapshot", []string{"img-mock0002", "my-env-v3"}, "--no-wait")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not activated (status: Available)")
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "snapshot", []string{"img-mock0001"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Expected 2 arguments (image ID and new image name), got 1")
+}
+
+func TestImageSnapshotUnsupportedServer(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	backend := mockserver.New()
+	_, err := backend.Seed(mockserver.SeedRequest{Images: 1, Statuses: []string{"RESOURCE_PUBLISHED"}, Seed: 1})
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/image/snapshot" {
+			http.NotFound(w, r)
+			return
+		}
+		backend.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "snapshot", []string{"img-mock0001", "my-env-v2"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support instance snapshots")
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+func TestDeleteImageTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/image/task/delete", r.URL.Path)
+
+		var body client.ImageTaskDeleteRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "task-123", body.TaskId)
+		assert.Equal(t, "test-session-id", body.SessionId)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success": true, "data": true}`)) // Ignore errors in test mock server
+	}))
+	defer server.Close()
+
+	apiClient := newLogsTestClient(server.URL)
+	resp, _, err := apiClient.ImageAPI.DeleteImageTask(context.Background(), "test-login-token", "test-session-id", "task-123")
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	assert.True(t, resp.Data)
+
+	_, _, err = apiClient.ImageAPI.DeleteImageTask(context.Background(), "test-login-token", "test-session-id", "")
+	assert.Error(t, err, "taskId is required")
+}
+
+func TestResolveCleanupOnFailure(t *testing.T) {
+	createCmd := findSubcommand(t, cmd.ImageCmd, "create")
+	enabled := true
+
+	assert.False(t, cmd.ResolveCleanupOnFailure(createCmd, &config.Config{}), "off by default")
+	assert.True(t, cmd.ResolveCleanupOnFailure(createCmd, &config.Config{CleanupOnFailure: &enabled}), "configured default")
+
+	require.NoError(t, createCmd.Flags().Set("cleanup-on-failure", "false"))
+	defer func() {
+		_ = createCmd.Flags().Set("cleanup-on-failure", "false") // Ignore errors in test cleanup
+		createCmd.Flags().Lookup("cleanup-on-failure").Changed = false
+	}()
+	assert.False(t, cmd.ResolveCleanupOnFailure(createCmd, &config.Config{CleanupOnFailure: &enabled}), "the flag overrides the config")
+}
+
+func TestImageTaskDeleteRequiresTaskID(t *testing.T) {
+	deleteCmd := findSubcommand(t, findSubcommand(t, cmd.ImageCmd, "task"), "delete")
+
+	var err error
+	captureStderr(func() { err = deleteCmd.Args(deleteCmd, nil) })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "<task-id>")
+	assert.NoError(t, deleteCmd.Args(deleteCmd, []string{"task-123"}))
+}
+
+func TestFindInProgressImageTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/image/task/list", r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("page"))
+
+		var tasks []client.ImageTaskInfo
+		switch r.URL.Query().Get("imageName") {
+		case "web-app":
+			tasks = []client.ImageTaskInfo{
+				{TaskID: "task-3", ImageName: "web-app-v2", Status: "Preparing"},
+				{TaskID: "task-2", ImageName: "web-app", Status: "Preparing"},
+				{TaskID: "task-1", ImageName: "web-app", Status: "Inline"},
+			}
+		case "finished":
+			tasks = []client.ImageTaskInfo{
+				{TaskID: "task-4", ImageName: "finished", Status: "Finished"},
+				{TaskID: "task-5", ImageName: "finished", Status: "Failed"},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.ImageTaskListResponse{ // Ignore errors in test mock server
+			Success: true,
+			Data:    client.ImageTaskListData{Tasks: tasks, Total: len(tasks), Page: 1, PageSize: 20},
+		})
+	}))
+	defer server.Close()
+
+	apiClient := newLogsTestClient(server.URL)
+
+	task, err := cmd.FindInProgressImageTask(context.Background(), apiClient, "test-login-token", "test-session-id", "web-app")
+	require.NoError(t, err)
+	require.NotNil(t, task)
+	assert.Equal(t, "task-2", task.TaskID, "the most recent running task with exactly this name")
+
+	task, err = cmd.FindInProgressImageTask(context.Background(), apiClient, "test-login-token", "test-session-id", "finished")
+	require.NoError(t, err)
+	assert.Nil(t, task, "finished and failed tasks are not reused")
+}
+
+func TestIsImageTaskInProgress(t *testing.T) {
+	assert.True(t, cmd.IsImageTaskInProgress("Inline"))
+	assert.True(t, cmd.IsImageTaskInProgress("Preparing"))
+	assert.False(t, cmd.IsImageTaskInProgress("Finished"))
+	assert.False(t, cmd.IsImageTaskInProgress("Failed"))
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		answer     string
+		defaultYes bool
+		want       bool
+	}{
+		{"y\n", false, true},
+		{"YES\n", false, true},
+		{"n\n", true, false},
+		{"\n", true, true},
+		{"\n", false, false},
+		{"", true, true},
+		{"maybe\n", false, false},
+	}
+	for _, tt := range tests {
+		var out strings.Builder
+		got := cmd.Confirm(strings.NewReader(tt.answer), &out, "Attach?", tt.defaultYes)
+		assert.Equal(t, tt.want, got, "answer %q, default yes %v", tt.answer, tt.defaultYes)
+		assert.Contains(t, out.String(), "Attach?")
+	}
+}
+
+func TestImageCreateForceNewFlag(t *testing.T) {
+	createCmd := findSubcommand(t, cmd.ImageCmd, "create")
+	flag := createCmd.Flags().Lookup("force-new")
+	require.NotNil(t, flag)
+	assert.Equal(t, "false", flag.DefValue)
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+)
+
+func TestImageTaskWatchesSeveralTasks(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 0)
+	dockerfile := filepath.Join(t.TempDir(), "Dockerfile")
+	require.NoError(t, os.WriteFile(dockerfile, []byte("FROM agb-code-space-1\n"), 0644))
+
+	for _, name := range []string{"fan-out-a", "fan-out-b"} {
+		_, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "create", []string{name}, "-f", dockerfile, "-i", "agb-code-space-1", "--force", "--force-new", "--detach")
+		require.NoError(t, err)
+	}
+	jobs, err := cmd.LoadJobs()
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	first, second := jobs[0].TaskID, jobs[1].TaskID
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "task", []string{first, second, first})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "TASK ID")
+	assert.Regexp(t, first+` +fan-out-a +Preparing`, stdout)
+	assert.Regexp(t, second+` +fan-out-b +Preparing`, stdout)
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "task", []string{first, second}, "--watch")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[MONITOR] Watching 2 task(s)...")
+	assert.Contains(t, stdout, first+" (fan-out-a)")
+	assert.Regexp(t, second+` +fan-out-b +Finished +img-`, stdout)
+	assert.Contains(t, stdout, "[DATA] Summary: 2 finished, 0 failed")
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "task", []string{first, "task-missing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 task(s) failed: task-missing")
+	assert.Contains(t, stdout, "TaskNotFound")
+}
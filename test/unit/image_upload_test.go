@@ -0,0 +1,271 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// storageRequest is a request received by the fake storage service
+type storageRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   string
+}
+
+// newFakeStorage records every request and answers with handler
+func newFakeStorage(t *testing.T, handler func(w http.ResponseWriter, r *http.Request)) (*httptest.Server, func() []storageRequest) {
+	var mu sync.Mutex
+	var requests []storageRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, storageRequest{r.Method, r.URL.Path, r.URL.RawQuery, r.Header.Clone(), string(body)})
+		mu.Unlock()
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []storageRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]storageRequest(nil), requests...)
+	}
+}
+
+func TestParseStorageBackend(t *testing.T) {
+	for name, expected := range map[string]string{
+		"":              client.StoragePresignedPut,
+		"oss":           client.StoragePresignedPut,
+		"S3":            client.StoragePresignedPut,
+		"presigned-put": client.StoragePresignedPut,
+		"s3-multipart":  client.StorageS3Multipart,
+		" azure-blob ":  client.StorageAzureBlob,
+	} {
+		backend, err := cmd.ParseStorageBackend(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, expected, backend, name)
+	}
+
+	_, err := cmd.ParseStorageBackend("gcs")
+	assert.EqualError(t, err, "unsupported upload storage 'gcs', expected presigned-put, s3-multipart or azure-blob")
+}
+
+func TestPresignedPutUpload(t *testing.T) {
+	server, requests := newFakeStorage(t, func(w http.ResponseWriter, r *http.Request) {})
+	uploader, err := cmd.NewDockerfileUploader(client.ImageUploadCredentialData{OssURL: server.URL + "/dockerfile?Signature=abc"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, uploader.Upload(context.Background(), []byte("FROM scratch\n")))
+
+	received := requests()
+	require.Len(t, received, 1)
+	assert.Equal(t, http.MethodPut, received[0].Method)
+	assert.Equal(t, "/dockerfile", received[0].Path)
+	assert.Equal(t, "Signature=abc", received[0].Query)
+	assert.Equal(t, "application/octet-stream", received[0].Header.Get("Content-Type"))
+	assert.Equal(t, "FROM scratch\n", received[0].Body)
+}
+
+func TestAzureBlobUpload(t *testing.T) {
+	server, requests := newFakeStorage(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	credential := client.ImageUploadCredentialData{StorageType: client.StorageAzureBlob, OssURL: server.URL + "/container/dockerfile?sv=2022-11-02&sig=abc"}
+	uploader, err := cmd.NewDockerfileUploader(credential, nil)
+	require.NoError(t, err)
+	require.NoError(t, uploader.Upload(context.Background(), []byte("FROM scratch\n")))
+
+	received := requests()
+	require.Len(t, received, 1)
+	assert.Equal(t, http.MethodPut, received[0].Method)
+	assert.Equal(t, "BlockBlob", received[0].Header.Get("x-ms-blob-type"))
+}
+
+func TestS3MultipartUpload(t *testing.T) {
+	server, requests := newFakeStorage(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			w.Header().Set("ETag", fmt.Sprintf(`"etag-%s"`, r.URL.Query().Get("partNumber")))
+		}
+	})
+	credential := client.ImageUploadCredentialData{
+		StorageType: client.StorageS3Multipart,
+		PartURLs:    []string{server.URL + "/dockerfile?partNumber=1&uploadId=u1", server.URL + "/dockerfile?partNumber=2&uploadId=u1"},
+		CompleteURL: server.URL + "/dockerfile?uploadId=u1",
+	}
+	uploader, err := cmd.NewDockerfileUploader(credential, nil)
+	require.NoError(t, err)
+	require.NoError(t, uploader.Upload(context.Background(), []byte("FROM scratch\n")))
+
+	received := requests()
+	require.Len(t, received, 2, "a small Dockerfile is a single part")
+	assert.Equal(t, http.MethodPut, received[0].Method)
+	assert.Equal(t, "partNumber=1&uploadId=u1", received[0].Query)
+	assert.Equal(t, "FROM scratch\n", received[0].Body)
+	assert.Equal(t, http.MethodPost, received[1].Method)
+	assert.Equal(t, "uploadId=u1", received[1].Query)
+	assert.Equal(t, `<CompleteMultipartUpload><Part><PartNumber>1</PartNumber><ETag>&#34;etag-1&#34;</ETag></Part></CompleteMultipartUpload>`, received[1].Body)
+}
+
+func TestS3MultipartUploadSplitsLargeDockerfiles(t *testing.T) {
+	server, requests := newFakeStorage(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"etag"`)
+	})
+	credential := client.ImageUploadCredentialData{
+		StorageType: client.StorageS3Multipart,
+		PartURLs:    []string{server.URL + "/p1", server.URL + "/p2"},
+		CompleteURL: server.URL + "/complete",
+	}
+	uploader, err := cmd.NewDockerfileUploader(credential, nil)
+	require.NoError(t, err)
+
+	content := []byte(strings.Repeat("x", 5*1024*1024+10))
+	require.NoError(t, uploader.Upload(context.Background(), content))
+	received := requests()
+	require.Len(t, received, 3)
+	assert.Len(t, received[0].Body, 5*1024*1024)
+	assert.Len(t, received[1].Body, 10)
+
+	content = []byte(strings.Repeat("x", 10*1024*1024+1))
+	assert.EqualError(t, uploader.Upload(context.Background(), content), "the Dockerfile needs 3 parts, but the upload credentials allow 2")
+}
+
+func TestS3MultipartCompletionErrorInBody(t *testing.T) {
+	server, _ := newFakeStorage(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			w.Header().Set("ETag", `"etag"`)
+			return
+		}
+		_, _ = w.Write([]byte("<Error><Code>InternalError</Code></Error>"))
+	})
+	credential := client.ImageUploadCredentialData{
+		StorageType: client.StorageS3Multipart,
+		PartURLs:    []string{server.URL + "/p1"},
+		CompleteURL: server.URL + "/complete",
+	}
+	uploader, err := cmd.NewDockerfileUploader(credential, nil)
+	require.NoError(t, err)
+
+	err = uploader.Upload(context.Background(), []byte("FROM scratch\n"))
+	var uploadErr *cmd.UploadError
+	require.True(t, errors.As(err, &uploadErr), "got %v", err)
+	assert.Contains(t, uploadErr.Body, "InternalError")
+}
+
+func TestUploadStorageConfigOverrides(t *testing.T) {
+	server, requests := newFakeStorage(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	// The announced OSS URL is not reachable; the configured endpoint is
+	credential := client.ImageUploadCredentialData{OssURL: "https://bucket.oss-cn-hangzhou.aliyuncs.com/dockerfile?Signature=abc"}
+	storage := &config.UploadStorage{Backend: "azure-blob", Endpoint: server.URL}
+	uploader, err := cmd.NewDockerfileUploader(credential, storage)
+	require.NoError(t, err)
+	require.NoError(t, uploader.Upload(context.Background(), []byte("FROM scratch\n")))
+
+	received := requests()
+	require.Len(t, received, 1)
+	assert.Equal(t, "/dockerfile", received[0].Path)
+	assert.Equal(t, "Signature=abc", received[0].Query)
+	assert.Equal(t, "BlockBlob", received[0].Header.Get("x-ms-blob-type"), "the configured backend wins")
+}
+
+func TestNewDockerfileUploaderRejectsIncompleteCredentials(t *testing.T) {
+	_, err := cmd.NewDockerfileUploader(client.ImageUploadCredentialData{StorageType: client.StorageS3Multipart, OssURL: "https://bucket/dockerfile"}, nil)
+	assert.Error(t, err)
+
+	_, err = cmd.NewDockerfileUploader(client.ImageUploadCredentialData{}, nil)
+	assert.Error(t, err)
+
+	_, err = cmd.NewDockerfileUploader(client.ImageUploadCredentialData{StorageType: "ftp", OssURL: "https://bucket/dockerfile"}, nil)
+	assert.Error(t, err)
+
+	_, err = cmd.NewDockerfileUploader(client.ImageUploadCredentialData{OssURL: "https://bucket/dockerfile"}, &config.UploadStorage{Endpoint: "ftp://internal"})
+	assert.Error(t, err)
+}
+
+func TestUploadCredentialExpiryOfMultipartUpload(t *testing.T) {
+	data := client.ImageUploadCredentialData{
+		StorageType: client.StorageS3Multipart,
+		CompleteURL: "https://bucket.s3.amazonaws.com/dockerfile?uploadId=u1&X-Amz-Date=20250911T054808Z&X-Amz-Expires=900",
+	}
+	expiresAt, ok := data.ExpiresAt()
+	require.True(t, ok)
+	assert.Equal(t, "2025-09-11T06:03:08Z", expiresAt.UTC().Format("2006-01-02T15:04:05Z"))
+}
+
+func TestS3MultipartUploadResumesFromJournal(t *testing.T) {
+	useTempConfigDir(t)
+	failPart2 := true
+	server, requests := newFakeStorage(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/p2" && failPart2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("ETag", `"etag`+strings.TrimPrefix(r.URL.Path, "/p")+`"`)
+	})
+	credential := client.ImageUploadCredentialData{
+		TaskID:      "task-1",
+		StorageType: client.StorageS3Multipart,
+		PartURLs:    []string{server.URL + "/p1", server.URL + "/p2"},
+		CompleteURL: server.URL + "/complete",
+		ExpireTime:  time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	}
+	content := []byte(strings.Repeat("x", 5*1024*1024+10))
+	uploader, err := cmd.NewDockerfileUploader(credential, nil)
+	require.NoError(t, err)
+
+	// The first part is stored before the upload is interrupted
+	require.Error(t, cmd.WithUploadJournal(uploader, cmd.NewUploadJournal(content, credential)).Upload(context.Background(), content))
+	journal, err := cmd.LoadUploadJournal(content)
+	require.NoError(t, err)
+	require.NotNil(t, journal, "the progress survives the CLI")
+	assert.Equal(t, "task-1", journal.Credential.TaskID)
+	require.Len(t, journal.Parts, 1)
+	assert.Equal(t, `"etag1"`, journal.Parts[0].ETag)
+	assert.False(t, journal.Expired(time.Now()))
+
+	other, err := cmd.LoadUploadJournal([]byte("FROM scratch\n"))
+	require.NoError(t, err)
+	assert.Nil(t, other, "journals belong to the content they upload")
+
+	// Resuming skips the stored part
+	failPart2 = false
+	before := len(requests())
+	require.NoError(t, cmd.WithUploadJournal(uploader, journal).Upload(context.Background(), content))
+	resumed := requests()[before:]
+	require.Len(t, resumed, 2)
+	assert.Equal(t, "/p2", resumed[0].Path)
+	assert.Contains(t, resumed[1].Body, "<ETag>&#34;etag1&#34;</ETag>")
+
+	// A part whose content does not match its recorded digest is uploaded again
+	journal.Parts[0].Digest = "sha256:0000"
+	before = len(requests())
+	require.NoError(t, cmd.WithUploadJournal(uploader, journal).Upload(context.Background(), content))
+	reuploaded := requests()[before:]
+	require.Len(t, reuploaded, 2)
+	assert.Equal(t, "/p1", reuploaded[0].Path)
+
+	journal.Remove()
+	journal, err = cmd.LoadUploadJournal(content)
+	require.NoError(t, err)
+	assert.Nil(t, journal)
+}
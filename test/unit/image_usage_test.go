@@ -0,0 +1,88 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/mockserver"
+)
+
+func TestFormatImageUsage(t *testing.T) {
+	lastUsed, hours, activations := cmd.FormatImageUsage(client.ImageInfo{})
+	assert.Equal(t, []string{"-", "-", "-"}, []string{lastUsed, hours, activations}, "unknown usage")
+
+	zero, none := 0, 0.0
+	lastUsed, hours, activations = cmd.FormatImageUsage(client.ImageInfo{ActivationCount: &zero, ActiveHours: &none})
+	assert.Equal(t, []string{"never", "0.0", "0"}, []string{lastUsed, hours, activations})
+
+	count, active, when := 3, 12.25, "2025-03-01T10:00:00Z"
+	_, hours, activations = cmd.FormatImageUsage(client.ImageInfo{ActivationCount: &count, ActiveHours: &active, LastUsedTime: &when})
+	assert.Equal(t, "12.2", hours)
+	assert.Equal(t, "3", activations)
+}
+
+func TestMockServerImageUsage(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 2, "IMAGE_AVAILABLE")
+	ctx := context.Background()
+	before, _, err := apiClient.ImageAPI.GetImageUsage(ctx, "token", "session", []string{"img-mock0001"})
+	require.NoError(t, err)
+	require.Len(t, before.Data, 1)
+
+	_, _, err = apiClient.ImageAPI.StartImage(ctx, "token", "session", "img-mock0001", 0, 0, false)
+	require.NoError(t, err)
+	after, _, err := apiClient.ImageAPI.GetImageUsage(ctx, "token", "session", []string{"img-mock0001"})
+	require.NoError(t, err)
+	require.Len(t, after.Data, 1)
+	assert.Equal(t, before.Data[0].ActivationCount+1, after.Data[0].ActivationCount, "every activation is counted")
+	assert.NotEmpty(t, after.Data[0].LastUsedTime)
+	assert.GreaterOrEqual(t, after.Data[0].ActiveHours, before.Data[0].ActiveHours)
+}
+
+func TestImageListWide(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 4, "IMAGE_AVAILABLE")
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "-o", "wide")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "LAST USED")
+	assert.Contains(t, stdout, "ACTIVE HOURS")
+	assert.Contains(t, stdout, "ACTIVATIONS")
+
+	// The plain table has no usage columns
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "-o", "table")
+	require.NoError(t, err)
+	assert.NotContains(t, stdout, "ACTIVATIONS")
+}
+
+func TestImageListWideWithoutUsageEndpoint(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	backend := mockserver.New()
+	_, err := backend.Seed(mockserver.SeedRequest{Images: 1, Statuses: []string{"IMAGE_AVAILABLE"}, Seed: 1})
+	require.NoError(t, err)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/image/usage" {
+			http.NotFound(w, r)
+			return
+		}
+		backend.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	stdout, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "-o", "wide")
+	require.NoError(t, err, "the list is shown without usage")
+	assert.Contains(t, stdout+stderr, "This server does not report image usage")
+	assert.Contains(t, stdout, "img-mock0001")
+	assert.Contains(t, stdout, "ACTIVATIONS")
+}
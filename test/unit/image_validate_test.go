@@ -0,0 +1,151 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/mockserver"
+)
+
+// writeTestDockerfile writes content to a Dockerfile in a temporary directory
+func writeTestDockerfile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "Dockerfile")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestValidateCreateFindings(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 1)
+	ctx := context.Background()
+
+	resp, _, err := apiClient.ImageAPI.ValidateCreate(ctx, "token", "session", client.ImageValidateOptions{
+		ImageName: "fresh", SourceImageId: "agb-code-space-1", Dockerfile: "RUN echo ok\n",
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.Data.Valid)
+	assert.Empty(t, resp.Data.Findings)
+
+	resp, _, err = apiClient.ImageAPI.ValidateCreate(ctx, "token", "session", client.ImageValidateOptions{
+		ImageName:     "fresh",
+		SourceImageId: "agb-unknown",
+		Dockerfile:    "# base\nFROM ubuntu:22.04\nRUN apt-get update && \\\n    VOLUME /data\nVOLUME /data\n",
+		Platforms:     []string{"linux/s390x"},
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Data.Valid)
+	var findings []string
+	for _, finding := range resp.Data.Findings {
+		assert.Equal(t, client.ValidationError, finding.Severity, finding.Code)
+		findings = append(findings, cmd.FormatValidationFinding(finding))
+	}
+	assert.Equal(t, []string{
+		"[ERROR] base image 'agb-unknown' does not exist (SourceImageNotFound)",
+		"[ERROR] platform 'linux/s390x' is not supported (UnsupportedPlatform)",
+		"[ERROR] Dockerfile line 2: FROM is not allowed (ForbiddenInstruction)",
+		"[ERROR] Dockerfile line 5: VOLUME is not allowed (ForbiddenInstruction)",
+	}, findings, "a continued line is not an instruction")
+
+	resp, _, err = apiClient.ImageAPI.ValidateCreate(ctx, "token", "session", client.ImageValidateOptions{
+		ImageName: "fresh", SourceImageId: "agb-code-space-1", Dockerfile: "# only a comment\n",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Data.Findings, 1)
+	assert.Equal(t, "EmptyDockerfile", resp.Data.Findings[0].Code)
+}
+
+func TestFormatValidationFinding(t *testing.T) {
+	assert.Equal(t, "[ERROR] Dockerfile line 2: FROM is not allowed (ForbiddenInstruction)",
+		cmd.FormatValidationFinding(client.ImageValidationFinding{Severity: client.ValidationError, Code: "ForbiddenInstruction", Line: 2, Message: "FROM is not allowed"}))
+	assert.Equal(t, "[WARN]  an image named 'demo' already exists (ImageNameExists)",
+		cmd.FormatValidationFinding(client.ImageValidationFinding{Severity: client.ValidationWarning, Code: "ImageNameExists", Message: "an image named 'demo' already exists"}))
+	assert.Equal(t, "[INFO]  the build takes about 5 minutes",
+		cmd.FormatValidationFinding(client.ImageValidationFinding{Severity: client.ValidationInfo, Message: "the build takes about 5 minutes"}))
+
+	validation := cmd.NewImageValidation("demo", "agb-code-space-1", "Dockerfile", client.ImageValidationData{
+		Valid:    true,
+		Findings: []client.ImageValidationFinding{{Severity: client.ValidationError}, {Severity: client.ValidationWarning}},
+	})
+	assert.False(t, validation.Valid, "an error finding makes the request invalid")
+	assert.Equal(t, 1, validation.Errors)
+	assert.Equal(t, 1, validation.Warnings)
+}
+
+func TestImageValidateRemoteCommand(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 1)
+	listResp, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	existing := listResp.Data.Images[0].ImageName
+
+	good := writeTestDockerfile(t, "RUN echo ok\n")
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "validate-remote", []string{"fresh"}, "-f", good, "-i", "agb-code-space-1")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] The server accepts the create request for 'fresh'")
+	assert.Contains(t, stdout, "[DATA] Summary: 0 error(s), 0 warning(s)")
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "validate-remote", []string{existing}, "-f", good, "-i", "agb-code-space-1")
+	require.NoError(t, err, "warnings pass unless --fail-on-warnings is given")
+	assert.Contains(t, stdout, "[WARN]  an image named '"+existing+"' already exists")
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "validate-remote", []string{existing}, "-f", good, "-i", "agb-code-space-1", "--fail-on-warnings")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 warning(s)")
+
+	bad := writeTestDockerfile(t, "FROM ubuntu:22.04\nRUN echo ok\n")
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "validate-remote", []string{"fresh"}, "-f", bad, "-i", "agb-code-space-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 error(s)")
+	assert.Contains(t, stdout, "[ERROR] Dockerfile line 1: FROM is not allowed (ForbiddenInstruction)")
+	assert.NotContains(t, stdout, "[OK]")
+
+	// Nothing was built or uploaded
+	tasks, _, err := apiClient.ImageAPI.ListImageTasks(context.Background(), "token", "session", client.ImageTaskListOptions{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Empty(t, tasks.Data.Tasks)
+}
+
+func TestImageValidateRemoteJSON(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 0)
+
+	bad := writeTestDockerfile(t, "VOLUME /data\n")
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "validate-remote", []string{"fresh"}, "-f", bad, "-i", "agb-code-space-1", "-o", "json")
+	require.Error(t, err)
+
+	var validation cmd.ImageValidation
+	require.NoError(t, json.Unmarshal([]byte(stdout), &validation), stdout)
+	assert.Equal(t, "fresh", validation.ImageName)
+	assert.False(t, validation.Valid)
+	assert.Equal(t, 1, validation.Errors)
+	require.Len(t, validation.Findings, 1)
+	assert.Equal(t, 1, validation.Findings[0].Line)
+}
+
+func TestMockServerImageQuota(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, mockserver.ImageQuota)
+	ctx := context.Background()
+
+	resp, _, err := apiClient.ImageAPI.ValidateCreate(ctx, "token", "session", client.ImageValidateOptions{
+		ImageName: "one-too-many", SourceImageId: "agb-code-space-1", Dockerfile: "RUN echo ok\n",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Data.Valid)
+	require.Len(t, resp.Data.Findings, 1)
+	assert.Equal(t, "ImageQuotaExceeded", resp.Data.Findings[0].Code)
+
+	_, _, err = apiClient.ImageAPI.CreateImage(ctx, "token", "session", "one-too-many", "task-1", "agb-code-space-1", client.ImageCreateOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ImageQuotaExceeded", "the quota the validation reports is enforced on create")
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/imagestatus"
+	"github.com/agbcloud/agbcloud-cli/internal/mockserver"
+)
+
+func TestImageStatusTransitions(t *testing.T) {
+	for _, status := range imagestatus.All {
+		assert.True(t, status.Known(), "status %s", status)
+		assert.NotEqual(t, status.IsTransitional(), status.IsTerminal(), "status %s is either transitional or terminal", status)
+
+		next, ok := status.NextExpected()
+		assert.Equal(t, status.IsTransitional(), ok, "status %s", status)
+		if ok {
+			assert.True(t, next.IsTerminal(), "%s settles in a terminal status, got %s", status, next)
+		}
+	}
+
+	next, _ := imagestatus.Deploying.NextExpected()
+	assert.Equal(t, imagestatus.Published, next)
+	next, _ = imagestatus.Deleting.NextExpected()
+	assert.Equal(t, imagestatus.Available, next)
+	next, _ = imagestatus.Restarting.NextExpected()
+	assert.Equal(t, imagestatus.Published, next)
+}
+
+func TestImageStatusUnknown(t *testing.T) {
+	unknown := imagestatus.Status("RESOURCE_MIGRATING")
+	assert.False(t, unknown.Known())
+	assert.False(t, unknown.IsTerminal(), "pollers keep watching unknown statuses")
+	assert.False(t, unknown.IsTransitional())
+	assert.False(t, unknown.CanActivate())
+	assert.Equal(t, "RESOURCE_MIGRATING", unknown.Label())
+	assert.Equal(t, "RESOURCE_MIGRATING", cmd.FormatImageStatus("RESOURCE_MIGRATING"))
+}
+
+func TestImageStatusPredicates(t *testing.T) {
+	activatable := []imagestatus.Status{imagestatus.Available, imagestatus.Failed, imagestatus.Ceased}
+	withInstance := []imagestatus.Status{imagestatus.Deploying, imagestatus.Published, imagestatus.Restarting}
+	for _, status := range imagestatus.All {
+		assert.Equal(t, slices.Contains(activatable, status), status.CanActivate(), "CanActivate(%s)", status)
+		assert.Equal(t, slices.Contains(withInstance, status), status.HasInstance(), "HasInstance(%s)", status)
+		assert.False(t, status.CanActivate() && status.HasInstance(), "status %s", status)
+	}
+	assert.True(t, imagestatus.CreateFailed.IsFailed())
+	assert.True(t, imagestatus.Failed.IsFailed())
+	assert.False(t, imagestatus.Ceased.IsFailed())
+	assert.Equal(t, "Activated", cmd.FormatImageStatus(string(imagestatus.Published)))
+}
+
+func TestMockServerStatusesAreKnown(t *testing.T) {
+	for _, status := range mockserver.Statuses {
+		assert.True(t, imagestatus.Status(status).Known(), "seed status %s", status)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+)
+
+func TestAskAndChoose(t *testing.T) {
+	// Consecutive questions share one reader so that buffered answers are kept
+	in := bufio.NewReader(strings.NewReader("web-app\n\n7\nx\n2\n"))
+	var out bytes.Buffer
+
+	assert.Equal(t, "web-app", cmd.Ask(in, &out, "Image name", "my-first-image"))
+	assert.Equal(t, "my-first-image", cmd.Ask(in, &out, "Image name", "my-first-image"), "Enter selects the default")
+
+	options := []string{"agb-code-space-1", "agb-browser-use-1"}
+	assert.Equal(t, 1, cmd.Choose(in, &out, "Base image", options, 0), "invalid answers are asked again")
+	assert.Equal(t, 2, strings.Count(out.String(), "[WARN]  Enter a number between 1 and 2"))
+	assert.Contains(t, out.String(), "  2) agb-browser-use-1")
+
+	assert.Equal(t, 0, cmd.Choose(in, &out, "Base image", options, 0), "no more input selects the default")
+	assert.Equal(t, "fallback", cmd.Ask(in, &out, "Path", "fallback"))
+}
+
+func TestInitWizardWritesStarterDockerfile(t *testing.T) {
+	useTempConfigDir(t)
+	server, _ := newSeededMockServer(t, 0)
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+	saveTestTokens(t)
+
+	dockerfile := filepath.Join(t.TempDir(), "Dockerfile")
+	answers := strings.Join([]string{
+		"2",           // agb-browser-use-1, System images are listed in the order of the mock server
+		dockerfile,    // Dockerfile path
+		"1-bad-name",  // rejected image name
+		"browser-app", // image name
+		"n",           // do not create the image
+	}, "\n") + "\n"
+
+	var out bytes.Buffer
+	require.NoError(t, cmd.RunInitWizard(context.Background(), strings.NewReader(answers), &out))
+
+	assert.Contains(t, out.String(), "[OK] Already logged in")
+	assert.Contains(t, out.String(), "[TIP] Without the wizard: agbcloud image list -t System")
+	assert.Contains(t, out.String(), "[OK] Base image: agb-browser-use-1")
+	assert.Contains(t, out.String(), "[WARN]  Image names have")
+	assert.Contains(t, out.String(), "[TIP] Without the wizard: agbcloud image create browser-app --dockerfile "+dockerfile+" --imageId agb-browser-use-1")
+	assert.Contains(t, out.String(), "[NOTE] Skipped; create the image later")
+
+	content, err := os.ReadFile(dockerfile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# Base image: agb-browser-use-1")
+	assert.Empty(t, cmd.LintDockerfile(string(content)), "the starter Dockerfile passes the local checks")
+
+	// An existing Dockerfile is kept unless the user asks to replace it
+	require.NoError(t, os.WriteFile(dockerfile, []byte("RUN echo custom\n"), 0644))
+	answers = strings.Join([]string{"1", dockerfile, "", "", "n"}, "\n") + "\n"
+	out.Reset()
+	require.NoError(t, cmd.RunInitWizard(context.Background(), strings.NewReader(answers), &out))
+	assert.Contains(t, out.String(), "[OK] Using "+dockerfile)
+	content, err = os.ReadFile(dockerfile)
+	require.NoError(t, err)
+	assert.Equal(t, "RUN echo custom\n", string(content))
+}
+
+func TestInitRequiresLogin(t *testing.T) {
+	useTempConfigDir(t)
+
+	var out bytes.Buffer
+	var runErr error
+	stderr := captureStderr(func() { runErr = cmd.RunInitWizard(context.Background(), strings.NewReader("n\n"), &out) })
+	require.Error(t, runErr)
+	assert.Contains(t, out.String(), "You are not logged in")
+	assert.Contains(t, stderr, "[TIP] Run 'agbcloud login', then 'agbcloud init' again")
+}
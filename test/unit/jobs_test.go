@@ -0,0 +1,140 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestJobsAreScopedToProfileAndEndpoint(t *testing.T) {
+	useTempConfigDir(t)
+	t.Setenv("AGB_CLI_PROFILE", "")
+	t.Setenv("AGB_CLI_ENDPOINT", "https://agb.example.com")
+
+	first, err := cmd.AddJob(cmd.Job{Type: cmd.JobCreate, ImageName: "web", TaskID: "task-1"})
+	require.NoError(t, err)
+	second, err := cmd.AddJob(cmd.Job{Type: cmd.JobActivate, ImageID: "img-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.ID)
+	assert.Equal(t, 2, second.ID)
+	assert.Equal(t, "https://agb.example.com", first.Endpoint)
+	assert.False(t, first.StartedAt.IsZero())
+
+	job, err := cmd.FindJob("2")
+	require.NoError(t, err)
+	assert.Equal(t, "img-1", job.Target())
+	_, err = cmd.FindJob("7")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "job 7 not found")
+	_, err = cmd.FindJob("web")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid job ID 'web'")
+
+	t.Setenv("AGB_CLI_ENDPOINT", "https://other.example.com")
+	jobs, err := cmd.LoadJobs()
+	require.NoError(t, err)
+	assert.Empty(t, jobs, "jobs are listed for the endpoint they were started on")
+	_, err = cmd.FindJob("1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "job 1 was started with the default profile on https://agb.example.com")
+
+	t.Setenv("AGB_CLI_ENDPOINT", "https://agb.example.com")
+	require.NoError(t, cmd.RemoveJob(1))
+	jobs, err = cmd.LoadJobs()
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, 2, jobs[0].ID)
+
+	third, err := cmd.AddJob(cmd.Job{Type: cmd.JobActivate, ImageID: "img-2"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, third.ID, "IDs are not reused")
+}
+
+func TestImageCreateDetachAndCancel(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 0)
+	dockerfile := filepath.Join(t.TempDir(), "Dockerfile")
+	require.NoError(t, os.WriteFile(dockerfile, []byte("FROM agb-code-space-1\n"), 0644))
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "create", []string{"detached"}, "-f", dockerfile, "-i", "agb-code-space-1", "--force", "--force-new", "--detach")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[JOB] Running in the background as job 1")
+	assert.NotContains(t, stdout, "[MONITOR]", "a detached build is not monitored")
+
+	jobs, err := cmd.LoadJobs()
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, cmd.JobCreate, jobs[0].Type)
+	assert.Equal(t, "detached", jobs[0].ImageName)
+	assert.NotEmpty(t, jobs[0].TaskID)
+
+	taskId := jobs[0].TaskID
+	stdout, _, err = runSubcommand(t, cmd.JobsCmd, server.URL, "cancel", []string{"1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Job 1 cancelled")
+	jobs, err = cmd.LoadJobs()
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	_, _, err = newLogsTestClient(server.URL).ImageAPI.GetImageTask(context.Background(), "token", "session", taskId)
+	code, _ := client.ErrorCode(err)
+	assert.Equal(t, "TaskNotFound", code, "the build task is deleted")
+}
+
+func TestImageActivateDetachAndAttach(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 1, "IMAGE_AVAILABLE")
+	listResp, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1})
+	require.NoError(t, err)
+	image := listResp.Data.Images[0]
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "activate", []string{image.ImageID}, "--detach")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[JOB] Running in the background as job 1")
+
+	stdout, _, err = runSubcommand(t, cmd.JobsCmd, server.URL, "list", nil)
+	require.NoError(t, err)
+	assert.Regexp(t, `1\s+activate\s+`+image.ImageName+`\s+Activating\s`, stdout)
+
+	stdout, _, err = runSubcommand(t, cmd.JobsCmd, server.URL, "attach", []string{"1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[SUCCESS] Image activated successfully! Image ID: "+image.ImageID)
+	jobs, err := cmd.LoadJobs()
+	require.NoError(t, err)
+	assert.Empty(t, jobs, "a job is removed once its operation has ended")
+
+	stdout, _, err = runSubcommand(t, cmd.JobsCmd, server.URL, "list", nil)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[EMPTY] No jobs.")
+}
+
+func TestJobsListRemovesEndedJobs(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 1, "RESOURCE_PUBLISHED")
+	listResp, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1})
+	require.NoError(t, err)
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+	_, err = cmd.AddJob(cmd.Job{Type: cmd.JobActivate, ImageID: listResp.Data.Images[0].ImageID})
+	require.NoError(t, err)
+
+	stdout, _, err := runSubcommand(t, cmd.JobsCmd, server.URL, "list", nil)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "Activated (ended)")
+	assert.Contains(t, stdout, "[NOTE] Jobs that have ended are removed from the list now")
+	jobs, err := cmd.LoadJobs()
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
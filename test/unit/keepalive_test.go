@@ -0,0 +1,151 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/auth"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// newRenewalServer answers session renewals with the given HTTP statuses in order;
+// 200 renews the session, anything else is an error. Once the statuses run out,
+// renewals are rejected.
+func newRenewalServer(t *testing.T, statuses ...int) (*httptest.Server, *int) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls > len(statuses) {
+			_, _ = w.Write([]byte(`{"success": false, "code": "UserLogin.Expired"}`))
+			return
+		}
+		if status := statuses[calls-1]; status != http.StatusOK {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"success": false, "code": "InvalidParameter"}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"success": true, "data": {"loginToken": "login-%d", "sessionId": "session-%d", "keepAliveToken": "keepalive-%d", "expiresAt": "2030-01-01T00:00:00Z"}}`, calls, calls, calls)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+// saveTestTokens stores a session in the temporary configuration
+func saveTestTokens(t *testing.T) {
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	require.NoError(t, cfg.SaveTokens("login-0", "session-0", "keepalive-0", "2030-01-01T00:00:00Z"))
+}
+
+func TestKeepAliveRenewsUntilRejected(t *testing.T) {
+	useTempConfigDir(t)
+	server, calls := newRenewalServer(t, http.StatusOK, http.StatusBadRequest, http.StatusOK)
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+	saveTestTokens(t)
+
+	var events []auth.KeepAliveEvent
+	clock := newFakeClock()
+	err := auth.KeepAlive{
+		Interval: 10 * time.Minute,
+		Clock:    clock,
+		OnRenew:  func(event auth.KeepAliveEvent) { events = append(events, event) },
+	}.Run(context.Background())
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, auth.ErrSessionRejected))
+	assert.Equal(t, 4, *calls)
+	assert.Equal(t, []time.Duration{10 * time.Minute, 10 * time.Minute, 10 * time.Minute}, clock.waits, "the first renewal is immediate")
+
+	require.Len(t, events, 3, "rejections end the loop instead of being reported")
+	assert.NoError(t, events[0].Err)
+	assert.Error(t, events[1].Err, "transient errors are retried")
+	assert.NoError(t, events[2].Err)
+	assert.Equal(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), events[2].ExpiresAt)
+
+	// The last renewed session is kept even though the final renewal was rejected
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	require.NotNil(t, cfg.Token)
+	assert.Equal(t, "keepalive-3", cfg.Token.KeepAliveToken)
+}
+
+func TestKeepAliveStopsWhenCancelled(t *testing.T) {
+	useTempConfigDir(t)
+	server, calls := newRenewalServer(t, http.StatusOK)
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+	saveTestTokens(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := auth.KeepAlive{
+		Interval: time.Minute,
+		Clock:    blockingClock{},
+		OnRenew:  func(auth.KeepAliveEvent) { cancel() },
+	}.Run(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *calls)
+}
+
+func TestKeepAliveLock(t *testing.T) {
+	dir := useTempConfigDir(t)
+
+	release, err := auth.AcquireKeepAliveLock(10 * time.Minute)
+	require.NoError(t, err)
+
+	record, err := auth.ReadKeepAliveLock()
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, os.Getpid(), record.PID)
+	assert.Equal(t, "10m0s", record.Interval)
+
+	// A second refresher is refused while the first one runs
+	_, err = auth.AcquireKeepAliveLock(time.Minute)
+	var lockedErr *auth.KeepAliveLockedError
+	require.True(t, errors.As(err, &lockedErr))
+	assert.Equal(t, os.Getpid(), lockedErr.Record.PID)
+
+	release()
+	record, err = auth.ReadKeepAliveLock()
+	require.NoError(t, err)
+	assert.Nil(t, record)
+
+	// A lock left behind by a process that no longer runs is replaced
+	stale, err := json.Marshal(auth.KeepAliveRecord{PID: 999999999, StartedAt: time.Now()})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "keepalive.lock"), stale, 0600))
+	release, err = auth.AcquireKeepAliveLock(time.Minute)
+	require.NoError(t, err)
+	release()
+}
+
+func TestAuthKeepAliveCommandValidation(t *testing.T) {
+	useTempConfigDir(t)
+	_, stderr, err := runSubcommand(t, cmd.AuthCmd, "", "keepalive", nil, "--interval", "10s")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "Invalid --interval value: 10s")
+
+	// Holding the lock makes a second keep-alive exit with a clear message
+	saveTestTokens(t)
+	release, err := auth.AcquireKeepAliveLock(time.Minute)
+	require.NoError(t, err)
+	defer release()
+
+	_, stderr, err = runSubcommand(t, cmd.AuthCmd, "", "keepalive", nil)
+	require.Error(t, err)
+	assert.Contains(t, stderr, "A session keep-alive is already running")
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/mockserver"
+)
+
+// newSeededMockServer starts the bundled mock backend with count images
+func newSeededMockServer(t *testing.T, count int, statuses ...string) (*httptest.Server, *client.APIClient) {
+	backend := mockserver.New()
+	_, err := backend.Seed(mockserver.SeedRequest{Images: count, Statuses: statuses, Seed: 1})
+	require.NoError(t, err)
+	server := httptest.NewServer(backend)
+	t.Cleanup(server.Close)
+	return server, newLogsTestClient(server.URL)
+}
+
+func TestMockServerParseStatuses(t *testing.T) {
+	statuses, err := mockserver.ParseStatuses("mixed")
+	require.NoError(t, err)
+	assert.Equal(t, mockserver.Statuses, statuses)
+
+	statuses, err = mockserver.ParseStatuses("image_available, RESOURCE_PUBLISHED")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"IMAGE_AVAILABLE", "RESOURCE_PUBLISHED"}, statuses)
+
+	_, err = mockserver.ParseStatuses("IMAGE_AVAILABLE,running")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown status "running"`)
+}
+
+func TestMockServerSeed(t *testing.T) {
+	backend := mockserver.New()
+	resp, err := backend.Seed(mockserver.SeedRequest{Images: 50, Seed: 7})
+	require.NoError(t, err)
+	assert.Equal(t, 50, resp.Images)
+	assert.Len(t, resp.ByStatus, len(mockserver.Statuses), "mixed uses every status")
+	total := 0
+	for _, count := range resp.ByStatus {
+		total += count
+	}
+	assert.Equal(t, 50, total)
+
+	_, err = backend.Seed(mockserver.SeedRequest{Images: -1})
+	assert.Error(t, err)
+}
+
+func TestMockServerListImages(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 25, "IMAGE_AVAILABLE")
+	ctx := context.Background()
+
+	resp, _, err := apiClient.ImageAPI.ListImages(ctx, "token", "session", client.ImageListOptions{ImageType: "User", Page: 3, PageSize: 10})
+	require.NoError(t, err)
+	require.True(t, resp.Success)
+	assert.Equal(t, 25, resp.Data.Total)
+	assert.Len(t, resp.Data.Images, 5, "last page")
+	assert.Equal(t, "img-mock0021", resp.Data.Images[0].ImageID)
+
+	resp, _, err = apiClient.ImageAPI.ListImages(ctx, "token", "session", client.ImageListOptions{ImageType: "System", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.Data.Images, "System images are always present")
+
+	resp, _, err = apiClient.ImageAPI.ListImages(ctx, "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10, ImageIds: []string{"img-mock0002"}})
+	require.NoError(t, err)
+	require.Len(t, resp.Data.Images, 1)
+
+	name := resp.Data.Images[0].ImageName
+	resp, _, err = apiClient.ImageAPI.ListImages(ctx, "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 50, NameContains: strings.ToUpper(name)})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Data.Images)
+	for _, image := range resp.Data.Images {
+		assert.Contains(t, image.ImageName, name, "name search is case-insensitive")
+	}
+}
+
+func TestMockServerActivationTransition(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 1, "IMAGE_AVAILABLE")
+	ctx := context.Background()
+
+	startResp, _, err := apiClient.ImageAPI.StartImage(ctx, "token", "session", "img-mock0001", 4, 8, false)
+	require.NoError(t, err)
+	require.True(t, startResp.Success)
+
+	status := func() string {
+		resp, _, err := apiClient.ImageAPI.ListImages(ctx, "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 1, ImageIds: []string{"img-mock0001"}})
+		require.NoError(t, err)
+		require.Len(t, resp.Data.Images, 1)
+		return resp.Data.Images[0].Status
+	}
+	assert.Equal(t, "RESOURCE_DEPLOYING", status())
+	assert.Equal(t, "RESOURCE_PUBLISHED", status())
+
+	stopResp, _, err := apiClient.ImageAPI.StopImage(ctx, "token", "session", "img-mock0001", client.ImageStopOptions{})
+	require.NoError(t, err)
+	require.True(t, stopResp.Success)
+	assert.Equal(t, "RESOURCE_DELETING", status())
+	assert.Equal(t, "IMAGE_AVAILABLE", status())
+
+	startResp, _, err = apiClient.ImageAPI.StartImage(ctx, "token", "session", "img-missing", 0, 0, false)
+	require.Error(t, err)
+	code, _ := client.ErrorCode(err)
+	assert.Equal(t, "ImageNotFound", code)
+	assert.False(t, startResp.Success)
+}
+
+func TestMockServerCreateImage(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 0)
+	ctx := context.Background()
+
+	credential, _, err := apiClient.ImageAPI.GetUploadCredential(ctx, "token", "session")
+	require.NoError(t, err)
+	require.True(t, credential.Success)
+	assert.Contains(t, credential.Data.OssURL, mockserver.UploadPath)
+
+	createResp, _, err := apiClient.ImageAPI.CreateImage(ctx, "token", "session", "demo", credential.Data.TaskID, "agb-code-space-1", client.ImageCreateOptions{})
+	require.NoError(t, err)
+	require.True(t, createResp.Success)
+
+	task, err := cmd.FindInProgressImageTask(ctx, apiClient, "token", "session", "demo")
+	require.NoError(t, err)
+	require.NotNil(t, task, "the new build is in progress")
+
+	taskResp, _, err := apiClient.ImageAPI.GetImageTask(ctx, "token", "session", credential.Data.TaskID)
+	require.NoError(t, err)
+	assert.Equal(t, "Preparing", taskResp.Data.Status)
+	taskResp, _, err = apiClient.ImageAPI.GetImageTask(ctx, "token", "session", credential.Data.TaskID)
+	require.NoError(t, err)
+	assert.Equal(t, "Finished", taskResp.Data.Status)
+	require.NotNil(t, taskResp.Data.ImageID)
+
+	existing, err := cmd.FindUserImageByName(ctx, apiClient, "token", "session", "demo")
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.Equal(t, "IMAGE_AVAILABLE", existing.Status)
+}
+
+func TestIsLocalEndpoint(t *testing.T) {
+	assert.True(t, cmd.IsLocalEndpoint("http://127.0.0.1:8089"))
+	assert.True(t, cmd.IsLocalEndpoint("http://localhost:8089"))
+	assert.True(t, cmd.IsLocalEndpoint("http://[::1]:8089"))
+	assert.False(t, cmd.IsLocalEndpoint("https://agb.cloud"))
+	assert.False(t, cmd.IsLocalEndpoint("http://10.0.0.5:8089"))
+}
+
+func TestDevSeedCommand(t *testing.T) {
+	server := httptest.NewServer(mockserver.New())
+	defer server.Close()
+
+	useTempConfigDir(t)
+
+	stdout, _, err := runSubcommand(t, cmd.DevCmd, server.URL, "seed", nil, "--images", "30", "--statuses", "IMAGE_AVAILABLE,RESOURCE_PUBLISHED")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Seeded 30 user images")
+	assert.Contains(t, stdout, "Saved demo credentials")
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.True(t, cfg.IsAuthenticated())
+
+	// The seeded images are visible to the image commands
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--all", "-q")
+	require.NoError(t, err)
+	assert.Len(t, strings.Fields(stdout), 30)
+}
+
+func TestDevSeedRefusesRemoteEndpoint(t *testing.T) {
+	useTempConfigDir(t)
+
+	_, stderr, err := runSubcommand(t, cmd.DevCmd, "https://agb.cloud", "seed", nil)
+	require.Error(t, err)
+	assert.Contains(t, stderr, "only a local mock backend can be seeded")
+}
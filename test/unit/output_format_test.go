@@ -0,0 +1,184 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+)
+
+func sampleListItems() []cmd.ImageListItem {
+	cpu, memory := 2, 4
+	return cmd.NewImageListItems([]client.ImageInfo{
+		{
+			ImageID:    "img-1234567890abcdef",
+			ImageName:  "O'Brien, image",
+			Status:     "RESOURCE_PUBLISHED",
+			Type:       "User",
+			CPU:        &cpu,
+			Memory:     &memory,
+			UpdateTime: "2025-09-11T05:48:08Z",
+		},
+		{
+			ImageID:   "img-2",
+			ImageName: "镜像-测试",
+			Status:    "IMAGE_AVAILABLE",
+			Type:      "User",
+		},
+	})
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  output.Format
+		expectErr bool
+	}{
+		{"", output.FormatTable, false},
+		{"table", output.FormatTable, false},
+		{"Wide", output.FormatWide, false},
+		{"JSON", output.FormatJSON, false},
+		{" csv ", output.FormatCSV, false},
+		{"pson", output.FormatPSON, false},
+		{"yaml", "", true},
+	}
+
+	for _, tt := range tests {
+		format, err := output.ParseFormat(tt.input)
+		if tt.expectErr {
+			assert.Error(t, err, "input %q", tt.input)
+			continue
+		}
+		assert.NoError(t, err, "input %q", tt.input)
+		assert.Equal(t, tt.expected, format, "input %q", tt.input)
+	}
+
+	assert.False(t, output.FormatTable.IsStructured())
+	assert.False(t, output.FormatWide.IsStructured())
+	assert.True(t, output.FormatJSON.IsStructured())
+	assert.True(t, output.FormatCSV.IsStructured())
+	assert.True(t, output.FormatPSON.IsStructured())
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, output.Write(&buf, output.FormatJSON, sampleListItems()))
+
+	var decoded []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded, 2)
+	assert.Equal(t, "img-1234567890abcdef", decoded[0]["imageId"])
+	assert.Equal(t, float64(2), decoded[0]["cpu"])
+	assert.Nil(t, decoded[1]["cpu"])
+	assert.True(t, strings.HasSuffix(buf.String(), output.Newline()))
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, output.Write(&buf, output.FormatCSV, sampleListItems()))
+
+	// Output must be BOM-free UTF-8
+	assert.False(t, bytes.HasPrefix(buf.Bytes(), []byte{0xEF, 0xBB, 0xBF}), "CSV output must not start with a BOM")
+
+	if output.Newline() == "\r\n" {
+		assert.Contains(t, buf.String(), "\r\n")
+	} else {
+		assert.NotContains(t, buf.String(), "\r\n")
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"imageId", "imageName", "status", "type", "cpu", "memory", "updateTime", "sourceImageId", "digest", "warmInstances", "pinned"}, records[0])
+	assert.Equal(t, "O'Brien, image", records[1][1], "commas must be quoted, not split")
+	assert.Equal(t, "2", records[1][4])
+	assert.Equal(t, "", records[2][4], "null values render as empty cells")
+	assert.Equal(t, "镜像-测试", records[2][1])
+}
+
+func TestWriteCSVOptions(t *testing.T) {
+	output.SetCSVOptions(output.CSVOptions{Delimiter: ';', NoHeader: true})
+	defer output.SetCSVOptions(output.CSVOptions{})
+
+	var buf bytes.Buffer
+	require.NoError(t, output.Write(&buf, output.FormatCSV, sampleListItems()))
+
+	reader := csv.NewReader(&buf)
+	reader.Comma = ';'
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2, "no header row")
+	assert.Equal(t, "img-1234567890abcdef", records[0][0])
+	assert.Equal(t, "O'Brien, image", records[0][1], "commas need no quoting with another delimiter")
+}
+
+func TestParseCSVDelimiter(t *testing.T) {
+	for value, expected := range map[string]rune{";": ';', "|": '|', "Tab": '\t', `\t`: '\t', "semicolon": ';', "comma": ','} {
+		delimiter, err := output.ParseCSVDelimiter(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, delimiter, value)
+	}
+	for _, value := range []string{"", ";;", `"`, "\n"} {
+		_, err := output.ParseCSVDelimiter(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestApplyCSVFlags(t *testing.T) {
+	defer output.SetCSVOptions(output.CSVOptions{})
+	var queries []url.Values
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server := newListQueryServer(t, 2, &queries)
+	defer server.Close()
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "-o", "csv", "--csv-delimiter", "tab", "--no-header")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(stdout, "img-1\t"), stdout)
+
+	_, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "-o", "csv", "--csv-delimiter", "::")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] invalid CSV delimiter '::'")
+}
+
+func TestWritePSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, output.Write(&buf, output.FormatPSON, sampleListItems()))
+	text := buf.String()
+
+	assert.True(t, strings.HasPrefix(text, "@("))
+	assert.Equal(t, 2, strings.Count(text, "[pscustomobject]@{"))
+	assert.Contains(t, text, "imageName = 'O''Brien, image'", "single quotes must be doubled")
+	assert.Contains(t, text, "cpu = 2")
+	assert.Contains(t, text, "cpu = $null")
+	assert.Contains(t, text, "status = 'IMAGE_AVAILABLE'")
+}
+
+func TestWriteSingleStruct(t *testing.T) {
+	var buf bytes.Buffer
+	item := sampleListItems()[1]
+	require.NoError(t, output.Write(&buf, output.FormatCSV, &item))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "img-2", records[1][0])
+}
+
+func TestWriteRejectsUnsupportedValues(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, output.Write(&buf, output.FormatCSV, []string{"a", "b"}))
+	assert.Error(t, output.Write(&buf, output.FormatTable, sampleListItems()))
+}
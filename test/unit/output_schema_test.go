@@ -0,0 +1,121 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/output"
+)
+
+func TestSchemaFor(t *testing.T) {
+	type nested struct {
+		Name string `json:"name"`
+	}
+	type sample struct {
+		ID       string            `json:"id"`
+		Count    *int              `json:"count"`
+		Tags     []string          `json:"tags,omitempty"`
+		Labels   map[string]string `json:"labels,omitempty"`
+		Created  time.Time         `json:"created"`
+		Ratio    float64           `json:"ratio"`
+		Enabled  bool              `json:"enabled"`
+		Child    *nested           `json:"child,omitempty"`
+		Internal string            `json:"-"`
+		hidden   string
+	}
+
+	schema := output.SchemaFor(sample{hidden: "unused"})
+	assert.Equal(t, output.SchemaDialect, schema.Schema)
+	assert.Equal(t, "object", schema.Type)
+	assert.Nil(t, schema.AdditionalProperties, "new fields can be added without a new version")
+	assert.Equal(t, []string{"id", "count", "created", "ratio", "enabled"}, schema.Required)
+
+	assert.Equal(t, []string{"integer", "null"}, schema.Properties["count"].Type)
+	assert.Equal(t, []string{"array", "null"}, schema.Properties["tags"].Type)
+	assert.Equal(t, "string", schema.Properties["tags"].Items.Type)
+	assert.Equal(t, "object", schema.Properties["labels"].Type)
+	assert.Equal(t, "string", schema.Properties["labels"].AdditionalProperties.Type)
+	assert.Equal(t, "date-time", schema.Properties["created"].Format)
+	assert.Equal(t, "number", schema.Properties["ratio"].Type)
+	assert.Equal(t, "boolean", schema.Properties["enabled"].Type)
+	assert.Equal(t, []string{"object", "null"}, schema.Properties["child"].Type)
+	assert.NotContains(t, schema.Properties, "Internal")
+	assert.NotContains(t, schema.Properties, "hidden")
+
+	list := output.SchemaFor([]sample{})
+	assert.Equal(t, "array", list.Type, "top-level results are never null")
+}
+
+// jsonKeys returns the sorted keys of a JSON object
+func jsonKeys(t *testing.T, v interface{}) []string {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	var object map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &object))
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// schemaKeys returns the sorted property names of an object schema
+func schemaKeys(schema *output.JSONSchema) []string {
+	keys := make([]string, 0, len(schema.Properties))
+	for key := range schema.Properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestCommandOutputSchemasMatchOutput(t *testing.T) {
+	cpu := 2
+	samples := map[string]interface{}{
+		"image list":           cmd.NewImageListItems([]client.ImageInfo{{ImageID: "img-1", ImageName: "demo", CPU: &cpu}})[0],
+		"image gc":             cmd.ImageGCDecision{ImageID: "img-1", Action: "delete", Result: "deleted"},
+		"image logs":           client.InstanceLogLine{Timestamp: "2025-09-30T10:00:00Z", Stream: "stdout", Message: "ok"},
+		"ssh-key list":         cmd.NewSSHKeyListItems([]client.SSHKeyInfo{{KeyID: "key-1", Name: "laptop", PublicKey: "ssh-ed25519 AAAA"}})[0],
+		"service-account list": cmd.NewServiceAccountListItems([]client.ServiceAccountInfo{{AccountID: "sa-1", Name: "ci", Scopes: []string{"image:read"}}})[0],
+		"auth sessions list":   cmd.NewSessionListItems([]client.SessionInfo{{SessionID: "session-1", Device: "laptop", Current: true}})[0],
+		"image deactivate":     cmd.ImageOperationResult{Image: "demo", ImageID: "img-1", Result: "failed", Status: "IMAGE_AVAILABLE", Error: "failed", Code: "Timeout", RequestID: "req-1"},
+	}
+
+	for command, sample := range samples {
+		schema, ok := cmd.LookupOutputSchema(command)
+		require.True(t, ok, command)
+		assert.Equal(t, "array", schema.Type, command)
+		assert.Contains(t, schema.ID, "/v1", command)
+		assert.Equal(t, schemaKeys(schema.Items), jsonKeys(t, sample), "%s: schema properties match the JSON output", command)
+	}
+
+	schema, ok := cmd.LookupOutputSchema("  config   export ")
+	require.True(t, ok)
+	assert.Equal(t, "object", schema.Type)
+	assert.NotContains(t, schema.Properties, "token")
+
+	_, ok = cmd.LookupOutputSchema("auth refresh")
+	assert.False(t, ok)
+}
+
+func TestSchemaFlagSkipsArgumentValidation(t *testing.T) {
+	out, _, err := runSubcommand(t, cmd.ImageCmd, "", "logs", nil, "--schema")
+	require.NoError(t, err, "no image ID is needed to print the schema")
+
+	var schema output.JSONSchema
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimSpace(out)), &schema))
+	assert.Equal(t, "agbcloud-cli/image-logs/v1", schema.ID)
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/pager"
+)
+
+func TestPagerDisabled(t *testing.T) {
+	for _, command := range []string{"", " ", "cat", "off", "OFF", "never"} {
+		assert.True(t, pager.Disabled(command), command)
+	}
+	assert.False(t, pager.Disabled("less -R"))
+}
+
+func TestPagerShortOutputIsNotPaged(t *testing.T) {
+	var out bytes.Buffer
+	p := pager.New(&out, "exit 1", 10)
+	fmt.Fprint(p, "line 1\nline 2\n")
+	assert.Empty(t, out.String(), "output is held back until it is known to fit")
+
+	require.NoError(t, p.Close())
+	assert.Equal(t, "line 1\nline 2\n", out.String())
+	assert.False(t, p.Paging())
+}
+
+func TestPagerLongOutputIsPaged(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pager commands run through sh")
+	}
+	paged := filepath.Join(t.TempDir(), "paged.txt")
+	var out bytes.Buffer
+	p := pager.New(&out, "cat > "+paged, 10)
+
+	var expected strings.Builder
+	for i := 1; i <= 25; i++ {
+		line := fmt.Sprintf("line %d\n", i)
+		expected.WriteString(line)
+		fmt.Fprint(p, line)
+	}
+	require.NoError(t, p.Close())
+
+	assert.True(t, p.Paging())
+	assert.Empty(t, out.String(), "paged output does not go to the terminal directly")
+	content, err := os.ReadFile(paged)
+	require.NoError(t, err)
+	assert.Equal(t, expected.String(), string(content))
+}
+
+func TestPagerQuitEarly(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("pager commands run through sh")
+	}
+	var out bytes.Buffer
+	p := pager.New(&out, "head -n 1 >/dev/null", 2)
+	for i := 0; i < 10000; i++ {
+		_, err := fmt.Fprintf(p, "line %d\n", i)
+		require.NoError(t, err, "output the pager no longer reads is discarded")
+	}
+	require.NoError(t, p.Close())
+}
+
+func TestPagerCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh as the pager program")
+	}
+	useTempConfigDir(t)
+	t.Setenv("AGB_PAGER", "")
+	os.Unsetenv("AGB_PAGER") // Restored by t.Setenv
+	t.Setenv("PAGER", "sh -c 'cat'")
+	assert.Equal(t, "sh -c 'cat'", cmd.PagerCommand(), "PAGER is used when nothing else is configured")
+
+	require.NoError(t, (&config.Config{Pager: "off"}).Save())
+	assert.Empty(t, cmd.PagerCommand(), "the configuration turns paging off")
+
+	t.Setenv("AGB_PAGER", "sh")
+	assert.Equal(t, "sh", cmd.PagerCommand(), "AGB_PAGER takes precedence")
+
+	t.Setenv("AGB_PAGER", "no-such-pager-program")
+	assert.Empty(t, cmd.PagerCommand(), "missing programs are not used")
+}
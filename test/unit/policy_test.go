@@ -0,0 +1,154 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/policy"
+)
+
+const testPolicy = `contact: "#platform-team"
+create:
+  requiredLabels: [team, cost-center]
+  allowedSourceImages: ["agb-code-space-*"]
+  imageNamePattern: "^team-"
+activate:
+  forbiddenSpecs: [8c16g]
+  maxMemory: 8
+`
+
+// writePolicy writes a policy file and returns its path
+func writePolicy(t *testing.T, content string) string {
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+	return file
+}
+
+func TestLoadPolicy(t *testing.T) {
+	p, err := policy.Load(writePolicy(t, testPolicy))
+	require.NoError(t, err)
+	assert.Equal(t, "#platform-team", p.Contact)
+	assert.Equal(t, []string{"team", "cost-center"}, p.Create.RequiredLabels)
+	assert.Equal(t, []string{"8c16g"}, p.Activate.ForbiddenSpecs)
+
+	// JSON is accepted as well
+	p, err = policy.Load(writePolicy(t, `{"activate": {"maxCpu": 4}}`))
+	require.NoError(t, err)
+	assert.Equal(t, 4, p.Activate.MaxCPU)
+
+	for content, problem := range map[string]string{
+		"activate:\n  forbidenSpecs: [8c16g]\n": "forbidenSpecs",
+		"activate:\n  forbiddenSpecs: [huge]\n": "invalid spec 'huge'",
+		"create:\n  imageNamePattern: '('\n":    "create.imageNamePattern",
+	} {
+		_, err := policy.Load(writePolicy(t, content))
+		require.Error(t, err, content)
+		assert.Contains(t, err.Error(), problem)
+	}
+}
+
+func TestPolicyChecks(t *testing.T) {
+	p, err := policy.Load(writePolicy(t, testPolicy))
+	require.NoError(t, err)
+
+	assert.Empty(t, p.CheckCreate(policy.CreateRequest{ImageName: "team-web", SourceImageID: "agb-code-space-1", Labels: map[string]string{"team": "web", "cost-center": "42"}}))
+	violations := p.CheckCreate(policy.CreateRequest{ImageName: "web", SourceImageID: "agb-browser-1", Labels: map[string]string{"team": "web"}})
+	rules := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		rules = append(rules, violation.Rule)
+	}
+	assert.Equal(t, []string{"create.requiredLabels", "create.allowedSourceImages", "create.imageNamePattern"}, rules)
+	assert.Equal(t, "create.requiredLabels: the Dockerfile has no LABEL cost-center", violations[0].String())
+
+	assert.Empty(t, p.CheckActivate(policy.ActivateRequest{CPU: 4, Memory: 8}))
+	assert.Empty(t, p.CheckActivate(policy.ActivateRequest{}), "the server's default spec is not known")
+	violations = p.CheckActivate(policy.ActivateRequest{CPU: 8, Memory: 16})
+	require.Len(t, violations, 2)
+	assert.Equal(t, "activate.forbiddenSpecs", violations[0].Rule)
+	assert.Equal(t, "activate.maxMemory", violations[1].Rule)
+
+	var none *policy.Policy
+	assert.Empty(t, none.CheckActivate(policy.ActivateRequest{CPU: 8, Memory: 16}))
+}
+
+func TestDockerfileLabels(t *testing.T) {
+	labels := policy.DockerfileLabels(`FROM agb-code-space-1
+# LABEL commented=out
+LABEL team=web "cost-center"="4 2"
+LABEL maintainer ops team
+LABEL version=1 \
+      stage='beta'
+RUN echo LABEL=no
+`)
+	assert.Equal(t, map[string]string{
+		"team":        "web",
+		"cost-center": "4 2",
+		"maintainer":  "ops team",
+		"version":     "1",
+		"stage":       "beta",
+	}, labels)
+}
+
+func TestImageCreateEnforcesPolicy(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 0)
+	dockerfile := filepath.Join(t.TempDir(), "Dockerfile")
+	require.NoError(t, os.WriteFile(dockerfile, []byte("FROM agb-code-space-1\nLABEL team=web\n"), 0644))
+
+	// The policy referenced in the configuration applies
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cfg.PolicyFile = writePolicy(t, testPolicy)
+	require.NoError(t, cfg.Save())
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "create", []string{"team-web"}, "-f", dockerfile, "-i", "agb-code-space-1", "--force-new", "--detach")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "[ERROR] Creating image 'team-web' is not allowed by the organization policy:")
+	assert.Contains(t, err.Error(), "• create.requiredLabels: the Dockerfile has no LABEL cost-center")
+	assert.Contains(t, err.Error(), "[TIP] Ask #platform-team for an exception")
+
+	// --policy-file takes its place, e.g. to try a relaxed policy
+	relaxed := writePolicy(t, "create:\n  requiredLabels: [team]\n")
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "create", []string{"team-web"}, "-f", dockerfile, "-i", "agb-code-space-1", "--force-new", "--detach", "--policy-file", relaxed)
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK]")
+
+	// A policy that cannot be read refuses the action
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "create", []string{"team-web"}, "-f", dockerfile, "-i", "agb-code-space-1", "--force-new", "--detach", "--policy-file", filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to load the organization policy")
+}
+
+func TestImageActivateEnforcesPolicy(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 1, "IMAGE_AVAILABLE")
+	listResp, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	imageId := listResp.Data.Images[0].ImageID
+	file := writePolicy(t, testPolicy)
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "activate", []string{imageId}, "--cpu", "8", "--memory", "16", "--detach", "--policy-file", file)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "• activate.forbiddenSpecs: the 8c16g spec (8 cores, 16 GB) is forbidden")
+	assert.Contains(t, err.Error(), "[NOTE] Policy file: "+file)
+
+	listResp, _, err = apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, "IMAGE_AVAILABLE", listResp.Data.Images[0].Status, "nothing was requested")
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "activate", []string{imageId}, "--cpu", "4", "--memory", "8", "--detach", "--policy-file", file)
+	require.NoError(t, err)
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/internal/poll"
+)
+
+// fakeClock advances instantly whenever the poller waits, recording each wait
+type fakeClock struct {
+	now   time.Time
+	waits []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+// blockingClock never fires, so only the context can end a wait
+type blockingClock struct{}
+
+func (blockingClock) Now() time.Time                       { return time.Now() }
+func (blockingClock) After(time.Duration) <-chan time.Time { return nil }
+
+// sequence returns a condition that yields the given results in order
+func sequence(results ...error) (poll.Condition, *int) {
+	calls := 0
+	return func(context.Context) (bool, error) {
+		i := calls
+		calls++
+		if i >= len(results) {
+			return true, nil
+		}
+		return false, results[i]
+	}, &calls
+}
+
+func TestPollerDone(t *testing.T) {
+	clock := newFakeClock()
+	condition, calls := sequence(nil, nil)
+	p := poll.Poller{Interval: poll.Constant(5 * time.Second), Timeout: time.Minute, Clock: clock}
+
+	require.NoError(t, p.Until(context.Background(), condition))
+	assert.Equal(t, 3, *calls)
+	assert.Equal(t, []time.Duration{5 * time.Second, 5 * time.Second, 5 * time.Second}, clock.waits, "waits before every check")
+}
+
+func TestPollerStopEndsImmediately(t *testing.T) {
+	failed := errors.New("build failed")
+	condition, calls := sequence(nil, poll.Stop(failed), nil)
+	p := poll.Poller{Interval: poll.Constant(time.Second), Clock: newFakeClock()}
+
+	err := p.Until(context.Background(), condition)
+	assert.Same(t, failed, err, "the wrapped error is returned unchanged")
+	assert.Equal(t, 2, *calls)
+	assert.Nil(t, poll.Stop(nil))
+}
+
+func TestPollerTransientErrors(t *testing.T) {
+	flaky := errors.New("503")
+
+	t.Run("ToleratedUpToMaxErrors", func(t *testing.T) {
+		condition, calls := sequence(flaky, flaky, nil, flaky, flaky)
+		p := poll.Poller{Interval: poll.Constant(time.Second), MaxErrors: 2, Clock: newFakeClock()}
+		require.NoError(t, p.Until(context.Background(), condition), "a successful check resets the error count")
+		assert.Equal(t, 6, *calls)
+	})
+
+	t.Run("GivesUpAfterMaxErrors", func(t *testing.T) {
+		condition, calls := sequence(flaky, flaky, flaky, flaky)
+		p := poll.Poller{Interval: poll.Constant(time.Second), MaxErrors: 2, Clock: newFakeClock()}
+		err := p.Until(context.Background(), condition)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, flaky)
+		assert.Contains(t, err.Error(), "giving up after 3 consecutive errors")
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("UnlimitedByDefault", func(t *testing.T) {
+		results := make([]error, 50)
+		for i := range results {
+			results[i] = flaky
+		}
+		condition, calls := sequence(results...)
+		p := poll.Poller{Clock: newFakeClock()}
+		require.NoError(t, p.Until(context.Background(), condition))
+		assert.Equal(t, 51, *calls)
+	})
+}
+
+func TestPollerTimeout(t *testing.T) {
+	clock := newFakeClock()
+	calls := 0
+	p := poll.Poller{Interval: poll.Constant(20 * time.Second), Timeout: time.Minute, Clock: clock}
+
+	err := p.Until(context.Background(), func(context.Context) (bool, error) {
+		calls++
+		return false, nil
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, poll.ErrTimeout)
+	assert.Equal(t, "timed out after 1m0s", err.Error())
+	assert.Equal(t, 3, calls)
+
+	t.Run("LastWaitIsShortened", func(t *testing.T) {
+		clock := newFakeClock()
+		p := poll.Poller{Interval: poll.Constant(40 * time.Second), Timeout: time.Minute, Clock: clock}
+		err := p.Until(context.Background(), func(context.Context) (bool, error) { return false, nil })
+		assert.ErrorIs(t, err, poll.ErrTimeout)
+		assert.Equal(t, []time.Duration{40 * time.Second, 20 * time.Second}, clock.waits)
+	})
+
+	t.Run("IncludesLastError", func(t *testing.T) {
+		flaky := errors.New("connection reset")
+		p := poll.Poller{Interval: poll.Constant(30 * time.Second), Timeout: time.Minute, Clock: newFakeClock()}
+		err := p.Until(context.Background(), func(context.Context) (bool, error) { return false, flaky })
+		assert.ErrorIs(t, err, poll.ErrTimeout)
+		assert.ErrorIs(t, err, flaky)
+		assert.Contains(t, err.Error(), "last error: connection reset")
+	})
+}
+
+func TestPollerContext(t *testing.T) {
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		p := poll.Poller{Clock: blockingClock{}}
+		err := p.Until(ctx, func(context.Context) (bool, error) {
+			t.Fatal("condition must not run after cancellation")
+			return false, nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("DeadlineIsTimeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		p := poll.Poller{Clock: blockingClock{}}
+		err := p.Until(ctx, func(context.Context) (bool, error) { return false, nil })
+		assert.ErrorIs(t, err, poll.ErrTimeout)
+	})
+
+	t.Run("ConditionContextHasTimeout", func(t *testing.T) {
+		p := poll.Poller{Timeout: time.Hour, Clock: newFakeClock()}
+		err := p.Until(context.Background(), func(ctx context.Context) (bool, error) {
+			_, ok := ctx.Deadline()
+			assert.True(t, ok, "requests made by the condition are bounded by the timeout")
+			return true, nil
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestPollerProgress(t *testing.T) {
+	flaky := errors.New("503")
+	condition, _ := sequence(flaky, nil)
+	var reports []poll.Progress
+	p := poll.Poller{
+		Interval: poll.Constant(5 * time.Second),
+		Clock:    newFakeClock(),
+		Progress: func(p poll.Progress) { reports = append(reports, p) },
+	}
+
+	require.NoError(t, p.Until(context.Background(), condition))
+	assert.Equal(t, []poll.Progress{
+		{Attempt: 1, Elapsed: 5 * time.Second, Err: flaky},
+		{Attempt: 2, Elapsed: 10 * time.Second},
+		{Attempt: 3, Elapsed: 15 * time.Second},
+	}, reports)
+}
+
+func TestPollIntervals(t *testing.T) {
+	constant := poll.Constant(3 * time.Second)
+	assert.Equal(t, 3*time.Second, constant(1))
+	assert.Equal(t, 3*time.Second, constant(100))
+
+	backoff := poll.Backoff(time.Second, 10*time.Second, 2)
+	var got []time.Duration
+	for attempt := 1; attempt <= 6; attempt++ {
+		got = append(got, backoff(attempt))
+	}
+	assert.Equal(t, []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second,
+	}, got)
+
+	t.Run("PollerUsesBackoff", func(t *testing.T) {
+		clock := newFakeClock()
+		condition, _ := sequence(nil, nil, nil)
+		p := poll.Poller{Interval: poll.Backoff(time.Second, 3*time.Second, 2), Clock: clock}
+		require.NoError(t, p.Until(context.Background(), condition))
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}, clock.waits)
+	})
+}
@@ -5,10 +5,12 @@ package unit
 
 import (
 	"net"
+	"strconv"
 	"testing"
 
 	"github.com/agbcloud/agbcloud-cli/internal/auth"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsPortOccupied(t *testing.T) {
@@ -131,7 +133,7 @@ func TestSelectAvailablePort(t *testing.T) {
 				t.Skip("Skipping test that requires port occupation setup")
 			}
 
-			selectedPort, err := auth.SelectAvailablePort(tt.defaultPort, tt.alternativePorts)
+			selectedPort, err := auth.SelectAvailablePort(tt.defaultPort, tt.alternativePorts, auth.PortPreferences{})
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -230,3 +232,52 @@ func TestPortValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePortRanges(t *testing.T) {
+	ranges, err := auth.ParsePortRanges("40000-40002, 50000")
+	require.NoError(t, err)
+	assert.Equal(t, []auth.PortRange{{Start: 40000, End: 40002}, {Start: 50000, End: 50000}}, ranges)
+
+	ranges, err = auth.ParsePortRanges("")
+	assert.NoError(t, err)
+	assert.Empty(t, ranges)
+
+	for _, invalid := range []string{"40100-40000", "0-10", "40000-70000", "abc", "40000-"} {
+		_, err := auth.ParsePortRanges(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCandidatePorts(t *testing.T) {
+	candidates := auth.CandidatePorts("3000", "51152,3000", auth.PortPreferences{
+		Recent: []string{"40001", "bad"},
+		Ranges: []auth.PortRange{{Start: 40000, End: 40002}},
+	})
+	assert.Equal(t, []string{"40001", "3000", "51152", "40000", "40002"}, candidates,
+		"recent ports first, then default, alternatives and ranges without duplicates")
+}
+
+func TestSelectAvailablePortScansRanges(t *testing.T) {
+	// Occupy the default port and the server's alternative
+	busy := make([]string, 0, 2)
+	for i := 0; i < 2; i++ {
+		listener, err := net.Listen("tcp", ":0")
+		require.NoError(t, err)
+		defer listener.Close()
+		busy = append(busy, strconv.Itoa(listener.Addr().(*net.TCPAddr).Port))
+	}
+
+	// A free port to be found through the range
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	free := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	port, err := auth.SelectAvailablePort(busy[0], busy[1], auth.PortPreferences{Ranges: []auth.PortRange{{Start: free, End: free}}})
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(free), port)
+
+	port, err = auth.SelectAvailablePort(busy[0], busy[1], auth.PortPreferences{Recent: []string{busy[1]}})
+	assert.Error(t, err, "an occupied recent port is skipped")
+	assert.Empty(t, port)
+}
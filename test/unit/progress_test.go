@@ -0,0 +1,231 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/progress"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestProgressSequentialFallback(t *testing.T) {
+	var buf syncBuffer
+	m := progress.New(&buf, progress.Options{})
+	assert.False(t, m.Interactive(), "non-terminal writers must use sequential output")
+
+	item := m.Add("img-1")
+	item.Start("activating")
+	item.Update("activating") // duplicate updates are not repeated
+	item.Succeed("activated")
+	item.Fail(errors.New("ignored")) // finished items are never reopened
+	m.Stop()
+
+	text := buf.String()
+	assert.NotContains(t, text, "\033[", "sequential output must not contain escape sequences")
+	assert.Equal(t, []string{
+		"[RUNNING] img-1  activating",
+		"[OK]      img-1  activated",
+	}, strings.Split(strings.TrimSpace(text), "\n"))
+	assert.Equal(t, progress.StateSucceeded, item.State())
+}
+
+func TestProgressConcurrentItems(t *testing.T) {
+	var buf syncBuffer
+	m := progress.New(&buf, progress.Options{Mode: progress.ModeSequential})
+
+	const count = 20
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		item := m.Add(fmt.Sprintf("img-%02d", i))
+		wg.Add(1)
+		go func(i int, item *progress.Item) {
+			defer wg.Done()
+			item.Start("working")
+			if i%4 == 0 {
+				item.Fail(errors.New("quota exceeded"))
+				return
+			}
+			item.Succeed("done")
+		}(i, item)
+	}
+	wg.Wait()
+	m.Stop()
+
+	succeeded, failed := m.Counts()
+	assert.Equal(t, 15, succeeded)
+	assert.Equal(t, 5, failed)
+
+	// Every line must be complete; interleaved writes would split or merge lines
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, count*2)
+	for _, line := range lines {
+		assert.Regexp(t, `^\[(RUNNING|OK|ERROR)\]\s+img-\d{2}  (working|done|quota exceeded)$`, line)
+	}
+}
+
+func TestProgressInteractiveRedraw(t *testing.T) {
+	var buf syncBuffer
+	m := progress.New(&buf, progress.Options{Mode: progress.ModeInteractive, Interval: 5 * time.Millisecond})
+	assert.True(t, m.Interactive())
+
+	first := m.Add("a")
+	second := m.Add("longer-name")
+	first.Start("working")
+	time.Sleep(20 * time.Millisecond)
+	first.Succeed("done")
+	second.Fail(errors.New("boom"))
+	m.Stop()
+	m.Stop() // idempotent
+
+	text := buf.String()
+	assert.Contains(t, text, "\033[2A", "lines must be redrawn in place")
+	assert.Contains(t, text, "\033[2K")
+
+	// The final frame shows both items with aligned names
+	frames := strings.Split(text, "\033[2A")
+	final := frames[len(frames)-1]
+	assert.Contains(t, final, "[OK]      a            done")
+	assert.Contains(t, final, "[ERROR]   longer-name  boom")
+}
+
+func TestStatusLinePrintsChangesOnly(t *testing.T) {
+	var buf syncBuffer
+	s := progress.NewStatusLine(&buf, progress.StatusOptions{Mode: progress.ModeSequential})
+	s.Resume()
+
+	assert.True(t, s.Update("[DATA] Status: Preparing"))
+	assert.False(t, s.Update("[DATA] Status: Preparing"))
+	assert.True(t, s.Update("[DATA] Status: Building - step 1/3"))
+	assert.True(t, s.Update("[DATA] Status: Building - step 2/3"), "a new task message is a change")
+	s.Stop()
+
+	assert.Equal(t, "[DATA] Status: Preparing\n[DATA] Status: Building - step 1/3\n[DATA] Status: Building - step 2/3\n", buf.String(),
+		"redirected output has no spinner")
+}
+
+func TestStatusLineRepeat(t *testing.T) {
+	var buf syncBuffer
+	s := progress.NewStatusLine(&buf, progress.StatusOptions{Mode: progress.ModeInteractive, Interval: time.Millisecond, Repeat: true})
+	s.Resume()
+
+	assert.True(t, s.Update("[DATA] Status: Preparing"))
+	assert.True(t, s.Update("[DATA] Status: Preparing"))
+	time.Sleep(10 * time.Millisecond)
+	s.Stop()
+
+	assert.Equal(t, "[DATA] Status: Preparing\n[DATA] Status: Preparing\n", buf.String(), "repeated statuses have no spinner")
+}
+
+func TestStatusLineSpinner(t *testing.T) {
+	var buf syncBuffer
+	s := progress.NewStatusLine(&buf, progress.StatusOptions{Mode: progress.ModeInteractive, Interval: time.Millisecond})
+
+	s.Update("[DATA] Status: Preparing")
+	s.Resume()
+	time.Sleep(20 * time.Millisecond)
+	s.Pause()
+	paused := buf.String()
+	assert.Contains(t, paused, "since the last change")
+	assert.True(t, strings.HasSuffix(paused, "\r\033[2K"), "pausing clears the spinner")
+
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, paused, buf.String(), "nothing is drawn while paused")
+
+	s.Resume()
+	s.Update("[DATA] Status: Building")
+	s.Stop()
+	s.Stop() // idempotent
+
+	text := buf.String()
+	assert.Contains(t, text, "\r\033[2K[DATA] Status: Building\n", "the spinner is cleared before a new status")
+	assert.True(t, strings.HasSuffix(text, "\r\033[2K"), "stopping clears the spinner")
+}
+
+func TestProgressTimestamps(t *testing.T) {
+	progress.SetTimestamps(true)
+	defer progress.SetTimestamps(false)
+	stamped := regexp.MustCompile(`^\d{2}:\d{2}:\d{2} `)
+
+	var status syncBuffer
+	s := progress.NewStatusLine(&status, progress.StatusOptions{Mode: progress.ModeSequential})
+	s.Update("[DATA] Status: Preparing")
+	s.Update("[DATA] Status: Finished")
+	s.Stop()
+	lines := strings.Split(strings.TrimSpace(status.String()), "\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.Regexp(t, stamped, line)
+	}
+	assert.True(t, strings.HasSuffix(lines[0], " [DATA] Status: Preparing"))
+
+	var items syncBuffer
+	m := progress.New(&items, progress.Options{})
+	item := m.Add("img-1")
+	item.Start("activating")
+	item.Succeed("activated")
+	m.Stop()
+	lines = strings.Split(strings.TrimSpace(items.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Regexp(t, stamped, lines[0])
+	assert.True(t, strings.HasSuffix(lines[1], " [OK]      img-1  activated"))
+
+	progress.SetTimestamps(false)
+	var plain syncBuffer
+	s = progress.NewStatusLine(&plain, progress.StatusOptions{Mode: progress.ModeSequential})
+	s.Update("[DATA] Status: Preparing")
+	s.Stop()
+	assert.Equal(t, "[DATA] Status: Preparing\n", plain.String(), "lines have no time by default")
+}
+
+func TestApplyTimestampsFlag(t *testing.T) {
+	useTempConfigDir(t)
+	defer progress.SetTimestamps(false)
+	validate := func(flags ...string) {
+		_, _, err := runSubcommand(t, cmd.ConfigCmd, "", "validate", nil, flags...)
+		require.NoError(t, err)
+	}
+
+	validate()
+	assert.False(t, progress.Timestamps(), "off by default")
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cfg.Timestamps = true
+	require.NoError(t, cfg.Save())
+	validate()
+	assert.True(t, progress.Timestamps(), "configured default")
+
+	validate("--timestamps=false")
+	assert.False(t, progress.Timestamps(), "the flag overrides the configuration")
+}
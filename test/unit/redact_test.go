@@ -0,0 +1,233 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/verbosity"
+)
+
+const (
+	secretLoginToken     = "secret-login-token-0001"
+	secretSessionId      = "secret-session-id-0002"
+	secretKeepAliveToken = "secret-keep-alive-0003"
+	secretAuthCode       = "secret-auth-code-0004"
+	secretSignature      = "secret-signature-0005"
+)
+
+var allSecrets = []string{secretLoginToken, secretSessionId, secretKeepAliveToken, secretAuthCode, secretSignature}
+
+// captureDebugLog routes logrus debug output into a buffer for the duration of the
+// test, at the most verbose tier (-vvv)
+func captureDebugLog(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	previousOut, previousLevel, previousVerbosity := log.StandardLogger().Out, log.GetLevel(), verbosity.Get()
+	log.SetOutput(&buf)
+	log.SetLevel(log.DebugLevel)
+	verbosity.Set(verbosity.Bodies)
+	t.Cleanup(func() {
+		log.SetOutput(previousOut)
+		log.SetLevel(previousLevel)
+		verbosity.Set(previousVerbosity)
+	})
+	return &buf
+}
+
+func TestRedactText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "query parameters",
+			input:    "https://agb.cloud/api/image/list?imageType=User&loginToken=abc123&sessionId=def456",
+			expected: "https://agb.cloud/api/image/list?imageType=User&loginToken=[REDACTED]&sessionId=[REDACTED]",
+		},
+		{
+			name:     "JSON fields",
+			input:    `{"loginToken": "abc", "keepAliveToken":"d\"ef", "code": "SUCCESS"}`,
+			expected: `{"loginToken": "[REDACTED]", "keepAliveToken":"[REDACTED]", "code": "SUCCESS"}`,
+		},
+		{
+			name:     "signed upload URL inside JSON",
+			input:    `{"ossUrl":"https://bucket.oss.aliyuncs.com/f?OSSAccessKeyId=AK&Expires=1700000000&Signature=xyz%3D"}`,
+			expected: `{"ossUrl":"https://bucket.oss.aliyuncs.com/f?OSSAccessKeyId=[REDACTED]&Expires=1700000000&Signature=[REDACTED]"}`,
+		},
+		{
+			name:     "raw HTTP dump headers",
+			input:    "GET / HTTP/1.1\r\nAuthorization: Bearer abc.def\r\nAccept: application/json\r\n",
+			expected: "GET / HTTP/1.1\r\nAuthorization: [REDACTED]\r\nAccept: application/json\r\n",
+		},
+		{
+			name:     "network error message",
+			input:    `Get "https://agb.cloud/api/biz_login/refresh?keepAliveToken=k&sessionId=s": dial tcp: connection refused`,
+			expected: `Get "https://agb.cloud/api/biz_login/refresh?keepAliveToken=[REDACTED]&sessionId=[REDACTED]": dial tcp: connection refused`,
+		},
+		{
+			name:     "no secrets",
+			input:    "imageId=img-123&page=1",
+			expected: "imageId=img-123&page=1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, client.RedactText(tt.input))
+		})
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Authorization", "Bearer "+secretLoginToken)
+	headers.Set("Accept", "application/json")
+
+	redacted := client.RedactHeaders(headers)
+	assert.Equal(t, client.RedactedValue, redacted.Get("Authorization"))
+	assert.Equal(t, "application/json", redacted.Get("Accept"))
+	assert.Equal(t, "Bearer "+secretLoginToken, headers.Get("Authorization"), "original headers must not be modified")
+}
+
+// TestDebugLoggingRedactsSecrets calls every API method with verbose logging and
+// raw dumps enabled and verifies that no token, session or signature reaches the log
+func TestDebugLoggingRedactsSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session="+secretSessionId)
+		_, _ = w.Write([]byte(`{
+			"success": true,
+			"code": "SUCCESS",
+			"data": {
+				"loginToken": "` + secretLoginToken + `",
+				"sessionId": "` + secretSessionId + `",
+				"keepAliveToken": "` + secretKeepAliveToken + `",
+				"ossUrl": "https://bucket.oss.aliyuncs.com/f?Expires=1700000000&Signature=` + secretSignature + `"
+			}
+		}`)) // Ignore errors in test mock server
+	}))
+	defer server.Close()
+
+	cfg := &client.Configuration{
+		Servers:       client.ServerConfigurations{{URL: server.URL}},
+		Debug:         true,
+		TokenExchange: client.TokenExchangeAuto,
+	}
+	apiClient := client.NewAPIClient(cfg)
+	ctx := context.WithValue(context.Background(), client.ContextLoginToken, secretLoginToken)
+
+	calls := map[string]func() error{
+		"GetLoginProviderURL": func() error {
+			_, _, err := apiClient.OAuthAPI.GetLoginProviderURL(ctx, "", "CLI", "")
+			return err
+		},
+		"GetLoginProviderURLWithPort": func() error {
+			_, _, err := apiClient.OAuthAPI.GetLoginProviderURLWithPort(ctx, "", "CLI", "", "3000")
+			return err
+		},
+		"LoginTranslate": func() error {
+			_, _, err := apiClient.OAuthAPI.LoginTranslate(ctx, "CLI", "GOOGLE_LOCALHOST", secretAuthCode)
+			return err
+		},
+		"LoginTranslateWithPort": func() error {
+			_, _, err := apiClient.OAuthAPI.LoginTranslateWithPort(ctx, "CLI", "GOOGLE_LOCALHOST", secretAuthCode, "3000")
+			return err
+		},
+		"LoginTranslateWithBody": func() error {
+			_, _, err := apiClient.OAuthAPI.LoginTranslateWithBody(ctx, "CLI", "GOOGLE_LOCALHOST", secretAuthCode, "3000")
+			return err
+		},
+		"RefreshToken": func() error {
+			_, _, err := apiClient.OAuthAPI.RefreshToken(ctx, secretKeepAliveToken, secretSessionId)
+			return err
+		},
+		"RefreshTokenWithBody": func() error {
+			_, _, err := apiClient.OAuthAPI.RefreshTokenWithBody(ctx, secretKeepAliveToken, secretSessionId)
+			return err
+		},
+		"Logout": func() error {
+			_, _, err := apiClient.OAuthAPI.Logout(ctx, secretLoginToken, secretSessionId)
+			return err
+		},
+		"GetUploadCredential": func() error {
+			_, _, err := apiClient.ImageAPI.GetUploadCredential(ctx, secretLoginToken, secretSessionId)
+			return err
+		},
+		"CreateImage": func() error {
+			_, _, err := apiClient.ImageAPI.CreateImage(ctx, secretLoginToken, secretSessionId, "my-image", "task-1", "base-1", client.ImageCreateOptions{})
+			return err
+		},
+		"GetImageTask": func() error {
+			_, _, err := apiClient.ImageAPI.GetImageTask(ctx, secretLoginToken, secretSessionId, "task-1")
+			return err
+		},
+		"ListImages": func() error {
+			_, _, err := apiClient.ImageAPI.ListImages(ctx, secretLoginToken, secretSessionId, client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10, ImageIds: []string{"img-1"}})
+			return err
+		},
+		"StartImage": func() error {
+			_, _, err := apiClient.ImageAPI.StartImage(ctx, secretLoginToken, secretSessionId, "img-1", 2, 4, false)
+			return err
+		},
+		"StopImage": func() error {
+			_, _, err := apiClient.ImageAPI.StopImage(ctx, secretLoginToken, secretSessionId, "img-1", client.ImageStopOptions{})
+			return err
+		},
+		"DeleteImage": func() error {
+			_, _, err := apiClient.ImageAPI.DeleteImage(ctx, secretLoginToken, secretSessionId, "img-1")
+			return err
+		},
+		"GetInstanceLogs": func() error {
+			_, _, err := apiClient.ImageAPI.GetInstanceLogs(ctx, secretLoginToken, secretSessionId, client.InstanceLogOptions{ImageId: "img-1"})
+			return err
+		},
+		"StreamInstanceLogs": func() error {
+			return apiClient.ImageAPI.StreamInstanceLogs(ctx, secretLoginToken, secretSessionId, client.InstanceLogOptions{ImageId: "img-1"},
+				func(client.InstanceLogLine) error { return nil })
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			logs := captureDebugLog(t)
+			// Some response models do not match the shared mock body; only the logs matter here
+			_ = call()
+
+			output := logs.String()
+			assert.Contains(t, output, "HTTP Request Information", "debug logging must be active")
+			assert.Contains(t, output, client.RedactedValue)
+			for _, secret := range allSecrets {
+				assert.NotContains(t, output, secret)
+			}
+		})
+	}
+
+	t.Run("NetworkError", func(t *testing.T) {
+		closed := httptest.NewServer(http.NotFoundHandler())
+		closed.Close()
+
+		offline := client.NewAPIClient(&client.Configuration{
+			Servers: client.ServerConfigurations{{URL: closed.URL}},
+		})
+
+		logs := captureDebugLog(t)
+		_, _, err := offline.OAuthAPI.RefreshToken(context.Background(), secretKeepAliveToken, secretSessionId)
+		assert.Error(t, err)
+
+		output := logs.String()
+		assert.Contains(t, output, "HTTP Request Error")
+		for _, secret := range allSecrets {
+			assert.NotContains(t, output, secret)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/pkg/version"
+)
+
+// fakeHost describes a host through its environment and existing files
+func fakeHost(env map[string]string, files ...string) version.HostProbe {
+	return version.HostProbe{
+		Getenv: func(key string) string { return env[key] },
+		Exists: func(path string) bool {
+			for _, file := range files {
+				if file == path {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+func TestReleaseArtifactName(t *testing.T) {
+	tests := []struct {
+		target   version.Target
+		expected string
+	}{
+		{version.Target{OS: "windows", Arch: "amd64"}, "agb-1.2.0-windows-amd64.exe"},
+		{version.Target{OS: "windows", Arch: "arm64"}, "agb-1.2.0-windows-arm64.exe"},
+		{version.Target{OS: "linux", Arch: "amd64"}, "agb-1.2.0-linux-amd64"},
+		{version.Target{OS: "linux", Arch: "arm64"}, "agb-1.2.0-linux-arm64"},
+		{version.Target{OS: "darwin", Arch: "arm64"}, "agb-1.2.0-darwin-arm64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.target.ArtifactName("v1.2.0"))
+			assert.Equal(t, tt.expected, tt.target.ArtifactName("1.2.0"), "the v prefix is optional")
+			assert.Equal(t, tt.expected+".sha256", tt.target.ChecksumName("v1.2.0"))
+
+			parsedVersion, parsedTarget, err := version.ParseArtifactName(tt.expected)
+			require.NoError(t, err)
+			assert.Equal(t, "1.2.0", parsedVersion)
+			assert.Equal(t, tt.target, parsedTarget)
+		})
+	}
+
+	target := version.Target{OS: "windows", Arch: "arm64"}
+	assert.Equal(t, "https://github.com/agbcloud/agbcloud-cli/releases/download/v1.2.0/agb-1.2.0-windows-arm64.exe", target.ArtifactURL("v1.2.0"))
+}
+
+func TestParseArtifactNameRejectsInvalidNames(t *testing.T) {
+	for _, name := range []string{
+		"agb-1.2.0-windows-amd64",    // Windows artifacts end in .exe
+		"agb-1.2.0-linux-amd64.exe",  // only Windows artifacts end in .exe
+		"agb-1.2.0-linux-amd64-musl", // Linux builds are static and need no libc variant
+		"agb-1.2.0-linux-amd64-glibc",
+		"agbcloud-1.2.0-linux-amd64",
+		"agb-linux",
+	} {
+		_, _, err := version.ParseArtifactName(name)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestDetectTargetWith(t *testing.T) {
+	tests := []struct {
+		name     string
+		goos     string
+		goarch   string
+		probe    version.HostProbe
+		expected version.Target
+	}{
+		{
+			name:     "Windows x64",
+			goos:     "windows",
+			goarch:   "amd64",
+			probe:    fakeHost(map[string]string{"PROCESSOR_ARCHITECTURE": "AMD64"}),
+			expected: version.Target{OS: "windows", Arch: "amd64"},
+		},
+		{
+			name:     "x64 binary emulated on Windows on ARM",
+			goos:     "windows",
+			goarch:   "amd64",
+			probe:    fakeHost(map[string]string{"PROCESSOR_ARCHITECTURE": "AMD64", "PROCESSOR_IDENTIFIER": "ARMv8 (64-bit) Family 8"}),
+			expected: version.Target{OS: "windows", Arch: "arm64"},
+		},
+		{
+			name:     "native Windows ARM64",
+			goos:     "windows",
+			goarch:   "arm64",
+			probe:    fakeHost(map[string]string{"PROCESSOR_ARCHITECTURE": "ARM64"}),
+			expected: version.Target{OS: "windows", Arch: "arm64"},
+		},
+		{
+			name:     "glibc Linux",
+			goos:     "linux",
+			goarch:   "amd64",
+			probe:    fakeHost(nil, "/lib64/ld-linux-x86-64.so.2"),
+			expected: version.Target{OS: "linux", Arch: "amd64"},
+		},
+		{
+			name:     "Alpine gets the static Linux build",
+			goos:     "linux",
+			goarch:   "arm64",
+			probe:    fakeHost(nil, "/lib/ld-musl-aarch64.so.1", "/etc/alpine-release"),
+			expected: version.Target{OS: "linux", Arch: "arm64"},
+		},
+		{
+			name:     "macOS",
+			goos:     "darwin",
+			goarch:   "arm64",
+			probe:    fakeHost(nil, "/etc/alpine-release"),
+			expected: version.Target{OS: "darwin", Arch: "arm64"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, version.DetectTargetWith(tt.goos, tt.goarch, tt.probe))
+		})
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	data := []byte("agb binary")
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+	artifact := "agb-1.2.0-windows-arm64.exe"
+
+	assert.NoError(t, version.VerifyChecksum(data, []byte(fmt.Sprintf("%s  %s\n", digest, artifact)), artifact))
+	assert.NoError(t, version.VerifyChecksum(data, []byte(fmt.Sprintf("%s *./%s\n", digest, artifact)), artifact), "binary mode and ./ prefix")
+
+	checksums := fmt.Sprintf("%s  agb-1.2.0-linux-amd64\n%s  %s\n", "00ff", digest, artifact)
+	assert.NoError(t, version.VerifyChecksum(data, []byte(checksums), artifact), "combined checksum file")
+
+	err := version.VerifyChecksum([]byte("tampered"), []byte(checksums), artifact)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch for "+artifact)
+
+	err = version.VerifyChecksum(data, []byte(checksums), "agb-1.2.0-windows-amd64.exe")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no checksum for agb-1.2.0-windows-amd64.exe")
+}
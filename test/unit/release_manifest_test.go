@@ -0,0 +1,152 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/pkg/version"
+)
+
+// writeReleaseArtifact writes an artifact to dir with its .sha256 file and returns its checksum
+func writeReleaseArtifact(t *testing.T, dir, name string) string {
+	content := []byte("binary " + name)
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+	writeTestFile(t, dir, name, content)
+	writeTestFile(t, dir, name+".sha256", []byte(fmt.Sprintf("%s  %s\n", checksum, name)))
+	return checksum
+}
+
+func TestReadReleaseArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	darwin := writeReleaseArtifact(t, dir, "agb-1.2.0-darwin-arm64")
+	// Artifacts of other versions are ignored
+	writeReleaseArtifact(t, dir, "agb-1.1.0-darwin-arm64")
+	// Local builds are named without the version
+	linux := writeReleaseArtifact(t, dir, "agb-linux-amd64")
+	// Only the checksum of this artifact is at hand
+	writeTestFile(t, dir, "SHA256SUMS.sha256", []byte("ABCDEF  *./agb-1.2.0-windows-amd64.exe\n"))
+
+	artifacts, err := version.ReadArtifacts(dir, "v1.2.0")
+	require.NoError(t, err)
+	require.Len(t, artifacts, 3)
+	assert.Equal(t, version.Artifact{Target: version.Target{OS: "darwin", Arch: "arm64"}, Name: "agb-1.2.0-darwin-arm64", SHA256: darwin}, artifacts[0])
+	assert.Equal(t, version.Artifact{Target: version.Target{OS: "linux", Arch: "amd64"}, Name: "agb-1.2.0-linux-amd64", SHA256: linux}, artifacts[1])
+	assert.Equal(t, version.Artifact{Target: version.Target{OS: "windows", Arch: "amd64"}, Name: "agb-1.2.0-windows-amd64.exe", SHA256: "abcdef"}, artifacts[2])
+
+	other := t.TempDir()
+	writeReleaseArtifact(t, other, "agb-1.1.0-darwin-arm64")
+	_, err = version.ReadArtifacts(other, "2.0.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no release artifacts of version 2.0.0")
+
+	// A checksum that does not match its artifact is never published
+	writeTestFile(t, dir, "agb-1.2.0-darwin-arm64", []byte("tampered"))
+	_, err = version.ReadArtifacts(dir, "1.2.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch for agb-1.2.0-darwin-arm64")
+}
+
+func TestBrewFormula(t *testing.T) {
+	artifacts := []version.Artifact{
+		{Target: version.Target{OS: "darwin", Arch: "arm64"}, Name: "agb-1.2.0-darwin-arm64", SHA256: "aa"},
+		{Target: version.Target{OS: "linux", Arch: "amd64"}, Name: "agb-1.2.0-linux-amd64", SHA256: "bb"},
+		{Target: version.Target{OS: "windows", Arch: "amd64"}, Name: "agb-1.2.0-windows-amd64.exe", SHA256: "dd"},
+	}
+
+	formula, err := version.BrewFormula("v1.2.0", artifacts)
+	require.NoError(t, err)
+	assert.Contains(t, formula, `  version "1.2.0"`)
+	assert.Contains(t, formula, `  license "Apache-2.0"`)
+	assert.Contains(t, formula, `
+  on_macos do
+    on_arm do
+      url "https://github.com/agbcloud/agbcloud-cli/releases/download/v1.2.0/agb-1.2.0-darwin-arm64"
+      sha256 "aa"
+
+      def install
+        bin.install "agb-1.2.0-darwin-arm64" => "agb"
+      end
+    end
+  end
+`)
+	assert.Contains(t, formula, `sha256 "bb"`)
+	assert.NotContains(t, formula, "on_intel do\n      url \"https://github.com/agbcloud/agbcloud-cli/releases/download/v1.2.0/agb-1.2.0-darwin", "platforms without an artifact are left out")
+	assert.NotContains(t, formula, "windows")
+
+	_, err = version.BrewFormula("1.2.0", artifacts[2:])
+	require.Error(t, err)
+}
+
+func TestScoopManifest(t *testing.T) {
+	artifacts := []version.Artifact{
+		{Target: version.Target{OS: "darwin", Arch: "arm64"}, Name: "agb-1.2.0-darwin-arm64", SHA256: "aa"},
+		{Target: version.Target{OS: "windows", Arch: "amd64"}, Name: "agb-1.2.0-windows-amd64.exe", SHA256: "dd"},
+		{Target: version.Target{OS: "windows", Arch: "arm64"}, Name: "agb-1.2.0-windows-arm64.exe", SHA256: "ee"},
+	}
+
+	data, err := version.ScoopManifest("1.2.0", artifacts)
+	require.NoError(t, err)
+	var manifest struct {
+		Version      string `json:"version"`
+		License      string `json:"license"`
+		Architecture map[string]struct {
+			URL  string     `json:"url"`
+			Hash string     `json:"hash"`
+			Bin  [][]string `json:"bin"`
+		} `json:"architecture"`
+		Autoupdate struct {
+			Architecture map[string]struct {
+				URL string `json:"url"`
+			} `json:"architecture"`
+		} `json:"autoupdate"`
+	}
+	require.NoError(t, json.Unmarshal(data, &manifest))
+	assert.Equal(t, "1.2.0", manifest.Version)
+	assert.Equal(t, "Apache-2.0", manifest.License)
+	require.Len(t, manifest.Architecture, 2)
+	assert.Equal(t, "https://github.com/agbcloud/agbcloud-cli/releases/download/v1.2.0/agb-1.2.0-windows-amd64.exe", manifest.Architecture["64bit"].URL)
+	assert.Equal(t, "dd", manifest.Architecture["64bit"].Hash)
+	assert.Equal(t, [][]string{{"agb-1.2.0-windows-amd64.exe", "agb"}}, manifest.Architecture["64bit"].Bin)
+	assert.Equal(t, "ee", manifest.Architecture["arm64"].Hash)
+	assert.Equal(t, "https://github.com/agbcloud/agbcloud-cli/releases/download/v$version/agb-$version-windows-arm64.exe", manifest.Autoupdate.Architecture["arm64"].URL)
+
+	_, err = version.ScoopManifest("1.2.0", artifacts[:1])
+	require.Error(t, err)
+}
+
+func TestReleaseManifestCommand(t *testing.T) {
+	dir := t.TempDir()
+	writeReleaseArtifact(t, dir, "agb-1.2.0-windows-amd64.exe")
+
+	stdout, _, err := runSubcommand(t, cmd.ReleaseCmd, "", "manifest", nil, "--scoop", "--version", "1.2.0", "--dir", dir)
+	require.NoError(t, err)
+	assert.True(t, json.Valid([]byte(stdout)), "stdout is only the manifest")
+	assert.Contains(t, stdout, `"version": "1.2.0"`)
+
+	_, _, err = runSubcommand(t, cmd.ReleaseCmd, "", "manifest", nil, "--brew", "--version", "1.2.0", "--dir", dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no macOS or Linux artifacts")
+
+	_, _, err = runSubcommand(t, cmd.ReleaseCmd, "", "manifest", nil, "--brew", "--scoop", "--version", "1.2.0", "--dir", dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exactly one of --brew and --scoop")
+
+	_, _, err = runSubcommand(t, cmd.ReleaseCmd, "", "manifest", nil, "--scoop", "--dir", dir)
+	require.Error(t, err, "a development build has no release version")
+
+	_, _, err = runSubcommand(t, cmd.ReleaseCmd, "", "manifest", nil, "--scoop", "--version", "1.2.0", "--dir", filepath.Join(dir, "missing"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Failed to read the release artifacts")
+}
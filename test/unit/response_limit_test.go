@@ -0,0 +1,138 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  int64
+		expectErr bool
+	}{
+		{"1048576", 1 << 20, false},
+		{"512KB", 512 << 10, false},
+		{"64mb", 64 << 20, false},
+		{"1 GiB", 1 << 30, false},
+		{"1.5M", 3 << 19, false},
+		{"", 0, true},
+		{"0", 0, true},
+		{"-5MB", 0, true},
+		{"10TB", 0, true},
+		{"lots", 0, true},
+	}
+
+	for _, tt := range tests {
+		size, err := client.ParseByteSize(tt.input)
+		if tt.expectErr {
+			assert.Error(t, err, "input %q", tt.input)
+			continue
+		}
+		assert.NoError(t, err, "input %q", tt.input)
+		assert.Equal(t, tt.expected, size, "input %q", tt.input)
+	}
+}
+
+func TestFormatByteSize(t *testing.T) {
+	assert.Equal(t, "16 MB", client.FormatByteSize(client.DefaultMaxResponseBytes))
+	assert.Equal(t, "1 GB", client.FormatByteSize(1<<30))
+	assert.Equal(t, "1.5 KB", client.FormatByteSize(1536))
+	assert.Equal(t, "100 bytes", client.FormatByteSize(100))
+}
+
+// newPaddedListServer answers image list requests with a valid JSON body of roughly size bytes
+func newPaddedListServer(size int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := `{"success": true, "code": "`
+		suffix := `", "data": {"images": [], "total": 0, "page": 1, "pageSize": 10}}`
+		padding := size - len(prefix) - len(suffix)
+		if padding < 0 {
+			padding = 0
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(prefix + strings.Repeat("x", padding) + suffix)) // Ignore errors in test mock server
+	}))
+}
+
+func TestResponseSizeLimit(t *testing.T) {
+	listImages := func(serverURL string, limit int64) error {
+		cfg := client.NewConfiguration()
+		cfg.Servers[0].URL = serverURL
+		cfg.MaxResponseBytes = limit
+		_, _, err := client.NewAPIClient(cfg).ImageAPI.ListImages(context.Background(), "test-login-token", "test-session-id",
+			client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+		return err
+	}
+
+	t.Run("WithinLimit", func(t *testing.T) {
+		server := newPaddedListServer(1024)
+		defer server.Close()
+		assert.NoError(t, listImages(server.URL, 1024), "a body exactly at the limit is accepted")
+	})
+
+	t.Run("OverLimit", func(t *testing.T) {
+		server := newPaddedListServer(4096)
+		defer server.Close()
+
+		err := listImages(server.URL, 1024)
+		var tooLarge *client.ResponseTooLargeError
+		require.ErrorAs(t, err, &tooLarge)
+		assert.Equal(t, "/api/image/list", tooLarge.Path)
+		assert.Equal(t, int64(1024), tooLarge.Limit)
+		assert.Contains(t, err.Error(), "AGB_CLI_MAX_RESPONSE_SIZE")
+	})
+
+	t.Run("LargeEndpointsStreamWithHigherLimit", func(t *testing.T) {
+		line := `{"timestamp": "2025-09-30T10:00:00Z", "message": "` + strings.Repeat("x", 100) + `"},`
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			body := `{"success": true, "data": {"lines": [` + strings.Repeat(line, 50) + `{"message": "last"}]}}`
+			_, _ = w.Write([]byte(body)) // Ignore errors in test mock server
+		}))
+		defer server.Close()
+
+		cfg := client.NewConfiguration()
+		cfg.Servers[0].URL = server.URL
+		cfg.MaxResponseBytes = 1024
+		apiClient := client.NewAPIClient(cfg)
+
+		resp, _, err := apiClient.ImageAPI.GetInstanceLogs(context.Background(), "test-login-token", "test-session-id", client.InstanceLogOptions{ImageId: "img-1"})
+		require.NoError(t, err, "logs use the large response limit")
+		assert.Len(t, resp.Data.Lines, 51)
+
+		cfg.MaxLargeResponseBytes = 2048
+		_, _, err = apiClient.ImageAPI.GetInstanceLogs(context.Background(), "test-login-token", "test-session-id", client.InstanceLogOptions{ImageId: "img-1"})
+		var tooLarge *client.ResponseTooLargeError
+		assert.ErrorAs(t, err, &tooLarge)
+	})
+}
+
+func TestResponseSizeLimitFromEnvironment(t *testing.T) {
+	useTempConfigDir(t)
+	server := newPaddedListServer(4096)
+	defer server.Close()
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+
+	t.Setenv("AGB_CLI_MAX_RESPONSE_SIZE", "2KB")
+	apiClient := client.NewDefault()
+	assert.Equal(t, int64(2048), apiClient.GetConfig().MaxResponseBytes)
+	_, _, err := apiClient.ImageAPI.ListImages(context.Background(), "test-login-token", "test-session-id",
+		client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	var tooLarge *client.ResponseTooLargeError
+	assert.ErrorAs(t, err, &tooLarge)
+
+	t.Setenv("AGB_CLI_MAX_RESPONSE_SIZE", "not-a-size")
+	assert.Zero(t, client.NewDefault().GetConfig().MaxResponseBytes, "invalid values fall back to the default")
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// newTestRetryPrompt returns a retry prompt that reads the given answers
+func newTestRetryPrompt(answers string, out *bytes.Buffer) cmd.RetryPrompt {
+	return cmd.RetryPrompt{In: strings.NewReader(answers), Out: out, Attempts: 2, Delay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+}
+
+// failingRun fails transiently the first failures calls and then succeeds
+func failingRun(calls *int, failures int) func() error {
+	return func() error {
+		*calls++
+		if *calls <= failures {
+			return &cmd.TransientError{Err: errors.New("network error: connection refused")}
+		}
+		return nil
+	}
+}
+
+func TestRetryPromptRerunsAfterTransientFailure(t *testing.T) {
+	var out bytes.Buffer
+	calls := 0
+	err := newTestRetryPrompt("y\n\n", &out).Run(context.Background(), failingRun(&calls, 2))
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls, "an empty answer retries too")
+	assert.Contains(t, out.String(), "[RETRY] The failure looks transient; 2 retry attempt(s) left")
+	assert.Contains(t, out.String(), "[?] Retry now? [Y/n]")
+	assert.Contains(t, out.String(), "[WAIT] Retrying in 1ms (attempt 2 of 3)...")
+	assert.Contains(t, out.String(), "[RETRY] The failure looks transient; 1 retry attempt(s) left")
+	assert.Contains(t, out.String(), "[WAIT] Retrying in 2ms (attempt 3 of 3)...", "the wait backs off")
+}
+
+func TestRetryPromptStops(t *testing.T) {
+	var out bytes.Buffer
+	calls := 0
+	err := newTestRetryPrompt("n\n", &out).Run(context.Background(), failingRun(&calls, 5))
+	require.Error(t, err)
+	assert.Equal(t, 1, calls, "a declined retry returns the failure")
+
+	out.Reset()
+	calls = 0
+	err = newTestRetryPrompt("y\ny\ny\n", &out).Run(context.Background(), failingRun(&calls, 5))
+	require.Error(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Contains(t, out.String(), "[NOTE] Giving up after 3 attempts")
+
+	out.Reset()
+	calls = 0
+	err = newTestRetryPrompt("y\n", &out).Run(context.Background(), func() error {
+		calls++
+		return errors.New("[ERROR] Failed to get image: ImageNotFound")
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, calls, "only transient failures are retried")
+	assert.Empty(t, out.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	err = newTestRetryPrompt("y\n", &out).Run(ctx, failingRun(&calls, 5))
+	require.Error(t, err)
+	assert.Equal(t, 1, calls, "an interrupted command is not retried")
+}
+
+func TestRetryPromptOnlyForRerunnableCommands(t *testing.T) {
+	for _, command := range []*cobra.Command{
+		findSubcommand(t, cmd.ImageCmd, "list"),
+		findSubcommand(t, cmd.ImageCmd, "status"),
+		findSubcommand(t, cmd.ImageCmd, "activate"),
+		findSubcommand(t, cmd.JobsCmd, "list"),
+	} {
+		assert.True(t, cmd.IsRerunnable(command), command.CommandPath())
+	}
+	// A rerun sends new idempotency keys, so commands that create something are never run again
+	for _, command := range []*cobra.Command{
+		findSubcommand(t, cmd.ImageCmd, "create"),
+		findSubcommand(t, cmd.ImageCmd, "create-batch"),
+		findSubcommand(t, cmd.SSHKeyCmd, "add"),
+		findSubcommand(t, cmd.ServiceAccountCmd, "create"),
+	} {
+		assert.False(t, cmd.IsRerunnable(command), command.CommandPath())
+	}
+}
+
+func TestIsTransientFailure(t *testing.T) {
+	assert.True(t, client.IsTransientFailure(errors.New("dial tcp 127.0.0.1:1: connect: connection refused")))
+	assert.True(t, client.IsTransientFailure(&client.RetriesExhaustedError{Attempts: 4, Err: errors.New("HTTP 503: 503 Service Unavailable"), StatusCode: http.StatusServiceUnavailable}))
+	assert.False(t, client.IsTransientFailure(&client.RetriesExhaustedError{Attempts: 1, Err: errors.New("HTTP 501: 501 Not Implemented"), StatusCode: http.StatusNotImplemented}))
+	assert.False(t, client.IsTransientFailure(errors.New("unsupported protocol scheme")))
+
+	exhausted := &client.RetriesExhaustedError{Attempts: 4, Err: errors.New("read: connection reset by peer")}
+	assert.Equal(t, "request failed after 4 attempts, last error: read: connection reset by peer", exhausted.Error())
+	assert.True(t, client.IsTransientFailure(exhausted))
+}
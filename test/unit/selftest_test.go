@@ -0,0 +1,94 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// selftestResults returns the result of every step by name
+func selftestResults(report cmd.SelftestReport) map[string]string {
+	results := make(map[string]string, len(report.Steps))
+	for _, step := range report.Steps {
+		results[step.Name] = step.Result
+	}
+	return results
+}
+
+func TestSelftestPasses(t *testing.T) {
+	server, apiClient := newSeededMockServer(t, 3)
+	useTempConfigDir(t)
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+	saveTestTokens(t)
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+
+	report := cmd.RunSelftest(context.Background(), apiClient, cfg, cmd.SelftestOptions{})
+	assert.True(t, report.Passed, "%+v", report.Steps)
+	assert.Equal(t, server.URL, report.Endpoint)
+	assert.Equal(t, map[string]string{
+		"Health":         "pass",
+		"Authentication": "pass",
+		"List images":    "pass",
+		"Create dry run": "pass",
+	}, selftestResults(report))
+	assert.Contains(t, report.Steps[3].Detail, "base image agb-code-space-1 would be accepted")
+}
+
+func TestSelftestSkipsStepsAfterFailure(t *testing.T) {
+	server, apiClient := newSeededMockServer(t, 0)
+	useTempConfigDir(t)
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+
+	// Without a session only the health check can pass
+	report := cmd.RunSelftest(context.Background(), apiClient, &config.Config{}, cmd.SelftestOptions{})
+	assert.False(t, report.Passed)
+	assert.Equal(t, map[string]string{
+		"Health":         "pass",
+		"Authentication": "fail",
+		"List images":    "skip",
+		"Create dry run": "skip",
+	}, selftestResults(report))
+	assert.Equal(t, "Authentication did not pass", report.Steps[2].Detail)
+}
+
+func TestSelftestCreateDryRun(t *testing.T) {
+	server, apiClient := newSeededMockServer(t, 0)
+	useTempConfigDir(t)
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+	saveTestTokens(t)
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+
+	report := cmd.RunSelftest(context.Background(), apiClient, cfg, cmd.SelftestOptions{BaseImageID: "agb-missing-1"})
+	assert.False(t, report.Passed)
+	assert.Equal(t, "fail", report.Steps[3].Result)
+	assert.Contains(t, report.Steps[3].Detail, "'agb-missing-1' is not a System image")
+
+	// Lint findings are reported without failing, like in 'image create'
+	report = cmd.RunSelftest(context.Background(), apiClient, cfg, cmd.SelftestOptions{Dockerfile: "FROM agb-code-space-1\nMAINTAINER me\n"})
+	assert.True(t, report.Passed)
+	assert.Contains(t, report.Steps[3].Detail, "would be accepted with 1 warning(s): Dockerfile line 2: MAINTAINER is deprecated")
+}
+
+func TestSelftestCommandReport(t *testing.T) {
+	server, _ := newSeededMockServer(t, 0)
+	useTempConfigDir(t)
+
+	out, _, err := runSubcommand(t, testRootCmd, server.URL, "selftest", nil)
+	assert.Error(t, err, "a failed step fails the command")
+	assert.Contains(t, out, "[SEARCH] Running selftest against "+server.URL)
+	assert.Contains(t, out, "[OK] Health")
+	assert.Contains(t, out, "[ERROR] Authentication")
+	assert.Contains(t, out, "not authenticated, run 'agbcloud login' first")
+	assert.Contains(t, out, "[SKIP] Create dry run: Authentication did not pass")
+	assert.Contains(t, out, "[ERROR] 1 of 4 step(s) failed")
+}
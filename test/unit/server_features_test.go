@@ -0,0 +1,100 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// newCapabilitiesServer answers capabilities requests with status, and with data when
+// status is 200, counting the requests
+func newCapabilitiesServer(t *testing.T, status int, data client.ImageCapabilitiesData) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/image/capabilities", r.URL.Path)
+		requests.Add(1)
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.ImageCapabilitiesResponse{Success: true, Code: "success", Data: data}) // Ignore errors in test mock server
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestSupportsFeature(t *testing.T) {
+	supported, known := client.ImageCapabilitiesData{}.SupportsFeature(client.FeatureGPU)
+	assert.False(t, known, "servers that report no features leave support unknown")
+	assert.False(t, supported)
+
+	capabilities := client.ImageCapabilitiesData{Features: []string{client.FeatureLogStreaming}}
+	supported, known = capabilities.SupportsFeature(client.FeatureLogStreaming)
+	assert.True(t, known)
+	assert.True(t, supported)
+	supported, known = capabilities.SupportsFeature(client.FeatureCursorPaging)
+	assert.True(t, known)
+	assert.False(t, supported)
+}
+
+func TestCapabilitiesAreCached(t *testing.T) {
+	ctx := context.Background()
+	server, requests := newCapabilitiesServer(t, http.StatusOK, client.ImageCapabilitiesData{
+		Platforms: []string{"linux/amd64"},
+		Features:  []string{client.FeatureBuildArgs, client.FeatureGPU},
+	})
+	apiClient := newLogsTestClient(server.URL)
+
+	for i := 0; i < 2; i++ {
+		capabilities, err := apiClient.Capabilities(ctx, "token", "session")
+		require.NoError(t, err)
+		assert.Equal(t, []string{client.FeatureBuildArgs, client.FeatureGPU}, capabilities.Features)
+	}
+	assert.Equal(t, int32(1), requests.Load(), "the server is asked once per client")
+}
+
+func TestCapabilitiesOfOlderServers(t *testing.T) {
+	ctx := context.Background()
+	server, requests := newCapabilitiesServer(t, http.StatusNotFound, client.ImageCapabilitiesData{})
+	apiClient := newLogsTestClient(server.URL)
+
+	for i := 0; i < 2; i++ {
+		_, err := apiClient.Capabilities(ctx, "token", "session")
+		assert.ErrorIs(t, err, client.ErrCapabilitiesUnsupported)
+	}
+	assert.Equal(t, int32(1), requests.Load())
+
+	server, requests = newCapabilitiesServer(t, http.StatusBadRequest, client.ImageCapabilitiesData{})
+	apiClient = newLogsTestClient(server.URL)
+	for i := 0; i < 2; i++ {
+		_, err := apiClient.Capabilities(ctx, "token", "session")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, client.ErrCapabilitiesUnsupported)
+	}
+	assert.Equal(t, int32(2), requests.Load(), "failed lookups are not cached")
+}
+
+func TestImageCreatePlatformNeedsServerFeature(t *testing.T) {
+	useTempConfigDir(t)
+	server, _ := newCapabilitiesServer(t, http.StatusOK, client.ImageCapabilitiesData{
+		Platforms: []string{"linux/amd64", "linux/arm64"},
+		Features:  []string{client.FeatureLogStreaming},
+	})
+
+	_, stderr, err := runImageCreate(t, server.URL, "multi-arch", "--force", "--platform", "linux/arm64")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] Not supported by server "+server.URL+": multi-platform builds")
+	assert.Contains(t, stderr, "[TIP] Remove --platform")
+}
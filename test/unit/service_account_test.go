@@ -0,0 +1,99 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// loginWithToken logs in with a service account token and returns stdout
+func loginWithToken(t *testing.T, token string) (string, error) {
+	var err error
+	stdout := captureStdout(func() {
+		captureStderr(func() { err = cmd.LoginWithServiceAccountToken(cmd.LoginCmd, strings.NewReader(token+"\n")) })
+	})
+	return stdout, err
+}
+
+func TestParseServiceAccountScopes(t *testing.T) {
+	scopes, err := cmd.ParseServiceAccountScopes([]string{"image:read", " IMAGE:BUILD ", "image:read"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"image:read", "image:build"}, scopes)
+
+	_, err = cmd.ParseServiceAccountScopes([]string{"image:admin"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown scope "image:admin"`)
+
+	_, err = cmd.ParseServiceAccountScopes(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one scope is required")
+
+	assert.NoError(t, cmd.ValidateServiceAccountName("ci-builds"))
+	assert.Error(t, cmd.ValidateServiceAccountName("CI builds"))
+	assert.Error(t, cmd.ValidateServiceAccountName("-ci"))
+}
+
+func TestServiceAccountLifecycle(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 0)
+
+	stdout, _, err := runSubcommand(t, cmd.ServiceAccountCmd, server.URL, "create", []string{"ci-builds"}, "--scope", "image:read,image:build")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Service account 'ci-builds' created (Account ID: sa-mock0001)")
+
+	_, _, err = runSubcommand(t, cmd.ServiceAccountCmd, server.URL, "create", []string{"ci-builds"}, "--scope", "image:read")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ServiceAccountNameExists")
+
+	_, _, err = runSubcommand(t, cmd.ServiceAccountCmd, server.URL, "create", []string{"deploys"})
+	require.Error(t, err, "a scope is required")
+
+	stdout, _, err = runSubcommand(t, cmd.ServiceAccountCmd, server.URL, "list", nil)
+	require.NoError(t, err)
+	assert.Regexp(t, `sa-mock0001 +ci-builds +image:read,image:build +\S.* +never`, stdout)
+
+	_, _, err = runSubcommand(t, cmd.ServiceAccountCmd, server.URL, "token", []string{"ci-builds"}, "--ttl", "10m")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid --ttl value")
+
+	stdout, _, err = runSubcommand(t, cmd.ServiceAccountCmd, server.URL, "token", []string{"ci-builds"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[WARN]  The token is shown only once")
+	token := regexp.MustCompile(`(?m)^  (agbsa_\S+)$`).FindStringSubmatch(stdout)
+	require.Len(t, token, 2, stdout)
+
+	// The pipeline logs in with the token instead of a personal session
+	stdout, err = loginWithToken(t, token[1])
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Logged in as service account 'ci-builds' (Account ID: sa-mock0001)")
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "login-sa-mock0001", cfg.Token.LoginToken)
+	assert.Empty(t, cfg.Token.KeepAliveToken)
+
+	stdout, _, err = runSubcommand(t, cmd.ServiceAccountCmd, server.URL, "delete", []string{"sa-mock0001"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Service account 'ci-builds' deleted")
+
+	_, err = loginWithToken(t, token[1])
+	require.Error(t, err, "deleting the account revokes its tokens")
+	assert.Contains(t, err.Error(), "InvalidServiceAccountToken")
+
+	_, _, err = runSubcommand(t, cmd.ServiceAccountCmd, server.URL, "delete", []string{"ci-builds"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "No service account with ID or name 'ci-builds'")
+
+	_, err = loginWithToken(t, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "No token on stdin")
+}
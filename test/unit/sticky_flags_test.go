@@ -0,0 +1,109 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// newStickyListServer serves three images to 'image list' and records the queries
+func newStickyListServer(t *testing.T, queries *[]url.Values) *httptest.Server {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server := newListQueryServer(t, 3, queries)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// enableSticky turns sticky flags on in the temporary configuration
+func enableSticky(t *testing.T) {
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cfg.Sticky = true
+	require.NoError(t, cfg.Save())
+}
+
+func TestStickyFlagsDisabledByDefault(t *testing.T) {
+	var queries []url.Values
+	server := newStickyListServer(t, &queries)
+
+	_, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--type", "System")
+	require.NoError(t, err)
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.Nil(t, cfg.StickyFlags, "nothing is remembered unless sticky flags are enabled")
+}
+
+func TestStickyFlagsRememberAndReuse(t *testing.T) {
+	var queries []url.Values
+	server := newStickyListServer(t, &queries)
+	enableSticky(t)
+	listCmd := findSubcommand(t, cmd.ImageCmd, "list")
+
+	_, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "-t", "System", "-o", "json", "--all")
+	require.NoError(t, err)
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"type": "System", "output": "json"}, cfg.RememberedFlags("image list"),
+		"only registered flags are remembered")
+
+	// Flags that were not given are filled in from the remembered values
+	_, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--size", "20")
+	require.NoError(t, err)
+	assert.Contains(t, stderr, "[NOTE] Using remembered flags: --type System --output json")
+	assert.Equal(t, "System", queries[len(queries)-1].Get("imageType"))
+	all, _ := listCmd.Flags().GetBool("all")
+	assert.False(t, all)
+
+	cfg, err = config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"type": "System", "output": "json", "size": "20"}, cfg.RememberedFlags("image list"))
+
+	// Given flags win over remembered ones
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--type", "User")
+	require.NoError(t, err)
+	assert.Equal(t, "User", queries[len(queries)-1].Get("imageType"))
+
+	// --no-sticky neither uses nor remembers flags
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--no-sticky", "--size", "50")
+	require.NoError(t, err)
+	output, _ := listCmd.Flags().GetString("output")
+	assert.Equal(t, "table", output)
+	cfg, err = config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "20", cfg.RememberedFlags("image list")["size"])
+}
+
+func TestStickyFlagsPerProfile(t *testing.T) {
+	var queries []url.Values
+	server := newStickyListServer(t, &queries)
+	enableSticky(t)
+
+	t.Setenv("AGB_CLI_PROFILE", "staging")
+	_, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "--type", "System")
+	require.NoError(t, err)
+
+	t.Setenv("AGB_CLI_PROFILE", "")
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "User", queries[len(queries)-1].Get("imageType"), "flags remembered in another profile are not used")
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "System", cfg.StickyFlags["staging"]["image list"]["type"])
+
+	cfg.ForgetFlags("")
+	assert.NotNil(t, cfg.StickyFlags, "only the active profile is cleared")
+	t.Setenv("AGB_CLI_PROFILE", "staging")
+	cfg.ForgetFlags("image list")
+	assert.Nil(t, cfg.StickyFlags)
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+)
+
+// decodeSingleJSONDocument fails unless text is exactly one JSON document
+func decodeSingleJSONDocument(t *testing.T, text string, v interface{}) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	require.NoError(t, decoder.Decode(v), "stdout is not a JSON document:\n%s", text)
+	_, err := decoder.Token()
+	assert.Equal(t, io.EOF, err, "stdout has more than one JSON document:\n%s", text)
+}
+
+func TestStrictJSONKeepsProgressOffStdout(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 3)
+
+	stdout, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "-o", "json")
+	require.NoError(t, err)
+	var items []map[string]interface{}
+	decodeSingleJSONDocument(t, stdout, &items)
+	assert.Len(t, items, 3)
+	assert.Contains(t, stderr, "[DOC] Listing User images")
+}
+
+func TestStrictJSONQuietListKeepsIDsOnStdout(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 2)
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "-o", "json", "-q")
+	require.NoError(t, err)
+	lines := strings.Fields(stdout)
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.True(t, strings.HasPrefix(line, "img-"), "unexpected line %q", line)
+	}
+}
+
+func TestTableOutputIsNotRedirected(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, _ := newSeededMockServer(t, 1)
+
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil, "-o", "table")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Found 1 images")
+}
+
+func TestOutputFlagRedirectsCommandsWithoutJSONResult(t *testing.T) {
+	useTempConfigDir(t)
+
+	// 'config validate' has no JSON result, so its report moves to stderr
+	stdout, stderr, err := runSubcommand(t, cmd.ConfigCmd, "", "validate", nil, "-o", "JSON")
+	require.NoError(t, err)
+	assert.Empty(t, stdout)
+	assert.Contains(t, stderr, "[OK] ")
+
+	stdout, _, err = runSubcommand(t, cmd.ConfigCmd, "", "validate", nil, "-o", "csv")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] ", "only JSON output is strict")
+}
+
+func TestImageCreateNoPollPrintsTaskHandle(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 0)
+	dockerfile := filepath.Join(t.TempDir(), "Dockerfile")
+	require.NoError(t, os.WriteFile(dockerfile, []byte("FROM agb-code-space-1\n"), 0644))
+
+	stdout, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "create", []string{"web"}, "-f", dockerfile, "-i", "agb-code-space-1", "--no-poll", "-o", "json")
+	require.NoError(t, err)
+	var handle cmd.ImageCreateHandle
+	decodeSingleJSONDocument(t, stdout, &handle)
+	assert.Equal(t, "web", handle.ImageName)
+	assert.NotEmpty(t, handle.TaskID)
+	assert.GreaterOrEqual(t, handle.UploadDurationMs, int64(0))
+	assert.Contains(t, stderr, "[OK] Image creation initiated")
+
+	// The build is left running for 'image task'; no job is recorded
+	taskResp, _, err := apiClient.ImageAPI.GetImageTask(context.Background(), "token", "session", handle.TaskID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, taskResp.Data.Status)
+	jobs, err := cmd.LoadJobs()
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	_, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "create", []string{"web"}, "-f", dockerfile, "-i", "agb-code-space-1", "--no-poll", "--detach")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--no-poll cannot be combined with --detach")
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// newSystemImageServer answers System image lists with two images, counting the requests
+func newSystemImageServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "System", r.URL.Query().Get("imageType"))
+		requests.Add(1)
+		images := []client.ImageInfo{
+			{ImageID: "agb-code-space-1", ImageName: "Code Space", Type: "System"},
+			{ImageID: "agb-browser-use-1", ImageName: "Browser Use", Type: "System"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.ImageListResponse{ // Ignore errors in test mock server
+			Success: true,
+			Data:    client.ImageListData{Images: images, Page: 1, PageSize: 50, Total: len(images)},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestParseSystemImageTTL(t *testing.T) {
+	ttl, err := cmd.ParseSystemImageTTL("")
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, ttl)
+	ttl, err = cmd.ParseSystemImageTTL("15m")
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, ttl)
+	ttl, err = cmd.ParseSystemImageTTL("0")
+	require.NoError(t, err)
+	assert.Zero(t, ttl)
+
+	for _, value := range []string{"soon", "-1h", "10"} {
+		_, err := cmd.ParseSystemImageTTL(value)
+		assert.Error(t, err, value)
+	}
+	assert.Contains(t, cmd.ValidateSharedConfig(config.SharedConfig{SystemImageTTL: "soon"}).Error(), "systemImageTTL")
+}
+
+func TestSystemImageListCache(t *testing.T) {
+	useTempConfigDir(t)
+	server, requests := newSystemImageServer(t)
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+	ctx := context.Background()
+	list := cmd.SystemImageList{APIClient: client.NewFromConfig(&config.Config{}), LoginToken: "token", SessionID: "session"}
+
+	images, cached, err := list.Images(ctx)
+	require.NoError(t, err)
+	assert.False(t, cached)
+	assert.Len(t, images, 2)
+
+	// Later checks are answered from the cache
+	images, cached, err = list.Images(ctx)
+	require.NoError(t, err)
+	assert.True(t, cached)
+	assert.Len(t, images, 2)
+	assert.Equal(t, int32(1), requests.Load())
+
+	refresh := list
+	refresh.Refresh = true
+	_, cached, err = refresh.Images(ctx)
+	require.NoError(t, err)
+	assert.False(t, cached, "--refresh bypasses the cache")
+	assert.Equal(t, int32(2), requests.Load())
+
+	later := list
+	later.Now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	_, cached, err = later.Images(ctx)
+	require.NoError(t, err)
+	assert.False(t, cached, "the cache expires after the TTL")
+	assert.Equal(t, int32(3), requests.Load())
+
+	// A TTL of 0 turns the cache off
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cfg.SystemImageTTL = "0"
+	require.NoError(t, cfg.Save())
+	_, cached, err = list.Images(ctx)
+	require.NoError(t, err)
+	assert.False(t, cached)
+	assert.Equal(t, int32(4), requests.Load())
+
+	// The cached list keeps working while the server is unreachable
+	cfg.SystemImageTTL = ""
+	require.NoError(t, cfg.Save())
+	server.Close()
+	images, cached, err = list.Images(ctx)
+	require.NoError(t, err)
+	assert.True(t, cached)
+	assert.Len(t, images, 2)
+}
+
+func TestImageCreateChecksSourceImage(t *testing.T) {
+	useTempConfigDir(t)
+	server, _ := newSeededMockServer(t, 0)
+
+	stdout, stderr, err := runImageCreate(t, server.URL, "reserved", "--force", "-i", "agb-nope-1")
+	require.Error(t, err)
+	assert.Contains(t, stdout, "[SEARCH] Checking the source image...")
+	assert.Contains(t, stderr, "[ERROR] Unknown source image: agb-nope-1")
+	assert.Contains(t, stderr, "agb-code-space-1")
+	assert.NotContains(t, stdout, "[UPLOAD]", "nothing is uploaded for an unknown source")
+
+	// A System image missing from a cached list is looked up again
+	require.NoError(t, cmd.SaveCachedSystemImages([]client.ImageInfo{{ImageID: "agb-old-1", Type: "System"}}, time.Now()))
+	stdout, _, err = runImageCreate(t, server.URL, "reserved", "--force", "-i", "agb-code-space-1", "--no-poll")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK]")
+}
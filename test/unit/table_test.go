@@ -0,0 +1,59 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/pager"
+)
+
+var tableTestColumns = []cmd.TableColumn{
+	{Header: "IMAGE ID", Width: 10, Truncate: true},
+	{Header: "IMAGE NAME", Width: 10, Truncate: true},
+	{Header: "STATUS", Width: 8},
+	{Header: "UPDATED AT"},
+}
+
+var tableTestRows = [][]string{
+	{"img-0123456789abcdef", "a-rather-long-name", "Available-for-use", "2025-01-01 10:00"},
+	{"img-short", "short", "Creating", "2025-01-01 10:00"},
+}
+
+func TestFitTableWidthsWithoutLimit(t *testing.T) {
+	// Piped output and --no-trunc show every value in full
+	widths := cmd.FitTableWidths(tableTestColumns, tableTestRows, 0)
+	assert.Equal(t, []int{20, 18, 8, 0}, widths, "only truncated columns are widened")
+}
+
+func TestFitTableWidthsWidensWhileTheTableFits(t *testing.T) {
+	// The default widths take 10+10+8+0 plus 3 separators = 31 columns
+	assert.Equal(t, []int{10, 10, 8, 0}, cmd.FitTableWidths(tableTestColumns, tableTestRows, 31))
+
+	// The image ID column is widened first, so that IDs can be copied
+	assert.Equal(t, []int{20, 10, 8, 0}, cmd.FitTableWidths(tableTestColumns, tableTestRows, 45))
+
+	assert.Equal(t, []int{20, 18, 8, 0}, cmd.FitTableWidths(tableTestColumns, tableTestRows, 120))
+}
+
+func TestFitTableWidthsKeepsShortColumns(t *testing.T) {
+	rows := [][]string{{"img-1", "a", "Creating", "-"}}
+	assert.Equal(t, []int{10, 10, 8, 0}, cmd.FitTableWidths(tableTestColumns, rows, 0))
+}
+
+func TestPagerWidthFromColumns(t *testing.T) {
+	t.Setenv("COLUMNS", "132")
+	assert.Equal(t, 132, pager.Width(os.Stdout))
+
+	t.Setenv("COLUMNS", "")
+	devNull, err := os.Open(os.DevNull)
+	require.NoError(t, err)
+	defer devNull.Close()
+	assert.Equal(t, pager.DefaultWidth, pager.Width(devNull))
+}
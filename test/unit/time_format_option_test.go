@@ -0,0 +1,78 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+func TestParseTimeFormat(t *testing.T) {
+	for input, expected := range map[string]cmd.TimeFormat{
+		"":         cmd.TimeFormatLocal,
+		"local":    cmd.TimeFormatLocal,
+		"UTC":      cmd.TimeFormatUTC,
+		"relative": cmd.TimeFormatRelative,
+		" raw ":    cmd.TimeFormatRaw,
+	} {
+		format, err := cmd.ParseTimeFormat(input)
+		assert.NoError(t, err, "input %q", input)
+		assert.Equal(t, expected, format, "input %q", input)
+	}
+
+	_, err := cmd.ParseTimeFormat("iso")
+	assert.Error(t, err)
+}
+
+func TestFormatTimestampAs(t *testing.T) {
+	now := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+	const timestamp = "2025-09-30T09:30:00Z"
+
+	assert.Equal(t, "2025-09-30 09:30 UTC", cmd.FormatTimestampAs(timestamp, cmd.TimeFormatUTC, now))
+	assert.Equal(t, "2h ago", cmd.FormatTimestampAs(timestamp, cmd.TimeFormatRelative, now))
+	assert.Equal(t, timestamp, cmd.FormatTimestampAs(timestamp, cmd.TimeFormatRaw, now))
+
+	parsed, _ := time.Parse(time.RFC3339, timestamp)
+	assert.Equal(t, parsed.Local().Format("2006-01-02 15:04"), cmd.FormatTimestampAs(timestamp, cmd.TimeFormatLocal, now))
+
+	// Empty and unparseable timestamps are handled the same way in every format
+	for _, format := range []cmd.TimeFormat{cmd.TimeFormatLocal, cmd.TimeFormatUTC, cmd.TimeFormatRelative} {
+		assert.Equal(t, "-", cmd.FormatTimestampAs("", format, now))
+		assert.Equal(t, "not-a-timestamp", cmd.FormatTimestampAs("not-a-timestamp", format, now))
+	}
+	assert.Equal(t, "2025-09-30 09:30:00.123456", cmd.FormatTimestampAs("2025-09-30 09:30:00.123456", cmd.TimeFormatRaw, now))
+}
+
+func TestFormatRelativeTime(t *testing.T) {
+	now := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		offset   time.Duration
+		expected string
+	}{
+		{-30 * time.Second, "just now"},
+		{-5 * time.Minute, "5m ago"},
+		{-59 * time.Minute, "59m ago"},
+		{-3 * time.Hour, "3h ago"},
+		{-50 * time.Hour, "2d ago"},
+		{-400 * 24 * time.Hour, "1y ago"},
+		{10 * time.Minute, "in 10m"},
+		{72 * time.Hour, "in 3d"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, cmd.FormatTimeAs(now.Add(tt.offset), cmd.TimeFormatRelative, now), "offset %v", tt.offset)
+	}
+}
+
+func TestTimeFormatIsShareable(t *testing.T) {
+	cfg := &config.Config{TimeFormat: "relative"}
+	assert.Equal(t, "relative", cfg.Export().TimeFormat)
+
+	assert.NoError(t, cmd.ValidateSharedConfig(cfg.Export()))
+	assert.Error(t, cmd.ValidateSharedConfig(config.SharedConfig{TimeFormat: "iso"}))
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+	"github.com/agbcloud/agbcloud-cli/internal/progress"
+)
+
+func TestParseTimeZone(t *testing.T) {
+	for input, expected := range map[string]*time.Location{
+		"":      time.Local,
+		"local": time.Local,
+		" UTC ": time.UTC,
+		"utc":   time.UTC,
+	} {
+		location, err := cmd.ParseTimeZone(input)
+		assert.NoError(t, err, "input %q", input)
+		assert.Equal(t, expected, location, "input %q", input)
+	}
+
+	location, err := cmd.ParseTimeZone("Asia/Shanghai")
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Shanghai", location.String())
+
+	_, err = cmd.ParseTimeZone("Mars/Olympus")
+	assert.Error(t, err)
+}
+
+// applyTimeZone runs a command with the global --tz flag, set to value if not empty
+func applyTimeZone(t *testing.T, value string) error {
+	var flags []string
+	if value != "" {
+		flags = []string{"--tz", value}
+	}
+	_, _, err := runSubcommand(t, cmd.ConfigCmd, "", "validate", nil, flags...)
+	return err
+}
+
+func TestApplyTimeZoneFlag(t *testing.T) {
+	useTempConfigDir(t)
+	defer func() { _ = applyTimeZone(t, "local") }()
+	now := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+	const timestamp = "2025-09-30T09:30:00Z"
+
+	require.NoError(t, applyTimeZone(t, "Asia/Shanghai"))
+	assert.Equal(t, "2025-09-30 17:30 CST", cmd.FormatTimestampAs(timestamp, cmd.TimeFormatLocal, now))
+	assert.Equal(t, "2025-09-30 09:30 UTC", cmd.FormatTimestampAs(timestamp, cmd.TimeFormatUTC, now), "--time-format utc is not affected")
+	assert.Equal(t, "2h ago", cmd.FormatTimestampAs(timestamp, cmd.TimeFormatRelative, now))
+
+	// Without the flag, the configured time zone is used
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cfg.TimeZone = "America/New_York"
+	require.NoError(t, cfg.Save())
+	require.NoError(t, applyTimeZone(t, ""))
+	assert.Equal(t, "2025-09-30 05:30 EDT", cmd.FormatTimestampAs(timestamp, cmd.TimeFormatLocal, now))
+
+	// The flag takes precedence over the configuration
+	require.NoError(t, applyTimeZone(t, "utc"))
+	assert.Equal(t, "2025-09-30 09:30 UTC", cmd.FormatTimestampAs(timestamp, cmd.TimeFormatLocal, now))
+
+	err = applyTimeZone(t, "Nowhere")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown time zone 'Nowhere'")
+
+	// Machine-local times carry no zone name, as before
+	require.NoError(t, applyTimeZone(t, "local"))
+	parsed, _ := time.Parse(time.RFC3339, timestamp)
+	assert.Equal(t, parsed.Local().Format("2006-01-02 15:04"), cmd.FormatTimestampAs(timestamp, cmd.TimeFormatLocal, now))
+}
+
+func TestTimestampsUseTimeZone(t *testing.T) {
+	progress.SetTimestamps(true)
+	defer progress.SetTimestamps(false)
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+	progress.SetLocation(kolkata)
+	defer progress.SetLocation(nil)
+
+	var status bytes.Buffer
+	before := time.Now().In(kolkata).Truncate(time.Second)
+	s := progress.NewStatusLine(&status, progress.StatusOptions{Mode: progress.ModeSequential})
+	s.Update("[DATA] Status: Preparing")
+	s.Stop()
+	after := time.Now().In(kolkata)
+
+	stampText := status.String()[:len(progress.TimestampLayout)]
+	assert.Contains(t, []string{before.Format(progress.TimestampLayout), after.Format(progress.TimestampLayout)}, stampText)
+}
+
+func TestTimeZoneIsShareable(t *testing.T) {
+	cfg := &config.Config{TimeZone: "Asia/Shanghai"}
+	assert.Equal(t, "Asia/Shanghai", cfg.Export().TimeZone)
+	assert.NoError(t, cmd.ValidateSharedConfig(cfg.Export()))
+	assert.Error(t, cmd.ValidateSharedConfig(config.SharedConfig{TimeZone: "Mars/Olympus"}))
+
+	imported := &config.Config{TimeZone: "UTC"}
+	imported.Import(config.SharedConfig{}, config.ImportOverwrite)
+	assert.Empty(t, imported.TimeZone)
+}
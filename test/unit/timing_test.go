@@ -0,0 +1,123 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func newTimingTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success": true, "code": "SUCCESS", "data": {}}`)) // Ignore errors in test mock server
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTimingRecordsEachCall(t *testing.T) {
+	server := newTimingTestServer(t)
+	recorder := &client.TimingRecorder{}
+	apiClient := client.NewAPIClient(&client.Configuration{
+		Servers: client.ServerConfigurations{{URL: server.URL}},
+		Timing:  recorder,
+	})
+
+	ctx := context.Background()
+	_, _, err := apiClient.OAuthAPI.GetLoginProviderURL(ctx, "", "CLI", "")
+	require.NoError(t, err)
+	_, _, err = apiClient.OAuthAPI.RefreshTokenWithBody(ctx, "keep-alive", "session")
+	require.NoError(t, err)
+
+	calls := recorder.Calls()
+	require.Len(t, calls, 2)
+
+	assert.Equal(t, http.MethodGet, calls[0].Method)
+	assert.Equal(t, "/api/oauth/login_provider", calls[0].Path)
+	assert.Equal(t, http.StatusOK, calls[0].StatusCode)
+	assert.False(t, calls[0].Reused)
+	assert.Positive(t, calls[0].Connect)
+	assert.Positive(t, calls[0].TTFB)
+	assert.GreaterOrEqual(t, calls[0].Total, calls[0].TTFB)
+
+	assert.Equal(t, http.MethodPost, calls[1].Method)
+	assert.True(t, calls[1].Reused, "the second call should reuse the keep-alive connection")
+	assert.Zero(t, calls[1].Connect)
+}
+
+func TestTimingRecordsFailedCall(t *testing.T) {
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	recorder := &client.TimingRecorder{}
+	apiClient := client.NewAPIClient(&client.Configuration{
+		Servers: client.ServerConfigurations{{URL: closed.URL}},
+		Timing:  recorder,
+	})
+
+	_, _, err := apiClient.OAuthAPI.RefreshToken(context.Background(), "secret-keep-alive", "secret-session")
+	require.Error(t, err)
+
+	calls := recorder.Calls()
+	require.Len(t, calls, 1)
+	assert.NotEmpty(t, calls[0].Err)
+	assert.NotContains(t, calls[0].Err, "secret-keep-alive", "errors are redacted")
+	assert.Zero(t, calls[0].StatusCode)
+}
+
+func TestCallTimingJSON(t *testing.T) {
+	server := newTimingTestServer(t)
+	recorder := &client.TimingRecorder{}
+	apiClient := client.NewAPIClient(&client.Configuration{
+		Servers: client.ServerConfigurations{{URL: server.URL}},
+		Timing:  recorder,
+	})
+	_, _, err := apiClient.OAuthAPI.GetLoginProviderURL(context.Background(), "", "CLI", "")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(recorder.Calls()[0])
+	require.NoError(t, err)
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	for _, key := range []string{"method", "path", "statusCode", "connectionReused", "dnsMs", "connectMs", "tlsMs", "ttfbMs", "totalMs"} {
+		assert.Contains(t, decoded, key)
+	}
+	assert.NotContains(t, decoded, "error")
+}
+
+func TestReportTimings(t *testing.T) {
+	t.Cleanup(client.DisableTiming)
+
+	var buf bytes.Buffer
+	cmd.ReportTimings(&buf)
+	assert.Empty(t, buf.String(), "nothing is reported unless --timing is enabled")
+
+	cmd.EnableTiming()
+	server := newTimingTestServer(t)
+	apiClient := client.NewAPIClient(&client.Configuration{
+		Servers: client.ServerConfigurations{{URL: server.URL}},
+		Timing:  client.DefaultTimingRecorder(),
+	})
+	_, _, err := apiClient.OAuthAPI.GetLoginProviderURL(context.Background(), "", "CLI", "")
+	require.NoError(t, err)
+
+	cmd.ReportTimings(&buf)
+	text := buf.String()
+	assert.Contains(t, text, "[TIME] API call timing (1 call(s)):")
+	assert.Contains(t, text, "TTFB")
+	assert.Contains(t, text, "/api/oauth/login_provider")
+	assert.Contains(t, text, "[TIME] Total time in API calls:")
+}
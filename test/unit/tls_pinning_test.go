@@ -0,0 +1,207 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/config"
+)
+
+// otherPin is a well-formed pin that matches no test certificate
+const otherPin = "sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="
+
+// newPinnedTLSServer starts a TLS server answering image list requests and points the
+// CLI at it. Its certificate is self-signed, so chain verification is skipped.
+func newPinnedTLSServer(t *testing.T, pins ...string) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success": true, "data": {"images": [], "total": 0, "page": 1, "pageSize": 10}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	useTempConfigDir(t)
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+	t.Setenv("AGB_CLI_SKIP_SSL_VERIFY", "true")
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	if len(pins) > 0 {
+		cfg.TLSPins = map[string][]string{server.URL: pins}
+		require.NoError(t, cfg.Save())
+	}
+	return server, &requests
+}
+
+func listWithPins(t *testing.T) error {
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, _, err = client.NewFromConfig(cfg).ImageAPI.ListImages(ctx, "login", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	return err
+}
+
+func TestSPKIPinAndValidation(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	pin := client.SPKIPin(server.Certificate())
+	assert.True(t, strings.HasPrefix(pin, "sha256/"))
+	assert.NoError(t, config.ValidateTLSPin(pin))
+
+	for _, invalid := range []string{"", "AAAA", "sha1/AAAAAAAAAAAAAAAAAAAAAAAAAAA=", "sha256/not base64", "sha256/AAAA"} {
+		assert.Error(t, config.ValidateTLSPin(invalid), "pin %q", invalid)
+	}
+}
+
+func TestPinnedKeysMatchHostInAnyForm(t *testing.T) {
+	cfg := &config.Config{TLSPins: map[string][]string{
+		"https://API.example.com:8443": {"sha256/a"},
+		"api.example.com":              {"sha256/b"},
+		"other.example.com":            {"sha256/c"},
+	}}
+	assert.ElementsMatch(t, []string{"sha256/a", "sha256/b"}, cfg.PinnedKeys("api.example.com"))
+	assert.Equal(t, []string{"sha256/c"}, cfg.PinnedKeys("other.example.com:443"))
+	assert.Empty(t, cfg.PinnedKeys("agb.cloud"))
+}
+
+func TestPinnedConnectionSucceeds(t *testing.T) {
+	server, requests := newPinnedTLSServer(t)
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cfg.TLSPins = map[string][]string{server.URL: {otherPin, client.SPKIPin(server.Certificate())}}
+	require.NoError(t, cfg.Save())
+
+	require.NoError(t, listWithPins(t))
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestPinMismatchFailsClosedWithoutRetries(t *testing.T) {
+	server, requests := newPinnedTLSServer(t, otherPin)
+
+	err := listWithPins(t)
+	require.Error(t, err)
+	var pinErr *client.PinMismatchError
+	require.True(t, errors.As(err, &pinErr), "unexpected error: %v", err)
+	assert.Equal(t, "127.0.0.1", pinErr.Host)
+	assert.Equal(t, []string{client.SPKIPin(server.Certificate())}, pinErr.Presented)
+	assert.False(t, client.IsRetryableError(err))
+	assert.Zero(t, requests.Load(), "no request may reach a server with an unpinned key")
+}
+
+func TestPinnedHostRefusesPlainHTTP(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	useTempConfigDir(t)
+	t.Setenv("AGB_CLI_ENDPOINT", server.URL)
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	cfg.TLSPins = map[string][]string{"127.0.0.1": {otherPin}}
+	require.NoError(t, cfg.Save())
+
+	err = listWithPins(t)
+	var pinErr *client.PinMismatchError
+	require.True(t, errors.As(err, &pinErr), "unexpected error: %v", err)
+	assert.True(t, pinErr.PlainHTTP)
+}
+
+// newTestCertificate returns a self-signed certificate with a fresh key
+func newTestCertificate(t *testing.T, name string) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestPinVerifierIgnoresUnverifiedIssuers(t *testing.T) {
+	leaf := newTestCertificate(t, "api.example.com")
+	issuer := newTestCertificate(t, "Example CA")
+
+	// Without a verified chain only the server's own key proves anything
+	verify := client.PinVerifier("api.example.com", []string{client.SPKIPin(issuer)})
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf, issuer}}
+	err := verify(state)
+	var pinErr *client.PinMismatchError
+	require.True(t, errors.As(err, &pinErr), "unexpected error: %v", err)
+	assert.Equal(t, []string{client.SPKIPin(leaf)}, pinErr.Presented)
+
+	state.VerifiedChains = [][]*x509.Certificate{{leaf, issuer}}
+	assert.NoError(t, verify(state))
+
+	assert.NoError(t, client.PinVerifier("api.example.com", []string{client.SPKIPin(leaf)})(tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}}))
+}
+
+func TestNetworkErrorExplainsPinMismatch(t *testing.T) {
+	server, _ := newPinnedTLSServer(t, otherPin)
+	saveTestTokens(t)
+
+	_, stderr, err := runSubcommand(t, cmd.ImageCmd, server.URL, "list", nil)
+	require.Error(t, err)
+	assert.Contains(t, stderr, "[ERROR] TLS pin mismatch: 127.0.0.1 presented a public key that is not pinned")
+	assert.Contains(t, stderr, client.SPKIPin(server.Certificate()))
+}
+
+func TestConfigTLSPinSavesPresentedKeys(t *testing.T) {
+	server, _ := newPinnedTLSServer(t)
+
+	stdout, _, err := runSubcommand(t, cmd.ConfigCmd, server.URL, "tls-pin", []string{server.URL}, "--save")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, client.SPKIPin(server.Certificate())+"  server:")
+	assert.Contains(t, stdout, "[OK] Pinned 1 public key(s) for 127.0.0.1")
+
+	cfg, err := config.GetConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []string{client.SPKIPin(server.Certificate())}, cfg.PinnedKeys("127.0.0.1"))
+	require.NoError(t, listWithPins(t))
+}
+
+func TestTLSPinsAreSharedAndValidated(t *testing.T) {
+	cfg := &config.Config{TLSPins: map[string][]string{"agb.cloud": {otherPin}}}
+	shared := cfg.Export()
+	assert.Equal(t, []string{otherPin}, shared.TLSPins["agb.cloud"])
+
+	var imported config.Config
+	imported.Import(shared, config.ImportMerge)
+	assert.Equal(t, []string{otherPin}, imported.PinnedKeys("agb.cloud"))
+
+	shared.TLSPins["agb.cloud"] = []string{"sha256/short"}
+	err := cmd.ValidateSharedConfig(shared)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tlsPins.agb.cloud[0]")
+
+	issues := config.ValidateConfigData([]byte(`{"tlsPins": {"agb.cloud": ["md5/abc"]}}`))
+	require.Len(t, issues, 1)
+	assert.Equal(t, "tlsPins.agb.cloud[0]", issues[0].Path)
+	assert.Equal(t, 1, issues[0].Position.Line)
+}
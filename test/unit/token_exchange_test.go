@@ -0,0 +1,229 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+const tokenExchangeSuccessBody = `{
+	"code": "200",
+	"requestId": "test-request-id",
+	"success": true,
+	"data": {
+		"loginToken": "new-login-token",
+		"sessionId": "new-session-id",
+		"keepAliveToken": "new-keep-alive-token",
+		"expiresAt": "2025-01-01T00:00:00Z"
+	},
+	"traceId": "test-trace-id",
+	"httpStatusCode": 200
+}`
+
+// tokenExchangeServer records every request and rejects POST when postSupported is false
+type tokenExchangeServer struct {
+	*httptest.Server
+	postSupported bool
+	posts         atomic.Int32
+	gets          atomic.Int32
+	lastQuery     atomic.Value
+	lastBody      atomic.Value
+}
+
+func newTokenExchangeServer(t *testing.T, postSupported bool) *tokenExchangeServer {
+	s := &tokenExchangeServer{postSupported: postSupported}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.lastQuery.Store(r.URL.RawQuery)
+		s.lastBody.Store(string(body))
+
+		switch r.Method {
+		case http.MethodPost:
+			s.posts.Add(1)
+			if !s.postSupported {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		case http.MethodGet:
+			s.gets.Add(1)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(tokenExchangeSuccessBody)) // Ignore errors in test mock server
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTokenExchangeClient(serverURL string, mode client.TokenExchangeMode) *client.APIClient {
+	cfg := &client.Configuration{
+		Servers:       client.ServerConfigurations{{URL: serverURL}},
+		TokenExchange: mode,
+	}
+	return client.NewAPIClient(cfg)
+}
+
+func TestParseTokenExchangeMode(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  client.TokenExchangeMode
+		expectErr bool
+	}{
+		{"", client.TokenExchangeAuto, false},
+		{"auto", client.TokenExchangeAuto, false},
+		{"POST", client.TokenExchangePost, false},
+		{" query ", client.TokenExchangeQuery, false},
+		{"header", client.TokenExchangeAuto, true},
+	}
+
+	for _, tt := range tests {
+		mode, err := client.ParseTokenExchangeMode(tt.input)
+		if tt.expectErr {
+			assert.Error(t, err, "input %q", tt.input)
+		} else {
+			assert.NoError(t, err, "input %q", tt.input)
+		}
+		assert.Equal(t, tt.expected, mode, "input %q", tt.input)
+	}
+}
+
+func TestRefreshTokenWithBody(t *testing.T) {
+	server := newTokenExchangeServer(t, true)
+	apiClient := newTokenExchangeClient(server.URL, client.TokenExchangeAuto)
+
+	response, _, err := apiClient.OAuthAPI.RefreshTokenWithBody(context.Background(), "secret-keep-alive", "secret-session")
+	require.NoError(t, err)
+	assert.Equal(t, "new-login-token", response.Data.LoginToken)
+
+	assert.Equal(t, "", server.lastQuery.Load(), "tokens must not appear in the URL")
+	var body client.OAuthRefreshTokenRequest
+	require.NoError(t, json.Unmarshal([]byte(server.lastBody.Load().(string)), &body))
+	assert.Equal(t, "secret-keep-alive", body.KeepAliveToken)
+	assert.Equal(t, "secret-session", body.SessionId)
+
+	_, _, err = apiClient.OAuthAPI.RefreshTokenWithBody(context.Background(), "", "secret-session")
+	assert.Error(t, err)
+}
+
+func TestLoginTranslateWithBody(t *testing.T) {
+	server := newTokenExchangeServer(t, true)
+	apiClient := newTokenExchangeClient(server.URL, client.TokenExchangeAuto)
+
+	_, _, err := apiClient.OAuthAPI.LoginTranslateWithBody(context.Background(), "CLI", "GOOGLE_LOCALHOST", "secret-code", "3001")
+	require.NoError(t, err)
+
+	assert.Equal(t, "", server.lastQuery.Load(), "auth code must not appear in the URL")
+	var body client.OAuthLoginTranslateRequest
+	require.NoError(t, json.Unmarshal([]byte(server.lastBody.Load().(string)), &body))
+	assert.Equal(t, client.OAuthLoginTranslateRequest{
+		LoginClient:   "CLI",
+		OauthProvider: "GOOGLE_LOCALHOST",
+		AuthCode:      "secret-code",
+		LocalhostPort: "3001",
+	}, body)
+}
+
+func TestTokenExchangeNegotiation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("AutoPrefersPost", func(t *testing.T) {
+		server := newTokenExchangeServer(t, true)
+		apiClient := newTokenExchangeClient(server.URL, client.TokenExchangeAuto)
+
+		_, _, err := apiClient.OAuthAPI.ExchangeAuthCode(ctx, "CLI", "GOOGLE_LOCALHOST", "code", "3001")
+		require.NoError(t, err)
+		_, _, err = apiClient.OAuthAPI.RenewSession(ctx, "keep-alive", "session")
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), server.posts.Load())
+		assert.Equal(t, int32(0), server.gets.Load())
+	})
+
+	t.Run("AutoFallsBackToQueryAndRemembers", func(t *testing.T) {
+		server := newTokenExchangeServer(t, false)
+		apiClient := newTokenExchangeClient(server.URL, client.TokenExchangeAuto)
+
+		response, _, err := apiClient.OAuthAPI.ExchangeAuthCode(ctx, "CLI", "GOOGLE_LOCALHOST", "code", "3001")
+		require.NoError(t, err)
+		assert.True(t, response.Success)
+		assert.Contains(t, server.lastQuery.Load(), "authCode=code")
+
+		// The server's lack of support is remembered for the rest of the client's life
+		_, _, err = apiClient.OAuthAPI.RenewSession(ctx, "keep-alive", "session")
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(1), server.posts.Load())
+		assert.Equal(t, int32(2), server.gets.Load())
+	})
+
+	t.Run("QueryModeNeverPosts", func(t *testing.T) {
+		server := newTokenExchangeServer(t, true)
+		apiClient := newTokenExchangeClient(server.URL, client.TokenExchangeQuery)
+
+		_, _, err := apiClient.OAuthAPI.RenewSession(ctx, "keep-alive", "session")
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(0), server.posts.Load())
+		assert.Equal(t, int32(1), server.gets.Load())
+	})
+
+	t.Run("PostModeNeverFallsBack", func(t *testing.T) {
+		server := newTokenExchangeServer(t, false)
+		apiClient := newTokenExchangeClient(server.URL, client.TokenExchangePost)
+
+		_, httpResp, err := apiClient.OAuthAPI.RenewSession(ctx, "keep-alive", "session")
+		require.Error(t, err)
+		require.NotNil(t, httpResp)
+		assert.Equal(t, http.StatusMethodNotAllowed, httpResp.StatusCode)
+		assert.Equal(t, int32(0), server.gets.Load(), "tokens must never be sent in the URL in post mode")
+	})
+}
+
+func TestRetryReplaysRequestBody(t *testing.T) {
+	var attempts atomic.Int32
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, body)
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	retryClient := client.NewRetryableHTTPClient(nil, &client.RetryConfig{
+		MaxRetries:    1,
+		InitialDelay:  time.Millisecond,
+		MaxDelay:      time.Millisecond,
+		BackoffFactor: 1,
+	})
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, bytes.NewBufferString(`{"sessionId":"abc"}`))
+	require.NoError(t, err)
+	req.Header.Set(client.IdempotencyKeyHeader, client.NewIdempotencyKey()) // Only POSTs with a key are retried
+
+	resp, err := retryClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Len(t, bodies, 2)
+	assert.Equal(t, `{"sessionId":"abc"}`, string(bodies[0]))
+	assert.Equal(t, `{"sessionId":"abc"}`, string(bodies[1]), "retried POST must resend the body")
+}
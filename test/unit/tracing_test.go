@@ -0,0 +1,221 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/internal/poll"
+	"github.com/agbcloud/agbcloud-cli/internal/tracing"
+)
+
+// enableTestTracer records spans in memory for the duration of the test
+func enableTestTracer(t *testing.T) *tracing.Tracer {
+	tracer := tracing.NewTracer(tracing.Resource{ServiceName: "agbcloud-cli", ServiceVersion: "test"}, nil)
+	tracing.Enable(tracer)
+	t.Cleanup(tracing.Disable)
+	return tracer
+}
+
+func spanAttribute(span *tracing.Span, key string) interface{} {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return nil
+}
+
+func TestStartWithoutTracerIsNoop(t *testing.T) {
+	tracing.Disable()
+	ctx := context.Background()
+	spanCtx, span := tracing.Start(ctx, "noop", tracing.SpanKindInternal)
+	assert.Nil(t, span)
+	assert.Equal(t, ctx, spanCtx)
+
+	// Span methods accept a nil receiver
+	span.SetAttributes(tracing.String("key", "value"))
+	span.Finish(errors.New("ignored"))
+	assert.Empty(t, span.Traceparent())
+}
+
+func TestAPICallSpans(t *testing.T) {
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success": true, "code": "OK", "data": {"platforms": ["linux/amd64"]}}`))
+	}))
+	defer server.Close()
+	tracer := enableTestTracer(t)
+
+	ctx, root := tracing.Start(context.Background(), "agb image create", tracing.SpanKindInternal)
+	_, _, err := newLogsTestClient(server.URL).ImageAPI.GetImageCapabilities(ctx, "secret-token", "secret-session")
+	require.NoError(t, err)
+	root.Finish(nil)
+
+	spans := tracer.Spans()
+	require.Len(t, spans, 2)
+	call := spans[0]
+	assert.Equal(t, "GET /api/image/capabilities", call.Name)
+	assert.Equal(t, tracing.SpanKindClient, call.Kind)
+	assert.Equal(t, root.TraceID, call.TraceID)
+	assert.Equal(t, root.SpanID, call.ParentSpanID)
+	assert.Equal(t, int64(http.StatusOK), spanAttribute(call, "http.response.status_code"))
+	assert.Empty(t, call.Err)
+	assert.Equal(t, call.Traceparent(), traceparent, "the trace continues on the server")
+
+	for _, attr := range call.Attributes {
+		assert.NotContains(t, fmt.Sprint(attr.Value), "secret", "credentials must not be recorded in %s", attr.Key)
+	}
+}
+
+func TestAPICallSpanMarksErrorResponses(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	tracer := enableTestTracer(t)
+
+	_, _, err := newLogsTestClient(server.URL).ImageAPI.GetImageCapabilities(context.Background(), "token", "session")
+	require.Error(t, err)
+
+	spans := tracer.Spans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "HTTP 404", spans[0].Err)
+	assert.Equal(t, [8]byte{}, spans[0].ParentSpanID, "a call without a command span starts its own trace")
+}
+
+func TestPollCycleSpans(t *testing.T) {
+	tracer := enableTestTracer(t)
+	p := poll.Poller{Interval: poll.Constant(time.Second), Clock: newFakeClock(), Name: "poll image task"}
+
+	checks := 0
+	err := p.Until(context.Background(), func(ctx context.Context) (bool, error) {
+		checks++
+		assert.NotNil(t, tracing.SpanFromContext(ctx), "each check runs in its own span")
+		return checks == 3, nil
+	})
+	require.NoError(t, err)
+
+	spans := tracer.Spans()
+	require.Len(t, spans, 3)
+	for i, span := range spans {
+		assert.Equal(t, "poll image task", span.Name)
+		assert.Equal(t, int64(i+1), spanAttribute(span, "poll.attempt"))
+	}
+}
+
+func TestTracesURL(t *testing.T) {
+	for endpoint, expected := range map[string]string{
+		"http://localhost:4318":            "http://localhost:4318/v1/traces",
+		"http://localhost:4318/":           "http://localhost:4318/v1/traces",
+		"https://otel.example.com/otlp":    "https://otel.example.com/otlp/v1/traces",
+		"http://localhost:4318/v1/traces":  "http://localhost:4318/v1/traces",
+		" http://collector:4318/v1/traces": "http://collector:4318/v1/traces",
+	} {
+		actual, err := tracing.TracesURL(endpoint)
+		require.NoError(t, err, endpoint)
+		assert.Equal(t, expected, actual, endpoint)
+	}
+
+	for _, endpoint := range []string{"localhost:4318", "ftp://collector", "http://"} {
+		_, err := tracing.TracesURL(endpoint)
+		assert.Error(t, err, endpoint)
+	}
+}
+
+func TestExporterFromEnv(t *testing.T) {
+	t.Setenv(tracing.EnvEndpoint, "")
+	t.Setenv(tracing.EnvOTelEndpoint, "")
+	t.Setenv(tracing.EnvOTelTracesURL, "")
+	t.Setenv(tracing.EnvOTelHeaders, "Authorization=Bearer%20abc, x-team = cli ,malformed")
+
+	exporter, err := tracing.ExporterFromEnv("")
+	require.NoError(t, err)
+	assert.Nil(t, exporter, "tracing is off unless an endpoint is configured")
+
+	exporter, err = tracing.ExporterFromEnv("http://configured:4318")
+	require.NoError(t, err)
+	assert.Equal(t, "http://configured:4318/v1/traces", exporter.URL)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer abc", "x-team": "cli"}, exporter.Headers)
+
+	t.Setenv(tracing.EnvOTelEndpoint, "http://otel:4318")
+	exporter, err = tracing.ExporterFromEnv("http://configured:4318")
+	require.NoError(t, err)
+	assert.Equal(t, "http://otel:4318/v1/traces", exporter.URL, "the environment overrides the configuration")
+
+	t.Setenv(tracing.EnvEndpoint, "not a url")
+	_, err = tracing.ExporterFromEnv("")
+	assert.Error(t, err)
+}
+
+func TestExportSendsOTLPJSON(t *testing.T) {
+	var body map[string]interface{}
+	var headers http.Header
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/traces", r.URL.Path)
+		headers = r.Header
+		data, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(data, &body))
+	}))
+	defer collector.Close()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracer := tracing.NewTracer(tracing.Resource{ServiceName: "agbcloud-cli", ServiceVersion: "1.2.3"},
+		&tracing.Exporter{URL: collector.URL + "/v1/traces", Headers: map[string]string{"Authorization": "Bearer abc"}})
+	tracer.Now = func() time.Time { return start }
+	tracing.Enable(tracer)
+	t.Cleanup(tracing.Disable)
+
+	ctx, root := tracing.Start(context.Background(), "agb image activate", tracing.SpanKindInternal, tracing.String("cli.command", "image activate"))
+	_, child := tracing.Start(ctx, "poll image activate", tracing.SpanKindInternal, tracing.Int("poll.attempt", 1))
+	child.Finish(errors.New("image failed"))
+	root.Finish(nil)
+
+	require.NoError(t, tracer.Flush(context.Background()))
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	assert.Equal(t, "Bearer abc", headers.Get("Authorization"))
+	assert.Empty(t, tracer.Spans(), "flushed spans are not exported again")
+
+	resourceSpans := body["resourceSpans"].([]interface{})[0].(map[string]interface{})
+	resource, _ := json.Marshal(resourceSpans["resource"])
+	assert.Contains(t, string(resource), `{"key":"service.name","value":{"stringValue":"agbcloud-cli"}}`)
+
+	spans := resourceSpans["scopeSpans"].([]interface{})[0].(map[string]interface{})["spans"].([]interface{})
+	require.Len(t, spans, 2)
+	pollSpan, rootSpan := spans[0].(map[string]interface{}), spans[1].(map[string]interface{})
+
+	assert.Equal(t, hex.EncodeToString(root.TraceID[:]), rootSpan["traceId"])
+	assert.Equal(t, hex.EncodeToString(root.SpanID[:]), pollSpan["parentSpanId"])
+	assert.NotContains(t, rootSpan, "parentSpanId")
+	assert.Equal(t, "1735689600000000000", rootSpan["startTimeUnixNano"])
+	assert.Equal(t, map[string]interface{}{"code": float64(2), "message": "image failed"}, pollSpan["status"])
+
+	attributes, _ := json.Marshal(pollSpan["attributes"])
+	assert.JSONEq(t, `[{"key":"poll.attempt","value":{"intValue":"1"}}]`, string(attributes))
+}
+
+func TestExportReportsCollectorErrors(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad token", http.StatusUnauthorized)
+	}))
+	defer collector.Close()
+
+	exporter := &tracing.Exporter{URL: collector.URL}
+	err := exporter.Export(context.Background(), tracing.Resource{ServiceName: "agbcloud-cli"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+	assert.Contains(t, err.Error(), "bad token")
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+func TestUploadCredentialExpiresAt(t *testing.T) {
+	t.Run("ServerProvidedExpireTime", func(t *testing.T) {
+		data := client.ImageUploadCredentialData{
+			OssURL:     "https://bucket.oss-cn-hangzhou.aliyuncs.com/dockerfile?Expires=1700000000",
+			ExpireTime: "2025-09-11T05:48:08Z",
+		}
+		expiresAt, ok := data.ExpiresAt()
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2025, 9, 11, 5, 48, 8, 0, time.UTC), expiresAt.UTC(), "server value takes precedence over the URL")
+	})
+
+	t.Run("OSSExpiresParameter", func(t *testing.T) {
+		data := client.ImageUploadCredentialData{
+			OssURL: "https://bucket.oss-cn-hangzhou.aliyuncs.com/dockerfile?OSSAccessKeyId=abc&Expires=1700000000&Signature=xyz",
+		}
+		expiresAt, ok := data.ExpiresAt()
+		require.True(t, ok)
+		assert.Equal(t, int64(1700000000), expiresAt.Unix())
+	})
+
+	t.Run("S3SignatureV4", func(t *testing.T) {
+		data := client.ImageUploadCredentialData{
+			OssURL: "https://bucket.s3.amazonaws.com/dockerfile?X-Amz-Algorithm=AWS4-HMAC-SHA256&X-Amz-Date=20250911T054808Z&X-Amz-Expires=900&X-Amz-Signature=abc",
+		}
+		expiresAt, ok := data.ExpiresAt()
+		require.True(t, ok)
+		assert.Equal(t, time.Date(2025, 9, 11, 6, 3, 8, 0, time.UTC), expiresAt.UTC())
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		data := client.ImageUploadCredentialData{OssURL: "https://test-oss-url.com/upload"}
+		_, ok := data.ExpiresAt()
+		assert.False(t, ok)
+	})
+
+	t.Run("InvalidExpireTimeFallsBackToURL", func(t *testing.T) {
+		data := client.ImageUploadCredentialData{
+			OssURL:     "https://bucket.oss-cn-hangzhou.aliyuncs.com/dockerfile?Expires=1700000000",
+			ExpireTime: "not-a-time",
+		}
+		expiresAt, ok := data.ExpiresAt()
+		require.True(t, ok)
+		assert.Equal(t, int64(1700000000), expiresAt.Unix())
+	})
+
+	t.Run("DecodesFromResponse", func(t *testing.T) {
+		body := `{"success":true,"data":{"ossUrl":"https://x","taskId":"task-1","expireTime":"2025-09-11T05:48:08Z"}}`
+		var resp client.ImageUploadCredentialResponse
+		require.NoError(t, json.Unmarshal([]byte(body), &resp))
+		assert.Equal(t, "2025-09-11T05:48:08Z", resp.Data.ExpireTime)
+	})
+}
+
+func TestIsUploadCredentialExpiredError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "OSS signature mismatch",
+			err:      &cmd.UploadError{StatusCode: 403, Body: "<Error><Code>SignatureDoesNotMatch</Code></Error>"},
+			expected: true,
+		},
+		{
+			name:     "Expired request",
+			err:      &cmd.UploadError{StatusCode: 403, Body: "<Error><Code>AccessDenied</Code><Message>Request has expired.</Message></Error>"},
+			expected: true,
+		},
+		{
+			name:     "Wrapped expired error",
+			err:      fmt.Errorf("dockerfile upload failed: %w", &cmd.UploadError{StatusCode: 403, Body: "Request has expired"}),
+			expected: true,
+		},
+		{
+			name:     "Other forbidden error",
+			err:      &cmd.UploadError{StatusCode: 403, Body: "<Error><Code>AccessDenied</Code><Message>Bucket policy denied</Message></Error>"},
+			expected: false,
+		},
+		{
+			name:     "Server error",
+			err:      &cmd.UploadError{StatusCode: 500, Body: "expired"},
+			expected: false,
+		},
+		{
+			name:     "Network error",
+			err:      fmt.Errorf("connection reset by peer"),
+			expected: false,
+		},
+		{
+			name:     "Nil error",
+			err:      nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cmd.IsUploadCredentialExpiredError(tt.err))
+		})
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+)
+
+// fastUploadRetries retries quickly and caps Retry-After waits so that tests do not wait
+var fastUploadRetries = &client.RetryConfig{
+	MaxRetries:    2,
+	InitialDelay:  time.Millisecond,
+	MaxDelay:      time.Millisecond,
+	BackoffFactor: 2,
+	MaxRetryAfter: 5 * time.Millisecond,
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 9, 30, 12, 0, 0, 0, time.UTC)
+
+	wait, ok := client.ParseRetryAfter("3", now)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	wait, ok = client.ParseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, 90*time.Second, wait)
+
+	wait, ok = client.ParseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now)
+	assert.True(t, ok, "dates in the past mean retry now")
+	assert.Zero(t, wait)
+
+	for _, value := range []string{"", "-1", "soon"} {
+		_, ok := client.ParseRetryAfter(value, now)
+		assert.False(t, ok, "value %q", value)
+	}
+}
+
+// hintedError asks for a wait like a response with Retry-After
+type hintedError struct{ wait time.Duration }
+
+func (e hintedError) Error() string                 { return "503 SlowDown" }
+func (e hintedError) RetryAfterHint() time.Duration { return e.wait }
+
+func TestRetryWaitsAsRequested(t *testing.T) {
+	config := &client.RetryConfig{MaxRetries: 3, InitialDelay: time.Second, MaxDelay: 4 * time.Second, BackoffFactor: 2, MaxRetryAfter: 10 * time.Second}
+	failures := []error{hintedError{wait: 7 * time.Second}, errors.New("connection reset by peer"), hintedError{wait: time.Hour}}
+
+	var waits []time.Duration
+	var retries []client.RetryAttempt
+	attempts, err := client.Retry(context.Background(), config, client.RetryOptions{
+		Retryable: func(error) bool { return true },
+		OnRetry:   func(attempt client.RetryAttempt) { retries = append(retries, attempt) },
+		After: func(d time.Duration) <-chan time.Time {
+			waits = append(waits, d)
+			return time.After(0)
+		},
+	}, func(attempt int) error {
+		if attempt <= len(failures) {
+			return failures[attempt-1]
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, attempts)
+	assert.Equal(t, []time.Duration{7 * time.Second, 2 * time.Second, 10 * time.Second}, waits,
+		"Retry-After replaces the backoff, which keeps growing, and is capped at MaxRetryAfter")
+	require.Len(t, retries, 3)
+	assert.True(t, retries[0].RetryAfter)
+	assert.False(t, retries[1].RetryAfter)
+	assert.Equal(t, 4, retries[0].MaxAttempts)
+}
+
+func TestRetryStopsOnPermanentErrors(t *testing.T) {
+	permanent := errors.New("403 AccessDenied")
+	attempts, err := client.Retry(context.Background(), fastUploadRetries, client.RetryOptions{
+		Retryable: func(err error) bool { return !errors.Is(err, permanent) },
+	}, func(int) error { return permanent })
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, permanent, err)
+}
+
+func TestAPIClientHonorsRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The backoff alone would retry after a millisecond
+	retryClient := client.NewRetryableHTTPClient(nil, &client.RetryConfig{MaxRetries: 1, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffFactor: 1})
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	started := time.Now()
+	resp, err := retryClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, int32(2), requests.Load())
+	assert.GreaterOrEqual(t, time.Since(started), time.Second)
+}
+
+func TestUploadWithRetryFlakyStorage(t *testing.T) {
+	var calls atomic.Int32
+	var stored atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// OSS throttles with 503 SlowDown and asks for a longer wait than the test allows
+		if calls.Add(1) <= 2 {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("<Error><Code>SlowDown</Code></Error>"))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		stored.Store(string(body))
+	}))
+	defer server.Close()
+
+	uploader, err := cmd.NewDockerfileUploader(client.ImageUploadCredentialData{OssURL: server.URL + "/dockerfile?Signature=abc"}, nil)
+	require.NoError(t, err)
+	warnings := &cmd.WarningRecorder{}
+	out := captureStdout(func() {
+		err = cmd.UploadWithRetry(context.Background(), uploader, []byte("FROM scratch\n"), fastUploadRetries, warnings)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, "FROM scratch\n", stored.Load())
+	assert.Contains(t, out, "retrying in 5ms as requested by the storage service")
+	assert.Contains(t, out, "[OK] Dockerfile upload succeeded on attempt 3")
+	require.Len(t, warnings.Warnings(), 2)
+	assert.Regexp(t, `^Upload attempt 1/3 failed after \d+(\.\d+)?[µm]?s: upload failed with status 503`, warnings.Warnings()[0])
+}
+
+func TestUploadWithRetryGivesUp(t *testing.T) {
+	var calls atomic.Int32
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	uploader, err := cmd.NewDockerfileUploader(client.ImageUploadCredentialData{OssURL: server.URL + "/dockerfile"}, nil)
+	require.NoError(t, err)
+
+	out := captureStdout(func() {
+		err = cmd.UploadWithRetry(context.Background(), uploader, []byte("FROM scratch\n"), fastUploadRetries, nil)
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed after 3 attempt(s)")
+	var uploadErr *cmd.UploadError
+	require.True(t, errors.As(err, &uploadErr))
+	assert.Equal(t, http.StatusServiceUnavailable, uploadErr.StatusCode)
+	assert.Contains(t, out, "[ERROR] All 3 upload attempts failed")
+	assert.Equal(t, int32(3), calls.Load())
+
+	// Rejected uploads are not retried
+	calls.Store(0)
+	status = http.StatusForbidden
+	out = captureStdout(func() {
+		err = cmd.UploadWithRetry(context.Background(), uploader, []byte("FROM scratch\n"), fastUploadRetries, nil)
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed after 1 attempt(s)")
+	assert.Contains(t, out, "not retryable")
+	assert.Equal(t, int32(1), calls.Load())
+}
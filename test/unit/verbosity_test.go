@@ -0,0 +1,97 @@
+// Copyright 2025 AgbCloud CLI Contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package unit
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/agbcloud/agbcloud-cli/cmd"
+	"github.com/agbcloud/agbcloud-cli/internal/client"
+	"github.com/agbcloud/agbcloud-cli/internal/verbosity"
+)
+
+// useVerbosity selects a verbosity tier for the duration of the test
+func useVerbosity(t *testing.T, level verbosity.Level) {
+	previous := verbosity.Get()
+	verbosity.Set(level)
+	t.Cleanup(func() { verbosity.Set(previous) })
+}
+
+func TestApplyVerboseFlag(t *testing.T) {
+	previousLevel := log.GetLevel()
+	t.Cleanup(func() { log.SetLevel(previousLevel) })
+	useVerbosity(t, verbosity.Normal)
+	useTempConfigDir(t)
+
+	tests := []struct {
+		args     []string
+		level    verbosity.Level
+		logLevel log.Level
+	}{
+		{nil, verbosity.Normal, log.InfoLevel},
+		{[]string{"-v"}, verbosity.Requests, log.DebugLevel},
+		{[]string{"-vv"}, verbosity.Headers, log.DebugLevel},
+		{[]string{"--verbose", "--verbose", "--verbose"}, verbosity.Bodies, log.DebugLevel},
+		{[]string{"-vvvvv"}, verbosity.Bodies, log.DebugLevel},
+	}
+	for _, tt := range tests {
+		_, _, err := runSubcommand(t, cmd.ConfigCmd, "", "validate", nil, tt.args...)
+		require.NoError(t, err, "%v", tt.args)
+		assert.Equal(t, tt.level, verbosity.Get(), "%v", tt.args)
+		assert.Equal(t, tt.logLevel, log.GetLevel(), "%v", tt.args)
+	}
+}
+
+func TestVerbosityTiersOfRequestLog(t *testing.T) {
+	_, apiClient := newSeededMockServer(t, 1)
+	options := client.ImageListOptions{ImageType: "System", Page: 1, PageSize: 10}
+
+	for _, tt := range []struct {
+		level           verbosity.Level
+		headers, bodies bool
+	}{
+		{verbosity.Requests, false, false},
+		{verbosity.Headers, true, false},
+		{verbosity.Bodies, true, true},
+	} {
+		logs := captureDebugLog(t)
+		useVerbosity(t, tt.level)
+		_, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", options)
+		require.NoError(t, err)
+
+		assert.Equal(t, tt.headers, strings.Contains(logs.String(), "=== HTTP Response Information ==="), "headers at tier %d", tt.level)
+		assert.Equal(t, tt.bodies, strings.Contains(logs.String(), "Response Body: {"), "bodies at tier %d", tt.level)
+		assert.NotContains(t, logs.String(), "token&", "the login token is redacted")
+	}
+}
+
+func TestMaskSecret(t *testing.T) {
+	assert.Equal(t, "abcd... (26 characters)", cmd.MaskSecret("abcdefghijklmnopqrstuvwxyz"))
+	assert.Equal(t, "[REDACTED] (6 characters)", cmd.MaskSecret("secret"))
+}
+
+func TestActivateStepDetailsNeedVerbose(t *testing.T) {
+	useTempConfigDir(t)
+	saveTestTokens(t)
+	server, apiClient := newSeededMockServer(t, 2, "IMAGE_AVAILABLE")
+	listResp, _, err := apiClient.ImageAPI.ListImages(context.Background(), "token", "session", client.ImageListOptions{ImageType: "User", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	images := listResp.Data.Images
+
+	useVerbosity(t, verbosity.Normal)
+	stdout, _, err := runSubcommand(t, cmd.ImageCmd, server.URL, "activate", []string{images[0].ImageID}, "--detach")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[OK] Image activation initiated successfully!")
+	assert.NotContains(t, stdout, "Request ID")
+
+	stdout, _, err = runSubcommand(t, cmd.ImageCmd, server.URL, "activate", []string{images[1].ImageID}, "--detach", "-v")
+	require.NoError(t, err)
+	assert.Contains(t, stdout, "[SEARCH] Request ID: mock-request-")
+}